@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"developer-portal-backend/internal/config"
+	"developer-portal-backend/internal/database"
+	"developer-portal-backend/internal/database/migrations"
+
+	"github.com/joho/godotenv"
+)
+
+// migrationNamePattern restricts names to what database.Migrator accepts in file names
+var migrationNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// migrationsDir is where create writes new migrations, relative to the repository root
+const migrationsDir = "internal/database/migrations"
+
+const usage = `Usage: migrate <command> [argument]
+
+Commands:
+  up [N]          apply all pending migrations, or the next N
+  down [N]        revert the last applied migration, or the last N
+  status          list migrations and whether they are applied
+  force VERSION   mark a dirty migration as applied after repairing it by hand
+  create NAME     add empty up and down files for a new migration
+
+The database is configured like the server (DATABASE_URL or DB_* variables, .env).
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	command, args := os.Args[1], os.Args[2:]
+
+	if command == "create" {
+		if len(args) != 1 {
+			log.Fatal("create needs the name of the migration")
+		}
+		if err := create(args[0]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found, using system environment variables")
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal("Failed to load configuration:", err)
+	}
+	db, err := database.Initialize(cfg.DatabaseURL, &database.Options{Migrations: database.MigrationsSkip})
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+	migrator, err := database.NewMigrator(db, migrations.FS)
+	if err != nil {
+		log.Fatal(err)
+	}
+	ctx := context.Background()
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx, optionalCount(args, 0))
+		for _, migration := range applied {
+			fmt.Printf("applied %d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+	case "down":
+		reverted, err := migrator.Down(ctx, optionalCount(args, 1))
+		for _, migration := range reverted {
+			fmt.Printf("reverted %d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+		printStatus(statuses)
+	case "force":
+		if len(args) != 1 {
+			log.Fatal("force needs the version of the dirty migration")
+		}
+		version, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			log.Fatalf("invalid version %s", args[0])
+		}
+		if err := migrator.Force(ctx, version); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("marked migration %d as applied\n", version)
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+// optionalCount parses the optional step count argument
+func optionalCount(args []string, fallback int) int {
+	if len(args) == 0 {
+		return fallback
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil || n <= 0 {
+		log.Fatalf("invalid number of migrations %s", args[0])
+	}
+	return n
+}
+
+func printStatus(statuses []database.MigrationStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATE\tAPPLIED AT")
+	for _, status := range statuses {
+		state, appliedAt := "pending", ""
+		if status.Applied {
+			state = "applied"
+			appliedAt = status.AppliedAt.Format(time.RFC3339)
+		}
+		switch {
+		case status.Dirty:
+			state = "dirty"
+		case status.Unknown:
+			state += " (unknown to this build)"
+		case status.Modified:
+			state += " (modified since)"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+	w.Flush()
+}
+
+// create writes empty up and down files numbered after the newest migration in migrationsDir.
+// It must run from the repository root.
+func create(name string) error {
+	name = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(name), "-", "_"))
+	if !migrationNamePattern.MatchString(name) {
+		return fmt.Errorf("migration name %s may only contain letters, digits and underscores", name)
+	}
+	migrator, err := database.NewMigrator(nil, os.DirFS(migrationsDir))
+	if err != nil {
+		return err
+	}
+	next := migrator.LatestVersion() + 1
+	for _, direction := range []string{"up", "down"} {
+		file := filepath.Join(migrationsDir, fmt.Sprintf("%06d_%s.%s.sql", next, name, direction))
+		if err := os.WriteFile(file, []byte(""), 0o644); err != nil {
+			return err
+		}
+		fmt.Println("created", file)
+	}
+	return nil
+}
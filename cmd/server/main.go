@@ -50,8 +50,12 @@ func main() {
 	// Set up logging
 	setupLogging(cfg.LogLevel)
 
-	// Initialize database
-	db, err := database.Initialize(cfg.DatabaseURL, nil)
+	// Initialize database, applying or checking schema migrations
+	migrations, err := database.ParseMigrationMode(cfg.DatabaseMigrations)
+	if err != nil {
+		logrus.Fatal("Invalid DB_MIGRATIONS:", err)
+	}
+	db, err := database.Initialize(cfg.DatabaseURL, &database.Options{Migrations: migrations})
 	if err != nil {
 		logrus.Fatal("Failed to initialize database:", err)
 	}
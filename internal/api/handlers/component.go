@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 
+	"developer-portal-backend/internal/auth"
 	apperrors "developer-portal-backend/internal/errors"
 	"developer-portal-backend/internal/service"
 
@@ -14,12 +15,12 @@ import (
 
 // ComponentHandler handles HTTP requests for component operations
 type ComponentHandler struct {
-	componentService *service.ComponentService
+	componentService service.ComponentServiceInterface
 	teamService      service.TeamServiceInterface
 }
 
 // NewComponentHandler creates a new component handler
-func NewComponentHandler(componentService *service.ComponentService, teamService service.TeamServiceInterface) *ComponentHandler {
+func NewComponentHandler(componentService service.ComponentServiceInterface, teamService service.TeamServiceInterface) *ComponentHandler {
 	return &ComponentHandler{
 		componentService: componentService,
 		teamService:      teamService,
@@ -28,19 +29,25 @@ func NewComponentHandler(componentService *service.ComponentService, teamService
 
 // ListComponents handles GET /components
 // @Summary List components
-// @Description List components filtered by either team-id or project-name. Returns an array of minimal component views. One of team-id or project-name is required.
+// @Description List components filtered by either team-id or project-name. Returns an array of minimal component views. One of team-id or project-name is required. End-of-life components are only listed if the lifecycle filter asks for them.
 // @Tags components
 // @Accept json
 // @Produce json
 // @Param team-id query string false "Team ID (UUID) to filter by owner_id"
 // @Param project-name query string false "Project name"
+// @Param lifecycle query string false "Comma-separated lifecycle states (experimental, production, deprecated, end-of-life); defaults to all but end-of-life"
 // @Success 200 {array} object "Successfully retrieved components"
-// @Failure 400 {object} map[string]interface{} "team-id or project-name parameter is required"
+// @Failure 400 {object} map[string]interface{} "team-id or project-name parameter is required, or invalid lifecycle"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security BearerAuth
 // @Router /components [get]
 func (h *ComponentHandler) ListComponents(c *gin.Context) {
 	projectName := c.Query("project-name")
+	lifecycles, err := service.ParseLifecycleFilter(c.Query("lifecycle"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
 	// If team-id is provided, return components owned by that team (uses pagination)
 	teamIDStr := c.Query("team-id")
@@ -60,8 +67,11 @@ func (h *ComponentHandler) ListComponents(c *gin.Context) {
 			return
 		}
 		// Build minimal view items with project info (same fields as project-name view plus project_id and project_title)
-		items := make([]gin.H, len(components))
-		for i, c := range components {
+		items := make([]gin.H, 0, len(components))
+		for _, c := range components {
+			if !lifecycles.Matches(c.Lifecycle) {
+				continue
+			}
 			// Extract qos, sonar, github from metadata if present
 			var qos, sonar, github string
 			if len(c.Metadata) > 0 {
@@ -105,18 +115,26 @@ func (h *ComponentHandler) ListComponents(c *gin.Context) {
 				projectTitle = title
 			}
 
-			items[i] = gin.H{
+			item := gin.H{
 				"id":            c.ID,
 				"owner_id":      c.OwnerID,
 				"name":          c.Name,
 				"title":         c.Title,
 				"description":   c.Description,
+				"lifecycle":     c.Lifecycle,
 				"qos":           qos,
 				"sonar":         sonar,
 				"github":        github,
 				"project_id":    c.ProjectID,
 				"project_title": projectTitle,
 			}
+			if c.DeprecationDate != nil {
+				item["deprecation_date"] = c.DeprecationDate.Format("2006-01-02")
+			}
+			if c.ReplacementID != nil {
+				item["replacement_id"] = c.ReplacementID
+			}
+			items = append(items, item)
 		}
 		c.JSON(http.StatusOK, items)
 		return
@@ -124,7 +142,7 @@ func (h *ComponentHandler) ListComponents(c *gin.Context) {
 
 	// If project-name is provided, return ALL components for the project (unpaginated minimal view) and ignore organization_id requirement
 	if projectName != "" {
-		views, err := h.componentService.GetByProjectNameAllView(projectName)
+		views, err := h.componentService.GetByProjectNameAllView(projectName, lifecycles)
 		if err != nil {
 			if errors.Is(err, apperrors.ErrProjectNotFound) {
 				c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
@@ -140,3 +158,256 @@ func (h *ComponentHandler) ListComponents(c *gin.Context) {
 	c.JSON(http.StatusBadRequest, gin.H{"error": "team-id or project-name parameter is required"})
 	return
 }
+
+// GetComponent handles GET /components/:id
+// @Summary Get a component by ID
+// @Tags components
+// @Produce json
+// @Param id path string true "Component ID (UUID)"
+// @Success 200 {object} service.ComponentResponse "Successfully retrieved component"
+// @Failure 400 {object} map[string]interface{} "Invalid component ID"
+// @Failure 404 {object} map[string]interface{} "Component not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /components/{id} [get]
+func (h *ComponentHandler) GetComponent(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid component ID"})
+		return
+	}
+
+	component, err := h.componentService.GetComponentByID(id)
+	if err != nil {
+		writeComponentError(c, err, "Failed to get component")
+		return
+	}
+
+	setETag(c, component.Version)
+	c.JSON(http.StatusOK, component)
+}
+
+// CreateComponent handles POST /components
+// @Summary Create a component
+// @Description Creates a component with a name unique in its project. Only managers of the owning team may do this.
+// @Tags components
+// @Accept json
+// @Produce json
+// @Param component body service.CreateComponentRequest true "Component data"
+// @Success 201 {object} service.ComponentResponse "Successfully created component"
+// @Failure 400 {object} map[string]interface{} "Invalid request or validation failed"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Forbidden by authorization policy"
+// @Failure 404 {object} map[string]interface{} "Project or team not found"
+// @Failure 409 {object} map[string]interface{} "Component with this name already exists in the project"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /components [post]
+func (h *ComponentHandler) CreateComponent(c *gin.Context) {
+	var req service.CreateComponentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Populate created_by from bearer token username
+	if username, ok := auth.GetUsername(c); ok && username != "" {
+		req.CreatedBy = username
+	} else {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing username in token"})
+		return
+	}
+
+	component, err := h.componentService.CreateComponent(&req)
+	if err != nil {
+		writeComponentError(c, err, "Failed to create component")
+		return
+	}
+
+	setETag(c, component.Version)
+	c.JSON(http.StatusCreated, component)
+}
+
+// UpdateComponent handles PATCH /components/:id
+// @Summary Update a component
+// @Description Updates the given fields of a component, including its lifecycle; omitted fields are kept. Only managers of the owning team may do this.
+// @Tags components
+// @Accept json
+// @Produce json
+// @Param id path string true "Component ID (UUID)"
+// @Param If-Match header string false "ETag of the component as last read; the update fails with 412 if it changed since"
+// @Param component body service.UpdateComponentRequest true "Fields to update"
+// @Success 200 {object} service.ComponentResponse "Successfully updated component"
+// @Failure 400 {object} map[string]interface{} "Invalid request or validation failed"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Forbidden by authorization policy"
+// @Failure 404 {object} map[string]interface{} "Component not found"
+// @Failure 412 {object} map[string]interface{} "Component was modified since it was read"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /components/{id} [patch]
+func (h *ComponentHandler) UpdateComponent(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid component ID"})
+		return
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req service.UpdateComponentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ExpectedVersion = expectedVersion
+
+	// Populate updated_by from bearer token username
+	if username, ok := auth.GetUsername(c); ok && username != "" {
+		req.UpdatedBy = username
+	} else {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing username in token"})
+		return
+	}
+
+	component, err := h.componentService.UpdateComponent(id, &req)
+	if err != nil {
+		writeComponentError(c, err, "Failed to update component")
+		return
+	}
+
+	setETag(c, component.Version)
+	c.JSON(http.StatusOK, component)
+}
+
+// DeleteComponent handles DELETE /components/:id
+// @Summary Delete a component
+// @Description Moves a component to the trash. Only managers of the owning team may do this.
+// @Tags components
+// @Produce json
+// @Param id path string true "Component ID (UUID)"
+// @Success 204 "Successfully deleted component"
+// @Failure 400 {object} map[string]interface{} "Invalid component ID"
+// @Failure 403 {object} map[string]interface{} "Forbidden by authorization policy"
+// @Failure 404 {object} map[string]interface{} "Component not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /components/{id} [delete]
+func (h *ComponentHandler) DeleteComponent(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid component ID"})
+		return
+	}
+
+	if err := h.componentService.DeleteComponent(id); err != nil {
+		writeComponentError(c, err, "Failed to delete component")
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// TransferOwnership handles PUT /components/:id/owner
+// @Summary Transfer a component to another team
+// @Description Makes another team the owner of a component and records the transfer in its ownership history. The caller must manage both the current and the new owning team.
+// @Tags components
+// @Accept json
+// @Produce json
+// @Param id path string true "Component ID (UUID)"
+// @Param If-Match header string false "ETag of the component as last read; the transfer fails with 412 if it changed since"
+// @Param request body service.TransferOwnershipRequest true "New owning team"
+// @Success 200 {object} service.ComponentResponse "Successfully transferred component"
+// @Failure 400 {object} map[string]interface{} "Invalid request or the team already owns the component"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Forbidden by authorization policy"
+// @Failure 404 {object} map[string]interface{} "Component or team not found"
+// @Failure 412 {object} map[string]interface{} "Component was modified since it was read"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /components/{id}/owner [put]
+func (h *ComponentHandler) TransferOwnership(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid component ID"})
+		return
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req service.TransferOwnershipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ExpectedVersion = expectedVersion
+
+	// Populate changed_by from bearer token username
+	if username, ok := auth.GetUsername(c); ok && username != "" {
+		req.ChangedBy = username
+	} else {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing username in token"})
+		return
+	}
+
+	component, err := h.componentService.TransferOwnership(id, &req)
+	if err != nil {
+		writeComponentError(c, err, "Failed to transfer component")
+		return
+	}
+
+	setETag(c, component.Version)
+	c.JSON(http.StatusOK, component)
+}
+
+// GetOwnershipHistory handles GET /components/:id/ownership-history
+// @Summary Get the ownership history of a component
+// @Description Returns the transfers of a component between teams, newest first
+// @Tags components
+// @Produce json
+// @Param id path string true "Component ID (UUID)"
+// @Success 200 {array} models.ComponentOwnershipChange "Successfully retrieved ownership history"
+// @Failure 400 {object} map[string]interface{} "Invalid component ID"
+// @Failure 404 {object} map[string]interface{} "Component not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /components/{id}/ownership-history [get]
+func (h *ComponentHandler) GetOwnershipHistory(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid component ID"})
+		return
+	}
+
+	changes, err := h.componentService.GetOwnershipHistory(id)
+	if err != nil {
+		writeComponentError(c, err, "Failed to get ownership history")
+		return
+	}
+
+	c.JSON(http.StatusOK, changes)
+}
+
+// writeComponentError maps errors of the component endpoints to responses
+func writeComponentError(c *gin.Context, err error, message string) {
+	switch {
+	case apperrors.IsValidation(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case apperrors.IsNotFound(err):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case apperrors.IsAlreadyExists(err):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, apperrors.ErrVersionConflict):
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+	}
+}
@@ -1,39 +1,76 @@
 package handlers_test
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"developer-portal-backend/internal/api/handlers"
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/service"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
 )
 
 // ComponentHandlerTestSuite defines the test suite for ComponentHandler (aligned to current /components API)
 type ComponentHandlerTestSuite struct {
 	suite.Suite
-	handler *handlers.ComponentHandler
-	router  *gin.Engine
+	ctrl          *gomock.Controller
+	mockComponent *mocks.MockComponentServiceInterface
+	mockTeam      *mocks.MockTeamServiceInterface
+	handler       *handlers.ComponentHandler
+	router        *gin.Engine
 }
 
 // SetupTest sets up the test suite
 func (suite *ComponentHandlerTestSuite) SetupTest() {
 	gin.SetMode(gin.TestMode)
 
-	// ComponentHandler: only /components GET is supported with team-id or project-name
-	// No component service or team service required for the negative-path tests we keep
-	suite.handler = handlers.NewComponentHandler(nil, nil)
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockComponent = mocks.NewMockComponentServiceInterface(suite.ctrl)
+	suite.mockTeam = mocks.NewMockTeamServiceInterface(suite.ctrl)
+	suite.handler = handlers.NewComponentHandler(suite.mockComponent, suite.mockTeam)
 	suite.router = gin.New()
+	suite.router.Use(func(c *gin.Context) {
+		c.Set("username", "jdoe")
+		c.Next()
+	})
 	suite.setupRoutes()
 }
 
-// setupRoutes sets up only the relevant route for testing
+// TearDownTest verifies the mock expectations
+func (suite *ComponentHandlerTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+// setupRoutes sets up the component routes for testing
 func (suite *ComponentHandlerTestSuite) setupRoutes() {
-	// Only the supported endpoint remains
 	suite.router.GET("/components", suite.handler.ListComponents)
+	suite.router.POST("/components", suite.handler.CreateComponent)
+	suite.router.GET("/components/:id", suite.handler.GetComponent)
+	suite.router.PATCH("/components/:id", suite.handler.UpdateComponent)
+	suite.router.DELETE("/components/:id", suite.handler.DeleteComponent)
+	suite.router.PUT("/components/:id/owner", suite.handler.TransferOwnership)
+	suite.router.GET("/components/:id/ownership-history", suite.handler.GetOwnershipHistory)
+}
+
+func (suite *ComponentHandlerTestSuite) do(method, path, body string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
 }
 
 // TestListComponents tests the ListComponents handler negative-paths
@@ -61,6 +98,121 @@ func (suite *ComponentHandlerTestSuite) TestListComponents() {
 	})
 }
 
+// TestListComponentsByLifecycle tests that end-of-life components are hidden unless asked for
+func (suite *ComponentHandlerTestSuite) TestListComponentsByLifecycle() {
+	teamID := uuid.New()
+	components := []models.Component{
+		{BaseModel: models.BaseModel{ID: uuid.New(), Name: "api"}, Lifecycle: models.ComponentLifecycleProduction},
+		{BaseModel: models.BaseModel{ID: uuid.New(), Name: "legacy"}, Lifecycle: models.ComponentLifecycleEndOfLife},
+	}
+	suite.mockTeam.EXPECT().GetTeamComponentsByID(teamID, 1, 1000000).Return(components, int64(2), nil).Times(2)
+	suite.mockComponent.EXPECT().GetProjectTitleByID(gomock.Any()).Return("CIS 2.0", nil).AnyTimes()
+
+	var items []map[string]interface{}
+	w := suite.do(http.MethodGet, "/components?team-id="+teamID.String(), "")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &items))
+	suite.Require().Len(items, 1)
+	suite.Equal("api", items[0]["name"])
+
+	w = suite.do(http.MethodGet, "/components?team-id="+teamID.String()+"&lifecycle=end-of-life", "")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &items))
+	suite.Require().Len(items, 1)
+	suite.Equal("legacy", items[0]["name"])
+
+	suite.mockComponent.EXPECT().GetByProjectNameAllView("cis20", service.LifecycleFilter{models.ComponentLifecycleDeprecated: true}).Return([]service.ComponentProjectView{}, nil)
+	suite.Equal(http.StatusOK, suite.do(http.MethodGet, "/components?project-name=cis20&lifecycle=deprecated", "").Code)
+
+	suite.Equal(http.StatusBadRequest, suite.do(http.MethodGet, "/components?project-name=cis20&lifecycle=retired", "").Code)
+}
+
+// TestCreateComponent tests creating a component
+func (suite *ComponentHandlerTestSuite) TestCreateComponent() {
+	suite.mockComponent.EXPECT().CreateComponent(gomock.Any()).DoAndReturn(func(req *service.CreateComponentRequest) (*service.ComponentResponse, error) {
+		suite.Equal("api", req.Name)
+		suite.Equal(models.ComponentLifecycleExperimental, req.Lifecycle)
+		suite.Equal("jdoe", req.CreatedBy)
+		return &service.ComponentResponse{ID: uuid.New(), Name: req.Name, Version: 1}, nil
+	})
+	suite.mockComponent.EXPECT().CreateComponent(gomock.Any()).Return(nil, apperrors.ErrComponentExists)
+
+	body := `{"name":"api","title":"API","project_id":"` + uuid.NewString() + `","owner_id":"` + uuid.NewString() + `","lifecycle":"experimental"}`
+	w := suite.do(http.MethodPost, "/components", body)
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Equal(`"1"`, w.Header().Get("ETag"))
+	suite.Equal(http.StatusConflict, suite.do(http.MethodPost, "/components", body).Code)
+}
+
+// TestGetComponent tests retrieving a component by ID
+func (suite *ComponentHandlerTestSuite) TestGetComponent() {
+	id := uuid.New()
+	suite.mockComponent.EXPECT().GetComponentByID(id).Return(&service.ComponentResponse{ID: id, Version: 3}, nil)
+	suite.mockComponent.EXPECT().GetComponentByID(gomock.Any()).Return(nil, apperrors.ErrComponentNotFound)
+
+	w := suite.do(http.MethodGet, "/components/"+id.String(), "")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"3"`, w.Header().Get("ETag"))
+	suite.Equal(http.StatusNotFound, suite.do(http.MethodGet, "/components/"+uuid.New().String(), "").Code)
+	suite.Equal(http.StatusBadRequest, suite.do(http.MethodGet, "/components/api", "").Code)
+}
+
+// TestUpdateComponent tests deprecating a component with If-Match
+func (suite *ComponentHandlerTestSuite) TestUpdateComponent() {
+	id := uuid.New()
+	replacement := uuid.New()
+	suite.mockComponent.EXPECT().UpdateComponent(id, gomock.Any()).DoAndReturn(func(_ uuid.UUID, req *service.UpdateComponentRequest) (*service.ComponentResponse, error) {
+		suite.Require().NotNil(req.Lifecycle)
+		suite.Equal(models.ComponentLifecycleDeprecated, *req.Lifecycle)
+		suite.Equal("2026-03-31", *req.DeprecationDate)
+		suite.Equal(replacement, *req.ReplacementID)
+		suite.Equal(int64(2), req.ExpectedVersion)
+		return &service.ComponentResponse{ID: id, Lifecycle: *req.Lifecycle, Version: 3}, nil
+	})
+	suite.mockComponent.EXPECT().UpdateComponent(id, gomock.Any()).Return(nil, apperrors.ErrVersionConflict)
+
+	body := `{"lifecycle":"deprecated","deprecation_date":"2026-03-31","replacement_id":"` + replacement.String() + `"}`
+	w := suite.do(http.MethodPatch, "/components/"+id.String(), body, "If-Match", `"2"`)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"3"`, w.Header().Get("ETag"))
+	suite.Equal(http.StatusPreconditionFailed, suite.do(http.MethodPatch, "/components/"+id.String(), `{}`, "If-Match", `"2"`).Code)
+}
+
+// TestDeleteComponent tests deleting a component
+func (suite *ComponentHandlerTestSuite) TestDeleteComponent() {
+	id := uuid.New()
+	suite.mockComponent.EXPECT().DeleteComponent(id).Return(nil)
+	suite.mockComponent.EXPECT().DeleteComponent(gomock.Any()).Return(apperrors.ErrComponentNotFound)
+
+	suite.Equal(http.StatusNoContent, suite.do(http.MethodDelete, "/components/"+id.String(), "").Code)
+	suite.Equal(http.StatusNotFound, suite.do(http.MethodDelete, "/components/"+uuid.New().String(), "").Code)
+}
+
+// TestTransferOwnership tests transferring a component and reading its history
+func (suite *ComponentHandlerTestSuite) TestTransferOwnership() {
+	id := uuid.New()
+	newOwner := uuid.New()
+	suite.mockComponent.EXPECT().TransferOwnership(id, gomock.Any()).DoAndReturn(func(_ uuid.UUID, req *service.TransferOwnershipRequest) (*service.ComponentResponse, error) {
+		suite.Equal(newOwner, req.OwnerID)
+		suite.Equal("reorg", req.Reason)
+		suite.Equal("jdoe", req.ChangedBy)
+		return &service.ComponentResponse{ID: id, OwnerID: newOwner, Version: 2}, nil
+	})
+	suite.mockComponent.EXPECT().GetOwnershipHistory(id).Return([]models.ComponentOwnershipChange{{ComponentID: id, ToOwnerID: newOwner, ChangedBy: "jdoe"}}, nil)
+
+	w := suite.do(http.MethodPut, "/components/"+id.String()+"/owner", `{"owner_id":"`+newOwner.String()+`","reason":"reorg"}`)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"2"`, w.Header().Get("ETag"))
+	suite.Equal(http.StatusBadRequest, suite.do(http.MethodPut, "/components/"+id.String()+"/owner", `{"reason":"reorg"}`).Code)
+
+	w = suite.do(http.MethodGet, "/components/"+id.String()+"/ownership-history", "")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var changes []models.ComponentOwnershipChange
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &changes))
+	suite.Require().Len(changes, 1)
+	suite.Equal(newOwner, changes[0].ToOwnerID)
+}
+
 // TestComponentHandlerTestSuite runs the test suite
 func TestComponentHandlerTestSuite(t *testing.T) {
 	suite.Run(t, new(ComponentHandlerTestSuite))
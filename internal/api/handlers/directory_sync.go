@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DirectorySyncHandler handles HTTP requests for the LDAP reconciliation of users
+type DirectorySyncHandler struct {
+	syncService service.DirectorySyncServiceInterface
+}
+
+// NewDirectorySyncHandler creates a new directory sync handler
+func NewDirectorySyncHandler(syncService service.DirectorySyncServiceInterface) *DirectorySyncHandler {
+	return &DirectorySyncHandler{
+		syncService: syncService,
+	}
+}
+
+// GetReport handles GET /admin/ldap-sync
+// @Summary Get the latest LDAP reconciliation report
+// @Description Returns the report of the latest reconciliation of users with LDAP run by this instance: updated attributes, users missing from the directory, users overdue for offboarding after the grace period and, with LDAP_SYNC_DELETE_MISSING, users deleted. Requires admin privileges.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} service.DirectorySyncReport "Latest reconciliation report"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 404 {object} map[string]interface{} "No reconciliation has run yet"
+// @Security BearerAuth
+// @Router /admin/ldap-sync [get]
+func (h *DirectorySyncHandler) GetReport(c *gin.Context) {
+	report, err := h.syncService.LastReport()
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get reconciliation report", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// RunSync handles POST /admin/ldap-sync
+// @Summary Reconcile users with LDAP now
+// @Description Runs a reconciliation of users with LDAP and returns its report. Requires admin privileges.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} service.DirectorySyncReport "Reconciliation report"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 409 {object} map[string]interface{} "A reconciliation is already running"
+// @Failure 502 {object} map[string]interface{} "LDAP connection or search failed"
+// @Security BearerAuth
+// @Router /admin/ldap-sync [post]
+func (h *DirectorySyncHandler) RunSync(c *gin.Context) {
+	report, err := h.syncService.Reconcile()
+	if err != nil {
+		if errors.Is(err, apperrors.ErrDirectorySyncRunning) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusBadGateway, gin.H{"error": "LDAP reconciliation failed", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
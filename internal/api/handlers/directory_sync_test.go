@@ -0,0 +1,78 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"developer-portal-backend/internal/api/handlers"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type DirectorySyncHandlerTestSuite struct {
+	suite.Suite
+	ctrl     *gomock.Controller
+	mockSync *mocks.MockDirectorySyncServiceInterface
+	router   *gin.Engine
+}
+
+func (suite *DirectorySyncHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockSync = mocks.NewMockDirectorySyncServiceInterface(suite.ctrl)
+	handler := handlers.NewDirectorySyncHandler(suite.mockSync)
+	suite.router = gin.New()
+	suite.router.GET("/admin/ldap-sync", handler.GetReport)
+	suite.router.POST("/admin/ldap-sync", handler.RunSync)
+}
+
+func (suite *DirectorySyncHandlerTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *DirectorySyncHandlerTestSuite) do(method string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, httptest.NewRequest(method, "/admin/ldap-sync", nil))
+	return w
+}
+
+func (suite *DirectorySyncHandlerTestSuite) TestGetReport() {
+	suite.mockSync.EXPECT().LastReport().Return(nil, apperrors.ErrDirectorySyncReportNotFound)
+	suite.mockSync.EXPECT().LastReport().Return(&service.DirectorySyncReport{
+		Checked:     2,
+		Deactivated: []service.DirectoryMissingUser{{UserID: "I123456"}},
+	}, nil)
+
+	suite.Equal(http.StatusNotFound, suite.do(http.MethodGet).Code)
+
+	w := suite.do(http.MethodGet)
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var report service.DirectorySyncReport
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &report))
+	suite.Equal(2, report.Checked)
+	suite.Equal("I123456", report.Deactivated[0].UserID)
+}
+
+func (suite *DirectorySyncHandlerTestSuite) TestRunSync() {
+	suite.mockSync.EXPECT().Reconcile().Return(&service.DirectorySyncReport{Checked: 5}, nil)
+	suite.mockSync.EXPECT().Reconcile().Return(nil, apperrors.ErrDirectorySyncRunning)
+	suite.mockSync.EXPECT().Reconcile().Return(&service.DirectorySyncReport{}, errors.New("failed to look up users in LDAP: connection refused"))
+
+	suite.Equal(http.StatusOK, suite.do(http.MethodPost).Code)
+	suite.Equal(http.StatusConflict, suite.do(http.MethodPost).Code)
+	w := suite.do(http.MethodPost)
+	suite.Equal(http.StatusBadGateway, w.Code)
+	suite.Contains(w.Body.String(), "connection refused")
+}
+
+func TestDirectorySyncHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(DirectorySyncHandlerTestSuite))
+}
@@ -1,9 +1,11 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"developer-portal-backend/internal/auth"
+	apperrors "developer-portal-backend/internal/errors"
 	"developer-portal-backend/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -33,6 +35,7 @@ func NewDocumentationHandler(docService service.DocumentationServiceInterface) *
 // @Success 201 {object} service.DocumentationResponse "Successfully created documentation"
 // @Failure 400 {object} map[string]interface{} "Invalid request or validation failed"
 // @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Forbidden by authorization policy"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security BearerAuth
 // @Router /documentations [post]
@@ -87,6 +90,7 @@ func (h *DocumentationHandler) GetDocumentationByID(c *gin.Context) {
 		return
 	}
 
+	setETag(c, doc.Version)
 	c.JSON(http.StatusOK, doc)
 }
 
@@ -128,11 +132,14 @@ func (h *DocumentationHandler) GetDocumentationsByTeamID(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Documentation ID (UUID)"
+// @Param If-Match header string false "ETag of the documentation as last read; the update fails with 412 if it changed since"
 // @Param documentation body service.UpdateDocumentationRequest true "Documentation update data"
 // @Success 200 {object} service.DocumentationResponse "Successfully updated documentation"
 // @Failure 400 {object} map[string]interface{} "Invalid request or validation failed"
 // @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Forbidden by authorization policy"
 // @Failure 404 {object} map[string]interface{} "Documentation not found"
+// @Failure 412 {object} map[string]interface{} "Documentation was modified since it was read"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security BearerAuth
 // @Router /documentations/{id} [patch]
@@ -144,11 +151,18 @@ func (h *DocumentationHandler) UpdateDocumentation(c *gin.Context) {
 		return
 	}
 
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	var req service.UpdateDocumentationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	req.ExpectedVersion = expectedVersion
 
 	// Populate updated_by from bearer token username
 	if username, ok := auth.GetUsername(c); ok && username != "" {
@@ -160,10 +174,15 @@ func (h *DocumentationHandler) UpdateDocumentation(c *gin.Context) {
 
 	doc, err := h.docService.UpdateDocumentation(id, &req)
 	if err != nil {
+		if errors.Is(err, apperrors.ErrVersionConflict) {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	setETag(c, doc.Version)
 	c.JSON(http.StatusOK, doc)
 }
 
@@ -176,6 +195,7 @@ func (h *DocumentationHandler) UpdateDocumentation(c *gin.Context) {
 // @Param id path string true "Documentation ID (UUID)"
 // @Success 204 "Successfully deleted documentation"
 // @Failure 400 {object} map[string]interface{} "Invalid documentation ID"
+// @Failure 403 {object} map[string]interface{} "Forbidden by authorization policy"
 // @Failure 404 {object} map[string]interface{} "Documentation not found"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security BearerAuth
@@ -1,3 +1,225 @@
 package handlers
 
-// Currently there are no endpoints for 'group' model. Placeholder file for consistency.
+import (
+	"net/http"
+
+	"developer-portal-backend/internal/auth"
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GroupHandler handles HTTP requests for groups
+type GroupHandler struct {
+	groupService service.GroupServiceInterface
+}
+
+// NewGroupHandler creates a new group handler
+func NewGroupHandler(groupService service.GroupServiceInterface) *GroupHandler {
+	return &GroupHandler{
+		groupService: groupService,
+	}
+}
+
+// CreateGroup handles POST /groups
+// @Summary Create a group
+// @Description Creates a group with a name unique in its organization. Only the owner of the organization may do this.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param group body service.CreateGroupRequest true "Group data"
+// @Success 201 {object} service.GroupResponse "Successfully created group"
+// @Failure 400 {object} map[string]interface{} "Invalid request or validation failed"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Forbidden by authorization policy"
+// @Failure 404 {object} map[string]interface{} "Organization not found"
+// @Failure 409 {object} map[string]interface{} "Group with this name already exists in the organization"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /groups [post]
+func (h *GroupHandler) CreateGroup(c *gin.Context) {
+	var req service.CreateGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Populate created_by from bearer token username
+	if username, ok := auth.GetUsername(c); ok && username != "" {
+		req.CreatedBy = username
+	} else {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing username in token"})
+		return
+	}
+
+	group, err := h.groupService.CreateGroup(&req)
+	if err != nil {
+		writeOrganizationError(c, err, "Failed to create group")
+		return
+	}
+
+	setETag(c, group.Version)
+	c.JSON(http.StatusCreated, group)
+}
+
+// GetGroup handles GET /groups/:id
+// @Summary Get a group with its teams
+// @Tags groups
+// @Produce json
+// @Param id path string true "Group ID (UUID)"
+// @Success 200 {object} service.GroupWithTeamsResponse "Successfully retrieved group"
+// @Failure 400 {object} map[string]interface{} "Invalid group ID"
+// @Failure 404 {object} map[string]interface{} "Group not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /groups/{id} [get]
+func (h *GroupHandler) GetGroup(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid group ID"})
+		return
+	}
+
+	group, err := h.groupService.GetGroupWithTeams(id)
+	if err != nil {
+		writeOrganizationError(c, err, "Failed to get group")
+		return
+	}
+
+	setETag(c, group.Version)
+	c.JSON(http.StatusOK, group)
+}
+
+// UpdateGroup handles PATCH /groups/:id
+// @Summary Update a group
+// @Description Updates the given fields of a group; omitted fields are kept. Only the owners of the group and of its organization may do this.
+// @Tags groups
+// @Accept json
+// @Produce json
+// @Param id path string true "Group ID (UUID)"
+// @Param If-Match header string false "ETag of the group as last read; the update fails with 412 if it changed since"
+// @Param group body service.UpdateGroupRequest true "Fields to update"
+// @Success 200 {object} service.GroupResponse "Successfully updated group"
+// @Failure 400 {object} map[string]interface{} "Invalid request or validation failed"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Forbidden by authorization policy"
+// @Failure 404 {object} map[string]interface{} "Group not found"
+// @Failure 412 {object} map[string]interface{} "Group was modified since it was read"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /groups/{id} [patch]
+func (h *GroupHandler) UpdateGroup(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid group ID"})
+		return
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req service.UpdateGroupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ExpectedVersion = expectedVersion
+
+	// Populate updated_by from bearer token username
+	if username, ok := auth.GetUsername(c); ok && username != "" {
+		req.UpdatedBy = username
+	} else {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing username in token"})
+		return
+	}
+
+	group, err := h.groupService.UpdateGroup(id, &req)
+	if err != nil {
+		writeOrganizationError(c, err, "Failed to update group")
+		return
+	}
+
+	setETag(c, group.Version)
+	c.JSON(http.StatusOK, group)
+}
+
+// DeleteGroup handles DELETE /groups/:id
+// @Summary Delete a group
+// @Description Deletes a group without teams. Only the owner of the group's organization may do this.
+// @Tags groups
+// @Produce json
+// @Param id path string true "Group ID (UUID)"
+// @Success 204 "Successfully deleted group"
+// @Failure 400 {object} map[string]interface{} "Invalid group ID"
+// @Failure 403 {object} map[string]interface{} "Forbidden by authorization policy"
+// @Failure 404 {object} map[string]interface{} "Group not found"
+// @Failure 409 {object} map[string]interface{} "Group still has teams"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /groups/{id} [delete]
+func (h *GroupHandler) DeleteGroup(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid group ID"})
+		return
+	}
+
+	if err := h.groupService.DeleteGroup(id); err != nil {
+		writeOrganizationError(c, err, "Failed to delete group")
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// MoveTeam handles PUT /teams/:id/group
+// @Summary Move a team to another group
+// @Description Moves a team to the given group, which must not have a team of the same name. The caller must own both the team's current group and the target group, or their organizations.
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path string true "Team ID (UUID)"
+// @Param request body service.MoveTeamRequest true "Target group"
+// @Success 200 {object} service.TeamResponse "Successfully moved team"
+// @Failure 400 {object} map[string]interface{} "Invalid team or group ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Forbidden by authorization policy"
+// @Failure 404 {object} map[string]interface{} "Team or group not found"
+// @Failure 409 {object} map[string]interface{} "Target group already has a team with this name"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /teams/{id}/group [put]
+func (h *GroupHandler) MoveTeam(c *gin.Context) {
+	teamID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team ID"})
+		return
+	}
+
+	var req service.MoveTeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Populate updated_by from bearer token username
+	if username, ok := auth.GetUsername(c); ok && username != "" {
+		req.UpdatedBy = username
+	} else {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing username in token"})
+		return
+	}
+
+	team, err := h.groupService.MoveTeam(teamID, &req)
+	if err != nil {
+		writeOrganizationError(c, err, "Failed to move team")
+		return
+	}
+
+	setETag(c, team.Version)
+	c.JSON(http.StatusOK, team)
+}
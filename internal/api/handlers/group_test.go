@@ -1,7 +1,136 @@
 package handlers_test
 
-import "testing"
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
 
-func TestGroupHandlersPlaceholder(t *testing.T) {
-	t.Skip("Placeholder test to keep package consisted and compiling.")
+	"developer-portal-backend/internal/api/handlers"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type GroupHandlerTestSuite struct {
+	suite.Suite
+	ctrl      *gomock.Controller
+	mockGroup *mocks.MockGroupServiceInterface
+	router    *gin.Engine
+}
+
+func (suite *GroupHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockGroup = mocks.NewMockGroupServiceInterface(suite.ctrl)
+	handler := handlers.NewGroupHandler(suite.mockGroup)
+	suite.router = gin.New()
+	suite.router.Use(func(c *gin.Context) {
+		c.Set("username", "jdoe")
+		c.Next()
+	})
+	suite.router.POST("/groups", handler.CreateGroup)
+	suite.router.GET("/groups/:id", handler.GetGroup)
+	suite.router.PATCH("/groups/:id", handler.UpdateGroup)
+	suite.router.DELETE("/groups/:id", handler.DeleteGroup)
+	suite.router.PUT("/teams/:id/group", handler.MoveTeam)
+}
+
+func (suite *GroupHandlerTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *GroupHandlerTestSuite) do(method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *GroupHandlerTestSuite) TestCreateGroup() {
+	orgID := uuid.New()
+	suite.mockGroup.EXPECT().CreateGroup(gomock.Any()).DoAndReturn(func(req *service.CreateGroupRequest) (*service.GroupResponse, error) {
+		suite.Equal(orgID, req.OrgID)
+		suite.Equal("jdoe", req.CreatedBy)
+		return &service.GroupResponse{ID: uuid.New(), OrgID: orgID, Name: req.Name, Version: 1}, nil
+	})
+
+	w := suite.do(http.MethodPost, "/groups", `{"org_id":"`+orgID.String()+`","name":"core-platform"}`)
+	suite.Equal(http.StatusCreated, w.Code)
+
+	suite.mockGroup.EXPECT().CreateGroup(gomock.Any()).Return(nil, apperrors.ErrOrganizationNotFound)
+	suite.Equal(http.StatusNotFound, suite.do(http.MethodPost, "/groups", `{"org_id":"`+uuid.New().String()+`"}`).Code)
+}
+
+func (suite *GroupHandlerTestSuite) TestGetGroup() {
+	id := uuid.New()
+	teamID := uuid.New()
+	suite.mockGroup.EXPECT().GetGroupWithTeams(id).Return(&service.GroupWithTeamsResponse{
+		GroupResponse: service.GroupResponse{ID: id, Name: "core-platform", Version: 2},
+		Teams:         []service.TeamResponse{{ID: teamID, GroupID: id, Name: "team-a"}},
+	}, nil)
+
+	w := suite.do(http.MethodGet, "/groups/"+id.String(), "")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Equal(`"2"`, w.Header().Get("ETag"))
+	var body service.GroupWithTeamsResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &body))
+	suite.Equal("core-platform", body.Name)
+	suite.Require().Len(body.Teams, 1)
+	suite.Equal(teamID, body.Teams[0].ID)
+
+	suite.mockGroup.EXPECT().GetGroupWithTeams(gomock.Any()).Return(nil, apperrors.ErrGroupNotFound)
+	suite.Equal(http.StatusNotFound, suite.do(http.MethodGet, "/groups/"+uuid.New().String(), "").Code)
+}
+
+func (suite *GroupHandlerTestSuite) TestUpdateGroup() {
+	id := uuid.New()
+	suite.mockGroup.EXPECT().UpdateGroup(id, gomock.Any()).DoAndReturn(func(_ uuid.UUID, req *service.UpdateGroupRequest) (*service.GroupResponse, error) {
+		suite.Require().NotNil(req.Owner)
+		suite.Equal("I000009", *req.Owner)
+		suite.Equal("jdoe", req.UpdatedBy)
+		return &service.GroupResponse{ID: id, Owner: *req.Owner, Version: 3}, nil
+	})
+	suite.Equal(http.StatusOK, suite.do(http.MethodPatch, "/groups/"+id.String(), `{"owner":"I000009"}`).Code)
+
+	suite.mockGroup.EXPECT().UpdateGroup(id, gomock.Any()).Return(nil, apperrors.NewValidationError("", "Owner failed on the 'min' tag"))
+	suite.Equal(http.StatusBadRequest, suite.do(http.MethodPatch, "/groups/"+id.String(), `{"owner":"I1"}`).Code)
+}
+
+func (suite *GroupHandlerTestSuite) TestDeleteGroup() {
+	id := uuid.New()
+	suite.mockGroup.EXPECT().DeleteGroup(id).Return(nil)
+	suite.Equal(http.StatusNoContent, suite.do(http.MethodDelete, "/groups/"+id.String(), "").Code)
+
+	suite.mockGroup.EXPECT().DeleteGroup(id).Return(apperrors.ErrGroupHasTeams)
+	suite.Equal(http.StatusConflict, suite.do(http.MethodDelete, "/groups/"+id.String(), "").Code)
+}
+
+func (suite *GroupHandlerTestSuite) TestMoveTeam() {
+	teamID := uuid.New()
+	groupID := uuid.New()
+	suite.mockGroup.EXPECT().MoveTeam(teamID, gomock.Any()).DoAndReturn(func(_ uuid.UUID, req *service.MoveTeamRequest) (*service.TeamResponse, error) {
+		suite.Equal(groupID, req.GroupID)
+		return &service.TeamResponse{ID: teamID, GroupID: groupID, Version: 5}, nil
+	})
+
+	w := suite.do(http.MethodPut, "/teams/"+teamID.String()+"/group", `{"group_id":"`+groupID.String()+`"}`)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"5"`, w.Header().Get("ETag"))
+
+	suite.Equal(http.StatusBadRequest, suite.do(http.MethodPut, "/teams/"+teamID.String()+"/group", `{}`).Code)
+
+	suite.mockGroup.EXPECT().MoveTeam(teamID, gomock.Any()).Return(nil, apperrors.ErrTeamExists)
+	suite.Equal(http.StatusConflict, suite.do(http.MethodPut, "/teams/"+teamID.String()+"/group", `{"group_id":"`+groupID.String()+`"}`).Code)
+}
+
+func TestGroupHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(GroupHandlerTestSuite))
 }
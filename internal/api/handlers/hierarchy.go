@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// HierarchyHandler handles HTTP requests for the organization chart
+type HierarchyHandler struct {
+	hierarchyService service.HierarchyServiceInterface
+}
+
+// NewHierarchyHandler creates a new hierarchy handler
+func NewHierarchyHandler(hierarchyService service.HierarchyServiceInterface) *HierarchyHandler {
+	return &HierarchyHandler{
+		hierarchyService: hierarchyService,
+	}
+}
+
+// GetOrganizationTree handles GET /organizations/:id/tree
+// @Summary Get the organization chart
+// @Description Returns an organization with its groups, their teams and the teams' members, ordered by name. Every node has the counts of the whole tree below it, regardless of depth.
+// @Tags organizations
+// @Produce json
+// @Param id path string true "Organization ID (UUID)"
+// @Param depth query int false "Levels below the organization: 1 groups, 2 teams, 3 members (default)"
+// @Param fields query []string false "Node fields to include: title, description, owner, email, picture_url (default all)" collectionFormat(csv)
+// @Param components query bool false "Include the number of components owned by each team"
+// @Success 200 {object} service.OrganizationTreeResponse "Successfully retrieved organization tree"
+// @Failure 400 {object} map[string]interface{} "Invalid organization ID, depth or fields"
+// @Failure 404 {object} map[string]interface{} "Organization not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /organizations/{id}/tree [get]
+func (h *HierarchyHandler) GetOrganizationTree(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		return
+	}
+
+	opts, err := hierarchyOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tree, err := h.hierarchyService.GetOrganizationTree(id, opts)
+	if err != nil {
+		writeOrganizationError(c, err, "Failed to get organization tree")
+		return
+	}
+
+	c.JSON(http.StatusOK, tree)
+}
+
+// GetGroupTree handles GET /groups/:id/tree
+// @Summary Get the chart of a group
+// @Description Returns a group with its teams and their members, ordered by name. Every node has the counts of the whole tree below it, regardless of depth.
+// @Tags groups
+// @Produce json
+// @Param id path string true "Group ID (UUID)"
+// @Param depth query int false "Levels below the group: 1 teams, 2 members (default)"
+// @Param fields query []string false "Node fields to include: title, description, owner, email, picture_url (default all)" collectionFormat(csv)
+// @Param components query bool false "Include the number of components owned by each team"
+// @Success 200 {object} service.GroupTreeResponse "Successfully retrieved group tree"
+// @Failure 400 {object} map[string]interface{} "Invalid group ID, depth or fields"
+// @Failure 404 {object} map[string]interface{} "Group not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /groups/{id}/tree [get]
+func (h *HierarchyHandler) GetGroupTree(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid group ID"})
+		return
+	}
+
+	opts, err := hierarchyOptions(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tree, err := h.hierarchyService.GetGroupTree(id, opts)
+	if err != nil {
+		writeOrganizationError(c, err, "Failed to get group tree")
+		return
+	}
+
+	c.JSON(http.StatusOK, tree)
+}
+
+// hierarchyOptions reads the depth, fields and components query parameters; depth defaults to the full tree
+func hierarchyOptions(c *gin.Context) (service.HierarchyOptions, error) {
+	opts := service.HierarchyOptions{
+		Depth:  service.MaxHierarchyDepth,
+		Fields: queryList(c, "fields"),
+	}
+	if raw := c.Query("depth"); raw != "" {
+		depth, err := strconv.Atoi(raw)
+		if err != nil {
+			return opts, fmt.Errorf("invalid depth: %q is not a number", raw)
+		}
+		opts.Depth = depth
+	}
+	components, err := queryBool(c, "components")
+	if err != nil {
+		return opts, err
+	}
+	opts.ComponentCounts = components != nil && *components
+	return opts, nil
+}
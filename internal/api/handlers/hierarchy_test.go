@@ -0,0 +1,94 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"developer-portal-backend/internal/api/handlers"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type HierarchyHandlerTestSuite struct {
+	suite.Suite
+	ctrl          *gomock.Controller
+	mockHierarchy *mocks.MockHierarchyServiceInterface
+	router        *gin.Engine
+}
+
+func (suite *HierarchyHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockHierarchy = mocks.NewMockHierarchyServiceInterface(suite.ctrl)
+	handler := handlers.NewHierarchyHandler(suite.mockHierarchy)
+	suite.router = gin.New()
+	suite.router.GET("/organizations/:id/tree", handler.GetOrganizationTree)
+	suite.router.GET("/groups/:id/tree", handler.GetGroupTree)
+}
+
+func (suite *HierarchyHandlerTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *HierarchyHandlerTestSuite) get(path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w
+}
+
+func (suite *HierarchyHandlerTestSuite) TestGetOrganizationTree() {
+	id := uuid.New()
+	suite.mockHierarchy.EXPECT().GetOrganizationTree(id, service.HierarchyOptions{Depth: service.MaxHierarchyDepth}).
+		Return(&service.OrganizationTreeResponse{HierarchyNode: service.HierarchyNode{ID: id, Name: "sap"}, GroupCount: 2}, nil)
+	suite.mockHierarchy.EXPECT().GetOrganizationTree(id, service.HierarchyOptions{Depth: 1, Fields: []string{"title", "owner", "email"}, ComponentCounts: true}).
+		Return(&service.OrganizationTreeResponse{}, nil)
+
+	w := suite.get("/organizations/" + id.String() + "/tree")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var tree map[string]interface{}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &tree))
+	suite.Equal("sap", tree["name"])
+	suite.Equal(float64(2), tree["group_count"])
+	suite.NotContains(tree, "component_count")
+
+	// Fields may be repeated or comma-separated
+	suite.Equal(http.StatusOK, suite.get("/organizations/"+id.String()+"/tree?depth=1&fields=title,owner&fields=email&components=true").Code)
+}
+
+func (suite *HierarchyHandlerTestSuite) TestGetOrganizationTree_Errors() {
+	id := uuid.New()
+	suite.mockHierarchy.EXPECT().GetOrganizationTree(id, gomock.Any()).Return(nil, apperrors.ErrOrganizationNotFound)
+	suite.mockHierarchy.EXPECT().GetOrganizationTree(id, gomock.Any()).Return(nil, apperrors.NewValidationError("fields", "unknown field"))
+
+	suite.Equal(http.StatusNotFound, suite.get("/organizations/"+id.String()+"/tree").Code)
+	suite.Equal(http.StatusBadRequest, suite.get("/organizations/"+id.String()+"/tree?fields=metadata").Code)
+	suite.Equal(http.StatusBadRequest, suite.get("/organizations/"+id.String()+"/tree?depth=all").Code)
+	suite.Equal(http.StatusBadRequest, suite.get("/organizations/"+id.String()+"/tree?components=maybe").Code)
+	suite.Equal(http.StatusBadRequest, suite.get("/organizations/sap/tree").Code)
+}
+
+func (suite *HierarchyHandlerTestSuite) TestGetGroupTree() {
+	id := uuid.New()
+	suite.mockHierarchy.EXPECT().GetGroupTree(id, service.HierarchyOptions{Depth: 1}).
+		Return(&service.GroupTreeResponse{HierarchyNode: service.HierarchyNode{ID: id, Name: "platform"}, TeamCount: 2}, nil)
+	suite.mockHierarchy.EXPECT().GetGroupTree(gomock.Any(), gomock.Any()).Return(nil, apperrors.ErrGroupNotFound)
+
+	w := suite.get("/groups/" + id.String() + "/tree?depth=1")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var tree service.GroupTreeResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &tree))
+	suite.Equal(2, tree.TeamCount)
+	suite.Equal(http.StatusNotFound, suite.get("/groups/"+uuid.New().String()+"/tree").Code)
+}
+
+func TestHierarchyHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(HierarchyHandlerTestSuite))
+}
@@ -2,12 +2,17 @@ package handlers
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"developer-portal-backend/internal/auth"
 	apperrors "developer-portal-backend/internal/errors"
 	"developer-portal-backend/internal/service"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 )
 
 // LandscapeHandler handles HTTP requests for landscape operations
@@ -22,8 +27,7 @@ func NewLandscapeHandler(landscapeService service.LandscapeServiceInterface) *La
 	}
 }
 
-
- // ListLandscapesByQuery handles GET /landscapes?project-name=<project_name>
+// ListLandscapesByQuery handles GET /landscapes?project-name=<project_name>
 // @Summary List landscapes by project name
 // @Description Return all landscapes that belong to the specified project (unpaginated, minimal fields)
 // @Tags landscapes
@@ -55,3 +59,252 @@ func (h *LandscapeHandler) ListLandscapesByQuery(c *gin.Context) {
 
 	c.JSON(http.StatusOK, mins)
 }
+
+// SearchLandscapes handles GET /landscapes/search
+// @Summary Search landscapes
+// @Description Returns a page of the landscapes matching all given filters, ordered by name. Domains and environments may be repeated or comma-separated and match any of the values.
+// @Tags landscapes
+// @Produce json
+// @Param q query string false "Text matched against name, title and description"
+// @Param domain query []string false "Domains" collectionFormat(multi)
+// @Param environment query []string false "Environments" collectionFormat(multi)
+// @Param project-name query string false "Project name"
+// @Param central-region query bool false "Only landscapes with (true) or without (false) the central-region metadata flag"
+// @Param extension query bool false "Only landscapes with (true) or without (false) the extension metadata flag"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Number of items per page" default(20)
+// @Success 200 {object} service.LandscapeListResponse "Successfully retrieved landscapes"
+// @Failure 400 {object} map[string]interface{} "Invalid filter"
+// @Failure 404 {object} map[string]interface{} "Project not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /landscapes/search [get]
+func (h *LandscapeHandler) SearchLandscapes(c *gin.Context) {
+	query := service.LandscapeQuery{
+		Q:            c.Query("q"),
+		Domains:      queryList(c, "domain"),
+		Environments: queryList(c, "environment"),
+		ProjectName:  c.Query("project-name"),
+	}
+	var err error
+	if query.CentralRegion, err = queryBool(c, "central-region"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if query.Extension, err = queryBool(c, "extension"); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	resp, err := h.landscapeService.ListByQuery(&query, page, pageSize)
+	if err != nil {
+		writeLandscapeError(c, err, "Failed to search landscapes")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetLandscape handles GET /landscapes/:id
+// @Summary Get a landscape by ID
+// @Tags landscapes
+// @Produce json
+// @Param id path string true "Landscape ID (UUID)"
+// @Success 200 {object} service.LandscapeResponse "Successfully retrieved landscape"
+// @Failure 400 {object} map[string]interface{} "Invalid landscape ID"
+// @Failure 404 {object} map[string]interface{} "Landscape not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /landscapes/{id} [get]
+func (h *LandscapeHandler) GetLandscape(c *gin.Context) {
+	id, ok := landscapeIDParam(c)
+	if !ok {
+		return
+	}
+
+	landscape, err := h.landscapeService.GetLandscapeByID(id)
+	if err != nil {
+		writeLandscapeError(c, err, "Failed to get landscape")
+		return
+	}
+
+	setETag(c, landscape.Version)
+	c.JSON(http.StatusOK, landscape)
+}
+
+// CreateLandscape handles POST /landscapes
+// @Summary Create a landscape
+// @Description Creates a landscape with a unique name in an existing project. Its metadata is validated against the landscape metadata schemas. Requires admin privileges.
+// @Tags landscapes
+// @Accept json
+// @Produce json
+// @Param landscape body service.CreateLandscapeRequest true "Landscape data"
+// @Success 201 {object} service.LandscapeResponse "Successfully created landscape"
+// @Failure 400 {object} map[string]interface{} "Invalid request or validation failed"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 404 {object} map[string]interface{} "Project not found"
+// @Failure 409 {object} map[string]interface{} "Landscape with this name already exists"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /landscapes [post]
+func (h *LandscapeHandler) CreateLandscape(c *gin.Context) {
+	var req service.CreateLandscapeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Populate created_by from bearer token username
+	if username, ok := auth.GetUsername(c); ok && username != "" {
+		req.CreatedBy = username
+	} else {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing username in token"})
+		return
+	}
+
+	landscape, err := h.landscapeService.CreateLandscape(&req)
+	if err != nil {
+		writeLandscapeError(c, err, "Failed to create landscape")
+		return
+	}
+
+	setETag(c, landscape.Version)
+	c.JSON(http.StatusCreated, landscape)
+}
+
+// UpdateLandscape handles PUT /landscapes/:id
+// @Summary Update a landscape
+// @Description Replaces the title and description of a landscape; project, domain, environment and metadata are kept when omitted. Requires admin privileges.
+// @Tags landscapes
+// @Accept json
+// @Produce json
+// @Param id path string true "Landscape ID (UUID)"
+// @Param If-Match header string false "ETag of the landscape as last read; the update fails with 412 if it changed since"
+// @Param landscape body service.UpdateLandscapeRequest true "Landscape data"
+// @Success 200 {object} service.LandscapeResponse "Successfully updated landscape"
+// @Failure 400 {object} map[string]interface{} "Invalid request or validation failed"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 404 {object} map[string]interface{} "Landscape or project not found"
+// @Failure 412 {object} map[string]interface{} "Landscape was modified since it was read"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /landscapes/{id} [put]
+func (h *LandscapeHandler) UpdateLandscape(c *gin.Context) {
+	id, ok := landscapeIDParam(c)
+	if !ok {
+		return
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req service.UpdateLandscapeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ExpectedVersion = expectedVersion
+
+	// Populate updated_by from bearer token username
+	if username, ok := auth.GetUsername(c); ok && username != "" {
+		req.UpdatedBy = username
+	} else {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing username in token"})
+		return
+	}
+
+	landscape, err := h.landscapeService.UpdateLandscape(id, &req)
+	if err != nil {
+		writeLandscapeError(c, err, "Failed to update landscape")
+		return
+	}
+
+	setETag(c, landscape.Version)
+	c.JSON(http.StatusOK, landscape)
+}
+
+// DeleteLandscape handles DELETE /landscapes/:id
+// @Summary Delete a landscape
+// @Description Deletes a landscape. Requires admin privileges.
+// @Tags landscapes
+// @Produce json
+// @Param id path string true "Landscape ID (UUID)"
+// @Success 204 "Successfully deleted landscape"
+// @Failure 400 {object} map[string]interface{} "Invalid landscape ID"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 404 {object} map[string]interface{} "Landscape not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /landscapes/{id} [delete]
+func (h *LandscapeHandler) DeleteLandscape(c *gin.Context) {
+	id, ok := landscapeIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.landscapeService.DeleteLandscape(id); err != nil {
+		writeLandscapeError(c, err, "Failed to delete landscape")
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// landscapeIDParam parses the id path parameter, responding with 400 if it is not a UUID
+func landscapeIDParam(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid landscape ID"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// queryList returns the non-empty values of a query parameter that may be repeated or comma-separated
+func queryList(c *gin.Context, key string) []string {
+	var values []string
+	for _, raw := range c.QueryArray(key) {
+		for _, value := range strings.Split(raw, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// queryBool parses an optional boolean query parameter; it returns nil if the parameter is absent
+func queryBool(c *gin.Context, key string) (*bool, error) {
+	raw, ok := c.GetQuery(key)
+	if !ok || raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %q is not a boolean", key, raw)
+	}
+	return &value, nil
+}
+
+// writeLandscapeError maps errors of the landscape endpoints to responses
+func writeLandscapeError(c *gin.Context, err error, message string) {
+	switch {
+	case apperrors.IsValidation(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case apperrors.IsNotFound(err):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case apperrors.IsAlreadyExists(err):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, apperrors.ErrVersionConflict):
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+	}
+}
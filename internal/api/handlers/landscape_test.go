@@ -1,15 +1,20 @@
 package handlers_test
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"developer-portal-backend/internal/api/handlers"
+	apperrors "developer-portal-backend/internal/errors"
 	"developer-portal-backend/internal/mocks"
 	"developer-portal-backend/internal/service"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"go.uber.org/mock/gomock"
@@ -31,6 +36,10 @@ func (suite *LandscapeHandlerTestSuite) SetupTest() {
 	suite.mockService = mocks.NewMockLandscapeServiceInterface(suite.ctrl)
 	suite.handler = handlers.NewLandscapeHandler(suite.mockService)
 	suite.router = gin.New()
+	suite.router.Use(func(c *gin.Context) {
+		c.Set("username", "jdoe")
+		c.Next()
+	})
 	suite.setupRoutes()
 }
 
@@ -42,8 +51,23 @@ func (suite *LandscapeHandlerTestSuite) TearDownTest() {
 // setupRoutes sets up the routes for testing
 func (suite *LandscapeHandlerTestSuite) setupRoutes() {
 	suite.router.GET("/landscapes", suite.handler.ListLandscapesByQuery)
+	suite.router.GET("/landscapes/search", suite.handler.SearchLandscapes)
+	suite.router.POST("/landscapes", suite.handler.CreateLandscape)
+	suite.router.GET("/landscapes/:id", suite.handler.GetLandscape)
+	suite.router.PUT("/landscapes/:id", suite.handler.UpdateLandscape)
+	suite.router.DELETE("/landscapes/:id", suite.handler.DeleteLandscape)
 }
 
+func (suite *LandscapeHandlerTestSuite) do(method, path, body string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
 
 // TestListLandscapesByQuery tests the ListLandscapesByQuery handler
 func (suite *LandscapeHandlerTestSuite) TestListLandscapesByQuery() {
@@ -64,7 +88,7 @@ func (suite *LandscapeHandlerTestSuite) TestListLandscapesByQuery() {
 		suite.mockService.EXPECT().
 			GetByProjectNameAll("test-project").
 			Return([]service.LandscapeMinimalResponse{}, nil)
-		
+
 		req := httptest.NewRequest(http.MethodGet, "/landscapes?project-name=test-project", nil)
 		w := httptest.NewRecorder()
 
@@ -74,6 +98,97 @@ func (suite *LandscapeHandlerTestSuite) TestListLandscapesByQuery() {
 	})
 }
 
+func (suite *LandscapeHandlerTestSuite) TestSearchLandscapes() {
+	suite.mockService.EXPECT().ListByQuery(gomock.Any(), 2, 10).DoAndReturn(func(query *service.LandscapeQuery, page, pageSize int) (*service.LandscapeListResponse, error) {
+		suite.Equal("eu", query.Q)
+		suite.Equal([]string{"eu10.example.com", "eu11.example.com", "eu12.example.com"}, query.Domains)
+		suite.Equal([]string{"live"}, query.Environments)
+		suite.Equal("cis20", query.ProjectName)
+		suite.Require().NotNil(query.CentralRegion)
+		suite.True(*query.CentralRegion)
+		suite.Nil(query.Extension)
+		return &service.LandscapeListResponse{Landscapes: []service.LandscapeResponse{{Name: "eu10"}}, Total: 11, Page: page, PageSize: pageSize}, nil
+	})
+
+	w := suite.do(http.MethodGet, "/landscapes/search?q=eu&domain=eu10.example.com,eu11.example.com&domain=eu12.example.com&environment=live&project-name=cis20&central-region=true&page=2&page_size=10", "")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var body service.LandscapeListResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &body))
+	suite.Equal(int64(11), body.Total)
+	suite.Equal("eu10", body.Landscapes[0].Name)
+
+	suite.Equal(http.StatusBadRequest, suite.do(http.MethodGet, "/landscapes/search?extension=maybe", "").Code)
+
+	suite.mockService.EXPECT().ListByQuery(gomock.Any(), 1, 20).Return(nil, apperrors.ErrProjectNotFound)
+	suite.Equal(http.StatusNotFound, suite.do(http.MethodGet, "/landscapes/search?project-name=unknown", "").Code)
+}
+
+func (suite *LandscapeHandlerTestSuite) TestGetLandscape() {
+	id := uuid.New()
+	suite.mockService.EXPECT().GetLandscapeByID(id).Return(&service.LandscapeResponse{ID: id, Version: 3}, nil)
+	suite.mockService.EXPECT().GetLandscapeByID(gomock.Any()).Return(nil, apperrors.ErrLandscapeNotFound)
+
+	w := suite.do(http.MethodGet, "/landscapes/"+id.String(), "")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"3"`, w.Header().Get("ETag"))
+	suite.Equal(http.StatusNotFound, suite.do(http.MethodGet, "/landscapes/"+uuid.New().String(), "").Code)
+	suite.Equal(http.StatusBadRequest, suite.do(http.MethodGet, "/landscapes/eu10", "").Code)
+}
+
+func (suite *LandscapeHandlerTestSuite) TestCreateLandscape() {
+	projectID := uuid.New()
+	suite.mockService.EXPECT().CreateLandscape(gomock.Any()).DoAndReturn(func(req *service.CreateLandscapeRequest) (*service.LandscapeResponse, error) {
+		suite.Equal("eu10", req.Name)
+		suite.Equal(projectID, req.ProjectID)
+		suite.Equal("jdoe", req.CreatedBy)
+		return &service.LandscapeResponse{ID: uuid.New(), Name: req.Name, Version: 1}, nil
+	})
+
+	body := `{"name":"eu10","title":"EU10","project_id":"` + projectID.String() + `","domain":"eu10.example.com","environment":"live"}`
+	w := suite.do(http.MethodPost, "/landscapes", body)
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Equal(`"1"`, w.Header().Get("ETag"))
+}
+
+func (suite *LandscapeHandlerTestSuite) TestCreateLandscape_Errors() {
+	for err, status := range map[error]int{
+		apperrors.NewValidationError("", "Key: 'CreateLandscapeRequest.Domain' Error:Field validation for 'Domain' failed on the 'required' tag"): http.StatusBadRequest,
+		apperrors.ErrProjectNotFound: http.StatusNotFound,
+		apperrors.ErrLandscapeExists: http.StatusConflict,
+		errors.New("db down"):        http.StatusInternalServerError,
+	} {
+		suite.mockService.EXPECT().CreateLandscape(gomock.Any()).Return(nil, err)
+		suite.Equal(status, suite.do(http.MethodPost, "/landscapes", `{"name":"eu10"}`).Code, err.Error())
+	}
+	suite.Equal(http.StatusBadRequest, suite.do(http.MethodPost, "/landscapes", `{"name":`).Code)
+}
+
+func (suite *LandscapeHandlerTestSuite) TestUpdateLandscape() {
+	id := uuid.New()
+	suite.mockService.EXPECT().UpdateLandscape(id, gomock.Any()).DoAndReturn(func(_ uuid.UUID, req *service.UpdateLandscapeRequest) (*service.LandscapeResponse, error) {
+		suite.Equal("EU10", req.Title)
+		suite.Equal(int64(2), req.ExpectedVersion)
+		suite.Equal("jdoe", req.UpdatedBy)
+		return &service.LandscapeResponse{ID: id, Title: req.Title, Version: 3}, nil
+	})
+	suite.mockService.EXPECT().UpdateLandscape(id, gomock.Any()).Return(nil, apperrors.ErrVersionConflict)
+
+	w := suite.do(http.MethodPut, "/landscapes/"+id.String(), `{"title":"EU10"}`, "If-Match", `"2"`)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"3"`, w.Header().Get("ETag"))
+	suite.Equal(http.StatusPreconditionFailed, suite.do(http.MethodPut, "/landscapes/"+id.String(), `{"title":"EU10"}`, "If-Match", `"2"`).Code)
+	suite.Equal(http.StatusBadRequest, suite.do(http.MethodPut, "/landscapes/"+id.String(), `{"title":"EU10"}`, "If-Match", "v2").Code)
+}
+
+func (suite *LandscapeHandlerTestSuite) TestDeleteLandscape() {
+	id := uuid.New()
+	suite.mockService.EXPECT().DeleteLandscape(id).Return(apperrors.ErrLandscapeNotFound)
+	suite.mockService.EXPECT().DeleteLandscape(id).Return(nil)
+
+	suite.Equal(http.StatusNotFound, suite.do(http.MethodDelete, "/landscapes/"+id.String(), "").Code)
+	suite.Equal(http.StatusNoContent, suite.do(http.MethodDelete, "/landscapes/"+id.String(), "").Code)
+}
+
 // Run the test suite
 func TestLandscapeHandlerTestSuite(t *testing.T) {
 	suite.Run(t, new(LandscapeHandlerTestSuite))
@@ -118,6 +118,7 @@ func (h *LinkHandler) CreateLink(c *gin.Context) {
 // @Param id path string true "Link ID (UUID)"
 // @Success 204 "Successfully deleted link"
 // @Failure 400 {object} map[string]interface{} "Invalid link ID"
+// @Failure 403 {object} map[string]interface{} "Forbidden by authorization policy"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security BearerAuth
 // @Router /links/{id} [delete]
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LinkCheckHandler handles HTTP requests of the dead-link checker
+type LinkCheckHandler struct {
+	checkService service.LinkCheckServiceInterface
+}
+
+// NewLinkCheckHandler creates a new link check handler
+func NewLinkCheckHandler(checkService service.LinkCheckServiceInterface) *LinkCheckHandler {
+	return &LinkCheckHandler{
+		checkService: checkService,
+	}
+}
+
+// GetBrokenLinks handles GET /links/broken
+// @Summary List broken links
+// @Description Lists the links found broken by their latest check, grouped by the user or team owning them and by category, with the status and time of the check. Links answering 401, 403 or 429 count as working.
+// @Tags links
+// @Produce json
+// @Success 200 {object} service.BrokenLinkReport "Broken links by owner and by category"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /links/broken [get]
+func (h *LinkCheckHandler) GetBrokenLinks(c *gin.Context) {
+	report, err := h.checkService.GetBrokenLinkReport()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get broken links", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// GetLastRun handles GET /admin/link-check
+// @Summary Get the latest link check
+// @Description Returns how many links the latest completed check of this instance checked and found broken. Requires admin privileges.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} service.LinkCheckRun "Latest link check"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 404 {object} map[string]interface{} "No check has completed yet"
+// @Security BearerAuth
+// @Router /admin/link-check [get]
+func (h *LinkCheckHandler) GetLastRun(c *gin.Context) {
+	run, err := h.checkService.LastRun()
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get link check", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
+
+// StartLinkCheck handles POST /admin/link-check
+// @Summary Check all links now
+// @Description Starts checking all links in the background; GET /admin/link-check returns the outcome once done. Requires admin privileges.
+// @Tags admin
+// @Produce json
+// @Success 202 {object} map[string]interface{} "Link check started"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 409 {object} map[string]interface{} "A link check is already running"
+// @Security BearerAuth
+// @Router /admin/link-check [post]
+func (h *LinkCheckHandler) StartLinkCheck(c *gin.Context) {
+	if err := h.checkService.StartLinkCheck(); err != nil {
+		if errors.Is(err, apperrors.ErrLinkCheckRunning) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start link check", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"message": "link check started"})
+}
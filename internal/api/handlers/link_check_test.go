@@ -0,0 +1,93 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"developer-portal-backend/internal/api/handlers"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type LinkCheckHandlerTestSuite struct {
+	suite.Suite
+	ctrl      *gomock.Controller
+	mockCheck *mocks.MockLinkCheckServiceInterface
+	router    *gin.Engine
+}
+
+func (suite *LinkCheckHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockCheck = mocks.NewMockLinkCheckServiceInterface(suite.ctrl)
+	handler := handlers.NewLinkCheckHandler(suite.mockCheck)
+	suite.router = gin.New()
+	suite.router.GET("/links/broken", handler.GetBrokenLinks)
+	suite.router.GET("/admin/link-check", handler.GetLastRun)
+	suite.router.POST("/admin/link-check", handler.StartLinkCheck)
+}
+
+func (suite *LinkCheckHandlerTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *LinkCheckHandlerTestSuite) do(method, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *LinkCheckHandlerTestSuite) TestGetBrokenLinks() {
+	suite.mockCheck.EXPECT().GetBrokenLinkReport().Return(&service.BrokenLinkReport{
+		Total:   1,
+		ByOwner: []service.BrokenLinkOwner{{OwnerType: "team", OwnerName: "team-a", Links: []service.BrokenLink{{Title: "Wiki", Status: 404}}}},
+	}, nil)
+	suite.mockCheck.EXPECT().GetBrokenLinkReport().Return(nil, errors.New("connection refused"))
+
+	w := suite.do(http.MethodGet, "/links/broken")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var report service.BrokenLinkReport
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &report))
+	suite.Equal(1, report.Total)
+	suite.Equal("team-a", report.ByOwner[0].OwnerName)
+	suite.Equal(404, report.ByOwner[0].Links[0].Status)
+	suite.NotContains(w.Body.String(), "response_time_ms")
+
+	suite.Equal(http.StatusInternalServerError, suite.do(http.MethodGet, "/links/broken").Code)
+}
+
+func (suite *LinkCheckHandlerTestSuite) TestGetLastRun() {
+	suite.mockCheck.EXPECT().LastRun().Return(nil, apperrors.ErrLinkCheckRunNotFound)
+	suite.mockCheck.EXPECT().LastRun().Return(&service.LinkCheckRun{Checked: 12, Broken: 2}, nil)
+
+	suite.Equal(http.StatusNotFound, suite.do(http.MethodGet, "/admin/link-check").Code)
+
+	w := suite.do(http.MethodGet, "/admin/link-check")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), `"checked":12`)
+	suite.Contains(w.Body.String(), `"broken":2`)
+}
+
+func (suite *LinkCheckHandlerTestSuite) TestStartLinkCheck() {
+	suite.mockCheck.EXPECT().StartLinkCheck().Return(nil)
+	suite.mockCheck.EXPECT().StartLinkCheck().Return(apperrors.ErrLinkCheckRunning)
+
+	suite.Equal(http.StatusAccepted, suite.do(http.MethodPost, "/admin/link-check").Code)
+
+	w := suite.do(http.MethodPost, "/admin/link-check")
+	suite.Equal(http.StatusConflict, w.Code)
+	suite.Contains(w.Body.String(), "already running")
+}
+
+func TestLinkCheckHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(LinkCheckHandlerTestSuite))
+}
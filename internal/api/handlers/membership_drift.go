@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"net/http"
+
+	"developer-portal-backend/internal/auth"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MembershipDriftHandler handles HTTP requests comparing teams with their distribution lists
+type MembershipDriftHandler struct {
+	driftService service.MembershipDriftServiceInterface
+}
+
+// NewMembershipDriftHandler creates a new membership drift handler
+func NewMembershipDriftHandler(driftService service.MembershipDriftServiceInterface) *MembershipDriftHandler {
+	return &MembershipDriftHandler{
+		driftService: driftService,
+	}
+}
+
+// GetTeamDrift handles GET /teams/:id/membership-drift
+// @Summary Compare a team with its distribution list
+// @Description Expands the distribution list of a team in LDAP and compares it with the members of the team: list members without a portal user, list members in another team or in none, and team members missing from the list.
+// @Tags teams
+// @Produce json
+// @Param id path string true "Team ID (UUID)"
+// @Success 200 {object} service.TeamMembershipDrift "Membership drift of the team"
+// @Failure 400 {object} map[string]interface{} "Invalid team ID"
+// @Failure 404 {object} map[string]interface{} "Team or distribution list not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /teams/{id}/membership-drift [get]
+func (h *MembershipDriftHandler) GetTeamDrift(c *gin.Context) {
+	teamID, ok := teamIDParam(c)
+	if !ok {
+		return
+	}
+
+	drift, err := h.driftService.GetTeamDrift(teamID)
+	if err != nil {
+		writeMembershipDriftError(c, err, "Failed to get membership drift")
+		return
+	}
+
+	c.JSON(http.StatusOK, drift)
+}
+
+// ApplyTeamDrift handles POST /teams/:id/membership-drift/apply
+// @Summary Reconcile a team with its distribution list
+// @Description Creates the users of the distribution list missing from the portal in the team and moves the list members in other teams or in none to it. Users also in the distribution list of their current team are not moved, and team members missing from the list are kept. Requires managing the team.
+// @Tags teams
+// @Produce json
+// @Param id path string true "Team ID (UUID)"
+// @Success 200 {object} service.TeamMembershipDrift "Membership drift found and the changes applied"
+// @Failure 400 {object} map[string]interface{} "Invalid team ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Forbidden by authorization policy"
+// @Failure 404 {object} map[string]interface{} "Team or distribution list not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /teams/{id}/membership-drift/apply [post]
+func (h *MembershipDriftHandler) ApplyTeamDrift(c *gin.Context) {
+	teamID, ok := teamIDParam(c)
+	if !ok {
+		return
+	}
+
+	username, ok := auth.GetUsername(c)
+	if !ok || username == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing username in token"})
+		return
+	}
+
+	drift, err := h.driftService.ApplyTeamDrift(teamID, username)
+	if err != nil {
+		writeMembershipDriftError(c, err, "Failed to apply membership drift")
+		return
+	}
+
+	c.JSON(http.StatusOK, drift)
+}
+
+// GetDriftReport handles GET /admin/membership-drift
+// @Summary Compare all teams with their distribution lists
+// @Description Returns the membership drift of every team with a distribution list. Teams whose list could not be expanded carry an error. Requires admin privileges.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} service.MembershipDriftReport "Membership drift of all teams"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /admin/membership-drift [get]
+func (h *MembershipDriftHandler) GetDriftReport(c *gin.Context) {
+	report, err := h.driftService.GetDriftReport()
+	if err != nil {
+		writeMembershipDriftError(c, err, "Failed to get membership drift")
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// ApplyDrift handles POST /admin/membership-drift/apply
+// @Summary Reconcile all teams with their distribution lists
+// @Description Applies the membership drift of every team with a distribution list, team by team, and returns what changed. Requires admin privileges.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} service.MembershipDriftReport "Membership drift found and the changes applied"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /admin/membership-drift/apply [post]
+func (h *MembershipDriftHandler) ApplyDrift(c *gin.Context) {
+	username, ok := auth.GetUsername(c)
+	if !ok || username == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing username in token"})
+		return
+	}
+
+	report, err := h.driftService.ApplyDrift(username)
+	if err != nil {
+		writeMembershipDriftError(c, err, "Failed to apply membership drift")
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// writeMembershipDriftError maps membership drift errors to HTTP responses
+func writeMembershipDriftError(c *gin.Context, err error, message string) {
+	switch {
+	case apperrors.IsValidation(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case apperrors.IsNotFound(err):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+	}
+}
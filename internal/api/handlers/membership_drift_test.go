@@ -0,0 +1,112 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"developer-portal-backend/internal/api/handlers"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type MembershipDriftHandlerTestSuite struct {
+	suite.Suite
+	ctrl      *gomock.Controller
+	mockDrift *mocks.MockMembershipDriftServiceInterface
+	router    *gin.Engine
+}
+
+func (suite *MembershipDriftHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockDrift = mocks.NewMockMembershipDriftServiceInterface(suite.ctrl)
+	handler := handlers.NewMembershipDriftHandler(suite.mockDrift)
+	suite.router = gin.New()
+	suite.router.Use(func(c *gin.Context) {
+		if username := c.GetHeader("X-Test-Username"); username != "" {
+			c.Set("username", username)
+		}
+		c.Next()
+	})
+	suite.router.GET("/teams/:id/membership-drift", handler.GetTeamDrift)
+	suite.router.POST("/teams/:id/membership-drift/apply", handler.ApplyTeamDrift)
+	suite.router.GET("/admin/membership-drift", handler.GetDriftReport)
+	suite.router.POST("/admin/membership-drift/apply", handler.ApplyDrift)
+}
+
+func (suite *MembershipDriftHandlerTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *MembershipDriftHandlerTestSuite) do(method, path, username string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if username != "" {
+		req.Header.Set("X-Test-Username", username)
+	}
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *MembershipDriftHandlerTestSuite) TestGetTeamDrift() {
+	id := uuid.New()
+	suite.mockDrift.EXPECT().GetTeamDrift(id).Return(&service.TeamMembershipDrift{
+		TeamID:  id,
+		InSync:  3,
+		Missing: []service.DriftUser{{UserID: "I000006"}},
+	}, nil)
+	suite.mockDrift.EXPECT().GetTeamDrift(id).Return(nil, apperrors.ErrDistributionListNotFound)
+	suite.mockDrift.EXPECT().GetTeamDrift(id).Return(nil, errors.New("failed to expand distribution list: connection refused"))
+
+	w := suite.do(http.MethodGet, "/teams/"+id.String()+"/membership-drift", "")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var drift service.TeamMembershipDrift
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &drift))
+	suite.Equal(3, drift.InSync)
+	suite.Equal("I000006", drift.Missing[0].UserID)
+
+	suite.Equal(http.StatusNotFound, suite.do(http.MethodGet, "/teams/"+id.String()+"/membership-drift", "").Code)
+	suite.Equal(http.StatusInternalServerError, suite.do(http.MethodGet, "/teams/"+id.String()+"/membership-drift", "").Code)
+	suite.Equal(http.StatusBadRequest, suite.do(http.MethodGet, "/teams/team-a/membership-drift", "").Code)
+}
+
+func (suite *MembershipDriftHandlerTestSuite) TestApplyTeamDrift() {
+	id := uuid.New()
+	suite.mockDrift.EXPECT().ApplyTeamDrift(id, "I123456").Return(&service.TeamMembershipDrift{Applied: true, Moved: []string{"I000002"}}, nil)
+	suite.mockDrift.EXPECT().ApplyTeamDrift(gomock.Any(), "I123456").Return(nil, apperrors.ErrTeamNotFound)
+
+	w := suite.do(http.MethodPost, "/teams/"+id.String()+"/membership-drift/apply", "I123456")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), `"moved":["I000002"]`)
+
+	suite.Equal(http.StatusNotFound, suite.do(http.MethodPost, "/teams/"+uuid.New().String()+"/membership-drift/apply", "I123456").Code)
+	suite.Equal(http.StatusUnauthorized, suite.do(http.MethodPost, "/teams/"+id.String()+"/membership-drift/apply", "").Code)
+}
+
+func (suite *MembershipDriftHandlerTestSuite) TestDriftReport() {
+	suite.mockDrift.EXPECT().GetDriftReport().Return(&service.MembershipDriftReport{Drifted: 1, Teams: []service.TeamMembershipDrift{{TeamName: "team-a"}}}, nil)
+	suite.mockDrift.EXPECT().ApplyDrift("I123456").Return(&service.MembershipDriftReport{}, nil)
+
+	w := suite.do(http.MethodGet, "/admin/membership-drift", "")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var report service.MembershipDriftReport
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &report))
+	suite.Equal(1, report.Drifted)
+	suite.Equal("team-a", report.Teams[0].TeamName)
+
+	suite.Equal(http.StatusOK, suite.do(http.MethodPost, "/admin/membership-drift/apply", "I123456").Code)
+	suite.Equal(http.StatusUnauthorized, suite.do(http.MethodPost, "/admin/membership-drift/apply", "").Code)
+}
+
+func TestMembershipDriftHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(MembershipDriftHandlerTestSuite))
+}
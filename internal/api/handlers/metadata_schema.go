@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"developer-portal-backend/internal/metaschema"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MetadataSchemaHandler handles HTTP requests for the JSON Schemas of entity metadata
+type MetadataSchemaHandler struct {
+	schemas *metaschema.Registry
+}
+
+// NewMetadataSchemaHandler creates a new metadata schema handler
+func NewMetadataSchemaHandler(schemas *metaschema.Registry) *MetadataSchemaHandler {
+	return &MetadataSchemaHandler{
+		schemas: schemas,
+	}
+}
+
+// MetadataSchemaListResponse is the swagger schema for GET /metadata-schemas
+type MetadataSchemaListResponse struct {
+	Schemas []metaschema.SchemaSet `json:"schemas"`
+}
+
+// ListMetadataSchemas handles GET /metadata-schemas
+// @Summary List metadata schemas
+// @Description Lists the JSON Schemas the metadata of teams, users, projects, components and landscapes must satisfy, along with the additional schemas of projects for their components and landscapes
+// @Tags metadata-schemas
+// @Produce json
+// @Success 200 {object} MetadataSchemaListResponse "Successfully retrieved metadata schemas"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Security BearerAuth
+// @Router /metadata-schemas [get]
+func (h *MetadataSchemaHandler) ListMetadataSchemas(c *gin.Context) {
+	c.JSON(http.StatusOK, MetadataSchemaListResponse{Schemas: h.schemas.List()})
+}
+
+// GetMetadataSchema handles GET /metadata-schemas/:entity
+// @Summary Get the metadata schema of an entity type
+// @Description Returns the JSON Schema the metadata of an entity must satisfy. With project, the schema of the project for the entity type is included via allOf, if there is one.
+// @Tags metadata-schemas
+// @Produce json
+// @Param entity path string true "Entity type" Enums(team, user, project, component, landscape)
+// @Param project query string false "Name of the project of the entity, e.g. cis20"
+// @Success 200 {object} map[string]interface{} "JSON Schema"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 404 {object} map[string]interface{} "Unknown entity type"
+// @Security BearerAuth
+// @Router /metadata-schemas/{entity} [get]
+func (h *MetadataSchemaHandler) GetMetadataSchema(c *gin.Context) {
+	schema, err := h.schemas.Schema(metaschema.Entity(c.Param("entity")), c.Query("project"))
+	if err != nil {
+		if errors.Is(err, metaschema.ErrUnknownEntity) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to get metadata schema", "details": err.Error()})
+		return
+	}
+
+	c.Data(http.StatusOK, "application/schema+json", schema)
+}
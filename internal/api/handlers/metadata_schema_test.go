@@ -0,0 +1,72 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"developer-portal-backend/internal/api/handlers"
+	"developer-portal-backend/internal/metaschema"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+)
+
+type MetadataSchemaHandlerTestSuite struct {
+	suite.Suite
+	router *gin.Engine
+}
+
+func (suite *MetadataSchemaHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	schemas, err := metaschema.NewRegistry()
+	suite.Require().NoError(err)
+	handler := handlers.NewMetadataSchemaHandler(schemas)
+
+	suite.router = gin.New()
+	suite.router.GET("/metadata-schemas", handler.ListMetadataSchemas)
+	suite.router.GET("/metadata-schemas/:entity", handler.GetMetadataSchema)
+}
+
+func (suite *MetadataSchemaHandlerTestSuite) get(url string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+	return w
+}
+
+func (suite *MetadataSchemaHandlerTestSuite) TestListMetadataSchemas() {
+	w := suite.get("/metadata-schemas")
+	suite.Equal(http.StatusOK, w.Code)
+
+	var resp handlers.MetadataSchemaListResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &resp))
+	suite.Len(resp.Schemas, len(metaschema.Entities))
+	suite.Equal(metaschema.EntityTeam, resp.Schemas[0].Entity)
+	suite.Contains(string(resp.Schemas[0].Schema), "project-key")
+}
+
+func (suite *MetadataSchemaHandlerTestSuite) TestGetMetadataSchema() {
+	w := suite.get("/metadata-schemas/landscape?project=cis20")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("application/schema+json", w.Header().Get("Content-Type"))
+
+	var schema map[string]interface{}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &schema))
+	suite.Len(schema["allOf"], 2)
+
+	w = suite.get("/metadata-schemas/landscape")
+	suite.Equal(http.StatusOK, w.Code)
+	schema = nil
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &schema))
+	suite.Equal("Landscape metadata", schema["title"])
+}
+
+func (suite *MetadataSchemaHandlerTestSuite) TestGetMetadataSchema_UnknownEntity() {
+	w := suite.get("/metadata-schemas/organization")
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func TestMetadataSchemaHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(MetadataSchemaHandlerTestSuite))
+}
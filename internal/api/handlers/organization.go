@@ -1,3 +1,256 @@
 package handlers
 
-// Currently there are no endpoints for 'organization' model. Placeholder file for consistency.
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"developer-portal-backend/internal/auth"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// OrganizationHandler handles HTTP requests for organizations
+type OrganizationHandler struct {
+	orgService   service.OrganizationServiceInterface
+	groupService service.GroupServiceInterface
+}
+
+// NewOrganizationHandler creates a new organization handler
+func NewOrganizationHandler(orgService service.OrganizationServiceInterface, groupService service.GroupServiceInterface) *OrganizationHandler {
+	return &OrganizationHandler{
+		orgService:   orgService,
+		groupService: groupService,
+	}
+}
+
+// ListOrganizations handles GET /organizations
+// @Summary List organizations
+// @Description Returns a page of all organizations
+// @Tags organizations
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Number of items per page" default(20)
+// @Success 200 {object} service.OrganizationListResponse "Successfully retrieved organizations"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /organizations [get]
+func (h *OrganizationHandler) ListOrganizations(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	resp, err := h.orgService.GetAllOrganizations(page, pageSize)
+	if err != nil {
+		writeOrganizationError(c, err, "Failed to list organizations")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetOrganization handles GET /organizations/:id
+// @Summary Get an organization by ID
+// @Tags organizations
+// @Produce json
+// @Param id path string true "Organization ID (UUID)"
+// @Success 200 {object} service.OrganizationResponse "Successfully retrieved organization"
+// @Failure 400 {object} map[string]interface{} "Invalid organization ID"
+// @Failure 404 {object} map[string]interface{} "Organization not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /organizations/{id} [get]
+func (h *OrganizationHandler) GetOrganization(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		return
+	}
+
+	org, err := h.orgService.GetOrganizationByID(id)
+	if err != nil {
+		writeOrganizationError(c, err, "Failed to get organization")
+		return
+	}
+
+	setETag(c, org.Version)
+	c.JSON(http.StatusOK, org)
+}
+
+// CreateOrganization handles POST /organizations
+// @Summary Create an organization
+// @Description Creates an organization with a unique name. Requires admin privileges.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param organization body service.CreateOrganizationRequest true "Organization data"
+// @Success 201 {object} service.OrganizationResponse "Successfully created organization"
+// @Failure 400 {object} map[string]interface{} "Invalid request or validation failed"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 409 {object} map[string]interface{} "Organization with this name already exists"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /organizations [post]
+func (h *OrganizationHandler) CreateOrganization(c *gin.Context) {
+	var req service.CreateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Populate created_by from bearer token username
+	if username, ok := auth.GetUsername(c); ok && username != "" {
+		req.CreatedBy = username
+	} else {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing username in token"})
+		return
+	}
+
+	org, err := h.orgService.CreateOrganization(&req)
+	if err != nil {
+		writeOrganizationError(c, err, "Failed to create organization")
+		return
+	}
+
+	setETag(c, org.Version)
+	c.JSON(http.StatusCreated, org)
+}
+
+// UpdateOrganization handles PATCH /organizations/:id
+// @Summary Update an organization
+// @Description Updates the given fields of an organization; omitted fields are kept. Only the organization's owner may do this.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization ID (UUID)"
+// @Param If-Match header string false "ETag of the organization as last read; the update fails with 412 if it changed since"
+// @Param organization body service.UpdateOrganizationRequest true "Fields to update"
+// @Success 200 {object} service.OrganizationResponse "Successfully updated organization"
+// @Failure 400 {object} map[string]interface{} "Invalid request or validation failed"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Forbidden by authorization policy"
+// @Failure 404 {object} map[string]interface{} "Organization not found"
+// @Failure 412 {object} map[string]interface{} "Organization was modified since it was read"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /organizations/{id} [patch]
+func (h *OrganizationHandler) UpdateOrganization(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		return
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req service.UpdateOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ExpectedVersion = expectedVersion
+
+	// Populate updated_by from bearer token username
+	if username, ok := auth.GetUsername(c); ok && username != "" {
+		req.UpdatedBy = username
+	} else {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing username in token"})
+		return
+	}
+
+	org, err := h.orgService.UpdateOrganization(id, &req)
+	if err != nil {
+		writeOrganizationError(c, err, "Failed to update organization")
+		return
+	}
+
+	setETag(c, org.Version)
+	c.JSON(http.StatusOK, org)
+}
+
+// DeleteOrganization handles DELETE /organizations/:id
+// @Summary Delete an organization
+// @Description Deletes an organization without groups. Only the organization's owner may do this.
+// @Tags organizations
+// @Produce json
+// @Param id path string true "Organization ID (UUID)"
+// @Success 204 "Successfully deleted organization"
+// @Failure 400 {object} map[string]interface{} "Invalid organization ID"
+// @Failure 403 {object} map[string]interface{} "Forbidden by authorization policy"
+// @Failure 404 {object} map[string]interface{} "Organization not found"
+// @Failure 409 {object} map[string]interface{} "Organization still has groups"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /organizations/{id} [delete]
+func (h *OrganizationHandler) DeleteOrganization(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		return
+	}
+
+	if err := h.orgService.DeleteOrganization(id); err != nil {
+		writeOrganizationError(c, err, "Failed to delete organization")
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// ListOrganizationGroups handles GET /organizations/:id/groups
+// @Summary List the groups of an organization
+// @Description Returns a page of the groups of an organization, optionally only those whose name, title or description contains q
+// @Tags organizations
+// @Produce json
+// @Param id path string true "Organization ID (UUID)"
+// @Param q query string false "Search term"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Number of items per page" default(20)
+// @Success 200 {object} service.GroupListResponse "Successfully retrieved groups"
+// @Failure 400 {object} map[string]interface{} "Invalid organization ID"
+// @Failure 404 {object} map[string]interface{} "Organization not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /organizations/{id}/groups [get]
+func (h *OrganizationHandler) ListOrganizationGroups(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid organization ID"})
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	resp, err := h.groupService.GetGroupsByOrganization(id, c.Query("q"), page, pageSize)
+	if err != nil {
+		writeOrganizationError(c, err, "Failed to list groups")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// writeOrganizationError maps errors of the organization and group endpoints to responses
+func writeOrganizationError(c *gin.Context, err error, message string) {
+	switch {
+	case apperrors.IsValidation(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case apperrors.IsNotFound(err):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case apperrors.IsAlreadyExists(err),
+		errors.Is(err, apperrors.ErrOrganizationHasGroups),
+		errors.Is(err, apperrors.ErrGroupHasTeams):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, apperrors.ErrVersionConflict):
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+	}
+}
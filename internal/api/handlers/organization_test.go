@@ -1,7 +1,166 @@
 package handlers_test
 
-import "testing"
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
 
-func TestOrganizationHandlersPlaceholder(t *testing.T) {
-	t.Skip("Placeholder test to keep package consisted and compiling.")
+	"developer-portal-backend/internal/api/handlers"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type OrganizationHandlerTestSuite struct {
+	suite.Suite
+	ctrl      *gomock.Controller
+	mockOrg   *mocks.MockOrganizationServiceInterface
+	mockGroup *mocks.MockGroupServiceInterface
+	router    *gin.Engine
+}
+
+func (suite *OrganizationHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockOrg = mocks.NewMockOrganizationServiceInterface(suite.ctrl)
+	suite.mockGroup = mocks.NewMockGroupServiceInterface(suite.ctrl)
+	handler := handlers.NewOrganizationHandler(suite.mockOrg, suite.mockGroup)
+	suite.router = gin.New()
+	suite.router.Use(func(c *gin.Context) {
+		c.Set("username", "jdoe")
+		c.Next()
+	})
+	suite.router.GET("/organizations", handler.ListOrganizations)
+	suite.router.POST("/organizations", handler.CreateOrganization)
+	suite.router.GET("/organizations/:id", handler.GetOrganization)
+	suite.router.PATCH("/organizations/:id", handler.UpdateOrganization)
+	suite.router.DELETE("/organizations/:id", handler.DeleteOrganization)
+	suite.router.GET("/organizations/:id/groups", handler.ListOrganizationGroups)
+}
+
+func (suite *OrganizationHandlerTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *OrganizationHandlerTestSuite) do(method, path, body string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *OrganizationHandlerTestSuite) TestListOrganizations() {
+	id := uuid.New()
+	suite.mockOrg.EXPECT().GetAllOrganizations(2, 10).Return(&service.OrganizationListResponse{
+		Organizations: []service.OrganizationResponse{{ID: id, Name: "sap-cfs"}},
+		Total:         11,
+		Page:          2,
+		PageSize:      10,
+	}, nil)
+
+	w := suite.do(http.MethodGet, "/organizations?page=2&page_size=10", "")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var body service.OrganizationListResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &body))
+	suite.Equal(int64(11), body.Total)
+	suite.Equal(id, body.Organizations[0].ID)
+}
+
+func (suite *OrganizationHandlerTestSuite) TestCreateOrganization() {
+	suite.mockOrg.EXPECT().CreateOrganization(gomock.Any()).DoAndReturn(func(req *service.CreateOrganizationRequest) (*service.OrganizationResponse, error) {
+		suite.Equal("sap-cfs", req.Name)
+		suite.Equal("jdoe", req.CreatedBy)
+		return &service.OrganizationResponse{ID: uuid.New(), Name: req.Name, Version: 1}, nil
+	})
+
+	w := suite.do(http.MethodPost, "/organizations", `{"name":"sap-cfs","title":"SAP CFS","owner":"I000001","email":"cfs@sap.com"}`)
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Equal(`"1"`, w.Header().Get("ETag"))
+}
+
+func (suite *OrganizationHandlerTestSuite) TestCreateOrganization_Errors() {
+	for err, status := range map[error]int{
+		apperrors.NewValidationError("", "Owner failed on the 'min' tag"): http.StatusBadRequest,
+		apperrors.ErrOrganizationExists:                                   http.StatusConflict,
+		errors.New("db down"):                                             http.StatusInternalServerError,
+	} {
+		suite.mockOrg.EXPECT().CreateOrganization(gomock.Any()).Return(nil, err)
+		suite.Equal(status, suite.do(http.MethodPost, "/organizations", `{"name":"sap-cfs"}`).Code, err.Error())
+	}
+}
+
+func (suite *OrganizationHandlerTestSuite) TestGetOrganization() {
+	id := uuid.New()
+	suite.mockOrg.EXPECT().GetOrganizationByID(id).Return(&service.OrganizationResponse{ID: id, Version: 4}, nil)
+	suite.mockOrg.EXPECT().GetOrganizationByID(gomock.Any()).Return(nil, apperrors.ErrOrganizationNotFound)
+
+	w := suite.do(http.MethodGet, "/organizations/"+id.String(), "")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"4"`, w.Header().Get("ETag"))
+	suite.Equal(http.StatusNotFound, suite.do(http.MethodGet, "/organizations/"+uuid.New().String(), "").Code)
+	suite.Equal(http.StatusBadRequest, suite.do(http.MethodGet, "/organizations/not-a-uuid", "").Code)
+}
+
+func (suite *OrganizationHandlerTestSuite) TestUpdateOrganization() {
+	id := uuid.New()
+	suite.mockOrg.EXPECT().UpdateOrganization(id, gomock.Any()).DoAndReturn(func(_ uuid.UUID, req *service.UpdateOrganizationRequest) (*service.OrganizationResponse, error) {
+		suite.Require().NotNil(req.Title)
+		suite.Equal("New title", *req.Title)
+		suite.Nil(req.Owner)
+		suite.Equal(int64(3), req.ExpectedVersion)
+		suite.Equal("jdoe", req.UpdatedBy)
+		return &service.OrganizationResponse{ID: id, Title: *req.Title, Version: 4}, nil
+	})
+
+	w := suite.do(http.MethodPatch, "/organizations/"+id.String(), `{"title":"New title"}`, "If-Match", `"3"`)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"4"`, w.Header().Get("ETag"))
+}
+
+func (suite *OrganizationHandlerTestSuite) TestUpdateOrganization_Errors() {
+	id := uuid.New()
+	suite.Equal(http.StatusBadRequest, suite.do(http.MethodPatch, "/organizations/"+id.String(), `{}`, "If-Match", "3").Code)
+
+	suite.mockOrg.EXPECT().UpdateOrganization(id, gomock.Any()).Return(nil, apperrors.ErrVersionConflict)
+	suite.Equal(http.StatusPreconditionFailed, suite.do(http.MethodPatch, "/organizations/"+id.String(), `{}`, "If-Match", `"3"`).Code)
+}
+
+func (suite *OrganizationHandlerTestSuite) TestDeleteOrganization() {
+	id := uuid.New()
+	suite.mockOrg.EXPECT().DeleteOrganization(id).Return(nil)
+	suite.Equal(http.StatusNoContent, suite.do(http.MethodDelete, "/organizations/"+id.String(), "").Code)
+
+	suite.mockOrg.EXPECT().DeleteOrganization(id).Return(apperrors.ErrOrganizationHasGroups)
+	suite.Equal(http.StatusConflict, suite.do(http.MethodDelete, "/organizations/"+id.String(), "").Code)
+}
+
+func (suite *OrganizationHandlerTestSuite) TestListOrganizationGroups() {
+	id := uuid.New()
+	suite.mockGroup.EXPECT().GetGroupsByOrganization(id, "core", 1, 20).Return(&service.GroupListResponse{
+		Groups: []service.GroupResponse{{ID: uuid.New(), OrgID: id, Name: "core-platform"}},
+		Total:  1,
+		Page:   1,
+	}, nil)
+
+	w := suite.do(http.MethodGet, "/organizations/"+id.String()+"/groups?q=core", "")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var body service.GroupListResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &body))
+	suite.Equal("core-platform", body.Groups[0].Name)
+}
+
+func TestOrganizationHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(OrganizationHandlerTestSuite))
 }
@@ -1,3 +1,309 @@
 package handlers
 
-// Currently there are no endpoints for 'project' model. Placeholder file for consistency.
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"developer-portal-backend/internal/auth"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ProjectHandler handles HTTP requests for projects
+type ProjectHandler struct {
+	projectService service.ProjectServiceInterface
+}
+
+// NewProjectHandler creates a new project handler
+func NewProjectHandler(projectService service.ProjectServiceInterface) *ProjectHandler {
+	return &ProjectHandler{
+		projectService: projectService,
+	}
+}
+
+// ListProjects handles GET /projects
+// @Summary List projects
+// @Description Returns a page of all projects ordered by name
+// @Tags projects
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Number of items per page" default(20)
+// @Success 200 {object} service.ProjectListResponse "Successfully retrieved projects"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /projects [get]
+func (h *ProjectHandler) ListProjects(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	resp, err := h.projectService.GetAllProjects(page, pageSize)
+	if err != nil {
+		writeProjectError(c, err, "Failed to list projects")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetProject handles GET /projects/:projectId
+// @Summary Get a project by ID
+// @Tags projects
+// @Produce json
+// @Param projectId path string true "Project ID (UUID)"
+// @Success 200 {object} service.ProjectResponse "Successfully retrieved project"
+// @Failure 400 {object} map[string]interface{} "Invalid project ID"
+// @Failure 404 {object} map[string]interface{} "Project not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /projects/{projectId} [get]
+func (h *ProjectHandler) GetProject(c *gin.Context) {
+	id, ok := projectIDParam(c)
+	if !ok {
+		return
+	}
+
+	project, err := h.projectService.GetProjectByID(id)
+	if err != nil {
+		writeProjectError(c, err, "Failed to get project")
+		return
+	}
+
+	setETag(c, project.Version)
+	c.JSON(http.StatusOK, project)
+}
+
+// CreateProject handles POST /projects
+// @Summary Create a project
+// @Description Creates a project with a unique name. Its metadata is validated against the project metadata schema. Requires admin privileges.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param project body service.CreateProjectRequest true "Project data"
+// @Success 201 {object} service.ProjectResponse "Successfully created project"
+// @Failure 400 {object} map[string]interface{} "Invalid request or validation failed"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 409 {object} map[string]interface{} "Project with this name already exists"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /projects [post]
+func (h *ProjectHandler) CreateProject(c *gin.Context) {
+	var req service.CreateProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Populate created_by from bearer token username
+	if username, ok := auth.GetUsername(c); ok && username != "" {
+		req.CreatedBy = username
+	} else {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing username in token"})
+		return
+	}
+
+	project, err := h.projectService.CreateProject(&req)
+	if err != nil {
+		writeProjectError(c, err, "Failed to create project")
+		return
+	}
+
+	setETag(c, project.Version)
+	c.JSON(http.StatusCreated, project)
+}
+
+// UpdateProject handles PATCH /projects/:projectId
+// @Summary Update a project
+// @Description Updates the given fields of a project; omitted fields are kept and metadata, if given, is replaced. Requires admin privileges.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param projectId path string true "Project ID (UUID)"
+// @Param If-Match header string false "ETag of the project as last read; the update fails with 412 if it changed since"
+// @Param project body service.UpdateProjectRequest true "Fields to update"
+// @Success 200 {object} service.ProjectResponse "Successfully updated project"
+// @Failure 400 {object} map[string]interface{} "Invalid request or validation failed"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 404 {object} map[string]interface{} "Project not found"
+// @Failure 412 {object} map[string]interface{} "Project was modified since it was read"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /projects/{projectId} [patch]
+func (h *ProjectHandler) UpdateProject(c *gin.Context) {
+	id, ok := projectIDParam(c)
+	if !ok {
+		return
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req service.UpdateProjectRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ExpectedVersion = expectedVersion
+
+	// Populate updated_by from bearer token username
+	if username, ok := auth.GetUsername(c); ok && username != "" {
+		req.UpdatedBy = username
+	} else {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing username in token"})
+		return
+	}
+
+	project, err := h.projectService.UpdateProject(id, &req)
+	if err != nil {
+		writeProjectError(c, err, "Failed to update project")
+		return
+	}
+
+	setETag(c, project.Version)
+	c.JSON(http.StatusOK, project)
+}
+
+// DeleteProject handles DELETE /projects/:projectId
+// @Summary Delete a project
+// @Description Deletes a project without components or landscapes. Requires admin privileges.
+// @Tags projects
+// @Produce json
+// @Param projectId path string true "Project ID (UUID)"
+// @Success 204 "Successfully deleted project"
+// @Failure 400 {object} map[string]interface{} "Invalid project ID"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 404 {object} map[string]interface{} "Project not found"
+// @Failure 409 {object} map[string]interface{} "Project still has components or landscapes"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /projects/{projectId} [delete]
+func (h *ProjectHandler) DeleteProject(c *gin.Context) {
+	id, ok := projectIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := h.projectService.DeleteProject(id); err != nil {
+		writeProjectError(c, err, "Failed to delete project")
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// GetProjectSettings handles GET /projects/:projectId/settings
+// @Summary Get the settings of a project
+// @Description Returns the alerts repository, landscape link templates and Sonar host of a project
+// @Tags projects
+// @Produce json
+// @Param projectId path string true "Project ID (UUID)"
+// @Success 200 {object} service.ProjectSettingsResponse "Successfully retrieved project settings"
+// @Failure 400 {object} map[string]interface{} "Invalid project ID"
+// @Failure 404 {object} map[string]interface{} "Project not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /projects/{projectId}/settings [get]
+func (h *ProjectHandler) GetProjectSettings(c *gin.Context) {
+	id, ok := projectIDParam(c)
+	if !ok {
+		return
+	}
+
+	settings, err := h.projectService.GetProjectSettings(id)
+	if err != nil {
+		writeProjectError(c, err, "Failed to get project settings")
+		return
+	}
+
+	setETag(c, settings.Version)
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateProjectSettings handles PUT /projects/:projectId/settings
+// @Summary Replace the settings of a project
+// @Description Replaces the settings of a project; empty settings are removed. Landscape link templates may only use the {name}, {domain} and {environment} placeholders. Requires admin privileges.
+// @Tags projects
+// @Accept json
+// @Produce json
+// @Param projectId path string true "Project ID (UUID)"
+// @Param If-Match header string false "ETag of the project as last read; the update fails with 412 if it changed since"
+// @Param settings body service.UpdateProjectSettingsRequest true "Project settings"
+// @Success 200 {object} service.ProjectSettingsResponse "Successfully updated project settings"
+// @Failure 400 {object} map[string]interface{} "Invalid request or validation failed"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 404 {object} map[string]interface{} "Project not found"
+// @Failure 412 {object} map[string]interface{} "Project was modified since it was read"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /projects/{projectId}/settings [put]
+func (h *ProjectHandler) UpdateProjectSettings(c *gin.Context) {
+	id, ok := projectIDParam(c)
+	if !ok {
+		return
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req service.UpdateProjectSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ExpectedVersion = expectedVersion
+
+	// Populate updated_by from bearer token username
+	if username, ok := auth.GetUsername(c); ok && username != "" {
+		req.UpdatedBy = username
+	} else {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing username in token"})
+		return
+	}
+
+	settings, err := h.projectService.UpdateProjectSettings(id, &req)
+	if err != nil {
+		writeProjectError(c, err, "Failed to update project settings")
+		return
+	}
+
+	setETag(c, settings.Version)
+	c.JSON(http.StatusOK, settings)
+}
+
+// projectIDParam parses the projectId path parameter, responding with 400 if it is not a UUID
+func projectIDParam(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("projectId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid project ID"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// writeProjectError maps errors of the project endpoints to responses
+func writeProjectError(c *gin.Context, err error, message string) {
+	switch {
+	case apperrors.IsValidation(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case apperrors.IsNotFound(err):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case apperrors.IsAlreadyExists(err), errors.Is(err, apperrors.ErrProjectHasResources):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, apperrors.ErrVersionConflict):
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+	}
+}
@@ -1,7 +1,173 @@
 package handlers_test
 
-import "testing"
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
 
-func TestProjectHandlersPlaceholder(t *testing.T) {
-	t.Skip("Placeholder test to keep package consisted and compiling.")
+	"developer-portal-backend/internal/api/handlers"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type ProjectHandlerTestSuite struct {
+	suite.Suite
+	ctrl        *gomock.Controller
+	mockProject *mocks.MockProjectServiceInterface
+	router      *gin.Engine
+}
+
+func (suite *ProjectHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockProject = mocks.NewMockProjectServiceInterface(suite.ctrl)
+	handler := handlers.NewProjectHandler(suite.mockProject)
+	suite.router = gin.New()
+	suite.router.Use(func(c *gin.Context) {
+		c.Set("username", "jdoe")
+		c.Next()
+	})
+	suite.router.GET("/projects", handler.ListProjects)
+	suite.router.POST("/projects", handler.CreateProject)
+	suite.router.GET("/projects/:projectId", handler.GetProject)
+	suite.router.PATCH("/projects/:projectId", handler.UpdateProject)
+	suite.router.DELETE("/projects/:projectId", handler.DeleteProject)
+	suite.router.GET("/projects/:projectId/settings", handler.GetProjectSettings)
+	suite.router.PUT("/projects/:projectId/settings", handler.UpdateProjectSettings)
+}
+
+func (suite *ProjectHandlerTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *ProjectHandlerTestSuite) do(method, path, body string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *ProjectHandlerTestSuite) TestListProjects() {
+	id := uuid.New()
+	suite.mockProject.EXPECT().GetAllProjects(1, 20).Return(&service.ProjectListResponse{
+		Projects: []service.ProjectResponse{{ID: id, Name: "cis20"}},
+		Total:    1,
+		Page:     1,
+		PageSize: 20,
+	}, nil)
+
+	w := suite.do(http.MethodGet, "/projects", "")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var body service.ProjectListResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &body))
+	suite.Equal(id, body.Projects[0].ID)
+}
+
+func (suite *ProjectHandlerTestSuite) TestCreateProject() {
+	suite.mockProject.EXPECT().CreateProject(gomock.Any()).DoAndReturn(func(req *service.CreateProjectRequest) (*service.ProjectResponse, error) {
+		suite.Equal("neo", req.Name)
+		suite.JSONEq(`{"sonar-host":"https://sonar.example.com"}`, string(req.Metadata))
+		suite.Equal("jdoe", req.CreatedBy)
+		return &service.ProjectResponse{ID: uuid.New(), Name: req.Name, Version: 1}, nil
+	})
+
+	w := suite.do(http.MethodPost, "/projects", `{"name":"neo","title":"Neo","metadata":{"sonar-host":"https://sonar.example.com"}}`)
+	suite.Equal(http.StatusCreated, w.Code)
+	suite.Equal(`"1"`, w.Header().Get("ETag"))
+}
+
+func (suite *ProjectHandlerTestSuite) TestCreateProject_Errors() {
+	for err, status := range map[error]int{
+		apperrors.NewValidationError("metadata", "sonar-host: does not match pattern"): http.StatusBadRequest,
+		apperrors.ErrProjectExists: http.StatusConflict,
+		errors.New("db down"):      http.StatusInternalServerError,
+	} {
+		suite.mockProject.EXPECT().CreateProject(gomock.Any()).Return(nil, err)
+		suite.Equal(status, suite.do(http.MethodPost, "/projects", `{"name":"neo"}`).Code, err.Error())
+	}
+}
+
+func (suite *ProjectHandlerTestSuite) TestGetProject() {
+	id := uuid.New()
+	suite.mockProject.EXPECT().GetProjectByID(id).Return(&service.ProjectResponse{ID: id, Version: 4}, nil)
+	suite.mockProject.EXPECT().GetProjectByID(gomock.Any()).Return(nil, apperrors.ErrProjectNotFound)
+
+	w := suite.do(http.MethodGet, "/projects/"+id.String(), "")
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"4"`, w.Header().Get("ETag"))
+	suite.Equal(http.StatusNotFound, suite.do(http.MethodGet, "/projects/"+uuid.New().String(), "").Code)
+	suite.Equal(http.StatusBadRequest, suite.do(http.MethodGet, "/projects/cis20", "").Code)
+}
+
+func (suite *ProjectHandlerTestSuite) TestUpdateProject() {
+	id := uuid.New()
+	suite.mockProject.EXPECT().UpdateProject(id, gomock.Any()).DoAndReturn(func(_ uuid.UUID, req *service.UpdateProjectRequest) (*service.ProjectResponse, error) {
+		suite.Require().NotNil(req.Title)
+		suite.Equal("CIS", *req.Title)
+		suite.Equal(int64(2), req.ExpectedVersion)
+		suite.Equal("jdoe", req.UpdatedBy)
+		return &service.ProjectResponse{ID: id, Title: *req.Title, Version: 3}, nil
+	})
+	suite.mockProject.EXPECT().UpdateProject(id, gomock.Any()).Return(nil, apperrors.ErrVersionConflict)
+
+	w := suite.do(http.MethodPatch, "/projects/"+id.String(), `{"title":"CIS"}`, "If-Match", `"2"`)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"3"`, w.Header().Get("ETag"))
+	suite.Equal(http.StatusPreconditionFailed, suite.do(http.MethodPatch, "/projects/"+id.String(), `{}`, "If-Match", `"2"`).Code)
+	suite.Equal(http.StatusBadRequest, suite.do(http.MethodPatch, "/projects/"+id.String(), `{}`, "If-Match", "v2").Code)
+}
+
+func (suite *ProjectHandlerTestSuite) TestDeleteProject() {
+	id := uuid.New()
+	suite.mockProject.EXPECT().DeleteProject(id).Return(apperrors.ErrProjectHasResources)
+	suite.mockProject.EXPECT().DeleteProject(id).Return(nil)
+
+	suite.Equal(http.StatusConflict, suite.do(http.MethodDelete, "/projects/"+id.String(), "").Code)
+	suite.Equal(http.StatusNoContent, suite.do(http.MethodDelete, "/projects/"+id.String(), "").Code)
+}
+
+func (suite *ProjectHandlerTestSuite) TestGetProjectSettings() {
+	id := uuid.New()
+	suite.mockProject.EXPECT().GetProjectSettings(id).Return(&service.ProjectSettingsResponse{
+		ProjectSettings: service.ProjectSettings{SonarHost: "https://sonar.example.com"},
+		Version:         5,
+	}, nil)
+
+	w := suite.do(http.MethodGet, "/projects/"+id.String()+"/settings", "")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Equal(`"5"`, w.Header().Get("ETag"))
+	suite.JSONEq(`{"alerts_repo":"","landscape_links":null,"sonar_host":"https://sonar.example.com","version":5}`, w.Body.String())
+}
+
+func (suite *ProjectHandlerTestSuite) TestUpdateProjectSettings() {
+	id := uuid.New()
+	suite.mockProject.EXPECT().UpdateProjectSettings(id, gomock.Any()).DoAndReturn(func(_ uuid.UUID, req *service.UpdateProjectSettingsRequest) (*service.ProjectSettingsResponse, error) {
+		suite.Equal(map[string]string{"Grafana": "https://grafana.{domain}"}, req.LandscapeLinks)
+		suite.Equal(int64(5), req.ExpectedVersion)
+		suite.Equal("jdoe", req.UpdatedBy)
+		return &service.ProjectSettingsResponse{ProjectSettings: req.ProjectSettings, Version: 6}, nil
+	})
+	suite.mockProject.EXPECT().UpdateProjectSettings(id, gomock.Any()).Return(nil, apperrors.NewValidationError("landscape_links", "unknown placeholder {region}"))
+
+	w := suite.do(http.MethodPut, "/projects/"+id.String()+"/settings", `{"landscape_links":{"Grafana":"https://grafana.{domain}"}}`, "If-Match", `"5"`)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"6"`, w.Header().Get("ETag"))
+	suite.Equal(http.StatusBadRequest, suite.do(http.MethodPut, "/projects/"+id.String()+"/settings", `{"landscape_links":{"Grafana":"https://grafana.{region}"}}`).Code)
+}
+
+func TestProjectHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ProjectHandlerTestSuite))
 }
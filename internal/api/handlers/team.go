@@ -101,11 +101,13 @@ func (h *TeamHandler) GetAllTeams(c *gin.Context) {
 			"picture_url":     teamWithMembers.TeamResponse.PictureURL,
 			"created_at":      teamWithMembers.TeamResponse.CreatedAt,
 			"updated_at":      teamWithMembers.TeamResponse.UpdatedAt,
+			"version":         teamWithMembers.TeamResponse.Version,
 			"jira_team":       jiraTeam,
 			"metadata":        metadata,
 			"members":         teamWithMembers.Members,
 			"links":           teamWithMembers.Links,
 		}
+		setETag(c, teamWithMembers.TeamResponse.Version)
 		c.JSON(http.StatusOK, resp)
 		return
 	}
@@ -153,11 +155,13 @@ func (h *TeamHandler) GetAllTeams(c *gin.Context) {
 			"picture_url":     teamWithMembers.TeamResponse.PictureURL,
 			"created_at":      teamWithMembers.TeamResponse.CreatedAt,
 			"updated_at":      teamWithMembers.TeamResponse.UpdatedAt,
+			"version":         teamWithMembers.TeamResponse.Version,
 			"jira_team":       jiraTeam,
 			"metadata":        metadata,
 			"members":         teamWithMembers.Members,
 			"links":           teamWithMembers.Links,
 		}
+		setETag(c, teamWithMembers.TeamResponse.Version)
 		c.JSON(http.StatusOK, resp)
 		return
 	}
@@ -208,10 +212,13 @@ type UpdateTeamMetadataRequest struct {
 // @Accept json
 // @Produce json
 // @Param id path string true "Team ID (UUID)"
+// @Param If-Match header string false "ETag of the team as last read; the update fails with 412 if it changed since"
 // @Param request body UpdateTeamMetadataRequest true "Metadata fields to update/add"
 // @Success 200 {object} map[string]interface{} "Updated team with merged metadata"
-// @Failure 400 {object} map[string]interface{} "Invalid request"
+// @Failure 400 {object} map[string]interface{} "Invalid request or merged metadata violates the team metadata schema"
+// @Failure 403 {object} map[string]interface{} "Forbidden by authorization policy"
 // @Failure 404 {object} map[string]interface{} "Team not found"
+// @Failure 412 {object} map[string]interface{} "Team was modified since it was read"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security BearerAuth
 // @Router /teams/{id}/metadata [patch]
@@ -223,6 +230,12 @@ func (h *TeamHandler) UpdateTeamMetadata(c *gin.Context) {
 		return
 	}
 
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	var req UpdateTeamMetadataRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -237,12 +250,20 @@ func (h *TeamHandler) UpdateTeamMetadata(c *gin.Context) {
 	}
 
 	// Update team metadata via service
-	updatedTeam, err := h.teamService.UpdateTeamMetadata(teamID, metadataJSON)
+	updatedTeam, err := h.teamService.UpdateTeamMetadata(teamID, metadataJSON, expectedVersion)
 	if err != nil {
 		if errors.Is(err, apperrors.ErrTeamNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if errors.Is(err, apperrors.ErrVersionConflict) {
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+			return
+		}
+		if apperrors.IsValidation(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -253,6 +274,7 @@ func (h *TeamHandler) UpdateTeamMetadata(c *gin.Context) {
 		_ = json.Unmarshal(updatedTeam.Metadata, &metadata)
 	}
 
+	setETag(c, updatedTeam.Version)
 	c.JSON(http.StatusOK, gin.H{
 		"id":          updatedTeam.ID,
 		"name":        updatedTeam.Name,
@@ -260,5 +282,6 @@ func (h *TeamHandler) UpdateTeamMetadata(c *gin.Context) {
 		"description": updatedTeam.Description,
 		"metadata":    metadata,
 		"updated_at":  updatedTeam.UpdatedAt,
+		"version":     updatedTeam.Version,
 	})
 }
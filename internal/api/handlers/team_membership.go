@@ -0,0 +1,283 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"developer-portal-backend/internal/auth"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// TeamMembershipHandler handles HTTP requests for team members and their history
+type TeamMembershipHandler struct {
+	membershipService service.TeamMembershipServiceInterface
+}
+
+// NewTeamMembershipHandler creates a new team membership handler
+func NewTeamMembershipHandler(membershipService service.TeamMembershipServiceInterface) *TeamMembershipHandler {
+	return &TeamMembershipHandler{
+		membershipService: membershipService,
+	}
+}
+
+// ListTeamMembers handles GET /teams/:id/members
+// @Summary List the members of a team
+// @Description Returns the current members of a team, or the members at a time (at) or during a period (from, to). Dates (YYYY-MM-DD, UTC) cover the whole day; timestamps are RFC 3339. Without from, the period starts at to; without to, it ends now.
+// @Tags teams
+// @Produce json
+// @Param id path string true "Team ID (UUID)"
+// @Param at query string false "Date or timestamp"
+// @Param from query string false "Start of the period, date or timestamp"
+// @Param to query string false "End of the period, date or timestamp"
+// @Success 200 {array} service.TeamMemberResponse "Successfully retrieved team members"
+// @Failure 400 {object} map[string]interface{} "Invalid team ID or time"
+// @Failure 404 {object} map[string]interface{} "Team not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /teams/{id}/members [get]
+func (h *TeamMembershipHandler) ListTeamMembers(c *gin.Context) {
+	teamID, ok := teamIDParam(c)
+	if !ok {
+		return
+	}
+
+	from, to, err := membershipPeriod(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	members, err := h.membershipService.GetTeamMembers(teamID, from, to)
+	if err != nil {
+		writeTeamMembershipError(c, err, "Failed to list team members")
+		return
+	}
+
+	c.JSON(http.StatusOK, members)
+}
+
+// AddTeamMember handles POST /teams/:id/members
+// @Summary Add a member to a team
+// @Description Makes a user a member of the team with the given role (default member) and domain (default: unchanged). A member of another team leaves that team. Requires managing the team.
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path string true "Team ID (UUID)"
+// @Param member body service.AddTeamMemberRequest true "User and role"
+// @Success 200 {object} service.UserResponse "Successfully added team member"
+// @Failure 400 {object} map[string]interface{} "Invalid request or unknown role or domain"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Forbidden by authorization policy"
+// @Failure 404 {object} map[string]interface{} "Team or user not found"
+// @Failure 409 {object} map[string]interface{} "User is already a member of the team"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /teams/{id}/members [post]
+func (h *TeamMembershipHandler) AddTeamMember(c *gin.Context) {
+	teamID, ok := teamIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req service.AddTeamMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Populate changed_by from bearer token username
+	if username, ok := auth.GetUsername(c); ok && username != "" {
+		req.ChangedBy = username
+	} else {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing username in token"})
+		return
+	}
+
+	user, err := h.membershipService.AddTeamMember(teamID, &req)
+	if err != nil {
+		writeTeamMembershipError(c, err, "Failed to add team member")
+		return
+	}
+
+	setETag(c, user.Version)
+	c.JSON(http.StatusOK, user)
+}
+
+// UpdateTeamMember handles PATCH /teams/:id/members/:user_id
+// @Summary Change the role or domain of a team member
+// @Description Changes the given fields of a member's team role and domain, starting a new membership period. Requires managing the team.
+// @Tags teams
+// @Accept json
+// @Produce json
+// @Param id path string true "Team ID (UUID)"
+// @Param user_id path string true "User ID (I/C/D user id)"
+// @Param If-Match header string false "ETag of the user as last read; the update fails with 412 if it changed since"
+// @Param member body service.UpdateTeamMemberRequest true "Role and domain"
+// @Success 200 {object} service.UserResponse "Successfully updated team member"
+// @Failure 400 {object} map[string]interface{} "Invalid request or unknown role or domain"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Forbidden by authorization policy"
+// @Failure 404 {object} map[string]interface{} "User not found or not a member of the team"
+// @Failure 412 {object} map[string]interface{} "User was modified since it was read"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /teams/{id}/members/{user_id} [patch]
+func (h *TeamMembershipHandler) UpdateTeamMember(c *gin.Context) {
+	teamID, ok := teamIDParam(c)
+	if !ok {
+		return
+	}
+
+	expectedVersion, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var req service.UpdateTeamMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.ExpectedVersion = expectedVersion
+
+	// Populate changed_by from bearer token username
+	if username, ok := auth.GetUsername(c); ok && username != "" {
+		req.ChangedBy = username
+	} else {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing username in token"})
+		return
+	}
+
+	user, err := h.membershipService.UpdateTeamMember(teamID, c.Param("user_id"), &req)
+	if err != nil {
+		writeTeamMembershipError(c, err, "Failed to update team member")
+		return
+	}
+
+	setETag(c, user.Version)
+	c.JSON(http.StatusOK, user)
+}
+
+// RemoveTeamMember handles DELETE /teams/:id/members/:user_id
+// @Summary Remove a member from a team
+// @Description Ends a user's membership in the team, leaving them without a team. Requires managing the team.
+// @Tags teams
+// @Produce json
+// @Param id path string true "Team ID (UUID)"
+// @Param user_id path string true "User ID (I/C/D user id)"
+// @Success 204 "Successfully removed team member"
+// @Failure 400 {object} map[string]interface{} "Invalid team ID"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Forbidden by authorization policy"
+// @Failure 404 {object} map[string]interface{} "User not found or not a member of the team"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /teams/{id}/members/{user_id} [delete]
+func (h *TeamMembershipHandler) RemoveTeamMember(c *gin.Context) {
+	teamID, ok := teamIDParam(c)
+	if !ok {
+		return
+	}
+
+	username, ok := auth.GetUsername(c)
+	if !ok || username == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing username in token"})
+		return
+	}
+
+	if err := h.membershipService.RemoveTeamMember(teamID, c.Param("user_id"), username); err != nil {
+		writeTeamMembershipError(c, err, "Failed to remove team member")
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// GetMembershipHistory handles GET /users/:user_id/memberships
+// @Summary List the team memberships of a user
+// @Description Returns every period in which the user belonged to a team with a role and domain, newest first. The current membership has no ended_at.
+// @Tags users
+// @Produce json
+// @Param user_id path string true "User ID (I/C/D user id)"
+// @Success 200 {array} models.TeamMembership "Successfully retrieved team memberships"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /users/{user_id}/memberships [get]
+func (h *TeamMembershipHandler) GetMembershipHistory(c *gin.Context) {
+	memberships, err := h.membershipService.GetMembershipHistory(c.Param("user_id"))
+	if err != nil {
+		writeTeamMembershipError(c, err, "Failed to get team memberships")
+		return
+	}
+
+	c.JSON(http.StatusOK, memberships)
+}
+
+// teamIDParam parses the id path parameter, responding with 400 if it is not a UUID
+func teamIDParam(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid team ID"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// membershipPeriod reads the period of the at, from and to query parameters; it defaults to now
+func membershipPeriod(c *gin.Context) (time.Time, time.Time, error) {
+	if raw := c.Query("at"); raw != "" {
+		return parsePeriod("at", raw)
+	}
+
+	now := time.Now()
+	from, to := now, now
+	if raw := c.Query("to"); raw != "" {
+		var err error
+		if from, to, err = parsePeriod("to", raw); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	if raw := c.Query("from"); raw != "" {
+		var err error
+		if from, _, err = parsePeriod("from", raw); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	return from, to, nil
+}
+
+// parsePeriod parses a date, covering that whole day in UTC, or an RFC 3339 timestamp
+func parsePeriod(key, raw string) (time.Time, time.Time, error) {
+	if day, err := time.Parse("2006-01-02", raw); err == nil {
+		return day, day.Add(24*time.Hour - time.Nanosecond), nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid %s: %q is neither a date (YYYY-MM-DD) nor an RFC 3339 timestamp", key, raw)
+	}
+	return t, t, nil
+}
+
+// writeTeamMembershipError maps errors of the team membership endpoints to responses
+func writeTeamMembershipError(c *gin.Context, err error, message string) {
+	switch {
+	case apperrors.IsValidation(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case apperrors.IsNotFound(err):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case apperrors.IsAlreadyExists(err):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, apperrors.ErrVersionConflict):
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+	}
+}
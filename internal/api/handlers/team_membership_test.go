@@ -0,0 +1,155 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"developer-portal-backend/internal/api/handlers"
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TeamMembershipHandlerTestSuite struct {
+	suite.Suite
+	ctrl           *gomock.Controller
+	mockMembership *mocks.MockTeamMembershipServiceInterface
+	router         *gin.Engine
+	teamID         uuid.UUID
+}
+
+func (suite *TeamMembershipHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockMembership = mocks.NewMockTeamMembershipServiceInterface(suite.ctrl)
+	handler := handlers.NewTeamMembershipHandler(suite.mockMembership)
+	suite.router = gin.New()
+	suite.router.Use(func(c *gin.Context) {
+		c.Set("username", "jdoe")
+		c.Next()
+	})
+	suite.router.GET("/teams/:id/members", handler.ListTeamMembers)
+	suite.router.POST("/teams/:id/members", handler.AddTeamMember)
+	suite.router.PATCH("/teams/:id/members/:user_id", handler.UpdateTeamMember)
+	suite.router.DELETE("/teams/:id/members/:user_id", handler.RemoveTeamMember)
+	suite.router.GET("/users/:user_id/memberships", handler.GetMembershipHistory)
+	suite.teamID = uuid.New()
+}
+
+func (suite *TeamMembershipHandlerTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *TeamMembershipHandlerTestSuite) do(method, path, body string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *TeamMembershipHandlerTestSuite) TestListTeamMembers() {
+	path := "/teams/" + suite.teamID.String() + "/members"
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	suite.mockMembership.EXPECT().GetTeamMembers(suite.teamID, day, day.Add(24*time.Hour-time.Nanosecond)).
+		Return([]service.TeamMemberResponse{{UserID: "I123456", TeamRole: "member", Since: day.AddDate(0, -1, 0)}}, nil)
+
+	w := suite.do(http.MethodGet, path+"?at=2026-03-01", "")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var members []service.TeamMemberResponse
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &members))
+	suite.Equal("I123456", members[0].UserID)
+
+	// A period from a date until a timestamp
+	until := time.Date(2026, 4, 15, 12, 0, 0, 0, time.UTC)
+	suite.mockMembership.EXPECT().GetTeamMembers(suite.teamID, day, until).Return([]service.TeamMemberResponse{}, nil)
+	suite.Equal(http.StatusOK, suite.do(http.MethodGet, path+"?from=2026-03-01&to=2026-04-15T12:00:00Z", "").Code)
+
+	// Current members
+	suite.mockMembership.EXPECT().GetTeamMembers(suite.teamID, gomock.Any(), gomock.Any()).DoAndReturn(func(_ uuid.UUID, from, to time.Time) ([]service.TeamMemberResponse, error) {
+		suite.Equal(from, to)
+		suite.WithinDuration(time.Now(), from, time.Minute)
+		return nil, apperrors.ErrTeamNotFound
+	})
+	suite.Equal(http.StatusNotFound, suite.do(http.MethodGet, path, "").Code)
+
+	suite.Equal(http.StatusBadRequest, suite.do(http.MethodGet, path+"?at=yesterday", "").Code)
+	suite.Equal(http.StatusBadRequest, suite.do(http.MethodGet, "/teams/team-coe/members", "").Code)
+}
+
+func (suite *TeamMembershipHandlerTestSuite) TestAddTeamMember() {
+	suite.mockMembership.EXPECT().AddTeamMember(suite.teamID, gomock.Any()).DoAndReturn(func(_ uuid.UUID, req *service.AddTeamMemberRequest) (*service.UserResponse, error) {
+		suite.Equal("I123456", req.UserID)
+		suite.Equal(models.TeamRoleScM, req.TeamRole)
+		suite.Equal("jdoe", req.ChangedBy)
+		return &service.UserResponse{ID: req.UserID, TeamID: &suite.teamID, Version: 4}, nil
+	})
+	suite.mockMembership.EXPECT().AddTeamMember(suite.teamID, gomock.Any()).Return(nil, apperrors.ErrTeamMemberExists)
+
+	path := "/teams/" + suite.teamID.String() + "/members"
+	w := suite.do(http.MethodPost, path, `{"user_id":"I123456","team_role":"scm"}`)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"4"`, w.Header().Get("ETag"))
+	suite.Equal(http.StatusConflict, suite.do(http.MethodPost, path, `{"user_id":"I123456"}`).Code)
+	suite.Equal(http.StatusBadRequest, suite.do(http.MethodPost, path, `{}`).Code)
+}
+
+func (suite *TeamMembershipHandlerTestSuite) TestUpdateTeamMember() {
+	suite.mockMembership.EXPECT().UpdateTeamMember(suite.teamID, "I123456", gomock.Any()).DoAndReturn(func(_ uuid.UUID, _ string, req *service.UpdateTeamMemberRequest) (*service.UserResponse, error) {
+		suite.Require().NotNil(req.TeamRole)
+		suite.Equal(models.TeamRoleManager, *req.TeamRole)
+		suite.Nil(req.TeamDomain)
+		suite.Equal(int64(4), req.ExpectedVersion)
+		return &service.UserResponse{ID: "I123456", Version: 5}, nil
+	})
+	suite.mockMembership.EXPECT().UpdateTeamMember(suite.teamID, "I123456", gomock.Any()).Return(nil, apperrors.ErrVersionConflict)
+	suite.mockMembership.EXPECT().UpdateTeamMember(suite.teamID, "I654321", gomock.Any()).Return(nil, apperrors.ErrTeamMemberNotFound)
+
+	path := "/teams/" + suite.teamID.String() + "/members/"
+	w := suite.do(http.MethodPatch, path+"I123456", `{"team_role":"manager"}`, "If-Match", `"4"`)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal(`"5"`, w.Header().Get("ETag"))
+	suite.Equal(http.StatusPreconditionFailed, suite.do(http.MethodPatch, path+"I123456", `{"team_role":"manager"}`, "If-Match", `"4"`).Code)
+	suite.Equal(http.StatusNotFound, suite.do(http.MethodPatch, path+"I654321", `{"team_role":"manager"}`).Code)
+}
+
+func (suite *TeamMembershipHandlerTestSuite) TestRemoveTeamMember() {
+	suite.mockMembership.EXPECT().RemoveTeamMember(suite.teamID, "I123456", "jdoe").Return(nil)
+
+	suite.Equal(http.StatusNoContent, suite.do(http.MethodDelete, "/teams/"+suite.teamID.String()+"/members/I123456", "").Code)
+}
+
+func (suite *TeamMembershipHandlerTestSuite) TestGetMembershipHistory() {
+	ended := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	suite.mockMembership.EXPECT().GetMembershipHistory("I123456").Return([]models.TeamMembership{
+		{TeamID: uuid.New(), TeamRole: models.TeamRoleScM, StartedAt: ended},
+		{TeamID: suite.teamID, TeamRole: models.TeamRoleMember, StartedAt: ended.AddDate(-1, 0, 0), EndedAt: &ended},
+	}, nil)
+	suite.mockMembership.EXPECT().GetMembershipHistory("I000000").Return(nil, apperrors.ErrUserNotFound)
+
+	w := suite.do(http.MethodGet, "/users/I123456/memberships", "")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var memberships []models.TeamMembership
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &memberships))
+	suite.Require().Len(memberships, 2)
+	suite.Nil(memberships[0].EndedAt)
+	suite.Equal(suite.teamID, memberships[1].TeamID)
+	suite.Equal(http.StatusNotFound, suite.do(http.MethodGet, "/users/I000000/memberships", "").Code)
+}
+
+func TestTeamMembershipHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(TeamMembershipHandlerTestSuite))
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"developer-portal-backend/internal/api/handlers"
@@ -47,6 +48,7 @@ func (suite *TeamHandlerTestSuite) newRouter(withViewer bool, viewerName string)
 		})
 	}
 	r.GET("/teams", suite.handler.GetAllTeams)
+	r.PATCH("/teams/:id/metadata", suite.handler.UpdateTeamMetadata)
 	return r
 }
 
@@ -371,6 +373,85 @@ func (suite *TeamHandlerTestSuite) TestGetAllTeams_ByName_InternalError() {
 	assert.Contains(suite.T(), w.Body.String(), "db failure")
 }
 
+func (suite *TeamHandlerTestSuite) TestUpdateTeamMetadata_IfMatch_Success() {
+	router := suite.newRouter(false, "")
+	teamID := uuid.New()
+
+	suite.mockTeam.EXPECT().UpdateTeamMetadata(teamID, gomock.Any(), int64(3)).
+		Return(&service.TeamResponse{ID: teamID, Name: "alpha", Metadata: []byte(`{"color":"red"}`), Version: 4}, nil)
+
+	req := httptest.NewRequest(http.MethodPatch, "/teams/"+teamID.String()+"/metadata", strings.NewReader(`{"metadata":{"color":"red"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"3"`)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), `"4"`, w.Header().Get("ETag"))
+	assert.Contains(suite.T(), w.Body.String(), `"version":4`)
+}
+
+func (suite *TeamHandlerTestSuite) TestUpdateTeamMetadata_WithoutIfMatch() {
+	router := suite.newRouter(false, "")
+	teamID := uuid.New()
+
+	suite.mockTeam.EXPECT().UpdateTeamMetadata(teamID, gomock.Any(), int64(0)).
+		Return(&service.TeamResponse{ID: teamID, Version: 2}, nil)
+
+	req := httptest.NewRequest(http.MethodPatch, "/teams/"+teamID.String()+"/metadata", strings.NewReader(`{"metadata":{"color":"red"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), `"2"`, w.Header().Get("ETag"))
+}
+
+func (suite *TeamHandlerTestSuite) TestUpdateTeamMetadata_VersionConflict() {
+	router := suite.newRouter(false, "")
+	teamID := uuid.New()
+
+	suite.mockTeam.EXPECT().UpdateTeamMetadata(teamID, gomock.Any(), int64(3)).Return(nil, apperrors.ErrVersionConflict)
+
+	req := httptest.NewRequest(http.MethodPatch, "/teams/"+teamID.String()+"/metadata", strings.NewReader(`{"metadata":{"color":"red"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("If-Match", `"3"`)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusPreconditionFailed, w.Code)
+}
+
+func (suite *TeamHandlerTestSuite) TestUpdateTeamMetadata_InvalidMetadata() {
+	router := suite.newRouter(false, "")
+	teamID := uuid.New()
+
+	suite.mockTeam.EXPECT().UpdateTeamMetadata(teamID, gomock.Any(), int64(0)).
+		Return(nil, apperrors.NewValidationError("metadata", "jira: Additional property project_key is not allowed"))
+
+	req := httptest.NewRequest(http.MethodPatch, "/teams/"+teamID.String()+"/metadata", strings.NewReader(`{"metadata":{"jira":{"project_key":"ABC"}}}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "project_key")
+}
+
+func (suite *TeamHandlerTestSuite) TestUpdateTeamMetadata_InvalidIfMatch() {
+	router := suite.newRouter(false, "")
+
+	for _, header := range []string{"3", `W/"3"`, `"3", "4"`, `"abc"`} {
+		req := httptest.NewRequest(http.MethodPatch, "/teams/"+uuid.New().String()+"/metadata", strings.NewReader(`{"metadata":{"color":"red"}}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("If-Match", header)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(suite.T(), http.StatusBadRequest, w.Code, header)
+	}
+}
+
 func TestTeamHandlerTestSuite(t *testing.T) {
 	suite.Run(t, new(TeamHandlerTestSuite))
 }
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// TrashHandler handles HTTP requests for soft-deleted catalog entities
+type TrashHandler struct {
+	trashService service.TrashServiceInterface
+}
+
+// NewTrashHandler creates a new trash handler
+func NewTrashHandler(trashService service.TrashServiceInterface) *TrashHandler {
+	return &TrashHandler{
+		trashService: trashService,
+	}
+}
+
+// ListTrash handles GET /admin/trash
+// @Summary List deleted entities
+// @Description List soft-deleted organizations, groups, teams, users, projects, components, landscapes, categories and links, most recently deleted first. Deleted entities are purged after the configured retention period. Requires admin privileges.
+// @Tags trash
+// @Produce json
+// @Param type query string false "Only entities of this type" Enums(organization, group, team, user, project, component, landscape, category, link)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Number of items per page" default(20)
+// @Success 200 {object} service.TrashListResponse "Successfully retrieved deleted entities"
+// @Failure 400 {object} map[string]interface{} "Invalid type"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /admin/trash [get]
+func (h *TrashHandler) ListTrash(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	resp, err := h.trashService.ListTrash(c.Query("type"), page, pageSize)
+	if err != nil {
+		writeTrashError(c, err, "Failed to list deleted entities")
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RestoreTrashItem handles POST /admin/trash/:type/:id/restore
+// @Summary Restore a deleted entity
+// @Description Undelete a soft-deleted entity. Its parent, e.g. the group of a team or the category of a link, must not be deleted. Requires admin privileges.
+// @Tags trash
+// @Produce json
+// @Param type path string true "Entity type" Enums(organization, group, team, user, project, component, landscape, category, link)
+// @Param id path string true "Entity ID (UUID)"
+// @Success 204 "Successfully restored entity"
+// @Failure 400 {object} map[string]interface{} "Invalid type or ID, or the parent is deleted"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 404 {object} map[string]interface{} "Deleted entity not found"
+// @Failure 409 {object} map[string]interface{} "A live entity has the same unique values"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /admin/trash/{type}/{id}/restore [post]
+func (h *TrashHandler) RestoreTrashItem(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid ID"})
+		return
+	}
+
+	if err := h.trashService.Restore(c.Param("type"), id); err != nil {
+		writeTrashError(c, err, "Failed to restore entity")
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// writeTrashError maps errors of the trash endpoints to responses
+func writeTrashError(c *gin.Context, err error, message string) {
+	switch {
+	case apperrors.IsValidation(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case apperrors.IsNotFound(err):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case apperrors.IsAlreadyExists(err):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+	}
+}
@@ -0,0 +1,98 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"developer-portal-backend/internal/api/handlers"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/repository"
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type TrashHandlerTestSuite struct {
+	suite.Suite
+	ctrl      *gomock.Controller
+	mockTrash *mocks.MockTrashServiceInterface
+	router    *gin.Engine
+}
+
+func (suite *TrashHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockTrash = mocks.NewMockTrashServiceInterface(suite.ctrl)
+	handler := handlers.NewTrashHandler(suite.mockTrash)
+	suite.router = gin.New()
+	suite.router.GET("/admin/trash", handler.ListTrash)
+	suite.router.POST("/admin/trash/:type/:id/restore", handler.RestoreTrashItem)
+}
+
+func (suite *TrashHandlerTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *TrashHandlerTestSuite) do(method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w
+}
+
+func (suite *TrashHandlerTestSuite) TestListTrash() {
+	id := uuid.New()
+	suite.mockTrash.EXPECT().ListTrash("team", 2, 5).Return(&service.TrashListResponse{
+		Items:    []service.TrashItemResponse{{TrashItem: repository.TrashItem{Kind: repository.TrashKindTeam, ID: id, Name: "team-a"}}},
+		Total:    6,
+		Page:     2,
+		PageSize: 5,
+	}, nil)
+
+	w := suite.do(http.MethodGet, "/admin/trash?type=team&page=2&page_size=5")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var body map[string]interface{}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &body))
+	suite.Equal(float64(6), body["total"])
+	item := body["items"].([]interface{})[0].(map[string]interface{})
+	suite.Equal("team", item["type"])
+	suite.Equal(id.String(), item["id"])
+}
+
+func (suite *TrashHandlerTestSuite) TestListTrash_UnknownType() {
+	suite.mockTrash.EXPECT().ListTrash("documentation", 1, 20).Return(nil, apperrors.NewValidationError("type", "unknown type"))
+
+	suite.Equal(http.StatusBadRequest, suite.do(http.MethodGet, "/admin/trash?type=documentation").Code)
+}
+
+func (suite *TrashHandlerTestSuite) TestRestoreTrashItem() {
+	id := uuid.New()
+	suite.mockTrash.EXPECT().Restore("link", id).Return(nil)
+
+	suite.Equal(http.StatusNoContent, suite.do(http.MethodPost, "/admin/trash/link/"+id.String()+"/restore").Code)
+}
+
+func (suite *TrashHandlerTestSuite) TestRestoreTrashItem_Errors() {
+	suite.Equal(http.StatusBadRequest, suite.do(http.MethodPost, "/admin/trash/link/not-a-uuid/restore").Code)
+
+	for err, status := range map[error]int{
+		apperrors.ErrTrashItemNotFound:                      http.StatusNotFound,
+		apperrors.ErrUserExists:                             http.StatusConflict,
+		apperrors.NewValidationError("group", "is deleted"): http.StatusBadRequest,
+		errors.New("db down"):                               http.StatusInternalServerError,
+	} {
+		id := uuid.New()
+		suite.mockTrash.EXPECT().Restore("user", id).Return(err)
+		suite.Equal(status, suite.do(http.MethodPost, "/admin/trash/user/"+id.String()+"/restore").Code, err.Error())
+	}
+}
+
+func TestTrashHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(TrashHandlerTestSuite))
+}
@@ -46,12 +46,14 @@ type CreateUserBody struct {
 // @Description Create a new user row in users table
 // @Description Optional fields: team_domain (default 'developer'), team_role (default 'member')
 // @Description created_by is derived from the bearer token 'username' claim and is NOT required in the payload
+// @Description Requires admin privileges.
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param user body CreateUserBody true "User data"
 // @Success 201 {object} service.UserResponse "Successfully created user"
 // @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
 // @Security BearerAuth
 // @Router /users [post]
 func (h *UserHandler) CreateUser(c *gin.Context) {
@@ -242,7 +244,8 @@ type UpdateUserTeamBody struct {
 
 // UpdateUserTeam handles PUT /users
 // @Summary Update user's team
-// @Description Update the user's team by UUID. Sets updated_by from token; updated_at is automatic.
+// @Description Update the user's team by UUID. Sets updated_by from token; updated_at is automatic. Requires managing both the user's current team and the new team.
+// @Description Deprecated: add the user to the new team with POST /teams/{id}/members instead.
 // @Tags users
 // @Accept json
 // @Produce json
@@ -250,8 +253,10 @@ type UpdateUserTeamBody struct {
 // @Success 200 {object} service.UserResponse "Successfully updated user's team"
 // @Failure 400 {object} map[string]interface{} "Invalid request body or UUIDs"
 // @Failure 401 {object} map[string]interface{} "Missing username in token"
-// @Failure 404 {object} map[string]interface{} "User not found"
+// @Failure 403 {object} map[string]interface{} "Not a manager of both teams"
+// @Failure 404 {object} map[string]interface{} "User or team not found"
 // @Security BearerAuth
+// @Deprecated
 // @Router /users [put]
 func (h *UserHandler) UpdateUserTeam(c *gin.Context) {
 	var body UpdateUserTeamBody
@@ -306,8 +311,10 @@ func (h *UserHandler) UpdateUserTeam(c *gin.Context) {
 // @Param user_id path string true "User ID (I/C/D user id, e.g. cis.devops)"
 // @Param link_id path string true "Link ID (UUID)"
 // @Success 200 {object} service.UserResponse "Successfully added favorite link"
-// @Failure 400 {object} map[string]interface{} "Invalid user_id or link_id"
+// @Failure 400 {object} map[string]interface{} "Invalid user_id or link_id, or metadata violating the user schema"
+// @Failure 403 {object} map[string]interface{} "Forbidden by authorization policy"
 // @Failure 404 {object} map[string]interface{} "User not found"
+// @Failure 409 {object} map[string]interface{} "User kept being modified concurrently"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security BearerAuth
 // @Router /users/{user_id}/favorites/{link_id} [post]
@@ -331,10 +338,19 @@ func (h *UserHandler) AddFavoriteLink(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if errors.Is(err, apperrors.ErrVersionConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if apperrors.IsValidation(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to add favorite", "details": err.Error()})
 		return
 	}
 
+	setETag(c, user.Version)
 	c.JSON(http.StatusOK, user)
 }
 
@@ -347,8 +363,10 @@ func (h *UserHandler) AddFavoriteLink(c *gin.Context) {
 // @Param user_id path string true "User ID (I/C/D user id, e.g. cis.devops)"
 // @Param link_id path string true "Link ID (UUID)"
 // @Success 200 {object} service.UserResponse "Successfully removed favorite link"
-// @Failure 400 {object} map[string]interface{} "Invalid user_id or link_id"
+// @Failure 400 {object} map[string]interface{} "Invalid user_id or link_id, or metadata violating the user schema"
+// @Failure 403 {object} map[string]interface{} "Forbidden by authorization policy"
 // @Failure 404 {object} map[string]interface{} "User not found"
+// @Failure 409 {object} map[string]interface{} "User kept being modified concurrently"
 // @Failure 500 {object} map[string]interface{} "Internal server error"
 // @Security BearerAuth
 // @Router /users/{user_id}/favorites/{link_id} [delete]
@@ -372,9 +390,18 @@ func (h *UserHandler) RemoveFavoriteLink(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
 			return
 		}
+		if errors.Is(err, apperrors.ErrVersionConflict) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if apperrors.IsValidation(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to remove favorite", "details": err.Error()})
 		return
 	}
 
+	setETag(c, user.Version)
 	c.JSON(http.StatusOK, user)
 }
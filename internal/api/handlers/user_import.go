@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"developer-portal-backend/internal/auth"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserImportHandler handles HTTP requests for bulk user imports
+type UserImportHandler struct {
+	importService service.UserImportServiceInterface
+}
+
+// NewUserImportHandler creates a new user import handler
+func NewUserImportHandler(importService service.UserImportServiceInterface) *UserImportHandler {
+	return &UserImportHandler{
+		importService: importService,
+	}
+}
+
+// ImportUsersBody is the JSON payload of an import: rows, or LDAP DNs that all join the same team
+type ImportUsersBody struct {
+	Rows   []service.UserImportRow `json:"rows"`
+	DNs    []string                `json:"dns" example:"CN=I123456,OU=I,DC=example,DC=com"`
+	Team   string                  `json:"team" example:"team-a"` // team name or ID for the dns
+	Role   string                  `json:"role" example:"member"`
+	Domain string                  `json:"domain" example:"developer"`
+}
+
+// ImportUsers handles POST /users/import
+// @Summary Import users in bulk
+// @Description Creates users from a CSV (columns user_id or dn, team, role, domain; as text/csv body or as multipart file "file") or from a JSON body with rows or with dns and the team they join. Names, emails and mobiles are taken from LDAP. All rows are validated up front and the result lists the outcome of each row.
+// @Description With dry_run nobody is created. With atomic the users are created in one transaction, and none of them if any row is invalid; the response is then 422. Requires admin privileges.
+// @Tags users
+// @Accept json
+// @Accept text/csv
+// @Accept multipart/form-data
+// @Produce json
+// @Param dry_run query bool false "Only validate the rows"
+// @Param atomic query bool false "Create all users or none"
+// @Param body body ImportUsersBody false "Rows or DNs to import"
+// @Param file formData file false "CSV to import"
+// @Success 200 {object} service.UserImportResult "Outcome of every row"
+// @Failure 400 {object} map[string]interface{} "Invalid CSV or request"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 422 {object} service.UserImportResult "Atomic import created nobody"
+// @Failure 500 {object} map[string]interface{} "LDAP or database failure"
+// @Security BearerAuth
+// @Router /users/import [post]
+func (h *UserImportHandler) ImportUsers(c *gin.Context) {
+	username, ok := auth.GetUsername(c)
+	if !ok || username == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing username in token"})
+		return
+	}
+
+	dryRun, err := queryBool(c, "dry_run")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	atomic, err := queryBool(c, "atomic")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rows, err := importRows(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req := service.UserImportRequest{
+		Rows:      rows,
+		DryRun:    dryRun != nil && *dryRun,
+		Atomic:    atomic != nil && *atomic,
+		CreatedBy: username,
+	}
+
+	result, err := h.importService.ImportUsers(&req)
+	if err != nil {
+		if apperrors.IsValidation(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to import users", "details": err.Error()})
+		return
+	}
+
+	if req.Atomic && !req.DryRun && result.Created == 0 {
+		c.JSON(http.StatusUnprocessableEntity, result)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// importRows reads the rows of an import from a CSV upload, a CSV body or a JSON body
+func importRows(c *gin.Context) ([]service.UserImportRow, error) {
+	switch c.ContentType() {
+	case "multipart/form-data":
+		header, err := c.FormFile("file")
+		if err != nil {
+			return nil, err
+		}
+		file, err := header.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return service.ParseUserImportCSV(file)
+	case "text/csv":
+		return service.ParseUserImportCSV(c.Request.Body)
+	}
+
+	var body ImportUsersBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, apperrors.NewValidationError("body", "rows or dns are required")
+		}
+		return nil, err
+	}
+	rows := body.Rows
+	for _, dn := range body.DNs {
+		rows = append(rows, service.UserImportRow{
+			DN:     strings.TrimSpace(dn),
+			Team:   body.Team,
+			Role:   body.Role,
+			Domain: body.Domain,
+		})
+	}
+	return rows, nil
+}
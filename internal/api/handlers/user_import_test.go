@@ -0,0 +1,118 @@
+package handlers_test
+
+import (
+	"bytes"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"developer-portal-backend/internal/api/handlers"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type UserImportHandlerTestSuite struct {
+	suite.Suite
+	ctrl       *gomock.Controller
+	mockImport *mocks.MockUserImportServiceInterface
+	router     *gin.Engine
+}
+
+func (suite *UserImportHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockImport = mocks.NewMockUserImportServiceInterface(suite.ctrl)
+	handler := handlers.NewUserImportHandler(suite.mockImport)
+	suite.router = gin.New()
+	suite.router.Use(func(c *gin.Context) {
+		if c.GetHeader("X-Anonymous") == "" {
+			c.Set("username", "I999999")
+		}
+		c.Next()
+	})
+	suite.router.POST("/users/import", handler.ImportUsers)
+}
+
+func (suite *UserImportHandlerTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *UserImportHandlerTestSuite) post(query, contentType string, body *bytes.Buffer) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/users/import"+query, body)
+	req.Header.Set("Content-Type", contentType)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *UserImportHandlerTestSuite) TestImportUsers_CSV() {
+	suite.mockImport.EXPECT().ImportUsers(&service.UserImportRequest{
+		Rows:      []service.UserImportRow{{UserID: "I000001", Team: "team-a", Role: "scm"}},
+		DryRun:    true,
+		CreatedBy: "I999999",
+	}).Return(&service.UserImportResult{DryRun: true, Valid: 1}, nil).Times(2)
+
+	w := suite.post("?dry_run=true", "text/csv", bytes.NewBufferString("user_id,team,role\nI000001,team-a,scm\n"))
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), `"valid":1`)
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	file, err := form.CreateFormFile("file", "team-a.csv")
+	suite.Require().NoError(err)
+	_, _ = file.Write([]byte("user_id,team,role\nI000001,team-a,scm\n"))
+	suite.Require().NoError(form.Close())
+	suite.Equal(http.StatusOK, suite.post("?dry_run=true", form.FormDataContentType(), &body).Code)
+
+	w = suite.post("", "text/csv", bytes.NewBufferString("user_id,email\n"))
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "unknown column")
+}
+
+func (suite *UserImportHandlerTestSuite) TestImportUsers_DNs() {
+	suite.mockImport.EXPECT().ImportUsers(&service.UserImportRequest{
+		Rows: []service.UserImportRow{
+			{UserID: "I000001", Team: "team-a"},
+			{DN: "CN=I000002,OU=I,DC=example,DC=com", Team: "team-b", Domain: "devops"},
+		},
+		Atomic:    true,
+		CreatedBy: "I999999",
+	}).Return(&service.UserImportResult{Atomic: true, Created: 2}, nil)
+
+	w := suite.post("?atomic=true", "application/json", bytes.NewBufferString(
+		`{"rows":[{"user_id":"I000001","team":"team-a"}],"dns":[" CN=I000002,OU=I,DC=example,DC=com"],"team":"team-b","domain":"devops"}`))
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *UserImportHandlerTestSuite) TestImportUsers_Errors() {
+	suite.mockImport.EXPECT().ImportUsers(gomock.Any()).Return(&service.UserImportResult{Atomic: true, Valid: 1}, nil)
+	suite.mockImport.EXPECT().ImportUsers(gomock.Any()).Return(nil, apperrors.NewValidationError("rows", "at least one row is required"))
+	suite.mockImport.EXPECT().ImportUsers(gomock.Any()).Return(nil, errors.New("failed to look up users in LDAP: connection refused"))
+
+	rows := func() *bytes.Buffer { return bytes.NewBufferString(`{"rows":[{"user_id":"I000001","team":"team-a"}]}`) }
+	suite.Equal(http.StatusUnprocessableEntity, suite.post("?atomic=true", "application/json", rows()).Code)
+	suite.Equal(http.StatusBadRequest, suite.post("", "application/json", bytes.NewBufferString(`{}`)).Code)
+	w := suite.post("", "application/json", rows())
+	suite.Equal(http.StatusInternalServerError, w.Code)
+	suite.Contains(w.Body.String(), "connection refused")
+
+	suite.Equal(http.StatusBadRequest, suite.post("?dry_run=maybe", "application/json", rows()).Code)
+	suite.Equal(http.StatusBadRequest, suite.post("", "application/json", bytes.NewBufferString("")).Code)
+
+	req := httptest.NewRequest(http.MethodPost, "/users/import", rows())
+	req.Header.Set("X-Anonymous", "true")
+	w = httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Equal(http.StatusUnauthorized, w.Code)
+}
+
+func TestUserImportHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(UserImportHandlerTestSuite))
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"developer-portal-backend/internal/auth"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UserOffboardingHandler handles HTTP requests for offboarding users
+type UserOffboardingHandler struct {
+	offboardingService service.UserOffboardingServiceInterface
+}
+
+// NewUserOffboardingHandler creates a new user offboarding handler
+func NewUserOffboardingHandler(offboardingService service.UserOffboardingServiceInterface) *UserOffboardingHandler {
+	return &UserOffboardingHandler{
+		offboardingService: offboardingService,
+	}
+}
+
+// PreviewOffboarding handles GET /admin/users/:user_id/offboarding
+// @Summary Preview the offboarding of a user
+// @Description Lists the links, teams, groups and organizations owned by the user, and how many users have one of the links among their favorites. Requires admin privileges.
+// @Tags admin
+// @Produce json
+// @Param user_id path string true "User ID (I/C/D user)"
+// @Success 200 {object} service.OffboardingPreview "Resources owned by the user"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /admin/users/{user_id}/offboarding [get]
+func (h *UserOffboardingHandler) PreviewOffboarding(c *gin.Context) {
+	preview, err := h.offboardingService.PreviewOffboarding(c.Param("user_id"))
+	if err != nil {
+		writeOffboardingError(c, err, "Failed to preview offboarding")
+		return
+	}
+
+	c.JSON(http.StatusOK, preview)
+}
+
+// OffboardUser handles POST /admin/users/:user_id/offboarding
+// @Summary Offboard a user
+// @Description Hands the links, teams, groups and organizations owned by the user over to new owners, then deletes the user and ends their team membership, in one transaction. Every kind of resource the user owns needs a new owner; links can move to a user or a team, or be deleted and removed from all favorites. The offboarding is recorded in the history. Requires admin privileges.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param user_id path string true "User ID (I/C/D user)"
+// @Param request body service.OffboardUserRequest false "New owners of the resources"
+// @Success 200 {object} models.UserOffboarding "Offboarding record"
+// @Failure 400 {object} map[string]interface{} "Invalid request or resources without a new owner"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 404 {object} map[string]interface{} "User or team not found"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /admin/users/{user_id}/offboarding [post]
+func (h *UserOffboardingHandler) OffboardUser(c *gin.Context) {
+	username, ok := auth.GetUsername(c)
+	if !ok || username == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing username in token"})
+		return
+	}
+
+	// Users owning nothing need no body
+	var req service.OffboardUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.OffboardedBy = username
+
+	record, err := h.offboardingService.OffboardUser(c.Param("user_id"), &req)
+	if err != nil {
+		writeOffboardingError(c, err, "Failed to offboard user")
+		return
+	}
+
+	c.JSON(http.StatusOK, record)
+}
+
+// GetOffboardingHistory handles GET /admin/offboardings
+// @Summary List user offboardings
+// @Description Returns the offboardings of users, newest first, with who took over their resources. Requires admin privileges.
+// @Tags admin
+// @Produce json
+// @Param limit query int false "Maximum number of offboardings"
+// @Success 200 {array} models.UserOffboarding "Offboarding records"
+// @Failure 400 {object} map[string]interface{} "Invalid limit"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 500 {object} map[string]interface{} "Internal server error"
+// @Security BearerAuth
+// @Router /admin/offboardings [get]
+func (h *UserOffboardingHandler) GetOffboardingHistory(c *gin.Context) {
+	limit := 0
+	if value := c.Query("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter"})
+			return
+		}
+		limit = parsed
+	}
+
+	records, err := h.offboardingService.GetOffboardingHistory(limit)
+	if err != nil {
+		writeOffboardingError(c, err, "Failed to get offboarding history")
+		return
+	}
+
+	c.JSON(http.StatusOK, records)
+}
+
+// writeOffboardingError maps user offboarding errors to HTTP responses
+func writeOffboardingError(c *gin.Context, err error, message string) {
+	switch {
+	case apperrors.IsValidation(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case apperrors.IsNotFound(err):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message, "details": err.Error()})
+	}
+}
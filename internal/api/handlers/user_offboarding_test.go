@@ -0,0 +1,122 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"developer-portal-backend/internal/api/handlers"
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type UserOffboardingHandlerTestSuite struct {
+	suite.Suite
+	ctrl            *gomock.Controller
+	mockOffboarding *mocks.MockUserOffboardingServiceInterface
+	router          *gin.Engine
+}
+
+func (suite *UserOffboardingHandlerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockOffboarding = mocks.NewMockUserOffboardingServiceInterface(suite.ctrl)
+	handler := handlers.NewUserOffboardingHandler(suite.mockOffboarding)
+	suite.router = gin.New()
+	suite.router.Use(func(c *gin.Context) {
+		if username := c.GetHeader("X-Test-Username"); username != "" {
+			c.Set("username", username)
+		}
+		c.Next()
+	})
+	suite.router.GET("/admin/users/:user_id/offboarding", handler.PreviewOffboarding)
+	suite.router.POST("/admin/users/:user_id/offboarding", handler.OffboardUser)
+	suite.router.GET("/admin/offboardings", handler.GetOffboardingHistory)
+}
+
+func (suite *UserOffboardingHandlerTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *UserOffboardingHandlerTestSuite) do(method, path, username, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if username != "" {
+		req.Header.Set("X-Test-Username", username)
+	}
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *UserOffboardingHandlerTestSuite) TestPreviewOffboarding() {
+	suite.mockOffboarding.EXPECT().PreviewOffboarding("I000001").Return(&service.OffboardingPreview{
+		UserID:      "I000001",
+		Teams:       []service.OwnedResource{{Name: "team-a"}},
+		FavoritedBy: 2,
+	}, nil)
+	suite.mockOffboarding.EXPECT().PreviewOffboarding("I000009").Return(nil, apperrors.ErrUserNotFound)
+
+	w := suite.do(http.MethodGet, "/admin/users/I000001/offboarding", "", "")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	var preview service.OffboardingPreview
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &preview))
+	suite.Equal("team-a", preview.Teams[0].Name)
+	suite.Equal(int64(2), preview.FavoritedBy)
+
+	suite.Equal(http.StatusNotFound, suite.do(http.MethodGet, "/admin/users/I000009/offboarding", "", "").Code)
+}
+
+func (suite *UserOffboardingHandlerTestSuite) TestOffboardUser() {
+	suite.mockOffboarding.EXPECT().OffboardUser("I000001", &service.OffboardUserRequest{
+		LinkTeam:     "team-a",
+		TeamOwner:    "I000002",
+		Reason:       "left the company",
+		OffboardedBy: "I999999",
+	}).Return(&models.UserOffboarding{Username: "I000001", TeamCount: 1, TeamOwner: "I000002"}, nil)
+	suite.mockOffboarding.EXPECT().OffboardUser("I000003", &service.OffboardUserRequest{OffboardedBy: "I999999"}).
+		Return(&models.UserOffboarding{Username: "I000003"}, nil)
+	suite.mockOffboarding.EXPECT().OffboardUser("I000001", gomock.Any()).
+		Return(nil, apperrors.NewValidationError("team_owner", "user owns 1 teams; team_owner is required"))
+	suite.mockOffboarding.EXPECT().OffboardUser("I000001", gomock.Any()).Return(nil, errors.New("connection refused"))
+
+	w := suite.do(http.MethodPost, "/admin/users/I000001/offboarding", "I999999",
+		`{"link_team":"team-a","team_owner":"I000002","reason":"left the company"}`)
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), `"team_owner":"I000002"`)
+
+	suite.Equal(http.StatusOK, suite.do(http.MethodPost, "/admin/users/I000003/offboarding", "I999999", "").Code)
+
+	w = suite.do(http.MethodPost, "/admin/users/I000001/offboarding", "I999999", `{}`)
+	suite.Equal(http.StatusBadRequest, w.Code)
+	suite.Contains(w.Body.String(), "team_owner is required")
+	suite.Equal(http.StatusInternalServerError, suite.do(http.MethodPost, "/admin/users/I000001/offboarding", "I999999", `{}`).Code)
+
+	suite.Equal(http.StatusBadRequest, suite.do(http.MethodPost, "/admin/users/I000001/offboarding", "I999999", `{"delete_links":"yes"}`).Code)
+	suite.Equal(http.StatusUnauthorized, suite.do(http.MethodPost, "/admin/users/I000001/offboarding", "", `{}`).Code)
+}
+
+func (suite *UserOffboardingHandlerTestSuite) TestGetOffboardingHistory() {
+	suite.mockOffboarding.EXPECT().GetOffboardingHistory(0).Return([]models.UserOffboarding{{Username: "I000001"}}, nil)
+	suite.mockOffboarding.EXPECT().GetOffboardingHistory(5).Return([]models.UserOffboarding{}, nil)
+
+	w := suite.do(http.MethodGet, "/admin/offboardings", "", "")
+	suite.Require().Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), `"user_id":"I000001"`)
+
+	suite.Equal(http.StatusOK, suite.do(http.MethodGet, "/admin/offboardings?limit=5", "", "").Code)
+	suite.Equal(http.StatusBadRequest, suite.do(http.MethodGet, "/admin/offboardings?limit=-1", "", "").Code)
+}
+
+func TestUserOffboardingHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(UserOffboardingHandlerTestSuite))
+}
@@ -10,6 +10,7 @@ import (
 
 	"developer-portal-backend/internal/api/handlers"
 	"developer-portal-backend/internal/database/models"
+	"developer-portal-backend/internal/metaschema"
 	"developer-portal-backend/internal/mocks"
 	"developer-portal-backend/internal/service"
 
@@ -75,7 +76,9 @@ func (suite *UserHandlerTestSuite) SetupTest() {
 	suite.mockTeamRepo = mocks.NewMockTeamRepositoryInterface(suite.ctrl)
 
 	v := validator.New()
-	suite.userService = service.NewUserService(suite.mockUserRepo, suite.mockLinkRepo, v)
+	schemas, err := metaschema.NewRegistry()
+	suite.Require().NoError(err)
+	suite.userService = service.NewUserService(suite.mockUserRepo, suite.mockLinkRepo, v, schemas)
 suite.handler = handlers.NewUserHandler(suite.userService, &teamRepoAdapter{inner: suite.mockTeamRepo})
 }
 
@@ -539,6 +542,23 @@ func (suite *UserHandlerTestSuite) TestAddFavoriteLink_InvalidLinkID() {
 	assert.Contains(suite.T(), w.Body.String(), "invalid link_id")
 }
 
+func (suite *UserHandlerTestSuite) TestAddFavoriteLink_InvalidMetadata() {
+	router := suite.newRouter(false, "")
+	linkID := uuid.New()
+
+	suite.mockUserRepo.EXPECT().GetByUserID("iuser-1").Return(&models.User{
+		UserID:   "iuser-1",
+		Metadata: json.RawMessage(`{"ai_core_member_of":42}`),
+	}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/users/iuser-1/favorites/"+linkID.String(), nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), "ai_core_member_of")
+}
+
 func (suite *UserHandlerTestSuite) TestAddFavoriteLink_UserNotFound() {
 	router := suite.newRouter(false, "")
 	linkID := uuid.New()
@@ -0,0 +1,329 @@
+package middleware_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"developer-portal-backend/internal/api/middleware"
+	"developer-portal-backend/internal/auth"
+	"developer-portal-backend/internal/database/models"
+	"developer-portal-backend/internal/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+// staticAdmins treats the listed usernames as admins
+type staticAdmins []string
+
+func (a staticAdmins) IsAdmin(claims *auth.AuthClaims) bool {
+	for _, name := range a {
+		if claims.Username == name {
+			return true
+		}
+	}
+	return false
+}
+
+type AuthorizationTestSuite struct {
+	suite.Suite
+	ctrl       *gomock.Controller
+	users      *mocks.MockUserRepositoryInterface
+	teams      *mocks.MockTeamRepositoryInterface
+	groups     *mocks.MockGroupRepositoryInterface
+	orgs       *mocks.MockOrganizationRepositoryInterface
+	docs       *mocks.MockDocumentationRepositoryInterface
+	links      *mocks.MockLinkRepositoryInterface
+	components *mocks.MockComponentRepositoryInterface
+	authz      *middleware.Authorizer
+	team       *models.Team
+	group      *models.Group
+	org        *models.Organization
+	otherTeam  uuid.UUID
+}
+
+func (suite *AuthorizationTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.users = mocks.NewMockUserRepositoryInterface(suite.ctrl)
+	suite.teams = mocks.NewMockTeamRepositoryInterface(suite.ctrl)
+	suite.groups = mocks.NewMockGroupRepositoryInterface(suite.ctrl)
+	suite.orgs = mocks.NewMockOrganizationRepositoryInterface(suite.ctrl)
+	suite.docs = mocks.NewMockDocumentationRepositoryInterface(suite.ctrl)
+	suite.links = mocks.NewMockLinkRepositoryInterface(suite.ctrl)
+	suite.components = mocks.NewMockComponentRepositoryInterface(suite.ctrl)
+	suite.authz = middleware.NewAuthorizer(suite.users, suite.teams, suite.groups, suite.orgs, suite.docs, suite.links, suite.components, staticAdmins{"admin"})
+
+	suite.org = &models.Organization{BaseModel: models.BaseModel{ID: uuid.New()}, Owner: "I000001"}
+	suite.group = &models.Group{BaseModel: models.BaseModel{ID: uuid.New()}, OrgID: suite.org.ID, Owner: "I000002"}
+	suite.team = &models.Team{BaseModel: models.BaseModel{ID: uuid.New()}, GroupID: suite.group.ID, Owner: "I000003"}
+	suite.otherTeam = uuid.New()
+
+	suite.teams.EXPECT().GetByID(suite.team.ID).Return(suite.team, nil).AnyTimes()
+	suite.teams.EXPECT().GetByID(gomock.Any()).Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+	suite.groups.EXPECT().GetByID(suite.group.ID).Return(suite.group, nil).AnyTimes()
+	suite.orgs.EXPECT().GetByID(suite.org.ID).Return(suite.org, nil).AnyTimes()
+}
+
+func (suite *AuthorizationTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+// withUser makes the caller resolve to a users row with the given team and role
+func (suite *AuthorizationTestSuite) withUser(name, userID string, teamID *uuid.UUID, role models.TeamRole) *models.User {
+	user := &models.User{BaseModel: models.BaseModel{ID: uuid.New(), Name: name}, UserID: userID, TeamID: teamID, TeamRole: role}
+	suite.users.EXPECT().GetByName(name).Return(user, nil).AnyTimes()
+	return user
+}
+
+// serve runs a request through an authenticated router with the given policy on the route
+func (suite *AuthorizationTestSuite) serve(username, method, route, path, body string, policy middleware.Policy) *httptest.ResponseRecorder {
+	claims := &auth.AuthClaims{Username: username, Email: username + "@example.com", Provider: "githubtools", EmailVerified: true}
+	return suite.serveAs(claims, method, route, path, body, policy)
+}
+
+// serveAs is serve for a caller with the given claims
+func (suite *AuthorizationTestSuite) serveAs(claims *auth.AuthClaims, method, route, path, body string, policy middleware.Policy) *httptest.ResponseRecorder {
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("auth_claims", claims)
+		c.Next()
+	})
+	r.Handle(method, route, suite.authz.Require(policy), func(c *gin.Context) {
+		// The handler must still be able to read the body
+		var payload map[string]interface{}
+		if body != "" {
+			suite.Require().NoError(c.ShouldBindJSON(&payload))
+		}
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *AuthorizationTestSuite) assertForbidden(w *httptest.ResponseRecorder) {
+	suite.Equal(http.StatusForbidden, w.Code)
+	var response map[string]interface{}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &response))
+	suite.Equal("Forbidden", response["error"])
+	suite.NotEmpty(response["details"])
+}
+
+func (suite *AuthorizationTestSuite) TestTeamManager() {
+	policy := middleware.TeamManager(middleware.TeamFromParam("id"))
+	path := "/teams/" + suite.team.ID.String() + "/metadata"
+
+	suite.withUser("manager", "I100001", &suite.team.ID, models.TeamRoleManager)
+	suite.withUser("scm", "I100002", &suite.team.ID, models.TeamRoleScM)
+	suite.withUser("member", "I100003", &suite.team.ID, models.TeamRoleMember)
+	suite.withUser("teamowner", "I000003", &suite.otherTeam, models.TeamRoleMember)
+	suite.withUser("groupowner", "I000002", nil, models.TeamRoleMember)
+	suite.withUser("orgowner", "I000001", nil, models.TeamRoleMember)
+	suite.withUser("outsider", "I100004", &suite.otherTeam, models.TeamRoleManager)
+
+	for _, allowed := range []string{"manager", "scm", "teamowner", "groupowner", "orgowner"} {
+		suite.Equal(http.StatusOK, suite.serve(allowed, "PATCH", "/teams/:id/metadata", path, "", policy).Code, allowed)
+	}
+	suite.assertForbidden(suite.serve("member", "PATCH", "/teams/:id/metadata", path, "", policy))
+	suite.assertForbidden(suite.serve("outsider", "PATCH", "/teams/:id/metadata", path, "", policy))
+
+	suite.Equal(http.StatusBadRequest, suite.serve("manager", "PATCH", "/teams/:id/metadata", "/teams/not-a-uuid/metadata", "", policy).Code)
+	suite.Equal(http.StatusNotFound, suite.serve("outsider", "PATCH", "/teams/:id/metadata", "/teams/"+uuid.New().String()+"/metadata", "", policy).Code)
+}
+
+func (suite *AuthorizationTestSuite) TestTeamMemberForDocumentations() {
+	docID := uuid.New()
+	suite.docs.EXPECT().GetByID(docID).Return(&models.Documentation{ID: docID, TeamID: suite.team.ID}, nil).AnyTimes()
+	suite.docs.EXPECT().GetByID(gomock.Any()).Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+	suite.withUser("member", "I100003", &suite.team.ID, models.TeamRoleMember)
+	suite.withUser("outsider", "I100004", &suite.otherTeam, models.TeamRoleMember)
+
+	byDoc := middleware.TeamMember(middleware.TeamFromDocumentation("id"))
+	suite.Equal(http.StatusOK, suite.serve("member", "DELETE", "/documentations/:id", "/documentations/"+docID.String(), "", byDoc).Code)
+	suite.assertForbidden(suite.serve("outsider", "DELETE", "/documentations/:id", "/documentations/"+docID.String(), "", byDoc))
+	suite.Equal(http.StatusNotFound, suite.serve("member", "DELETE", "/documentations/:id", "/documentations/"+uuid.New().String(), "", byDoc).Code)
+
+	byBody := middleware.TeamMember(middleware.TeamFromBody("team_id"))
+	body := `{"team_id":"` + suite.team.ID.String() + `","title":"Docs"}`
+	suite.Equal(http.StatusOK, suite.serve("member", "POST", "/documentations", "/documentations", body, byBody).Code)
+	suite.assertForbidden(suite.serve("outsider", "POST", "/documentations", "/documentations", body, byBody))
+	suite.Equal(http.StatusBadRequest, suite.serve("member", "POST", "/documentations", "/documentations", `{"title":"Docs"}`, byBody).Code)
+}
+
+func (suite *AuthorizationTestSuite) TestTeamManagerForComponents() {
+	component := &models.Component{BaseModel: models.BaseModel{ID: uuid.New()}, OwnerID: suite.team.ID}
+	suite.components.EXPECT().GetByID(component.ID).Return(component, nil).AnyTimes()
+	suite.components.EXPECT().GetByID(gomock.Any()).Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+	suite.withUser("manager", "I100002", &suite.team.ID, models.TeamRoleManager)
+	suite.withUser("member", "I100003", &suite.team.ID, models.TeamRoleMember)
+
+	policy := middleware.TeamManager(middleware.TeamFromComponent("id"))
+	path := "/components/" + component.ID.String()
+	suite.Equal(http.StatusOK, suite.serve("manager", "PATCH", "/components/:id", path, "", policy).Code)
+	suite.assertForbidden(suite.serve("member", "PATCH", "/components/:id", path, "", policy))
+	suite.Equal(http.StatusNotFound, suite.serve("manager", "PATCH", "/components/:id", "/components/"+uuid.New().String(), "", policy).Code)
+	suite.Equal(http.StatusBadRequest, suite.serve("manager", "PATCH", "/components/:id", "/components/svc", "", policy).Code)
+}
+
+func (suite *AuthorizationTestSuite) TestLinkOwner() {
+	owner := suite.withUser("owner", "I100001", nil, models.TeamRoleMember)
+	suite.withUser("member", "I100003", &suite.team.ID, models.TeamRoleMember)
+	suite.withUser("outsider", "I100004", &suite.otherTeam, models.TeamRoleMember)
+
+	userLink := models.Link{BaseModel: models.BaseModel{ID: uuid.New()}, Owner: owner.ID}
+	teamLink := models.Link{BaseModel: models.BaseModel{ID: uuid.New()}, Owner: suite.team.ID}
+	suite.links.EXPECT().GetByIDs([]uuid.UUID{userLink.ID}).Return([]models.Link{userLink}, nil).AnyTimes()
+	suite.links.EXPECT().GetByIDs([]uuid.UUID{teamLink.ID}).Return([]models.Link{teamLink}, nil).AnyTimes()
+	suite.links.EXPECT().GetByIDs(gomock.Any()).Return([]models.Link{}, nil).AnyTimes()
+
+	policy := middleware.LinkOwner("id")
+	suite.Equal(http.StatusOK, suite.serve("owner", "DELETE", "/links/:id", "/links/"+userLink.ID.String(), "", policy).Code)
+	suite.assertForbidden(suite.serve("outsider", "DELETE", "/links/:id", "/links/"+userLink.ID.String(), "", policy))
+	suite.Equal(http.StatusOK, suite.serve("member", "DELETE", "/links/:id", "/links/"+teamLink.ID.String(), "", policy).Code)
+	suite.assertForbidden(suite.serve("outsider", "DELETE", "/links/:id", "/links/"+teamLink.ID.String(), "", policy))
+	suite.Equal(http.StatusNotFound, suite.serve("owner", "DELETE", "/links/:id", "/links/"+uuid.New().String(), "", policy).Code)
+}
+
+func (suite *AuthorizationTestSuite) TestGroupManager() {
+	suite.groups.EXPECT().GetByID(gomock.Any()).Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+	suite.withUser("groupowner", "I000002", nil, models.TeamRoleMember)
+	suite.withUser("orgowner", "I000001", nil, models.TeamRoleMember)
+	suite.withUser("teamowner", "I000003", &suite.team.ID, models.TeamRoleManager)
+
+	policy := middleware.GroupManager(middleware.GroupFromParam("id"))
+	path := "/groups/" + suite.group.ID.String()
+	suite.Equal(http.StatusOK, suite.serve("groupowner", "PATCH", "/groups/:id", path, "", policy).Code)
+	suite.Equal(http.StatusOK, suite.serve("orgowner", "PATCH", "/groups/:id", path, "", policy).Code)
+	suite.assertForbidden(suite.serve("teamowner", "PATCH", "/groups/:id", path, "", policy))
+	suite.Equal(http.StatusNotFound, suite.serve("groupowner", "PATCH", "/groups/:id", "/groups/"+uuid.New().String(), "", policy).Code)
+
+	ofTeam := middleware.GroupManager(middleware.GroupOfTeam(middleware.TeamFromParam("id")))
+	teamPath := "/teams/" + suite.team.ID.String() + "/group"
+	suite.Equal(http.StatusOK, suite.serve("groupowner", "PUT", "/teams/:id/group", teamPath, "", ofTeam).Code)
+	suite.assertForbidden(suite.serve("teamowner", "PUT", "/teams/:id/group", teamPath, "", ofTeam))
+	suite.Equal(http.StatusNotFound, suite.serve("groupowner", "PUT", "/teams/:id/group", "/teams/"+uuid.New().String()+"/group", "", ofTeam).Code)
+
+	byBody := middleware.GroupManager(middleware.GroupFromBody("group_id"))
+	suite.Equal(http.StatusOK, suite.serve("orgowner", "PUT", "/teams/:id/group", teamPath, `{"group_id":"`+suite.group.ID.String()+`"}`, byBody).Code)
+	suite.Equal(http.StatusBadRequest, suite.serve("orgowner", "PUT", "/teams/:id/group", teamPath, `{"group_id":"nope"}`, byBody).Code)
+}
+
+func (suite *AuthorizationTestSuite) TestOrganizationOwner() {
+	suite.orgs.EXPECT().GetByID(gomock.Any()).Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+	suite.withUser("orgowner", "I000001", nil, models.TeamRoleMember)
+	suite.withUser("groupowner", "I000002", nil, models.TeamRoleMember)
+
+	policy := middleware.OrganizationOwner(middleware.OrganizationFromParam("id"))
+	path := "/organizations/" + suite.org.ID.String()
+	suite.Equal(http.StatusOK, suite.serve("orgowner", "PATCH", "/organizations/:id", path, "", policy).Code)
+	suite.assertForbidden(suite.serve("groupowner", "PATCH", "/organizations/:id", path, "", policy))
+	suite.Equal(http.StatusNotFound, suite.serve("orgowner", "PATCH", "/organizations/:id", "/organizations/"+uuid.New().String(), "", policy).Code)
+	suite.Equal(http.StatusBadRequest, suite.serve("orgowner", "PATCH", "/organizations/:id", "/organizations/not-a-uuid", "", policy).Code)
+
+	byBody := middleware.OrganizationOwner(middleware.OrganizationFromBody("org_id"))
+	body := `{"org_id":"` + suite.org.ID.String() + `","name":"new-group"}`
+	suite.Equal(http.StatusOK, suite.serve("orgowner", "POST", "/groups", "/groups", body, byBody).Code)
+	suite.assertForbidden(suite.serve("groupowner", "POST", "/groups", "/groups", body, byBody))
+
+	ofGroup := middleware.OrganizationOwner(middleware.OrganizationOfGroup(middleware.GroupFromParam("id")))
+	suite.Equal(http.StatusOK, suite.serve("orgowner", "DELETE", "/groups/:id", "/groups/"+suite.group.ID.String(), "", ofGroup).Code)
+	suite.assertForbidden(suite.serve("groupowner", "DELETE", "/groups/:id", "/groups/"+suite.group.ID.String(), "", ofGroup))
+}
+
+func (suite *AuthorizationTestSuite) TestSelf() {
+	suite.withUser("jdoe", "I100001", nil, models.TeamRoleMember)
+	policy := middleware.Self("user_id")
+
+	suite.Equal(http.StatusOK, suite.serve("jdoe", "POST", "/users/:user_id/favorites", "/users/I100001/favorites", "", policy).Code)
+	suite.Equal(http.StatusOK, suite.serve("jdoe", "POST", "/users/:user_id/favorites", "/users/jdoe/favorites", "", policy).Code)
+	suite.assertForbidden(suite.serve("jdoe", "POST", "/users/:user_id/favorites", "/users/I999999/favorites", "", policy))
+}
+
+func (suite *AuthorizationTestSuite) TestAdminBypassesPolicies() {
+	suite.users.EXPECT().GetByName("admin").Return(nil, gorm.ErrRecordNotFound)
+	suite.users.EXPECT().GetByEmail("admin@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+	w := suite.serve("admin", "PATCH", "/teams/:id/metadata", "/teams/"+suite.team.ID.String()+"/metadata", "", middleware.AdminOnly())
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *AuthorizationTestSuite) TestUnknownUserIsForbidden() {
+	suite.users.EXPECT().GetByName("ghost").Return(nil, gorm.ErrRecordNotFound)
+	suite.users.EXPECT().GetByEmail("ghost@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+	suite.assertForbidden(suite.serve("ghost", "PATCH", "/teams/:id/metadata", "/teams/"+suite.team.ID.String()+"/metadata", "", middleware.TeamManager(middleware.TeamFromParam("id"))))
+}
+
+func (suite *AuthorizationTestSuite) TestServiceAccountIsNotAUser() {
+	// A service account named like a team manager must not act as that user
+	suite.withUser("jdoe", "I100001", &suite.team.ID, models.TeamRoleManager)
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("auth_claims", &auth.AuthClaims{Username: "jdoe", TokenType: auth.TokenTypeServiceAccount, Scopes: []string{auth.ScopeWrite}})
+		c.Next()
+	})
+	r.PATCH("/teams/:id/metadata", suite.authz.Require(middleware.TeamManager(middleware.TeamFromParam("id"))), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("PATCH", "/teams/"+suite.team.ID.String()+"/metadata", nil))
+	suite.assertForbidden(w)
+}
+
+func (suite *AuthorizationTestSuite) TestUserTeamManager() {
+	suite.withUser("jdoe", "I100001", &suite.team.ID, models.TeamRoleManager)
+	suite.withUser("outsider", "I100002", nil, models.TeamRoleMember)
+	member := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, UserID: "I100003", TeamID: &suite.team.ID}
+	teamless := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, UserID: "I100004"}
+	suite.users.EXPECT().GetByID(member.ID).Return(member, nil).AnyTimes()
+	suite.users.EXPECT().GetByID(teamless.ID).Return(teamless, nil).AnyTimes()
+	suite.users.EXPECT().GetByID(gomock.Any()).Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+	policy := middleware.UserTeamManager("user_uuid")
+	body := func(id uuid.UUID) string { return `{"user_uuid":"` + id.String() + `"}` }
+
+	suite.Equal(http.StatusOK, suite.serve("jdoe", "PUT", "/users", "/users", body(member.ID), policy).Code)
+	suite.assertForbidden(suite.serve("outsider", "PUT", "/users", "/users", body(member.ID), policy))
+	// Users without a team belong to no manager
+	suite.Equal(http.StatusOK, suite.serve("outsider", "PUT", "/users", "/users", body(teamless.ID), policy).Code)
+	suite.Equal(http.StatusNotFound, suite.serve("jdoe", "PUT", "/users", "/users", body(uuid.New()), policy).Code)
+	suite.Equal(http.StatusBadRequest, suite.serve("jdoe", "PUT", "/users", "/users", `{"user_uuid":"nope"}`, policy).Code)
+}
+
+func (suite *AuthorizationTestSuite) TestOnlyVerifiedEmailsResolveUsers() {
+	manager := &models.User{BaseModel: models.BaseModel{ID: uuid.New(), Name: "jdoe"}, UserID: "I100001", TeamID: &suite.team.ID, TeamRole: models.TeamRoleManager}
+	policy := middleware.TeamManager(middleware.TeamFromParam("id"))
+	path := "/teams/" + suite.team.ID.String() + "/metadata"
+	suite.users.EXPECT().GetByName("corp/jdoe").Return(nil, gorm.ErrRecordNotFound).Times(2)
+
+	// An OIDC account claiming the manager's email without verifying it is nobody
+	claims := &auth.AuthClaims{Username: "corp/jdoe", Email: "jdoe@example.com", Provider: "corp"}
+	suite.assertForbidden(suite.serveAs(claims, "PATCH", "/teams/:id/metadata", path, "", policy))
+
+	suite.users.EXPECT().GetByEmail("jdoe@example.com").Return(manager, nil)
+	claims.EmailVerified = true
+	suite.Equal(http.StatusOK, suite.serveAs(claims, "PATCH", "/teams/:id/metadata", path, "", policy).Code)
+}
+
+func (suite *AuthorizationTestSuite) TestAnyOf() {
+	suite.withUser("jdoe", "I100001", &suite.team.ID, models.TeamRoleMember)
+	policy := middleware.AnyOf(middleware.AdminOnly(), middleware.TeamMember(middleware.TeamFromParam("id")))
+
+	suite.Equal(http.StatusOK, suite.serve("jdoe", "GET", "/teams/:id", "/teams/"+suite.team.ID.String(), "", policy).Code)
+	suite.assertForbidden(suite.serve("jdoe", "GET", "/teams/:id", "/teams/"+suite.team.ID.String(), "", middleware.AnyOf(middleware.AdminOnly(), middleware.TeamManager(middleware.TeamFromParam("id")))))
+}
+
+func TestAuthorizationTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthorizationTestSuite))
+}
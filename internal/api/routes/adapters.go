@@ -0,0 +1,556 @@
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"developer-portal-backend/internal/auth"
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// tokenStoreAdapter adapts repository.RefreshTokenRepositoryInterface to auth.TokenStore
+type tokenStoreAdapter struct {
+	repo repository.RefreshTokenRepositoryInterface
+}
+
+// Ensure tokenStoreAdapter implements auth.TokenStore
+var _ auth.TokenStore = (*tokenStoreAdapter)(nil)
+
+func (a *tokenStoreAdapter) Save(tokenHash string, data *auth.RefreshTokenData) error {
+	return a.repo.Create(toRefreshTokenModel(tokenHash, data))
+}
+
+func (a *tokenStoreAdapter) Get(tokenHash string) (*auth.RefreshTokenData, error) {
+	token, err := a.repo.GetByTokenHash(tokenHash)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+	return toRefreshTokenData(token), nil
+}
+
+func (a *tokenStoreAdapter) Rotate(oldHash, newHash string, data *auth.RefreshTokenData) error {
+	if err := a.repo.Rotate(oldHash, toRefreshTokenModel(newHash, data)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrInvalidRefreshToken
+		}
+		return err
+	}
+	return nil
+}
+
+func (a *tokenStoreAdapter) Delete(tokenHash string) error {
+	return a.repo.DeleteByTokenHash(tokenHash)
+}
+
+func (a *tokenStoreAdapter) DeleteByUser(userID int64, provider string) (int64, error) {
+	return a.repo.DeleteByUser(userID, provider)
+}
+
+func (a *tokenStoreAdapter) DeleteByEmail(email string) (int64, error) {
+	return a.repo.DeleteByEmail(email)
+}
+
+func (a *tokenStoreAdapter) FindActiveByUser(userID int64, provider string) (*auth.RefreshTokenData, error) {
+	token, err := a.repo.GetLatestActiveByUser(userID, provider, time.Now())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return toRefreshTokenData(token), nil
+}
+
+func (a *tokenStoreAdapter) DeleteExpired(now time.Time) (int64, error) {
+	return a.repo.DeleteExpired(now)
+}
+
+func (a *tokenStoreAdapter) ListStaleCredentials(prefix string, limit int) (map[string]*auth.RefreshTokenData, error) {
+	rows, err := a.repo.GetActiveWithoutAccessTokenPrefix(prefix, time.Now(), limit)
+	if err != nil {
+		return nil, err
+	}
+	sessions := make(map[string]*auth.RefreshTokenData, len(rows))
+	for i := range rows {
+		sessions[rows[i].TokenHash] = toRefreshTokenData(&rows[i])
+	}
+	return sessions, nil
+}
+
+func (a *tokenStoreAdapter) UpdateAccessToken(tokenHash, accessToken string) error {
+	return a.repo.UpdateAccessToken(tokenHash, accessToken)
+}
+
+func toRefreshTokenModel(tokenHash string, data *auth.RefreshTokenData) *models.RefreshToken {
+	return &models.RefreshToken{
+		CreatedAt:     data.CreatedAt,
+		TokenHash:     tokenHash,
+		UserID:        data.UserID,
+		Username:      data.Username,
+		Email:         data.Email,
+		MemberID:      data.MemberID,
+		Provider:      data.Provider,
+		AccessToken:   data.AccessToken,
+		ExpiresAt:     data.ExpiresAt,
+		EmailVerified: data.EmailVerified,
+	}
+}
+
+func toRefreshTokenData(token *models.RefreshToken) *auth.RefreshTokenData {
+	return &auth.RefreshTokenData{
+		UserID:        token.UserID,
+		Username:      token.Username,
+		Email:         token.Email,
+		MemberID:      token.MemberID,
+		Provider:      token.Provider,
+		AccessToken:   token.AccessToken,
+		ExpiresAt:     token.ExpiresAt,
+		CreatedAt:     token.CreatedAt,
+		EmailVerified: token.EmailVerified,
+	}
+}
+
+// revocationStoreAdapter adapts repository.TokenRevocationRepositoryInterface to auth.RevocationStore
+type revocationStoreAdapter struct {
+	repo repository.TokenRevocationRepositoryInterface
+}
+
+// Ensure revocationStoreAdapter implements auth.RevocationStore
+var _ auth.RevocationStore = (*revocationStoreAdapter)(nil)
+
+func (a *revocationStoreAdapter) Add(revocation *auth.Revocation) error {
+	return a.repo.Create(&models.TokenRevocation{
+		JTI:       revocation.JTI,
+		Email:     revocation.Email,
+		RevokedAt: revocation.RevokedAt,
+		RevokedBy: revocation.RevokedBy,
+		Reason:    revocation.Reason,
+		ExpiresAt: revocation.ExpiresAt,
+	})
+}
+
+func (a *revocationStoreAdapter) ListSince(since time.Time) ([]auth.Revocation, error) {
+	rows, err := a.repo.GetActiveSince(since, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	revocations := make([]auth.Revocation, 0, len(rows))
+	for _, row := range rows {
+		revocations = append(revocations, auth.Revocation{
+			JTI:       row.JTI,
+			Email:     row.Email,
+			RevokedAt: row.RevokedAt,
+			RevokedBy: row.RevokedBy,
+			Reason:    row.Reason,
+			ExpiresAt: row.ExpiresAt,
+		})
+	}
+	return revocations, nil
+}
+
+func (a *revocationStoreAdapter) DeleteExpired(now time.Time) (int64, error) {
+	return a.repo.DeleteExpired(now)
+}
+
+// signingKeyStoreAdapter adapts repository.SigningKeyRepositoryInterface to auth.SigningKeyStore
+type signingKeyStoreAdapter struct {
+	repo repository.SigningKeyRepositoryInterface
+}
+
+// Ensure signingKeyStoreAdapter implements auth.SigningKeyStore
+var _ auth.SigningKeyStore = (*signingKeyStoreAdapter)(nil)
+
+func (a *signingKeyStoreAdapter) Save(key *auth.SigningKey) error {
+	return a.repo.Create(&models.SigningKey{
+		ID:         key.ID,
+		Algorithm:  key.Algorithm,
+		PublicKey:  key.PublicKey,
+		PrivateKey: key.PrivateKey,
+		NotBefore:  key.NotBefore,
+		RotatesAt:  key.RotatesAt,
+		ExpiresAt:  key.ExpiresAt,
+		CreatedAt:  key.CreatedAt,
+	})
+}
+
+func (a *signingKeyStoreAdapter) ListActive(now time.Time) ([]auth.SigningKey, error) {
+	rows, err := a.repo.GetActive(now)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]auth.SigningKey, 0, len(rows))
+	for _, row := range rows {
+		keys = append(keys, auth.SigningKey{
+			ID:         row.ID,
+			Algorithm:  row.Algorithm,
+			PublicKey:  row.PublicKey,
+			PrivateKey: row.PrivateKey,
+			NotBefore:  row.NotBefore,
+			RotatesAt:  row.RotatesAt,
+			ExpiresAt:  row.ExpiresAt,
+			CreatedAt:  row.CreatedAt,
+		})
+	}
+	return keys, nil
+}
+
+func (a *signingKeyStoreAdapter) DeleteExpired(now time.Time) (int64, error) {
+	return a.repo.DeleteExpired(now)
+}
+
+// oauthStateStoreAdapter adapts repository.OAuthStateRepositoryInterface to auth.OAuthStateStore
+type oauthStateStoreAdapter struct {
+	repo repository.OAuthStateRepositoryInterface
+}
+
+// Ensure oauthStateStoreAdapter implements auth.OAuthStateStore
+var _ auth.OAuthStateStore = (*oauthStateStoreAdapter)(nil)
+
+func (a *oauthStateStoreAdapter) Save(stateHash string, state *auth.OAuthState) error {
+	return a.repo.Create(&models.OAuthState{
+		CreatedAt:    state.CreatedAt,
+		StateHash:    stateHash,
+		Provider:     state.Provider,
+		CodeVerifier: state.CodeVerifier,
+		ExpiresAt:    state.ExpiresAt,
+	})
+}
+
+func (a *oauthStateStoreAdapter) Consume(stateHash string) (*auth.OAuthState, error) {
+	state, err := a.repo.Consume(stateHash)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrInvalidOAuthState
+		}
+		return nil, err
+	}
+	return &auth.OAuthState{
+		Provider:     state.Provider,
+		CodeVerifier: state.CodeVerifier,
+		ExpiresAt:    state.ExpiresAt,
+		CreatedAt:    state.CreatedAt,
+	}, nil
+}
+
+func (a *oauthStateStoreAdapter) DeleteExpired(now time.Time) (int64, error) {
+	return a.repo.DeleteExpired(now)
+}
+
+// apiTokenStoreAdapter adapts repository.APITokenRepositoryInterface to auth.APITokenStore
+type apiTokenStoreAdapter struct {
+	repo repository.APITokenRepositoryInterface
+}
+
+// Ensure apiTokenStoreAdapter implements auth.APITokenStore
+var _ auth.APITokenStore = (*apiTokenStoreAdapter)(nil)
+
+func (a *apiTokenStoreAdapter) Save(tokenHash string, token *auth.APIToken) error {
+	id, err := uuid.Parse(token.ID)
+	if err != nil {
+		return fmt.Errorf("invalid API token ID: %w", err)
+	}
+	row := &models.APIToken{
+		ID:            id,
+		CreatedAt:     token.CreatedAt,
+		TokenHash:     tokenHash,
+		Prefix:        token.Prefix,
+		Name:          token.Name,
+		Scopes:        strings.Join(token.Scopes, ","),
+		UserID:        token.UserID,
+		Username:      token.Username,
+		Email:         token.Email,
+		Provider:      token.Provider,
+		CreatedBy:     token.CreatedBy,
+		ExpiresAt:     token.ExpiresAt,
+		LastUsedAt:    token.LastUsedAt,
+		EmailVerified: token.EmailVerified,
+	}
+	if token.ServiceAccount != "" {
+		row.ServiceAccount = &token.ServiceAccount
+	}
+	return a.repo.Create(row)
+}
+
+func (a *apiTokenStoreAdapter) Get(id string) (*auth.APIToken, error) {
+	tokenID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.ErrAPITokenNotFound
+	}
+	token, err := a.repo.GetByID(tokenID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrAPITokenNotFound
+		}
+		return nil, err
+	}
+	return toAPIToken(token), nil
+}
+
+func (a *apiTokenStoreAdapter) GetByHash(tokenHash string) (*auth.APIToken, error) {
+	token, err := a.repo.GetByTokenHash(tokenHash)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrInvalidAPIToken
+		}
+		return nil, err
+	}
+	return toAPIToken(token), nil
+}
+
+func (a *apiTokenStoreAdapter) ListPersonal(userID int64, provider string) ([]auth.APIToken, error) {
+	rows, err := a.repo.GetPersonalByUser(userID, provider)
+	if err != nil {
+		return nil, err
+	}
+	return toAPITokens(rows), nil
+}
+
+func (a *apiTokenStoreAdapter) ListByServiceAccount(name string) ([]auth.APIToken, error) {
+	rows, err := a.repo.GetByServiceAccount(name)
+	if err != nil {
+		return nil, err
+	}
+	return toAPITokens(rows), nil
+}
+
+func (a *apiTokenStoreAdapter) MarkUsed(id string, at time.Time) error {
+	tokenID, err := uuid.Parse(id)
+	if err != nil {
+		return fmt.Errorf("invalid API token ID: %w", err)
+	}
+	return a.repo.UpdateLastUsed(tokenID, at)
+}
+
+func (a *apiTokenStoreAdapter) Delete(id string) error {
+	tokenID, err := uuid.Parse(id)
+	if err != nil {
+		return nil
+	}
+	return a.repo.Delete(tokenID)
+}
+
+func (a *apiTokenStoreAdapter) DeletePersonalByEmail(email string) (int64, error) {
+	return a.repo.DeletePersonalByEmail(email)
+}
+
+func toAPIToken(row *models.APIToken) *auth.APIToken {
+	token := &auth.APIToken{
+		ID:            row.ID.String(),
+		Name:          row.Name,
+		Prefix:        row.Prefix,
+		Scopes:        strings.Split(row.Scopes, ","),
+		UserID:        row.UserID,
+		Username:      row.Username,
+		Email:         row.Email,
+		Provider:      row.Provider,
+		CreatedBy:     row.CreatedBy,
+		CreatedAt:     row.CreatedAt,
+		ExpiresAt:     row.ExpiresAt,
+		LastUsedAt:    row.LastUsedAt,
+		EmailVerified: row.EmailVerified,
+	}
+	if row.ServiceAccount != nil {
+		token.ServiceAccount = *row.ServiceAccount
+	}
+	return token
+}
+
+func toAPITokens(rows []models.APIToken) []auth.APIToken {
+	tokens := make([]auth.APIToken, 0, len(rows))
+	for i := range rows {
+		tokens = append(tokens, *toAPIToken(&rows[i]))
+	}
+	return tokens
+}
+
+// serviceAccountStoreAdapter adapts repository.ServiceAccountRepositoryInterface to auth.ServiceAccountStore
+type serviceAccountStoreAdapter struct {
+	repo repository.ServiceAccountRepositoryInterface
+}
+
+// Ensure serviceAccountStoreAdapter implements auth.ServiceAccountStore
+var _ auth.ServiceAccountStore = (*serviceAccountStoreAdapter)(nil)
+
+func (a *serviceAccountStoreAdapter) Save(account *auth.ServiceAccount) error {
+	if _, err := a.repo.GetByName(account.Name); err == nil {
+		return apperrors.ErrServiceAccountExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	return a.repo.Create(&models.ServiceAccount{
+		CreatedAt:   account.CreatedAt,
+		Name:        account.Name,
+		Email:       account.Email,
+		Description: account.Description,
+		CreatedBy:   account.CreatedBy,
+	})
+}
+
+func (a *serviceAccountStoreAdapter) Get(name string) (*auth.ServiceAccount, error) {
+	account, err := a.repo.GetByName(name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrServiceAccountNotFound
+		}
+		return nil, err
+	}
+	return toServiceAccount(account), nil
+}
+
+func (a *serviceAccountStoreAdapter) List() ([]auth.ServiceAccount, error) {
+	rows, err := a.repo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	accounts := make([]auth.ServiceAccount, 0, len(rows))
+	for i := range rows {
+		accounts = append(accounts, *toServiceAccount(&rows[i]))
+	}
+	return accounts, nil
+}
+
+func (a *serviceAccountStoreAdapter) Delete(name string) error {
+	if err := a.repo.Delete(name); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrServiceAccountNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func toServiceAccount(row *models.ServiceAccount) *auth.ServiceAccount {
+	return &auth.ServiceAccount{
+		Name:        row.Name,
+		Email:       row.Email,
+		Description: row.Description,
+		CreatedBy:   row.CreatedBy,
+		CreatedAt:   row.CreatedAt,
+	}
+}
+
+// identityStoreAdapter adapts repository.UserIdentityRepositoryInterface to auth.IdentityStore
+type identityStoreAdapter struct {
+	repo repository.UserIdentityRepositoryInterface
+}
+
+// Ensure identityStoreAdapter implements auth.IdentityStore
+var _ auth.IdentityStore = (*identityStoreAdapter)(nil)
+
+func (a *identityStoreAdapter) Link(identity *auth.LinkedIdentity) error {
+	return a.repo.Upsert(&models.UserIdentity{
+		MemberID:       identity.MemberID,
+		Provider:       identity.Provider,
+		ProviderUserID: identity.ProviderUserID,
+		Username:       identity.Username,
+		Email:          identity.Email,
+		LinkedAt:       identity.LinkedAt,
+		LastLoginAt:    identity.LastLoginAt,
+	})
+}
+
+func (a *identityStoreAdapter) Get(provider string, providerUserID int64) (*auth.LinkedIdentity, error) {
+	row, err := a.repo.GetByProviderAccount(provider, providerUserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrIdentityNotFound
+		}
+		return nil, err
+	}
+	return toLinkedIdentity(row), nil
+}
+
+func (a *identityStoreAdapter) ListByMember(memberID string) ([]auth.LinkedIdentity, error) {
+	rows, err := a.repo.GetByMemberID(memberID)
+	if err != nil {
+		return nil, err
+	}
+	identities := make([]auth.LinkedIdentity, 0, len(rows))
+	for i := range rows {
+		identities = append(identities, *toLinkedIdentity(&rows[i]))
+	}
+	return identities, nil
+}
+
+func (a *identityStoreAdapter) Unlink(memberID, provider string) error {
+	if err := a.repo.Delete(memberID, provider); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrIdentityNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func toLinkedIdentity(row *models.UserIdentity) *auth.LinkedIdentity {
+	return &auth.LinkedIdentity{
+		MemberID:       row.MemberID,
+		Provider:       row.Provider,
+		ProviderUserID: row.ProviderUserID,
+		Username:       row.Username,
+		Email:          row.Email,
+		LinkedAt:       row.LinkedAt,
+		LastLoginAt:    row.LastLoginAt,
+	}
+}
+
+// impersonationAuditAdapter adapts repository.ImpersonationAuditLogRepositoryInterface to auth.ImpersonationAuditStore
+type impersonationAuditAdapter struct {
+	repo repository.ImpersonationAuditLogRepositoryInterface
+}
+
+// Ensure impersonationAuditAdapter implements auth.ImpersonationAuditStore
+var _ auth.ImpersonationAuditStore = (*impersonationAuditAdapter)(nil)
+
+func (a *impersonationAuditAdapter) Record(entry *auth.ImpersonationAuditEntry) error {
+	row := &models.ImpersonationAuditLog{
+		CreatedAt: entry.CreatedAt,
+		SessionID: entry.SessionID,
+		Event:     entry.Event,
+		Admin:     entry.Admin,
+		Username:  entry.Username,
+		Email:     entry.Email,
+		Reason:    entry.Reason,
+		Method:    entry.Method,
+		Path:      entry.Path,
+		Status:    entry.Status,
+	}
+	if id, err := uuid.Parse(entry.ID); err == nil {
+		row.ID = id
+	}
+	return a.repo.Create(row)
+}
+
+func (a *impersonationAuditAdapter) List(filter auth.ImpersonationAuditFilter) ([]auth.ImpersonationAuditEntry, error) {
+	rows, err := a.repo.GetFiltered(filter.Admin, filter.Email, filter.SessionID, filter.Since, filter.Limit)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]auth.ImpersonationAuditEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, auth.ImpersonationAuditEntry{
+			ID:        row.ID.String(),
+			SessionID: row.SessionID,
+			Event:     row.Event,
+			Admin:     row.Admin,
+			Username:  row.Username,
+			Email:     row.Email,
+			Reason:    row.Reason,
+			Method:    row.Method,
+			Path:      row.Path,
+			Status:    row.Status,
+			CreatedAt: row.CreatedAt,
+		})
+	}
+	return entries, nil
+}
@@ -1,13 +1,16 @@
 package routes
 
 import (
+	"context"
 	"developer-portal-backend/internal/api/handlers"
 	"developer-portal-backend/internal/api/middleware"
 	"developer-portal-backend/internal/auth"
 	"developer-portal-backend/internal/config"
+	"developer-portal-backend/internal/metaschema"
 	"developer-portal-backend/internal/repository"
 	"developer-portal-backend/internal/service"
 	"log"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
@@ -39,6 +42,12 @@ func SetupRoutes(db *gorm.DB, cfg *config.Config) *gin.Engine {
 	// Initialize validator
 	validator := validator.New()
 
+	// Load the JSON Schemas entity metadata is validated against
+	metadataSchemas, err := metaschema.NewRegistry()
+	if err != nil {
+		log.Fatalf("Failed to load metadata schemas: %v", err)
+	}
+
 	// Initialize repositories
 	organizationRepo := repository.NewOrganizationRepository(db)
 	groupRepo := repository.NewGroupRepository(db)
@@ -50,16 +59,51 @@ func SetupRoutes(db *gorm.DB, cfg *config.Config) *gin.Engine {
 	categoryRepo := repository.NewCategoryRepository(db)
 	linkRepo := repository.NewLinkRepository(db)
 	docRepo := repository.NewDocumentationRepository(db)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(db)
+	tokenRevocationRepo := repository.NewTokenRevocationRepository(db)
+	oauthStateRepo := repository.NewOAuthStateRepository(db)
+	apiTokenRepo := repository.NewAPITokenRepository(db)
+	serviceAccountRepo := repository.NewServiceAccountRepository(db)
+	signingKeyRepo := repository.NewSigningKeyRepository(db)
+	userIdentityRepo := repository.NewUserIdentityRepository(db)
+	impersonationAuditRepo := repository.NewImpersonationAuditLogRepository(db)
+	trashRepo := repository.NewTrashRepository(db)
+	userOffboardingRepo := repository.NewUserOffboardingRepository(db)
 
 	// Initialize services
-	userService := service.NewUserService(userRepo, linkRepo, validator)
-	teamService := service.NewTeamService(teamRepo, groupRepo, organizationRepo, userRepo, linkRepo, componentRepo, validator)
-	componentService := service.NewComponentService(componentRepo, organizationRepo, projectRepo, validator)
-	landscapeService := service.NewLandscapeService(landscapeRepo, organizationRepo, projectRepo, validator)
+	userService := service.NewUserService(userRepo, linkRepo, validator, metadataSchemas)
+	teamService := service.NewTeamService(teamRepo, groupRepo, organizationRepo, userRepo, linkRepo, componentRepo, validator, metadataSchemas)
+	componentService := service.NewComponentService(componentRepo, projectRepo, teamRepo, validator, metadataSchemas)
+	landscapeService := service.NewLandscapeService(landscapeRepo, organizationRepo, projectRepo, validator, metadataSchemas)
 	categoryService := service.NewCategoryService(categoryRepo, validator)
 	linkService := service.NewLinkService(linkRepo, userRepo, teamRepo, categoryRepo, validator)
 	docService := service.NewDocumentationService(docRepo, teamRepo, validator)
+	organizationService := service.NewOrganizationService(organizationRepo, groupRepo, validator)
+	groupService := service.NewGroupService(groupRepo, organizationRepo, teamRepo, validator)
+	hierarchyService := service.NewHierarchyService(organizationRepo, groupRepo, componentRepo)
+	teamMembershipService := service.NewTeamMembershipService(userRepo, teamRepo)
+	projectService := service.NewProjectService(projectRepo, componentRepo, landscapeRepo, validator, metadataSchemas)
+	trashService := service.NewTrashService(trashRepo, time.Duration(cfg.TrashRetentionDays)*24*time.Hour)
+	// Purge soft-deleted entities past the retention period once an hour
+	trashService.StartTrashPurge(context.Background(), time.Hour)
 	ldapService := service.NewLDAPService(cfg)
+	directorySyncService := service.NewDirectorySyncService(userRepo, userOffboardingRepo, ldapService, time.Duration(cfg.LDAPSyncGraceDays)*24*time.Hour, cfg.LDAPSyncDeleteMissing)
+	// Reconcile users with LDAP periodically
+	directorySyncService.StartDirectorySync(context.Background(), time.Duration(cfg.LDAPSyncIntervalHours)*time.Hour)
+	membershipDriftService := service.NewMembershipDriftService(teamRepo, userRepo, ldapService)
+	userImportService := service.NewUserImportService(userRepo, teamRepo, ldapService)
+	userOffboardingService := service.NewUserOffboardingService(userOffboardingRepo, userRepo, teamRepo)
+	if cfg.LDAPTeamSyncAutoApply {
+		// Reconcile team members with the distribution lists of their teams
+		membershipDriftService.StartDriftAutoApply(context.Background(), time.Duration(cfg.LDAPSyncIntervalHours)*time.Hour)
+	}
+	linkCheckService := service.NewLinkCheckService(linkRepo, userRepo, teamRepo, categoryRepo, service.LinkCheckOptions{
+		Concurrency:  cfg.LinkCheckConcurrency,
+		HostInterval: time.Duration(cfg.LinkCheckHostIntervalMs) * time.Millisecond,
+		Timeout:      time.Duration(cfg.LinkCheckTimeoutSec) * time.Second,
+	})
+	// Check all links for dead targets periodically
+	linkCheckService.StartLinkChecker(context.Background(), time.Duration(cfg.LinkCheckIntervalHours)*time.Hour)
 	jiraService := service.NewJiraService(cfg)
 	// Initialize Jira PAT on startup: use fixed-name PAT with machine identifier, delete existing if present, then create a new one
 	if err := jiraService.InitializePATOnStartup(); err != nil {
@@ -82,10 +126,27 @@ func SetupRoutes(db *gorm.DB, cfg *config.Config) *gin.Engine {
 	var authService *auth.AuthService
 	if authConfig != nil {
 		memberRepoAuth := &userRepoAdapter{repo: userRepo}
-		authService, err = auth.NewAuthService(authConfig, memberRepoAuth)
+		authService, err = auth.NewAuthServiceWithStores(authConfig, memberRepoAuth, auth.Stores{
+			Tokens:          &tokenStoreAdapter{repo: refreshTokenRepo},
+			Revocations:     &revocationStoreAdapter{repo: tokenRevocationRepo},
+			OAuthStates:     &oauthStateStoreAdapter{repo: oauthStateRepo},
+			APITokens:       &apiTokenStoreAdapter{repo: apiTokenRepo},
+			ServiceAccounts: &serviceAccountStoreAdapter{repo: serviceAccountRepo},
+			SigningKeys:     &signingKeyStoreAdapter{repo: signingKeyRepo},
+			Identities:      &identityStoreAdapter{repo: userIdentityRepo},
+			Impersonations:  &impersonationAuditAdapter{repo: impersonationAuditRepo},
+		})
 		if err != nil {
 			log.Printf("Warning: Failed to initialize auth service: %v", err)
 		} else {
+			// Purge expired refresh tokens, revocations and OAuth states from the database once an hour
+			authService.StartTokenCleanup(context.Background(), time.Hour)
+			// Pick up tokens revoked on other replicas
+			authService.StartRevocationSync(context.Background(), 15*time.Second)
+			// Create the next JWT signing key ahead of rotation and pick up keys created by other replicas
+			authService.StartKeyRotation(context.Background(), 5*time.Minute)
+			// Seal stored access tokens that are plaintext or use a retired token encryption key
+			authService.StartCredentialReencryption(context.Background(), time.Hour)
 			authHandler = auth.NewAuthHandler(authService)
 			authMiddleware = auth.NewAuthMiddleware(authService)
 		}
@@ -95,12 +156,24 @@ func SetupRoutes(db *gorm.DB, cfg *config.Config) *gin.Engine {
 	healthHandler := handlers.NewHealthHandler(db)
 	userHandler := handlers.NewUserHandler(userService, teamRepo)
 	teamHandler := handlers.NewTeamHandler(teamService)
+	teamMembershipHandler := handlers.NewTeamMembershipHandler(teamMembershipService)
 	componentHandler := handlers.NewComponentHandler(componentService, teamService)
 	landscapeHandler := handlers.NewLandscapeHandler(landscapeService)
 	categoryHandler := handlers.NewCategoryHandler(categoryService)
 	linkHandler := handlers.NewLinkHandler(linkService)
 	docHandler := handlers.NewDocumentationHandler(docService)
+	organizationHandler := handlers.NewOrganizationHandler(organizationService, groupService)
+	groupHandler := handlers.NewGroupHandler(groupService)
+	hierarchyHandler := handlers.NewHierarchyHandler(hierarchyService)
+	projectHandler := handlers.NewProjectHandler(projectService)
+	trashHandler := handlers.NewTrashHandler(trashService)
+	metadataSchemaHandler := handlers.NewMetadataSchemaHandler(metadataSchemas)
 	ldapHandler := handlers.NewLDAPHandler(ldapService, userRepo)
+	directorySyncHandler := handlers.NewDirectorySyncHandler(directorySyncService)
+	membershipDriftHandler := handlers.NewMembershipDriftHandler(membershipDriftService)
+	userImportHandler := handlers.NewUserImportHandler(userImportService)
+	userOffboardingHandler := handlers.NewUserOffboardingHandler(userOffboardingService)
+	linkCheckHandler := handlers.NewLinkCheckHandler(linkCheckService)
 	jiraHandler := handlers.NewJiraHandler(jiraService)
 	jenkinsHandler := handlers.NewJenkinsHandler(jenkinsService)
 	sonarHandler := handlers.NewSonarHandler(sonarService)
@@ -134,6 +207,9 @@ func SetupRoutes(db *gorm.DB, cfg *config.Config) *gin.Engine {
 			// Helper endpoint for token validation (not part of Backstage spec)
 			auth.POST("/validate", authHandler.ValidateToken)
 		}
+
+		// Public keys for services that verify portal tokens
+		router.GET("/.well-known/jwks.json", authHandler.JWKS)
 	}
 
 	// API v1 routes - All endpoints require authentication
@@ -145,18 +221,26 @@ func SetupRoutes(db *gorm.DB, cfg *config.Config) *gin.Engine {
 	}
 	v1.Use(authMiddleware.RequireAuth())
 
+	// Authorization policies are declared per route below; admins bypass them
+	authz := middleware.NewAuthorizer(userRepo, teamRepo, groupRepo, organizationRepo, docRepo, linkRepo, componentRepo, authService)
+
 	{
 
 		// Users routes
 		users := v1.Group("/users")
 		{
 			users.GET("/search/new", ldapHandler.UserSearch)
-			users.POST("", userHandler.CreateUser)
-			users.PUT("", userHandler.UpdateUserTeam)
+			users.POST("", authz.Require(middleware.AdminOnly()), userHandler.CreateUser)
+			users.POST("/import", authz.Require(middleware.AdminOnly()), userImportHandler.ImportUsers) // CSV or JSON; ?dry_run=true&atomic=true
+			users.PUT("", // Deprecated: use POST /teams/:id/members
+				authz.Require(middleware.UserTeamManager("user_uuid")),
+				authz.Require(middleware.TeamManager(middleware.TeamFromBody("new_team_uuid"))),
+				userHandler.UpdateUserTeam)
 			users.GET("", userHandler.ListUsers)
 			users.GET("/:user_id", userHandler.GetMemberByUserID)
-			users.POST("/:user_id/favorites/:link_id", userHandler.AddFavoriteLink)
-			users.DELETE("/:user_id/favorites/:link_id", userHandler.RemoveFavoriteLink)
+			users.GET("/:user_id/memberships", teamMembershipHandler.GetMembershipHistory)
+			users.POST("/:user_id/favorites/:link_id", authz.Require(middleware.Self("user_id")), userHandler.AddFavoriteLink)
+			users.DELETE("/:user_id/favorites/:link_id", authz.Require(middleware.Self("user_id")), userHandler.RemoveFavoriteLink)
 		}
 
 		// Current user route: /users/me
@@ -166,27 +250,89 @@ func SetupRoutes(db *gorm.DB, cfg *config.Config) *gin.Engine {
 		teams := v1.Group("/teams")
 		{
 			teams.GET("", teamHandler.GetAllTeams)
-			teams.PATCH("/:id/metadata", teamHandler.UpdateTeamMetadata) // Update team metadata
+			teams.PATCH("/:id/metadata", authz.Require(middleware.TeamManager(middleware.TeamFromParam("id"))), teamHandler.UpdateTeamMetadata) // Update team metadata
 			teams.GET("/:id/documentations", docHandler.GetDocumentationsByTeamID) // Get documentations by team ID
+			teams.PUT("/:id/group",
+				authz.Require(middleware.GroupManager(middleware.GroupOfTeam(middleware.TeamFromParam("id")))),
+				authz.Require(middleware.GroupManager(middleware.GroupFromBody("group_id"))),
+				groupHandler.MoveTeam) // Move team to another group
+			teams.GET("/:id/members", teamMembershipHandler.ListTeamMembers) // ?at=<date>|from=<date>&to=<date>
+			teams.POST("/:id/members", authz.Require(middleware.TeamManager(middleware.TeamFromParam("id"))), teamMembershipHandler.AddTeamMember)
+			teams.PATCH("/:id/members/:user_id", authz.Require(middleware.TeamManager(middleware.TeamFromParam("id"))), teamMembershipHandler.UpdateTeamMember)
+			teams.DELETE("/:id/members/:user_id", authz.Require(middleware.TeamManager(middleware.TeamFromParam("id"))), teamMembershipHandler.RemoveTeamMember)
+			teams.GET("/:id/membership-drift", membershipDriftHandler.GetTeamDrift) // Compare members with the team's distribution list
+			teams.POST("/:id/membership-drift/apply", authz.Require(middleware.TeamManager(middleware.TeamFromParam("id"))), membershipDriftHandler.ApplyTeamDrift)
+		}
+
+		// Organization routes
+		organizations := v1.Group("/organizations")
+		{
+			organizations.GET("", organizationHandler.ListOrganizations)
+			organizations.POST("", authz.Require(middleware.AdminOnly()), organizationHandler.CreateOrganization)
+			organizations.GET("/:id", organizationHandler.GetOrganization)
+			organizations.PATCH("/:id", authz.Require(middleware.OrganizationOwner(middleware.OrganizationFromParam("id"))), organizationHandler.UpdateOrganization)
+			organizations.DELETE("/:id", authz.Require(middleware.OrganizationOwner(middleware.OrganizationFromParam("id"))), organizationHandler.DeleteOrganization)
+			organizations.GET("/:id/groups", organizationHandler.ListOrganizationGroups) // GET /organizations/:id/groups?q=<search>
+			organizations.GET("/:id/tree", hierarchyHandler.GetOrganizationTree)         // GET /organizations/:id/tree?depth=3&fields=title,owner&components=true
+		}
+
+		// Group routes
+		groups := v1.Group("/groups")
+		{
+			groups.POST("", authz.Require(middleware.OrganizationOwner(middleware.OrganizationFromBody("org_id"))), groupHandler.CreateGroup)
+			groups.GET("/:id", groupHandler.GetGroup) // Group with its teams
+			groups.GET("/:id/tree", hierarchyHandler.GetGroupTree)
+			groups.PATCH("/:id", authz.Require(middleware.GroupManager(middleware.GroupFromParam("id"))), groupHandler.UpdateGroup)
+			groups.DELETE("/:id", authz.Require(middleware.OrganizationOwner(middleware.OrganizationOfGroup(middleware.GroupFromParam("id")))), groupHandler.DeleteGroup)
+		}
+
+		// Project routes; the path parameter is named projectId to share the tree with the alerts routes
+		projects := v1.Group("/projects")
+		{
+			projects.GET("", projectHandler.ListProjects)
+			projects.POST("", authz.Require(middleware.AdminOnly()), projectHandler.CreateProject)
+			projects.GET("/:projectId", projectHandler.GetProject)
+			projects.PATCH("/:projectId", authz.Require(middleware.AdminOnly()), projectHandler.UpdateProject)
+			projects.DELETE("/:projectId", authz.Require(middleware.AdminOnly()), projectHandler.DeleteProject)
+			projects.GET("/:projectId/settings", projectHandler.GetProjectSettings)
+			projects.PUT("/:projectId/settings", authz.Require(middleware.AdminOnly()), projectHandler.UpdateProjectSettings)
 		}
 
 		// Documentation routes
 		documentations := v1.Group("/documentations")
 		{
-			documentations.POST("", docHandler.CreateDocumentation)
+			documentations.POST("", authz.Require(middleware.TeamMember(middleware.TeamFromBody("team_id"))), docHandler.CreateDocumentation)
 			documentations.GET("/:id", docHandler.GetDocumentationByID)
-			documentations.PATCH("/:id", docHandler.UpdateDocumentation)
-			documentations.DELETE("/:id", docHandler.DeleteDocumentation)
+			documentations.PATCH("/:id", authz.Require(middleware.TeamMember(middleware.TeamFromDocumentation("id"))), docHandler.UpdateDocumentation)
+			documentations.DELETE("/:id", authz.Require(middleware.TeamMember(middleware.TeamFromDocumentation("id"))), docHandler.DeleteDocumentation)
 		}
 
 		// Component routes
 		components := v1.Group("/components")
 		{
-			components.GET("", componentHandler.ListComponents)
+			components.GET("", componentHandler.ListComponents) // ?team-id=<id>|project-name=<name>&lifecycle=<states>
+			components.POST("", authz.Require(middleware.TeamManager(middleware.TeamFromBody("owner_id"))), componentHandler.CreateComponent)
+			components.GET("/:id", componentHandler.GetComponent)
+			components.PATCH("/:id", authz.Require(middleware.TeamManager(middleware.TeamFromComponent("id"))), componentHandler.UpdateComponent)
+			components.DELETE("/:id", authz.Require(middleware.TeamManager(middleware.TeamFromComponent("id"))), componentHandler.DeleteComponent)
+			// The caller must manage both the current and the new owning team
+			components.PUT("/:id/owner",
+				authz.Require(middleware.TeamManager(middleware.TeamFromComponent("id"))),
+				authz.Require(middleware.TeamManager(middleware.TeamFromBody("owner_id"))),
+				componentHandler.TransferOwnership)
+			components.GET("/:id/ownership-history", componentHandler.GetOwnershipHistory)
 		}
 
-		// Query-param endpoint: /api/v1/landscapes?project-name=<project_name>
-		v1.GET("/landscapes", landscapeHandler.ListLandscapesByQuery)
+		// Landscape routes; landscapes belong to projects, which only admins manage
+		landscapes := v1.Group("/landscapes")
+		{
+			landscapes.GET("", landscapeHandler.ListLandscapesByQuery)   // GET /landscapes?project-name=<project_name>
+			landscapes.GET("/search", landscapeHandler.SearchLandscapes) // GET /landscapes/search?domain=<d>&environment=<e>&central-region=true&page=1
+			landscapes.POST("", authz.Require(middleware.AdminOnly()), landscapeHandler.CreateLandscape)
+			landscapes.GET("/:id", landscapeHandler.GetLandscape)
+			landscapes.PUT("/:id", authz.Require(middleware.AdminOnly()), landscapeHandler.UpdateLandscape)
+			landscapes.DELETE("/:id", authz.Require(middleware.AdminOnly()), landscapeHandler.DeleteLandscape)
+		}
 
 		// CIS public endpoints proxy: /api/v1/cis-public/proxy?url=<component_public_url>
 		// Used for proxying health checks, version info, and other public endpoints
@@ -258,6 +404,13 @@ func SetupRoutes(db *gorm.DB, cfg *config.Config) *gin.Engine {
 			alerts.POST("/pr", alertsHandler.CreateAlertPR) // POST /api/v1/projects/:projectId/alerts/pr
 		}
 
+		// Metadata schema routes, e.g. for rendering metadata forms
+		schemas := v1.Group("/metadata-schemas")
+		{
+			schemas.GET("", metadataSchemaHandler.ListMetadataSchemas)
+			schemas.GET("/:entity", metadataSchemaHandler.GetMetadataSchema)
+		}
+
 		// Category routes
 		categories := v1.Group("/categories")
 		{
@@ -269,7 +422,49 @@ func SetupRoutes(db *gorm.DB, cfg *config.Config) *gin.Engine {
 		{
 			links.GET("", linkHandler.ListLinks) // GET /api/v1/links?owner=<user_id>
 			links.POST("", linkHandler.CreateLink)
-			links.DELETE("/:id", linkHandler.DeleteLink)
+			links.GET("/broken", linkCheckHandler.GetBrokenLinks) // GET /api/v1/links/broken
+			links.DELETE("/:id", authz.Require(middleware.LinkOwner("id")), linkHandler.DeleteLink)
+		}
+
+		// Personal access token routes
+		tokens := v1.Group("/tokens")
+		{
+			tokens.GET("", authHandler.ListAPITokens)
+			tokens.POST("", authHandler.CreateAPIToken)
+			tokens.DELETE("/:id", authHandler.RevokeAPIToken)
+		}
+
+		// Linked provider identity routes
+		identities := v1.Group("/identities")
+		{
+			identities.GET("", authHandler.ListIdentities)
+			identities.DELETE("/:provider", authHandler.UnlinkIdentity)
+		}
+
+		// Admin routes
+		admin := v1.Group("/admin")
+		admin.Use(authMiddleware.RequireAdmin())
+		{
+			admin.POST("/sessions/revoke", authHandler.RevokeUserSessions) // POST /api/v1/admin/sessions/revoke
+			admin.GET("/service-accounts", authHandler.ListServiceAccounts)
+			admin.POST("/service-accounts", authHandler.CreateServiceAccount)
+			admin.DELETE("/service-accounts/:name", authHandler.DeleteServiceAccount)
+			admin.GET("/service-accounts/:name/tokens", authHandler.ListServiceAccountTokens)
+			admin.POST("/service-accounts/:name/tokens", authHandler.CreateServiceAccountToken)
+			admin.DELETE("/service-accounts/:name/tokens/:id", authHandler.RevokeServiceAccountToken)
+			admin.POST("/impersonations", authHandler.StartImpersonation)                       // POST /api/v1/admin/impersonations
+			admin.GET("/impersonations/audit", authHandler.ListImpersonationAudit)              // GET /api/v1/admin/impersonations/audit
+			admin.GET("/trash", trashHandler.ListTrash)                                         // GET /api/v1/admin/trash?type=team
+			admin.POST("/trash/:type/:id/restore", trashHandler.RestoreTrashItem)               // POST /api/v1/admin/trash/team/<id>/restore
+			admin.GET("/ldap-sync", directorySyncHandler.GetReport)                             // GET /api/v1/admin/ldap-sync
+			admin.POST("/ldap-sync", directorySyncHandler.RunSync)                              // POST /api/v1/admin/ldap-sync
+			admin.GET("/membership-drift", membershipDriftHandler.GetDriftReport)               // GET /api/v1/admin/membership-drift
+			admin.POST("/membership-drift/apply", membershipDriftHandler.ApplyDrift)            // POST /api/v1/admin/membership-drift/apply
+			admin.GET("/users/:user_id/offboarding", userOffboardingHandler.PreviewOffboarding) // GET /api/v1/admin/users/I123456/offboarding
+			admin.POST("/users/:user_id/offboarding", userOffboardingHandler.OffboardUser)      // POST /api/v1/admin/users/I123456/offboarding
+			admin.GET("/offboardings", userOffboardingHandler.GetOffboardingHistory)            // GET /api/v1/admin/offboardings?limit=20
+			admin.GET("/link-check", linkCheckHandler.GetLastRun)                               // GET /api/v1/admin/link-check
+			admin.POST("/link-check", linkCheckHandler.StartLinkCheck)                          // POST /api/v1/admin/link-check
 		}
 
 		// Nested resource routes moved to respective groups to avoid conflicts
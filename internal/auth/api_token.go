@@ -0,0 +1,529 @@
+package auth
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	apperrors "developer-portal-backend/internal/errors"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// APITokenPrefix marks personal access and service account tokens, so they can be told apart from JWTs
+const APITokenPrefix = "pat_"
+
+// API token scopes
+const (
+	ScopeRead  = "read"  // GET, HEAD and OPTIONS requests
+	ScopeWrite = "write" // requests of any method, implies read
+	ScopeAdmin = "admin" // admin endpoints; only admins can create tokens with this scope
+)
+
+// Token types reported in AuthClaims.TokenType. Portal JWTs leave it empty.
+const (
+	TokenTypePersonal       = "personal_access_token"
+	TokenTypeServiceAccount = "service_account"
+)
+
+// ServiceAccountProvider is the provider reported for service account tokens
+const ServiceAccountProvider = "service-account"
+
+const (
+	// defaultAPITokenTTL is the lifetime of API tokens created without an explicit expiry
+	defaultAPITokenTTL = 90 * 24 * time.Hour
+	// maxAPITokenTTL is the longest lifetime an API token may be created with
+	maxAPITokenTTL = 365 * 24 * time.Hour
+	// apiTokenUsageInterval limits how often the last-used time of a token is written
+	apiTokenUsageInterval = time.Minute
+)
+
+// serviceAccountNamePattern restricts service account names to lowercase slugs
+var serviceAccountNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{1,39}$`)
+
+// APIToken is a personal access token or service account token. Personal access tokens act as
+// the provider identity (UserID, Username, Email, Provider) of the user who created them.
+type APIToken struct {
+	ID             string     `json:"id" example:"3fa85f64-5717-4562-b3fc-2c963f66afa6"`
+	Name           string     `json:"name" example:"ci-pipeline"`
+	Prefix         string     `json:"prefix" example:"pat_Xk3v9Q"`
+	Scopes         []string   `json:"scopes" example:"read"`
+	ServiceAccount string     `json:"service_account,omitempty" example:"release-bot"`
+	UserID         int64      `json:"user_id,omitempty" example:"12345"`
+	Username       string     `json:"username" example:"johndoe"`
+	Email          string     `json:"email,omitempty" example:"john.doe@example.com"`
+	Provider       string     `json:"provider" example:"githubtools"`
+	EmailVerified  bool       `json:"-"` // whether the provider had verified Email when the token was created
+	CreatedBy      string     `json:"created_by" example:"johndoe"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	LastUsedAt     *time.Time `json:"last_used_at,omitempty"`
+}
+
+// ServiceAccount is a non-human identity that authenticates with API tokens
+type ServiceAccount struct {
+	Name        string    `json:"name" example:"release-bot"`
+	Email       string    `json:"email,omitempty" example:"release-team@example.com"`
+	Description string    `json:"description,omitempty" example:"Creates release pull requests"`
+	CreatedBy   string    `json:"created_by" example:"johndoe"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// APITokenStore persists API tokens. Like refresh tokens, a token is looked up by its hash
+// (see hashToken) and the raw value is never stored.
+type APITokenStore interface {
+	// Save stores a new token under the given hash
+	Save(tokenHash string, token *APIToken) error
+	// Get returns the token with the given ID, or apperrors.ErrAPITokenNotFound if unknown
+	Get(id string) (*APIToken, error)
+	// GetByHash returns the token for the hash, or apperrors.ErrInvalidAPIToken if unknown
+	GetByHash(tokenHash string) (*APIToken, error)
+	// ListPersonal returns the personal access tokens of the provider user, newest first
+	ListPersonal(userID int64, provider string) ([]APIToken, error)
+	// ListByServiceAccount returns the tokens of the service account, newest first
+	ListByServiceAccount(name string) ([]APIToken, error)
+	// MarkUsed records the time the token was last used
+	MarkUsed(id string, at time.Time) error
+	// Delete removes the token; deleting an unknown token is not an error
+	Delete(id string) error
+	// DeletePersonalByEmail removes the personal access tokens of every provider identity with the
+	// email (case-insensitive) and returns how many were removed
+	DeletePersonalByEmail(email string) (int64, error)
+}
+
+// ServiceAccountStore persists service accounts
+type ServiceAccountStore interface {
+	// Save stores a new service account, or returns apperrors.ErrServiceAccountExists
+	Save(account *ServiceAccount) error
+	// Get returns the service account, or apperrors.ErrServiceAccountNotFound if unknown
+	Get(name string) (*ServiceAccount, error)
+	// List returns all service accounts ordered by name
+	List() ([]ServiceAccount, error)
+	// Delete removes the service account, or returns apperrors.ErrServiceAccountNotFound if unknown
+	Delete(name string) error
+}
+
+// IsAPIToken reports whether the claims were derived from an API token rather than a portal JWT
+func (c *AuthClaims) IsAPIToken() bool {
+	return c.TokenType != ""
+}
+
+// HasScope reports whether the claims grant the scope. Portal JWTs grant every scope but admin,
+// which is decided by the auth config admins alone; impersonation sessions only grant write if
+// they were started with it.
+func (c *AuthClaims) HasScope(scope string) bool {
+	if c.IsImpersonation() && scope == ScopeWrite && !c.Impersonation.AllowWrite {
+		return false
+	}
+	if !c.IsAPIToken() {
+		return scope != ScopeAdmin
+	}
+	for _, granted := range c.Scopes {
+		if granted == scope || (granted == ScopeWrite && scope == ScopeRead) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsMethod reports whether the claims' scopes allow a request with the given HTTP method
+func (c *AuthClaims) AllowsMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return c.HasScope(ScopeRead)
+	default:
+		return c.HasScope(ScopeWrite)
+	}
+}
+
+// Authenticate validates a bearer token: an API token if it carries APITokenPrefix, a portal JWT otherwise
+func (s *AuthService) Authenticate(tokenString string) (*AuthClaims, error) {
+	if strings.HasPrefix(tokenString, APITokenPrefix) {
+		return s.ValidateAPIToken(tokenString)
+	}
+	return s.ValidateJWT(tokenString)
+}
+
+// ValidateAPIToken looks up an API token and returns claims for the identity it acts as
+func (s *AuthService) ValidateAPIToken(tokenString string) (*AuthClaims, error) {
+	token, err := s.apiTokens.GetByHash(hashToken(tokenString))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !now.Before(token.ExpiresAt) {
+		return nil, apperrors.ErrAPITokenExpired
+	}
+
+	// Writing on every request would turn each read into a write, so the time is coarse
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= apiTokenUsageInterval {
+		if err := s.apiTokens.MarkUsed(token.ID, now); err != nil {
+			log.Printf("Warning: failed to record use of API token %s: %v", token.ID, err)
+		}
+	}
+
+	claims := &AuthClaims{
+		UserID:        token.UserID,
+		Username:      token.Username,
+		Email:         token.Email,
+		Provider:      token.Provider,
+		EmailVerified: token.EmailVerified,
+		TokenType:     TokenTypePersonal,
+		Scopes:        token.Scopes,
+		Subject:       fmt.Sprintf("%d", token.UserID),
+		ExpiresAt:     token.ExpiresAt.Unix(),
+		IssuedAt:      token.CreatedAt.Unix(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID: token.ID,
+		},
+	}
+	if token.ServiceAccount != "" {
+		claims.TokenType = TokenTypeServiceAccount
+		claims.Subject = token.ServiceAccount
+	} else if s.revoked.isRevoked(claims) {
+		// RevokeUserSessions deletes the tokens too; this covers replicas that have not synced yet
+		return nil, apperrors.ErrTokenRevoked
+	}
+	return claims, nil
+}
+
+// CreatePersonalAccessToken creates a token acting as the caller. It returns the raw token,
+// which is not stored and cannot be shown again, and the stored token. A zero ttl uses the default.
+func (s *AuthService) CreatePersonalAccessToken(claims *AuthClaims, name string, scopes []string, ttl time.Duration) (string, *APIToken, error) {
+	if claims.IsAPIToken() {
+		return "", nil, &apperrors.AuthorizationError{Message: "API tokens cannot be used to create API tokens"}
+	}
+	if claims.IsImpersonation() {
+		return "", nil, &apperrors.AuthorizationError{Message: "impersonation sessions cannot create API tokens"}
+	}
+	scopes, err := normalizeScopes(scopes)
+	if err != nil {
+		return "", nil, err
+	}
+	if containsString(scopes, ScopeAdmin) && !s.IsAdmin(claims) {
+		return "", nil, &apperrors.AuthorizationError{Message: "only admins can create tokens with the admin scope"}
+	}
+
+	return s.issueAPIToken(&APIToken{
+		Name:          name,
+		Scopes:        scopes,
+		UserID:        claims.UserID,
+		Username:      claims.Username,
+		Email:         claims.Email,
+		Provider:      claims.Provider,
+		EmailVerified: claims.EmailVerified,
+		CreatedBy:     claims.Username,
+	}, ttl)
+}
+
+// ListPersonalAccessTokens returns the caller's personal access tokens
+func (s *AuthService) ListPersonalAccessTokens(claims *AuthClaims) ([]APIToken, error) {
+	return s.apiTokens.ListPersonal(claims.UserID, claims.Provider)
+}
+
+// RevokePersonalAccessToken deletes one of the caller's personal access tokens.
+// Tokens of other users are reported as not found.
+func (s *AuthService) RevokePersonalAccessToken(claims *AuthClaims, id string) error {
+	token, err := s.apiTokens.Get(id)
+	if err != nil {
+		return err
+	}
+	if token.ServiceAccount != "" || token.UserID != claims.UserID || token.Provider != claims.Provider {
+		return apperrors.ErrAPITokenNotFound
+	}
+	return s.apiTokens.Delete(id)
+}
+
+// CreateServiceAccount registers a new service account
+func (s *AuthService) CreateServiceAccount(account *ServiceAccount) error {
+	if !serviceAccountNamePattern.MatchString(account.Name) {
+		return &apperrors.ValidationError{Field: "name", Message: "must be 2-40 lowercase letters, digits, '.', '_' or '-'"}
+	}
+	account.CreatedAt = time.Now()
+	return s.serviceAccounts.Save(account)
+}
+
+// ListServiceAccounts returns all service accounts
+func (s *AuthService) ListServiceAccounts() ([]ServiceAccount, error) {
+	return s.serviceAccounts.List()
+}
+
+// DeleteServiceAccount removes a service account and all of its tokens
+func (s *AuthService) DeleteServiceAccount(name string) error {
+	tokens, err := s.ListServiceAccountTokens(name)
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		if err := s.apiTokens.Delete(token.ID); err != nil {
+			return fmt.Errorf("failed to revoke token %s: %w", token.ID, err)
+		}
+	}
+	return s.serviceAccounts.Delete(name)
+}
+
+// CreateServiceAccountToken creates a token for the service account. It returns the raw token,
+// which is not stored and cannot be shown again, and the stored token. A zero ttl uses the default.
+func (s *AuthService) CreateServiceAccountToken(accountName, createdBy, name string, scopes []string, ttl time.Duration) (string, *APIToken, error) {
+	account, err := s.serviceAccounts.Get(accountName)
+	if err != nil {
+		return "", nil, err
+	}
+	scopes, err = normalizeScopes(scopes)
+	if err != nil {
+		return "", nil, err
+	}
+	if containsString(scopes, ScopeAdmin) {
+		return "", nil, &apperrors.ValidationError{Field: "scopes", Message: "service accounts cannot be granted the admin scope"}
+	}
+
+	return s.issueAPIToken(&APIToken{
+		Name:           name,
+		Scopes:         scopes,
+		ServiceAccount: account.Name,
+		Username:       account.Name,
+		Email:          account.Email,
+		Provider:       ServiceAccountProvider,
+		CreatedBy:      createdBy,
+	}, ttl)
+}
+
+// ListServiceAccountTokens returns the tokens of the service account
+func (s *AuthService) ListServiceAccountTokens(accountName string) ([]APIToken, error) {
+	if _, err := s.serviceAccounts.Get(accountName); err != nil {
+		return nil, err
+	}
+	return s.apiTokens.ListByServiceAccount(accountName)
+}
+
+// RevokeServiceAccountToken deletes a token of the service account
+func (s *AuthService) RevokeServiceAccountToken(accountName, id string) error {
+	token, err := s.apiTokens.Get(id)
+	if err != nil {
+		return err
+	}
+	if token.ServiceAccount != accountName {
+		return apperrors.ErrAPITokenNotFound
+	}
+	return s.apiTokens.Delete(id)
+}
+
+// issueAPIToken generates a token value and stores the token under its hash
+func (s *AuthService) issueAPIToken(token *APIToken, ttl time.Duration) (string, *APIToken, error) {
+	if strings.TrimSpace(token.Name) == "" {
+		return "", nil, &apperrors.ValidationError{Field: "name", Message: "is required"}
+	}
+	if ttl == 0 {
+		ttl = defaultAPITokenTTL
+	}
+	if ttl < 0 || ttl > maxAPITokenTTL {
+		return "", nil, &apperrors.ValidationError{Field: "expires_in_days", Message: fmt.Sprintf("must be between 1 and %d days", int(maxAPITokenTTL.Hours()/24))}
+	}
+
+	secret, err := s.generateRandomString(32)
+	if err != nil {
+		return "", nil, err
+	}
+	raw := APITokenPrefix + strings.TrimRight(secret, "=")
+
+	now := time.Now()
+	token.ID = uuid.NewString()
+	token.Prefix = raw[:len(APITokenPrefix)+6]
+	token.CreatedAt = now
+	token.ExpiresAt = now.Add(ttl)
+	if err := s.apiTokens.Save(hashToken(raw), token); err != nil {
+		return "", nil, fmt.Errorf("failed to store API token: %w", err)
+	}
+	return raw, token, nil
+}
+
+// normalizeScopes validates the requested scopes and returns them sorted and without duplicates
+func normalizeScopes(scopes []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var normalized []string
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		switch scope {
+		case ScopeRead, ScopeWrite, ScopeAdmin:
+		default:
+			return nil, &apperrors.ValidationError{Field: "scopes", Message: fmt.Sprintf("unknown scope %q", scope)}
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			normalized = append(normalized, scope)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, &apperrors.ValidationError{Field: "scopes", Message: "at least one scope is required"}
+	}
+	sort.Strings(normalized)
+	return normalized, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// memoryAPITokenStore is an in-process APITokenStore for tests and local development
+type memoryAPITokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]*APIToken // by hash
+}
+
+// NewMemoryAPITokenStore creates an in-memory API token store
+func NewMemoryAPITokenStore() APITokenStore {
+	return &memoryAPITokenStore{tokens: make(map[string]*APIToken)}
+}
+
+func (m *memoryAPITokenStore) Save(tokenHash string, token *APIToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored := *token
+	m.tokens[tokenHash] = &stored
+	return nil
+}
+
+func (m *memoryAPITokenStore) Get(id string) (*APIToken, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, token := range m.tokens {
+		if token.ID == id {
+			found := *token
+			return &found, nil
+		}
+	}
+	return nil, apperrors.ErrAPITokenNotFound
+}
+
+func (m *memoryAPITokenStore) GetByHash(tokenHash string) (*APIToken, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	token, exists := m.tokens[tokenHash]
+	if !exists {
+		return nil, apperrors.ErrInvalidAPIToken
+	}
+	found := *token
+	return &found, nil
+}
+
+func (m *memoryAPITokenStore) ListPersonal(userID int64, provider string) ([]APIToken, error) {
+	return m.list(func(t *APIToken) bool {
+		return t.ServiceAccount == "" && t.UserID == userID && t.Provider == provider
+	}), nil
+}
+
+func (m *memoryAPITokenStore) ListByServiceAccount(name string) ([]APIToken, error) {
+	return m.list(func(t *APIToken) bool { return t.ServiceAccount == name }), nil
+}
+
+func (m *memoryAPITokenStore) list(match func(*APIToken) bool) []APIToken {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	tokens := []APIToken{}
+	for _, token := range m.tokens {
+		if match(token) {
+			tokens = append(tokens, *token)
+		}
+	}
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].CreatedAt.After(tokens[j].CreatedAt) })
+	return tokens
+}
+
+func (m *memoryAPITokenStore) MarkUsed(id string, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, token := range m.tokens {
+		if token.ID == id {
+			token.LastUsedAt = &at
+		}
+	}
+	return nil
+}
+
+func (m *memoryAPITokenStore) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for hash, token := range m.tokens {
+		if token.ID == id {
+			delete(m.tokens, hash)
+		}
+	}
+	return nil
+}
+
+func (m *memoryAPITokenStore) DeletePersonalByEmail(email string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var removed int64
+	for hash, token := range m.tokens {
+		if token.ServiceAccount == "" && strings.EqualFold(token.Email, email) {
+			delete(m.tokens, hash)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// memoryServiceAccountStore is an in-process ServiceAccountStore for tests and local development
+type memoryServiceAccountStore struct {
+	mu       sync.RWMutex
+	accounts map[string]ServiceAccount
+}
+
+// NewMemoryServiceAccountStore creates an in-memory service account store
+func NewMemoryServiceAccountStore() ServiceAccountStore {
+	return &memoryServiceAccountStore{accounts: make(map[string]ServiceAccount)}
+}
+
+func (m *memoryServiceAccountStore) Save(account *ServiceAccount) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, exists := m.accounts[account.Name]; exists {
+		return apperrors.ErrServiceAccountExists
+	}
+	m.accounts[account.Name] = *account
+	return nil
+}
+
+func (m *memoryServiceAccountStore) Get(name string) (*ServiceAccount, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	account, exists := m.accounts[name]
+	if !exists {
+		return nil, apperrors.ErrServiceAccountNotFound
+	}
+	return &account, nil
+}
+
+func (m *memoryServiceAccountStore) List() ([]ServiceAccount, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	accounts := make([]ServiceAccount, 0, len(m.accounts))
+	for _, account := range m.accounts {
+		accounts = append(accounts, account)
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].Name < accounts[j].Name })
+	return accounts, nil
+}
+
+func (m *memoryServiceAccountStore) Delete(name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, exists := m.accounts[name]; !exists {
+		return apperrors.ErrServiceAccountNotFound
+	}
+	delete(m.accounts, name)
+	return nil
+}
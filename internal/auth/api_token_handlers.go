@@ -0,0 +1,287 @@
+package auth
+
+import (
+	"net/http"
+	"time"
+
+	apperrors "developer-portal-backend/internal/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateAPITokenRequest represents the request for creating a personal access or service account token
+type CreateAPITokenRequest struct {
+	Name          string   `json:"name" binding:"required,max=100" example:"ci-pipeline"`
+	Scopes        []string `json:"scopes" binding:"required,min=1" example:"read"`
+	ExpiresInDays int      `json:"expires_in_days,omitempty" binding:"omitempty,min=1,max=365" example:"90"`
+}
+
+// CreateAPITokenResponse represents a newly created API token. Token is only returned once.
+type CreateAPITokenResponse struct {
+	Token string `json:"token" example:"pat_Xk3v9Q..."`
+	APIToken
+}
+
+// CreateServiceAccountRequest represents the request for creating a service account
+type CreateServiceAccountRequest struct {
+	Name        string `json:"name" binding:"required" example:"release-bot"`
+	Email       string `json:"email,omitempty" binding:"omitempty,email" example:"release-team@example.com"`
+	Description string `json:"description,omitempty" binding:"max=200" example:"Creates release pull requests"`
+}
+
+// ListAPITokens handles GET /api/v1/tokens
+// @Summary List personal access tokens
+// @Description List the personal access tokens of the authenticated user. Token values are never returned.
+// @Tags api-tokens
+// @Produce json
+// @Success 200 {array} APIToken "Personal access tokens"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 500 {object} map[string]interface{} "Failed to list tokens"
+// @Security BearerAuth
+// @Router /api/v1/tokens [get]
+func (h *AuthHandler) ListAPITokens(c *gin.Context) {
+	claims, ok := GetAuthClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	tokens, err := h.service.ListPersonalAccessTokens(claims)
+	if err != nil {
+		writeAPITokenError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+// CreateAPIToken handles POST /api/v1/tokens
+// @Summary Create a personal access token
+// @Description Create a token that acts as the authenticated user. The token value is only returned in this response. Tokens cannot be created with another API token.
+// @Tags api-tokens
+// @Accept json
+// @Produce json
+// @Param request body CreateAPITokenRequest true "Token name, scopes (read, write, admin) and lifetime"
+// @Success 201 {object} CreateAPITokenResponse "Token created"
+// @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Scope not allowed"
+// @Failure 500 {object} map[string]interface{} "Failed to create token"
+// @Security BearerAuth
+// @Router /api/v1/tokens [post]
+func (h *AuthHandler) CreateAPIToken(c *gin.Context) {
+	claims, ok := GetAuthClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var req CreateAPITokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	raw, token, err := h.service.CreatePersonalAccessToken(claims, req.Name, req.Scopes, expiresIn(req.ExpiresInDays))
+	if err != nil {
+		writeAPITokenError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, CreateAPITokenResponse{Token: raw, APIToken: *token})
+}
+
+// RevokeAPIToken handles DELETE /api/v1/tokens/{id}
+// @Summary Revoke a personal access token
+// @Description Delete one of the authenticated user's personal access tokens
+// @Tags api-tokens
+// @Param id path string true "Token ID"
+// @Success 204 "Token revoked"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 404 {object} map[string]interface{} "Token not found"
+// @Failure 500 {object} map[string]interface{} "Failed to revoke token"
+// @Security BearerAuth
+// @Router /api/v1/tokens/{id} [delete]
+func (h *AuthHandler) RevokeAPIToken(c *gin.Context) {
+	claims, ok := GetAuthClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	if err := h.service.RevokePersonalAccessToken(claims, c.Param("id")); err != nil {
+		writeAPITokenError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListServiceAccounts handles GET /api/v1/admin/service-accounts
+// @Summary List service accounts
+// @Description List all service accounts. Requires admin privileges.
+// @Tags api-tokens
+// @Produce json
+// @Success 200 {array} ServiceAccount "Service accounts"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 500 {object} map[string]interface{} "Failed to list service accounts"
+// @Security BearerAuth
+// @Router /api/v1/admin/service-accounts [get]
+func (h *AuthHandler) ListServiceAccounts(c *gin.Context) {
+	accounts, err := h.service.ListServiceAccounts()
+	if err != nil {
+		writeAPITokenError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, accounts)
+}
+
+// CreateServiceAccount handles POST /api/v1/admin/service-accounts
+// @Summary Create a service account
+// @Description Create a non-human identity that authenticates with API tokens. Requires admin privileges.
+// @Tags api-tokens
+// @Accept json
+// @Produce json
+// @Param request body CreateServiceAccountRequest true "Service account"
+// @Success 201 {object} ServiceAccount "Service account created"
+// @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 409 {object} map[string]interface{} "Service account already exists"
+// @Failure 500 {object} map[string]interface{} "Failed to create service account"
+// @Security BearerAuth
+// @Router /api/v1/admin/service-accounts [post]
+func (h *AuthHandler) CreateServiceAccount(c *gin.Context) {
+	var req CreateServiceAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	createdBy, _ := GetUsername(c)
+	account := &ServiceAccount{
+		Name:        req.Name,
+		Email:       req.Email,
+		Description: req.Description,
+		CreatedBy:   createdBy,
+	}
+	if err := h.service.CreateServiceAccount(account); err != nil {
+		writeAPITokenError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, account)
+}
+
+// DeleteServiceAccount handles DELETE /api/v1/admin/service-accounts/{name}
+// @Summary Delete a service account
+// @Description Delete a service account and revoke all of its tokens. Requires admin privileges.
+// @Tags api-tokens
+// @Param name path string true "Service account name"
+// @Success 204 "Service account deleted"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 404 {object} map[string]interface{} "Service account not found"
+// @Failure 500 {object} map[string]interface{} "Failed to delete service account"
+// @Security BearerAuth
+// @Router /api/v1/admin/service-accounts/{name} [delete]
+func (h *AuthHandler) DeleteServiceAccount(c *gin.Context) {
+	if err := h.service.DeleteServiceAccount(c.Param("name")); err != nil {
+		writeAPITokenError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ListServiceAccountTokens handles GET /api/v1/admin/service-accounts/{name}/tokens
+// @Summary List service account tokens
+// @Description List the tokens of a service account. Token values are never returned. Requires admin privileges.
+// @Tags api-tokens
+// @Produce json
+// @Param name path string true "Service account name"
+// @Success 200 {array} APIToken "Service account tokens"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 404 {object} map[string]interface{} "Service account not found"
+// @Failure 500 {object} map[string]interface{} "Failed to list tokens"
+// @Security BearerAuth
+// @Router /api/v1/admin/service-accounts/{name}/tokens [get]
+func (h *AuthHandler) ListServiceAccountTokens(c *gin.Context) {
+	tokens, err := h.service.ListServiceAccountTokens(c.Param("name"))
+	if err != nil {
+		writeAPITokenError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, tokens)
+}
+
+// CreateServiceAccountToken handles POST /api/v1/admin/service-accounts/{name}/tokens
+// @Summary Create a service account token
+// @Description Create a token for a service account. The token value is only returned in this response. Requires admin privileges.
+// @Tags api-tokens
+// @Accept json
+// @Produce json
+// @Param name path string true "Service account name"
+// @Param request body CreateAPITokenRequest true "Token name, scopes (read, write) and lifetime"
+// @Success 201 {object} CreateAPITokenResponse "Token created"
+// @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 404 {object} map[string]interface{} "Service account not found"
+// @Failure 500 {object} map[string]interface{} "Failed to create token"
+// @Security BearerAuth
+// @Router /api/v1/admin/service-accounts/{name}/tokens [post]
+func (h *AuthHandler) CreateServiceAccountToken(c *gin.Context) {
+	var req CreateAPITokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	createdBy, _ := GetUsername(c)
+	raw, token, err := h.service.CreateServiceAccountToken(c.Param("name"), createdBy, req.Name, req.Scopes, expiresIn(req.ExpiresInDays))
+	if err != nil {
+		writeAPITokenError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, CreateAPITokenResponse{Token: raw, APIToken: *token})
+}
+
+// RevokeServiceAccountToken handles DELETE /api/v1/admin/service-accounts/{name}/tokens/{id}
+// @Summary Revoke a service account token
+// @Description Delete a token of a service account. Requires admin privileges.
+// @Tags api-tokens
+// @Param name path string true "Service account name"
+// @Param id path string true "Token ID"
+// @Success 204 "Token revoked"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 404 {object} map[string]interface{} "Token not found"
+// @Failure 500 {object} map[string]interface{} "Failed to revoke token"
+// @Security BearerAuth
+// @Router /api/v1/admin/service-accounts/{name}/tokens/{id} [delete]
+func (h *AuthHandler) RevokeServiceAccountToken(c *gin.Context) {
+	if err := h.service.RevokeServiceAccountToken(c.Param("name"), c.Param("id")); err != nil {
+		writeAPITokenError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// expiresIn converts the requested lifetime in days; zero selects the default lifetime
+func expiresIn(days int) time.Duration {
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// writeAPITokenError maps errors of the API token endpoints to responses
+func writeAPITokenError(c *gin.Context, err error) {
+	switch {
+	case apperrors.IsValidation(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case apperrors.IsAuthorization(err):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden", "details": err.Error()})
+	case apperrors.IsNotFound(err):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case apperrors.IsAlreadyExists(err):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "API token operation failed", "details": err.Error()})
+	}
+}
@@ -0,0 +1,280 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apperrors "developer-portal-backend/internal/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAPITokenTestService(t *testing.T) (*AuthService, APITokenStore) {
+	store := NewMemoryAPITokenStore()
+	config := &AuthConfig{
+		JWTSecret:   "test-signing-key-for-api-tokens",
+		RedirectURL: "http://localhost:3000",
+		Providers: map[string]ProviderConfig{
+			"githubtools": {ClientID: "test-client-id", ClientSecret: "test-client-secret"},
+		},
+		Admins: []string{"githubtools:admin"},
+	}
+	service, err := NewAuthServiceWithStores(config, nil, Stores{APITokens: store})
+	require.NoError(t, err)
+	return service, store
+}
+
+var testUserClaims = &AuthClaims{UserID: 7, Username: "johndoe", Email: "john.doe@example.com", Provider: "githubtools"}
+
+func TestCreatePersonalAccessToken(t *testing.T) {
+	service, store := newAPITokenTestService(t)
+
+	raw, token, err := service.CreatePersonalAccessToken(testUserClaims, "ci", []string{"write", "read", "write"}, 0)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(raw, APITokenPrefix))
+	assert.True(t, strings.HasPrefix(raw, token.Prefix))
+	assert.Equal(t, []string{ScopeRead, ScopeWrite}, token.Scopes)
+	assert.WithinDuration(t, time.Now().Add(defaultAPITokenTTL), token.ExpiresAt, time.Minute)
+
+	// Only the hash is stored
+	stored, err := store.GetByHash(hashToken(raw))
+	require.NoError(t, err)
+	assert.Equal(t, token.ID, stored.ID)
+	_, err = store.GetByHash(raw)
+	assert.ErrorIs(t, err, apperrors.ErrInvalidAPIToken)
+
+	t.Run("rejects unknown scopes", func(t *testing.T) {
+		_, _, err := service.CreatePersonalAccessToken(testUserClaims, "ci", []string{"delete"}, 0)
+		assert.True(t, apperrors.IsValidation(err))
+	})
+
+	t.Run("rejects lifetimes above the maximum", func(t *testing.T) {
+		_, _, err := service.CreatePersonalAccessToken(testUserClaims, "ci", []string{"read"}, maxAPITokenTTL+time.Hour)
+		assert.True(t, apperrors.IsValidation(err))
+	})
+
+	t.Run("admin scope requires an admin", func(t *testing.T) {
+		_, _, err := service.CreatePersonalAccessToken(testUserClaims, "ci", []string{"admin"}, 0)
+		assert.True(t, apperrors.IsAuthorization(err))
+
+		admin := &AuthClaims{UserID: 1, Username: "admin", Provider: "githubtools"}
+		_, _, err = service.CreatePersonalAccessToken(admin, "ops", []string{"admin"}, 0)
+		assert.NoError(t, err)
+	})
+
+	t.Run("API tokens cannot create API tokens", func(t *testing.T) {
+		claims, err := service.ValidateAPIToken(raw)
+		require.NoError(t, err)
+		_, _, err = service.CreatePersonalAccessToken(claims, "nested", []string{"read"}, 0)
+		assert.True(t, apperrors.IsAuthorization(err))
+	})
+}
+
+func TestValidateAPIToken(t *testing.T) {
+	service, store := newAPITokenTestService(t)
+
+	raw, token, err := service.CreatePersonalAccessToken(testUserClaims, "cli", []string{"read"}, 24*time.Hour)
+	require.NoError(t, err)
+
+	claims, err := service.Authenticate(raw)
+	require.NoError(t, err)
+	assert.Equal(t, testUserClaims.UserID, claims.UserID)
+	assert.Equal(t, testUserClaims.Username, claims.Username)
+	assert.Equal(t, testUserClaims.Email, claims.Email)
+	assert.Equal(t, testUserClaims.Provider, claims.Provider)
+	assert.Equal(t, TokenTypePersonal, claims.TokenType)
+	assert.Equal(t, token.ID, claims.ID)
+	assert.True(t, claims.AllowsMethod(http.MethodGet))
+	assert.False(t, claims.AllowsMethod(http.MethodPost))
+
+	stored, err := store.Get(token.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored.LastUsedAt, "use is recorded")
+	firstUse := *stored.LastUsedAt
+
+	_, err = service.ValidateAPIToken(raw)
+	require.NoError(t, err)
+	stored, err = store.Get(token.ID)
+	require.NoError(t, err)
+	assert.Equal(t, firstUse, *stored.LastUsedAt, "use is recorded at most once per interval")
+
+	t.Run("unknown token", func(t *testing.T) {
+		_, err := service.Authenticate(APITokenPrefix + "unknown")
+		assert.ErrorIs(t, err, apperrors.ErrInvalidAPIToken)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		require.NoError(t, store.Save(hashToken(APITokenPrefix+"expired"), &APIToken{
+			ID:        "expired",
+			Name:      "old",
+			Scopes:    []string{ScopeRead},
+			CreatedAt: time.Now().Add(-48 * time.Hour),
+			ExpiresAt: time.Now().Add(-time.Hour),
+		}))
+		_, err := service.Authenticate(APITokenPrefix + "expired")
+		assert.ErrorIs(t, err, apperrors.ErrAPITokenExpired)
+	})
+
+	t.Run("revoked token", func(t *testing.T) {
+		other := &AuthClaims{UserID: 8, Username: "janedoe", Provider: "githubtools"}
+		assert.ErrorIs(t, service.RevokePersonalAccessToken(other, token.ID), apperrors.ErrAPITokenNotFound)
+
+		require.NoError(t, service.RevokePersonalAccessToken(testUserClaims, token.ID))
+		_, err := service.Authenticate(raw)
+		assert.ErrorIs(t, err, apperrors.ErrInvalidAPIToken)
+	})
+}
+
+func TestRevokeUserSessionsRevokesPersonalAccessTokens(t *testing.T) {
+	service, store := newAPITokenTestService(t)
+
+	raw, token, err := service.CreatePersonalAccessToken(testUserClaims, "cli", []string{"read"}, 24*time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, service.CreateServiceAccount(&ServiceAccount{Name: "release-bot", Email: testUserClaims.Email, CreatedBy: "admin"}))
+	botRaw, _, err := service.CreateServiceAccountToken("release-bot", "admin", "deploy", []string{"read"}, 0)
+	require.NoError(t, err)
+
+	refreshTokens, apiTokens, err := service.RevokeUserSessions("John.Doe@example.com", "admin", "compromised laptop")
+	require.NoError(t, err)
+	assert.Zero(t, refreshTokens)
+	assert.Equal(t, int64(1), apiTokens)
+
+	_, err = service.Authenticate(raw)
+	assert.ErrorIs(t, err, apperrors.ErrInvalidAPIToken)
+	_, err = service.Authenticate(botRaw)
+	assert.NoError(t, err, "service account tokens are not the user's")
+
+	t.Run("tokens created before the revocation are rejected", func(t *testing.T) {
+		// As on a replica that still has the token
+		require.NoError(t, store.Save(hashToken(raw), token))
+		_, err := service.Authenticate(raw)
+		assert.ErrorIs(t, err, apperrors.ErrTokenRevoked)
+	})
+}
+
+func TestAPITokenScopes(t *testing.T) {
+	jwtClaims := &AuthClaims{Username: "johndoe"}
+	assert.True(t, jwtClaims.AllowsMethod(http.MethodDelete), "portal JWTs are not limited by scopes")
+	assert.False(t, jwtClaims.HasScope(ScopeAdmin))
+
+	write := &AuthClaims{TokenType: TokenTypePersonal, Scopes: []string{ScopeWrite}}
+	assert.True(t, write.AllowsMethod(http.MethodGet))
+	assert.True(t, write.AllowsMethod(http.MethodPatch))
+
+	service, _ := newAPITokenTestService(t)
+	assert.False(t, service.IsAdmin(&AuthClaims{Username: "admin", Provider: "githubtools", TokenType: TokenTypePersonal, Scopes: []string{ScopeWrite}}))
+	assert.True(t, service.IsAdmin(&AuthClaims{Username: "admin", Provider: "githubtools", TokenType: TokenTypePersonal, Scopes: []string{ScopeAdmin}}))
+	assert.False(t, service.IsAdmin(&AuthClaims{Username: "admin", Provider: "githubtools", TokenType: TokenTypeServiceAccount, Scopes: []string{ScopeAdmin}}))
+}
+
+func TestServiceAccounts(t *testing.T) {
+	service, _ := newAPITokenTestService(t)
+
+	assert.True(t, apperrors.IsValidation(service.CreateServiceAccount(&ServiceAccount{Name: "Release Bot"})))
+	require.NoError(t, service.CreateServiceAccount(&ServiceAccount{Name: "release-bot", Email: "release@example.com", CreatedBy: "admin"}))
+	assert.ErrorIs(t, service.CreateServiceAccount(&ServiceAccount{Name: "release-bot"}), apperrors.ErrServiceAccountExists)
+
+	_, _, err := service.CreateServiceAccountToken("release-bot", "admin", "deploy", []string{"admin"}, 0)
+	assert.True(t, apperrors.IsValidation(err))
+	_, _, err = service.CreateServiceAccountToken("missing", "admin", "deploy", []string{"read"}, 0)
+	assert.ErrorIs(t, err, apperrors.ErrServiceAccountNotFound)
+
+	raw, token, err := service.CreateServiceAccountToken("release-bot", "admin", "deploy", []string{"write"}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "release-bot", token.ServiceAccount)
+
+	claims, err := service.Authenticate(raw)
+	require.NoError(t, err)
+	assert.Equal(t, TokenTypeServiceAccount, claims.TokenType)
+	assert.Equal(t, "release-bot", claims.Username)
+	assert.Equal(t, "release@example.com", claims.Email)
+	assert.Equal(t, ServiceAccountProvider, claims.Provider)
+
+	// Service account tokens are not personal access tokens of anyone
+	assert.ErrorIs(t, service.RevokePersonalAccessToken(&AuthClaims{}, token.ID), apperrors.ErrAPITokenNotFound)
+
+	tokens, err := service.ListServiceAccountTokens("release-bot")
+	require.NoError(t, err)
+	assert.Len(t, tokens, 1)
+
+	require.NoError(t, service.DeleteServiceAccount("release-bot"))
+	_, err = service.Authenticate(raw)
+	assert.ErrorIs(t, err, apperrors.ErrInvalidAPIToken, "tokens are revoked with their service account")
+	assert.ErrorIs(t, service.DeleteServiceAccount("release-bot"), apperrors.ErrServiceAccountNotFound)
+}
+
+func TestRequireAuthWithAPITokens(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	service, _ := newAPITokenTestService(t)
+	middleware := NewAuthMiddleware(service)
+	handler := NewAuthHandler(service)
+
+	router := gin.New()
+	v1 := router.Group("/api/v1", middleware.RequireAuth())
+	v1.GET("/whoami", func(c *gin.Context) {
+		userID, _ := GetUserID(c)
+		email, _ := GetUserEmail(c)
+		claims, _ := GetAuthClaims(c)
+		c.JSON(http.StatusOK, gin.H{"user_id": userID, "email": email, "token_type": claims.TokenType})
+	})
+	v1.POST("/things", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	v1.GET("/tokens", handler.ListAPITokens)
+	v1.POST("/tokens", handler.CreateAPIToken)
+	v1.DELETE("/tokens/:id", handler.RevokeAPIToken)
+
+	do := func(method, path, token, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	jwtToken, err := service.GenerateJWT(&UserProfile{ID: 7, Username: "johndoe", Email: "john.doe@example.com"}, "githubtools")
+	require.NoError(t, err)
+
+	w := do("POST", "/api/v1/tokens", jwtToken, `{"name":"ci","scopes":["read"],"expires_in_days":30}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created CreateAPITokenResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.NotEmpty(t, created.Token)
+	assert.WithinDuration(t, time.Now().Add(30*24*time.Hour), created.ExpiresAt, time.Minute)
+
+	t.Run("populates the same context as a JWT", func(t *testing.T) {
+		w := do("GET", "/api/v1/whoami", created.Token, "")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"user_id":7,"email":"john.doe@example.com","token_type":"personal_access_token"}`, w.Body.String())
+	})
+
+	t.Run("read scope cannot write", func(t *testing.T) {
+		assert.Equal(t, http.StatusForbidden, do("POST", "/api/v1/things", created.Token, "").Code)
+		assert.Equal(t, http.StatusCreated, do("POST", "/api/v1/things", jwtToken, "").Code)
+	})
+
+	t.Run("listing never returns token values", func(t *testing.T) {
+		w := do("GET", "/api/v1/tokens", jwtToken, "")
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.NotContains(t, w.Body.String(), created.Token)
+		assert.Contains(t, w.Body.String(), created.ID)
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, do("POST", "/api/v1/tokens", jwtToken, `{"name":"ci","scopes":[]}`).Code)
+		assert.Equal(t, http.StatusBadRequest, do("POST", "/api/v1/tokens", jwtToken, `{"name":"ci","scopes":["root"]}`).Code)
+		assert.Equal(t, http.StatusForbidden, do("POST", "/api/v1/tokens", jwtToken, `{"name":"ci","scopes":["admin"]}`).Code)
+	})
+
+	t.Run("revoked token is rejected", func(t *testing.T) {
+		assert.Equal(t, http.StatusNoContent, do("DELETE", "/api/v1/tokens/"+created.ID, jwtToken, "").Code)
+		assert.Equal(t, http.StatusNotFound, do("DELETE", "/api/v1/tokens/"+created.ID, jwtToken, "").Code)
+		assert.Equal(t, http.StatusUnauthorized, do("GET", "/api/v1/whoami", created.Token, "").Code)
+	})
+}
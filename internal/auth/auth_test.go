@@ -4,9 +4,16 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	apperrors "developer-portal-backend/internal/errors"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -364,3 +371,360 @@ func TestJWTExpiration(t *testing.T) {
 	assert.Equal(t, userProfile.Username, claims.Username)
 	assert.Equal(t, "githubtools", claims.Provider)
 }
+
+func newTestAuthServiceWithStore(t *testing.T, store TokenStore) *AuthService {
+	config := &AuthConfig{
+		JWTSecret:   "test-signing-key-for-token-store",
+		RedirectURL: "http://localhost:3000",
+		Providers: map[string]ProviderConfig{
+			"githubtools": {
+				ClientID:     "test-client-id",
+				ClientSecret: "test-client-secret",
+			},
+		},
+	}
+
+	service, err := NewAuthServiceWithStores(config, nil, Stores{Tokens: store})
+	require.NoError(t, err)
+	return service
+}
+
+func TestRefreshTokenRotation(t *testing.T) {
+	store := NewMemoryTokenStore()
+	service := newTestAuthServiceWithStore(t, store)
+
+	now := time.Now()
+	require.NoError(t, store.Save(hashToken("initial-refresh-token"), &RefreshTokenData{
+		UserID:      12345,
+		Username:    "testuser",
+		Email:       "test@example.com",
+		Provider:    "githubtools",
+		AccessToken: "gho_upstream",
+		ExpiresAt:   now.Add(time.Hour),
+		CreatedAt:   now,
+	}))
+
+	t.Run("rotates refresh token and keeps upstream session", func(t *testing.T) {
+		resp, err := service.RefreshToken("initial-refresh-token")
+		require.NoError(t, err)
+		assert.NotEmpty(t, resp.AccessToken)
+		assert.NotEqual(t, "initial-refresh-token", resp.RefreshToken)
+		assert.Equal(t, "testuser", resp.Profile.Username)
+
+		// Old token is gone, new one carries the same upstream access token
+		_, err = store.Get(hashToken("initial-refresh-token"))
+		assert.ErrorIs(t, err, apperrors.ErrInvalidRefreshToken)
+		data, err := store.Get(hashToken(resp.RefreshToken))
+		require.NoError(t, err)
+		assert.Equal(t, "gho_upstream", data.AccessToken)
+	})
+
+	t.Run("replayed refresh token is rejected", func(t *testing.T) {
+		_, err := service.RefreshToken("initial-refresh-token")
+		assert.ErrorIs(t, err, apperrors.ErrInvalidRefreshToken)
+	})
+
+	t.Run("expired refresh token is rejected and removed", func(t *testing.T) {
+		require.NoError(t, store.Save(hashToken("expired-refresh-token"), &RefreshTokenData{
+			UserID:    1,
+			Provider:  "githubtools",
+			ExpiresAt: time.Now().Add(-time.Minute),
+		}))
+
+		_, err := service.RefreshToken("expired-refresh-token")
+		assert.ErrorIs(t, err, apperrors.ErrRefreshTokenExpired)
+		_, err = store.Get(hashToken("expired-refresh-token"))
+		assert.ErrorIs(t, err, apperrors.ErrInvalidRefreshToken)
+	})
+}
+
+func TestGetGitHubAccessTokenFromClaimsUsesTokenStore(t *testing.T) {
+	store := NewMemoryTokenStore()
+	service := newTestAuthServiceWithStore(t, store)
+
+	now := time.Now()
+	require.NoError(t, store.Save(hashToken("older"), &RefreshTokenData{
+		UserID: 7, Provider: "githubtools", AccessToken: "older-token",
+		ExpiresAt: now.Add(time.Hour), CreatedAt: now.Add(-time.Hour),
+	}))
+	require.NoError(t, store.Save(hashToken("newer"), &RefreshTokenData{
+		UserID: 7, Provider: "githubtools", AccessToken: "newer-token",
+		ExpiresAt: now.Add(time.Hour), CreatedAt: now,
+	}))
+
+	token, err := service.GetGitHubAccessTokenFromClaims(&AuthClaims{UserID: 7, Provider: "githubtools"})
+	require.NoError(t, err)
+	assert.Equal(t, "newer-token", token)
+
+	_, err = service.GetGitHubAccessTokenFromClaims(&AuthClaims{UserID: 7, Provider: "githubwdf"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no valid GitHub session found")
+}
+
+func TestCleanupExpiredTokens(t *testing.T) {
+	store := NewMemoryTokenStore()
+	service := newTestAuthServiceWithStore(t, store)
+
+	require.NoError(t, store.Save(hashToken("expired"), &RefreshTokenData{UserID: 1, ExpiresAt: time.Now().Add(-time.Second)}))
+	require.NoError(t, store.Save(hashToken("active"), &RefreshTokenData{UserID: 2, ExpiresAt: time.Now().Add(time.Hour)}))
+
+	removed, err := service.CleanupExpiredTokens()
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed)
+
+	_, err = store.Get(hashToken("active"))
+	assert.NoError(t, err)
+}
+
+func TestNewAuthServiceWithStoresDefaultsToMemoryStores(t *testing.T) {
+	config := &AuthConfig{
+		JWTSecret:   "test-secret",
+		RedirectURL: "http://localhost:3000",
+		Providers:   map[string]ProviderConfig{"githubtools": {ClientID: "id", ClientSecret: "secret"}},
+	}
+
+	service, err := NewAuthServiceWithStores(config, nil, Stores{})
+	require.NoError(t, err)
+	assert.NotNil(t, service.tokenStore)
+	assert.NotNil(t, service.revocations)
+	assert.NotNil(t, service.oauthStates)
+}
+
+func TestValidateJWTRequiresExpiry(t *testing.T) {
+	service := newTestAuthServiceWithStore(t, NewMemoryTokenStore())
+	sign := func(claims *AuthClaims) string {
+		return signTestJWT(t, service, claims)
+	}
+
+	token, err := service.GenerateJWT(&UserProfile{ID: 1, Username: "testuser"}, "githubtools")
+	require.NoError(t, err)
+	claims, err := service.ValidateJWT(token)
+	require.NoError(t, err)
+	assert.NotZero(t, claims.ExpiresAt)
+	assert.NotZero(t, claims.IssuedAt)
+
+	_, err = service.ValidateJWT(sign(&AuthClaims{UserID: 1, ExpiresAt: time.Now().Add(-time.Minute).Unix()}))
+	assert.ErrorIs(t, err, jwt.ErrTokenExpired)
+
+	_, err = service.ValidateJWT(sign(&AuthClaims{UserID: 1}))
+	assert.Error(t, err, "tokens without exp are rejected")
+}
+
+func TestGenerateJWTSetsJTI(t *testing.T) {
+	service := newTestAuthServiceWithStore(t, NewMemoryTokenStore())
+	profile := &UserProfile{ID: 1, Username: "testuser", Email: "test@example.com"}
+
+	first, err := service.GenerateJWT(profile, "githubtools")
+	require.NoError(t, err)
+	second, err := service.GenerateJWT(profile, "githubtools")
+	require.NoError(t, err)
+
+	firstClaims, err := service.ValidateJWT(first)
+	require.NoError(t, err)
+	secondClaims, err := service.ValidateJWT(second)
+	require.NoError(t, err)
+	assert.NotEmpty(t, firstClaims.ID)
+	assert.NotEqual(t, firstClaims.ID, secondClaims.ID)
+}
+
+func TestLogoutRevokesTokens(t *testing.T) {
+	store := NewMemoryTokenStore()
+	service := newTestAuthServiceWithStore(t, store)
+	profile := &UserProfile{ID: 42, Username: "testuser", Email: "test@example.com"}
+
+	now := time.Now()
+	for _, token := range []string{"session-a", "session-b"} {
+		require.NoError(t, store.Save(hashToken(token), &RefreshTokenData{
+			UserID: 42, Email: "test@example.com", Provider: "githubtools",
+			ExpiresAt: now.Add(time.Hour), CreatedAt: now,
+		}))
+	}
+	require.NoError(t, store.Save(hashToken("other-provider"), &RefreshTokenData{
+		UserID: 42, Email: "test@example.com", Provider: "githubwdf",
+		ExpiresAt: now.Add(time.Hour), CreatedAt: now,
+	}))
+
+	loggedOut, err := service.GenerateJWT(profile, "githubtools")
+	require.NoError(t, err)
+	stillValid, err := service.GenerateJWT(profile, "githubtools")
+	require.NoError(t, err)
+	claims, err := service.ValidateJWT(loggedOut)
+	require.NoError(t, err)
+
+	require.NoError(t, service.Logout(claims, ""))
+
+	_, err = service.ValidateJWT(loggedOut)
+	assert.ErrorIs(t, err, apperrors.ErrTokenRevoked)
+	_, err = service.ValidateJWT(stillValid)
+	assert.NoError(t, err, "only the logged out token is revoked")
+
+	_, err = service.RefreshToken("session-a")
+	assert.ErrorIs(t, err, apperrors.ErrInvalidRefreshToken)
+	_, err = service.RefreshToken("session-b")
+	assert.ErrorIs(t, err, apperrors.ErrInvalidRefreshToken)
+	_, err = store.Get(hashToken("other-provider"))
+	assert.NoError(t, err, "sessions of other providers are kept")
+}
+
+func TestLogoutWithRefreshTokenOnly(t *testing.T) {
+	store := NewMemoryTokenStore()
+	service := newTestAuthServiceWithStore(t, store)
+	require.NoError(t, store.Save(hashToken("cookie-session"), &RefreshTokenData{UserID: 1, ExpiresAt: time.Now().Add(time.Hour)}))
+
+	require.NoError(t, service.Logout(nil, "cookie-session"))
+
+	_, err := store.Get(hashToken("cookie-session"))
+	assert.ErrorIs(t, err, apperrors.ErrInvalidRefreshToken)
+}
+
+func TestRevokeUserSessions(t *testing.T) {
+	store := NewMemoryTokenStore()
+	service := newTestAuthServiceWithStore(t, store)
+
+	require.NoError(t, store.Save(hashToken("tools"), &RefreshTokenData{UserID: 1, Email: "test@example.com", Provider: "githubtools", ExpiresAt: time.Now().Add(time.Hour)}))
+	require.NoError(t, store.Save(hashToken("wdf"), &RefreshTokenData{UserID: 2, Email: "Test@Example.com", Provider: "githubwdf", ExpiresAt: time.Now().Add(time.Hour)}))
+	require.NoError(t, store.Save(hashToken("other"), &RefreshTokenData{UserID: 3, Email: "other@example.com", Provider: "githubtools", ExpiresAt: time.Now().Add(time.Hour)}))
+
+	// Backdate the issued token so it is strictly older than the revocation
+	issued := time.Now().Add(-time.Minute)
+	signed := signTestJWT(t, service, &AuthClaims{
+		UserID: 1, Username: "testuser", Email: "test@example.com", Provider: "githubtools",
+		ExpiresAt:        issued.Add(time.Hour).Unix(),
+		IssuedAt:         issued.Unix(),
+		RegisteredClaims: jwt.RegisteredClaims{ID: "old-jti"},
+	})
+	otherUser, err := service.GenerateJWT(&UserProfile{ID: 3, Username: "other", Email: "other@example.com"}, "githubtools")
+	require.NoError(t, err)
+
+	removed, _, err := service.RevokeUserSessions("test@example.com", "admin", "compromised laptop")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), removed)
+
+	_, err = service.ValidateJWT(signed)
+	assert.ErrorIs(t, err, apperrors.ErrTokenRevoked)
+	_, err = service.ValidateJWT(otherUser)
+	assert.NoError(t, err)
+	_, err = store.Get(hashToken("other"))
+	assert.NoError(t, err)
+
+	t.Run("other replicas pick up the revocation on sync", func(t *testing.T) {
+		replica, err := NewAuthServiceWithStores(service.config, nil, Stores{Tokens: store, Revocations: service.revocations, SigningKeys: service.signingKeys})
+		require.NoError(t, err)
+		_, err = replica.ValidateJWT(signed)
+		assert.ErrorIs(t, err, apperrors.ErrTokenRevoked)
+	})
+
+	t.Run("email is required", func(t *testing.T) {
+		_, _, err := service.RevokeUserSessions("", "admin", "")
+		assert.Error(t, err)
+	})
+}
+
+func TestRevocationSync(t *testing.T) {
+	revocations := NewMemoryRevocationStore()
+	signingKeys := NewMemorySigningKeyStore()
+	config := &AuthConfig{
+		JWTSecret:   "test-signing-key-for-revocation-sync",
+		RedirectURL: "http://localhost:3000",
+		Providers:   map[string]ProviderConfig{"githubtools": {ClientID: "id", ClientSecret: "secret"}},
+	}
+	replicaA, err := NewAuthServiceWithStores(config, nil, Stores{Revocations: revocations, SigningKeys: signingKeys})
+	require.NoError(t, err)
+	replicaB, err := NewAuthServiceWithStores(config, nil, Stores{Revocations: revocations, SigningKeys: signingKeys})
+	require.NoError(t, err)
+
+	token, err := replicaA.GenerateJWT(&UserProfile{ID: 1, Username: "testuser", Email: "test@example.com"}, "githubtools")
+	require.NoError(t, err)
+	claims, err := replicaA.ValidateJWT(token)
+	require.NoError(t, err)
+
+	require.NoError(t, replicaA.Logout(claims, ""))
+
+	// Replica B still accepts the token until it syncs the denylist
+	_, err = replicaB.ValidateJWT(token)
+	assert.NoError(t, err)
+	require.NoError(t, replicaB.SyncRevocations())
+	_, err = replicaB.ValidateJWT(token)
+	assert.ErrorIs(t, err, apperrors.ErrTokenRevoked)
+}
+
+func TestRevocationMiddlewareAndAdminEndpoint(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	service := newTestAuthServiceWithStore(t, NewMemoryTokenStore())
+	service.config.Admins = []string{"githubtools:admin@example.com"}
+	middleware := NewAuthMiddleware(service)
+	handler := NewAuthHandler(service)
+
+	router := gin.New()
+	router.GET("/protected", middleware.RequireAuth(), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.POST("/admin/sessions/revoke", middleware.RequireAuth(), middleware.RequireAdmin(), handler.RevokeUserSessions)
+
+	userToken, err := service.GenerateJWT(&UserProfile{ID: 1, Username: "testuser", Email: "test@example.com"}, "githubtools")
+	require.NoError(t, err)
+	adminToken, err := service.GenerateJWT(&UserProfile{ID: 2, Username: "admin", Email: "admin@example.com", EmailVerified: true}, "githubtools")
+	require.NoError(t, err)
+
+	do := func(method, path, token, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, do("GET", "/protected", userToken, "").Code)
+
+	w := do("POST", "/admin/sessions/revoke", userToken, `{"email":"test@example.com"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	w = do("POST", "/admin/sessions/revoke", adminToken, `{"email":"not-an-email"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = do("POST", "/admin/sessions/revoke", adminToken, `{"email":"test@example.com","reason":"offboarding"}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = do("GET", "/protected", userToken, "")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), apperrors.ErrTokenRevoked.Error())
+
+	assert.Equal(t, http.StatusOK, do("GET", "/protected", adminToken, "").Code)
+}
+
+func TestIsAdmin(t *testing.T) {
+	store := NewMemoryTokenStore()
+	service := newTestAuthServiceWithStore(t, store)
+	service.config.Admins = []string{"githubtools:jdoe", "githubwdf:ops@example.com", "octocat"}
+
+	assert.True(t, service.IsAdmin(&AuthClaims{Username: "JDoe", Provider: "githubtools"}))
+	assert.False(t, service.IsAdmin(&AuthClaims{Username: "jdoe", Provider: "githubwdf"}), "the same name on another provider is another account")
+	assert.True(t, service.IsAdmin(&AuthClaims{Username: "ops", Email: "ops@example.com", Provider: "githubwdf", EmailVerified: true}))
+	assert.False(t, service.IsAdmin(&AuthClaims{Username: "ops", Email: "ops@example.com", Provider: "githubwdf"}), "unverified emails do not match")
+	assert.False(t, service.IsAdmin(&AuthClaims{Username: "octocat", Provider: "githubtools"}), "entries without a provider match nobody")
+
+	t.Run("verified emails are kept across refreshes", func(t *testing.T) {
+		require.NoError(t, store.Save(hashToken("ops-session"), &RefreshTokenData{
+			UserID: 2, Username: "ops", Email: "ops@example.com", Provider: "githubwdf", EmailVerified: true, ExpiresAt: time.Now().Add(time.Hour),
+		}))
+		response, err := service.RefreshToken("ops-session")
+		require.NoError(t, err)
+		claims, err := service.ValidateJWT(response.AccessToken)
+		require.NoError(t, err)
+		assert.True(t, claims.EmailVerified)
+		assert.True(t, service.IsAdmin(claims))
+	})
+}
+
+func TestLoadAuthConfigRequiresQualifiedAdmins(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	for _, name := range []string{"GITHUB_TOOLS_APP_CLIENT_ID", "GITHUB_TOOLS_APP_CLIENT_SECRET", "GITHUB_WDF_APP_CLIENT_ID", "GITHUB_WDF_APP_CLIENT_SECRET"} {
+		t.Setenv(name, "github")
+	}
+	t.Setenv("AUTH_ADMINS", "githubtools:jdoe, octocat, :nobody, githubwdf:ops@example.com")
+	path := filepath.Join(t.TempDir(), "auth.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`redirect_url: "http://localhost:3000"`), 0o600))
+
+	config, err := LoadAuthConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"githubtools:jdoe", "githubwdf:ops@example.com"}, config.Admins)
+}
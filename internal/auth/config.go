@@ -2,7 +2,10 @@ package auth
 
 import (
 	"fmt"
+	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -12,13 +15,40 @@ type AuthConfig struct {
 	JWTSecret   string                    `yaml:"jwt_secret" json:"jwt_secret"`
 	RedirectURL string                    `yaml:"redirect_url" json:"redirect_url"`
 	Providers   map[string]ProviderConfig `yaml:"providers" json:"providers"`
+	// JWTAlgorithm signs portal tokens: RS256 (default), ES256 or the legacy HS256 with jwt_secret.
+	// With RS256 and ES256, jwt_secret protects the private signing keys at rest.
+	JWTAlgorithm string `yaml:"jwt_algorithm,omitempty" json:"jwt_algorithm,omitempty" mapstructure:"jwt_algorithm"`
+	// KeyRotationInterval is how long a signing key signs tokens before the next one takes over
+	KeyRotationInterval time.Duration `yaml:"key_rotation_interval,omitempty" json:"key_rotation_interval,omitempty" mapstructure:"key_rotation_interval"`
+	// Admins lists the provider accounts allowed to use administrative auth endpoints, as
+	// <provider>:<username> or <provider>:<email> (e.g. githubtools:jdoe)
+	Admins []string `yaml:"admins,omitempty" json:"admins,omitempty"`
+	// TokenEncryption seals the upstream OAuth access tokens stored with each session
+	TokenEncryption TokenEncryptionConfig `yaml:"token_encryption,omitempty" json:"token_encryption,omitempty" mapstructure:"token_encryption"`
 }
 
 // ProviderConfig holds configuration for a specific provider
 type ProviderConfig struct {
-	ClientID          string `yaml:"client_id" json:"client_id"`
-	ClientSecret      string `yaml:"client_secret" json:"client_secret"`
+	// Type is ProviderTypeGitHub (default) or ProviderTypeOIDC
+	Type              string `yaml:"type,omitempty" json:"type,omitempty"`
+	ClientID          string `yaml:"client_id" json:"client_id" mapstructure:"client_id"`
+	ClientSecret      string `yaml:"client_secret" json:"client_secret" mapstructure:"client_secret"`
 	EnterpriseBaseURL string `yaml:"enterprise_base_url,omitempty" json:"enterprise_base_url,omitempty"`
+
+	// OIDC providers only
+	IssuerURL    string       `yaml:"issuer_url,omitempty" json:"issuer_url,omitempty" mapstructure:"issuer_url"`
+	DiscoveryURL string       `yaml:"discovery_url,omitempty" json:"discovery_url,omitempty" mapstructure:"discovery_url"` // defaults to <issuer_url>/.well-known/openid-configuration
+	Scopes       []string     `yaml:"scopes,omitempty" json:"scopes,omitempty"`                                            // defaults to openid, email and profile
+	Claims       ClaimMapping `yaml:"claims,omitempty" json:"claims,omitempty"`
+}
+
+// ClaimMapping names the ID token (or userinfo) claims that hold the user's profile fields.
+// Empty fields use the standard OIDC claim names.
+type ClaimMapping struct {
+	Username  string `yaml:"username,omitempty" json:"username,omitempty"`                               // default preferred_username
+	Email     string `yaml:"email,omitempty" json:"email,omitempty"`                                     // default email
+	Name      string `yaml:"name,omitempty" json:"name,omitempty"`                                       // default name
+	AvatarURL string `yaml:"avatar_url,omitempty" json:"avatar_url,omitempty" mapstructure:"avatar_url"` // default picture
 }
 
 // LoadAuthConfig loads and validates authentication configuration
@@ -85,6 +115,16 @@ func LoadAuthConfig(configPath string) (*AuthConfig, error) {
 		}
 	}
 
+	// AUTH_ADMINS is a comma separated list of <provider>:<username> or <provider>:<email>
+	if admins := os.Getenv("AUTH_ADMINS"); admins != "" {
+		config.Admins = nil
+		for _, admin := range strings.Split(admins, ",") {
+			if admin = strings.TrimSpace(admin); admin != "" {
+				config.Admins = append(config.Admins, admin)
+			}
+		}
+	}
+	config.Admins = qualifiedAdmins(config.Admins)
 
 	// Override provider secrets from environment using your specific variable names
 	config = overrideFromEnvironment(config)
@@ -97,6 +137,21 @@ func LoadAuthConfig(configPath string) (*AuthConfig, error) {
 	return &config, nil
 }
 
+// qualifiedAdmins drops admin entries without a provider. Usernames are only unique per provider,
+// so a bare name would make the same-named account of every provider an admin.
+func qualifiedAdmins(admins []string) []string {
+	qualified := make([]string, 0, len(admins))
+	for _, admin := range admins {
+		provider, name, ok := strings.Cut(admin, ":")
+		if !ok || provider == "" || name == "" {
+			log.Printf("Warning: ignoring admin '%s', admins must be given as <provider>:<username or email>", admin)
+			continue
+		}
+		qualified = append(qualified, admin)
+	}
+	return qualified
+}
+
 // GetProvider returns the configuration for a specific provider
 func (c *AuthConfig) GetProvider(provider string) (*ProviderConfig, error) {
 	providerConfig, exists := c.Providers[provider]
@@ -117,6 +172,18 @@ func (c *AuthConfig) ValidateConfig() error {
 		return fmt.Errorf("redirect URL is required")
 	}
 
+	switch c.JWTAlgorithm {
+	case "", JWTAlgorithmRS256, JWTAlgorithmES256, JWTAlgorithmHS256:
+	default:
+		return fmt.Errorf("unsupported jwt_algorithm '%s'", c.JWTAlgorithm)
+	}
+	if c.KeyRotationInterval < 0 || (c.KeyRotationInterval > 0 && c.KeyRotationInterval < time.Hour) {
+		return fmt.Errorf("key_rotation_interval must be at least 1h")
+	}
+	if err := c.TokenEncryption.validate(); err != nil {
+		return err
+	}
+
 	if len(c.Providers) == 0 {
 		return fmt.Errorf("at least one provider must be configured")
 	}
@@ -129,11 +196,36 @@ func (c *AuthConfig) ValidateConfig() error {
 		if provider.ClientSecret == "" {
 			return fmt.Errorf("client_secret is required for provider '%s'", providerName)
 		}
+		switch provider.Type {
+		case "", ProviderTypeGitHub:
+		case ProviderTypeOIDC:
+			if provider.IssuerURL == "" {
+				return fmt.Errorf("issuer_url is required for OIDC provider '%s'", providerName)
+			}
+		default:
+			return fmt.Errorf("unknown type '%s' for provider '%s'", provider.Type, providerName)
+		}
 	}
 
 	return nil
 }
 
+// signingAlgorithm returns the configured JWT algorithm, RS256 if none is set
+func (c *AuthConfig) signingAlgorithm() string {
+	if c.JWTAlgorithm == "" {
+		return JWTAlgorithmRS256
+	}
+	return c.JWTAlgorithm
+}
+
+// keyRotationInterval returns the configured key rotation interval or its default
+func (c *AuthConfig) keyRotationInterval() time.Duration {
+	if c.KeyRotationInterval == 0 {
+		return defaultKeyRotationInterval
+	}
+	return c.KeyRotationInterval
+}
+
 // setAuthDefaults sets default values for auth configuration
 func setAuthDefaults(v *viper.Viper) {
 	v.SetDefault("redirect_url", "http://localhost:3000")
@@ -154,8 +246,9 @@ func setAuthDefaults(v *viper.Viper) {
 
 // overrideFromEnvironment overrides config values with your specific environment variables
 func overrideFromEnvironment(config AuthConfig) AuthConfig {
-	// Helper function to safely update provider config
-	updateProviderConfig := func(providerName, clientID, clientSecret string) {
+	// Helper function to safely update provider config. It returns the variables of ${...}
+	// placeholders that are not set; those values are left unchanged.
+	updateProviderConfig := func(providerName, clientID, clientSecret string) (unset []string) {
 		if provider, exists := config.Providers[providerName]; exists {
 			// Create a copy of the provider config to modify
 			newProvider := provider
@@ -173,12 +266,16 @@ func overrideFromEnvironment(config AuthConfig) AuthConfig {
 				envVar := newProvider.ClientID[2 : len(newProvider.ClientID)-1]
 				if envValue := os.Getenv(envVar); envValue != "" {
 					newProvider.ClientID = envValue
+				} else {
+					unset = append(unset, envVar)
 				}
 			}
 			if newProvider.ClientSecret != "" && len(newProvider.ClientSecret) > 3 && newProvider.ClientSecret[:2] == "${" && newProvider.ClientSecret[len(newProvider.ClientSecret)-1:] == "}" {
 				envVar := newProvider.ClientSecret[2 : len(newProvider.ClientSecret)-1]
 				if envValue := os.Getenv(envVar); envValue != "" {
 					newProvider.ClientSecret = envValue
+				} else {
+					unset = append(unset, envVar)
 				}
 			}
 
@@ -186,6 +283,7 @@ func overrideFromEnvironment(config AuthConfig) AuthConfig {
 
 			config.Providers[providerName] = newProvider
 		}
+		return unset
 	}
 
 	// GitHub Tools
@@ -198,5 +296,17 @@ func overrideFromEnvironment(config AuthConfig) AuthConfig {
 		os.Getenv("GITHUB_WDF_APP_CLIENT_ID"),
 		os.Getenv("GITHUB_WDF_APP_CLIENT_SECRET"))
 
+	// Other providers (e.g. OIDC) take their credentials from ${ENV_VAR} placeholders only. A
+	// provider whose variables are not set is not configured for this deployment and is skipped.
+	for providerName := range config.Providers {
+		if providerName == "githubtools" || providerName == "githubwdf" {
+			continue
+		}
+		if unset := updateProviderConfig(providerName, "", ""); len(unset) > 0 {
+			log.Printf("Warning: skipping auth provider '%s', %s not set", providerName, strings.Join(unset, " and "))
+			delete(config.Providers, providerName)
+		}
+	}
+
 	return config
 }
@@ -0,0 +1,312 @@
+package auth
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// sealedCredentialPrefix marks credentials sealed by credentialCipher. Stored values without it
+// are plaintext tokens written before encryption was configured.
+const sealedCredentialPrefix = "enc1:"
+
+// reencryptBatchSize is how many sessions ReencryptCredentials rewrites per store round trip
+const reencryptBatchSize = 100
+
+// masterKeyVersionPattern restricts key versions to characters that need no escaping in the
+// sealed format or in LIKE patterns
+var masterKeyVersionPattern = regexp.MustCompile(`^[A-Za-z0-9-]{1,32}$`)
+
+// TokenEncryptionConfig configures envelope encryption of the upstream OAuth access tokens
+// stored with each session. Without keys, tokens are stored in plaintext.
+type TokenEncryptionConfig struct {
+	// CurrentKey is the version of the master key that seals new and re-encrypted tokens
+	CurrentKey string `yaml:"current_key,omitempty" json:"current_key,omitempty" mapstructure:"current_key"`
+	// Keys are the master keys by version. Keep a retired key until no stored token uses it.
+	Keys []MasterKeyConfig `yaml:"keys,omitempty" json:"keys,omitempty"`
+}
+
+// MasterKeyConfig locates a base64 encoded 32 byte AES-256 master key in a file or an environment variable
+type MasterKeyConfig struct {
+	Version string `yaml:"version" json:"version"`
+	File    string `yaml:"file,omitempty" json:"file,omitempty"`
+	Env     string `yaml:"env,omitempty" json:"env,omitempty"`
+}
+
+// validate checks the structure of the configuration; the key material is loaded by newCredentialCipher
+func (c *TokenEncryptionConfig) validate() error {
+	if len(c.Keys) == 0 {
+		if c.CurrentKey != "" {
+			return fmt.Errorf("token_encryption.current_key '%s' has no key", c.CurrentKey)
+		}
+		return nil
+	}
+	versions := make(map[string]bool)
+	for _, key := range c.Keys {
+		if !masterKeyVersionPattern.MatchString(key.Version) {
+			return fmt.Errorf("token_encryption key version '%s' must be 1-32 letters, digits or dashes", key.Version)
+		}
+		if versions[key.Version] {
+			return fmt.Errorf("token_encryption key version '%s' is configured twice", key.Version)
+		}
+		versions[key.Version] = true
+		if (key.File == "") == (key.Env == "") {
+			return fmt.Errorf("token_encryption key '%s' needs exactly one of file or env", key.Version)
+		}
+	}
+	if !versions[c.CurrentKey] {
+		return fmt.Errorf("token_encryption.current_key must name one of the configured keys")
+	}
+	return nil
+}
+
+// credentialCipher seals upstream credentials with envelope encryption. Each value is encrypted
+// with a fresh AES-256-GCM data key, which is in turn encrypted with the current master key.
+// Sealed values read "enc1:<key version>:<wrapped data key>:<ciphertext>", both parts base64url
+// encoded with their nonce prepended, so a value names the master key needed to open it.
+type credentialCipher struct {
+	current string
+	masters map[string]cipher.AEAD
+}
+
+// newCredentialCipher loads the configured master keys, or returns nil if none are configured
+func newCredentialCipher(config TokenEncryptionConfig) (*credentialCipher, error) {
+	if len(config.Keys) == 0 {
+		return nil, nil
+	}
+	c := &credentialCipher{current: config.CurrentKey, masters: make(map[string]cipher.AEAD)}
+	for _, key := range config.Keys {
+		material, err := loadMasterKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load token encryption key '%s': %w", key.Version, err)
+		}
+		aead, err := newGCM(material)
+		if err != nil {
+			return nil, err
+		}
+		c.masters[key.Version] = aead
+	}
+	return c, nil
+}
+
+// loadMasterKey reads and decodes the key material of a master key
+func loadMasterKey(key MasterKeyConfig) ([]byte, error) {
+	var encoded string
+	if key.File != "" {
+		raw, err := os.ReadFile(key.File)
+		if err != nil {
+			return nil, err
+		}
+		encoded = string(raw)
+	} else {
+		encoded = os.Getenv(key.Env)
+		if encoded == "" {
+			return nil, fmt.Errorf("environment variable %s is not set", key.Env)
+		}
+	}
+	material, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("key is not valid base64: %w", err)
+	}
+	if len(material) != 32 {
+		return nil, fmt.Errorf("key must be 32 bytes, got %d", len(material))
+	}
+	return material, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// currentPrefix is the prefix of values sealed with the current master key
+func (c *credentialCipher) currentPrefix() string {
+	return sealedCredentialPrefix + c.current + ":"
+}
+
+// seal encrypts a credential with a fresh data key wrapped by the current master key
+func (c *credentialCipher) seal(plaintext string) (string, error) {
+	dataKey := make([]byte, 32)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", fmt.Errorf("failed to generate data key: %w", err)
+	}
+	header := c.currentPrefix()
+	wrapped, err := sealWith(c.masters[c.current], dataKey, []byte(header))
+	if err != nil {
+		return "", err
+	}
+	dataAEAD, err := newGCM(dataKey)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := sealWith(dataAEAD, []byte(plaintext), []byte(header))
+	if err != nil {
+		return "", err
+	}
+	return header + base64.RawURLEncoding.EncodeToString(wrapped) + ":" + base64.RawURLEncoding.EncodeToString(ciphertext), nil
+}
+
+// open decrypts a value sealed by seal with any configured master key
+func (c *credentialCipher) open(sealed string) (string, error) {
+	parts := strings.Split(strings.TrimPrefix(sealed, sealedCredentialPrefix), ":")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("malformed sealed credential")
+	}
+	master, ok := c.masters[parts[0]]
+	if !ok {
+		return "", fmt.Errorf("credential is sealed with unknown token encryption key '%s'", parts[0])
+	}
+	header := []byte(sealedCredentialPrefix + parts[0] + ":")
+	wrapped, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("malformed sealed credential: %w", err)
+	}
+	ciphertext, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("malformed sealed credential: %w", err)
+	}
+	dataKey, err := openWith(master, wrapped, header)
+	if err != nil {
+		return "", fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	dataAEAD, err := newGCM(dataKey)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := openWith(dataAEAD, ciphertext, header)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt credential: %w", err)
+	}
+	return string(plaintext), nil
+}
+
+// sealWith encrypts with AES-GCM; the nonce is prepended to the result
+func sealWith(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// openWith decrypts a value sealed by sealWith
+func openWith(aead cipher.AEAD, sealed, additionalData []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("sealed value is too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, additionalData)
+}
+
+// sealCredential prepares an upstream credential for storage. Without token_encryption
+// it is stored as is.
+func (s *AuthService) sealCredential(plaintext string) (string, error) {
+	if s.credentials == nil {
+		return plaintext, nil
+	}
+	return s.credentials.seal(plaintext)
+}
+
+// openCredential returns the plaintext of a stored upstream credential. Plaintext values
+// stored before encryption was configured are returned unchanged.
+func (s *AuthService) openCredential(stored string) (string, error) {
+	if !strings.HasPrefix(stored, sealedCredentialPrefix) {
+		return stored, nil
+	}
+	if s.credentials == nil {
+		return "", fmt.Errorf("credential is encrypted but token_encryption is not configured")
+	}
+	return s.credentials.open(stored)
+}
+
+// resealCredential returns a stored credential sealed with the current master key,
+// re-encrypting it if it is plaintext or sealed with a retired key
+func (s *AuthService) resealCredential(stored string) (string, error) {
+	if s.credentials == nil || strings.HasPrefix(stored, s.credentials.currentPrefix()) {
+		return stored, nil
+	}
+	plaintext, err := s.openCredential(stored)
+	if err != nil {
+		return "", err
+	}
+	return s.credentials.seal(plaintext)
+}
+
+// ReencryptCredentials seals every stored access token that is plaintext or sealed with a retired
+// master key with the current one, and returns how many were rewritten. Sessions keep working
+// while this runs, since every configured key can still open their tokens. Tokens that cannot be
+// opened are logged and left as they are.
+func (s *AuthService) ReencryptCredentials() (int, error) {
+	if s.credentials == nil {
+		return 0, nil
+	}
+	prefix := s.credentials.currentPrefix()
+	rewritten := 0
+	failed := make(map[string]bool)
+	for {
+		sessions, err := s.tokenStore.ListStaleCredentials(prefix, reencryptBatchSize+len(failed))
+		if err != nil {
+			return rewritten, fmt.Errorf("failed to list sessions to re-encrypt: %w", err)
+		}
+		progress := false
+		for tokenHash, data := range sessions {
+			if failed[tokenHash] {
+				continue
+			}
+			sealed, err := s.resealCredential(data.AccessToken)
+			if err != nil {
+				log.Printf("Warning: cannot re-encrypt access token of %s session of %s: %v", data.Provider, data.Username, err)
+				failed[tokenHash] = true
+				continue
+			}
+			if err := s.tokenStore.UpdateAccessToken(tokenHash, sealed); err != nil {
+				return rewritten, fmt.Errorf("failed to store re-encrypted access token: %w", err)
+			}
+			rewritten++
+			progress = true
+		}
+		if !progress {
+			return rewritten, nil
+		}
+	}
+}
+
+// StartCredentialReencryption re-encrypts stored access tokens right away and then periodically
+// until ctx is cancelled, so tokens move to a new current_key without logging users out
+func (s *AuthService) StartCredentialReencryption(ctx context.Context, interval time.Duration) {
+	if s.credentials == nil {
+		return
+	}
+	reencrypt := func() {
+		rewritten, err := s.ReencryptCredentials()
+		if err != nil {
+			log.Printf("Warning: access token re-encryption failed: %v", err)
+		} else if rewritten > 0 {
+			log.Printf("Re-encrypted %d stored access tokens with token encryption key %s", rewritten, s.credentials.current)
+		}
+	}
+	go func() {
+		reencrypt()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				reencrypt()
+			}
+		}
+	}()
+}
@@ -0,0 +1,233 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMasterKey returns a base64 encoded random master key
+func newMasterKey(t *testing.T) string {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(key)
+}
+
+// newEncryptionTestService creates a service sealing tokens with current, able to open all keys
+func newEncryptionTestService(t *testing.T, store TokenStore, current string, keys ...MasterKeyConfig) *AuthService {
+	config := &AuthConfig{
+		JWTSecret:   "test-signing-key-for-token-encryption",
+		RedirectURL: "http://localhost:3000",
+		Providers: map[string]ProviderConfig{
+			"githubtools": {ClientID: "test-client-id", ClientSecret: "test-client-secret"},
+		},
+		TokenEncryption: TokenEncryptionConfig{CurrentKey: current, Keys: keys},
+	}
+	service, err := NewAuthServiceWithStores(config, nil, Stores{Tokens: store})
+	require.NoError(t, err)
+	return service
+}
+
+func saveSession(t *testing.T, store TokenStore, refreshToken string, userID int64, accessToken string) {
+	now := time.Now()
+	require.NoError(t, store.Save(hashToken(refreshToken), &RefreshTokenData{
+		UserID:      userID,
+		Username:    "testuser",
+		Provider:    "githubtools",
+		AccessToken: accessToken,
+		ExpiresAt:   now.Add(time.Hour),
+		CreatedAt:   now,
+	}))
+}
+
+func storedAccessToken(t *testing.T, store TokenStore, refreshToken string) string {
+	data, err := store.Get(hashToken(refreshToken))
+	require.NoError(t, err)
+	return data.AccessToken
+}
+
+func TestAccessTokensAreSealedAtRest(t *testing.T) {
+	t.Setenv("TEST_TOKEN_KEY_V1", newMasterKey(t))
+	github := newMockGitHub(t)
+	store := NewMemoryTokenStore()
+	config := &AuthConfig{
+		JWTSecret:   "test-signing-key-for-token-encryption",
+		RedirectURL: "http://localhost:3000",
+		Providers: map[string]ProviderConfig{
+			"githubtools": {ClientID: "tools-id", ClientSecret: "tools-secret", EnterpriseBaseURL: github.server.URL},
+		},
+		TokenEncryption: TokenEncryptionConfig{CurrentKey: "v1", Keys: []MasterKeyConfig{{Version: "v1", Env: "TEST_TOKEN_KEY_V1"}}},
+	}
+	service, err := NewAuthServiceWithStores(config, nil, Stores{Tokens: store})
+	require.NoError(t, err)
+
+	authURL, err := service.GetAuthURL("githubtools", "sealed-state")
+	require.NoError(t, err)
+	github.recordChallenge(t, authURL)
+	resp, err := service.HandleCallback(context.Background(), "githubtools", "code", "sealed-state")
+	require.NoError(t, err)
+
+	stored := storedAccessToken(t, store, resp.RefreshToken)
+	assert.True(t, strings.HasPrefix(stored, "enc1:v1:"))
+	assert.NotContains(t, stored, "gho_upstream")
+
+	token, err := service.GetGitHubAccessTokenFromClaims(&AuthClaims{UserID: resp.Profile.ID, Provider: "githubtools"})
+	require.NoError(t, err)
+	assert.Equal(t, "gho_upstream", token)
+}
+
+func TestSealedCredentialsDetectTampering(t *testing.T) {
+	t.Setenv("TEST_TOKEN_KEY_V1", newMasterKey(t))
+	service := newEncryptionTestService(t, nil, "v1", MasterKeyConfig{Version: "v1", Env: "TEST_TOKEN_KEY_V1"})
+
+	sealed, err := service.sealCredential("gho_upstream")
+	require.NoError(t, err)
+	other, err := service.sealCredential("gho_upstream")
+	require.NoError(t, err)
+	assert.NotEqual(t, sealed, other, "every credential gets its own data key and nonce")
+
+	parts := strings.Split(sealed, ":")
+	require.Len(t, parts, 4)
+
+	// Pairing the data key of one value with the ciphertext of another fails
+	swapped := strings.Join([]string{parts[0], parts[1], strings.Split(other, ":")[2], parts[3]}, ":")
+	_, err = service.openCredential(swapped)
+	assert.Error(t, err)
+
+	// Claiming another key version fails too
+	_, err = service.openCredential(strings.Replace(sealed, "enc1:v1:", "enc1:v2:", 1))
+	assert.ErrorContains(t, err, "unknown token encryption key")
+}
+
+func TestReencryptCredentials(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "v1.key"), []byte(newMasterKey(t)+"\n"), 0o600))
+	t.Setenv("TEST_TOKEN_KEY_V2", newMasterKey(t))
+	v1 := MasterKeyConfig{Version: "v1", File: filepath.Join(dir, "v1.key")}
+	v2 := MasterKeyConfig{Version: "v2", Env: "TEST_TOKEN_KEY_V2"}
+	store := NewMemoryTokenStore()
+
+	// Sessions written before encryption and with the retired key
+	saveSession(t, store, "plaintext", 1, "gho_plaintext")
+	old := newEncryptionTestService(t, store, "v1", v1)
+	sealedV1, err := old.sealCredential("gho_v1")
+	require.NoError(t, err)
+	saveSession(t, store, "retired", 2, sealedV1)
+
+	service := newEncryptionTestService(t, store, "v2", v1, v2)
+	sealedV2, err := service.sealCredential("gho_v2")
+	require.NoError(t, err)
+	saveSession(t, store, "current", 3, sealedV2)
+	saveSession(t, store, "unknown-key", 4, "enc1:v0:wrapped:sealed")
+
+	// Every session keeps working before the sweep
+	for userID, expected := range map[int64]string{1: "gho_plaintext", 2: "gho_v1", 3: "gho_v2"} {
+		token, err := service.GetGitHubAccessTokenFromClaims(&AuthClaims{UserID: userID, Provider: "githubtools"})
+		require.NoError(t, err)
+		assert.Equal(t, expected, token)
+	}
+
+	rewritten, err := service.ReencryptCredentials()
+	require.NoError(t, err)
+	assert.Equal(t, 2, rewritten)
+
+	for refreshToken, userID := range map[string]int64{"plaintext": 1, "retired": 2} {
+		assert.True(t, strings.HasPrefix(storedAccessToken(t, store, refreshToken), "enc1:v2:"))
+		_, err := service.GetGitHubAccessTokenFromClaims(&AuthClaims{UserID: userID, Provider: "githubtools"})
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, sealedV2, storedAccessToken(t, store, "current"))
+	assert.Equal(t, "enc1:v0:wrapped:sealed", storedAccessToken(t, store, "unknown-key"))
+
+	// A second sweep has nothing left to do
+	rewritten, err = service.ReencryptCredentials()
+	require.NoError(t, err)
+	assert.Zero(t, rewritten)
+}
+
+func TestRefreshMovesAccessTokenToCurrentKey(t *testing.T) {
+	t.Setenv("TEST_TOKEN_KEY_V1", newMasterKey(t))
+	store := NewMemoryTokenStore()
+	saveSession(t, store, "legacy-refresh-token", 1, "gho_plaintext")
+	service := newEncryptionTestService(t, store, "v1", MasterKeyConfig{Version: "v1", Env: "TEST_TOKEN_KEY_V1"})
+
+	resp, err := service.RefreshToken("legacy-refresh-token")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(storedAccessToken(t, store, resp.RefreshToken), "enc1:v1:"))
+
+	token, err := service.GetGitHubAccessTokenFromClaims(&AuthClaims{UserID: 1, Provider: "githubtools"})
+	require.NoError(t, err)
+	assert.Equal(t, "gho_plaintext", token)
+}
+
+func TestTokenEncryptionConfigValidation(t *testing.T) {
+	base := AuthConfig{
+		JWTSecret:   "test-secret",
+		RedirectURL: "http://localhost:3000",
+		Providers:   map[string]ProviderConfig{"githubtools": {ClientID: "id", ClientSecret: "secret"}},
+	}
+	tests := []struct {
+		name       string
+		encryption TokenEncryptionConfig
+		error      string
+	}{
+		{"current key without keys", TokenEncryptionConfig{CurrentKey: "v1"}, "has no key"},
+		{"unknown current key", TokenEncryptionConfig{CurrentKey: "v2", Keys: []MasterKeyConfig{{Version: "v1", Env: "KEY"}}}, "current_key"},
+		{"invalid version", TokenEncryptionConfig{CurrentKey: "v:1", Keys: []MasterKeyConfig{{Version: "v:1", Env: "KEY"}}}, "letters, digits or dashes"},
+		{"duplicate version", TokenEncryptionConfig{CurrentKey: "v1", Keys: []MasterKeyConfig{{Version: "v1", Env: "A"}, {Version: "v1", Env: "B"}}}, "twice"},
+		{"file and env", TokenEncryptionConfig{CurrentKey: "v1", Keys: []MasterKeyConfig{{Version: "v1", Env: "KEY", File: "/key"}}}, "exactly one"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config := base
+			config.TokenEncryption = tc.encryption
+			assert.ErrorContains(t, config.ValidateConfig(), tc.error)
+		})
+	}
+
+	t.Run("key material is checked on startup", func(t *testing.T) {
+		t.Setenv("TEST_SHORT_KEY", base64.StdEncoding.EncodeToString([]byte("too-short")))
+		config := base
+		config.TokenEncryption = TokenEncryptionConfig{CurrentKey: "v1", Keys: []MasterKeyConfig{{Version: "v1", Env: "TEST_SHORT_KEY"}}}
+		_, err := NewAuthService(&config, nil)
+		assert.ErrorContains(t, err, "must be 32 bytes")
+
+		config.TokenEncryption.Keys[0].Env = "TEST_UNSET_KEY"
+		_, err = NewAuthService(&config, nil)
+		assert.ErrorContains(t, err, "not set")
+	})
+}
+
+func TestLoadAuthConfigWithTokenEncryption(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	for _, name := range []string{"GITHUB_TOOLS_APP_CLIENT_ID", "GITHUB_TOOLS_APP_CLIENT_SECRET", "GITHUB_WDF_APP_CLIENT_ID", "GITHUB_WDF_APP_CLIENT_SECRET"} {
+		t.Setenv(name, "github")
+	}
+	path := filepath.Join(t.TempDir(), "auth.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+token_encryption:
+  current_key: "2025-02"
+  keys:
+    - version: "2024-11"
+      file: /run/secrets/token-key-2024-11
+    - version: "2025-02"
+      env: TOKEN_ENCRYPTION_KEY
+`), 0o600))
+
+	config, err := LoadAuthConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-02", config.TokenEncryption.CurrentKey)
+	assert.Equal(t, []MasterKeyConfig{
+		{Version: "2024-11", File: "/run/secrets/token-key-2024-11"},
+		{Version: "2025-02", Env: "TOKEN_ENCRYPTION_KEY"},
+	}, config.TokenEncryption.Keys)
+}
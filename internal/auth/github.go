@@ -23,6 +23,9 @@ type UserProfile struct {
 	Name      string  `json:"name"`
 	AvatarURL string  `json:"avatarUrl"`
 	MemberID  *string `json:"memberId,omitempty"` // ID of member with matching email
+	// EmailVerified tells whether the provider verified that the user owns Email. Identities are
+	// only linked to the portal user of a verified email.
+	EmailVerified bool `json:"-"`
 }
 
 // NewGitHubClient creates a new GitHub API client
@@ -77,9 +80,11 @@ func (c *GitHubClient) GetUserProfile(ctx context.Context, accessToken string) (
 
 	// Find primary email
 	primaryEmail := ""
+	emailVerified := false
 	for _, email := range emails {
 		if email.GetPrimary() {
 			primaryEmail = email.GetEmail()
+			emailVerified = email.GetVerified()
 			break
 		}
 	}
@@ -89,22 +94,24 @@ func (c *GitHubClient) GetUserProfile(ctx context.Context, accessToken string) (
 		for _, email := range emails {
 			if email.GetVerified() {
 				primaryEmail = email.GetEmail()
+				emailVerified = true
 				break
 			}
 		}
 	}
 
-	// Fallback to user email from profile if available
+	// Fallback to user email from profile if available; it is not known to be verified
 	if primaryEmail == "" && user.GetEmail() != "" {
 		primaryEmail = user.GetEmail()
 	}
 
 	profile := &UserProfile{
-		ID:        user.GetID(),
-		Username:  user.GetLogin(),
-		Email:     primaryEmail,
-		Name:      user.GetName(),
-		AvatarURL: user.GetAvatarURL(),
+		ID:            user.GetID(),
+		Username:      user.GetLogin(),
+		Email:         primaryEmail,
+		Name:          user.GetName(),
+		AvatarURL:     user.GetAvatarURL(),
+		EmailVerified: emailVerified,
 	}
 
 	return profile, nil
@@ -137,6 +144,44 @@ func (c *GitHubClient) GetOAuth2Config(redirectURL string) *oauth2.Config {
 	}
 }
 
+// AuthCodeURL returns the authorization URL for the given state. When codeVerifier is set the
+// URL carries the matching PKCE S256 code_challenge.
+func (c *GitHubClient) AuthCodeURL(redirectURL, state, codeVerifier string) string {
+	opts := []oauth2.AuthCodeOption{oauth2.AccessTypeOffline}
+	if codeVerifier != "" {
+		opts = append(opts, oauth2.S256ChallengeOption(codeVerifier))
+	}
+	return c.GetOAuth2Config(redirectURL).AuthCodeURL(state, opts...)
+}
+
+// Exchange trades an authorization code for an access token, sending the PKCE code_verifier if set
+func (c *GitHubClient) Exchange(ctx context.Context, redirectURL, code, codeVerifier string) (*oauth2.Token, error) {
+	var opts []oauth2.AuthCodeOption
+	if codeVerifier != "" {
+		opts = append(opts, oauth2.VerifierOption(codeVerifier))
+	}
+	return c.GetOAuth2Config(redirectURL).Exchange(ctx, code, opts...)
+}
+
+// LoginURL implements IdentityProvider
+func (c *GitHubClient) LoginURL(ctx context.Context, redirectURL, state, codeVerifier string) (string, error) {
+	return c.AuthCodeURL(redirectURL, state, codeVerifier), nil
+}
+
+// Authenticate implements IdentityProvider: it exchanges the code and fetches the GitHub profile
+func (c *GitHubClient) Authenticate(ctx context.Context, redirectURL, code, codeVerifier string) (*UserProfile, string, error) {
+	token, err := c.Exchange(ctx, redirectURL, code, codeVerifier)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to exchange code for token: %w", err)
+	}
+
+	profile, err := c.GetUserProfile(ctx, token.AccessToken)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get user profile: %w", err)
+	}
+	return profile, token.AccessToken, nil
+}
+
 // ValidateConfig validates the GitHub client configuration
 func (c *GitHubClient) ValidateConfig() error {
 	if c.config.ClientID == "" {
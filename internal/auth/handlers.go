@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
 	"html"
@@ -30,6 +31,9 @@ func escapeJSString(s string) string {
 	return e
 }
 
+// oauthStateCookie binds an issued OAuth state to the browser that started the login
+const oauthStateCookie = "oauth_state"
+
 // AuthHandler handles HTTP requests for authentication
 type AuthHandler struct {
 	service *AuthService
@@ -46,7 +50,7 @@ func NewAuthHandler(service *AuthService) *AuthHandler {
 // @Tags authentication
 // @Accept json
 // @Produce json
-// @Param provider path string true "OAuth provider (githubtools or githubwdf)"
+// @Param provider path string true "OAuth provider configured in auth.yaml (e.g. githubtools, githubwdf)"
 // @Param env query string false "Environment (development, staging, production)"
 // @Success 302 {string} string "Redirect to OAuth provider authorization URL"
 // @Failure 400 {object} map[string]interface{} "Invalid provider or request parameters"
@@ -62,7 +66,7 @@ func (h *AuthHandler) Start(c *gin.Context) {
 	}
 
 	// Validate supported providers
-	if provider != "githubtools" && provider != "githubwdf" {
+	if !h.service.HasProvider(provider) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported provider"})
 		return
 	}
@@ -81,7 +85,11 @@ func (h *AuthHandler) Start(c *gin.Context) {
 		return
 	}
 
-	// Redirect to GHES OAuth authorization URL
+	// The callback must come back to the same browser; Lax still sends the cookie on the provider's redirect
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oauthStateCookie, state, int(oauthStateTTL.Seconds()), "/", "", false, true)
+
+	// Redirect to the provider's authorization URL
 	c.Redirect(http.StatusFound, authURL)
 }
 
@@ -92,9 +100,9 @@ func (h *AuthHandler) Start(c *gin.Context) {
 // @Tags authentication
 // @Accept json
 // @Produce text/html
-// @Param provider path string true "OAuth provider (githubtools or githubwdf)"
+// @Param provider path string true "OAuth provider configured in auth.yaml (e.g. githubtools, githubwdf)"
 // @Param code query string true "OAuth authorization code from provider"
-// @Param state query string true "OAuth state parameter for security"
+// @Param state query string true "OAuth state parameter issued by the start endpoint; must match the oauth_state cookie and can be used once"
 // @Param env query string false "Environment (development, staging, production)"
 // @Param error query string false "OAuth error parameter from provider"
 // @Param error_description query string false "OAuth error description from provider"
@@ -135,8 +143,18 @@ func (h *AuthHandler) HandlerFrame(c *gin.Context) {
 		return
 	}
 
+	// The state must belong to this browser's login attempt; the service then checks it was issued by us
+	stateCookie, _ := c.Cookie(oauthStateCookie)
+	c.SetCookie(oauthStateCookie, "", -1, "/", "", false, true)
+
 	// Service callback – may return various shapes; we'll normalize in JS
-	serviceResp, err := h.service.HandleCallback(c.Request.Context(), provider, code, state)
+	var serviceResp *AuthHandlerResponse
+	var err error
+	if stateCookie == "" || subtle.ConstantTimeCompare([]byte(stateCookie), []byte(state)) != 1 {
+		err = apperrors.ErrInvalidOAuthState
+	} else {
+		serviceResp, err = h.service.HandleCallback(c.Request.Context(), provider, code, state)
+	}
 	if err != nil {
 		errorHTML := `<!doctype html><html><body><script>
 (function(){
@@ -206,7 +224,7 @@ func (h *AuthHandler) HandlerFrame(c *gin.Context) {
 // @Tags authentication
 // @Accept json
 // @Produce json
-// @Param provider path string true "OAuth provider (githubtools or githubwdf)"
+// @Param provider path string true "OAuth provider configured in auth.yaml (e.g. githubtools, githubwdf)"
 // @Param env query string false "Environment (development, staging, production)"
 // @Param refresh_token query string false "Refresh token to use for getting new access token"
 // @Param Authorization header string false "Bearer token for validation" example("Bearer eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9...")
@@ -225,7 +243,7 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Provider is required"})
 		return
 	}
-	if provider != "githubtools" && provider != "githubwdf" {
+	if !h.service.HasProvider(provider) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported provider"})
 		return
 	}
@@ -414,12 +432,13 @@ func (h *AuthHandler) Refresh(c *gin.Context) {
 
 // Logout handles POST /api/auth/{provider}/logout?env=development
 // @Summary Logout user
-// @Description Logout user and invalidate authentication session
+// @Description Logout user and invalidate authentication session. The access token (from the Authorization header or auth_token cookie) is revoked, together with all refresh tokens of the user for this provider.
 // @Tags authentication
 // @Accept json
 // @Produce json
-// @Param provider path string true "OAuth provider (githubtools or githubwdf)"
+// @Param provider path string true "OAuth provider configured in auth.yaml (e.g. githubtools, githubwdf)"
 // @Param env query string false "Environment (development, staging, production)"
+// @Param Authorization header string false "Bearer token to revoke" example("Bearer eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9...")
 // @Success 200 {object} AuthLogoutResponse "Successfully logged out"
 // @Failure 400 {object} map[string]interface{} "Invalid provider"
 // @Failure 500 {object} map[string]interface{} "Logout failed"
@@ -433,18 +452,26 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Provider is required"})
 		return
 	}
-	if provider != "githubtools" && provider != "githubwdf" {
+	if !h.service.HasProvider(provider) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported provider"})
 		return
 	}
 
+	// Collect the session to revoke before the cookies are cleared.
+	// An invalid or already revoked access token just leaves nothing to revoke.
+	var claims *AuthClaims
+	if tokenString := h.sessionToken(c); tokenString != "" {
+		claims, _ = h.service.ValidateJWT(tokenString)
+	}
+	refreshToken, _ := c.Cookie("refresh_token")
+
 	// Clear all session cookies by setting MaxAge=-1
 	// This sends Set-Cookie headers that delete the cookies
 	c.SetCookie("auth_token", "", -1, "/", "", false, true)
 	c.SetCookie("refresh_token", "", -1, "/", "", false, true)
 	c.SetCookie("user_profile", "", -1, "/", "", false, false)
 
-	if err := h.service.Logout(); err != nil {
+	if err := h.service.Logout(claims, refreshToken); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Logout failed", "details": err.Error()})
 		return
 	}
@@ -497,6 +524,63 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
 }
 
+// sessionToken returns the portal JWT from the Authorization header or, failing that, the auth_token cookie
+func (h *AuthHandler) sessionToken(c *gin.Context) string {
+	if authHeader := c.GetHeader("Authorization"); authHeader != "" {
+		if tokenString := strings.TrimPrefix(authHeader, "Bearer "); tokenString != authHeader {
+			return tokenString
+		}
+	}
+	if cookie, err := c.Cookie("auth_token"); err == nil {
+		return cookie
+	}
+	return ""
+}
+
+// RevokeSessionsRequest represents the request for revoking all sessions of a user
+type RevokeSessionsRequest struct {
+	Email  string `json:"email" binding:"required,email" example:"john.doe@example.com"`
+	Reason string `json:"reason,omitempty" example:"Laptop reported stolen"`
+}
+
+// RevokeSessionsResponse represents the response from the revoke sessions endpoint
+type RevokeSessionsResponse struct {
+	Email                string `json:"email" example:"john.doe@example.com"`
+	RevokedRefreshTokens int64  `json:"revoked_refresh_tokens" example:"2"`
+	RevokedAPITokens     int64  `json:"revoked_api_tokens" example:"1"`
+}
+
+// RevokeUserSessions handles POST /api/v1/admin/sessions/revoke
+// @Summary Revoke all sessions of a user
+// @Description Revoke every access token issued to the user so far and delete all of the user's refresh tokens and personal access tokens on every provider. Requires admin privileges.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Param request body RevokeSessionsRequest true "User whose sessions should be revoked"
+// @Success 200 {object} RevokeSessionsResponse "Sessions revoked"
+// @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 500 {object} map[string]interface{} "Failed to revoke sessions"
+// @Security BearerAuth
+// @Router /api/v1/admin/sessions/revoke [post]
+func (h *AuthHandler) RevokeUserSessions(c *gin.Context) {
+	var req RevokeSessionsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	revokedBy, _ := GetUsername(c)
+	refreshTokens, apiTokens, err := h.service.RevokeUserSessions(req.Email, revokedBy, req.Reason)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke sessions", "details": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, RevokeSessionsResponse{Email: req.Email, RevokedRefreshTokens: refreshTokens, RevokedAPITokens: apiTokens})
+}
+
 // ValidateToken is a helper endpoint to validate JWT tokens (not part of Backstage spec but useful for debugging)
 // @Summary Validate JWT token
 // @Description Validate JWT token and return token claims
@@ -531,6 +615,18 @@ func (h *AuthHandler) ValidateToken(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"valid": true, "claims": claims})
 }
 
+// JWKS publishes the public keys that verify portal tokens
+// @Summary JSON Web Key Set
+// @Description Public keys (RFC 7517) that verify portal JWTs, selected by the kid header of a token. The set includes the next key before it starts signing and keeps retired keys until the tokens they signed have expired.
+// @Tags authentication
+// @Produce json
+// @Success 200 {object} JWKSet "Public signing keys"
+// @Router /.well-known/jwks.json [get]
+func (h *AuthHandler) JWKS(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.service.JWKS())
+}
+
 // ---------- tiny helpers for Refresh normalization ----------
 
 func firstString(m map[string]interface{}, keys ...string) string {
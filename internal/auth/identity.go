@@ -0,0 +1,229 @@
+package auth
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	apperrors "developer-portal-backend/internal/errors"
+)
+
+// LinkedIdentity is a provider account (e.g. the user's githubtools and githubwdf logins) owned by
+// a portal user. Identities are linked on login when the provider's verified email matches a user; the
+// upstream access token of each identity is the one of its latest session in the TokenStore.
+type LinkedIdentity struct {
+	MemberID       string    `json:"member_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Provider       string    `json:"provider" example:"githubwdf"`
+	ProviderUserID int64     `json:"provider_user_id" example:"12345"`
+	Username       string    `json:"username" example:"johndoe"`
+	Email          string    `json:"email" example:"john.doe@example.com"`
+	LinkedAt       time.Time `json:"linked_at"`
+	LastLoginAt    time.Time `json:"last_login_at"`
+	// Set in responses: whether a non-expired session (and thus an access token) exists
+	HasSession bool `json:"has_session" example:"true"`
+}
+
+// IdentityStore persists the provider identities linked to portal users
+type IdentityStore interface {
+	// Link stores the identity, moving it to identity.MemberID if it was linked to another user,
+	// and updates its username, email and LastLoginAt
+	Link(identity *LinkedIdentity) error
+	// Get returns the identity of a provider account, or apperrors.ErrIdentityNotFound
+	Get(provider string, providerUserID int64) (*LinkedIdentity, error)
+	// ListByMember returns all identities linked to a user
+	ListByMember(memberID string) ([]LinkedIdentity, error)
+	// Unlink removes the user's identity of the provider, or returns apperrors.ErrIdentityNotFound
+	Unlink(memberID, provider string) error
+}
+
+// linkIdentity records the provider account of a login for the user it belongs to. Accounts with
+// an unverified email are not linked, since anyone could claim the email of a portal user. Failures
+// do not fail the login; the identity is linked again on the next one.
+func (s *AuthService) linkIdentity(profile *UserProfile, provider string) {
+	if profile.MemberID == nil || !profile.EmailVerified {
+		return
+	}
+	now := time.Now()
+	err := s.identities.Link(&LinkedIdentity{
+		MemberID:       *profile.MemberID,
+		Provider:       provider,
+		ProviderUserID: profile.ID,
+		Username:       profile.Username,
+		Email:          profile.Email,
+		LinkedAt:       now,
+		LastLoginAt:    now,
+	})
+	if err != nil {
+		log.Printf("Warning: failed to link %s identity of %s: %v", provider, profile.Username, err)
+	}
+}
+
+// memberIDForClaims returns the user owning the identity the claims were issued for
+func (s *AuthService) memberIDForClaims(claims *AuthClaims) (string, error) {
+	if claims.TokenType == TokenTypeServiceAccount {
+		return "", apperrors.NewAuthorizationError("service accounts have no linked identities")
+	}
+	identity, err := s.identities.Get(claims.Provider, claims.UserID)
+	if err != nil {
+		return "", err
+	}
+	return identity.MemberID, nil
+}
+
+// ListLinkedIdentities returns the identities linked to the user of the claims, including the one
+// of the claims themselves
+func (s *AuthService) ListLinkedIdentities(claims *AuthClaims) ([]LinkedIdentity, error) {
+	memberID, err := s.memberIDForClaims(claims)
+	if err != nil {
+		return nil, err
+	}
+	identities, err := s.identities.ListByMember(memberID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list linked identities: %w", err)
+	}
+	for i := range identities {
+		session, err := s.tokenStore.FindActiveByUser(identities[i].ProviderUserID, identities[i].Provider)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up session: %w", err)
+		}
+		identities[i].HasSession = session != nil
+	}
+	return identities, nil
+}
+
+// GetLinkedIdentityClaims returns claims for each other provider identity of the user of the claims
+// that has an active session, so GitHub data can be read with the access token of every linked
+// account. Claims of users without linked identities yield an empty list.
+func (s *AuthService) GetLinkedIdentityClaims(claims *AuthClaims) ([]*AuthClaims, error) {
+	memberID, err := s.memberIDForClaims(claims)
+	if err != nil {
+		if apperrors.IsNotFound(err) || apperrors.IsAuthorization(err) {
+			return []*AuthClaims{}, nil
+		}
+		return nil, err
+	}
+	identities, err := s.identities.ListByMember(memberID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list linked identities: %w", err)
+	}
+
+	linked := make([]*AuthClaims, 0, len(identities))
+	for _, identity := range identities {
+		if identity.Provider == claims.Provider && identity.ProviderUserID == claims.UserID {
+			continue
+		}
+		session, err := s.tokenStore.FindActiveByUser(identity.ProviderUserID, identity.Provider)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up session: %w", err)
+		}
+		if session == nil {
+			continue
+		}
+		linked = append(linked, &AuthClaims{
+			UserID:   identity.ProviderUserID,
+			Username: identity.Username,
+			Email:    identity.Email,
+			Provider: identity.Provider,
+		})
+	}
+	return linked, nil
+}
+
+// UnlinkIdentity removes the user's identity of the provider and ends its sessions, so its access
+// token is no longer used. The identity of the current login cannot be unlinked; logging in with
+// the provider again links it again.
+func (s *AuthService) UnlinkIdentity(claims *AuthClaims, provider string) error {
+	if provider == claims.Provider {
+		return apperrors.NewValidationError("provider", "the identity of the current login cannot be unlinked")
+	}
+	memberID, err := s.memberIDForClaims(claims)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			return apperrors.ErrIdentityNotFound
+		}
+		return err
+	}
+
+	identities, err := s.identities.ListByMember(memberID)
+	if err != nil {
+		return fmt.Errorf("failed to list linked identities: %w", err)
+	}
+	for _, identity := range identities {
+		if identity.Provider != provider {
+			continue
+		}
+		if err := s.identities.Unlink(memberID, provider); err != nil {
+			return err
+		}
+		if _, err := s.tokenStore.DeleteByUser(identity.ProviderUserID, provider); err != nil {
+			return fmt.Errorf("failed to end sessions of unlinked identity: %w", err)
+		}
+		return nil
+	}
+	return apperrors.ErrIdentityNotFound
+}
+
+// memoryIdentityStore is an in-process IdentityStore for tests and local development
+type memoryIdentityStore struct {
+	mu         sync.RWMutex
+	identities map[string]LinkedIdentity // provider/provider user ID -> identity
+}
+
+// NewMemoryIdentityStore creates an in-memory identity store
+func NewMemoryIdentityStore() IdentityStore {
+	return &memoryIdentityStore{identities: make(map[string]LinkedIdentity)}
+}
+
+func identityKey(provider string, providerUserID int64) string {
+	return fmt.Sprintf("%s/%d", provider, providerUserID)
+}
+
+func (m *memoryIdentityStore) Link(identity *LinkedIdentity) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := identityKey(identity.Provider, identity.ProviderUserID)
+	linked := *identity
+	if existing, exists := m.identities[key]; exists && existing.MemberID == identity.MemberID {
+		linked.LinkedAt = existing.LinkedAt
+	}
+	m.identities[key] = linked
+	return nil
+}
+
+func (m *memoryIdentityStore) Get(provider string, providerUserID int64) (*LinkedIdentity, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	identity, exists := m.identities[identityKey(provider, providerUserID)]
+	if !exists {
+		return nil, apperrors.ErrIdentityNotFound
+	}
+	return &identity, nil
+}
+
+func (m *memoryIdentityStore) ListByMember(memberID string) ([]LinkedIdentity, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	result := make([]LinkedIdentity, 0)
+	for _, identity := range m.identities {
+		if identity.MemberID == memberID {
+			result = append(result, identity)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].LinkedAt.Before(result[j].LinkedAt) })
+	return result, nil
+}
+
+func (m *memoryIdentityStore) Unlink(memberID, provider string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, identity := range m.identities {
+		if identity.MemberID == memberID && strings.EqualFold(identity.Provider, provider) {
+			delete(m.identities, key)
+			return nil
+		}
+	}
+	return apperrors.ErrIdentityNotFound
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"net/http"
+
+	apperrors "developer-portal-backend/internal/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListIdentities handles GET /api/v1/identities
+// @Summary List linked identities
+// @Description List the provider identities (e.g. githubtools and githubwdf) linked to the authenticated user. Identities are linked on login when the provider's email matches the user. GitHub endpoints aggregate data across all linked identities with an active session.
+// @Tags authentication
+// @Produce json
+// @Success 200 {array} LinkedIdentity "Linked identities"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 404 {object} map[string]interface{} "The login is not linked to a portal user"
+// @Security BearerAuth
+// @Router /api/v1/identities [get]
+func (h *AuthHandler) ListIdentities(c *gin.Context) {
+	claims, ok := GetAuthClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	identities, err := h.service.ListLinkedIdentities(claims)
+	if err != nil {
+		writeIdentityError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, identities)
+}
+
+// UnlinkIdentity handles DELETE /api/v1/identities/{provider}
+// @Summary Unlink an identity
+// @Description Unlink the authenticated user's identity of another provider and end its sessions. The identity is linked again on the next login with that provider.
+// @Tags authentication
+// @Param provider path string true "Provider of the identity" example("githubwdf")
+// @Success 204 "Identity unlinked"
+// @Failure 400 {object} map[string]interface{} "The identity of the current login cannot be unlinked"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 404 {object} map[string]interface{} "Identity not found"
+// @Security BearerAuth
+// @Router /api/v1/identities/{provider} [delete]
+func (h *AuthHandler) UnlinkIdentity(c *gin.Context) {
+	claims, ok := GetAuthClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	if err := h.service.UnlinkIdentity(claims, c.Param("provider")); err != nil {
+		writeIdentityError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// writeIdentityError maps errors of the identity endpoints to responses
+func writeIdentityError(c *gin.Context, err error) {
+	switch {
+	case apperrors.IsValidation(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case apperrors.IsAuthorization(err):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden", "details": err.Error()})
+	case apperrors.IsNotFound(err):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Identity operation failed", "details": err.Error()})
+	}
+}
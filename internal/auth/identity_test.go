@@ -0,0 +1,178 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apperrors "developer-portal-backend/internal/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type identityTestMember struct {
+	ID string
+}
+
+// identityTestUsers resolves every email to the same portal user
+type identityTestUsers struct{}
+
+func (identityTestUsers) GetByEmail(email string) (interface{}, error) {
+	return &identityTestMember{ID: "member-1"}, nil
+}
+
+// loginWithBothProviders logs octocat in with githubtools and githubwdf and returns the claims of each login
+func loginWithBothProviders(t *testing.T) (*AuthService, *AuthClaims, *AuthClaims) {
+	github := newMockGitHub(t)
+	service := newOAuthTestService(t, github.server.URL)
+	service.userRepo = identityTestUsers{}
+
+	login := func(provider string) *AuthClaims {
+		authURL, err := service.GetAuthURL(provider, provider+"-state")
+		require.NoError(t, err)
+		github.recordChallenge(t, authURL)
+		resp, err := service.HandleCallback(context.Background(), provider, "code", provider+"-state")
+		require.NoError(t, err)
+		claims, err := service.ValidateJWT(resp.AccessToken)
+		require.NoError(t, err)
+		return claims
+	}
+	return service, login("githubtools"), login("githubwdf")
+}
+
+func TestLoginLinksIdentities(t *testing.T) {
+	service, tools, wdf := loginWithBothProviders(t)
+
+	identities, err := service.ListLinkedIdentities(tools)
+	require.NoError(t, err)
+	require.Len(t, identities, 2)
+	for _, identity := range identities {
+		assert.Equal(t, "member-1", identity.MemberID)
+		assert.Equal(t, int64(42), identity.ProviderUserID)
+		assert.True(t, identity.HasSession)
+	}
+
+	linked, err := service.GetLinkedIdentityClaims(tools)
+	require.NoError(t, err)
+	require.Len(t, linked, 1)
+	assert.Equal(t, "githubwdf", linked[0].Provider)
+	assert.Equal(t, "octocat", linked[0].Username)
+
+	// The linked claims read the access token of the other identity's session
+	token, err := service.GetGitHubAccessTokenFromClaims(linked[0])
+	require.NoError(t, err)
+	assert.Equal(t, "gho_upstream", token)
+
+	linked, err = service.GetLinkedIdentityClaims(wdf)
+	require.NoError(t, err)
+	require.Len(t, linked, 1)
+	assert.Equal(t, "githubtools", linked[0].Provider)
+}
+
+func TestLoginWithUnverifiedEmailDoesNotLinkIdentity(t *testing.T) {
+	github := newMockGitHub(t)
+	github.emails = `[{"email":"octocat@example.com","primary":true,"verified":false}]`
+	service := newOAuthTestService(t, github.server.URL)
+	service.userRepo = identityTestUsers{}
+
+	authURL, err := service.GetAuthURL("githubtools", "state")
+	require.NoError(t, err)
+	github.recordChallenge(t, authURL)
+	resp, err := service.HandleCallback(context.Background(), "githubtools", "code", "state")
+	require.NoError(t, err)
+	claims, err := service.ValidateJWT(resp.AccessToken)
+	require.NoError(t, err)
+
+	_, err = service.ListLinkedIdentities(claims)
+	assert.ErrorIs(t, err, apperrors.ErrIdentityNotFound)
+	_, err = service.identities.Get("githubtools", 42)
+	assert.ErrorIs(t, err, apperrors.ErrIdentityNotFound)
+}
+
+func TestLinkedIdentitiesWithoutSessionAreNotAggregated(t *testing.T) {
+	service, tools, wdf := loginWithBothProviders(t)
+	_, err := service.tokenStore.DeleteByUser(wdf.UserID, "githubwdf")
+	require.NoError(t, err)
+
+	linked, err := service.GetLinkedIdentityClaims(tools)
+	require.NoError(t, err)
+	assert.Empty(t, linked)
+
+	identities, err := service.ListLinkedIdentities(tools)
+	require.NoError(t, err)
+	assert.Len(t, identities, 2)
+}
+
+func TestUnlinkIdentity(t *testing.T) {
+	service, tools, _ := loginWithBothProviders(t)
+
+	err := service.UnlinkIdentity(tools, "githubtools")
+	assert.True(t, apperrors.IsValidation(err))
+
+	require.NoError(t, service.UnlinkIdentity(tools, "githubwdf"))
+	identities, err := service.ListLinkedIdentities(tools)
+	require.NoError(t, err)
+	require.Len(t, identities, 1)
+	assert.Equal(t, "githubtools", identities[0].Provider)
+
+	// The unlinked identity's sessions are ended
+	session, err := service.tokenStore.FindActiveByUser(42, "githubwdf")
+	require.NoError(t, err)
+	assert.Nil(t, session)
+
+	assert.ErrorIs(t, service.UnlinkIdentity(tools, "githubwdf"), apperrors.ErrIdentityNotFound)
+}
+
+func TestLinkedIdentitiesOfUnlinkedLogins(t *testing.T) {
+	service := newOAuthTestService(t, "https://github.example.com")
+
+	// Logins without a portal user have no linked identities
+	claims := &AuthClaims{UserID: 7, Username: "guest", Provider: "githubtools"}
+	linked, err := service.GetLinkedIdentityClaims(claims)
+	require.NoError(t, err)
+	assert.Empty(t, linked)
+	_, err = service.ListLinkedIdentities(claims)
+	assert.ErrorIs(t, err, apperrors.ErrIdentityNotFound)
+
+	// Neither do service accounts
+	account := &AuthClaims{Username: "ci-bot", TokenType: TokenTypeServiceAccount}
+	_, err = service.ListLinkedIdentities(account)
+	assert.True(t, apperrors.IsAuthorization(err))
+}
+
+func TestIdentityHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	service, tools, _ := loginWithBothProviders(t)
+	handler := NewAuthHandler(service)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("auth_claims", tools)
+		c.Next()
+	})
+	router.GET("/api/v1/identities", handler.ListIdentities)
+	router.DELETE("/api/v1/identities/:provider", handler.UnlinkIdentity)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/identities", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var identities []LinkedIdentity
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &identities))
+	assert.Len(t, identities, 2)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/v1/identities/githubtools", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/v1/identities/githubwdf", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/v1/identities/githubwdf", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
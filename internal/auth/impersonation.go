@@ -0,0 +1,290 @@
+package auth
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	apperrors "developer-portal-backend/internal/errors"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const (
+	// defaultImpersonationTTL is the lifetime of impersonation sessions started without an explicit one
+	defaultImpersonationTTL = 30 * time.Minute
+	// maxImpersonationTTL is the longest lifetime an impersonation session may be started with
+	maxImpersonationTTL = 2 * time.Hour
+	// defaultAuditPageSize and maxAuditPageSize bound the entries returned by ListImpersonationAudit
+	defaultAuditPageSize = 100
+	maxAuditPageSize     = 1000
+)
+
+// Impersonation audit events
+const (
+	ImpersonationEventStart   = "start"   // an admin started a session
+	ImpersonationEventRequest = "request" // a request was made with the session
+)
+
+// Impersonation marks claims of an impersonation session and names the admin behind it.
+// Sessions are read-only unless AllowWrite is set, so a missing field is read-only.
+type Impersonation struct {
+	Admin         string `json:"admin" example:"jane.admin"`
+	AdminEmail    string `json:"admin_email,omitempty" example:"jane.admin@example.com"`
+	AdminProvider string `json:"admin_provider,omitempty" example:"githubtools"`
+	Reason        string `json:"reason" example:"Reproduce empty AI Core deployments list"`
+	AllowWrite    bool   `json:"allow_write,omitempty" example:"false"`
+}
+
+// ImpersonationAuditEntry records the start of an impersonation session or a request made with it
+type ImpersonationAuditEntry struct {
+	ID        string    `json:"id" example:"3fa85f64-5717-4562-b3fc-2c963f66afa6"`
+	SessionID string    `json:"session_id" example:"8f14e45f-ceea-467f-a0e6-7d6d5f0b1c2a"` // jti of the impersonation JWT
+	Event     string    `json:"event" example:"request"`
+	Admin     string    `json:"admin" example:"jane.admin"`
+	Username  string    `json:"username" example:"johndoe"`           // impersonated user
+	Email     string    `json:"email" example:"john.doe@example.com"` // impersonated user
+	Reason    string    `json:"reason" example:"Reproduce empty AI Core deployments list"`
+	Method    string    `json:"method,omitempty" example:"GET"`
+	Path      string    `json:"path,omitempty" example:"/api/v1/ai-core/deployments"`
+	Status    int       `json:"status,omitempty" example:"200"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ImpersonationAuditFilter selects audit entries; empty fields match everything
+type ImpersonationAuditFilter struct {
+	Admin     string
+	Email     string
+	SessionID string
+	Since     time.Time
+	Limit     int
+}
+
+// ImpersonationAuditStore persists the impersonation audit log
+type ImpersonationAuditStore interface {
+	// Record appends an entry to the audit log
+	Record(entry *ImpersonationAuditEntry) error
+	// List returns the entries matching the filter, newest first
+	List(filter ImpersonationAuditFilter) ([]ImpersonationAuditEntry, error)
+}
+
+// ImpersonationRequest starts an impersonation session
+type ImpersonationRequest struct {
+	Email      string `json:"email" binding:"required,email" example:"john.doe@example.com"`
+	Reason     string `json:"reason" binding:"required" example:"Reproduce empty AI Core deployments list"`
+	TTLMinutes int    `json:"ttl_minutes,omitempty" example:"30"` // default 30, at most 120
+	AllowWrite bool   `json:"allow_write,omitempty" example:"false"`
+}
+
+// ImpersonationSession is a started impersonation session. It has no refresh token;
+// a new session has to be started once it expires.
+type ImpersonationSession struct {
+	AccessToken   string        `json:"accessToken" example:"eyJhbGciOiJSUzI1NiIsImtpZCI6Ii4uLiJ9..."`
+	TokenType     string        `json:"tokenType" example:"Bearer"`
+	ExpiresIn     int64         `json:"expiresIn" example:"1800"`
+	ExpiresAt     time.Time     `json:"expiresAt"`
+	SessionID     string        `json:"sessionId" example:"8f14e45f-ceea-467f-a0e6-7d6d5f0b1c2a"`
+	User          UserProfile   `json:"user"`
+	Impersonation Impersonation `json:"impersonation"`
+}
+
+// IsImpersonation reports whether the claims belong to an impersonation session
+func (c *AuthClaims) IsImpersonation() bool {
+	return c.Impersonation != nil
+}
+
+// StartImpersonation mints a short-lived JWT with which the admin acts as the portal user with
+// the given email. The user's claims are taken from their linked identity, preferring the admin's
+// provider. The session is read-only unless req.AllowWrite is set, never carries admin rights and
+// cannot use the user's GitHub session. Its start and every request made with it are audited.
+func (s *AuthService) StartImpersonation(admin *AuthClaims, req ImpersonationRequest) (*ImpersonationSession, error) {
+	if admin.IsAPIToken() || !s.IsAdmin(admin) {
+		return nil, apperrors.NewAuthorizationError("only admins signed in to the portal can impersonate users")
+	}
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, apperrors.NewValidationError("reason", "a reason is required to impersonate a user")
+	}
+	if strings.EqualFold(req.Email, admin.Email) {
+		return nil, apperrors.NewValidationError("email", "admins cannot impersonate themselves")
+	}
+	ttl := time.Duration(req.TTLMinutes) * time.Minute
+	switch {
+	case ttl == 0:
+		ttl = defaultImpersonationTTL
+	case ttl < 0 || ttl > maxImpersonationTTL:
+		return nil, apperrors.NewValidationError("ttl_minutes", fmt.Sprintf("must be between 1 and %d", int(maxImpersonationTTL.Minutes())))
+	}
+
+	user, err := s.impersonatedProfile(req.Email, admin.Provider)
+	if err != nil {
+		return nil, err
+	}
+	impersonation := Impersonation{
+		Admin:         admin.Username,
+		AdminEmail:    admin.Email,
+		AdminProvider: admin.Provider,
+		Reason:        reason,
+		AllowWrite:    req.AllowWrite,
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	sessionID := uuid.NewString()
+	claims := &AuthClaims{
+		UserID:        user.ID,
+		Username:      user.Username,
+		Email:         user.Email,
+		Provider:      user.provider,
+		Impersonation: &impersonation,
+		Issuer:        "developer-portal-backend",
+		Subject:       fmt.Sprintf("%d", user.ID),
+		ExpiresAt:     expiresAt.Unix(),
+		IssuedAt:      now.Unix(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			NotBefore: jwt.NewNumericDate(now),
+			ID:        sessionID,
+		},
+	}
+
+	// The session must not exist without its audit entry
+	if err := s.impersonationAudit.Record(&ImpersonationAuditEntry{
+		ID:        uuid.NewString(),
+		SessionID: sessionID,
+		Event:     ImpersonationEventStart,
+		Admin:     admin.Username,
+		Username:  user.Username,
+		Email:     user.Email,
+		Reason:    reason,
+		CreatedAt: now,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to audit impersonation: %w", err)
+	}
+	signed, err := s.signJWT(claims, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate JWT: %w", err)
+	}
+	log.Printf("Admin %s started impersonating %s until %s: %s", admin.Username, user.Email, expiresAt.Format(time.RFC3339), reason)
+
+	return &ImpersonationSession{
+		AccessToken:   signed,
+		TokenType:     "Bearer",
+		ExpiresIn:     int64(ttl.Seconds()),
+		ExpiresAt:     expiresAt,
+		SessionID:     sessionID,
+		User:          user.UserProfile,
+		Impersonation: impersonation,
+	}, nil
+}
+
+// impersonatedUser is the profile and provider an impersonation session acts as
+type impersonatedUser struct {
+	UserProfile
+	provider string
+}
+
+// impersonatedProfile resolves the portal user with the email to the identity an impersonation
+// session acts as. Users without a linked identity are impersonated by email only.
+func (s *AuthService) impersonatedProfile(email, preferredProvider string) (*impersonatedUser, error) {
+	memberID := s.getMemberIDByEmail(email)
+	if memberID == nil {
+		return nil, apperrors.ErrUserNotFound
+	}
+	user := &impersonatedUser{
+		UserProfile: UserProfile{Username: email, Email: email, MemberID: memberID},
+		provider:    preferredProvider,
+	}
+
+	identities, err := s.identities.ListByMember(*memberID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list linked identities: %w", err)
+	}
+	for i, identity := range identities {
+		if i == 0 || identity.Provider == preferredProvider {
+			user.ID = identity.ProviderUserID
+			user.Username = identity.Username
+			user.provider = identity.Provider
+		}
+		if identity.Provider == preferredProvider {
+			break
+		}
+	}
+	return user, nil
+}
+
+// AuditImpersonatedRequest records a request made with an impersonation session. Failures are
+// logged; the request has already been served when it is audited.
+func (s *AuthService) AuditImpersonatedRequest(claims *AuthClaims, method, path string, status int) {
+	if !claims.IsImpersonation() {
+		return
+	}
+	err := s.impersonationAudit.Record(&ImpersonationAuditEntry{
+		ID:        uuid.NewString(),
+		SessionID: claims.ID,
+		Event:     ImpersonationEventRequest,
+		Admin:     claims.Impersonation.Admin,
+		Username:  claims.Username,
+		Email:     claims.Email,
+		Reason:    claims.Impersonation.Reason,
+		Method:    method,
+		Path:      path,
+		Status:    status,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		log.Printf("Warning: failed to audit %s %s of %s impersonating %s: %v", method, path, claims.Impersonation.Admin, claims.Email, err)
+	}
+}
+
+// ListImpersonationAudit returns impersonation audit entries, newest first
+func (s *AuthService) ListImpersonationAudit(filter ImpersonationAuditFilter) ([]ImpersonationAuditEntry, error) {
+	switch {
+	case filter.Limit == 0:
+		filter.Limit = defaultAuditPageSize
+	case filter.Limit < 0 || filter.Limit > maxAuditPageSize:
+		return nil, apperrors.NewValidationError("limit", fmt.Sprintf("must be between 1 and %d", maxAuditPageSize))
+	}
+	return s.impersonationAudit.List(filter)
+}
+
+// memoryImpersonationAuditStore is an in-process ImpersonationAuditStore for tests and local development
+type memoryImpersonationAuditStore struct {
+	mu      sync.RWMutex
+	entries []ImpersonationAuditEntry
+}
+
+// NewMemoryImpersonationAuditStore creates an in-memory impersonation audit store
+func NewMemoryImpersonationAuditStore() ImpersonationAuditStore {
+	return &memoryImpersonationAuditStore{}
+}
+
+func (m *memoryImpersonationAuditStore) Record(entry *ImpersonationAuditEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = append(m.entries, *entry)
+	return nil
+}
+
+func (m *memoryImpersonationAuditStore) List(filter ImpersonationAuditFilter) ([]ImpersonationAuditEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	result := make([]ImpersonationAuditEntry, 0)
+	for _, entry := range m.entries {
+		if (filter.Admin != "" && !strings.EqualFold(entry.Admin, filter.Admin)) ||
+			(filter.Email != "" && !strings.EqualFold(entry.Email, filter.Email)) ||
+			(filter.SessionID != "" && entry.SessionID != filter.SessionID) ||
+			entry.CreatedAt.Before(filter.Since) {
+			continue
+		}
+		result = append(result, entry)
+	}
+	sort.SliceStable(result, func(i, j int) bool { return result[i].CreatedAt.After(result[j].CreatedAt) })
+	if filter.Limit > 0 && len(result) > filter.Limit {
+		result = result[:filter.Limit]
+	}
+	return result, nil
+}
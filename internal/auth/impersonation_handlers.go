@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	apperrors "developer-portal-backend/internal/errors"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StartImpersonation handles POST /api/v1/admin/impersonations
+// @Summary Impersonate a user
+// @Description Start a time-limited session acting as the portal user with the given email, e.g. to reproduce what they see. The returned access token is marked as an impersonation session in its claims, is read-only unless allow_write is set, never carries admin rights, cannot use the user's GitHub session and cannot be refreshed. Starting the session and every request made with it are recorded in the impersonation audit log. Requires admin privileges and a portal login.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Param request body ImpersonationRequest true "User to impersonate and the reason"
+// @Success 201 {object} ImpersonationSession "Impersonation session started"
+// @Failure 400 {object} map[string]interface{} "Invalid request body"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 404 {object} map[string]interface{} "User not found"
+// @Failure 500 {object} map[string]interface{} "Failed to start impersonation"
+// @Security BearerAuth
+// @Router /api/v1/admin/impersonations [post]
+func (h *AuthHandler) StartImpersonation(c *gin.Context) {
+	claims, ok := GetAuthClaims(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	var req ImpersonationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body", "details": err.Error()})
+		return
+	}
+
+	session, err := h.service.StartImpersonation(claims, req)
+	if err != nil {
+		writeImpersonationError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, session)
+}
+
+// ListImpersonationAudit handles GET /api/v1/admin/impersonations/audit
+// @Summary List the impersonation audit log
+// @Description List started impersonation sessions and the requests made with them, newest first. Requires admin privileges.
+// @Tags authentication
+// @Produce json
+// @Param admin query string false "Only entries of this admin" example("jane.admin")
+// @Param email query string false "Only entries impersonating the user with this email" example("john.doe@example.com")
+// @Param session_id query string false "Only entries of this session"
+// @Param since query string false "Only entries at or after this time (RFC 3339)" example("2025-01-01T00:00:00Z")
+// @Param limit query int false "Maximum number of entries (default 100, max 1000)" example(100)
+// @Success 200 {array} ImpersonationAuditEntry "Audit entries"
+// @Failure 400 {object} map[string]interface{} "Invalid query parameters"
+// @Failure 401 {object} map[string]interface{} "Authentication required"
+// @Failure 403 {object} map[string]interface{} "Admin privileges required"
+// @Failure 500 {object} map[string]interface{} "Failed to list audit entries"
+// @Security BearerAuth
+// @Router /api/v1/admin/impersonations/audit [get]
+func (h *AuthHandler) ListImpersonationAudit(c *gin.Context) {
+	filter := ImpersonationAuditFilter{
+		Admin:     c.Query("admin"),
+		Email:     c.Query("email"),
+		SessionID: c.Query("session_id"),
+	}
+	if since := c.Query("since"); since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid since parameter", "details": err.Error()})
+			return
+		}
+		filter.Since = parsed
+	}
+	if limit := c.Query("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit parameter", "details": err.Error()})
+			return
+		}
+		filter.Limit = parsed
+	}
+
+	entries, err := h.service.ListImpersonationAudit(filter)
+	if err != nil {
+		writeImpersonationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, entries)
+}
+
+// writeImpersonationError maps errors of the impersonation endpoints to responses
+func writeImpersonationError(c *gin.Context, err error) {
+	switch {
+	case apperrors.IsValidation(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case apperrors.IsAuthorization(err):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden", "details": err.Error()})
+	case apperrors.IsNotFound(err):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Impersonation operation failed", "details": err.Error()})
+	}
+}
@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	apperrors "developer-portal-backend/internal/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newImpersonationTestService returns a service where octocat is logged in with both providers
+// and admin@example.com is an admin, together with the admin's claims
+func newImpersonationTestService(t *testing.T) (*AuthService, *AuthClaims) {
+	service, _, _ := loginWithBothProviders(t)
+	service.config.Admins = []string{"githubwdf:admin@example.com"}
+	return service, &AuthClaims{UserID: 2, Username: "admin", Email: "admin@example.com", Provider: "githubwdf", EmailVerified: true}
+}
+
+func TestStartImpersonation(t *testing.T) {
+	service, admin := newImpersonationTestService(t)
+
+	session, err := service.StartImpersonation(admin, ImpersonationRequest{Email: "octocat@example.com", Reason: "  support ticket 42 "})
+	require.NoError(t, err)
+	assert.Equal(t, int64(defaultImpersonationTTL.Seconds()), session.ExpiresIn)
+	assert.Equal(t, "support ticket 42", session.Impersonation.Reason)
+	assert.False(t, session.Impersonation.AllowWrite)
+
+	// The session acts as the user's identity of the admin's provider
+	claims, err := service.ValidateJWT(session.AccessToken)
+	require.NoError(t, err)
+	require.True(t, claims.IsImpersonation())
+	assert.Equal(t, "octocat", claims.Username)
+	assert.Equal(t, int64(42), claims.UserID)
+	assert.Equal(t, "githubwdf", claims.Provider)
+	assert.Equal(t, "admin", claims.Impersonation.Admin)
+	assert.Equal(t, session.SessionID, claims.ID)
+
+	// It is read-only, has no admin rights and cannot use the user's GitHub session
+	assert.True(t, claims.AllowsMethod(http.MethodGet))
+	assert.False(t, claims.AllowsMethod(http.MethodPost))
+	service.config.Admins = append(service.config.Admins, "githubwdf:octocat")
+	assert.False(t, service.IsAdmin(claims))
+	_, err = service.GetGitHubAccessTokenFromClaims(claims)
+	assert.True(t, apperrors.IsAuthorization(err))
+	_, _, err = service.CreatePersonalAccessToken(claims, "ci", []string{ScopeRead}, 0)
+	assert.True(t, apperrors.IsAuthorization(err))
+
+	// Nor can it be used to impersonate anyone else
+	_, err = service.StartImpersonation(claims, ImpersonationRequest{Email: "other@example.com", Reason: "x"})
+	assert.True(t, apperrors.IsAuthorization(err))
+
+	entries, err := service.ListImpersonationAudit(ImpersonationAuditFilter{SessionID: session.SessionID})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, ImpersonationEventStart, entries[0].Event)
+	assert.Equal(t, "octocat@example.com", entries[0].Email)
+}
+
+func TestStartImpersonationWithWriteAccess(t *testing.T) {
+	service, admin := newImpersonationTestService(t)
+
+	session, err := service.StartImpersonation(admin, ImpersonationRequest{Email: "octocat@example.com", Reason: "fix settings", TTLMinutes: 5, AllowWrite: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), session.ExpiresIn)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), session.ExpiresAt, 5*time.Second)
+
+	claims, err := service.ValidateJWT(session.AccessToken)
+	require.NoError(t, err)
+	assert.True(t, claims.AllowsMethod(http.MethodDelete))
+}
+
+func TestStartImpersonationIsRestricted(t *testing.T) {
+	service, admin := newImpersonationTestService(t)
+	valid := ImpersonationRequest{Email: "octocat@example.com", Reason: "support ticket 42"}
+
+	user := &AuthClaims{UserID: 3, Username: "someone", Email: "someone@example.com", Provider: "githubtools"}
+	_, err := service.StartImpersonation(user, valid)
+	assert.True(t, apperrors.IsAuthorization(err))
+
+	adminToken := *admin
+	adminToken.TokenType = TokenTypePersonal
+	adminToken.Scopes = []string{ScopeAdmin}
+	_, err = service.StartImpersonation(&adminToken, valid)
+	assert.True(t, apperrors.IsAuthorization(err), "API tokens cannot impersonate, even with the admin scope")
+
+	for name, req := range map[string]ImpersonationRequest{
+		"blank reason": {Email: "octocat@example.com", Reason: "   "},
+		"self":         {Email: "Admin@example.com", Reason: "testing"},
+		"ttl too long": {Email: "octocat@example.com", Reason: "testing", TTLMinutes: 121},
+		"negative ttl": {Email: "octocat@example.com", Reason: "testing", TTLMinutes: -1},
+	} {
+		_, err := service.StartImpersonation(admin, req)
+		assert.True(t, apperrors.IsValidation(err), name)
+	}
+
+	service.userRepo = nil
+	_, err = service.StartImpersonation(admin, valid)
+	assert.ErrorIs(t, err, apperrors.ErrUserNotFound)
+
+	entries, err := service.ListImpersonationAudit(ImpersonationAuditFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, entries, "rejected attempts start no session")
+}
+
+func TestImpersonatedRequestsAreAudited(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	service, admin := newImpersonationTestService(t)
+	middleware := NewAuthMiddleware(service)
+	handler := NewAuthHandler(service)
+
+	router := gin.New()
+	router.GET("/api/v1/projects", middleware.RequireAuth(), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.POST("/api/v1/projects", middleware.RequireAuth(), func(c *gin.Context) { c.Status(http.StatusCreated) })
+	router.POST("/api/v1/admin/impersonations", middleware.RequireAuth(), middleware.RequireAdmin(), handler.StartImpersonation)
+	router.GET("/api/v1/admin/impersonations/audit", middleware.RequireAuth(), middleware.RequireAdmin(), handler.ListImpersonationAudit)
+
+	adminToken, err := service.GenerateJWT(&UserProfile{ID: admin.UserID, Username: admin.Username, Email: admin.Email, EmailVerified: true}, admin.Provider)
+	require.NoError(t, err)
+	do := func(method, path, token, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do("POST", "/api/v1/admin/impersonations", adminToken, `{"email":"octocat@example.com"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	w = do("POST", "/api/v1/admin/impersonations", adminToken, `{"email":"octocat@example.com","reason":"support ticket 42"}`)
+	require.Equal(t, http.StatusCreated, w.Code)
+	var session ImpersonationSession
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &session))
+
+	assert.Equal(t, http.StatusOK, do("GET", "/api/v1/projects?name=cis", session.AccessToken, "").Code)
+	w = do("POST", "/api/v1/projects", session.AccessToken, `{}`)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "read-only")
+	assert.Equal(t, http.StatusForbidden, do("GET", "/api/v1/admin/impersonations/audit", session.AccessToken, "").Code)
+
+	w = do("GET", "/api/v1/admin/impersonations/audit?session_id="+session.SessionID, adminToken, "")
+	require.Equal(t, http.StatusOK, w.Code)
+	var entries []ImpersonationAuditEntry
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &entries))
+	require.Len(t, entries, 4)
+	statuses := make(map[string]int)
+	for _, entry := range entries[:3] {
+		assert.Equal(t, ImpersonationEventRequest, entry.Event)
+		assert.Equal(t, "admin", entry.Admin)
+		assert.Equal(t, "octocat@example.com", entry.Email)
+		statuses[entry.Method+" "+entry.Path] = entry.Status
+	}
+	assert.Equal(t, map[string]int{
+		"GET /api/v1/projects?name=cis":          http.StatusOK,
+		"POST /api/v1/projects":                  http.StatusForbidden,
+		"GET /api/v1/admin/impersonations/audit": http.StatusForbidden,
+	}, statuses)
+	assert.Equal(t, ImpersonationEventStart, entries[3].Event)
+
+	assert.Equal(t, http.StatusBadRequest, do("GET", "/api/v1/admin/impersonations/audit?since=yesterday", adminToken, "").Code)
+	assert.Equal(t, http.StatusBadRequest, do("GET", "/api/v1/admin/impersonations/audit?limit=5000", adminToken, "").Code)
+}
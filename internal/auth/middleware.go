@@ -7,7 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// AuthMiddleware provides JWT authentication middleware
+// AuthMiddleware provides authentication middleware for portal JWTs and API tokens
 type AuthMiddleware struct {
 	service *AuthService
 }
@@ -17,7 +17,10 @@ func NewAuthMiddleware(service *AuthService) *AuthMiddleware {
 	return &AuthMiddleware{service: service}
 }
 
-// RequireAuth validates JWT tokens and sets user context
+// RequireAuth validates JWTs or API tokens and sets user context. Requests made with an
+// API token are rejected with 403 if the token's scopes do not allow the HTTP method, as are
+// writes with a read-only impersonation session. Every request of an impersonation session,
+// including rejected ones, is recorded in the impersonation audit log.
 func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
@@ -35,14 +38,32 @@ func (m *AuthMiddleware) RequireAuth() gin.HandlerFunc {
 			return
 		}
 
-		// Validate token
-		claims, err := m.service.ValidateJWT(tokenString)
+		// Validate token (portal JWT or API token)
+		claims, err := m.service.Authenticate(tokenString)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token", "details": err.Error()})
 			c.Abort()
 			return
 		}
 
+		if claims.IsImpersonation() {
+			method, path := c.Request.Method, c.Request.URL.RequestURI()
+			defer func() {
+				m.service.AuditImpersonatedRequest(claims, method, path, c.Writer.Status())
+			}()
+		}
+
+		// API tokens are limited to the methods their scopes allow
+		if !claims.AllowsMethod(c.Request.Method) {
+			if claims.IsImpersonation() {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Impersonation session is read-only", "details": "impersonation sessions without allow_write do not allow " + c.Request.Method + " requests"})
+			} else {
+				c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient token scope", "details": "token scopes do not allow " + c.Request.Method + " requests"})
+			}
+			c.Abort()
+			return
+		}
+
 		// Set user context
 		c.Set("user_id", claims.UserID)
 		c.Set("username", claims.Username)
@@ -72,9 +93,9 @@ func (m *AuthMiddleware) OptionalAuth() gin.HandlerFunc {
 			return
 		}
 
-		// Validate token
-		claims, err := m.service.ValidateJWT(tokenString)
-		if err != nil {
+		// Validate token (portal JWT or API token)
+		claims, err := m.service.Authenticate(tokenString)
+		if err != nil || !claims.AllowsMethod(c.Request.Method) {
 			// Invalid token, continue without setting user context
 			c.Next()
 			return
@@ -127,6 +148,26 @@ func (m *AuthMiddleware) RequireProvider(allowedProviders ...string) gin.Handler
 	}
 }
 
+// RequireAdmin allows only users listed as admins in the auth config. It must run after RequireAuth.
+func (m *AuthMiddleware) RequireAdmin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, exists := GetAuthClaims(c)
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+			c.Abort()
+			return
+		}
+
+		if !m.service.IsAdmin(claims) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Admin privileges required"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
 // GetUserID is a helper function to extract user ID from context
 func GetUserID(c *gin.Context) (int64, bool) {
 	userID, exists := c.Get("user_id")
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"sync"
+	"time"
+
+	apperrors "developer-portal-backend/internal/errors"
+)
+
+// oauthStateTTL is how long a login started with /start may take to come back to the callback
+const oauthStateTTL = 10 * time.Minute
+
+// OAuthState is a login attempt started by /start and not yet completed
+type OAuthState struct {
+	Provider     string    `json:"provider"`
+	CodeVerifier string    `json:"-"` // PKCE code_verifier, never leaves the server
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// OAuthStateStore keeps issued OAuth state parameters until the callback consumes them.
+// States are addressed by their hash (see hashToken), like refresh tokens.
+type OAuthStateStore interface {
+	// Save stores a newly issued state
+	Save(stateHash string, state *OAuthState) error
+	// Consume atomically removes and returns the state. It returns apperrors.ErrInvalidOAuthState
+	// if the state is unknown or was already consumed, so a state can be used only once.
+	Consume(stateHash string) (*OAuthState, error)
+	// DeleteExpired removes states that expired before now and returns how many were removed
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+// memoryOAuthStateStore is an in-process OAuthStateStore for tests and local development
+type memoryOAuthStateStore struct {
+	mu     sync.Mutex
+	states map[string]*OAuthState
+}
+
+// NewMemoryOAuthStateStore creates an in-memory OAuth state store
+func NewMemoryOAuthStateStore() OAuthStateStore {
+	return &memoryOAuthStateStore{states: make(map[string]*OAuthState)}
+}
+
+func (m *memoryOAuthStateStore) Save(stateHash string, state *OAuthState) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.states[stateHash] = state
+	return nil
+}
+
+func (m *memoryOAuthStateStore) Consume(stateHash string) (*OAuthState, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	state, exists := m.states[stateHash]
+	if !exists {
+		return nil, apperrors.ErrInvalidOAuthState
+	}
+	delete(m.states, stateHash)
+	return state, nil
+}
+
+func (m *memoryOAuthStateStore) DeleteExpired(now time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var removed int64
+	for hash, state := range m.states {
+		if !now.Before(state.ExpiresAt) {
+			delete(m.states, hash)
+			removed++
+		}
+	}
+	return removed, nil
+}
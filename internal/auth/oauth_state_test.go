@@ -0,0 +1,233 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	apperrors "developer-portal-backend/internal/errors"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+// mockGitHub is a minimal GitHub Enterprise server that enforces PKCE on the token exchange
+type mockGitHub struct {
+	server    *httptest.Server
+	challenge string // code_challenge of the last authorization request
+	exchanges int
+	emails    string // response of /user/emails
+}
+
+func newMockGitHub(t *testing.T) *mockGitHub {
+	m := &mockGitHub{emails: `[{"email":"octocat@example.com","primary":true,"verified":true}]`}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		m.exchanges++
+		if oauth2.S256ChallengeFromVerifier(r.Form.Get("code_verifier")) != m.challenge {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant","error_description":"code_verifier mismatch"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"gho_upstream","token_type":"bearer","scope":"user:email"}`))
+	})
+	mux.HandleFunc("/api/v3/user", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":42,"login":"octocat","email":"octocat@example.com","name":"Octo Cat"}`))
+	})
+	mux.HandleFunc("/api/v3/user/emails", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(m.emails))
+	})
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+	return m
+}
+
+// recordChallenge remembers the PKCE challenge the authorization URL carries
+func (m *mockGitHub) recordChallenge(t *testing.T, authURL string) url.Values {
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	query := parsed.Query()
+	m.challenge = query.Get("code_challenge")
+	return query
+}
+
+func newOAuthTestService(t *testing.T, baseURL string) *AuthService {
+	config := &AuthConfig{
+		JWTSecret:   "test-signing-key-for-oauth-state",
+		RedirectURL: "http://localhost:3000",
+		Providers: map[string]ProviderConfig{
+			"githubtools": {ClientID: "tools-id", ClientSecret: "tools-secret", EnterpriseBaseURL: baseURL},
+			"githubwdf":   {ClientID: "wdf-id", ClientSecret: "wdf-secret", EnterpriseBaseURL: baseURL},
+		},
+	}
+	service, err := NewAuthService(config, nil)
+	require.NoError(t, err)
+	return service
+}
+
+func TestGetAuthURLAddsPKCEChallenge(t *testing.T) {
+	github := newMockGitHub(t)
+	service := newOAuthTestService(t, github.server.URL)
+
+	authURL, err := service.GetAuthURL("githubtools", "some-state")
+	require.NoError(t, err)
+
+	query := github.recordChallenge(t, authURL)
+	assert.Equal(t, "some-state", query.Get("state"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	assert.NotEmpty(t, query.Get("code_challenge"))
+	assert.Empty(t, query.Get("code_verifier"), "the verifier never leaves the server")
+
+	_, err = service.GetAuthURL("githubtools", "")
+	assert.Error(t, err)
+}
+
+func TestHandleCallbackValidatesState(t *testing.T) {
+	github := newMockGitHub(t)
+	service := newOAuthTestService(t, github.server.URL)
+	ctx := context.Background()
+
+	t.Run("issued state completes the login with PKCE", func(t *testing.T) {
+		authURL, err := service.GetAuthURL("githubtools", "valid-state")
+		require.NoError(t, err)
+		github.recordChallenge(t, authURL)
+
+		resp, err := service.HandleCallback(ctx, "githubtools", "code", "valid-state")
+		require.NoError(t, err)
+		assert.Equal(t, "octocat", resp.Profile.Username)
+		assert.NotEmpty(t, resp.AccessToken)
+	})
+
+	t.Run("replayed state is rejected", func(t *testing.T) {
+		exchanges := github.exchanges
+		_, err := service.HandleCallback(ctx, "githubtools", "code", "valid-state")
+		assert.ErrorIs(t, err, apperrors.ErrInvalidOAuthState)
+		assert.Equal(t, exchanges, github.exchanges, "the code is not exchanged for a rejected state")
+	})
+
+	t.Run("expired state is rejected", func(t *testing.T) {
+		require.NoError(t, service.oauthStates.Save(hashToken("expired-state"), &OAuthState{
+			Provider:     "githubtools",
+			CodeVerifier: oauth2.GenerateVerifier(),
+			ExpiresAt:    time.Now().Add(-time.Second),
+			CreatedAt:    time.Now().Add(-oauthStateTTL),
+		}))
+
+		_, err := service.HandleCallback(ctx, "githubtools", "code", "expired-state")
+		assert.ErrorIs(t, err, apperrors.ErrOAuthStateExpired)
+	})
+
+	t.Run("state never issued by us is rejected", func(t *testing.T) {
+		_, err := service.HandleCallback(ctx, "githubtools", "code", "forged-state")
+		assert.ErrorIs(t, err, apperrors.ErrInvalidOAuthState)
+	})
+
+	t.Run("state issued for another provider is rejected", func(t *testing.T) {
+		_, err := service.GetAuthURL("githubwdf", "wdf-state")
+		require.NoError(t, err)
+
+		_, err = service.HandleCallback(ctx, "githubtools", "code", "wdf-state")
+		assert.ErrorIs(t, err, apperrors.ErrInvalidOAuthState)
+	})
+
+	t.Run("code exchange fails without the matching verifier", func(t *testing.T) {
+		authURL, err := service.GetAuthURL("githubtools", "stolen-code-state")
+		require.NoError(t, err)
+		github.recordChallenge(t, authURL)
+		// An attacker that injects a code obtained with another challenge cannot redeem it
+		github.challenge = oauth2.S256ChallengeFromVerifier(oauth2.GenerateVerifier())
+
+		_, err = service.HandleCallback(ctx, "githubtools", "code", "stolen-code-state")
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to exchange code for token")
+	})
+}
+
+func TestCleanupExpiredTokensRemovesOAuthStates(t *testing.T) {
+	service := newOAuthTestService(t, "https://github.example.com")
+	require.NoError(t, service.oauthStates.Save(hashToken("old"), &OAuthState{Provider: "githubtools", ExpiresAt: time.Now().Add(-time.Second)}))
+	_, err := service.GetAuthURL("githubtools", "fresh")
+	require.NoError(t, err)
+
+	_, err = service.CleanupExpiredTokens()
+	require.NoError(t, err)
+
+	_, err = service.oauthStates.Consume(hashToken("old"))
+	assert.ErrorIs(t, err, apperrors.ErrInvalidOAuthState)
+	_, err = service.oauthStates.Consume(hashToken("fresh"))
+	assert.NoError(t, err)
+}
+
+func TestHandlerFrameStateCookie(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	github := newMockGitHub(t)
+	service := newOAuthTestService(t, github.server.URL)
+	handler := NewAuthHandler(service)
+
+	router := gin.New()
+	router.GET("/api/auth/:provider/start", handler.Start)
+	router.GET("/api/auth/:provider/handler/frame", handler.HandlerFrame)
+
+	start := func() (string, *http.Cookie) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/api/auth/githubtools/start", nil))
+		require.Equal(t, http.StatusFound, w.Code)
+
+		state := github.recordChallenge(t, w.Header().Get("Location")).Get("state")
+		for _, cookie := range w.Result().Cookies() {
+			if cookie.Name == oauthStateCookie {
+				assert.True(t, cookie.HttpOnly)
+				assert.Equal(t, http.SameSiteLaxMode, cookie.SameSite)
+				return state, cookie
+			}
+		}
+		t.Fatal("start did not set the oauth_state cookie")
+		return "", nil
+	}
+
+	callback := func(state string, cookie *http.Cookie) string {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", "/api/auth/githubtools/handler/frame?code=code&state="+url.QueryEscape(state), nil)
+		if cookie != nil {
+			req.AddCookie(cookie)
+		}
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		return w.Body.String()
+	}
+
+	t.Run("matching cookie completes the login", func(t *testing.T) {
+		state, cookie := start()
+		body := callback(state, cookie)
+		assert.Contains(t, body, "octocat")
+		assert.NotContains(t, body, "error:")
+
+		// Replaying the same callback fails even with the cookie
+		body = callback(state, cookie)
+		assert.Contains(t, body, escapeJSString(apperrors.ErrInvalidOAuthState.Error()))
+	})
+
+	t.Run("state started in another browser is rejected", func(t *testing.T) {
+		victimState, _ := start()
+		_, attackerCookie := start()
+
+		body := callback(victimState, attackerCookie)
+		assert.Contains(t, body, escapeJSString(apperrors.ErrInvalidOAuthState.Error()))
+	})
+
+	t.Run("missing cookie is rejected", func(t *testing.T) {
+		state, _ := start()
+		body := callback(state, nil)
+		assert.Contains(t, body, escapeJSString(apperrors.ErrInvalidOAuthState.Error()))
+	})
+}
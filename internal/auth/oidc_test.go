@@ -0,0 +1,303 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+// mockOIDC is a minimal OpenID Connect provider that enforces PKCE on the token exchange
+type mockOIDC struct {
+	server    *httptest.Server
+	challenge string        // code_challenge of the last authorization request
+	nonce     string        // nonce of the last authorization request
+	claims    jwt.MapClaims // extra ID token claims, overriding the defaults
+	userinfo  map[string]interface{}
+}
+
+func newMockOIDC(t *testing.T) *mockOIDC {
+	m := &mockOIDC{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 m.server.URL,
+			"authorization_endpoint": m.server.URL + "/authorize",
+			"token_endpoint":         m.server.URL + "/token",
+			"userinfo_endpoint":      m.server.URL + "/userinfo",
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		w.Header().Set("Content-Type", "application/json")
+		if oauth2.S256ChallengeFromVerifier(r.Form.Get("code_verifier")) != m.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		claims := jwt.MapClaims{
+			"iss":   m.server.URL,
+			"aud":   "oidc-client",
+			"sub":   "user-7",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"nonce": m.nonce,
+			"email": "jane.doe@example.com",
+			"name":  "Jane Doe",
+			"login": "jdoe",
+		}
+		for name, value := range m.claims {
+			claims[name] = value
+		}
+		idToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("idp-key"))
+		require.NoError(t, err)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "oidc-upstream",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     idToken,
+		})
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer oidc-upstream" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(m.userinfo)
+	})
+	m.server = httptest.NewServer(mux)
+	t.Cleanup(m.server.Close)
+	return m
+}
+
+// recordRequest remembers the PKCE challenge and nonce the authorization URL carries
+func (m *mockOIDC) recordRequest(t *testing.T, authURL string) url.Values {
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	query := parsed.Query()
+	m.challenge = query.Get("code_challenge")
+	m.nonce = query.Get("nonce")
+	return query
+}
+
+func newOIDCTestService(t *testing.T, issuer string) *AuthService {
+	config := &AuthConfig{
+		JWTSecret:   "test-signing-key-for-oidc",
+		RedirectURL: "http://localhost:3000",
+		Providers: map[string]ProviderConfig{
+			"corp": {
+				Type:         ProviderTypeOIDC,
+				ClientID:     "oidc-client",
+				ClientSecret: "oidc-secret",
+				IssuerURL:    issuer,
+				Claims:       ClaimMapping{Username: "login"},
+			},
+		},
+	}
+	service, err := NewAuthService(config, nil)
+	require.NoError(t, err)
+	return service
+}
+
+func TestOIDCLogin(t *testing.T) {
+	idp := newMockOIDC(t)
+	service := newOIDCTestService(t, idp.server.URL)
+	ctx := context.Background()
+
+	login := func(state string) (*AuthHandlerResponse, error) {
+		authURL, err := service.GetAuthURL("corp", state)
+		require.NoError(t, err)
+		idp.recordRequest(t, authURL)
+		return service.HandleCallback(ctx, "corp", "code", state)
+	}
+
+	t.Run("authorization URL comes from discovery", func(t *testing.T) {
+		authURL, err := service.GetAuthURL("corp", "url-state")
+		require.NoError(t, err)
+		assert.Contains(t, authURL, idp.server.URL+"/authorize")
+
+		query := idp.recordRequest(t, authURL)
+		assert.Equal(t, "oidc-client", query.Get("client_id"))
+		assert.Equal(t, "openid email profile", query.Get("scope"))
+		assert.Equal(t, "S256", query.Get("code_challenge_method"))
+		assert.NotEmpty(t, query.Get("nonce"))
+	})
+
+	t.Run("claims are mapped to the profile", func(t *testing.T) {
+		idp.claims = nil
+		resp, err := login("mapped-state")
+		require.NoError(t, err)
+		// Usernames of OIDC providers are namespaced, so they cannot name a GitHub login
+		assert.Equal(t, "corp/jdoe", resp.Profile.Username)
+		assert.Equal(t, "jane.doe@example.com", resp.Profile.Email)
+		assert.Equal(t, "Jane Doe", resp.Profile.Name)
+		assert.Equal(t, subjectID("user-7"), resp.Profile.ID)
+
+		claims, err := service.ValidateJWT(resp.AccessToken)
+		require.NoError(t, err)
+		assert.Equal(t, "corp", claims.Provider)
+		assert.Equal(t, "corp/jdoe", claims.Username)
+
+		// The session refreshes like any other provider's
+		refreshed, err := service.RefreshToken(resp.RefreshToken)
+		require.NoError(t, err)
+		assert.Equal(t, "corp/jdoe", refreshed.Profile.Username)
+	})
+
+	t.Run("missing claims are completed from userinfo", func(t *testing.T) {
+		idp.claims = jwt.MapClaims{"login": nil, "email": nil}
+		idp.userinfo = map[string]interface{}{"sub": "user-7", "login": "jdoe", "email": "jane.doe@example.com"}
+		resp, err := login("userinfo-state")
+		require.NoError(t, err)
+		assert.Equal(t, "corp/jdoe", resp.Profile.Username)
+		assert.Equal(t, "jane.doe@example.com", resp.Profile.Email)
+	})
+
+	t.Run("email is verified by the email_verified claim", func(t *testing.T) {
+		for value, verified := range map[interface{}]bool{true: true, "true": true, false: false, nil: false} {
+			idp.claims = jwt.MapClaims{"email_verified": value}
+			resp, err := login("verified-state")
+			require.NoError(t, err)
+			assert.Equal(t, verified, resp.Profile.EmailVerified, "email_verified %v", value)
+		}
+	})
+
+	t.Run("userinfo for another subject is rejected", func(t *testing.T) {
+		idp.claims = jwt.MapClaims{"email": nil}
+		idp.userinfo = map[string]interface{}{"sub": "someone-else", "email": "x@example.com"}
+		_, err := login("other-subject-state")
+		assert.ErrorContains(t, err, "userinfo subject does not match")
+	})
+
+	rejected := []struct {
+		name   string
+		claims jwt.MapClaims
+		error  string
+	}{
+		{"foreign audience", jwt.MapClaims{"aud": "other-client"}, "not issued for this client"},
+		{"foreign issuer", jwt.MapClaims{"iss": "https://evil.example.com"}, "issuer"},
+		{"expired token", jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()}, "expired"},
+		{"replayed nonce", jwt.MapClaims{"nonce": "stale"}, "nonce"},
+		{"missing subject", jwt.MapClaims{"sub": ""}, "no subject"},
+	}
+	for _, tc := range rejected {
+		t.Run(tc.name+" is rejected", func(t *testing.T) {
+			idp.claims = tc.claims
+			_, err := login("rejected-state")
+			assert.ErrorContains(t, err, tc.error)
+		})
+	}
+
+	t.Run("code exchange fails without the matching verifier", func(t *testing.T) {
+		idp.claims = nil
+		authURL, err := service.GetAuthURL("corp", "stolen-code-state")
+		require.NoError(t, err)
+		idp.recordRequest(t, authURL)
+		idp.challenge = oauth2.S256ChallengeFromVerifier(oauth2.GenerateVerifier())
+
+		_, err = service.HandleCallback(ctx, "corp", "code", "stolen-code-state")
+		assert.ErrorContains(t, err, "failed to exchange code for token")
+	})
+}
+
+func TestOIDCDiscoveryRejectsIssuerMismatch(t *testing.T) {
+	idp := newMockOIDC(t)
+	// Discovery is served by the mock, but it claims another issuer than configured
+	service := newOIDCTestService(t, "https://login.example.com")
+	service.providers["corp"].(*OIDCProvider).config.DiscoveryURL = idp.server.URL + "/.well-known/openid-configuration"
+
+	_, err := service.GetAuthURL("corp", "state")
+	assert.ErrorContains(t, err, "does not match configured issuer")
+}
+
+func TestOIDCStartHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	idp := newMockOIDC(t)
+	handler := NewAuthHandler(newOIDCTestService(t, idp.server.URL))
+
+	router := gin.New()
+	router.GET("/api/auth/:provider/start", handler.Start)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/auth/corp/start", nil))
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Contains(t, w.Header().Get("Location"), idp.server.URL+"/authorize")
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/auth/unknown/start", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestLoadAuthConfigWithOIDCProvider(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	// The GitHub providers are always part of the defaults
+	for _, name := range []string{"GITHUB_TOOLS_APP_CLIENT_ID", "GITHUB_TOOLS_APP_CLIENT_SECRET", "GITHUB_WDF_APP_CLIENT_ID", "GITHUB_WDF_APP_CLIENT_SECRET"} {
+		t.Setenv(name, "github")
+	}
+	t.Setenv("OIDC_TEST_CLIENT_SECRET", "from-env")
+	path := filepath.Join(t.TempDir(), "auth.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+redirect_url: "http://localhost:3000"
+providers:
+  corp:
+    type: oidc
+    client_id: "portal"
+    client_secret: "${OIDC_TEST_CLIENT_SECRET}"
+    issuer_url: "https://login.example.com"
+    discovery_url: "https://login.example.com/custom/discovery"
+    scopes: [openid, email]
+    claims:
+      username: upn
+      avatar_url: photo
+`), 0o600))
+
+	config, err := LoadAuthConfig(path)
+	require.NoError(t, err)
+
+	provider, err := config.GetProvider("corp")
+	require.NoError(t, err)
+	assert.Equal(t, ProviderTypeOIDC, provider.Type)
+	assert.Equal(t, "from-env", provider.ClientSecret)
+	assert.Equal(t, "https://login.example.com", provider.IssuerURL)
+	assert.Equal(t, "https://login.example.com/custom/discovery", provider.DiscoveryURL)
+	assert.Equal(t, []string{"openid", "email"}, provider.Scopes)
+	assert.Equal(t, "upn", provider.Claims.Username)
+	assert.Equal(t, "photo", provider.Claims.AvatarURL)
+}
+
+func TestLoadAuthConfigSkipsUnsetOIDCProvider(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+	for _, name := range []string{"GITHUB_TOOLS_APP_CLIENT_ID", "GITHUB_TOOLS_APP_CLIENT_SECRET", "GITHUB_WDF_APP_CLIENT_ID", "GITHUB_WDF_APP_CLIENT_SECRET"} {
+		t.Setenv(name, "github")
+	}
+	path := filepath.Join(t.TempDir(), "auth.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+redirect_url: "http://localhost:3000"
+providers:
+  corp:
+    type: oidc
+    client_id: "portal"
+    client_secret: "${OIDC_UNSET_CLIENT_SECRET}"
+    issuer_url: "https://login.example.com"
+`), 0o600))
+
+	// A provider nobody configured does not keep the others from loading
+	config, err := LoadAuthConfig(path)
+	require.NoError(t, err)
+	_, err = config.GetProvider("corp")
+	assert.Error(t, err)
+	_, err = config.GetProvider("githubtools")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,34 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+)
+
+// Provider types selectable with the type key of a provider in config/auth.yaml
+const (
+	ProviderTypeGitHub = "github" // GitHub or GitHub Enterprise OAuth app (default)
+	ProviderTypeOIDC   = "oidc"   // any OpenID Connect identity provider
+)
+
+// IdentityProvider is an upstream login provider behind the start/handler/refresh flow
+type IdentityProvider interface {
+	// LoginURL returns the provider's authorization URL for the state. The URL carries the
+	// PKCE S256 challenge of codeVerifier.
+	LoginURL(ctx context.Context, redirectURL, state, codeVerifier string) (string, error)
+	// Authenticate redeems the authorization code and returns the user's profile together with
+	// the upstream access token kept in the refresh token store
+	Authenticate(ctx context.Context, redirectURL, code, codeVerifier string) (*UserProfile, string, error)
+}
+
+// NewIdentityProvider creates the provider implementation selected by config.Type
+func NewIdentityProvider(config *ProviderConfig) (IdentityProvider, error) {
+	switch config.Type {
+	case "", ProviderTypeGitHub:
+		return NewGitHubClient(config), nil
+	case ProviderTypeOIDC:
+		return NewOIDCProvider(config), nil
+	default:
+		return nil, fmt.Errorf("unknown provider type '%s'", config.Type)
+	}
+}
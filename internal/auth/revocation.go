@@ -0,0 +1,167 @@
+package auth
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// revocationSyncOverlap is how far back each sync re-reads the denylist, so entries committed
+// late by another replica (or written with a slightly skewed clock) are not missed
+const revocationSyncOverlap = time.Minute
+
+// Revocation is an entry of the JWT denylist. It either revokes a single token (JTI set)
+// or every token issued to a user up to RevokedAt (Email set, JTI empty).
+type Revocation struct {
+	JTI       string    `json:"jti,omitempty"`
+	Email     string    `json:"email,omitempty"`
+	RevokedAt time.Time `json:"revoked_at"`
+	RevokedBy string    `json:"revoked_by,omitempty"`
+	Reason    string    `json:"reason,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// RevocationStore persists the JWT denylist so it is shared by all replicas
+type RevocationStore interface {
+	// Add stores a revocation entry
+	Add(revocation *Revocation) error
+	// ListSince returns all non-expired entries revoked at or after since
+	ListSince(since time.Time) ([]Revocation, error)
+	// DeleteExpired removes entries that expired before now and returns how many were removed
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+// memoryRevocationStore is an in-process RevocationStore for tests and local development
+type memoryRevocationStore struct {
+	mu          sync.RWMutex
+	revocations []Revocation
+}
+
+// NewMemoryRevocationStore creates an in-memory revocation store
+func NewMemoryRevocationStore() RevocationStore {
+	return &memoryRevocationStore{}
+}
+
+func (m *memoryRevocationStore) Add(revocation *Revocation) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.revocations = append(m.revocations, *revocation)
+	return nil
+}
+
+func (m *memoryRevocationStore) ListSince(since time.Time) ([]Revocation, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	now := time.Now()
+	result := make([]Revocation, 0)
+	for _, r := range m.revocations {
+		if !r.RevokedAt.Before(since) && now.Before(r.ExpiresAt) {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+func (m *memoryRevocationStore) DeleteExpired(now time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	kept := m.revocations[:0]
+	for _, r := range m.revocations {
+		if now.Before(r.ExpiresAt) {
+			kept = append(kept, r)
+		}
+	}
+	removed := int64(len(m.revocations) - len(kept))
+	m.revocations = kept
+	return removed, nil
+}
+
+// revocationCache is the in-process view of the denylist consulted on every request.
+// It is filled from the RevocationStore by periodic syncs and updated immediately
+// for revocations made by this process.
+type revocationCache struct {
+	mu       sync.RWMutex
+	jtis     map[string]time.Time // jti -> entry expiry
+	users    map[string]time.Time // lower-cased email -> latest RevokedAt
+	syncedAt time.Time
+}
+
+func newRevocationCache() *revocationCache {
+	return &revocationCache{
+		jtis:  make(map[string]time.Time),
+		users: make(map[string]time.Time),
+	}
+}
+
+// add records a revocation in the cache
+func (c *revocationCache) add(r Revocation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.addLocked(r)
+}
+
+func (c *revocationCache) addLocked(r Revocation) {
+	if r.JTI != "" {
+		c.jtis[r.JTI] = r.ExpiresAt
+		return
+	}
+	if r.Email == "" {
+		return
+	}
+	email := strings.ToLower(r.Email)
+	if r.RevokedAt.After(c.users[email]) {
+		c.users[email] = r.RevokedAt
+	}
+}
+
+// isRevoked reports whether the token described by claims is on the denylist
+func (c *revocationCache) isRevoked(claims *AuthClaims) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if claims.ID != "" {
+		if _, revoked := c.jtis[claims.ID]; revoked {
+			return true
+		}
+	}
+	if claims.Email == "" || claims.IssuedAt == 0 {
+		return false
+	}
+	revokedAt, exists := c.users[strings.ToLower(claims.Email)]
+	// IssuedAt has second precision, so a token issued in the same second as the revocation is revoked too
+	return exists && !time.Unix(claims.IssuedAt, 0).After(revokedAt)
+}
+
+// sync loads entries added to the store since the last sync and drops expired ones
+func (c *revocationCache) sync(store RevocationStore) error {
+	c.mu.RLock()
+	since := c.syncedAt
+	c.mu.RUnlock()
+	if !since.IsZero() {
+		since = since.Add(-revocationSyncOverlap)
+	}
+
+	started := time.Now()
+	revocations, err := store.ListSince(since)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, r := range revocations {
+		c.addLocked(r)
+	}
+	for jti, expiresAt := range c.jtis {
+		if !started.Before(expiresAt) {
+			delete(c.jtis, jti)
+		}
+	}
+	for email, revokedAt := range c.users {
+		// User-wide entries only matter while tokens issued before them can still be valid
+		if !started.Before(revokedAt.Add(jwtTTL)) {
+			delete(c.users, email)
+		}
+	}
+	c.syncedAt = started
+	return nil
+}
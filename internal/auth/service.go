@@ -4,15 +4,27 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"log"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 
+	apperrors "developer-portal-backend/internal/errors"
+
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"golang.org/x/oauth2"
 )
 
+// jwtTTL is how long an issued portal JWT stays valid
+const jwtTTL = time.Hour
+
+// refreshTokenTTL is how long a refresh token (and the upstream session it carries) stays valid
+const refreshTokenTTL = 30 * 24 * time.Hour
+
 // RefreshTokenData stores information about a refresh token
 type RefreshTokenData struct {
 	UserID      int64     `json:"user_id"`
@@ -20,9 +32,11 @@ type RefreshTokenData struct {
 	Email       string    `json:"email"`
 	MemberID    *string   `json:"member_id,omitempty"` // ID of member with matching email
 	Provider    string    `json:"provider"`
-	AccessToken string    `json:"access_token"`
+	AccessToken string    `json:"-"` // upstream OAuth access token, sealed when token_encryption is configured
 	ExpiresAt   time.Time `json:"expires_at"`
 	CreatedAt   time.Time `json:"created_at"`
+	// EmailVerified tells whether the provider verified Email at login
+	EmailVerified bool `json:"email_verified"`
 }
 
 // MemberRepository defines the interface for member operations needed by auth service
@@ -32,11 +46,21 @@ type UserRepository interface {
 
 // AuthService provides authentication functionality
 type AuthService struct {
-	config        *AuthConfig
-	githubClients map[string]*GitHubClient
-	refreshTokens map[string]*RefreshTokenData // In-memory store for refresh tokens
-	tokenMutex    sync.RWMutex                 // Protect the refresh token store
-	userRepo      UserRepository               // Repository for member lookup
+	config             *AuthConfig
+	providers          map[string]IdentityProvider // Login providers by name, as configured in auth.yaml
+	tokenStore         TokenStore                  // Store for refresh tokens and upstream sessions
+	revocations        RevocationStore             // Persisted JWT denylist
+	oauthStates        OAuthStateStore             // Issued OAuth states awaiting their callback
+	apiTokens          APITokenStore               // Personal access and service account tokens
+	serviceAccounts    ServiceAccountStore         // Non-human identities that own API tokens
+	signingKeys        SigningKeyStore             // JWT key set shared by all replicas
+	identities         IdentityStore               // Provider identities linked to portal users
+	impersonationAudit ImpersonationAuditStore     // Log of impersonation sessions and their requests
+	credentials        *credentialCipher           // Seals stored upstream access tokens; nil stores them in plaintext
+	revoked            *revocationCache            // In-process view of the denylist checked on every request
+	keys               *keyRing                    // In-process view of the JWT key set
+	rotateMu           sync.Mutex                  // Serializes key rotations of this process
+	userRepo           UserRepository              // Repository for member lookup
 }
 
 // AuthClaims represents JWT token claims
@@ -45,6 +69,13 @@ type AuthClaims struct {
 	Username string `json:"username" example:"johndoe"`
 	Email    string `json:"email" example:"john.doe@example.com"`
 	Provider string `json:"provider" example:"githubtools"`
+	// EmailVerified tells whether the provider verified Email; only verified emails grant admin rights
+	EmailVerified bool `json:"email_verified,omitempty" example:"true"`
+	// Set only for claims derived from API tokens, see TokenTypePersonal and TokenTypeServiceAccount
+	TokenType string   `json:"token_type,omitempty" example:"personal_access_token"`
+	Scopes    []string `json:"scopes,omitempty" example:"read"`
+	// Set only for impersonation sessions, in which an admin acts as the user of the claims
+	Impersonation *Impersonation `json:"impersonation,omitempty"`
 	// Standard JWT fields
 	Issuer               string `json:"iss,omitempty" example:"developer-portal-backend"`
 	Subject              string `json:"sub,omitempty" example:"12345"`
@@ -54,6 +85,44 @@ type AuthClaims struct {
 	jwt.RegisteredClaims `swaggerignore:"true"`
 }
 
+// The standard fields above shadow the ones of the embedded RegisteredClaims when the token is
+// (un)marshalled, so the jwt.Claims getters must read them instead of the embedded struct.
+
+// GetExpirationTime implements jwt.Claims
+func (c AuthClaims) GetExpirationTime() (*jwt.NumericDate, error) {
+	return unixNumericDate(c.ExpiresAt), nil
+}
+
+// GetIssuedAt implements jwt.Claims
+func (c AuthClaims) GetIssuedAt() (*jwt.NumericDate, error) {
+	return unixNumericDate(c.IssuedAt), nil
+}
+
+// GetIssuer implements jwt.Claims
+func (c AuthClaims) GetIssuer() (string, error) {
+	return c.Issuer, nil
+}
+
+// GetSubject implements jwt.Claims
+func (c AuthClaims) GetSubject() (string, error) {
+	return c.Subject, nil
+}
+
+// GetAudience implements jwt.Claims
+func (c AuthClaims) GetAudience() (jwt.ClaimStrings, error) {
+	if c.Audience == "" {
+		return nil, nil
+	}
+	return jwt.ClaimStrings{c.Audience}, nil
+}
+
+func unixNumericDate(seconds int64) *jwt.NumericDate {
+	if seconds == 0 {
+		return nil
+	}
+	return jwt.NewNumericDate(time.Unix(seconds, 0))
+}
+
 // AuthStartResponse represents the response for auth start endpoint
 type AuthStartResponse struct {
 	URL string `json:"url"`
@@ -94,25 +163,103 @@ type AuthValidateResponse struct {
 	Claims *AuthClaims `json:"claims"`
 }
 
-// NewAuthService creates a new authentication service
+// Stores holds the persistence used by AuthService. Nil stores default to in-memory ones,
+// which do not survive restarts and are not shared between replicas.
+type Stores struct {
+	Tokens          TokenStore
+	Revocations     RevocationStore
+	OAuthStates     OAuthStateStore
+	APITokens       APITokenStore
+	ServiceAccounts ServiceAccountStore
+	SigningKeys     SigningKeyStore
+	Identities      IdentityStore
+	Impersonations  ImpersonationAuditStore
+}
+
+// NewAuthService creates a new authentication service backed by in-memory stores
 func NewAuthService(config *AuthConfig, userRepo UserRepository) (*AuthService, error) {
+	return NewAuthServiceWithStores(config, userRepo, Stores{})
+}
+
+// NewAuthServiceWithStores creates a new authentication service that keeps refresh tokens,
+// the JWT denylist, OAuth states, API tokens, service accounts, JWT signing keys, linked identities
+// and the impersonation audit log in the given stores. Use persistent stores when running more than one replica.
+func NewAuthServiceWithStores(config *AuthConfig, userRepo UserRepository, stores Stores) (*AuthService, error) {
+	if stores.Tokens == nil {
+		stores.Tokens = NewMemoryTokenStore()
+	}
+	if stores.Revocations == nil {
+		stores.Revocations = NewMemoryRevocationStore()
+	}
+	if stores.OAuthStates == nil {
+		stores.OAuthStates = NewMemoryOAuthStateStore()
+	}
+	if stores.APITokens == nil {
+		stores.APITokens = NewMemoryAPITokenStore()
+	}
+	if stores.ServiceAccounts == nil {
+		stores.ServiceAccounts = NewMemoryServiceAccountStore()
+	}
+	if stores.SigningKeys == nil {
+		stores.SigningKeys = NewMemorySigningKeyStore()
+	}
+	if stores.Identities == nil {
+		stores.Identities = NewMemoryIdentityStore()
+	}
+	if stores.Impersonations == nil {
+		stores.Impersonations = NewMemoryImpersonationAuditStore()
+	}
 	if err := config.ValidateConfig(); err != nil {
 		return nil, fmt.Errorf("invalid auth config: %w", err)
 	}
 
-	// Initialize GitHub clients for each provider
-	githubClients := make(map[string]*GitHubClient)
+	// Initialize the identity provider of each configured provider
+	providers := make(map[string]IdentityProvider)
 	for providerName, providerConfig := range config.Providers {
-		githubClients[providerName] = NewGitHubClient(&providerConfig)
+		providerConfig := providerConfig
+		provider, err := NewIdentityProvider(&providerConfig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid provider '%s': %w", providerName, err)
+		}
+		providers[providerName] = provider
+	}
+
+	credentials, err := newCredentialCipher(config.TokenEncryption)
+	if err != nil {
+		return nil, err
+	}
+	if credentials == nil {
+		log.Printf("Warning: token_encryption is not configured, upstream access tokens are stored in plaintext")
+	}
+
+	s := &AuthService{
+		config:             config,
+		providers:          providers,
+		tokenStore:         stores.Tokens,
+		revocations:        stores.Revocations,
+		oauthStates:        stores.OAuthStates,
+		apiTokens:          stores.APITokens,
+		serviceAccounts:    stores.ServiceAccounts,
+		signingKeys:        stores.SigningKeys,
+		identities:         stores.Identities,
+		impersonationAudit: stores.Impersonations,
+		credentials:        credentials,
+		revoked:            newRevocationCache(),
+		keys:               newKeyRing(),
+		userRepo:           userRepo,
+	}
+
+	// Load the current denylist so tokens revoked before startup are rejected right away
+	if err := s.SyncRevocations(); err != nil {
+		return nil, fmt.Errorf("failed to load token revocations: %w", err)
 	}
 
-	return &AuthService{
-		config:        config,
-		githubClients: githubClients,
-		refreshTokens: make(map[string]*RefreshTokenData),
-		tokenMutex:    sync.RWMutex{},
-		userRepo:      userRepo,
-	}, nil
+	// Make sure a signing key exists before the first token is issued
+	if err := s.RotateSigningKeys(); err != nil {
+		return nil, fmt.Errorf("failed to initialize JWT signing keys: %w", err)
+	}
+
+	return s, nil
 }
 
 // getMemberIDByEmail looks up a member by email and returns their ID as a string pointer
@@ -152,25 +299,70 @@ func (s *AuthService) GetMemberIDByEmail(email string) *string {
 	return s.getMemberIDByEmail(email)
 }
 
-// GetAuthURL generates OAuth2 authorization URL
+// HasProvider reports whether a login provider with the given name is configured
+func (s *AuthService) HasProvider(provider string) bool {
+	_, exists := s.providers[provider]
+	return exists
+}
+
+// GetAuthURL generates OAuth2 authorization URL. The state is recorded for oauthStateTTL together
+// with a PKCE code_verifier, and HandleCallback only accepts it once, for the same provider.
 func (s *AuthService) GetAuthURL(provider, state string) (string, error) {
 	_, err := s.config.GetProvider(provider)
 	if err != nil {
 		return "", err
 	}
 
-	githubClient, exists := s.githubClients[provider]
+	identityProvider, exists := s.providers[provider]
 	if !exists {
-		return "", fmt.Errorf("GitHub client not found for provider %s", provider)
+		return "", fmt.Errorf("identity provider not found for provider %s", provider)
+	}
+	if state == "" {
+		return "", fmt.Errorf("state is required")
+	}
+
+	// Remember the state so the callback can verify it was issued by us
+	now := time.Now()
+	codeVerifier := oauth2.GenerateVerifier()
+	err = s.oauthStates.Save(hashToken(state), &OAuthState{
+		Provider:     provider,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    now.Add(oauthStateTTL),
+		CreatedAt:    now,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to store OAuth state: %w", err)
 	}
 
 	// Generate callback URL
 	callbackURL := fmt.Sprintf("%s/api/auth/%s/handler/frame", s.config.RedirectURL, provider)
 
-	oauth2Config := githubClient.GetOAuth2Config(callbackURL)
-	authURL := oauth2Config.AuthCodeURL(state, oauth2.AccessTypeOffline)
+	return identityProvider.LoginURL(context.Background(), callbackURL, state, codeVerifier)
+}
+
+// consumeState validates a state returned to the callback and removes it, so it cannot be replayed
+func (s *AuthService) consumeState(provider, state string) (*OAuthState, error) {
+	if state == "" {
+		return nil, apperrors.ErrInvalidOAuthState
+	}
+
+	issued, err := s.oauthStates.Consume(hashToken(state))
+	if err != nil {
+		if errors.Is(err, apperrors.ErrInvalidOAuthState) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to load OAuth state: %w", err)
+	}
+
+	if !time.Now().Before(issued.ExpiresAt) {
+		return nil, apperrors.ErrOAuthStateExpired
+	}
+	// A state issued for another provider must not complete this provider's login
+	if issued.Provider != provider {
+		return nil, apperrors.ErrInvalidOAuthState
+	}
 
-	return authURL, nil
+	return issued, nil
 }
 
 // HandleCallback processes OAuth2 callback and returns user information
@@ -180,30 +372,30 @@ func (s *AuthService) HandleCallback(ctx context.Context, provider, code, state
 		return nil, err
 	}
 
-	githubClient, exists := s.githubClients[provider]
+	identityProvider, exists := s.providers[provider]
 	if !exists {
-		return nil, fmt.Errorf("GitHub client not found for provider %s", provider)
+		return nil, fmt.Errorf("identity provider not found for provider %s", provider)
 	}
 
-	// Generate callback URL
-	callbackURL := fmt.Sprintf("%s/api/auth/%s/handler/frame", s.config.RedirectURL, provider)
-
-	oauth2Config := githubClient.GetOAuth2Config(callbackURL)
-
-	// Exchange authorization code for access token
-	token, err := oauth2Config.Exchange(ctx, code)
+	// Verify the state before spending the authorization code
+	issued, err := s.consumeState(provider, state)
 	if err != nil {
-		return nil, fmt.Errorf("failed to exchange code for token: %w", err)
+		return nil, err
 	}
 
-	// Get user profile from GitHub
-	profile, err := githubClient.GetUserProfile(ctx, token.AccessToken)
+	// Generate callback URL
+	callbackURL := fmt.Sprintf("%s/api/auth/%s/handler/frame", s.config.RedirectURL, provider)
+
+	// Exchange authorization code for the upstream access token and the user's profile
+	profile, accessToken, err := identityProvider.Authenticate(ctx, callbackURL, code, issued.CodeVerifier)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user profile: %w", err)
+		return nil, err
 	}
+	profile.Username = s.namespacedUsername(provider, profile.Username)
 
 	// Look up member by email and populate MemberID if found
 	profile.MemberID = s.getMemberIDByEmail(profile.Email)
+	s.linkIdentity(profile, provider)
 
 	// Generate JWT token
 	jwtToken, err := s.GenerateJWT(profile, provider)
@@ -218,18 +410,25 @@ func (s *AuthService) HandleCallback(ctx context.Context, provider, code, state
 	}
 
 	// Store refresh token data
-	s.tokenMutex.Lock()
-	s.refreshTokens[refreshToken] = &RefreshTokenData{
-		UserID:      profile.ID,
-		Username:    profile.Username,
-		Email:       profile.Email,
-		MemberID:    profile.MemberID,
-		Provider:    provider,
-		AccessToken: token.AccessToken,                   // Store the original OAuth access token
-		ExpiresAt:   time.Now().Add(30 * 24 * time.Hour), // 30 days
-		CreatedAt:   time.Now(),
-	}
-	s.tokenMutex.Unlock()
+	sealedAccessToken, err := s.sealCredential(accessToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt access token: %w", err)
+	}
+	now := time.Now()
+	err = s.tokenStore.Save(hashToken(refreshToken), &RefreshTokenData{
+		UserID:        profile.ID,
+		Username:      profile.Username,
+		Email:         profile.Email,
+		MemberID:      profile.MemberID,
+		Provider:      provider,
+		EmailVerified: profile.EmailVerified,
+		AccessToken:   sealedAccessToken, // The original OAuth access token, sealed when token_encryption is configured
+		ExpiresAt:     now.Add(refreshTokenTTL),
+		CreatedAt:     now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store refresh token: %w", err)
+	}
 
 	response := &AuthHandlerResponse{
 		AccessToken:  jwtToken,
@@ -242,31 +441,43 @@ func (s *AuthService) HandleCallback(ctx context.Context, provider, code, state
 	return response, nil
 }
 
+// namespacedUsername prefixes the usernames of non-GitHub providers with the provider name (e.g.
+// corp/jdoe). Users often choose those usernames themselves, so unprefixed they could name someone's
+// GitHub login and resolve to that user's row and rights; GitHub logins cannot contain '/'.
+func (s *AuthService) namespacedUsername(provider, username string) string {
+	switch s.config.Providers[provider].Type {
+	case "", ProviderTypeGitHub:
+		return username
+	default:
+		return provider + "/" + username
+	}
+}
+
 // RefreshToken generates a new JWT token from a refresh token
 func (s *AuthService) RefreshToken(refreshToken string) (*AuthHandlerResponse, error) {
-	s.tokenMutex.RLock()
-	tokenData, exists := s.refreshTokens[refreshToken]
-	s.tokenMutex.RUnlock()
-
-	if !exists {
-		return nil, fmt.Errorf("invalid refresh token")
+	tokenHash := hashToken(refreshToken)
+	tokenData, err := s.tokenStore.Get(tokenHash)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrInvalidRefreshToken) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to load refresh token: %w", err)
 	}
 
 	// Check if refresh token has expired (valid for 30 days)
 	if time.Now().After(tokenData.ExpiresAt) {
 		// Clean up expired token
-		s.tokenMutex.Lock()
-		delete(s.refreshTokens, refreshToken)
-		s.tokenMutex.Unlock()
-		return nil, fmt.Errorf("refresh token has expired")
+		_ = s.tokenStore.Delete(tokenHash)
+		return nil, apperrors.ErrRefreshTokenExpired
 	}
 
 	// Create user profile from stored data
 	profile := &UserProfile{
-		ID:       tokenData.UserID,
-		Username: tokenData.Username,
-		Email:    tokenData.Email,
-		MemberID: tokenData.MemberID,
+		ID:            tokenData.UserID,
+		Username:      tokenData.Username,
+		Email:         tokenData.Email,
+		MemberID:      tokenData.MemberID,
+		EmailVerified: tokenData.EmailVerified,
 	}
 
 	// Generate new JWT token
@@ -281,20 +492,32 @@ func (s *AuthService) RefreshToken(refreshToken string) (*AuthHandlerResponse, e
 		return nil, fmt.Errorf("failed to generate new refresh token: %w", err)
 	}
 
-	// Store new refresh token and remove old one
-	s.tokenMutex.Lock()
-	delete(s.refreshTokens, refreshToken)
-	s.refreshTokens[newRefreshToken] = &RefreshTokenData{
-		UserID:      tokenData.UserID,
-		Username:    tokenData.Username,
-		Email:       tokenData.Email,
-		MemberID:    tokenData.MemberID,
-		Provider:    tokenData.Provider,
-		AccessToken: tokenData.AccessToken,               // Keep the original OAuth access token
-		ExpiresAt:   time.Now().Add(30 * 24 * time.Hour), // 30 days
-		CreatedAt:   time.Now(),
+	// Carry the OAuth access token over, moving it to the current encryption key if needed
+	accessToken, err := s.resealCredential(tokenData.AccessToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to re-encrypt access token: %w", err)
+	}
+
+	// Replace the old refresh token with the new one. The store guarantees that only one
+	// caller (on any replica) can rotate a given token.
+	now := time.Now()
+	err = s.tokenStore.Rotate(tokenHash, hashToken(newRefreshToken), &RefreshTokenData{
+		UserID:        tokenData.UserID,
+		Username:      tokenData.Username,
+		Email:         tokenData.Email,
+		MemberID:      tokenData.MemberID,
+		Provider:      tokenData.Provider,
+		EmailVerified: tokenData.EmailVerified,
+		AccessToken:   accessToken, // Keep the original OAuth access token
+		ExpiresAt:     now.Add(refreshTokenTTL),
+		CreatedAt:     now,
+	})
+	if err != nil {
+		if errors.Is(err, apperrors.ErrInvalidRefreshToken) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to rotate refresh token: %w", err)
 	}
-	s.tokenMutex.Unlock()
 
 	response := &AuthHandlerResponse{
 		AccessToken:  jwtToken,
@@ -311,44 +534,92 @@ func (s *AuthService) RefreshToken(refreshToken string) (*AuthHandlerResponse, e
 func (s *AuthService) GenerateJWT(userProfile *UserProfile, provider string) (string, error) {
 	now := time.Now()
 	claims := &AuthClaims{
-		UserID:   userProfile.ID,
-		Username: userProfile.Username,
-		Email:    userProfile.Email,
-		Provider: provider,
+		UserID:        userProfile.ID,
+		Username:      userProfile.Username,
+		Email:         userProfile.Email,
+		Provider:      provider,
+		EmailVerified: userProfile.EmailVerified,
+		Issuer:        "developer-portal-backend",
+		Subject:       fmt.Sprintf("%d", userProfile.ID),
+		ExpiresAt:     now.Add(jwtTTL).Unix(),
+		IssuedAt:      now.Unix(),
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
-			IssuedAt:  jwt.NewNumericDate(now),
 			NotBefore: jwt.NewNumericDate(now),
-			Issuer:    "developer-portal-backend",
-			Subject:   fmt.Sprintf("%d", userProfile.ID),
+			ID:        uuid.NewString(),
 		},
 	}
+	return s.signJWT(claims, now)
+}
+
+// signJWT signs claims with the current signing key, or jwt_secret with the legacy HS256
+func (s *AuthService) signJWT(claims *AuthClaims, now time.Time) (string, error) {
+	if s.config.signingAlgorithm() == JWTAlgorithmHS256 {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		return token.SignedString([]byte(s.config.JWTSecret))
+	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.config.JWTSecret))
+	key := s.keys.signing(now, s.config.signingAlgorithm())
+	if key == nil {
+		// The current key rotated before the scheduled rotation ran
+		if err := s.RotateSigningKeys(); err != nil {
+			return "", fmt.Errorf("failed to rotate signing keys: %w", err)
+		}
+		if key = s.keys.signing(now, s.config.signingAlgorithm()); key == nil {
+			return "", fmt.Errorf("no JWT signing key available")
+		}
+	}
+	token := jwt.NewWithClaims(signingMethod(key.Algorithm), claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.private)
 }
 
 // ValidateJWT validates and parses a JWT token
 func (s *AuthService) ValidateJWT(tokenString string) (*AuthClaims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &AuthClaims{}, func(token *jwt.Token) (interface{}, error) {
-		// Verify signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(s.config.JWTSecret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &AuthClaims{}, s.verificationKey, jwt.WithExpirationRequired())
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
 	}
 
 	if claims, ok := token.Claims.(*AuthClaims); ok && token.Valid {
+		if s.revoked.isRevoked(claims) {
+			return nil, apperrors.ErrTokenRevoked
+		}
 		return claims, nil
 	}
 
 	return nil, fmt.Errorf("invalid token")
 }
 
+// verificationKey is the jwt.Keyfunc of ValidateJWT. With RS256 and ES256 the token names its key
+// with kid; unknown kids (e.g. a key just created by another replica) reload the key set.
+func (s *AuthService) verificationKey(token *jwt.Token) (interface{}, error) {
+	if s.config.signingAlgorithm() == JWTAlgorithmHS256 {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(s.config.JWTSecret), nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, fmt.Errorf("token has no key ID")
+	}
+	key := s.keys.verification(kid, time.Now())
+	if key == nil {
+		if err := s.keys.reloadIfStale(s.signingKeys, s.config.JWTSecret); err != nil {
+			return nil, fmt.Errorf("failed to load signing keys: %w", err)
+		}
+		if key = s.keys.verification(kid, time.Now()); key == nil {
+			return nil, fmt.Errorf("unknown signing key %s", kid)
+		}
+	}
+	if token.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.public, nil
+}
+
 // GenerateState generates a random state parameter for OAuth2
 func (s *AuthService) GenerateState() (string, error) {
 	return s.generateRandomString(32)
@@ -379,39 +650,193 @@ func (s *AuthService) GetGitHubAccessTokenFromClaims(claims *AuthClaims) (string
 	if claims == nil {
 		return "", fmt.Errorf("claims cannot be nil")
 	}
-
-	s.tokenMutex.RLock()
-	defer s.tokenMutex.RUnlock()
+	if claims.IsImpersonation() {
+		return "", apperrors.NewAuthorizationError("impersonation sessions cannot use the user's GitHub session")
+	}
 
 	// Find a valid refresh token for this user/provider
-	for _, tokenData := range s.refreshTokens {
-		if tokenData.UserID == claims.UserID &&
-			tokenData.Provider == claims.Provider &&
-			time.Now().Before(tokenData.ExpiresAt) {
-
-			return tokenData.AccessToken, nil
+	tokenData, err := s.tokenStore.FindActiveByUser(claims.UserID, claims.Provider)
+	if err != nil {
+		return "", fmt.Errorf("failed to look up GitHub session: %w", err)
+	}
+	if tokenData != nil {
+		accessToken, err := s.openCredential(tokenData.AccessToken)
+		if err != nil {
+			return "", fmt.Errorf("failed to decrypt GitHub access token: %w", err)
 		}
+		return accessToken, nil
 	}
 
 	return "", fmt.Errorf("no valid GitHub session found for user %d with provider %s", claims.UserID, claims.Provider)
 }
 
-// GetGitHubClient retrieves the GitHub client for a specific provider
+// CleanupExpiredTokens removes expired refresh tokens, revocation entries and OAuth states
+// and returns the number of removed refresh tokens
+func (s *AuthService) CleanupExpiredTokens() (int64, error) {
+	now := time.Now()
+	removed, err := s.tokenStore.DeleteExpired(now)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := s.revocations.DeleteExpired(now); err != nil {
+		return removed, fmt.Errorf("failed to remove expired revocations: %w", err)
+	}
+	if _, err := s.oauthStates.DeleteExpired(now); err != nil {
+		return removed, fmt.Errorf("failed to remove expired OAuth states: %w", err)
+	}
+	return removed, nil
+}
+
+// StartTokenCleanup periodically removes expired refresh tokens until ctx is cancelled
+func (s *AuthService) StartTokenCleanup(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				removed, err := s.CleanupExpiredTokens()
+				if err != nil {
+					log.Printf("Warning: refresh token cleanup failed: %v", err)
+				} else if removed > 0 {
+					log.Printf("Removed %d expired refresh tokens", removed)
+				}
+			}
+		}
+	}()
+}
+
+// SyncRevocations loads revocations recorded by other replicas into the in-process cache
+func (s *AuthService) SyncRevocations() error {
+	return s.revoked.sync(s.revocations)
+}
+
+// StartRevocationSync periodically refreshes the in-process denylist until ctx is cancelled.
+// The interval bounds how long a token revoked on another replica is still accepted here.
+func (s *AuthService) StartRevocationSync(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.SyncRevocations(); err != nil {
+					log.Printf("Warning: token revocation sync failed: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// GetGitHubClient retrieves the GitHub client for a specific provider. Providers of other types
+// (e.g. OIDC) have no GitHub client.
 func (s *AuthService) GetGitHubClient(provider string) (*GitHubClient, error) {
 	if s == nil {
 		return nil, fmt.Errorf("auth service is not initialized")
 	}
 
-	client, exists := s.githubClients[provider]
-	if !exists {
+	client, ok := s.providers[provider].(*GitHubClient)
+	if !ok {
 		return nil, fmt.Errorf("GitHub client not found for provider %s", provider)
 	}
 	return client, nil
 }
 
-// Logout handles user logout (stateless JWT tokens don't require server-side logout)
-func (s *AuthService) Logout() error {
-	// For JWT tokens, logout is typically handled client-side by removing the token
-	// In a production system, you might maintain a blacklist of invalidated tokens
+// Logout revokes the JWT described by claims and all refresh tokens of the user for that provider.
+// Either argument may be empty: claims revoke the access token and the user's sessions,
+// refreshToken revokes that single refresh token (e.g. when the access token already expired).
+func (s *AuthService) Logout(claims *AuthClaims, refreshToken string) error {
+	if claims != nil {
+		if claims.ID != "" {
+			expiresAt := time.Now().Add(jwtTTL)
+			if claims.ExpiresAt != 0 {
+				expiresAt = time.Unix(claims.ExpiresAt, 0)
+			}
+			revocation := &Revocation{
+				JTI:       claims.ID,
+				Email:     claims.Email,
+				RevokedAt: time.Now(),
+				RevokedBy: claims.Username,
+				Reason:    "logout",
+				ExpiresAt: expiresAt,
+			}
+			if err := s.revocations.Add(revocation); err != nil {
+				return fmt.Errorf("failed to revoke token: %w", err)
+			}
+			s.revoked.add(*revocation)
+		}
+		if _, err := s.tokenStore.DeleteByUser(claims.UserID, claims.Provider); err != nil {
+			return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+		}
+	}
+
+	if refreshToken != "" {
+		if err := s.tokenStore.Delete(hashToken(refreshToken)); err != nil {
+			return fmt.Errorf("failed to revoke refresh token: %w", err)
+		}
+	}
+
 	return nil
 }
+
+// RevokeUserSessions revokes every JWT issued to the user so far and deletes all of the
+// user's refresh tokens and personal access tokens on every provider. It returns the number of
+// removed refresh tokens and personal access tokens.
+func (s *AuthService) RevokeUserSessions(email, revokedBy, reason string) (int64, int64, error) {
+	if email == "" {
+		return 0, 0, fmt.Errorf("email is required")
+	}
+
+	now := time.Now()
+	revocation := &Revocation{
+		Email:     email,
+		RevokedAt: now,
+		RevokedBy: revokedBy,
+		Reason:    reason,
+		// Every token issued before now has expired by then
+		ExpiresAt: now.Add(jwtTTL),
+	}
+	if err := s.revocations.Add(revocation); err != nil {
+		return 0, 0, fmt.Errorf("failed to revoke tokens: %w", err)
+	}
+	s.revoked.add(*revocation)
+
+	refreshTokens, err := s.tokenStore.DeleteByEmail(email)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+	apiTokens, err := s.apiTokens.DeletePersonalByEmail(email)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to revoke personal access tokens: %w", err)
+	}
+	return refreshTokens, apiTokens, nil
+}
+
+// IsAdmin reports whether the authenticated user is listed in the auth config admins. Entries
+// name a provider and a username or email of it (e.g. githubtools:jdoe); emails only match if the
+// provider verified them.
+// API tokens only carry admin rights if they were created with the admin scope by an admin.
+// Impersonation sessions never do, even when the impersonated user is an admin.
+func (s *AuthService) IsAdmin(claims *AuthClaims) bool {
+	if claims == nil || claims.IsImpersonation() {
+		return false
+	}
+	if claims.IsAPIToken() && (claims.TokenType == TokenTypeServiceAccount || !claims.HasScope(ScopeAdmin)) {
+		return false
+	}
+	for _, admin := range s.config.Admins {
+		provider, name, ok := strings.Cut(admin, ":")
+		if !ok || provider != claims.Provider {
+			continue
+		}
+		if (claims.Username != "" && strings.EqualFold(name, claims.Username)) ||
+			(claims.EmailVerified && claims.Email != "" && strings.EqualFold(name, claims.Email)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,459 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// JWT signing algorithms selectable with jwt_algorithm in config/auth.yaml
+const (
+	JWTAlgorithmRS256 = "RS256" // RSA 2048 (default)
+	JWTAlgorithmES256 = "ES256" // ECDSA P-256
+	JWTAlgorithmHS256 = "HS256" // legacy: jwt_secret signs the tokens, no JWKS is published
+)
+
+// defaultKeyRotationInterval is how long a signing key signs tokens before the next one takes over
+const defaultKeyRotationInterval = 30 * 24 * time.Hour
+
+// maxKeyPublishLead is how long before taking over a new key is published in the JWKS, so
+// verifiers caching the key set know it before the first token signed with it arrives
+const maxKeyPublishLead = 24 * time.Hour
+
+// keyReloadInterval throttles reloading the key set when a token names an unknown kid
+const keyReloadInterval = 10 * time.Second
+
+// SigningKey is a key of the JWT key set. It signs tokens from NotBefore until RotatesAt and
+// verifies them until ExpiresAt, when every token it signed has expired.
+type SigningKey struct {
+	ID         string    `json:"kid"`
+	Algorithm  string    `json:"alg"`
+	PublicKey  []byte    `json:"-"` // PKIX DER
+	PrivateKey []byte    `json:"-"` // PKCS#8 DER, sealed with a key derived from jwt_secret
+	NotBefore  time.Time `json:"not_before"`
+	RotatesAt  time.Time `json:"rotates_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// SigningKeyStore persists the JWT key set so all replicas sign and verify with the same keys
+type SigningKeyStore interface {
+	// Save stores a new key
+	Save(key *SigningKey) error
+	// ListActive returns the keys that have not expired at now
+	ListActive(now time.Time) ([]SigningKey, error)
+	// DeleteExpired removes keys that expired before now and returns how many were removed
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+// memorySigningKeyStore is an in-process SigningKeyStore for tests and local development
+type memorySigningKeyStore struct {
+	mu   sync.RWMutex
+	keys []SigningKey
+}
+
+// NewMemorySigningKeyStore creates an in-memory signing key store
+func NewMemorySigningKeyStore() SigningKeyStore {
+	return &memorySigningKeyStore{}
+}
+
+func (m *memorySigningKeyStore) Save(key *SigningKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.keys = append(m.keys, *key)
+	return nil
+}
+
+func (m *memorySigningKeyStore) ListActive(now time.Time) ([]SigningKey, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	result := make([]SigningKey, 0, len(m.keys))
+	for _, key := range m.keys {
+		if now.Before(key.ExpiresAt) {
+			result = append(result, key)
+		}
+	}
+	return result, nil
+}
+
+func (m *memorySigningKeyStore) DeleteExpired(now time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	kept := m.keys[:0]
+	for _, key := range m.keys {
+		if now.Before(key.ExpiresAt) {
+			kept = append(kept, key)
+		}
+	}
+	removed := int64(len(m.keys) - len(kept))
+	m.keys = kept
+	return removed, nil
+}
+
+// loadedKey is a SigningKey with its parsed keys. private is nil if the key cannot be unsealed
+// (e.g. after jwt_secret changed); such a key still verifies but never signs.
+type loadedKey struct {
+	SigningKey
+	public  crypto.PublicKey
+	private crypto.Signer
+}
+
+// keyRing is the in-process view of the key set, reloaded from the SigningKeyStore on rotation
+// and whenever a token names a kid it does not know
+type keyRing struct {
+	mu       sync.RWMutex
+	keys     map[string]*loadedKey
+	loadedAt time.Time
+}
+
+func newKeyRing() *keyRing {
+	return &keyRing{keys: make(map[string]*loadedKey)}
+}
+
+// load replaces the ring with the active keys of the store
+func (r *keyRing) load(store SigningKeyStore, secret string) error {
+	now := time.Now()
+	stored, err := store.ListActive(now)
+	if err != nil {
+		return err
+	}
+
+	keys := make(map[string]*loadedKey, len(stored))
+	for _, key := range stored {
+		loaded, err := parseSigningKey(key, secret)
+		if err != nil {
+			log.Printf("Warning: skipping JWT signing key %s: %v", key.ID, err)
+			continue
+		}
+		keys[key.ID] = loaded
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.keys = keys
+	r.loadedAt = now
+	return nil
+}
+
+// reloadIfStale reloads the ring unless it was loaded within keyReloadInterval
+func (r *keyRing) reloadIfStale(store SigningKeyStore, secret string) error {
+	r.mu.RLock()
+	fresh := time.Since(r.loadedAt) < keyReloadInterval
+	r.mu.RUnlock()
+	if fresh {
+		return nil
+	}
+	return r.load(store, secret)
+}
+
+// signing returns the key that signs tokens at now: the one that took over last among the
+// usable keys of the algorithm whose signing period covers now
+func (r *keyRing) signing(now time.Time, algorithm string) *loadedKey {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var current *loadedKey
+	for _, key := range r.keys {
+		if key.private == nil || key.Algorithm != algorithm || now.Before(key.NotBefore) || !now.Before(key.RotatesAt) {
+			continue
+		}
+		if current == nil || key.NotBefore.After(current.NotBefore) ||
+			(key.NotBefore.Equal(current.NotBefore) && key.ID > current.ID) {
+			current = key
+		}
+	}
+	return current
+}
+
+// successor reports whether a usable key of the algorithm takes over at or after the given time
+func (r *keyRing) successor(after time.Time, algorithm string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, key := range r.keys {
+		if key.private != nil && key.Algorithm == algorithm && !key.NotBefore.Before(after) {
+			return true
+		}
+	}
+	return false
+}
+
+// verification returns the key with the given kid if it has not expired
+func (r *keyRing) verification(kid string, now time.Time) *loadedKey {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	key, exists := r.keys[kid]
+	if !exists || !now.Before(key.ExpiresAt) {
+		return nil
+	}
+	return key
+}
+
+// published returns all keys of the ring ordered by the time they take over
+func (r *keyRing) published() []*loadedKey {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	keys := make([]*loadedKey, 0, len(r.keys))
+	for _, key := range r.keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if !keys[i].NotBefore.Equal(keys[j].NotBefore) {
+			return keys[i].NotBefore.Before(keys[j].NotBefore)
+		}
+		return keys[i].ID < keys[j].ID
+	})
+	return keys
+}
+
+// RotateSigningKeys removes expired keys and creates a key when none signs right now or when
+// the current one is about to rotate without a successor. Run on every replica; if two create
+// a key at the same time, both are published and the same one is picked for signing.
+func (s *AuthService) RotateSigningKeys() error {
+	if s.config.signingAlgorithm() == JWTAlgorithmHS256 {
+		return nil
+	}
+
+	s.rotateMu.Lock()
+	defer s.rotateMu.Unlock()
+	now := time.Now()
+	if _, err := s.signingKeys.DeleteExpired(now); err != nil {
+		return fmt.Errorf("failed to remove expired signing keys: %w", err)
+	}
+	if err := s.keys.load(s.signingKeys, s.config.JWTSecret); err != nil {
+		return fmt.Errorf("failed to load signing keys: %w", err)
+	}
+
+	algorithm := s.config.signingAlgorithm()
+	interval := s.config.keyRotationInterval()
+	lead := interval / 2
+	if lead > maxKeyPublishLead {
+		lead = maxKeyPublishLead
+	}
+
+	current := s.keys.signing(now, algorithm)
+	switch {
+	case current == nil:
+		if err := s.createSigningKey(now, interval); err != nil {
+			return err
+		}
+	case current.RotatesAt.Sub(now) <= lead && !s.keys.successor(current.RotatesAt, algorithm):
+		if err := s.createSigningKey(current.RotatesAt, interval); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+	return s.keys.load(s.signingKeys, s.config.JWTSecret)
+}
+
+// StartKeyRotation periodically rotates the signing keys until ctx is cancelled
+func (s *AuthService) StartKeyRotation(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := s.RotateSigningKeys(); err != nil {
+					log.Printf("Warning: JWT signing key rotation failed: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// createSigningKey generates and stores a key that signs from notBefore for one interval
+func (s *AuthService) createSigningKey(notBefore time.Time, interval time.Duration) error {
+	algorithm := s.config.signingAlgorithm()
+	var private crypto.Signer
+	var err error
+	switch algorithm {
+	case JWTAlgorithmES256:
+		private, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		private, err = rsa.GenerateKey(rand.Reader, 2048)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to generate signing key: %w", err)
+	}
+
+	publicDER, err := x509.MarshalPKIXPublicKey(private.Public())
+	if err != nil {
+		return fmt.Errorf("failed to encode signing key: %w", err)
+	}
+	privateDER, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return fmt.Errorf("failed to encode signing key: %w", err)
+	}
+	sealed, err := sealPrivateKey(s.config.JWTSecret, privateDER)
+	if err != nil {
+		return err
+	}
+
+	rotatesAt := notBefore.Add(interval)
+	key := &SigningKey{
+		ID:         uuid.NewString(),
+		Algorithm:  algorithm,
+		PublicKey:  publicDER,
+		PrivateKey: sealed,
+		NotBefore:  notBefore,
+		RotatesAt:  rotatesAt,
+		ExpiresAt:  rotatesAt.Add(jwtTTL),
+		CreatedAt:  time.Now(),
+	}
+	if err := s.signingKeys.Save(key); err != nil {
+		return fmt.Errorf("failed to store signing key: %w", err)
+	}
+	log.Printf("Created JWT signing key %s (%s), signing from %s", key.ID, key.Algorithm, notBefore.Format(time.RFC3339))
+	return nil
+}
+
+// signingMethod returns the jwt signing method of an algorithm
+func signingMethod(algorithm string) jwt.SigningMethod {
+	switch algorithm {
+	case JWTAlgorithmES256:
+		return jwt.SigningMethodES256
+	case JWTAlgorithmHS256:
+		return jwt.SigningMethodHS256
+	default:
+		return jwt.SigningMethodRS256
+	}
+}
+
+// parseSigningKey decodes the public key and unseals the private key of a stored key
+func parseSigningKey(key SigningKey, secret string) (*loadedKey, error) {
+	public, err := x509.ParsePKIXPublicKey(key.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	loaded := &loadedKey{SigningKey: key, public: public}
+
+	privateDER, err := openPrivateKey(secret, key.PrivateKey)
+	if err != nil {
+		log.Printf("Warning: JWT signing key %s cannot be unsealed and is used for verification only: %v", key.ID, err)
+		return loaded, nil
+	}
+	private, err := x509.ParsePKCS8PrivateKey(privateDER)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	signer, ok := private.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", private)
+	}
+	loaded.private = signer
+	return loaded, nil
+}
+
+// keyEncryptionKey derives the AES-256 key that seals private signing keys at rest
+func keyEncryptionKey(secret string) []byte {
+	sum := sha256.Sum256([]byte("jwt-signing-key:" + secret))
+	return sum[:]
+}
+
+// sealPrivateKey encrypts a private key with AES-GCM; the nonce is prepended to the result
+func sealPrivateKey(secret string, plaintext []byte) ([]byte, error) {
+	aead, err := newKeyAEAD(secret)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// openPrivateKey decrypts a private key sealed by sealPrivateKey
+func openPrivateKey(secret string, sealed []byte) ([]byte, error) {
+	aead, err := newKeyAEAD(secret)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < aead.NonceSize() {
+		return nil, fmt.Errorf("sealed key is too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
+
+func newKeyAEAD(secret string) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(keyEncryptionKey(secret))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// JWK is a public key of the JSON Web Key Set (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty" example:"RSA"`
+	Kid string `json:"kid" example:"8f14e45f-ceea-467f-a0e6-7d6d5f0b1c2a"`
+	Use string `json:"use" example:"sig"`
+	Alg string `json:"alg" example:"RS256"`
+	// RSA keys
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty" example:"AQAB"`
+	// EC keys
+	Crv string `json:"crv,omitempty" example:"P-256"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKSet is the response of the JWKS endpoint
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys that verify portal tokens, including the next key once it is
+// published. The set is empty with the legacy HS256 algorithm.
+func (s *AuthService) JWKS() JWKSet {
+	set := JWKSet{Keys: make([]JWK, 0)}
+	now := time.Now()
+	for _, key := range s.keys.published() {
+		if !now.Before(key.ExpiresAt) {
+			continue
+		}
+		jwk := JWK{Kid: key.ID, Use: "sig", Alg: key.Algorithm}
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case *ecdsa.PublicKey:
+			ecdh, err := public.ECDH()
+			if err != nil {
+				continue
+			}
+			// Uncompressed point: 0x04 || X || Y
+			point := ecdh.Bytes()[1:]
+			size := len(point) / 2
+			jwk.Kty = "EC"
+			jwk.Crv = public.Curve.Params().Name
+			jwk.X = base64.RawURLEncoding.EncodeToString(point[:size])
+			jwk.Y = base64.RawURLEncoding.EncodeToString(point[size:])
+		default:
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
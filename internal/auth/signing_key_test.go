@@ -0,0 +1,270 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSigningTestService(t *testing.T, algorithm string, store SigningKeyStore) *AuthService {
+	config := &AuthConfig{
+		JWTSecret:    "test-signing-key-for-jwks",
+		JWTAlgorithm: algorithm,
+		RedirectURL:  "http://localhost:3000",
+		Providers: map[string]ProviderConfig{
+			"githubtools": {ClientID: "test-client-id", ClientSecret: "test-client-secret"},
+		},
+	}
+	service, err := NewAuthServiceWithStores(config, nil, Stores{SigningKeys: store})
+	require.NoError(t, err)
+	return service
+}
+
+func issueTestJWT(t *testing.T, service *AuthService) (string, *jwt.Token) {
+	signed, err := service.GenerateJWT(&UserProfile{ID: 12345, Username: "testuser", Email: "test@example.com"}, "githubtools")
+	require.NoError(t, err)
+	token, _, err := jwt.NewParser().ParseUnverified(signed, &AuthClaims{})
+	require.NoError(t, err)
+	return signed, token
+}
+
+// signTestJWT signs arbitrary claims with the service's current signing key
+func signTestJWT(t *testing.T, service *AuthService, claims *AuthClaims) string {
+	key := service.keys.signing(time.Now(), service.config.signingAlgorithm())
+	require.NotNil(t, key)
+	token := jwt.NewWithClaims(signingMethod(key.Algorithm), claims)
+	token.Header["kid"] = key.ID
+	signed, err := token.SignedString(key.private)
+	require.NoError(t, err)
+	return signed
+}
+
+// shiftKeys moves every stored key back in time, as if d had passed
+func shiftKeys(t *testing.T, service *AuthService, d time.Duration) {
+	store := service.signingKeys.(*memorySigningKeyStore)
+	store.mu.Lock()
+	for i := range store.keys {
+		store.keys[i].NotBefore = store.keys[i].NotBefore.Add(-d)
+		store.keys[i].RotatesAt = store.keys[i].RotatesAt.Add(-d)
+		store.keys[i].ExpiresAt = store.keys[i].ExpiresAt.Add(-d)
+	}
+	store.mu.Unlock()
+	require.NoError(t, service.RotateSigningKeys())
+}
+
+// publicKeyFromJWK rebuilds a public key the way a sibling service verifying portal tokens would
+func publicKeyFromJWK(t *testing.T, jwk JWK) interface{} {
+	decode := func(value string) *big.Int {
+		raw, err := base64.RawURLEncoding.DecodeString(value)
+		require.NoError(t, err)
+		return new(big.Int).SetBytes(raw)
+	}
+	switch jwk.Kty {
+	case "RSA":
+		return &rsa.PublicKey{N: decode(jwk.N), E: int(decode(jwk.E).Int64())}
+	case "EC":
+		require.Equal(t, "P-256", jwk.Crv)
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: decode(jwk.X), Y: decode(jwk.Y)}
+	}
+	t.Fatalf("unexpected key type %s", jwk.Kty)
+	return nil
+}
+
+func TestJWTSignedWithPublishedKey(t *testing.T) {
+	for _, algorithm := range []string{"", JWTAlgorithmRS256, JWTAlgorithmES256} {
+		expected := algorithm
+		if expected == "" {
+			expected = JWTAlgorithmRS256
+		}
+		t.Run(expected, func(t *testing.T) {
+			service := newSigningTestService(t, algorithm, nil)
+			signed, token := issueTestJWT(t, service)
+			assert.Equal(t, expected, token.Header["alg"])
+			kid, _ := token.Header["kid"].(string)
+			require.NotEmpty(t, kid)
+
+			claims, err := service.ValidateJWT(signed)
+			require.NoError(t, err)
+			assert.Equal(t, "testuser", claims.Username)
+
+			jwks := service.JWKS()
+			require.Len(t, jwks.Keys, 1)
+			assert.Equal(t, kid, jwks.Keys[0].Kid)
+			assert.Equal(t, expected, jwks.Keys[0].Alg)
+			assert.Equal(t, "sig", jwks.Keys[0].Use)
+
+			// A sibling service verifies the token with nothing but the JWKS
+			verified, err := jwt.Parse(signed, func(token *jwt.Token) (interface{}, error) {
+				return publicKeyFromJWK(t, jwks.Keys[0]), nil
+			}, jwt.WithValidMethods([]string{expected}))
+			require.NoError(t, err)
+			assert.True(t, verified.Valid)
+		})
+	}
+}
+
+func TestSigningKeyRotation(t *testing.T) {
+	service := newSigningTestService(t, JWTAlgorithmES256, nil)
+	interval := service.config.keyRotationInterval()
+	oldToken, token := issueTestJWT(t, service)
+	oldKid := token.Header["kid"]
+
+	t.Run("successor is published before it signs", func(t *testing.T) {
+		shiftKeys(t, service, interval-time.Hour)
+
+		assert.Len(t, service.JWKS().Keys, 2)
+		_, token := issueTestJWT(t, service)
+		assert.Equal(t, oldKid, token.Header["kid"])
+
+		// Rotating again does not create a third key
+		require.NoError(t, service.RotateSigningKeys())
+		assert.Len(t, service.JWKS().Keys, 2)
+	})
+
+	t.Run("successor signs after rotation while the old key still verifies", func(t *testing.T) {
+		shiftKeys(t, service, 90*time.Minute)
+
+		newToken, token := issueTestJWT(t, service)
+		assert.NotEqual(t, oldKid, token.Header["kid"])
+		_, err := service.ValidateJWT(newToken)
+		assert.NoError(t, err)
+		_, err = service.ValidateJWT(oldToken)
+		assert.NoError(t, err)
+	})
+
+	t.Run("old key is dropped once its tokens have expired", func(t *testing.T) {
+		shiftKeys(t, service, jwtTTL)
+
+		jwks := service.JWKS()
+		require.Len(t, jwks.Keys, 1)
+		assert.NotEqual(t, oldKid, jwks.Keys[0].Kid)
+		_, err := service.ValidateJWT(oldToken)
+		assert.ErrorContains(t, err, "unknown signing key")
+	})
+}
+
+func TestValidateJWTReloadsKeysOfOtherReplicas(t *testing.T) {
+	store := NewMemorySigningKeyStore()
+	replicaA := newSigningTestService(t, JWTAlgorithmRS256, store)
+	replicaB := newSigningTestService(t, JWTAlgorithmRS256, store)
+
+	// Replica A switches to a key replica B has not loaded yet
+	require.NoError(t, replicaA.createSigningKey(time.Now(), replicaA.config.keyRotationInterval()))
+	require.NoError(t, replicaA.keys.load(store, replicaA.config.JWTSecret))
+	signed, _ := issueTestJWT(t, replicaA)
+
+	// Within the reload interval unknown kids are rejected without hitting the store
+	_, err := replicaB.ValidateJWT(signed)
+	assert.ErrorContains(t, err, "unknown signing key")
+
+	replicaB.keys.loadedAt = time.Time{}
+	_, err = replicaB.ValidateJWT(signed)
+	assert.NoError(t, err)
+}
+
+func TestValidateJWTRejectsForeignTokens(t *testing.T) {
+	service := newSigningTestService(t, JWTAlgorithmRS256, nil)
+	_, token := issueTestJWT(t, service)
+	kid := token.Header["kid"].(string)
+	claims := &AuthClaims{Username: "mallory", ExpiresAt: time.Now().Add(time.Hour).Unix()}
+
+	t.Run("HS256 signed with the public key", func(t *testing.T) {
+		forged := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		forged.Header["kid"] = kid
+		publicDER, err := x509.MarshalPKIXPublicKey(service.keys.verification(kid, time.Now()).public)
+		require.NoError(t, err)
+		signed, err := forged.SignedString(publicDER)
+		require.NoError(t, err)
+
+		_, err = service.ValidateJWT(signed)
+		assert.ErrorContains(t, err, "unexpected signing method")
+	})
+
+	t.Run("HS256 signed with jwt_secret", func(t *testing.T) {
+		signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(service.config.JWTSecret))
+		require.NoError(t, err)
+
+		_, err = service.ValidateJWT(signed)
+		assert.ErrorContains(t, err, "no key ID")
+	})
+}
+
+func TestSigningKeysAreSealedAtRest(t *testing.T) {
+	store := NewMemorySigningKeyStore()
+	service := newSigningTestService(t, JWTAlgorithmRS256, store)
+	signed, _ := issueTestJWT(t, service)
+
+	keys, err := store.ListActive(time.Now())
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	privateDER, err := x509.MarshalPKCS8PrivateKey(service.keys.signing(time.Now(), JWTAlgorithmRS256).private)
+	require.NoError(t, err)
+	assert.False(t, bytes.Contains(keys[0].PrivateKey, privateDER[len(privateDER)-64:]))
+
+	// A replica with another jwt_secret verifies with the public key but signs with its own key
+	config := *service.config
+	config.JWTSecret = "another-secret"
+	other, err := NewAuthServiceWithStores(&config, nil, Stores{SigningKeys: store})
+	require.NoError(t, err)
+	_, err = other.ValidateJWT(signed)
+	assert.NoError(t, err)
+	assert.Len(t, other.JWKS().Keys, 2)
+}
+
+func TestLegacyHS256Signing(t *testing.T) {
+	service := newSigningTestService(t, JWTAlgorithmHS256, nil)
+	signed, token := issueTestJWT(t, service)
+	assert.Equal(t, "HS256", token.Header["alg"])
+	assert.Nil(t, token.Header["kid"])
+
+	_, err := service.ValidateJWT(signed)
+	assert.NoError(t, err)
+	assert.Empty(t, service.JWKS().Keys)
+}
+
+func TestJWKSHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	service := newSigningTestService(t, JWTAlgorithmES256, nil)
+	router := gin.New()
+	router.GET("/.well-known/jwks.json", NewAuthHandler(service).JWKS)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/.well-known/jwks.json", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Cache-Control"), "max-age")
+
+	var jwks JWKSet
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &jwks))
+	require.Len(t, jwks.Keys, 1)
+	assert.Equal(t, "EC", jwks.Keys[0].Kty)
+	assert.NotEmpty(t, jwks.Keys[0].X)
+	assert.Empty(t, jwks.Keys[0].N)
+}
+
+func TestConfigValidationRejectsUnknownJWTAlgorithm(t *testing.T) {
+	config := &AuthConfig{
+		JWTSecret:    "test-secret",
+		JWTAlgorithm: "none",
+		RedirectURL:  "http://localhost:3000",
+		Providers:    map[string]ProviderConfig{"githubtools": {ClientID: "id", ClientSecret: "secret"}},
+	}
+	assert.ErrorContains(t, config.ValidateConfig(), "unsupported jwt_algorithm")
+
+	config.JWTAlgorithm = JWTAlgorithmES256
+	config.KeyRotationInterval = time.Minute
+	assert.ErrorContains(t, config.ValidateConfig(), "key_rotation_interval")
+}
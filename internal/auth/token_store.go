@@ -0,0 +1,174 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	apperrors "developer-portal-backend/internal/errors"
+)
+
+// TokenStore persists refresh tokens together with the upstream OAuth session they carry.
+// Tokens are addressed by their hash (see hashToken), so a store never sees raw refresh tokens.
+type TokenStore interface {
+	// Save stores the session data under the given token hash
+	Save(tokenHash string, data *RefreshTokenData) error
+	// Get returns the session data for the token hash, or apperrors.ErrInvalidRefreshToken if unknown
+	Get(tokenHash string) (*RefreshTokenData, error)
+	// Rotate atomically replaces oldHash with newHash. It returns apperrors.ErrInvalidRefreshToken
+	// if oldHash no longer exists, so a token can only be rotated once across all replicas.
+	Rotate(oldHash, newHash string, data *RefreshTokenData) error
+	// Delete removes the token hash; deleting an unknown hash is not an error
+	Delete(tokenHash string) error
+	// DeleteByUser removes every session of the provider user and returns how many were removed
+	DeleteByUser(userID int64, provider string) (int64, error)
+	// DeleteByEmail removes every session issued to the email on any provider
+	DeleteByEmail(email string) (int64, error)
+	// FindActiveByUser returns the most recent non-expired session for the provider user,
+	// or nil if there is none
+	FindActiveByUser(userID int64, provider string) (*RefreshTokenData, error)
+	// DeleteExpired removes all sessions that expired before now and returns how many were removed
+	DeleteExpired(now time.Time) (int64, error)
+	// ListStaleCredentials returns up to limit non-expired sessions, keyed by token hash, whose
+	// access token does not start with prefix, i.e. is not sealed with the current encryption key
+	ListStaleCredentials(prefix string, limit int) (map[string]*RefreshTokenData, error)
+	// UpdateAccessToken replaces the stored access token of a session; unknown hashes are not an error
+	UpdateAccessToken(tokenHash, accessToken string) error
+}
+
+// hashToken returns the hex encoded SHA-256 hash of a token
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// memoryTokenStore is an in-process TokenStore. It does not survive restarts and is not
+// shared between replicas, so it is only meant for tests and local development.
+type memoryTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]*RefreshTokenData
+}
+
+// NewMemoryTokenStore creates an in-memory token store
+func NewMemoryTokenStore() TokenStore {
+	return &memoryTokenStore{tokens: make(map[string]*RefreshTokenData)}
+}
+
+func (m *memoryTokenStore) Save(tokenHash string, data *RefreshTokenData) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens[tokenHash] = data
+	return nil
+}
+
+func (m *memoryTokenStore) Get(tokenHash string) (*RefreshTokenData, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	data, exists := m.tokens[tokenHash]
+	if !exists {
+		return nil, apperrors.ErrInvalidRefreshToken
+	}
+	return data, nil
+}
+
+func (m *memoryTokenStore) Rotate(oldHash, newHash string, data *RefreshTokenData) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, exists := m.tokens[oldHash]; !exists {
+		return apperrors.ErrInvalidRefreshToken
+	}
+	delete(m.tokens, oldHash)
+	m.tokens[newHash] = data
+	return nil
+}
+
+func (m *memoryTokenStore) Delete(tokenHash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.tokens, tokenHash)
+	return nil
+}
+
+func (m *memoryTokenStore) DeleteByUser(userID int64, provider string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var removed int64
+	for hash, data := range m.tokens {
+		if data.UserID == userID && data.Provider == provider {
+			delete(m.tokens, hash)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func (m *memoryTokenStore) DeleteByEmail(email string) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var removed int64
+	for hash, data := range m.tokens {
+		if strings.EqualFold(data.Email, email) {
+			delete(m.tokens, hash)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func (m *memoryTokenStore) FindActiveByUser(userID int64, provider string) (*RefreshTokenData, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	now := time.Now()
+	var latest *RefreshTokenData
+	for _, data := range m.tokens {
+		if data.UserID != userID || data.Provider != provider || !now.Before(data.ExpiresAt) {
+			continue
+		}
+		if latest == nil || data.CreatedAt.After(latest.CreatedAt) {
+			latest = data
+		}
+	}
+	return latest, nil
+}
+
+func (m *memoryTokenStore) DeleteExpired(now time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var removed int64
+	for hash, data := range m.tokens {
+		if !now.Before(data.ExpiresAt) {
+			delete(m.tokens, hash)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+func (m *memoryTokenStore) ListStaleCredentials(prefix string, limit int) (map[string]*RefreshTokenData, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	now := time.Now()
+	stale := make(map[string]*RefreshTokenData)
+	for hash, data := range m.tokens {
+		if len(stale) >= limit {
+			break
+		}
+		if now.Before(data.ExpiresAt) && !strings.HasPrefix(data.AccessToken, prefix) {
+			stale[hash] = data
+		}
+	}
+	return stale, nil
+}
+
+func (m *memoryTokenStore) UpdateAccessToken(tokenHash, accessToken string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if data, exists := m.tokens[tokenHash]; exists {
+		updated := *data
+		updated.AccessToken = accessToken
+		m.tokens[tokenHash] = &updated
+	}
+	return nil
+}
@@ -21,6 +21,10 @@ type Config struct {
 	DatabasePassword string `mapstructure:"DB_PASSWORD"`
 	DatabaseName     string `mapstructure:"DB_NAME"`
 	DatabaseSSLMode  string `mapstructure:"DB_SSL_MODE"`
+	// DatabaseMigrations is what the server does with pending migrations on startup: apply, check or skip
+	DatabaseMigrations string `mapstructure:"DB_MIGRATIONS"`
+	// TrashRetentionDays is how long soft-deleted catalog entities are kept before they are purged; 0 keeps them forever
+	TrashRetentionDays int `mapstructure:"TRASH_RETENTION_DAYS"`
 
 	// JWT configuration
 	JWTSecret string `mapstructure:"JWT_SECRET"`
@@ -36,6 +40,25 @@ type Config struct {
 	LDAPBaseDN             string `mapstructure:"LDAP_BASE_DN"`
 	LDAPInsecureSkipVerify bool   `mapstructure:"LDAP_INSECURE_SKIP_VERIFY"`
 	LDAPTimeoutSec         int    `mapstructure:"LDAP_TIMEOUT_SEC"`
+	// LDAPSyncIntervalHours is how often users are reconciled with LDAP; 0 disables the job
+	LDAPSyncIntervalHours int `mapstructure:"LDAP_SYNC_INTERVAL_HOURS"`
+	// LDAPSyncGraceDays is how long a user may be missing from LDAP before the reconciliation reports them as overdue
+	LDAPSyncGraceDays int `mapstructure:"LDAP_SYNC_GRACE_DAYS"`
+	// LDAPSyncDeleteMissing makes the reconciliation delete overdue users who own no resources
+	LDAPSyncDeleteMissing bool `mapstructure:"LDAP_SYNC_DELETE_MISSING"`
+	// LDAPTeamSyncAutoApply makes the LDAP reconciliation job also reconcile team members with the
+	// distribution lists of their teams
+	LDAPTeamSyncAutoApply bool `mapstructure:"LDAP_TEAM_SYNC_AUTO_APPLY"`
+
+	// Dead-link checker configuration
+	// LinkCheckIntervalHours is how often all links are checked; 0 disables the job
+	LinkCheckIntervalHours int `mapstructure:"LINK_CHECK_INTERVAL_HOURS"`
+	// LinkCheckConcurrency is the number of links checked at the same time
+	LinkCheckConcurrency int `mapstructure:"LINK_CHECK_CONCURRENCY"`
+	// LinkCheckHostIntervalMs is the minimum time between two requests to the same host
+	LinkCheckHostIntervalMs int `mapstructure:"LINK_CHECK_HOST_INTERVAL_MS"`
+	// LinkCheckTimeoutSec is how long a link may take to respond, redirects included
+	LinkCheckTimeoutSec int `mapstructure:"LINK_CHECK_TIMEOUT_SEC"`
 
 	// Jira configuration
 	JiraDomain   string `mapstructure:"JIRA_DOMAIN"`
@@ -102,6 +125,8 @@ func setDefaults() {
 	viper.SetDefault("DB_PASSWORD", "postgres")
 	viper.SetDefault("DB_NAME", "developer_portal")
 	viper.SetDefault("DB_SSL_MODE", "disable")
+	viper.SetDefault("DB_MIGRATIONS", "apply")
+	viper.SetDefault("TRASH_RETENTION_DAYS", 30)
 
 	// JWT defaults
 	viper.SetDefault("JWT_SECRET", "your-secret-key-change-in-production")
@@ -117,6 +142,16 @@ func setDefaults() {
 	viper.SetDefault("LDAP_BASE_DN", "DC=example,DC=com")
 	viper.SetDefault("LDAP_INSECURE_SKIP_VERIFY", true)
 	viper.SetDefault("LDAP_TIMEOUT_SEC", 10)
+	viper.SetDefault("LDAP_SYNC_INTERVAL_HOURS", 24)
+	viper.SetDefault("LDAP_SYNC_GRACE_DAYS", 7)
+	viper.SetDefault("LDAP_SYNC_DELETE_MISSING", false)
+	viper.SetDefault("LDAP_TEAM_SYNC_AUTO_APPLY", false)
+
+	// Dead-link checker defaults
+	viper.SetDefault("LINK_CHECK_INTERVAL_HOURS", 24)
+	viper.SetDefault("LINK_CHECK_CONCURRENCY", 8)
+	viper.SetDefault("LINK_CHECK_HOST_INTERVAL_MS", 1000)
+	viper.SetDefault("LINK_CHECK_TIMEOUT_SEC", 10)
 
 	// Jira defaults
 	viper.SetDefault("JIRA_DOMAIN", "")
@@ -154,6 +189,21 @@ func validate(config *Config) error {
 		return fmt.Errorf("database name is required")
 	}
 
+	if config.TrashRetentionDays < 0 {
+		return fmt.Errorf("TRASH_RETENTION_DAYS must not be negative")
+	}
+
+	if config.LDAPSyncIntervalHours < 0 || config.LDAPSyncGraceDays < 0 {
+		return fmt.Errorf("LDAP_SYNC_INTERVAL_HOURS and LDAP_SYNC_GRACE_DAYS must not be negative")
+	}
+
+	if config.LinkCheckIntervalHours < 0 || config.LinkCheckHostIntervalMs < 0 {
+		return fmt.Errorf("LINK_CHECK_INTERVAL_HOURS and LINK_CHECK_HOST_INTERVAL_MS must not be negative")
+	}
+	if config.LinkCheckConcurrency < 1 || config.LinkCheckTimeoutSec < 1 {
+		return fmt.Errorf("LINK_CHECK_CONCURRENCY and LINK_CHECK_TIMEOUT_SEC must be positive")
+	}
+
 	return nil
 }
 
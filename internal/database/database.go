@@ -1,27 +1,56 @@
 package database
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"strings"
 	"time"
 
-	"developer-portal-backend/internal/database/models"
+	"developer-portal-backend/internal/database/migrations"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 )
 
+// MigrationMode selects what Initialize does with pending schema migrations
+type MigrationMode string
+
+const (
+	// MigrationsApply applies pending migrations on startup (the default)
+	MigrationsApply MigrationMode = "apply"
+	// MigrationsCheck refuses to start if migrations are pending or the schema is dirty
+	MigrationsCheck MigrationMode = "check"
+	// MigrationsSkip leaves the schema alone, e.g. for the migrate command itself
+	MigrationsSkip MigrationMode = "skip"
+)
+
+// ParseMigrationMode parses a MigrationMode; empty means MigrationsApply
+func ParseMigrationMode(value string) (MigrationMode, error) {
+	switch mode := MigrationMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return MigrationsApply, nil
+	case MigrationsApply, MigrationsCheck, MigrationsSkip:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid migration mode '%s': must be apply, check or skip", value)
+	}
+}
+
 type Options struct {
 	LogLevel        logger.LogLevel
 	MaxOpenConns    int
 	MaxIdleConns    int
 	ConnMaxLifetime time.Duration
 	ConnMaxIdleTime time.Duration
-	AutoMigrate     bool
+	Migrations      MigrationMode
+	// MigrationTimeout bounds waiting for the migration lock and applying migrations
+	MigrationTimeout time.Duration
 }
 
-// Initialize opens a Postgres connection and creates the schema from GORM models.
-// Simplified single-phase AutoMigrate since cyclic foreign keys were removed.
+// Initialize opens a Postgres connection and brings the schema up to date with the embedded
+// migrations, or only checks it, depending on opts.Migrations.
 func Initialize(dsn string, opts *Options) (*gorm.DB, error) {
 	// Defaults
 	if opts == nil {
@@ -42,8 +71,11 @@ func Initialize(dsn string, opts *Options) (*gorm.DB, error) {
 	if opts.ConnMaxIdleTime == 0 {
 		opts.ConnMaxIdleTime = 10 * time.Minute
 	}
-	if !opts.AutoMigrate {
-		opts.AutoMigrate = true
+	if opts.Migrations == "" {
+		opts.Migrations = MigrationsApply
+	}
+	if opts.MigrationTimeout == 0 {
+		opts.MigrationTimeout = 5 * time.Minute
 	}
 
 	// Open DB
@@ -63,31 +95,27 @@ func Initialize(dsn string, opts *Options) (*gorm.DB, error) {
 	// Ensure required extension for UUID generation (used by BaseModel default gen_random_uuid())
 	_ = db.Exec(`CREATE EXTENSION IF NOT EXISTS pgcrypto`).Error
 
-	// AutoMigrate all models (no cycles)
-	if opts.AutoMigrate {
-		all := []interface{}{
-			&models.Organization{},
-			&models.Group{},
-			&models.User{},
-			&models.Team{},
-			&models.Documentation{},
-			&models.Landscape{},
-			&models.Project{},
-			&models.Component{},
-			&models.Category{},
-			&models.Link{},
-			//&models.TeamComponentOwnership{},
-			//&models.TeamLeadership{},
-			//&models.ComponentDeployment{},
-			//&models.DeploymentTimeline{},
-			//&models.DutySchedule{},
-			//&models.OutageCall{},
-			//&models.OutageCallAssignee{},
+	if opts.Migrations == MigrationsSkip {
+		return db, nil
+	}
+	migrator, err := NewMigrator(db, migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), opts.MigrationTimeout)
+	defer cancel()
+	if opts.Migrations == MigrationsApply {
+		applied, err := migrator.Up(ctx, 0)
+		if err != nil {
+			return nil, fmt.Errorf("migrate: %w", err)
 		}
-		if err := db.AutoMigrate(all...); err != nil {
-			return nil, fmt.Errorf("auto-migrate: %w", err)
+		for _, migration := range applied {
+			log.Printf("Applied migration %d_%s", migration.Version, migration.Name)
 		}
 	}
+	if err := migrator.Check(ctx); err != nil {
+		return nil, err
+	}
 
 	return db, nil
 }
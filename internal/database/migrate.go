@@ -0,0 +1,398 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// migrationLockID is the Postgres advisory lock held while migrations run, so replicas starting
+// at the same time apply each migration once
+const migrationLockID int64 = 7008_0001
+
+// noTransactionDirective on the first line of a migration runs it outside a transaction, as needed
+// for e.g. CREATE INDEX CONCURRENTLY. Such a migration leaves the schema dirty if it fails.
+const noTransactionDirective = "-- migrate:no-transaction"
+
+// migrationFilePattern matches <version>_<name>.up.sql and <version>_<name>.down.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+var (
+	// ErrSchemaDirty is returned when a migration failed half-way and the schema needs manual repair
+	ErrSchemaDirty = errors.New("database schema is dirty")
+	// ErrSchemaPending is returned by Check when migrations have not been applied yet
+	ErrSchemaPending = errors.New("database schema has pending migrations")
+)
+
+// Migration is a versioned schema change read from the migrations directory
+type Migration struct {
+	Version       int64
+	Name          string
+	Up            string
+	Down          string // empty if the migration cannot be reverted
+	NoTransaction bool
+}
+
+// checksum identifies the up script, so status can report migrations edited after they were applied
+func (m *Migration) checksum() string {
+	sum := sha256.Sum256([]byte(m.Up))
+	return hex.EncodeToString(sum[:])
+}
+
+// MigrationStatus reports the state of a migration in the database
+type MigrationStatus struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+	Dirty     bool       `json:"dirty"`
+	Modified  bool       `json:"modified"` // the up script changed since it was applied
+	Unknown   bool       `json:"unknown"`  // applied, but not known to this build (e.g. after a rollback)
+}
+
+// schemaMigration is a row of the schema_migrations table
+type schemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"size:200;not null"`
+	Checksum  string    `gorm:"size:64;not null"`
+	Dirty     bool      `gorm:"not null;default:false"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+// TableName returns the table name for schemaMigration
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrator applies and reverts the versioned SQL migrations and records them in schema_migrations
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration // ordered by version
+}
+
+// NewMigrator creates a migrator for the migrations in source, which holds the migration files
+// at its root (see the migrations package)
+func NewMigrator(db *gorm.DB, source fs.FS) (*Migrator, error) {
+	migrations, err := loadMigrations(source)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// loadMigrations reads and orders the migration files of source
+func loadMigrations(source fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(source, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read migrations: %w", err)
+	}
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || path.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration file %s must be named <version>_<name>.(up|down).sql", entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration file %s has an invalid version", entry.Name())
+		}
+		content, err := fs.ReadFile(source, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("read migration %s: %w", entry.Name(), err)
+		}
+
+		migration, exists := byVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d is used by %s and %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+			migration.NoTransaction = strings.HasPrefix(strings.TrimSpace(migration.Up), noTransactionDirective)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if strings.TrimSpace(migration.Up) == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// LatestVersion returns the version of the newest known migration, or 0 if there are none
+func (m *Migrator) LatestVersion() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up applies up to steps pending migrations in version order, or all of them if steps is 0, and
+// returns the applied ones. It refuses to run on a dirty schema.
+func (m *Migrator) Up(ctx context.Context, steps int) ([]Migration, error) {
+	var applied []Migration
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		records, err := m.records(conn)
+		if err != nil {
+			return err
+		}
+		if err := dirtyError(records); err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if _, done := records[migration.Version]; done {
+				continue
+			}
+			if steps > 0 && len(applied) == steps {
+				break
+			}
+			if err := m.apply(conn, migration); err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the steps most recently applied migrations, newest first, and returns them.
+// It refuses to run on a dirty schema or to revert migrations without a down script.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps <= 0 {
+		return nil, fmt.Errorf("steps must be positive")
+	}
+	var reverted []Migration
+	err := m.withLock(ctx, func(conn *gorm.DB) error {
+		records, err := m.records(conn)
+		if err != nil {
+			return err
+		}
+		if err := dirtyError(records); err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, done := records[migration.Version]; !done {
+				continue
+			}
+			if strings.TrimSpace(migration.Down) == "" {
+				return fmt.Errorf("migration %d_%s cannot be reverted: it has no down script", migration.Version, migration.Name)
+			}
+			if err := m.revert(conn, migration); err != nil {
+				return err
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status reports every known migration and every applied one unknown to this build, by version
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	conn := m.db.WithContext(ctx)
+	if err := ensureMigrationsTable(conn); err != nil {
+		return nil, err
+	}
+	records, err := m.records(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if record, done := records[migration.Version]; done {
+			appliedAt := record.AppliedAt
+			status.Applied = true
+			status.AppliedAt = &appliedAt
+			status.Dirty = record.Dirty
+			status.Modified = record.Checksum != migration.checksum()
+			delete(records, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, record := range records {
+		appliedAt := record.AppliedAt
+		statuses = append(statuses, MigrationStatus{
+			Version:   record.Version,
+			Name:      record.Name,
+			Applied:   true,
+			AppliedAt: &appliedAt,
+			Dirty:     record.Dirty,
+			Unknown:   true,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// Check returns ErrSchemaDirty or ErrSchemaPending unless every known migration has been applied
+func (m *Migrator) Check(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	var pending []string
+	for _, status := range statuses {
+		if status.Dirty {
+			return fmt.Errorf("%w: migration %d_%s failed; repair it and run `migrate force %d`", ErrSchemaDirty, status.Version, status.Name, status.Version)
+		}
+		if !status.Applied {
+			pending = append(pending, fmt.Sprintf("%d_%s", status.Version, status.Name))
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: %s", ErrSchemaPending, strings.Join(pending, ", "))
+	}
+	return nil
+}
+
+// Force marks a dirty migration as applied once its changes have been repaired by hand
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+	return m.withLock(ctx, func(conn *gorm.DB) error {
+		res := conn.Model(&schemaMigration{}).Where("version = ? AND dirty", version).Update("dirty", false)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return fmt.Errorf("migration %d is not dirty", version)
+		}
+		return nil
+	})
+}
+
+// withLock runs fn on a single connection holding the migration advisory lock
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *gorm.DB) error) error {
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockID).Error; err != nil {
+			return fmt.Errorf("acquire migration lock: %w", err)
+		}
+		defer conn.Exec("SELECT pg_advisory_unlock(?)", migrationLockID)
+
+		if err := ensureMigrationsTable(conn); err != nil {
+			return err
+		}
+		return fn(conn)
+	})
+}
+
+// ensureMigrationsTable creates the schema_migrations table if it does not exist
+func ensureMigrationsTable(conn *gorm.DB) error {
+	err := conn.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    bigint PRIMARY KEY,
+		name       varchar(200) NOT NULL,
+		checksum   varchar(64) NOT NULL,
+		dirty      boolean NOT NULL DEFAULT false,
+		applied_at timestamptz NOT NULL
+	)`).Error
+	if err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+	return nil
+}
+
+// records returns the rows of schema_migrations by version
+func (m *Migrator) records(conn *gorm.DB) (map[int64]schemaMigration, error) {
+	var rows []schemaMigration
+	if err := conn.Order("version").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("read schema_migrations: %w", err)
+	}
+	records := make(map[int64]schemaMigration, len(rows))
+	for _, row := range rows {
+		records[row.Version] = row
+	}
+	return records, nil
+}
+
+// dirtyError returns ErrSchemaDirty if a migration was left dirty
+func dirtyError(records map[int64]schemaMigration) error {
+	for _, record := range records {
+		if record.Dirty {
+			return fmt.Errorf("%w: migration %d_%s failed; repair it and run `migrate force %d`", ErrSchemaDirty, record.Version, record.Name, record.Version)
+		}
+	}
+	return nil
+}
+
+// apply runs the up script of a migration and records it. Transactional migrations leave no trace
+// if they fail; the others are recorded as dirty before they run.
+func (m *Migrator) apply(conn *gorm.DB, migration Migration) error {
+	record := &schemaMigration{
+		Version:   migration.Version,
+		Name:      migration.Name,
+		Checksum:  migration.checksum(),
+		AppliedAt: time.Now(),
+	}
+	if !migration.NoTransaction {
+		err := conn.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(migration.Up).Error; err != nil {
+				return err
+			}
+			return tx.Create(record).Error
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		return nil
+	}
+
+	record.Dirty = true
+	if err := conn.Create(record).Error; err != nil {
+		return fmt.Errorf("record migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	if err := conn.Exec(migration.Up).Error; err != nil {
+		return fmt.Errorf("migration %d_%s left the schema dirty: %w", migration.Version, migration.Name, err)
+	}
+	return conn.Model(record).Update("dirty", false).Error
+}
+
+// revert runs the down script of a migration and removes its record
+func (m *Migrator) revert(conn *gorm.DB, migration Migration) error {
+	deleteRecord := func(db *gorm.DB) error {
+		return db.Where("version = ?", migration.Version).Delete(&schemaMigration{}).Error
+	}
+	if !migration.NoTransaction {
+		err := conn.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(migration.Down).Error; err != nil {
+				return err
+			}
+			return deleteRecord(tx)
+		})
+		if err != nil {
+			return fmt.Errorf("revert migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		return nil
+	}
+
+	if err := conn.Model(&schemaMigration{}).Where("version = ?", migration.Version).Update("dirty", true).Error; err != nil {
+		return fmt.Errorf("record migration %d_%s: %w", migration.Version, migration.Name, err)
+	}
+	if err := conn.Exec(migration.Down).Error; err != nil {
+		return fmt.Errorf("reverting migration %d_%s left the schema dirty: %w", migration.Version, migration.Name, err)
+	}
+	return deleteRecord(conn)
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"developer-portal-backend/internal/database/migrations"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	source := fstest.MapFS{
+		"10_add_index.up.sql":     {Data: []byte("-- migrate:no-transaction\nCREATE INDEX CONCURRENTLY idx ON t (c);")},
+		"10_add_index.down.sql":   {Data: []byte("DROP INDEX idx;")},
+		"2_create_table.up.sql":   {Data: []byte("CREATE TABLE t (c int);")},
+		"3_backfill.up.sql":       {Data: []byte("UPDATE t SET c = 1;")},
+		"2_create_table.down.sql": {Data: []byte("DROP TABLE t;")},
+		"migrations.go":           {Data: []byte("package migrations")},
+		"README.md":               {Data: []byte("ignored")},
+	}
+
+	loaded, err := loadMigrations(source)
+	require.NoError(t, err)
+	require.Len(t, loaded, 3)
+	assert.Equal(t, []int64{2, 3, 10}, []int64{loaded[0].Version, loaded[1].Version, loaded[2].Version})
+	assert.Equal(t, "create_table", loaded[0].Name)
+	assert.Equal(t, "DROP TABLE t;", loaded[0].Down)
+	assert.Empty(t, loaded[1].Down, "migrations without a down script cannot be reverted")
+	assert.False(t, loaded[0].NoTransaction)
+	assert.True(t, loaded[2].NoTransaction)
+}
+
+func TestLoadMigrationsRejectsInvalidFiles(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"bad name":          {"1-create.up.sql": {Data: []byte("SELECT 1;")}},
+		"zero version":      {"0_create.up.sql": {Data: []byte("SELECT 1;")}},
+		"missing up":        {"1_create.down.sql": {Data: []byte("SELECT 1;")}},
+		"empty up":          {"1_create.up.sql": {Data: []byte("  \n")}},
+		"duplicate version": {"1_create.up.sql": {Data: []byte("SELECT 1;")}, "1_other.up.sql": {Data: []byte("SELECT 1;")}},
+	}
+	for name, source := range tests {
+		_, err := loadMigrations(source)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	migrator, err := NewMigrator(nil, migrations.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, migrator.migrations)
+	assert.Equal(t, int64(1), migrator.migrations[0].Version)
+	for _, migration := range migrator.migrations {
+		assert.NotEmpty(t, migration.Down, "migration %d_%s needs a down script", migration.Version, migration.Name)
+	}
+}
+
+func TestParseMigrationMode(t *testing.T) {
+	for value, expected := range map[string]MigrationMode{"": MigrationsApply, "apply": MigrationsApply, " Check ": MigrationsCheck, "skip": MigrationsSkip} {
+		mode, err := ParseMigrationMode(value)
+		require.NoError(t, err)
+		assert.Equal(t, expected, mode)
+	}
+	_, err := ParseMigrationMode("auto")
+	assert.Error(t, err)
+}
@@ -0,0 +1,18 @@
+DROP TABLE IF EXISTS impersonation_audit_logs;
+DROP TABLE IF EXISTS user_identities;
+DROP TABLE IF EXISTS signing_keys;
+DROP TABLE IF EXISTS api_tokens;
+DROP TABLE IF EXISTS service_accounts;
+DROP TABLE IF EXISTS oauth_states;
+DROP TABLE IF EXISTS token_revocations;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS links;
+DROP TABLE IF EXISTS categories;
+DROP TABLE IF EXISTS components;
+DROP TABLE IF EXISTS projects;
+DROP TABLE IF EXISTS landscapes;
+DROP TABLE IF EXISTS documentations;
+DROP TABLE IF EXISTS teams;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS groups;
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,289 @@
+-- Baseline schema, as previously created by GORM AutoMigrate. Every statement is idempotent so
+-- databases created by AutoMigrate adopt the baseline without changes. gen_random_uuid() is built
+-- into Postgres 13+; database.Initialize enables pgcrypto for older servers.
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at  timestamptz,
+    created_by  varchar(40),
+    updated_at  timestamptz,
+    updated_by  varchar(40),
+    name        varchar(40) NOT NULL,
+    title       varchar(100) NOT NULL,
+    description varchar(200),
+    metadata    jsonb,
+    owner       varchar(20) NOT NULL,
+    email       varchar(50) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS groups (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at  timestamptz,
+    created_by  varchar(40),
+    updated_at  timestamptz,
+    updated_by  varchar(40),
+    name        varchar(40) NOT NULL,
+    title       varchar(100) NOT NULL,
+    description varchar(200),
+    metadata    jsonb,
+    org_id      uuid NOT NULL,
+    owner       varchar(20) NOT NULL,
+    email       varchar(50) NOT NULL,
+    picture_url varchar(200) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_groups_org_id ON groups (org_id);
+
+CREATE TABLE IF NOT EXISTS users (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at  timestamptz,
+    created_by  varchar(40),
+    updated_at  timestamptz,
+    updated_by  varchar(40),
+    name        varchar(40) NOT NULL,
+    title       varchar(100) NOT NULL,
+    description varchar(200),
+    metadata    jsonb,
+    team_id     uuid,
+    user_id     varchar(20) NOT NULL,
+    first_name  varchar(100) NOT NULL,
+    last_name   varchar(100) NOT NULL,
+    email       varchar(255) NOT NULL,
+    mobile      varchar(20),
+    team_domain varchar(50) NOT NULL DEFAULT 'developer',
+    team_role   varchar(50) NOT NULL DEFAULT 'member'
+);
+CREATE INDEX IF NOT EXISTS idx_users_team_id ON users (team_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_members_email_active ON users (email);
+
+CREATE TABLE IF NOT EXISTS teams (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at  timestamptz,
+    created_by  varchar(40),
+    updated_at  timestamptz,
+    updated_by  varchar(40),
+    name        varchar(40) NOT NULL,
+    title       varchar(100) NOT NULL,
+    description varchar(200),
+    metadata    jsonb,
+    group_id    uuid NOT NULL,
+    owner       varchar(20) NOT NULL,
+    email       varchar(50) NOT NULL,
+    picture_url varchar(200) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_teams_group_id ON teams (group_id);
+
+CREATE TABLE IF NOT EXISTS documentations (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at  timestamptz,
+    created_by  varchar(40),
+    updated_at  timestamptz,
+    updated_by  varchar(40),
+    deleted_at  timestamptz,
+    team_id     uuid NOT NULL,
+    owner       varchar(100) NOT NULL,
+    repo        varchar(100) NOT NULL,
+    branch      varchar(100) NOT NULL DEFAULT 'main',
+    docs_path   varchar(500) NOT NULL,
+    title       varchar(100) NOT NULL,
+    description varchar(200),
+    CONSTRAINT fk_teams_documentations FOREIGN KEY (team_id) REFERENCES teams (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_documentations_deleted_at ON documentations (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_documentations_team_id ON documentations (team_id);
+
+CREATE TABLE IF NOT EXISTS landscapes (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at  timestamptz,
+    created_by  varchar(40),
+    updated_at  timestamptz,
+    updated_by  varchar(40),
+    name        varchar(40) NOT NULL,
+    title       varchar(100) NOT NULL,
+    description varchar(200),
+    metadata    jsonb,
+    project_id  uuid NOT NULL,
+    domain      varchar(200) NOT NULL,
+    environment varchar(20) NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_landscapes_project_id ON landscapes (project_id);
+
+CREATE TABLE IF NOT EXISTS projects (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at  timestamptz,
+    created_by  varchar(40),
+    updated_at  timestamptz,
+    updated_by  varchar(40),
+    name        varchar(40) NOT NULL,
+    title       varchar(100) NOT NULL,
+    description varchar(200),
+    metadata    jsonb
+);
+
+CREATE TABLE IF NOT EXISTS components (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at  timestamptz,
+    created_by  varchar(40),
+    updated_at  timestamptz,
+    updated_by  varchar(40),
+    name        varchar(40) NOT NULL,
+    title       varchar(100) NOT NULL,
+    description varchar(200),
+    metadata    jsonb,
+    project_id  uuid NOT NULL,
+    owner_id    uuid NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_components_project_id ON components (project_id);
+CREATE INDEX IF NOT EXISTS idx_components_owner_id ON components (owner_id);
+
+CREATE TABLE IF NOT EXISTS categories (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at  timestamptz,
+    created_by  varchar(40),
+    updated_at  timestamptz,
+    updated_by  varchar(40),
+    name        varchar(40) NOT NULL,
+    title       varchar(100) NOT NULL,
+    description varchar(200),
+    metadata    jsonb,
+    icon        varchar(50) NOT NULL,
+    color       varchar(50) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS links (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at  timestamptz,
+    created_by  varchar(40),
+    updated_at  timestamptz,
+    updated_by  varchar(40),
+    name        varchar(40) NOT NULL,
+    title       varchar(100) NOT NULL,
+    description varchar(200),
+    metadata    jsonb,
+    owner       uuid NOT NULL,
+    url         varchar(2000) NOT NULL,
+    category_id uuid NOT NULL,
+    tags        varchar(200)
+);
+CREATE INDEX IF NOT EXISTS idx_links_owner ON links (owner);
+CREATE INDEX IF NOT EXISTS idx_links_category_id ON links (category_id);
+
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id           uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at   timestamptz,
+    updated_at   timestamptz,
+    token_hash   varchar(64) NOT NULL,
+    user_id      bigint NOT NULL,
+    username     varchar(100) NOT NULL,
+    email        varchar(255),
+    member_id    varchar(40),
+    provider     varchar(50) NOT NULL,
+    access_token text NOT NULL,
+    expires_at   timestamptz NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refresh_tokens_token_hash ON refresh_tokens (token_hash);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_provider ON refresh_tokens (user_id, provider);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_expires_at ON refresh_tokens (expires_at);
+
+CREATE TABLE IF NOT EXISTS token_revocations (
+    id         uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at timestamptz,
+    jti        varchar(64),
+    email      varchar(255),
+    revoked_at timestamptz NOT NULL,
+    revoked_by varchar(100),
+    reason     varchar(200),
+    expires_at timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_token_revocations_jti ON token_revocations (jti);
+CREATE INDEX IF NOT EXISTS idx_token_revocations_email ON token_revocations (email);
+CREATE INDEX IF NOT EXISTS idx_token_revocations_revoked_at ON token_revocations (revoked_at);
+CREATE INDEX IF NOT EXISTS idx_token_revocations_expires_at ON token_revocations (expires_at);
+
+CREATE TABLE IF NOT EXISTS oauth_states (
+    id            uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at    timestamptz,
+    state_hash    varchar(64) NOT NULL,
+    provider      varchar(50) NOT NULL,
+    code_verifier varchar(128) NOT NULL,
+    expires_at    timestamptz NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_oauth_states_state_hash ON oauth_states (state_hash);
+CREATE INDEX IF NOT EXISTS idx_oauth_states_expires_at ON oauth_states (expires_at);
+
+CREATE TABLE IF NOT EXISTS service_accounts (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at  timestamptz,
+    updated_at  timestamptz,
+    name        varchar(40) NOT NULL,
+    email       varchar(255),
+    description varchar(200),
+    created_by  varchar(100)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_service_accounts_name ON service_accounts (name);
+
+CREATE TABLE IF NOT EXISTS api_tokens (
+    id              uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at      timestamptz,
+    updated_at      timestamptz,
+    token_hash      varchar(64) NOT NULL,
+    prefix          varchar(16),
+    name            varchar(100) NOT NULL,
+    scopes          varchar(200) NOT NULL,
+    service_account varchar(40),
+    user_id         bigint,
+    username        varchar(100) NOT NULL,
+    email           varchar(255),
+    provider        varchar(50),
+    created_by      varchar(100),
+    expires_at      timestamptz NOT NULL,
+    last_used_at    timestamptz
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_tokens_token_hash ON api_tokens (token_hash);
+CREATE INDEX IF NOT EXISTS idx_api_tokens_service_account ON api_tokens (service_account);
+CREATE INDEX IF NOT EXISTS idx_api_tokens_user_provider ON api_tokens (user_id, provider);
+CREATE INDEX IF NOT EXISTS idx_api_tokens_expires_at ON api_tokens (expires_at);
+
+CREATE TABLE IF NOT EXISTS signing_keys (
+    id          varchar(64) PRIMARY KEY,
+    created_at  timestamptz,
+    algorithm   varchar(10) NOT NULL,
+    public_key  bytea NOT NULL,
+    private_key bytea NOT NULL,
+    not_before  timestamptz NOT NULL,
+    rotates_at  timestamptz NOT NULL,
+    expires_at  timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_signing_keys_expires_at ON signing_keys (expires_at);
+
+CREATE TABLE IF NOT EXISTS user_identities (
+    id               uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at       timestamptz,
+    updated_at       timestamptz,
+    member_id        varchar(40) NOT NULL,
+    provider         varchar(50) NOT NULL,
+    provider_user_id bigint NOT NULL,
+    username         varchar(100) NOT NULL,
+    email            varchar(255),
+    linked_at        timestamptz NOT NULL,
+    last_login_at    timestamptz NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_user_identities_member_id ON user_identities (member_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_identities_provider_account ON user_identities (provider, provider_user_id);
+
+CREATE TABLE IF NOT EXISTS impersonation_audit_logs (
+    id         uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at timestamptz NOT NULL,
+    session_id varchar(64) NOT NULL,
+    event      varchar(20) NOT NULL,
+    admin      varchar(100) NOT NULL,
+    username   varchar(100) NOT NULL,
+    email      varchar(255) NOT NULL,
+    reason     text NOT NULL,
+    method     varchar(10),
+    path       text,
+    status     bigint
+);
+CREATE INDEX IF NOT EXISTS idx_impersonation_audit_logs_created_at ON impersonation_audit_logs (created_at);
+CREATE INDEX IF NOT EXISTS idx_impersonation_audit_logs_session_id ON impersonation_audit_logs (session_id);
+CREATE INDEX IF NOT EXISTS idx_impersonation_audit_logs_admin ON impersonation_audit_logs (admin);
+CREATE INDEX IF NOT EXISTS idx_impersonation_audit_logs_email ON impersonation_audit_logs (email);
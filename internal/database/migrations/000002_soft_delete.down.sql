@@ -0,0 +1,32 @@
+-- Deleted rows would otherwise reappear as live ones
+DELETE FROM links WHERE deleted_at IS NOT NULL;
+DELETE FROM components WHERE deleted_at IS NOT NULL;
+DELETE FROM landscapes WHERE deleted_at IS NOT NULL;
+DELETE FROM users WHERE deleted_at IS NOT NULL;
+DELETE FROM teams WHERE deleted_at IS NOT NULL;
+DELETE FROM groups WHERE deleted_at IS NOT NULL;
+DELETE FROM organizations WHERE deleted_at IS NOT NULL;
+DELETE FROM projects WHERE deleted_at IS NOT NULL;
+DELETE FROM categories WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_members_email_active;
+CREATE UNIQUE INDEX idx_members_email_active ON users (email);
+
+DROP INDEX IF EXISTS idx_organizations_deleted_at;
+ALTER TABLE organizations DROP COLUMN IF EXISTS deleted_at;
+DROP INDEX IF EXISTS idx_groups_deleted_at;
+ALTER TABLE groups DROP COLUMN IF EXISTS deleted_at;
+DROP INDEX IF EXISTS idx_users_deleted_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
+DROP INDEX IF EXISTS idx_teams_deleted_at;
+ALTER TABLE teams DROP COLUMN IF EXISTS deleted_at;
+DROP INDEX IF EXISTS idx_projects_deleted_at;
+ALTER TABLE projects DROP COLUMN IF EXISTS deleted_at;
+DROP INDEX IF EXISTS idx_components_deleted_at;
+ALTER TABLE components DROP COLUMN IF EXISTS deleted_at;
+DROP INDEX IF EXISTS idx_landscapes_deleted_at;
+ALTER TABLE landscapes DROP COLUMN IF EXISTS deleted_at;
+DROP INDEX IF EXISTS idx_categories_deleted_at;
+ALTER TABLE categories DROP COLUMN IF EXISTS deleted_at;
+DROP INDEX IF EXISTS idx_links_deleted_at;
+ALTER TABLE links DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,33 @@
+-- Soft delete for catalog entities. Deleted rows keep their id so references to them stay
+-- resolvable until the trash retention job purges them.
+
+ALTER TABLE organizations ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_organizations_deleted_at ON organizations (deleted_at);
+
+ALTER TABLE groups ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_groups_deleted_at ON groups (deleted_at);
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_teams_deleted_at ON teams (deleted_at);
+
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_projects_deleted_at ON projects (deleted_at);
+
+ALTER TABLE components ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_components_deleted_at ON components (deleted_at);
+
+ALTER TABLE landscapes ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_landscapes_deleted_at ON landscapes (deleted_at);
+
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_categories_deleted_at ON categories (deleted_at);
+
+ALTER TABLE links ADD COLUMN IF NOT EXISTS deleted_at timestamptz;
+CREATE INDEX IF NOT EXISTS idx_links_deleted_at ON links (deleted_at);
+
+-- A deleted user must not block a new user with the same email
+DROP INDEX IF EXISTS idx_members_email_active;
+CREATE UNIQUE INDEX idx_members_email_active ON users (email) WHERE deleted_at IS NULL;
@@ -0,0 +1,10 @@
+ALTER TABLE organizations DROP COLUMN IF EXISTS version;
+ALTER TABLE groups DROP COLUMN IF EXISTS version;
+ALTER TABLE users DROP COLUMN IF EXISTS version;
+ALTER TABLE teams DROP COLUMN IF EXISTS version;
+ALTER TABLE projects DROP COLUMN IF EXISTS version;
+ALTER TABLE components DROP COLUMN IF EXISTS version;
+ALTER TABLE landscapes DROP COLUMN IF EXISTS version;
+ALTER TABLE categories DROP COLUMN IF EXISTS version;
+ALTER TABLE links DROP COLUMN IF EXISTS version;
+ALTER TABLE documentations DROP COLUMN IF EXISTS version;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS component_ownership_changes;
+
+DROP INDEX IF EXISTS idx_components_lifecycle;
+ALTER TABLE components DROP COLUMN IF EXISTS replacement_id;
+ALTER TABLE components DROP COLUMN IF EXISTS deprecation_date;
+ALTER TABLE components DROP COLUMN IF EXISTS lifecycle;
@@ -0,0 +1,17 @@
+-- Component lifecycle states and the history of ownership transfers between teams.
+
+ALTER TABLE components ADD COLUMN IF NOT EXISTS lifecycle varchar(20) NOT NULL DEFAULT 'production';
+ALTER TABLE components ADD COLUMN IF NOT EXISTS deprecation_date date;
+ALTER TABLE components ADD COLUMN IF NOT EXISTS replacement_id uuid;
+CREATE INDEX IF NOT EXISTS idx_components_lifecycle ON components (lifecycle);
+
+CREATE TABLE IF NOT EXISTS component_ownership_changes (
+    id            uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at    timestamptz NOT NULL,
+    component_id  uuid NOT NULL,
+    from_owner_id uuid NOT NULL,
+    to_owner_id   uuid NOT NULL,
+    changed_by    varchar(40) NOT NULL,
+    reason        varchar(200)
+);
+CREATE INDEX IF NOT EXISTS idx_component_ownership_changes_component_id ON component_ownership_changes (component_id);
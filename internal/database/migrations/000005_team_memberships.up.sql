@@ -0,0 +1,23 @@
+-- Time-ranged team memberships of users. A user has at most one current membership, the one
+-- without ended_at; it mirrors users.team_id, team_role and team_domain.
+
+CREATE TABLE IF NOT EXISTS team_memberships (
+    id          uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id     uuid NOT NULL,
+    team_id     uuid NOT NULL,
+    team_role   varchar(50) NOT NULL,
+    team_domain varchar(50) NOT NULL,
+    started_at  timestamptz NOT NULL,
+    started_by  varchar(40),
+    ended_at    timestamptz,
+    ended_by    varchar(40)
+);
+CREATE INDEX IF NOT EXISTS idx_team_memberships_user_id ON team_memberships (user_id);
+CREATE INDEX IF NOT EXISTS idx_team_memberships_team_id ON team_memberships (team_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_team_memberships_current ON team_memberships (user_id) WHERE ended_at IS NULL;
+
+-- Current members start their membership when their user was created; earlier moves are unknown
+INSERT INTO team_memberships (user_id, team_id, team_role, team_domain, started_at, started_by)
+SELECT id, team_id, team_role, team_domain, COALESCE(created_at, now()), created_by
+FROM users
+WHERE team_id IS NOT NULL AND deleted_at IS NULL;
@@ -0,0 +1,3 @@
+-- When the LDAP reconciliation first missed a user; cleared once the user is found again.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS directory_missing_since timestamptz;
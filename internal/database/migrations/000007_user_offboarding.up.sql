@@ -0,0 +1,21 @@
+-- Offboardings of users and who took over the links, teams, groups and organizations they owned.
+
+CREATE TABLE IF NOT EXISTS user_offboardings (
+    id                 uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    created_at         timestamptz NOT NULL,
+    user_id            uuid NOT NULL,
+    username           varchar(20) NOT NULL,
+    link_count         bigint NOT NULL,
+    link_owner_id      uuid,
+    favorites_removed  bigint NOT NULL,
+    team_count         bigint NOT NULL,
+    team_owner         varchar(20),
+    group_count        bigint NOT NULL,
+    group_owner        varchar(20),
+    organization_count bigint NOT NULL,
+    organization_owner varchar(20),
+    offboarded_by      varchar(40) NOT NULL,
+    reason             varchar(200)
+);
+CREATE INDEX IF NOT EXISTS idx_user_offboardings_created_at ON user_offboardings (created_at);
+CREATE INDEX IF NOT EXISTS idx_user_offboardings_user_id ON user_offboardings (user_id);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_links_broken;
+ALTER TABLE links DROP COLUMN IF EXISTS broken;
+ALTER TABLE links DROP COLUMN IF EXISTS response_time_ms;
+ALTER TABLE links DROP COLUMN IF EXISTS check_error;
+ALTER TABLE links DROP COLUMN IF EXISTS check_status;
+ALTER TABLE links DROP COLUMN IF EXISTS checked_at;
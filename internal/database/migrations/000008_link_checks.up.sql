@@ -0,0 +1,8 @@
+-- Outcome of the latest dead-link check of each link.
+
+ALTER TABLE links ADD COLUMN IF NOT EXISTS checked_at timestamptz;
+ALTER TABLE links ADD COLUMN IF NOT EXISTS check_status bigint;
+ALTER TABLE links ADD COLUMN IF NOT EXISTS check_error varchar(200);
+ALTER TABLE links ADD COLUMN IF NOT EXISTS response_time_ms bigint;
+ALTER TABLE links ADD COLUMN IF NOT EXISTS broken boolean NOT NULL DEFAULT false;
+CREATE INDEX IF NOT EXISTS idx_links_broken ON links (broken);
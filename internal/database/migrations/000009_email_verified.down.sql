@@ -0,0 +1,2 @@
+ALTER TABLE api_tokens DROP COLUMN IF EXISTS email_verified;
+ALTER TABLE refresh_tokens DROP COLUMN IF EXISTS email_verified;
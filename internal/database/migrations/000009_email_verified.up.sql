@@ -0,0 +1,5 @@
+-- Whether the provider verified the email of a session or personal access token; only verified
+-- emails match email entries of the auth config admins.
+
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS email_verified boolean NOT NULL DEFAULT false;
+ALTER TABLE api_tokens ADD COLUMN IF NOT EXISTS email_verified boolean NOT NULL DEFAULT false;
@@ -0,0 +1,13 @@
+// Package migrations embeds the versioned SQL migrations of the portal database.
+//
+// Each migration is a pair of files named <version>_<name>.up.sql and <version>_<name>.down.sql,
+// applied in version order by database.Migrator. Add new migrations with
+// `go run ./cmd/migrate create <name>`; never edit a migration once it has been released.
+package migrations
+
+import "embed"
+
+// FS holds the migration files
+//
+//go:embed *.sql
+var FS embed.FS
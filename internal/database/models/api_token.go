@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ServiceAccount is a non-human identity (CI job, CLI tool, bot) that authenticates with API tokens
+type ServiceAccount struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Name        string `json:"name" gorm:"size:40;not null;uniqueIndex"`
+	Email       string `json:"email" gorm:"size:255"` // contact address, reported as the caller's email
+	Description string `json:"description" gorm:"size:200"`
+	CreatedBy   string `json:"created_by" gorm:"size:100"`
+}
+
+// TableName returns the table name for ServiceAccount
+func (ServiceAccount) TableName() string {
+	return "service_accounts"
+}
+
+// BeforeCreate sets the UUID if not already set
+func (a *ServiceAccount) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// APIToken is a long-lived bearer token for /api/v1, either a personal access token acting as the
+// user who created it or a token of a service account (ServiceAccount set).
+// Only the SHA-256 hash of the token is stored, the raw value is handed to the client once.
+type APIToken struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	TokenHash string `json:"-" gorm:"size:64;not null;uniqueIndex"` // hex encoded SHA-256 of the token
+	Prefix    string `json:"prefix" gorm:"size:16"`                 // first characters of the token, to recognize it in listings
+	Name      string `json:"name" gorm:"size:100;not null"`
+	Scopes    string `json:"scopes" gorm:"size:200;not null"` // comma separated values
+
+	// Identity the token acts as. For personal access tokens this is the provider identity of the owner.
+	ServiceAccount *string `json:"service_account,omitempty" gorm:"size:40;index"`
+	UserID         int64   `json:"user_id" gorm:"index:idx_api_tokens_user_provider"`
+	Username       string  `json:"username" gorm:"size:100;not null"`
+	Email          string  `json:"email" gorm:"size:255"`
+	Provider       string  `json:"provider" gorm:"size:50;index:idx_api_tokens_user_provider"`
+	EmailVerified  bool    `json:"email_verified" gorm:"not null;default:false"` // whether the provider had verified Email
+
+	CreatedBy  string     `json:"created_by" gorm:"size:100"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"not null;index"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// TableName returns the table name for APIToken
+func (APIToken) TableName() string {
+	return "api_tokens"
+}
+
+// BeforeCreate sets the UUID if not already set
+func (t *APIToken) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
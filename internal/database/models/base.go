@@ -19,6 +19,8 @@ type BaseModel struct {
 	Title        string         `json:"title" gorm:"size:100;not null" validate:"required,min=1,max=100"` // AKA display name
 	Description  string         `json:"description" gorm:"size:200" validate:"max=200"`
 	Metadata     json.RawMessage `json:"metadata" gorm:"type:jsonb"`
+	DeletedAt    gorm.DeletedAt  `json:"deleted_at,omitempty" gorm:"index"` // soft delete; see repository.TrashRepository
+	Version      int64           `json:"version" gorm:"not null;default:1"`  // incremented by every update, for optimistic locking
 }
 
 // BeforeCreate sets the UUID if not already set
@@ -26,6 +28,9 @@ func (base *BaseModel) BeforeCreate(tx *gorm.DB) error {
 	if base.ID == uuid.Nil {
 		base.ID = uuid.New()
 	}
+	if base.Version == 0 {
+		base.Version = 1
+	}
 	return nil
 }
 
@@ -1,7 +1,10 @@
 package models
 
 import (
+	"time"
+
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // Component represents a software component/service
@@ -9,9 +12,38 @@ type Component struct {
 	BaseModel
 	ProjectID uuid.UUID `json:"project_id" gorm:"type:uuid;not null;index" validate:"required"`
 	OwnerID   uuid.UUID `json:"owner_id" gorm:"type:uuid;not null;index" validate:"required"`
+
+	Lifecycle       ComponentLifecycle `json:"lifecycle" gorm:"size:20;not null;default:production;index" validate:"required,oneof=experimental production deprecated end-of-life"`
+	DeprecationDate *time.Time         `json:"deprecation_date,omitempty" gorm:"type:date"` // when the component was or will be deprecated
+	ReplacementID   *uuid.UUID         `json:"replacement_id,omitempty" gorm:"type:uuid"`   // component to use instead
 }
 
 // TableName returns the table name for Component
 func (Component) TableName() string {
 	return "components"
 }
+
+// ComponentOwnershipChange records the transfer of a component from one team to another
+type ComponentOwnershipChange struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null"`
+
+	ComponentID uuid.UUID `json:"component_id" gorm:"type:uuid;not null;index"`
+	FromOwnerID uuid.UUID `json:"from_owner_id" gorm:"type:uuid;not null"`
+	ToOwnerID   uuid.UUID `json:"to_owner_id" gorm:"type:uuid;not null"`
+	ChangedBy   string    `json:"changed_by" gorm:"size:40;not null"`
+	Reason      string    `json:"reason" gorm:"size:200"`
+}
+
+// TableName returns the table name for ComponentOwnershipChange
+func (ComponentOwnershipChange) TableName() string {
+	return "component_ownership_changes"
+}
+
+// BeforeCreate sets the UUID if not already set
+func (c *ComponentOwnershipChange) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
@@ -17,6 +17,7 @@ type Documentation struct {
 	UpdatedAt time.Time      `json:"updated_at"`
 	UpdatedBy string         `json:"updated_by" gorm:"size:40" validate:"max=40"`
 	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
+	Version   int64          `json:"version" gorm:"not null;default:1"` // incremented by every update, for optimistic locking
 
 	// Foreign key to Team (one-to-many: team has many documentations)
 	TeamID uuid.UUID `json:"team_id" gorm:"type:uuid;not null;index" validate:"required"`
@@ -43,6 +44,9 @@ func (doc *Documentation) BeforeCreate(tx *gorm.DB) error {
 	if doc.ID == uuid.Nil {
 		doc.ID = uuid.New()
 	}
+	if doc.Version == 0 {
+		doc.Version = 1
+	}
 	return nil
 }
 
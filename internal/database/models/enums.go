@@ -37,3 +37,27 @@ func (s ShiftType) IsValid() bool {
 	}
 	return false
 }
+
+// ComponentLifecycle defines the lifecycle states of a component
+type ComponentLifecycle string
+
+const (
+	ComponentLifecycleExperimental ComponentLifecycle = "experimental"
+	ComponentLifecycleProduction   ComponentLifecycle = "production"
+	ComponentLifecycleDeprecated   ComponentLifecycle = "deprecated"
+	ComponentLifecycleEndOfLife    ComponentLifecycle = "end-of-life"
+)
+
+// IsValid checks if the ComponentLifecycle is valid
+func (l ComponentLifecycle) IsValid() bool {
+	switch l {
+	case ComponentLifecycleExperimental, ComponentLifecycleProduction, ComponentLifecycleDeprecated, ComponentLifecycleEndOfLife:
+		return true
+	}
+	return false
+}
+
+// IsRetiring reports whether the component is being phased out, i.e. deprecated or end-of-life
+func (l ComponentLifecycle) IsRetiring() bool {
+	return l == ComponentLifecycleDeprecated || l == ComponentLifecycleEndOfLife
+}
@@ -11,6 +11,9 @@ type Group struct {
 	Owner      string    `json:"owner" gorm:"not null;size:20" validate:"required,min=5,max=20"` // I/C/D user
 	Email      string    `json:"email" gorm:"not null;size:50" validate:"required,min=5,max=50"` // DL
 	PictureURL string    `json:"picture_url" gorm:"not null;size:200" validate:"required,min=5,max=200"`
+
+	// Relationships
+	Teams []Team `json:"teams,omitempty" gorm:"foreignKey:GroupID"`
 }
 
 // TableName returns the table name for Group
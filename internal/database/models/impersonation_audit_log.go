@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ImpersonationAuditLog records the start of an admin impersonation session or a request made with it
+type ImpersonationAuditLog struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null;index"`
+
+	SessionID string `json:"session_id" gorm:"size:64;not null;index"` // jti of the impersonation JWT
+	Event     string `json:"event" gorm:"size:20;not null"`
+	Admin     string `json:"admin" gorm:"size:100;not null;index"`
+	Username  string `json:"username" gorm:"size:100;not null"` // impersonated user
+	Email     string `json:"email" gorm:"size:255;not null;index"`
+	Reason    string `json:"reason" gorm:"type:text;not null"`
+	Method    string `json:"method" gorm:"size:10"`
+	Path      string `json:"path" gorm:"type:text"`
+	Status    int    `json:"status"`
+}
+
+// TableName returns the table name for ImpersonationAuditLog
+func (ImpersonationAuditLog) TableName() string {
+	return "impersonation_audit_logs"
+}
+
+// BeforeCreate sets the UUID if not already set
+func (l *ImpersonationAuditLog) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == uuid.Nil {
+		l.ID = uuid.New()
+	}
+	return nil
+}
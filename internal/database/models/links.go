@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 )
 
@@ -10,6 +12,14 @@ type Link struct {
 	URL      string    `json:"url" gorm:"not null;size:2000" validate:"required,max=2000"`
 	CategoryID uuid.UUID `json:"category_id" gorm:"type:uuid;not null;index" validate:"required"`
 	Tags     string    `json:"tags" gorm:"size:200" validate:"max=200"` // comma seperated values
+
+	// Outcome of the latest dead-link check, see service.LinkCheckService; CheckedAt is nil until the first one.
+	// CheckError and ResponseTimeMs tell about the network of the checker and are never serialized.
+	CheckedAt      *time.Time `json:"checked_at,omitempty"`
+	CheckStatus    int        `json:"check_status,omitempty"` // HTTP status of the final response, 0 if there was none
+	CheckError     string     `json:"-" gorm:"size:200"`
+	ResponseTimeMs int64      `json:"-"`
+	Broken         bool       `json:"broken" gorm:"not null;default:false;index"`
 }
 
 // TableName returns the table name for Link
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OAuthState is an OAuth state parameter issued by the login start endpoint and not yet
+// returned to the callback. Only the SHA-256 hash of the state is stored.
+type OAuthState struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt time.Time `json:"created_at"`
+
+	StateHash    string    `json:"-" gorm:"size:64;not null;uniqueIndex"` // hex encoded SHA-256 of the state
+	Provider     string    `json:"provider" gorm:"size:50;not null"`
+	CodeVerifier string    `json:"-" gorm:"size:128;not null"` // PKCE code_verifier sent with the token exchange
+	ExpiresAt    time.Time `json:"expires_at" gorm:"not null;index"`
+}
+
+// TableName returns the table name for OAuthState
+func (OAuthState) TableName() string {
+	return "oauth_states"
+}
+
+// BeforeCreate sets the UUID if not already set
+func (s *OAuthState) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
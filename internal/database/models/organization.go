@@ -5,6 +5,9 @@ type Organization struct {
 	BaseModel
 	Owner string `json:"owner" gorm:"not null;size:20" validate:"required,min=5,max=20"` // I/C/D user
 	Email string `json:"email" gorm:"not null;size:50" validate:"required,min=5,max=50"` // DL
+
+	// Relationships
+	Groups []Group `json:"groups,omitempty" gorm:"foreignKey:OrgID"`
 }
 
 // TableName returns the table name for Organization
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RefreshToken represents a portal refresh token and the upstream OAuth session it belongs to.
+// Only the SHA-256 hash of the refresh token is stored, the raw value is handed to the client once.
+type RefreshToken struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	TokenHash string `json:"-" gorm:"size:64;not null;uniqueIndex"` // hex encoded SHA-256 of the refresh token
+
+	// Identity as reported by the OAuth provider
+	UserID   int64   `json:"user_id" gorm:"not null;index:idx_refresh_tokens_user_provider"` // provider user ID (e.g. GitHub user ID)
+	Username string  `json:"username" gorm:"size:100;not null"`
+	Email    string  `json:"email" gorm:"size:255"`
+	MemberID *string `json:"member_id,omitempty" gorm:"size:40"` // ID of users row with matching email
+	Provider string  `json:"provider" gorm:"size:50;not null;index:idx_refresh_tokens_user_provider"`
+	// EmailVerified tells whether the provider verified Email at login
+	EmailVerified bool `json:"email_verified" gorm:"not null;default:false"`
+
+	AccessToken string    `json:"-" gorm:"type:text;not null"` // upstream OAuth access token, sealed when token_encryption is configured
+	ExpiresAt   time.Time `json:"expires_at" gorm:"not null;index"`
+}
+
+// TableName returns the table name for RefreshToken
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// BeforeCreate sets the UUID if not already set
+func (t *RefreshToken) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
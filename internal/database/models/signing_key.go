@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+// SigningKey is a key of the JWT key set. It signs portal tokens from NotBefore until RotatesAt
+// and verifies them until ExpiresAt. The private key is sealed by the auth service.
+type SigningKey struct {
+	ID        string    `json:"kid" gorm:"size:64;primary_key"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Algorithm  string    `json:"alg" gorm:"size:10;not null"`
+	PublicKey  []byte    `json:"-" gorm:"not null"`
+	PrivateKey []byte    `json:"-" gorm:"not null"`
+	NotBefore  time.Time `json:"not_before" gorm:"not null"`
+	RotatesAt  time.Time `json:"rotates_at" gorm:"not null"`
+	ExpiresAt  time.Time `json:"expires_at" gorm:"not null;index"`
+}
+
+// TableName returns the table name for SigningKey
+func (SigningKey) TableName() string {
+	return "signing_keys"
+}
@@ -14,6 +14,7 @@ type Team struct {
 
 	// Relationships
 	Documentations []Documentation `json:"documentations,omitempty" gorm:"foreignKey:TeamID"`
+	Users          []User          `json:"users,omitempty" gorm:"foreignKey:TeamID"`
 }
 
 // TableName returns the table name for Team
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TokenRevocation is an entry of the portal JWT denylist.
+// An entry either revokes a single token (JTI set) or every token issued to a user
+// up to RevokedAt (Email set, JTI empty).
+type TokenRevocation struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt time.Time `json:"created_at"`
+
+	JTI       string    `json:"jti,omitempty" gorm:"size:64;index"`
+	Email     string    `json:"email,omitempty" gorm:"size:255;index"`
+	RevokedAt time.Time `json:"revoked_at" gorm:"not null;index"`
+	RevokedBy string    `json:"revoked_by" gorm:"size:100"`
+	Reason    string    `json:"reason" gorm:"size:200"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null;index"` // entry is irrelevant once all affected tokens have expired
+}
+
+// TableName returns the table name for TokenRevocation
+func (TokenRevocation) TableName() string {
+	return "token_revocations"
+}
+
+// BeforeCreate sets the UUID if not already set
+func (r *TokenRevocation) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
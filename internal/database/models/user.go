@@ -2,8 +2,10 @@ package models
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type TeamDomain string
@@ -15,6 +17,15 @@ const (
 	TeamDomainArchitect TeamDomain = "architect"
 )
 
+// IsValid reports whether d is a known team domain
+func (d TeamDomain) IsValid() bool {
+	switch d {
+	case TeamDomainDeveloper, TeamDomainDevOps, TeamDomainPO, TeamDomainArchitect:
+		return true
+	}
+	return false
+}
+
 // TeamRole represents the role within a team context
 type TeamRole string
 
@@ -25,21 +36,100 @@ const (
 	TeamRoleMMM     TeamRole = "mmm"
 )
 
+// IsValid reports whether r is a known team role
+func (r TeamRole) IsValid() bool {
+	switch r {
+	case TeamRoleMember, TeamRoleScM, TeamRoleManager, TeamRoleMMM:
+		return true
+	}
+	return false
+}
+
 // Member represents a member of an organization (replaces User)
 type User struct {
 	BaseModel
-	TeamID      *uuid.UUID      `json:"team_id,omitempty" gorm:"type:uuid;index"`
-	UserID      string          `gorm:"not null;size:20" validate:"required,min=5,max=20"` // I/C/D user
-	FirstName   string          `json:"first_name" gorm:"not null;size:100" validate:"required,max=100"`
-	LastName    string          `json:"last_name" gorm:"not null;size:100" validate:"required,max=100"`
-	Email       string          `json:"email" gorm:"uniqueIndex:idx_members_email_active;not null;size:255" validate:"required,email,max=255"`
-	Mobile      string          `json:"mobile" gorm:"size:20"`
-	TeamDomain  TeamDomain      `json:"role" gorm:"type:varchar(50);not null;default:'developer'" validate:"required"`
-	TeamRole    TeamRole        `json:"team_role" gorm:"type:varchar(50);not null;default:'member'"`
-	Metadata    json.RawMessage `json:"metadata" gorm:"type:jsonb"`
+	TeamID     *uuid.UUID      `json:"team_id,omitempty" gorm:"type:uuid;index"`
+	UserID     string          `gorm:"not null;size:20" validate:"required,min=5,max=20"` // I/C/D user
+	FirstName  string          `json:"first_name" gorm:"not null;size:100" validate:"required,max=100"`
+	LastName   string          `json:"last_name" gorm:"not null;size:100" validate:"required,max=100"`
+	Email      string          `json:"email" gorm:"uniqueIndex:idx_members_email_active,where:deleted_at IS NULL;not null;size:255" validate:"required,email,max=255"`
+	Mobile     string          `json:"mobile" gorm:"size:20"`
+	TeamDomain TeamDomain      `json:"role" gorm:"type:varchar(50);not null;default:'developer'" validate:"required"`
+	TeamRole   TeamRole        `json:"team_role" gorm:"type:varchar(50);not null;default:'member'"`
+	Metadata   json.RawMessage `json:"metadata" gorm:"type:jsonb"`
+	// DirectoryMissingSince is when the directory reconciliation first missed the user in LDAP
+	DirectoryMissingSince *time.Time `json:"directory_missing_since,omitempty"`
 }
 
 // TableName returns the table name for User
 func (User) TableName() string {
 	return "users"
 }
+
+// TeamMembership records a period in which a user belonged to a team with a role and domain.
+// Changing any of them ends the current membership and starts a new one; the current
+// membership has no EndedAt.
+type TeamMembership struct {
+	ID uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+
+	UserID     uuid.UUID  `json:"user_uuid" gorm:"type:uuid;not null;index"`
+	TeamID     uuid.UUID  `json:"team_id" gorm:"type:uuid;not null;index"`
+	TeamRole   TeamRole   `json:"team_role" gorm:"type:varchar(50);not null"`
+	TeamDomain TeamDomain `json:"team_domain" gorm:"type:varchar(50);not null"`
+	StartedAt  time.Time  `json:"started_at" gorm:"not null"`
+	StartedBy  string     `json:"started_by" gorm:"size:40"`
+	EndedAt    *time.Time `json:"ended_at,omitempty"`
+	EndedBy    string     `json:"ended_by,omitempty" gorm:"size:40"`
+
+	// Relationships
+	User *User `json:"-" gorm:"foreignKey:UserID"`
+}
+
+// TableName returns the table name for TeamMembership
+func (TeamMembership) TableName() string {
+	return "team_memberships"
+}
+
+// BeforeCreate sets the UUID if not already set
+func (m *TeamMembership) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+// UserOffboarding records the offboarding of a user: who took over the resources they owned.
+// An empty owner means no resources of that kind were handed over.
+type UserOffboarding struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt time.Time `json:"created_at" gorm:"not null;index"`
+
+	UserID   uuid.UUID `json:"user_uuid" gorm:"type:uuid;not null;index"`
+	Username string    `json:"user_id" gorm:"size:20;not null"` // I/C/D user
+
+	LinkCount         int        `json:"link_count" gorm:"not null"`
+	LinkOwnerID       *uuid.UUID `json:"link_owner_id,omitempty" gorm:"type:uuid"` // user or team; nil if the links were deleted
+	FavoritesRemoved  int        `json:"favorites_removed" gorm:"not null"`        // users whose favorites lost a deleted link
+	TeamCount         int        `json:"team_count" gorm:"not null"`
+	TeamOwner         string     `json:"team_owner,omitempty" gorm:"size:20"`
+	GroupCount        int        `json:"group_count" gorm:"not null"`
+	GroupOwner        string     `json:"group_owner,omitempty" gorm:"size:20"`
+	OrganizationCount int        `json:"organization_count" gorm:"not null"`
+	OrganizationOwner string     `json:"organization_owner,omitempty" gorm:"size:20"`
+
+	OffboardedBy string `json:"offboarded_by" gorm:"size:40;not null"`
+	Reason       string `json:"reason" gorm:"size:200"`
+}
+
+// TableName returns the table name for UserOffboarding
+func (UserOffboarding) TableName() string {
+	return "user_offboardings"
+}
+
+// BeforeCreate sets the UUID if not already set
+func (o *UserOffboarding) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserIdentity links a provider account (e.g. a githubtools or githubwdf login) to a portal user.
+// A user may own one identity per provider account; each has its own upstream session.
+type UserIdentity struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	MemberID       string `json:"member_id" gorm:"size:40;not null;index"` // ID of the owning users row
+	Provider       string `json:"provider" gorm:"size:50;not null;uniqueIndex:idx_user_identities_provider_account"`
+	ProviderUserID int64  `json:"provider_user_id" gorm:"not null;uniqueIndex:idx_user_identities_provider_account"`
+	Username       string `json:"username" gorm:"size:100;not null"`
+	Email          string `json:"email" gorm:"size:255"`
+
+	LinkedAt    time.Time `json:"linked_at" gorm:"not null"`
+	LastLoginAt time.Time `json:"last_login_at" gorm:"not null"`
+}
+
+// TableName returns the table name for UserIdentity
+func (UserIdentity) TableName() string {
+	return "user_identities"
+}
+
+// BeforeCreate sets the UUID if not already set
+func (i *UserIdentity) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
@@ -104,6 +104,14 @@ var (
 	ErrProjectComponentNotFound       = &NotFoundError{Entity: "project-component relationship"}
 	ErrProjectLandscapeNotFound       = &NotFoundError{Entity: "project-landscape relationship"}
 	ErrOutageCallAssigneeNotFound     = &NotFoundError{Entity: "outage call assignee"}
+	ErrAPITokenNotFound               = &NotFoundError{Entity: "API token"}
+	ErrServiceAccountNotFound         = &NotFoundError{Entity: "service account"}
+	ErrIdentityNotFound               = &NotFoundError{Entity: "linked identity"}
+	ErrTrashItemNotFound              = &NotFoundError{Entity: "deleted entity"}
+	ErrTeamMemberNotFound             = &NotFoundError{Entity: "team member"}
+	ErrDirectorySyncReportNotFound    = &NotFoundError{Entity: "directory reconciliation report"}
+	ErrDistributionListNotFound       = &NotFoundError{Entity: "distribution list"}
+	ErrLinkCheckRunNotFound           = &NotFoundError{Entity: "link check"}
 )
 
 // Already Exists Errors
@@ -122,6 +130,8 @@ var (
 	ErrProjectComponentExists          = &AlreadyExistsError{Entity: "project-component relationship", Context: ""}
 	ErrProjectLandscapeExists          = &AlreadyExistsError{Entity: "project-landscape relationship", Context: ""}
 	ErrOutageCallAssigneeExists        = &AlreadyExistsError{Entity: "outage call assignee", Context: ""}
+	ErrServiceAccountExists            = &AlreadyExistsError{Entity: "service account", Context: "with this name"}
+	ErrTeamMemberExists                = &AlreadyExistsError{Entity: "team member", Context: "in this team"}
 )
 
 // Association Errors
@@ -149,12 +159,23 @@ var (
 	ErrGitHubAPIRateLimitExceeded = errors.New("GitHub API rate limit exceeded")
 	ErrProviderNotConfigured      = errors.New("provider is not configured")
 	ErrInvalidPeriodFormat        = errors.New("invalid period format")
+	ErrVersionConflict            = errors.New("resource was modified since it was read; reload it and try again")
+	ErrOrganizationHasGroups      = errors.New("organization still has groups")
+	ErrGroupHasTeams              = errors.New("group still has teams")
+	ErrProjectHasResources        = errors.New("project still has components or landscapes")
+	ErrDirectorySyncRunning       = errors.New("directory reconciliation is already running")
+	ErrLinkCheckRunning           = errors.New("link check is already running")
 )
 
 // Authentication Errors
 var (
 	ErrInvalidRefreshToken = errors.New("invalid refresh token")
 	ErrRefreshTokenExpired = errors.New("refresh token has expired")
+	ErrTokenRevoked        = errors.New("token has been revoked")
+	ErrInvalidOAuthState   = errors.New("invalid or already used OAuth state")
+	ErrOAuthStateExpired   = errors.New("OAuth state has expired")
+	ErrInvalidAPIToken     = errors.New("invalid API token")
+	ErrAPITokenExpired     = errors.New("API token has expired")
 
 	// AI Core specific authentication errors
 	ErrUserEmailNotFound     = &AuthenticationError{Message: "user email not found in context"}
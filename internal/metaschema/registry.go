@@ -0,0 +1,206 @@
+// Package metaschema validates the free-form Metadata of catalog entities against JSON Schemas.
+//
+// Every entity type has a schema in schemas/<entity>.json. A project may add a schema for the
+// metadata of its components and landscapes in schemas/projects/<project>/<entity>.json, which
+// applies in addition to the one of the entity type. The schemas describe the keys the portal
+// reads; metadata may hold further keys.
+package metaschema
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	apperrors "developer-portal-backend/internal/errors"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// Entity names an entity type with metadata
+type Entity string
+
+const (
+	EntityTeam      Entity = "team"
+	EntityUser      Entity = "user"
+	EntityProject   Entity = "project"
+	EntityComponent Entity = "component"
+	EntityLandscape Entity = "landscape"
+)
+
+// Entities lists all entity types with metadata
+var Entities = []Entity{EntityTeam, EntityUser, EntityProject, EntityComponent, EntityLandscape}
+
+// ErrUnknownEntity is returned for entity types without a metadata schema
+var ErrUnknownEntity = errors.New("unknown entity type")
+
+//go:embed schemas
+var schemaFS embed.FS
+
+// schema is a compiled JSON Schema along with its source
+type schema struct {
+	source   json.RawMessage
+	compiled *gojsonschema.Schema
+}
+
+// SchemaSet holds the metadata schema of an entity type and the additional schemas of projects
+type SchemaSet struct {
+	Entity   Entity                     `json:"entity"`
+	Schema   json.RawMessage            `json:"schema" swaggertype:"object"`
+	Projects map[string]json.RawMessage `json:"projects,omitempty" swaggertype:"object"`
+}
+
+// Registry holds the metadata schemas of all entity types
+type Registry struct {
+	entities map[Entity]*schema
+	projects map[string]map[Entity]*schema
+}
+
+// NewRegistry compiles the metadata schemas built into the portal
+func NewRegistry() (*Registry, error) {
+	schemas, err := fs.Sub(schemaFS, "schemas")
+	if err != nil {
+		return nil, err
+	}
+	return newRegistry(schemas)
+}
+
+func newRegistry(schemas fs.FS) (*Registry, error) {
+	r := &Registry{
+		entities: make(map[Entity]*schema),
+		projects: make(map[string]map[Entity]*schema),
+	}
+	err := fs.WalkDir(schemas, ".", func(file string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		entity := Entity(strings.TrimSuffix(path.Base(file), ".json"))
+		if path.Ext(file) != ".json" || !entity.IsValid() {
+			return fmt.Errorf("metadata schema %s is not named after an entity type", file)
+		}
+		s, err := compileSchema(schemas, file)
+		if err != nil {
+			return err
+		}
+
+		switch parts := strings.Split(file, "/"); {
+		case len(parts) == 1:
+			r.entities[entity] = s
+		case len(parts) == 3 && parts[0] == "projects":
+			if r.projects[parts[1]] == nil {
+				r.projects[parts[1]] = make(map[Entity]*schema)
+			}
+			r.projects[parts[1]][entity] = s
+		default:
+			return fmt.Errorf("metadata schema %s must be <entity>.json or projects/<project>/<entity>.json", file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, entity := range Entities {
+		if r.entities[entity] == nil {
+			return nil, fmt.Errorf("metadata schema %s.json is missing", entity)
+		}
+	}
+	return r, nil
+}
+
+func compileSchema(schemas fs.FS, file string) (*schema, error) {
+	source, err := fs.ReadFile(schemas, file)
+	if err != nil {
+		return nil, err
+	}
+	compiled, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(source))
+	if err != nil {
+		return nil, fmt.Errorf("metadata schema %s: %w", file, err)
+	}
+	return &schema{source: source, compiled: compiled}, nil
+}
+
+// IsValid reports whether the entity type has metadata
+func (e Entity) IsValid() bool {
+	for _, entity := range Entities {
+		if e == entity {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks metadata of an entity of the project, which may be empty, against the schemas.
+// Missing metadata is valid. Returns a ValidationError listing all violations.
+func (r *Registry) Validate(entity Entity, project string, metadata json.RawMessage) error {
+	base, ok := r.entities[entity]
+	if !ok {
+		return fmt.Errorf("%w %q", ErrUnknownEntity, entity)
+	}
+	if trimmed := strings.TrimSpace(string(metadata)); trimmed == "" || trimmed == "null" {
+		return nil
+	}
+
+	schemas := []*schema{base}
+	if s, ok := r.projects[project][entity]; ok {
+		schemas = append(schemas, s)
+	}
+	var violations []string
+	for _, s := range schemas {
+		result, err := s.compiled.Validate(gojsonschema.NewBytesLoader(metadata))
+		if err != nil {
+			return apperrors.NewValidationError("metadata", "must be a JSON object: "+err.Error())
+		}
+		for _, violation := range result.Errors() {
+			field := violation.Field()
+			if field == gojsonschema.STRING_ROOT_SCHEMA_PROPERTY {
+				violations = append(violations, violation.Description())
+				continue
+			}
+			violations = append(violations, field+": "+violation.Description())
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	sort.Strings(violations)
+	return apperrors.NewValidationError("metadata", strings.Join(violations, "; "))
+}
+
+// Schema returns the schema metadata of an entity of the project must satisfy. If the project adds
+// a schema, the result combines both with allOf.
+func (r *Registry) Schema(entity Entity, project string) (json.RawMessage, error) {
+	base, ok := r.entities[entity]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownEntity, entity)
+	}
+	s, ok := r.projects[project][entity]
+	if !ok {
+		return base.source, nil
+	}
+	return json.Marshal(map[string]interface{}{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"allOf":   []json.RawMessage{base.source, s.source},
+	})
+}
+
+// List returns the schemas of all entity types
+func (r *Registry) List() []SchemaSet {
+	sets := make([]SchemaSet, 0, len(Entities))
+	for _, entity := range Entities {
+		set := SchemaSet{Entity: entity, Schema: r.entities[entity].source}
+		for project, schemas := range r.projects {
+			if s, ok := schemas[entity]; ok {
+				if set.Projects == nil {
+					set.Projects = make(map[string]json.RawMessage)
+				}
+				set.Projects[project] = s.source
+			}
+		}
+		sets = append(sets, set)
+	}
+	return sets
+}
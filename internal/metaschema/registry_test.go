@@ -0,0 +1,152 @@
+package metaschema
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	apperrors "developer-portal-backend/internal/errors"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func newTestRegistry(t *testing.T) *Registry {
+	registry, err := NewRegistry()
+	require.NoError(t, err)
+	return registry
+}
+
+func TestValidate(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	tests := []struct {
+		name     string
+		entity   Entity
+		project  string
+		metadata string
+		errors   []string
+	}{
+		{name: "empty", entity: EntityTeam, metadata: ""},
+		{name: "null", entity: EntityTeam, metadata: "null"},
+		{name: "unknown keys are allowed", entity: EntityTeam, metadata: `{"slack":"#team"}`},
+		{name: "valid team", entity: EntityTeam, metadata: `{"color":"#3b82f6","jira":{"team":"TeamCOE","project-key":"SAPBTPCFS","components":["COE"],"board-id":"30372"}}`},
+		{name: "typo in jira key", entity: EntityTeam, metadata: `{"jira":{"project_key":"SAPBTPCFS"}}`, errors: []string{"jira: Additional property project_key is not allowed"}},
+		{name: "wrong type", entity: EntityTeam, metadata: `{"jira":{"board-id":30372}}`, errors: []string{"jira.board-id: Invalid type. Expected: string, given: integer"}},
+		{name: "not an object", entity: EntityUser, metadata: `["a"]`, errors: []string{"Invalid type. Expected: object, given: array"}},
+		{name: "favorites are link ids", entity: EntityUser, metadata: `{"favorites":["not-a-uuid"]}`, errors: []string{"favorites.0: Does not match format 'uuid'"}},
+		{name: "single ai core team", entity: EntityUser, metadata: `{"ai_core_member_of":"team-a"}`},
+		{name: "component qos job", entity: EntityComponent, metadata: `{"ci":{"qos":{"jenkins_url":"https://jenkins.example.com/job/qos"}},"sonar":{"project_id":"svc"}}`},
+		{name: "alerts repository must be a folder", entity: EntityProject, metadata: `{"alerts-repo":"https://github.example.com/org/repo"}`, errors: []string{"alerts-repo: Does not match pattern '^https://[^/]+/[^/]+/[^/]+/tree/[^/]+/.+$'"}},
+		{name: "project schema applies", entity: EntityLandscape, project: "cis20", metadata: `{"central-region":"yes","oc-prefix":"Bad Prefix"}`, errors: []string{
+			"central-region: Invalid type. Expected: boolean, given: string",
+			"oc-prefix: Does not match pattern '^[a-z0-9]([a-z0-9.-]*[a-z0-9])?$'",
+		}},
+		{name: "project schema of other projects does not apply", entity: EntityLandscape, project: "usrv", metadata: `{"oc-prefix":"Bad Prefix"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := registry.Validate(tt.entity, tt.project, json.RawMessage(tt.metadata))
+			if len(tt.errors) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *apperrors.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, "metadata", validationErr.Field)
+			for _, message := range tt.errors {
+				assert.Contains(t, validationErr.Message, message)
+			}
+		})
+	}
+
+	assert.ErrorIs(t, registry.Validate("organization", "", json.RawMessage(`{}`)), ErrUnknownEntity)
+}
+
+func TestSchema(t *testing.T) {
+	registry := newTestRegistry(t)
+
+	team, err := registry.Schema(EntityTeam, "cis20")
+	require.NoError(t, err)
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(team, &schema))
+	assert.Equal(t, "Team metadata", schema["title"])
+
+	landscape, err := registry.Schema(EntityLandscape, "cis20")
+	require.NoError(t, err)
+	schema = nil
+	require.NoError(t, json.Unmarshal(landscape, &schema))
+	assert.Len(t, schema["allOf"], 2)
+
+	_, err = registry.Schema("organization", "")
+	assert.ErrorIs(t, err, ErrUnknownEntity)
+
+	sets := registry.List()
+	require.Len(t, sets, len(Entities))
+	for _, set := range sets {
+		if set.Entity == EntityLandscape {
+			assert.Contains(t, set.Projects, "cis20")
+		} else {
+			assert.Empty(t, set.Projects)
+		}
+	}
+}
+
+func TestNewRegistryRejectsMisplacedSchemas(t *testing.T) {
+	valid := []byte(`{"type":"object"}`)
+	schemas := fstest.MapFS{}
+	for _, entity := range Entities {
+		schemas[string(entity)+".json"] = &fstest.MapFile{Data: valid}
+	}
+	_, err := newRegistry(schemas)
+	require.NoError(t, err)
+
+	schemas["teams.json"] = &fstest.MapFile{Data: valid}
+	_, err = newRegistry(schemas)
+	assert.ErrorContains(t, err, "not named after an entity type")
+	delete(schemas, "teams.json")
+
+	schemas["cis20/landscape.json"] = &fstest.MapFile{Data: valid}
+	_, err = newRegistry(schemas)
+	assert.ErrorContains(t, err, "projects/<project>/<entity>.json")
+	delete(schemas, "cis20/landscape.json")
+
+	delete(schemas, "user.json")
+	_, err = newRegistry(schemas)
+	assert.ErrorContains(t, err, "user.json is missing")
+}
+
+// TestInitialDataIsValid checks the metadata in the YAML files of scripts/load_initial_data.go
+func TestInitialDataIsValid(t *testing.T) {
+	registry := newTestRegistry(t)
+	files := map[string]Entity{
+		"teams.yaml":      EntityTeam,
+		"users.yaml":      EntityUser,
+		"projects.yaml":   EntityProject,
+		"components.yaml": EntityComponent,
+		"landscapes.yaml": EntityLandscape,
+	}
+	for file, entity := range files {
+		data, err := os.ReadFile(filepath.Join("..", "..", "scripts", "data", file))
+		require.NoError(t, err)
+		var content map[string][]struct {
+			Name     string                 `yaml:"name"`
+			Project  string                 `yaml:"project"`
+			Metadata map[string]interface{} `yaml:"metadata"`
+		}
+		require.NoError(t, yaml.Unmarshal(data, &content), file)
+		for _, items := range content {
+			for _, item := range items {
+				if item.Metadata == nil {
+					continue
+				}
+				metadata, err := json.Marshal(item.Metadata)
+				require.NoError(t, err)
+				assert.NoError(t, registry.Validate(entity, item.Project, metadata), "%s: %s", file, item.Name)
+			}
+		}
+	}
+}
@@ -11,7 +11,9 @@ package mocks
 
 import (
 	models "developer-portal-backend/internal/database/models"
+	repository "developer-portal-backend/internal/repository"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
@@ -272,18 +274,32 @@ func (mr *MockUserRepositoryInterfaceMockRecorder) Create(member any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserRepositoryInterface)(nil).Create), member)
 }
 
+// CreateBatch mocks base method.
+func (m *MockUserRepositoryInterface) CreateBatch(members []*models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", members)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockUserRepositoryInterfaceMockRecorder) CreateBatch(members any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockUserRepositoryInterface)(nil).CreateBatch), members)
+}
+
 // Delete mocks base method.
-func (m *MockUserRepositoryInterface) Delete(id uuid.UUID) error {
+func (m *MockUserRepositoryInterface) Delete(id uuid.UUID, deletedBy string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", id, deletedBy)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockUserRepositoryInterfaceMockRecorder) Delete(id any) *gomock.Call {
+func (mr *MockUserRepositoryInterfaceMockRecorder) Delete(id, deletedBy any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepositoryInterface)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserRepositoryInterface)(nil).Delete), id, deletedBy)
 }
 
 // GetActiveByOrganization mocks base method.
@@ -394,6 +410,21 @@ func (mr *MockUserRepositoryInterfaceMockRecorder) GetByUserID(userID any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockUserRepositoryInterface)(nil).GetByUserID), userID)
 }
 
+// GetDeletedByUserID mocks base method.
+func (m *MockUserRepositoryInterface) GetDeletedByUserID(userID string) (*models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedByUserID", userID)
+	ret0, _ := ret[0].(*models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedByUserID indicates an expected call of GetDeletedByUserID.
+func (mr *MockUserRepositoryInterfaceMockRecorder) GetDeletedByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedByUserID", reflect.TypeOf((*MockUserRepositoryInterface)(nil).GetDeletedByUserID), userID)
+}
+
 // GetExistingUserIDs mocks base method.
 func (m *MockUserRepositoryInterface) GetExistingUserIDs(ids []string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -409,6 +440,36 @@ func (mr *MockUserRepositoryInterfaceMockRecorder) GetExistingUserIDs(ids any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExistingUserIDs", reflect.TypeOf((*MockUserRepositoryInterface)(nil).GetExistingUserIDs), ids)
 }
 
+// GetMemberships mocks base method.
+func (m *MockUserRepositoryInterface) GetMemberships(memberID uuid.UUID) ([]models.TeamMembership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberships", memberID)
+	ret0, _ := ret[0].([]models.TeamMembership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberships indicates an expected call of GetMemberships.
+func (mr *MockUserRepositoryInterfaceMockRecorder) GetMemberships(memberID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberships", reflect.TypeOf((*MockUserRepositoryInterface)(nil).GetMemberships), memberID)
+}
+
+// GetTeamMemberships mocks base method.
+func (m *MockUserRepositoryInterface) GetTeamMemberships(teamID uuid.UUID, from, to time.Time) ([]models.TeamMembership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamMemberships", teamID, from, to)
+	ret0, _ := ret[0].([]models.TeamMembership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamMemberships indicates an expected call of GetTeamMemberships.
+func (mr *MockUserRepositoryInterfaceMockRecorder) GetTeamMemberships(teamID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamMemberships", reflect.TypeOf((*MockUserRepositoryInterface)(nil).GetTeamMemberships), teamID, from, to)
+}
+
 // GetUserIDsByPrefix mocks base method.
 func (m *MockUserRepositoryInterface) GetUserIDsByPrefix(prefix string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -439,36 +500,36 @@ func (mr *MockUserRepositoryInterfaceMockRecorder) GetWithOrganization(id any) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWithOrganization", reflect.TypeOf((*MockUserRepositoryInterface)(nil).GetWithOrganization), id)
 }
 
-	// SearchByOrganization mocks base method.
-func (m *MockUserRepositoryInterface) SearchByOrganization(orgID uuid.UUID, query string, limit, offset int) ([]models.User, int64, error) {
+// SearchByNameOrTitleGlobal mocks base method.
+func (m *MockUserRepositoryInterface) SearchByNameOrTitleGlobal(query string, limit, offset int) ([]models.User, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchByOrganization", orgID, query, limit, offset)
+	ret := m.ctrl.Call(m, "SearchByNameOrTitleGlobal", query, limit, offset)
 	ret0, _ := ret[0].([]models.User)
 	ret1, _ := ret[1].(int64)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// SearchByOrganization indicates an expected call of SearchByOrganization.
-func (mr *MockUserRepositoryInterfaceMockRecorder) SearchByOrganization(orgID, query, limit, offset any) *gomock.Call {
+// SearchByNameOrTitleGlobal indicates an expected call of SearchByNameOrTitleGlobal.
+func (mr *MockUserRepositoryInterfaceMockRecorder) SearchByNameOrTitleGlobal(query, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByOrganization", reflect.TypeOf((*MockUserRepositoryInterface)(nil).SearchByOrganization), orgID, query, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByNameOrTitleGlobal", reflect.TypeOf((*MockUserRepositoryInterface)(nil).SearchByNameOrTitleGlobal), query, limit, offset)
 }
 
-// SearchByNameOrTitleGlobal mocks base method.
-func (m *MockUserRepositoryInterface) SearchByNameOrTitleGlobal(query string, limit, offset int) ([]models.User, int64, error) {
+// SearchByOrganization mocks base method.
+func (m *MockUserRepositoryInterface) SearchByOrganization(orgID uuid.UUID, query string, limit, offset int) ([]models.User, int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SearchByNameOrTitleGlobal", query, limit, offset)
+	ret := m.ctrl.Call(m, "SearchByOrganization", orgID, query, limit, offset)
 	ret0, _ := ret[0].([]models.User)
 	ret1, _ := ret[1].(int64)
 	ret2, _ := ret[2].(error)
 	return ret0, ret1, ret2
 }
 
-// SearchByNameOrTitleGlobal indicates an expected call of SearchByNameOrTitleGlobal.
-func (mr *MockUserRepositoryInterfaceMockRecorder) SearchByNameOrTitleGlobal(query, limit, offset any) *gomock.Call {
+// SearchByOrganization indicates an expected call of SearchByOrganization.
+func (mr *MockUserRepositoryInterfaceMockRecorder) SearchByOrganization(orgID, query, limit, offset any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByNameOrTitleGlobal", reflect.TypeOf((*MockUserRepositoryInterface)(nil).SearchByNameOrTitleGlobal), query, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchByOrganization", reflect.TypeOf((*MockUserRepositoryInterface)(nil).SearchByOrganization), orgID, query, limit, offset)
 }
 
 // Update mocks base method.
@@ -630,17 +691,17 @@ func (mr *MockGroupRepositoryInterfaceMockRecorder) Search(organizationID, query
 }
 
 // Update mocks base method.
-func (m *MockGroupRepositoryInterface) Update(id uuid.UUID, updates map[string]any) error {
+func (m *MockGroupRepositoryInterface) Update(group *models.Group) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", id, updates)
+	ret := m.ctrl.Call(m, "Update", group)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockGroupRepositoryInterfaceMockRecorder) Update(id, updates any) *gomock.Call {
+func (mr *MockGroupRepositoryInterfaceMockRecorder) Update(group any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGroupRepositoryInterface)(nil).Update), id, updates)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockGroupRepositoryInterface)(nil).Update), group)
 }
 
 // MockTeamRepositoryInterface is a mock of TeamRepositoryInterface interface.
@@ -710,6 +771,22 @@ func (mr *MockTeamRepositoryInterfaceMockRecorder) GetAll() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTeamRepositoryInterface)(nil).GetAll))
 }
 
+// GetByGroupID mocks base method.
+func (m *MockTeamRepositoryInterface) GetByGroupID(groupID uuid.UUID, limit, offset int) ([]models.Team, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByGroupID", groupID, limit, offset)
+	ret0, _ := ret[0].([]models.Team)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetByGroupID indicates an expected call of GetByGroupID.
+func (mr *MockTeamRepositoryInterfaceMockRecorder) GetByGroupID(groupID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByGroupID", reflect.TypeOf((*MockTeamRepositoryInterface)(nil).GetByGroupID), groupID, limit, offset)
+}
+
 // GetByID mocks base method.
 func (m *MockTeamRepositoryInterface) GetByID(id uuid.UUID) (*models.Team, error) {
 	m.ctrl.T.Helper()
@@ -852,6 +929,22 @@ func (mr *MockProjectRepositoryInterfaceMockRecorder) Delete(id any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).Delete), id)
 }
 
+// GetAll mocks base method.
+func (m *MockProjectRepositoryInterface) GetAll(limit, offset int) ([]models.Project, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", limit, offset)
+	ret0, _ := ret[0].([]models.Project)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockProjectRepositoryInterfaceMockRecorder) GetAll(limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockProjectRepositoryInterface)(nil).GetAll), limit, offset)
+}
+
 // GetByID mocks base method.
 func (m *MockProjectRepositoryInterface) GetByID(id uuid.UUID) (*models.Project, error) {
 	m.ctrl.T.Helper()
@@ -936,6 +1029,21 @@ func (m *MockComponentRepositoryInterface) EXPECT() *MockComponentRepositoryInte
 	return m.recorder
 }
 
+// CountByOwnerIDs mocks base method.
+func (m *MockComponentRepositoryInterface) CountByOwnerIDs(ownerIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByOwnerIDs", ownerIDs)
+	ret0, _ := ret[0].(map[uuid.UUID]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByOwnerIDs indicates an expected call of CountByOwnerIDs.
+func (mr *MockComponentRepositoryInterfaceMockRecorder) CountByOwnerIDs(ownerIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByOwnerIDs", reflect.TypeOf((*MockComponentRepositoryInterface)(nil).CountByOwnerIDs), ownerIDs)
+}
+
 // Create mocks base method.
 func (m *MockComponentRepositoryInterface) Create(component *models.Component) error {
 	m.ctrl.T.Helper()
@@ -1026,6 +1134,35 @@ func (mr *MockComponentRepositoryInterfaceMockRecorder) GetByProjectID(projectID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProjectID", reflect.TypeOf((*MockComponentRepositoryInterface)(nil).GetByProjectID), projectID, limit, offset)
 }
 
+// GetOwnershipHistory mocks base method.
+func (m *MockComponentRepositoryInterface) GetOwnershipHistory(componentID uuid.UUID) ([]models.ComponentOwnershipChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnershipHistory", componentID)
+	ret0, _ := ret[0].([]models.ComponentOwnershipChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnershipHistory indicates an expected call of GetOwnershipHistory.
+func (mr *MockComponentRepositoryInterfaceMockRecorder) GetOwnershipHistory(componentID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnershipHistory", reflect.TypeOf((*MockComponentRepositoryInterface)(nil).GetOwnershipHistory), componentID)
+}
+
+// TransferOwnership mocks base method.
+func (m *MockComponentRepositoryInterface) TransferOwnership(component *models.Component, change *models.ComponentOwnershipChange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferOwnership", component, change)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TransferOwnership indicates an expected call of TransferOwnership.
+func (mr *MockComponentRepositoryInterfaceMockRecorder) TransferOwnership(component, change any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferOwnership", reflect.TypeOf((*MockComponentRepositoryInterface)(nil).TransferOwnership), component, change)
+}
+
 // Update mocks base method.
 func (m *MockComponentRepositoryInterface) Update(component *models.Component) error {
 	m.ctrl.T.Helper()
@@ -1170,6 +1307,38 @@ func (mr *MockLandscapeRepositoryInterfaceMockRecorder) GetByStatus(status, limi
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByStatus", reflect.TypeOf((*MockLandscapeRepositoryInterface)(nil).GetByStatus), status, limit, offset)
 }
 
+// GetFiltered mocks base method.
+func (m *MockLandscapeRepositoryInterface) GetFiltered(filter repository.LandscapeFilter, limit, offset int) ([]models.Landscape, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFiltered", filter, limit, offset)
+	ret0, _ := ret[0].([]models.Landscape)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFiltered indicates an expected call of GetFiltered.
+func (mr *MockLandscapeRepositoryInterfaceMockRecorder) GetFiltered(filter, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFiltered", reflect.TypeOf((*MockLandscapeRepositoryInterface)(nil).GetFiltered), filter, limit, offset)
+}
+
+// GetLandscapesByProjectID mocks base method.
+func (m *MockLandscapeRepositoryInterface) GetLandscapesByProjectID(projectID uuid.UUID, limit, offset int) ([]models.Landscape, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLandscapesByProjectID", projectID, limit, offset)
+	ret0, _ := ret[0].([]models.Landscape)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLandscapesByProjectID indicates an expected call of GetLandscapesByProjectID.
+func (mr *MockLandscapeRepositoryInterfaceMockRecorder) GetLandscapesByProjectID(projectID, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLandscapesByProjectID", reflect.TypeOf((*MockLandscapeRepositoryInterface)(nil).GetLandscapesByProjectID), projectID, limit, offset)
+}
+
 // Update mocks base method.
 func (m *MockLandscapeRepositoryInterface) Update(landscape *models.Landscape) error {
 	m.ctrl.T.Helper()
@@ -1277,6 +1446,50 @@ func (mr *MockLinkRepositoryInterfaceMockRecorder) Create(link any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLinkRepositoryInterface)(nil).Create), link)
 }
 
+// Delete mocks base method.
+func (m *MockLinkRepositoryInterface) Delete(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockLinkRepositoryInterfaceMockRecorder) Delete(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLinkRepositoryInterface)(nil).Delete), id)
+}
+
+// GetAll mocks base method.
+func (m *MockLinkRepositoryInterface) GetAll() ([]models.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]models.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockLinkRepositoryInterfaceMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockLinkRepositoryInterface)(nil).GetAll))
+}
+
+// GetBroken mocks base method.
+func (m *MockLinkRepositoryInterface) GetBroken() ([]models.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBroken")
+	ret0, _ := ret[0].([]models.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBroken indicates an expected call of GetBroken.
+func (mr *MockLinkRepositoryInterfaceMockRecorder) GetBroken() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBroken", reflect.TypeOf((*MockLinkRepositoryInterface)(nil).GetBroken))
+}
+
 // GetByIDs mocks base method.
 func (m *MockLinkRepositoryInterface) GetByIDs(ids []uuid.UUID) ([]models.Link, error) {
 	m.ctrl.T.Helper()
@@ -1301,14 +1514,66 @@ func (m *MockLinkRepositoryInterface) GetByOwner(owner uuid.UUID) ([]models.Link
 	return ret0, ret1
 }
 
- // GetByOwner indicates an expected call of GetByOwner.
+// GetByOwner indicates an expected call of GetByOwner.
 func (mr *MockLinkRepositoryInterfaceMockRecorder) GetByOwner(owner any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByOwner", reflect.TypeOf((*MockLinkRepositoryInterface)(nil).GetByOwner), owner)
 }
 
+// UpdateCheck mocks base method.
+func (m *MockLinkRepositoryInterface) UpdateCheck(link *models.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCheck", link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCheck indicates an expected call of UpdateCheck.
+func (mr *MockLinkRepositoryInterfaceMockRecorder) UpdateCheck(link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCheck", reflect.TypeOf((*MockLinkRepositoryInterface)(nil).UpdateCheck), link)
+}
+
+// MockDocumentationRepositoryInterface is a mock of DocumentationRepositoryInterface interface.
+type MockDocumentationRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockDocumentationRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockDocumentationRepositoryInterfaceMockRecorder is the mock recorder for MockDocumentationRepositoryInterface.
+type MockDocumentationRepositoryInterfaceMockRecorder struct {
+	mock *MockDocumentationRepositoryInterface
+}
+
+// NewMockDocumentationRepositoryInterface creates a new mock instance.
+func NewMockDocumentationRepositoryInterface(ctrl *gomock.Controller) *MockDocumentationRepositoryInterface {
+	mock := &MockDocumentationRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockDocumentationRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDocumentationRepositoryInterface) EXPECT() *MockDocumentationRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockDocumentationRepositoryInterface) Create(doc *models.Documentation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", doc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockDocumentationRepositoryInterfaceMockRecorder) Create(doc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockDocumentationRepositoryInterface)(nil).Create), doc)
+}
+
 // Delete mocks base method.
-func (m *MockLinkRepositoryInterface) Delete(id uuid.UUID) error {
+func (m *MockDocumentationRepositoryInterface) Delete(id uuid.UUID) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Delete", id)
 	ret0, _ := ret[0].(error)
@@ -1316,7 +1581,966 @@ func (m *MockLinkRepositoryInterface) Delete(id uuid.UUID) error {
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockLinkRepositoryInterfaceMockRecorder) Delete(id any) *gomock.Call {
+func (mr *MockDocumentationRepositoryInterfaceMockRecorder) Delete(id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLinkRepositoryInterface)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDocumentationRepositoryInterface)(nil).Delete), id)
+}
+
+// GetAll mocks base method.
+func (m *MockDocumentationRepositoryInterface) GetAll(limit, offset int) ([]models.Documentation, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", limit, offset)
+	ret0, _ := ret[0].([]models.Documentation)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockDocumentationRepositoryInterfaceMockRecorder) GetAll(limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockDocumentationRepositoryInterface)(nil).GetAll), limit, offset)
+}
+
+// GetByID mocks base method.
+func (m *MockDocumentationRepositoryInterface) GetByID(id uuid.UUID) (*models.Documentation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*models.Documentation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockDocumentationRepositoryInterfaceMockRecorder) GetByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockDocumentationRepositoryInterface)(nil).GetByID), id)
+}
+
+// GetByTeamID mocks base method.
+func (m *MockDocumentationRepositoryInterface) GetByTeamID(teamID uuid.UUID) ([]models.Documentation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTeamID", teamID)
+	ret0, _ := ret[0].([]models.Documentation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTeamID indicates an expected call of GetByTeamID.
+func (mr *MockDocumentationRepositoryInterfaceMockRecorder) GetByTeamID(teamID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTeamID", reflect.TypeOf((*MockDocumentationRepositoryInterface)(nil).GetByTeamID), teamID)
+}
+
+// Update mocks base method.
+func (m *MockDocumentationRepositoryInterface) Update(doc *models.Documentation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", doc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockDocumentationRepositoryInterfaceMockRecorder) Update(doc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDocumentationRepositoryInterface)(nil).Update), doc)
+}
+
+// MockRefreshTokenRepositoryInterface is a mock of RefreshTokenRepositoryInterface interface.
+type MockRefreshTokenRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRefreshTokenRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockRefreshTokenRepositoryInterfaceMockRecorder is the mock recorder for MockRefreshTokenRepositoryInterface.
+type MockRefreshTokenRepositoryInterfaceMockRecorder struct {
+	mock *MockRefreshTokenRepositoryInterface
+}
+
+// NewMockRefreshTokenRepositoryInterface creates a new mock instance.
+func NewMockRefreshTokenRepositoryInterface(ctrl *gomock.Controller) *MockRefreshTokenRepositoryInterface {
+	mock := &MockRefreshTokenRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockRefreshTokenRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRefreshTokenRepositoryInterface) EXPECT() *MockRefreshTokenRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockRefreshTokenRepositoryInterface) Create(token *models.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRefreshTokenRepositoryInterfaceMockRecorder) Create(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRefreshTokenRepositoryInterface)(nil).Create), token)
+}
+
+// DeleteByEmail mocks base method.
+func (m *MockRefreshTokenRepositoryInterface) DeleteByEmail(email string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByEmail", email)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByEmail indicates an expected call of DeleteByEmail.
+func (mr *MockRefreshTokenRepositoryInterfaceMockRecorder) DeleteByEmail(email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByEmail", reflect.TypeOf((*MockRefreshTokenRepositoryInterface)(nil).DeleteByEmail), email)
+}
+
+// DeleteByTokenHash mocks base method.
+func (m *MockRefreshTokenRepositoryInterface) DeleteByTokenHash(tokenHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByTokenHash", tokenHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByTokenHash indicates an expected call of DeleteByTokenHash.
+func (mr *MockRefreshTokenRepositoryInterfaceMockRecorder) DeleteByTokenHash(tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByTokenHash", reflect.TypeOf((*MockRefreshTokenRepositoryInterface)(nil).DeleteByTokenHash), tokenHash)
+}
+
+// DeleteByUser mocks base method.
+func (m *MockRefreshTokenRepositoryInterface) DeleteByUser(userID int64, provider string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUser", userID, provider)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByUser indicates an expected call of DeleteByUser.
+func (mr *MockRefreshTokenRepositoryInterfaceMockRecorder) DeleteByUser(userID, provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUser", reflect.TypeOf((*MockRefreshTokenRepositoryInterface)(nil).DeleteByUser), userID, provider)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockRefreshTokenRepositoryInterface) DeleteExpired(before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockRefreshTokenRepositoryInterfaceMockRecorder) DeleteExpired(before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockRefreshTokenRepositoryInterface)(nil).DeleteExpired), before)
+}
+
+// GetActiveWithoutAccessTokenPrefix mocks base method.
+func (m *MockRefreshTokenRepositoryInterface) GetActiveWithoutAccessTokenPrefix(prefix string, now time.Time, limit int) ([]models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveWithoutAccessTokenPrefix", prefix, now, limit)
+	ret0, _ := ret[0].([]models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveWithoutAccessTokenPrefix indicates an expected call of GetActiveWithoutAccessTokenPrefix.
+func (mr *MockRefreshTokenRepositoryInterfaceMockRecorder) GetActiveWithoutAccessTokenPrefix(prefix, now, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveWithoutAccessTokenPrefix", reflect.TypeOf((*MockRefreshTokenRepositoryInterface)(nil).GetActiveWithoutAccessTokenPrefix), prefix, now, limit)
+}
+
+// GetByTokenHash mocks base method.
+func (m *MockRefreshTokenRepositoryInterface) GetByTokenHash(tokenHash string) (*models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTokenHash", tokenHash)
+	ret0, _ := ret[0].(*models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTokenHash indicates an expected call of GetByTokenHash.
+func (mr *MockRefreshTokenRepositoryInterfaceMockRecorder) GetByTokenHash(tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTokenHash", reflect.TypeOf((*MockRefreshTokenRepositoryInterface)(nil).GetByTokenHash), tokenHash)
+}
+
+// GetLatestActiveByUser mocks base method.
+func (m *MockRefreshTokenRepositoryInterface) GetLatestActiveByUser(userID int64, provider string, now time.Time) (*models.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestActiveByUser", userID, provider, now)
+	ret0, _ := ret[0].(*models.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestActiveByUser indicates an expected call of GetLatestActiveByUser.
+func (mr *MockRefreshTokenRepositoryInterfaceMockRecorder) GetLatestActiveByUser(userID, provider, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestActiveByUser", reflect.TypeOf((*MockRefreshTokenRepositoryInterface)(nil).GetLatestActiveByUser), userID, provider, now)
+}
+
+// Rotate mocks base method.
+func (m *MockRefreshTokenRepositoryInterface) Rotate(oldTokenHash string, newToken *models.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rotate", oldTokenHash, newToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rotate indicates an expected call of Rotate.
+func (mr *MockRefreshTokenRepositoryInterfaceMockRecorder) Rotate(oldTokenHash, newToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rotate", reflect.TypeOf((*MockRefreshTokenRepositoryInterface)(nil).Rotate), oldTokenHash, newToken)
+}
+
+// UpdateAccessToken mocks base method.
+func (m *MockRefreshTokenRepositoryInterface) UpdateAccessToken(tokenHash, accessToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccessToken", tokenHash, accessToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAccessToken indicates an expected call of UpdateAccessToken.
+func (mr *MockRefreshTokenRepositoryInterfaceMockRecorder) UpdateAccessToken(tokenHash, accessToken any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccessToken", reflect.TypeOf((*MockRefreshTokenRepositoryInterface)(nil).UpdateAccessToken), tokenHash, accessToken)
+}
+
+// MockTokenRevocationRepositoryInterface is a mock of TokenRevocationRepositoryInterface interface.
+type MockTokenRevocationRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTokenRevocationRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockTokenRevocationRepositoryInterfaceMockRecorder is the mock recorder for MockTokenRevocationRepositoryInterface.
+type MockTokenRevocationRepositoryInterfaceMockRecorder struct {
+	mock *MockTokenRevocationRepositoryInterface
+}
+
+// NewMockTokenRevocationRepositoryInterface creates a new mock instance.
+func NewMockTokenRevocationRepositoryInterface(ctrl *gomock.Controller) *MockTokenRevocationRepositoryInterface {
+	mock := &MockTokenRevocationRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockTokenRevocationRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTokenRevocationRepositoryInterface) EXPECT() *MockTokenRevocationRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTokenRevocationRepositoryInterface) Create(revocation *models.TokenRevocation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", revocation)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTokenRevocationRepositoryInterfaceMockRecorder) Create(revocation any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTokenRevocationRepositoryInterface)(nil).Create), revocation)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockTokenRevocationRepositoryInterface) DeleteExpired(before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockTokenRevocationRepositoryInterfaceMockRecorder) DeleteExpired(before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockTokenRevocationRepositoryInterface)(nil).DeleteExpired), before)
+}
+
+// GetActiveSince mocks base method.
+func (m *MockTokenRevocationRepositoryInterface) GetActiveSince(since, now time.Time) ([]models.TokenRevocation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveSince", since, now)
+	ret0, _ := ret[0].([]models.TokenRevocation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveSince indicates an expected call of GetActiveSince.
+func (mr *MockTokenRevocationRepositoryInterfaceMockRecorder) GetActiveSince(since, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveSince", reflect.TypeOf((*MockTokenRevocationRepositoryInterface)(nil).GetActiveSince), since, now)
+}
+
+// MockOAuthStateRepositoryInterface is a mock of OAuthStateRepositoryInterface interface.
+type MockOAuthStateRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockOAuthStateRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockOAuthStateRepositoryInterfaceMockRecorder is the mock recorder for MockOAuthStateRepositoryInterface.
+type MockOAuthStateRepositoryInterfaceMockRecorder struct {
+	mock *MockOAuthStateRepositoryInterface
+}
+
+// NewMockOAuthStateRepositoryInterface creates a new mock instance.
+func NewMockOAuthStateRepositoryInterface(ctrl *gomock.Controller) *MockOAuthStateRepositoryInterface {
+	mock := &MockOAuthStateRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockOAuthStateRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOAuthStateRepositoryInterface) EXPECT() *MockOAuthStateRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Consume mocks base method.
+func (m *MockOAuthStateRepositoryInterface) Consume(stateHash string) (*models.OAuthState, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", stateHash)
+	ret0, _ := ret[0].(*models.OAuthState)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockOAuthStateRepositoryInterfaceMockRecorder) Consume(stateHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockOAuthStateRepositoryInterface)(nil).Consume), stateHash)
+}
+
+// Create mocks base method.
+func (m *MockOAuthStateRepositoryInterface) Create(state *models.OAuthState) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", state)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOAuthStateRepositoryInterfaceMockRecorder) Create(state any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOAuthStateRepositoryInterface)(nil).Create), state)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockOAuthStateRepositoryInterface) DeleteExpired(before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockOAuthStateRepositoryInterfaceMockRecorder) DeleteExpired(before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockOAuthStateRepositoryInterface)(nil).DeleteExpired), before)
+}
+
+// MockAPITokenRepositoryInterface is a mock of APITokenRepositoryInterface interface.
+type MockAPITokenRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPITokenRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockAPITokenRepositoryInterfaceMockRecorder is the mock recorder for MockAPITokenRepositoryInterface.
+type MockAPITokenRepositoryInterfaceMockRecorder struct {
+	mock *MockAPITokenRepositoryInterface
+}
+
+// NewMockAPITokenRepositoryInterface creates a new mock instance.
+func NewMockAPITokenRepositoryInterface(ctrl *gomock.Controller) *MockAPITokenRepositoryInterface {
+	mock := &MockAPITokenRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockAPITokenRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPITokenRepositoryInterface) EXPECT() *MockAPITokenRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAPITokenRepositoryInterface) Create(token *models.APIToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAPITokenRepositoryInterfaceMockRecorder) Create(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAPITokenRepositoryInterface)(nil).Create), token)
+}
+
+// Delete mocks base method.
+func (m *MockAPITokenRepositoryInterface) Delete(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAPITokenRepositoryInterfaceMockRecorder) Delete(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAPITokenRepositoryInterface)(nil).Delete), id)
+}
+
+// DeletePersonalByEmail mocks base method.
+func (m *MockAPITokenRepositoryInterface) DeletePersonalByEmail(email string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePersonalByEmail", email)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePersonalByEmail indicates an expected call of DeletePersonalByEmail.
+func (mr *MockAPITokenRepositoryInterfaceMockRecorder) DeletePersonalByEmail(email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePersonalByEmail", reflect.TypeOf((*MockAPITokenRepositoryInterface)(nil).DeletePersonalByEmail), email)
+}
+
+// GetByID mocks base method.
+func (m *MockAPITokenRepositoryInterface) GetByID(id uuid.UUID) (*models.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(*models.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockAPITokenRepositoryInterfaceMockRecorder) GetByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAPITokenRepositoryInterface)(nil).GetByID), id)
+}
+
+// GetByServiceAccount mocks base method.
+func (m *MockAPITokenRepositoryInterface) GetByServiceAccount(name string) ([]models.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByServiceAccount", name)
+	ret0, _ := ret[0].([]models.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByServiceAccount indicates an expected call of GetByServiceAccount.
+func (mr *MockAPITokenRepositoryInterfaceMockRecorder) GetByServiceAccount(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByServiceAccount", reflect.TypeOf((*MockAPITokenRepositoryInterface)(nil).GetByServiceAccount), name)
+}
+
+// GetByTokenHash mocks base method.
+func (m *MockAPITokenRepositoryInterface) GetByTokenHash(tokenHash string) (*models.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByTokenHash", tokenHash)
+	ret0, _ := ret[0].(*models.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByTokenHash indicates an expected call of GetByTokenHash.
+func (mr *MockAPITokenRepositoryInterfaceMockRecorder) GetByTokenHash(tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByTokenHash", reflect.TypeOf((*MockAPITokenRepositoryInterface)(nil).GetByTokenHash), tokenHash)
+}
+
+// GetPersonalByUser mocks base method.
+func (m *MockAPITokenRepositoryInterface) GetPersonalByUser(userID int64, provider string) ([]models.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPersonalByUser", userID, provider)
+	ret0, _ := ret[0].([]models.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPersonalByUser indicates an expected call of GetPersonalByUser.
+func (mr *MockAPITokenRepositoryInterfaceMockRecorder) GetPersonalByUser(userID, provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPersonalByUser", reflect.TypeOf((*MockAPITokenRepositoryInterface)(nil).GetPersonalByUser), userID, provider)
+}
+
+// UpdateLastUsed mocks base method.
+func (m *MockAPITokenRepositoryInterface) UpdateLastUsed(id uuid.UUID, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastUsed", id, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastUsed indicates an expected call of UpdateLastUsed.
+func (mr *MockAPITokenRepositoryInterfaceMockRecorder) UpdateLastUsed(id, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastUsed", reflect.TypeOf((*MockAPITokenRepositoryInterface)(nil).UpdateLastUsed), id, at)
+}
+
+// MockSigningKeyRepositoryInterface is a mock of SigningKeyRepositoryInterface interface.
+type MockSigningKeyRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSigningKeyRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockSigningKeyRepositoryInterfaceMockRecorder is the mock recorder for MockSigningKeyRepositoryInterface.
+type MockSigningKeyRepositoryInterfaceMockRecorder struct {
+	mock *MockSigningKeyRepositoryInterface
+}
+
+// NewMockSigningKeyRepositoryInterface creates a new mock instance.
+func NewMockSigningKeyRepositoryInterface(ctrl *gomock.Controller) *MockSigningKeyRepositoryInterface {
+	mock := &MockSigningKeyRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockSigningKeyRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSigningKeyRepositoryInterface) EXPECT() *MockSigningKeyRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSigningKeyRepositoryInterface) Create(key *models.SigningKey) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSigningKeyRepositoryInterfaceMockRecorder) Create(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSigningKeyRepositoryInterface)(nil).Create), key)
+}
+
+// DeleteExpired mocks base method.
+func (m *MockSigningKeyRepositoryInterface) DeleteExpired(before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpired", before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpired indicates an expected call of DeleteExpired.
+func (mr *MockSigningKeyRepositoryInterfaceMockRecorder) DeleteExpired(before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpired", reflect.TypeOf((*MockSigningKeyRepositoryInterface)(nil).DeleteExpired), before)
+}
+
+// GetActive mocks base method.
+func (m *MockSigningKeyRepositoryInterface) GetActive(now time.Time) ([]models.SigningKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActive", now)
+	ret0, _ := ret[0].([]models.SigningKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActive indicates an expected call of GetActive.
+func (mr *MockSigningKeyRepositoryInterfaceMockRecorder) GetActive(now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActive", reflect.TypeOf((*MockSigningKeyRepositoryInterface)(nil).GetActive), now)
+}
+
+// MockServiceAccountRepositoryInterface is a mock of ServiceAccountRepositoryInterface interface.
+type MockServiceAccountRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockServiceAccountRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockServiceAccountRepositoryInterfaceMockRecorder is the mock recorder for MockServiceAccountRepositoryInterface.
+type MockServiceAccountRepositoryInterfaceMockRecorder struct {
+	mock *MockServiceAccountRepositoryInterface
+}
+
+// NewMockServiceAccountRepositoryInterface creates a new mock instance.
+func NewMockServiceAccountRepositoryInterface(ctrl *gomock.Controller) *MockServiceAccountRepositoryInterface {
+	mock := &MockServiceAccountRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockServiceAccountRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockServiceAccountRepositoryInterface) EXPECT() *MockServiceAccountRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockServiceAccountRepositoryInterface) Create(account *models.ServiceAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", account)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockServiceAccountRepositoryInterfaceMockRecorder) Create(account any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockServiceAccountRepositoryInterface)(nil).Create), account)
+}
+
+// Delete mocks base method.
+func (m *MockServiceAccountRepositoryInterface) Delete(name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockServiceAccountRepositoryInterfaceMockRecorder) Delete(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockServiceAccountRepositoryInterface)(nil).Delete), name)
+}
+
+// GetAll mocks base method.
+func (m *MockServiceAccountRepositoryInterface) GetAll() ([]models.ServiceAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll")
+	ret0, _ := ret[0].([]models.ServiceAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockServiceAccountRepositoryInterfaceMockRecorder) GetAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockServiceAccountRepositoryInterface)(nil).GetAll))
+}
+
+// GetByName mocks base method.
+func (m *MockServiceAccountRepositoryInterface) GetByName(name string) (*models.ServiceAccount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByName", name)
+	ret0, _ := ret[0].(*models.ServiceAccount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByName indicates an expected call of GetByName.
+func (mr *MockServiceAccountRepositoryInterfaceMockRecorder) GetByName(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByName", reflect.TypeOf((*MockServiceAccountRepositoryInterface)(nil).GetByName), name)
+}
+
+// MockUserIdentityRepositoryInterface is a mock of UserIdentityRepositoryInterface interface.
+type MockUserIdentityRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserIdentityRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockUserIdentityRepositoryInterfaceMockRecorder is the mock recorder for MockUserIdentityRepositoryInterface.
+type MockUserIdentityRepositoryInterfaceMockRecorder struct {
+	mock *MockUserIdentityRepositoryInterface
+}
+
+// NewMockUserIdentityRepositoryInterface creates a new mock instance.
+func NewMockUserIdentityRepositoryInterface(ctrl *gomock.Controller) *MockUserIdentityRepositoryInterface {
+	mock := &MockUserIdentityRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockUserIdentityRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserIdentityRepositoryInterface) EXPECT() *MockUserIdentityRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockUserIdentityRepositoryInterface) Delete(memberID, provider string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", memberID, provider)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUserIdentityRepositoryInterfaceMockRecorder) Delete(memberID, provider any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUserIdentityRepositoryInterface)(nil).Delete), memberID, provider)
+}
+
+// GetByMemberID mocks base method.
+func (m *MockUserIdentityRepositoryInterface) GetByMemberID(memberID string) ([]models.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByMemberID", memberID)
+	ret0, _ := ret[0].([]models.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByMemberID indicates an expected call of GetByMemberID.
+func (mr *MockUserIdentityRepositoryInterfaceMockRecorder) GetByMemberID(memberID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByMemberID", reflect.TypeOf((*MockUserIdentityRepositoryInterface)(nil).GetByMemberID), memberID)
+}
+
+// GetByProviderAccount mocks base method.
+func (m *MockUserIdentityRepositoryInterface) GetByProviderAccount(provider string, providerUserID int64) (*models.UserIdentity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProviderAccount", provider, providerUserID)
+	ret0, _ := ret[0].(*models.UserIdentity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProviderAccount indicates an expected call of GetByProviderAccount.
+func (mr *MockUserIdentityRepositoryInterfaceMockRecorder) GetByProviderAccount(provider, providerUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProviderAccount", reflect.TypeOf((*MockUserIdentityRepositoryInterface)(nil).GetByProviderAccount), provider, providerUserID)
+}
+
+// Upsert mocks base method.
+func (m *MockUserIdentityRepositoryInterface) Upsert(identity *models.UserIdentity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", identity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockUserIdentityRepositoryInterfaceMockRecorder) Upsert(identity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockUserIdentityRepositoryInterface)(nil).Upsert), identity)
+}
+
+// MockImpersonationAuditLogRepositoryInterface is a mock of ImpersonationAuditLogRepositoryInterface interface.
+type MockImpersonationAuditLogRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockImpersonationAuditLogRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockImpersonationAuditLogRepositoryInterfaceMockRecorder is the mock recorder for MockImpersonationAuditLogRepositoryInterface.
+type MockImpersonationAuditLogRepositoryInterfaceMockRecorder struct {
+	mock *MockImpersonationAuditLogRepositoryInterface
+}
+
+// NewMockImpersonationAuditLogRepositoryInterface creates a new mock instance.
+func NewMockImpersonationAuditLogRepositoryInterface(ctrl *gomock.Controller) *MockImpersonationAuditLogRepositoryInterface {
+	mock := &MockImpersonationAuditLogRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockImpersonationAuditLogRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImpersonationAuditLogRepositoryInterface) EXPECT() *MockImpersonationAuditLogRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockImpersonationAuditLogRepositoryInterface) Create(entry *models.ImpersonationAuditLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockImpersonationAuditLogRepositoryInterfaceMockRecorder) Create(entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockImpersonationAuditLogRepositoryInterface)(nil).Create), entry)
+}
+
+// GetFiltered mocks base method.
+func (m *MockImpersonationAuditLogRepositoryInterface) GetFiltered(admin, email, sessionID string, since time.Time, limit int) ([]models.ImpersonationAuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFiltered", admin, email, sessionID, since, limit)
+	ret0, _ := ret[0].([]models.ImpersonationAuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFiltered indicates an expected call of GetFiltered.
+func (mr *MockImpersonationAuditLogRepositoryInterfaceMockRecorder) GetFiltered(admin, email, sessionID, since, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFiltered", reflect.TypeOf((*MockImpersonationAuditLogRepositoryInterface)(nil).GetFiltered), admin, email, sessionID, since, limit)
+}
+
+// MockTrashRepositoryInterface is a mock of TrashRepositoryInterface interface.
+type MockTrashRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockTrashRepositoryInterfaceMockRecorder is the mock recorder for MockTrashRepositoryInterface.
+type MockTrashRepositoryInterfaceMockRecorder struct {
+	mock *MockTrashRepositoryInterface
+}
+
+// NewMockTrashRepositoryInterface creates a new mock instance.
+func NewMockTrashRepositoryInterface(ctrl *gomock.Controller) *MockTrashRepositoryInterface {
+	mock := &MockTrashRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockTrashRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrashRepositoryInterface) EXPECT() *MockTrashRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetDeleted mocks base method.
+func (m *MockTrashRepositoryInterface) GetDeleted(kind repository.TrashKind, id uuid.UUID) (*repository.TrashItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeleted", kind, id)
+	ret0, _ := ret[0].(*repository.TrashItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeleted indicates an expected call of GetDeleted.
+func (mr *MockTrashRepositoryInterfaceMockRecorder) GetDeleted(kind, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeleted", reflect.TypeOf((*MockTrashRepositoryInterface)(nil).GetDeleted), kind, id)
+}
+
+// IsActive mocks base method.
+func (m *MockTrashRepositoryInterface) IsActive(kind repository.TrashKind, id uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsActive", kind, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsActive indicates an expected call of IsActive.
+func (mr *MockTrashRepositoryInterfaceMockRecorder) IsActive(kind, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsActive", reflect.TypeOf((*MockTrashRepositoryInterface)(nil).IsActive), kind, id)
+}
+
+// ListDeleted mocks base method.
+func (m *MockTrashRepositoryInterface) ListDeleted(kind repository.TrashKind, limit, offset int) ([]repository.TrashItem, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDeleted", kind, limit, offset)
+	ret0, _ := ret[0].([]repository.TrashItem)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListDeleted indicates an expected call of ListDeleted.
+func (mr *MockTrashRepositoryInterfaceMockRecorder) ListDeleted(kind, limit, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDeleted", reflect.TypeOf((*MockTrashRepositoryInterface)(nil).ListDeleted), kind, limit, offset)
+}
+
+// PurgeDeletedBefore mocks base method.
+func (m *MockTrashRepositoryInterface) PurgeDeletedBefore(cutoff time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedBefore", cutoff)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedBefore indicates an expected call of PurgeDeletedBefore.
+func (mr *MockTrashRepositoryInterfaceMockRecorder) PurgeDeletedBefore(cutoff any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedBefore", reflect.TypeOf((*MockTrashRepositoryInterface)(nil).PurgeDeletedBefore), cutoff)
+}
+
+// Restore mocks base method.
+func (m *MockTrashRepositoryInterface) Restore(kind repository.TrashKind, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", kind, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockTrashRepositoryInterfaceMockRecorder) Restore(kind, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTrashRepositoryInterface)(nil).Restore), kind, id)
+}
+
+// MockUserOffboardingRepositoryInterface is a mock of UserOffboardingRepositoryInterface interface.
+type MockUserOffboardingRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserOffboardingRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockUserOffboardingRepositoryInterfaceMockRecorder is the mock recorder for MockUserOffboardingRepositoryInterface.
+type MockUserOffboardingRepositoryInterfaceMockRecorder struct {
+	mock *MockUserOffboardingRepositoryInterface
+}
+
+// NewMockUserOffboardingRepositoryInterface creates a new mock instance.
+func NewMockUserOffboardingRepositoryInterface(ctrl *gomock.Controller) *MockUserOffboardingRepositoryInterface {
+	mock := &MockUserOffboardingRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockUserOffboardingRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserOffboardingRepositoryInterface) EXPECT() *MockUserOffboardingRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetHistory mocks base method.
+func (m *MockUserOffboardingRepositoryInterface) GetHistory(limit int) ([]models.UserOffboarding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistory", limit)
+	ret0, _ := ret[0].([]models.UserOffboarding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistory indicates an expected call of GetHistory.
+func (mr *MockUserOffboardingRepositoryInterfaceMockRecorder) GetHistory(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistory", reflect.TypeOf((*MockUserOffboardingRepositoryInterface)(nil).GetHistory), limit)
+}
+
+// GetOwnedResources mocks base method.
+func (m *MockUserOffboardingRepositoryInterface) GetOwnedResources(userID uuid.UUID, username string) (*repository.OwnedResources, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnedResources", userID, username)
+	ret0, _ := ret[0].(*repository.OwnedResources)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnedResources indicates an expected call of GetOwnedResources.
+func (mr *MockUserOffboardingRepositoryInterfaceMockRecorder) GetOwnedResources(userID, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnedResources", reflect.TypeOf((*MockUserOffboardingRepositoryInterface)(nil).GetOwnedResources), userID, username)
+}
+
+// Offboard mocks base method.
+func (m *MockUserOffboardingRepositoryInterface) Offboard(record *models.UserOffboarding) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Offboard", record)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Offboard indicates an expected call of Offboard.
+func (mr *MockUserOffboardingRepositoryInterfaceMockRecorder) Offboard(record any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Offboard", reflect.TypeOf((*MockUserOffboardingRepositoryInterface)(nil).Offboard), record)
 }
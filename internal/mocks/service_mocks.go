@@ -17,6 +17,7 @@ import (
 	json "encoding/json"
 	multipart "mime/multipart"
 	reflect "reflect"
+	time "time"
 
 	gin "github.com/gin-gonic/gin"
 	uuid "github.com/google/uuid"
@@ -78,17 +79,17 @@ func (mr *MockUserServiceInterfaceMockRecorder) CreateUser(req any) *gomock.Call
 }
 
 // DeleteUser mocks base method.
-func (m *MockUserServiceInterface) DeleteUser(id uuid.UUID) error {
+func (m *MockUserServiceInterface) DeleteUser(id uuid.UUID, deletedBy string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteUser", id)
+	ret := m.ctrl.Call(m, "DeleteUser", id, deletedBy)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteUser indicates an expected call of DeleteUser.
-func (mr *MockUserServiceInterfaceMockRecorder) DeleteUser(id any) *gomock.Call {
+func (mr *MockUserServiceInterfaceMockRecorder) DeleteUser(id, deletedBy any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserServiceInterface)(nil).DeleteUser), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockUserServiceInterface)(nil).DeleteUser), id, deletedBy)
 }
 
 // GetActiveUsers mocks base method.
@@ -497,18 +498,18 @@ func (mr *MockTeamServiceInterfaceMockRecorder) Update(id, req any) *gomock.Call
 }
 
 // UpdateTeamMetadata mocks base method.
-func (m *MockTeamServiceInterface) UpdateTeamMetadata(id uuid.UUID, metadata json.RawMessage) (*service.TeamResponse, error) {
+func (m *MockTeamServiceInterface) UpdateTeamMetadata(id uuid.UUID, metadata json.RawMessage, expectedVersion int64) (*service.TeamResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTeamMetadata", id, metadata)
+	ret := m.ctrl.Call(m, "UpdateTeamMetadata", id, metadata, expectedVersion)
 	ret0, _ := ret[0].(*service.TeamResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateTeamMetadata indicates an expected call of UpdateTeamMetadata.
-func (mr *MockTeamServiceInterfaceMockRecorder) UpdateTeamMetadata(id, metadata any) *gomock.Call {
+func (mr *MockTeamServiceInterfaceMockRecorder) UpdateTeamMetadata(id, metadata, expectedVersion any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeamMetadata", reflect.TypeOf((*MockTeamServiceInterface)(nil).UpdateTeamMetadata), id, metadata)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeamMetadata", reflect.TypeOf((*MockTeamServiceInterface)(nil).UpdateTeamMetadata), id, metadata, expectedVersion)
 }
 
 // MockProjectServiceInterface is a mock of ProjectServiceInterface interface.
@@ -535,6 +536,21 @@ func (m *MockProjectServiceInterface) EXPECT() *MockProjectServiceInterfaceMockR
 	return m.recorder
 }
 
+// CreateProject mocks base method.
+func (m *MockProjectServiceInterface) CreateProject(req *service.CreateProjectRequest) (*service.ProjectResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProject", req)
+	ret0, _ := ret[0].(*service.ProjectResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateProject indicates an expected call of CreateProject.
+func (mr *MockProjectServiceInterfaceMockRecorder) CreateProject(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProject", reflect.TypeOf((*MockProjectServiceInterface)(nil).CreateProject), req)
+}
+
 // DeleteProject mocks base method.
 func (m *MockProjectServiceInterface) DeleteProject(id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -549,6 +565,81 @@ func (mr *MockProjectServiceInterfaceMockRecorder) DeleteProject(id any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProject", reflect.TypeOf((*MockProjectServiceInterface)(nil).DeleteProject), id)
 }
 
+// GetAllProjects mocks base method.
+func (m *MockProjectServiceInterface) GetAllProjects(page, pageSize int) (*service.ProjectListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllProjects", page, pageSize)
+	ret0, _ := ret[0].(*service.ProjectListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllProjects indicates an expected call of GetAllProjects.
+func (mr *MockProjectServiceInterfaceMockRecorder) GetAllProjects(page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllProjects", reflect.TypeOf((*MockProjectServiceInterface)(nil).GetAllProjects), page, pageSize)
+}
+
+// GetProjectByID mocks base method.
+func (m *MockProjectServiceInterface) GetProjectByID(id uuid.UUID) (*service.ProjectResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectByID", id)
+	ret0, _ := ret[0].(*service.ProjectResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectByID indicates an expected call of GetProjectByID.
+func (mr *MockProjectServiceInterfaceMockRecorder) GetProjectByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectByID", reflect.TypeOf((*MockProjectServiceInterface)(nil).GetProjectByID), id)
+}
+
+// GetProjectSettings mocks base method.
+func (m *MockProjectServiceInterface) GetProjectSettings(id uuid.UUID) (*service.ProjectSettingsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectSettings", id)
+	ret0, _ := ret[0].(*service.ProjectSettingsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectSettings indicates an expected call of GetProjectSettings.
+func (mr *MockProjectServiceInterfaceMockRecorder) GetProjectSettings(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectSettings", reflect.TypeOf((*MockProjectServiceInterface)(nil).GetProjectSettings), id)
+}
+
+// UpdateProject mocks base method.
+func (m *MockProjectServiceInterface) UpdateProject(id uuid.UUID, req *service.UpdateProjectRequest) (*service.ProjectResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProject", id, req)
+	ret0, _ := ret[0].(*service.ProjectResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProject indicates an expected call of UpdateProject.
+func (mr *MockProjectServiceInterfaceMockRecorder) UpdateProject(id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProject", reflect.TypeOf((*MockProjectServiceInterface)(nil).UpdateProject), id, req)
+}
+
+// UpdateProjectSettings mocks base method.
+func (m *MockProjectServiceInterface) UpdateProjectSettings(id uuid.UUID, req *service.UpdateProjectSettingsRequest) (*service.ProjectSettingsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProjectSettings", id, req)
+	ret0, _ := ret[0].(*service.ProjectSettingsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProjectSettings indicates an expected call of UpdateProjectSettings.
+func (mr *MockProjectServiceInterfaceMockRecorder) UpdateProjectSettings(id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProjectSettings", reflect.TypeOf((*MockProjectServiceInterface)(nil).UpdateProjectSettings), id, req)
+}
+
 // MockComponentServiceInterface is a mock of ComponentServiceInterface interface.
 type MockComponentServiceInterface struct {
 	ctrl     *gomock.Controller
@@ -573,6 +664,21 @@ func (m *MockComponentServiceInterface) EXPECT() *MockComponentServiceInterfaceM
 	return m.recorder
 }
 
+// CreateComponent mocks base method.
+func (m *MockComponentServiceInterface) CreateComponent(req *service.CreateComponentRequest) (*service.ComponentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateComponent", req)
+	ret0, _ := ret[0].(*service.ComponentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateComponent indicates an expected call of CreateComponent.
+func (mr *MockComponentServiceInterfaceMockRecorder) CreateComponent(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateComponent", reflect.TypeOf((*MockComponentServiceInterface)(nil).CreateComponent), req)
+}
+
 // DeleteComponent mocks base method.
 func (m *MockComponentServiceInterface) DeleteComponent(id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -587,6 +693,96 @@ func (mr *MockComponentServiceInterfaceMockRecorder) DeleteComponent(id any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteComponent", reflect.TypeOf((*MockComponentServiceInterface)(nil).DeleteComponent), id)
 }
 
+// GetByProjectNameAllView mocks base method.
+func (m *MockComponentServiceInterface) GetByProjectNameAllView(projectName string, lifecycles service.LifecycleFilter) ([]service.ComponentProjectView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProjectNameAllView", projectName, lifecycles)
+	ret0, _ := ret[0].([]service.ComponentProjectView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProjectNameAllView indicates an expected call of GetByProjectNameAllView.
+func (mr *MockComponentServiceInterfaceMockRecorder) GetByProjectNameAllView(projectName, lifecycles any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProjectNameAllView", reflect.TypeOf((*MockComponentServiceInterface)(nil).GetByProjectNameAllView), projectName, lifecycles)
+}
+
+// GetComponentByID mocks base method.
+func (m *MockComponentServiceInterface) GetComponentByID(id uuid.UUID) (*service.ComponentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetComponentByID", id)
+	ret0, _ := ret[0].(*service.ComponentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetComponentByID indicates an expected call of GetComponentByID.
+func (mr *MockComponentServiceInterfaceMockRecorder) GetComponentByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetComponentByID", reflect.TypeOf((*MockComponentServiceInterface)(nil).GetComponentByID), id)
+}
+
+// GetOwnershipHistory mocks base method.
+func (m *MockComponentServiceInterface) GetOwnershipHistory(id uuid.UUID) ([]models.ComponentOwnershipChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnershipHistory", id)
+	ret0, _ := ret[0].([]models.ComponentOwnershipChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnershipHistory indicates an expected call of GetOwnershipHistory.
+func (mr *MockComponentServiceInterfaceMockRecorder) GetOwnershipHistory(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnershipHistory", reflect.TypeOf((*MockComponentServiceInterface)(nil).GetOwnershipHistory), id)
+}
+
+// GetProjectTitleByID mocks base method.
+func (m *MockComponentServiceInterface) GetProjectTitleByID(id uuid.UUID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProjectTitleByID", id)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProjectTitleByID indicates an expected call of GetProjectTitleByID.
+func (mr *MockComponentServiceInterfaceMockRecorder) GetProjectTitleByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProjectTitleByID", reflect.TypeOf((*MockComponentServiceInterface)(nil).GetProjectTitleByID), id)
+}
+
+// TransferOwnership mocks base method.
+func (m *MockComponentServiceInterface) TransferOwnership(id uuid.UUID, req *service.TransferOwnershipRequest) (*service.ComponentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TransferOwnership", id, req)
+	ret0, _ := ret[0].(*service.ComponentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TransferOwnership indicates an expected call of TransferOwnership.
+func (mr *MockComponentServiceInterfaceMockRecorder) TransferOwnership(id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransferOwnership", reflect.TypeOf((*MockComponentServiceInterface)(nil).TransferOwnership), id, req)
+}
+
+// UpdateComponent mocks base method.
+func (m *MockComponentServiceInterface) UpdateComponent(id uuid.UUID, req *service.UpdateComponentRequest) (*service.ComponentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateComponent", id, req)
+	ret0, _ := ret[0].(*service.ComponentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateComponent indicates an expected call of UpdateComponent.
+func (mr *MockComponentServiceInterfaceMockRecorder) UpdateComponent(id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateComponent", reflect.TypeOf((*MockComponentServiceInterface)(nil).UpdateComponent), id, req)
+}
+
 // MockLandscapeServiceInterface is a mock of LandscapeServiceInterface interface.
 type MockLandscapeServiceInterface struct {
 	ctrl     *gomock.Controller
@@ -702,18 +898,18 @@ func (mr *MockLandscapeServiceInterfaceMockRecorder) GetLandscapesByOrganization
 }
 
 // ListByQuery mocks base method.
-func (m *MockLandscapeServiceInterface) ListByQuery(q string, domains, environments []string, limit, offset int) (*service.LandscapeListResponse, error) {
+func (m *MockLandscapeServiceInterface) ListByQuery(query *service.LandscapeQuery, page, pageSize int) (*service.LandscapeListResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListByQuery", q, domains, environments, limit, offset)
+	ret := m.ctrl.Call(m, "ListByQuery", query, page, pageSize)
 	ret0, _ := ret[0].(*service.LandscapeListResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListByQuery indicates an expected call of ListByQuery.
-func (mr *MockLandscapeServiceInterfaceMockRecorder) ListByQuery(q, domains, environments, limit, offset any) *gomock.Call {
+func (mr *MockLandscapeServiceInterfaceMockRecorder) ListByQuery(query, page, pageSize any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByQuery", reflect.TypeOf((*MockLandscapeServiceInterface)(nil).ListByQuery), q, domains, environments, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByQuery", reflect.TypeOf((*MockLandscapeServiceInterface)(nil).ListByQuery), query, page, pageSize)
 }
 
 // UpdateLandscape mocks base method.
@@ -1447,3 +1643,734 @@ func (mr *MockDocumentationServiceInterfaceMockRecorder) UpdateDocumentation(id,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDocumentation", reflect.TypeOf((*MockDocumentationServiceInterface)(nil).UpdateDocumentation), id, req)
 }
+
+// MockTrashServiceInterface is a mock of TrashServiceInterface interface.
+type MockTrashServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockTrashServiceInterfaceMockRecorder is the mock recorder for MockTrashServiceInterface.
+type MockTrashServiceInterfaceMockRecorder struct {
+	mock *MockTrashServiceInterface
+}
+
+// NewMockTrashServiceInterface creates a new mock instance.
+func NewMockTrashServiceInterface(ctrl *gomock.Controller) *MockTrashServiceInterface {
+	mock := &MockTrashServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockTrashServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrashServiceInterface) EXPECT() *MockTrashServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ListTrash mocks base method.
+func (m *MockTrashServiceInterface) ListTrash(kind string, page, pageSize int) (*service.TrashListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTrash", kind, page, pageSize)
+	ret0, _ := ret[0].(*service.TrashListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTrash indicates an expected call of ListTrash.
+func (mr *MockTrashServiceInterfaceMockRecorder) ListTrash(kind, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTrash", reflect.TypeOf((*MockTrashServiceInterface)(nil).ListTrash), kind, page, pageSize)
+}
+
+// Restore mocks base method.
+func (m *MockTrashServiceInterface) Restore(kind string, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", kind, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockTrashServiceInterfaceMockRecorder) Restore(kind, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTrashServiceInterface)(nil).Restore), kind, id)
+}
+
+// MockOrganizationServiceInterface is a mock of OrganizationServiceInterface interface.
+type MockOrganizationServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrganizationServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockOrganizationServiceInterfaceMockRecorder is the mock recorder for MockOrganizationServiceInterface.
+type MockOrganizationServiceInterfaceMockRecorder struct {
+	mock *MockOrganizationServiceInterface
+}
+
+// NewMockOrganizationServiceInterface creates a new mock instance.
+func NewMockOrganizationServiceInterface(ctrl *gomock.Controller) *MockOrganizationServiceInterface {
+	mock := &MockOrganizationServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockOrganizationServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrganizationServiceInterface) EXPECT() *MockOrganizationServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateOrganization mocks base method.
+func (m *MockOrganizationServiceInterface) CreateOrganization(req *service.CreateOrganizationRequest) (*service.OrganizationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrganization", req)
+	ret0, _ := ret[0].(*service.OrganizationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrganization indicates an expected call of CreateOrganization.
+func (mr *MockOrganizationServiceInterfaceMockRecorder) CreateOrganization(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrganization", reflect.TypeOf((*MockOrganizationServiceInterface)(nil).CreateOrganization), req)
+}
+
+// DeleteOrganization mocks base method.
+func (m *MockOrganizationServiceInterface) DeleteOrganization(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrganization", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrganization indicates an expected call of DeleteOrganization.
+func (mr *MockOrganizationServiceInterfaceMockRecorder) DeleteOrganization(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrganization", reflect.TypeOf((*MockOrganizationServiceInterface)(nil).DeleteOrganization), id)
+}
+
+// GetAllOrganizations mocks base method.
+func (m *MockOrganizationServiceInterface) GetAllOrganizations(page, pageSize int) (*service.OrganizationListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllOrganizations", page, pageSize)
+	ret0, _ := ret[0].(*service.OrganizationListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAllOrganizations indicates an expected call of GetAllOrganizations.
+func (mr *MockOrganizationServiceInterfaceMockRecorder) GetAllOrganizations(page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOrganizations", reflect.TypeOf((*MockOrganizationServiceInterface)(nil).GetAllOrganizations), page, pageSize)
+}
+
+// GetOrganizationByID mocks base method.
+func (m *MockOrganizationServiceInterface) GetOrganizationByID(id uuid.UUID) (*service.OrganizationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationByID", id)
+	ret0, _ := ret[0].(*service.OrganizationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationByID indicates an expected call of GetOrganizationByID.
+func (mr *MockOrganizationServiceInterfaceMockRecorder) GetOrganizationByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationByID", reflect.TypeOf((*MockOrganizationServiceInterface)(nil).GetOrganizationByID), id)
+}
+
+// UpdateOrganization mocks base method.
+func (m *MockOrganizationServiceInterface) UpdateOrganization(id uuid.UUID, req *service.UpdateOrganizationRequest) (*service.OrganizationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrganization", id, req)
+	ret0, _ := ret[0].(*service.OrganizationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrganization indicates an expected call of UpdateOrganization.
+func (mr *MockOrganizationServiceInterfaceMockRecorder) UpdateOrganization(id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrganization", reflect.TypeOf((*MockOrganizationServiceInterface)(nil).UpdateOrganization), id, req)
+}
+
+// MockGroupServiceInterface is a mock of GroupServiceInterface interface.
+type MockGroupServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockGroupServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockGroupServiceInterfaceMockRecorder is the mock recorder for MockGroupServiceInterface.
+type MockGroupServiceInterfaceMockRecorder struct {
+	mock *MockGroupServiceInterface
+}
+
+// NewMockGroupServiceInterface creates a new mock instance.
+func NewMockGroupServiceInterface(ctrl *gomock.Controller) *MockGroupServiceInterface {
+	mock := &MockGroupServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockGroupServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGroupServiceInterface) EXPECT() *MockGroupServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateGroup mocks base method.
+func (m *MockGroupServiceInterface) CreateGroup(req *service.CreateGroupRequest) (*service.GroupResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroup", req)
+	ret0, _ := ret[0].(*service.GroupResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGroup indicates an expected call of CreateGroup.
+func (mr *MockGroupServiceInterfaceMockRecorder) CreateGroup(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroup", reflect.TypeOf((*MockGroupServiceInterface)(nil).CreateGroup), req)
+}
+
+// DeleteGroup mocks base method.
+func (m *MockGroupServiceInterface) DeleteGroup(id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGroup", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGroup indicates an expected call of DeleteGroup.
+func (mr *MockGroupServiceInterfaceMockRecorder) DeleteGroup(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroup", reflect.TypeOf((*MockGroupServiceInterface)(nil).DeleteGroup), id)
+}
+
+// GetGroupWithTeams mocks base method.
+func (m *MockGroupServiceInterface) GetGroupWithTeams(id uuid.UUID) (*service.GroupWithTeamsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupWithTeams", id)
+	ret0, _ := ret[0].(*service.GroupWithTeamsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupWithTeams indicates an expected call of GetGroupWithTeams.
+func (mr *MockGroupServiceInterfaceMockRecorder) GetGroupWithTeams(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupWithTeams", reflect.TypeOf((*MockGroupServiceInterface)(nil).GetGroupWithTeams), id)
+}
+
+// GetGroupsByOrganization mocks base method.
+func (m *MockGroupServiceInterface) GetGroupsByOrganization(orgID uuid.UUID, query string, page, pageSize int) (*service.GroupListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupsByOrganization", orgID, query, page, pageSize)
+	ret0, _ := ret[0].(*service.GroupListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupsByOrganization indicates an expected call of GetGroupsByOrganization.
+func (mr *MockGroupServiceInterfaceMockRecorder) GetGroupsByOrganization(orgID, query, page, pageSize any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupsByOrganization", reflect.TypeOf((*MockGroupServiceInterface)(nil).GetGroupsByOrganization), orgID, query, page, pageSize)
+}
+
+// MoveTeam mocks base method.
+func (m *MockGroupServiceInterface) MoveTeam(teamID uuid.UUID, req *service.MoveTeamRequest) (*service.TeamResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTeam", teamID, req)
+	ret0, _ := ret[0].(*service.TeamResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveTeam indicates an expected call of MoveTeam.
+func (mr *MockGroupServiceInterfaceMockRecorder) MoveTeam(teamID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTeam", reflect.TypeOf((*MockGroupServiceInterface)(nil).MoveTeam), teamID, req)
+}
+
+// UpdateGroup mocks base method.
+func (m *MockGroupServiceInterface) UpdateGroup(id uuid.UUID, req *service.UpdateGroupRequest) (*service.GroupResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroup", id, req)
+	ret0, _ := ret[0].(*service.GroupResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGroup indicates an expected call of UpdateGroup.
+func (mr *MockGroupServiceInterfaceMockRecorder) UpdateGroup(id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroup", reflect.TypeOf((*MockGroupServiceInterface)(nil).UpdateGroup), id, req)
+}
+
+// MockTeamMembershipServiceInterface is a mock of TeamMembershipServiceInterface interface.
+type MockTeamMembershipServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTeamMembershipServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockTeamMembershipServiceInterfaceMockRecorder is the mock recorder for MockTeamMembershipServiceInterface.
+type MockTeamMembershipServiceInterfaceMockRecorder struct {
+	mock *MockTeamMembershipServiceInterface
+}
+
+// NewMockTeamMembershipServiceInterface creates a new mock instance.
+func NewMockTeamMembershipServiceInterface(ctrl *gomock.Controller) *MockTeamMembershipServiceInterface {
+	mock := &MockTeamMembershipServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockTeamMembershipServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTeamMembershipServiceInterface) EXPECT() *MockTeamMembershipServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AddTeamMember mocks base method.
+func (m *MockTeamMembershipServiceInterface) AddTeamMember(teamID uuid.UUID, req *service.AddTeamMemberRequest) (*service.UserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTeamMember", teamID, req)
+	ret0, _ := ret[0].(*service.UserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTeamMember indicates an expected call of AddTeamMember.
+func (mr *MockTeamMembershipServiceInterfaceMockRecorder) AddTeamMember(teamID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTeamMember", reflect.TypeOf((*MockTeamMembershipServiceInterface)(nil).AddTeamMember), teamID, req)
+}
+
+// GetMembershipHistory mocks base method.
+func (m *MockTeamMembershipServiceInterface) GetMembershipHistory(userID string) ([]models.TeamMembership, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMembershipHistory", userID)
+	ret0, _ := ret[0].([]models.TeamMembership)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMembershipHistory indicates an expected call of GetMembershipHistory.
+func (mr *MockTeamMembershipServiceInterfaceMockRecorder) GetMembershipHistory(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMembershipHistory", reflect.TypeOf((*MockTeamMembershipServiceInterface)(nil).GetMembershipHistory), userID)
+}
+
+// GetTeamMembers mocks base method.
+func (m *MockTeamMembershipServiceInterface) GetTeamMembers(teamID uuid.UUID, from, to time.Time) ([]service.TeamMemberResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamMembers", teamID, from, to)
+	ret0, _ := ret[0].([]service.TeamMemberResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamMembers indicates an expected call of GetTeamMembers.
+func (mr *MockTeamMembershipServiceInterfaceMockRecorder) GetTeamMembers(teamID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamMembers", reflect.TypeOf((*MockTeamMembershipServiceInterface)(nil).GetTeamMembers), teamID, from, to)
+}
+
+// RemoveTeamMember mocks base method.
+func (m *MockTeamMembershipServiceInterface) RemoveTeamMember(teamID uuid.UUID, userID, removedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTeamMember", teamID, userID, removedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTeamMember indicates an expected call of RemoveTeamMember.
+func (mr *MockTeamMembershipServiceInterfaceMockRecorder) RemoveTeamMember(teamID, userID, removedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTeamMember", reflect.TypeOf((*MockTeamMembershipServiceInterface)(nil).RemoveTeamMember), teamID, userID, removedBy)
+}
+
+// UpdateTeamMember mocks base method.
+func (m *MockTeamMembershipServiceInterface) UpdateTeamMember(teamID uuid.UUID, userID string, req *service.UpdateTeamMemberRequest) (*service.UserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTeamMember", teamID, userID, req)
+	ret0, _ := ret[0].(*service.UserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTeamMember indicates an expected call of UpdateTeamMember.
+func (mr *MockTeamMembershipServiceInterfaceMockRecorder) UpdateTeamMember(teamID, userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeamMember", reflect.TypeOf((*MockTeamMembershipServiceInterface)(nil).UpdateTeamMember), teamID, userID, req)
+}
+
+
+// MockHierarchyServiceInterface is a mock of HierarchyServiceInterface interface.
+type MockHierarchyServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockHierarchyServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockHierarchyServiceInterfaceMockRecorder is the mock recorder for MockHierarchyServiceInterface.
+type MockHierarchyServiceInterfaceMockRecorder struct {
+	mock *MockHierarchyServiceInterface
+}
+
+// NewMockHierarchyServiceInterface creates a new mock instance.
+func NewMockHierarchyServiceInterface(ctrl *gomock.Controller) *MockHierarchyServiceInterface {
+	mock := &MockHierarchyServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockHierarchyServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHierarchyServiceInterface) EXPECT() *MockHierarchyServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetGroupTree mocks base method.
+func (m *MockHierarchyServiceInterface) GetGroupTree(id uuid.UUID, opts service.HierarchyOptions) (*service.GroupTreeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGroupTree", id, opts)
+	ret0, _ := ret[0].(*service.GroupTreeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGroupTree indicates an expected call of GetGroupTree.
+func (mr *MockHierarchyServiceInterfaceMockRecorder) GetGroupTree(id, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGroupTree", reflect.TypeOf((*MockHierarchyServiceInterface)(nil).GetGroupTree), id, opts)
+}
+
+// GetOrganizationTree mocks base method.
+func (m *MockHierarchyServiceInterface) GetOrganizationTree(id uuid.UUID, opts service.HierarchyOptions) (*service.OrganizationTreeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrganizationTree", id, opts)
+	ret0, _ := ret[0].(*service.OrganizationTreeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrganizationTree indicates an expected call of GetOrganizationTree.
+func (mr *MockHierarchyServiceInterfaceMockRecorder) GetOrganizationTree(id, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrganizationTree", reflect.TypeOf((*MockHierarchyServiceInterface)(nil).GetOrganizationTree), id, opts)
+}
+
+// MockDirectorySyncServiceInterface is a mock of DirectorySyncServiceInterface interface.
+type MockDirectorySyncServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockDirectorySyncServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockDirectorySyncServiceInterfaceMockRecorder is the mock recorder for MockDirectorySyncServiceInterface.
+type MockDirectorySyncServiceInterfaceMockRecorder struct {
+	mock *MockDirectorySyncServiceInterface
+}
+
+// NewMockDirectorySyncServiceInterface creates a new mock instance.
+func NewMockDirectorySyncServiceInterface(ctrl *gomock.Controller) *MockDirectorySyncServiceInterface {
+	mock := &MockDirectorySyncServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockDirectorySyncServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDirectorySyncServiceInterface) EXPECT() *MockDirectorySyncServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// LastReport mocks base method.
+func (m *MockDirectorySyncServiceInterface) LastReport() (*service.DirectorySyncReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastReport")
+	ret0, _ := ret[0].(*service.DirectorySyncReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastReport indicates an expected call of LastReport.
+func (mr *MockDirectorySyncServiceInterfaceMockRecorder) LastReport() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastReport", reflect.TypeOf((*MockDirectorySyncServiceInterface)(nil).LastReport))
+}
+
+// Reconcile mocks base method.
+func (m *MockDirectorySyncServiceInterface) Reconcile() (*service.DirectorySyncReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reconcile")
+	ret0, _ := ret[0].(*service.DirectorySyncReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reconcile indicates an expected call of Reconcile.
+func (mr *MockDirectorySyncServiceInterfaceMockRecorder) Reconcile() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reconcile", reflect.TypeOf((*MockDirectorySyncServiceInterface)(nil).Reconcile))
+}
+
+// MockMembershipDriftServiceInterface is a mock of MembershipDriftServiceInterface interface.
+type MockMembershipDriftServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockMembershipDriftServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockMembershipDriftServiceInterfaceMockRecorder is the mock recorder for MockMembershipDriftServiceInterface.
+type MockMembershipDriftServiceInterfaceMockRecorder struct {
+	mock *MockMembershipDriftServiceInterface
+}
+
+// NewMockMembershipDriftServiceInterface creates a new mock instance.
+func NewMockMembershipDriftServiceInterface(ctrl *gomock.Controller) *MockMembershipDriftServiceInterface {
+	mock := &MockMembershipDriftServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockMembershipDriftServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMembershipDriftServiceInterface) EXPECT() *MockMembershipDriftServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ApplyDrift mocks base method.
+func (m *MockMembershipDriftServiceInterface) ApplyDrift(appliedBy string) (*service.MembershipDriftReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyDrift", appliedBy)
+	ret0, _ := ret[0].(*service.MembershipDriftReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyDrift indicates an expected call of ApplyDrift.
+func (mr *MockMembershipDriftServiceInterfaceMockRecorder) ApplyDrift(appliedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyDrift", reflect.TypeOf((*MockMembershipDriftServiceInterface)(nil).ApplyDrift), appliedBy)
+}
+
+// ApplyTeamDrift mocks base method.
+func (m *MockMembershipDriftServiceInterface) ApplyTeamDrift(teamID uuid.UUID, appliedBy string) (*service.TeamMembershipDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyTeamDrift", teamID, appliedBy)
+	ret0, _ := ret[0].(*service.TeamMembershipDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyTeamDrift indicates an expected call of ApplyTeamDrift.
+func (mr *MockMembershipDriftServiceInterfaceMockRecorder) ApplyTeamDrift(teamID, appliedBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyTeamDrift", reflect.TypeOf((*MockMembershipDriftServiceInterface)(nil).ApplyTeamDrift), teamID, appliedBy)
+}
+
+// GetDriftReport mocks base method.
+func (m *MockMembershipDriftServiceInterface) GetDriftReport() (*service.MembershipDriftReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDriftReport")
+	ret0, _ := ret[0].(*service.MembershipDriftReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDriftReport indicates an expected call of GetDriftReport.
+func (mr *MockMembershipDriftServiceInterfaceMockRecorder) GetDriftReport() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDriftReport", reflect.TypeOf((*MockMembershipDriftServiceInterface)(nil).GetDriftReport))
+}
+
+// GetTeamDrift mocks base method.
+func (m *MockMembershipDriftServiceInterface) GetTeamDrift(teamID uuid.UUID) (*service.TeamMembershipDrift, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamDrift", teamID)
+	ret0, _ := ret[0].(*service.TeamMembershipDrift)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTeamDrift indicates an expected call of GetTeamDrift.
+func (mr *MockMembershipDriftServiceInterfaceMockRecorder) GetTeamDrift(teamID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamDrift", reflect.TypeOf((*MockMembershipDriftServiceInterface)(nil).GetTeamDrift), teamID)
+}
+
+// MockUserImportServiceInterface is a mock of UserImportServiceInterface interface.
+type MockUserImportServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserImportServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockUserImportServiceInterfaceMockRecorder is the mock recorder for MockUserImportServiceInterface.
+type MockUserImportServiceInterfaceMockRecorder struct {
+	mock *MockUserImportServiceInterface
+}
+
+// NewMockUserImportServiceInterface creates a new mock instance.
+func NewMockUserImportServiceInterface(ctrl *gomock.Controller) *MockUserImportServiceInterface {
+	mock := &MockUserImportServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockUserImportServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserImportServiceInterface) EXPECT() *MockUserImportServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ImportUsers mocks base method.
+func (m *MockUserImportServiceInterface) ImportUsers(req *service.UserImportRequest) (*service.UserImportResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportUsers", req)
+	ret0, _ := ret[0].(*service.UserImportResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportUsers indicates an expected call of ImportUsers.
+func (mr *MockUserImportServiceInterfaceMockRecorder) ImportUsers(req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportUsers", reflect.TypeOf((*MockUserImportServiceInterface)(nil).ImportUsers), req)
+}
+
+// MockUserOffboardingServiceInterface is a mock of UserOffboardingServiceInterface interface.
+type MockUserOffboardingServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserOffboardingServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockUserOffboardingServiceInterfaceMockRecorder is the mock recorder for MockUserOffboardingServiceInterface.
+type MockUserOffboardingServiceInterfaceMockRecorder struct {
+	mock *MockUserOffboardingServiceInterface
+}
+
+// NewMockUserOffboardingServiceInterface creates a new mock instance.
+func NewMockUserOffboardingServiceInterface(ctrl *gomock.Controller) *MockUserOffboardingServiceInterface {
+	mock := &MockUserOffboardingServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockUserOffboardingServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserOffboardingServiceInterface) EXPECT() *MockUserOffboardingServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetOffboardingHistory mocks base method.
+func (m *MockUserOffboardingServiceInterface) GetOffboardingHistory(limit int) ([]models.UserOffboarding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOffboardingHistory", limit)
+	ret0, _ := ret[0].([]models.UserOffboarding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOffboardingHistory indicates an expected call of GetOffboardingHistory.
+func (mr *MockUserOffboardingServiceInterfaceMockRecorder) GetOffboardingHistory(limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOffboardingHistory", reflect.TypeOf((*MockUserOffboardingServiceInterface)(nil).GetOffboardingHistory), limit)
+}
+
+// OffboardUser mocks base method.
+func (m *MockUserOffboardingServiceInterface) OffboardUser(userID string, req *service.OffboardUserRequest) (*models.UserOffboarding, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OffboardUser", userID, req)
+	ret0, _ := ret[0].(*models.UserOffboarding)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OffboardUser indicates an expected call of OffboardUser.
+func (mr *MockUserOffboardingServiceInterfaceMockRecorder) OffboardUser(userID, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OffboardUser", reflect.TypeOf((*MockUserOffboardingServiceInterface)(nil).OffboardUser), userID, req)
+}
+
+// PreviewOffboarding mocks base method.
+func (m *MockUserOffboardingServiceInterface) PreviewOffboarding(userID string) (*service.OffboardingPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewOffboarding", userID)
+	ret0, _ := ret[0].(*service.OffboardingPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewOffboarding indicates an expected call of PreviewOffboarding.
+func (mr *MockUserOffboardingServiceInterfaceMockRecorder) PreviewOffboarding(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewOffboarding", reflect.TypeOf((*MockUserOffboardingServiceInterface)(nil).PreviewOffboarding), userID)
+}
+
+// MockLinkCheckServiceInterface is a mock of LinkCheckServiceInterface interface.
+type MockLinkCheckServiceInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinkCheckServiceInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockLinkCheckServiceInterfaceMockRecorder is the mock recorder for MockLinkCheckServiceInterface.
+type MockLinkCheckServiceInterfaceMockRecorder struct {
+	mock *MockLinkCheckServiceInterface
+}
+
+// NewMockLinkCheckServiceInterface creates a new mock instance.
+func NewMockLinkCheckServiceInterface(ctrl *gomock.Controller) *MockLinkCheckServiceInterface {
+	mock := &MockLinkCheckServiceInterface{ctrl: ctrl}
+	mock.recorder = &MockLinkCheckServiceInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinkCheckServiceInterface) EXPECT() *MockLinkCheckServiceInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetBrokenLinkReport mocks base method.
+func (m *MockLinkCheckServiceInterface) GetBrokenLinkReport() (*service.BrokenLinkReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBrokenLinkReport")
+	ret0, _ := ret[0].(*service.BrokenLinkReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBrokenLinkReport indicates an expected call of GetBrokenLinkReport.
+func (mr *MockLinkCheckServiceInterfaceMockRecorder) GetBrokenLinkReport() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBrokenLinkReport", reflect.TypeOf((*MockLinkCheckServiceInterface)(nil).GetBrokenLinkReport))
+}
+
+// LastRun mocks base method.
+func (m *MockLinkCheckServiceInterface) LastRun() (*service.LinkCheckRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastRun")
+	ret0, _ := ret[0].(*service.LinkCheckRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LastRun indicates an expected call of LastRun.
+func (mr *MockLinkCheckServiceInterfaceMockRecorder) LastRun() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastRun", reflect.TypeOf((*MockLinkCheckServiceInterface)(nil).LastRun))
+}
+
+// StartLinkCheck mocks base method.
+func (m *MockLinkCheckServiceInterface) StartLinkCheck() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartLinkCheck")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartLinkCheck indicates an expected call of StartLinkCheck.
+func (mr *MockLinkCheckServiceInterfaceMockRecorder) StartLinkCheck() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartLinkCheck", reflect.TypeOf((*MockLinkCheckServiceInterface)(nil).StartLinkCheck))
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// APITokenRepository handles database operations for personal access and service account tokens
+type APITokenRepository struct {
+	db *gorm.DB
+}
+
+// Ensure APITokenRepository implements APITokenRepositoryInterface
+var _ APITokenRepositoryInterface = (*APITokenRepository)(nil)
+
+// NewAPITokenRepository creates a new API token repository
+func NewAPITokenRepository(db *gorm.DB) *APITokenRepository {
+	return &APITokenRepository{db: db}
+}
+
+// Create inserts a new API token
+func (r *APITokenRepository) Create(token *models.APIToken) error {
+	return r.db.Create(token).Error
+}
+
+// GetByID retrieves an API token by ID
+func (r *APITokenRepository) GetByID(id uuid.UUID) (*models.APIToken, error) {
+	var token models.APIToken
+	if err := r.db.First(&token, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// GetByTokenHash retrieves an API token by the hash of its value
+func (r *APITokenRepository) GetByTokenHash(tokenHash string) (*models.APIToken, error) {
+	var token models.APIToken
+	if err := r.db.First(&token, "token_hash = ?", tokenHash).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// GetPersonalByUser retrieves the personal access tokens of a provider user, newest first
+func (r *APITokenRepository) GetPersonalByUser(userID int64, provider string) ([]models.APIToken, error) {
+	var tokens []models.APIToken
+	err := r.db.Where("service_account IS NULL AND user_id = ? AND provider = ?", userID, provider).
+		Order("created_at DESC").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// GetByServiceAccount retrieves the tokens of a service account, newest first
+func (r *APITokenRepository) GetByServiceAccount(name string) ([]models.APIToken, error) {
+	var tokens []models.APIToken
+	err := r.db.Where("service_account = ?", name).
+		Order("created_at DESC").
+		Find(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// UpdateLastUsed records when the token was last used
+func (r *APITokenRepository) UpdateLastUsed(id uuid.UUID, at time.Time) error {
+	return r.db.Model(&models.APIToken{}).Where("id = ?", id).UpdateColumn("last_used_at", at).Error
+}
+
+// Delete removes an API token
+func (r *APITokenRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.APIToken{}, "id = ?", id).Error
+}
+
+// DeletePersonalByEmail removes the personal access tokens issued to the given email across all
+// providers and returns the number of removed rows
+func (r *APITokenRepository) DeletePersonalByEmail(email string) (int64, error) {
+	res := r.db.Where("service_account IS NULL AND LOWER(email) = LOWER(?)", email).Delete(&models.APIToken{})
+	return res.RowsAffected, res.Error
+}
+
+// ServiceAccountRepository handles database operations for service accounts
+type ServiceAccountRepository struct {
+	db *gorm.DB
+}
+
+// Ensure ServiceAccountRepository implements ServiceAccountRepositoryInterface
+var _ ServiceAccountRepositoryInterface = (*ServiceAccountRepository)(nil)
+
+// NewServiceAccountRepository creates a new service account repository
+func NewServiceAccountRepository(db *gorm.DB) *ServiceAccountRepository {
+	return &ServiceAccountRepository{db: db}
+}
+
+// Create inserts a new service account
+func (r *ServiceAccountRepository) Create(account *models.ServiceAccount) error {
+	return r.db.Create(account).Error
+}
+
+// GetByName retrieves a service account by name
+func (r *ServiceAccountRepository) GetByName(name string) (*models.ServiceAccount, error) {
+	var account models.ServiceAccount
+	if err := r.db.First(&account, "name = ?", name).Error; err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+// GetAll retrieves all service accounts ordered by name
+func (r *ServiceAccountRepository) GetAll() ([]models.ServiceAccount, error) {
+	var accounts []models.ServiceAccount
+	if err := r.db.Order("name ASC").Find(&accounts).Error; err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// Delete removes a service account together with all of its tokens.
+// Returns gorm.ErrRecordNotFound if the service account does not exist.
+func (r *ServiceAccountRepository) Delete(name string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("name = ?", name).Delete(&models.ServiceAccount{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Where("service_account = ?", name).Delete(&models.APIToken{}).Error
+	})
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+	"developer-portal-backend/internal/testutils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+// APITokenRepositoryTestSuite tests the APITokenRepository and ServiceAccountRepository
+type APITokenRepositoryTestSuite struct {
+	suite.Suite
+	baseTestSuite *testutils.BaseTestSuite
+	repo          *APITokenRepository
+	accounts      *ServiceAccountRepository
+}
+
+// SetupSuite runs before all tests in the suite
+func (suite *APITokenRepositoryTestSuite) SetupSuite() {
+	suite.baseTestSuite = testutils.SetupTestSuite(suite.T())
+	suite.repo = NewAPITokenRepository(suite.baseTestSuite.DB)
+	suite.accounts = NewServiceAccountRepository(suite.baseTestSuite.DB)
+}
+
+// TearDownSuite runs after all tests in the suite
+func (suite *APITokenRepositoryTestSuite) TearDownSuite() {
+	suite.baseTestSuite.TeardownTestSuite()
+}
+
+// SetupTest runs before each test
+func (suite *APITokenRepositoryTestSuite) SetupTest() {
+	suite.baseTestSuite.SetupTest()
+}
+
+// TearDownTest runs after each test
+func (suite *APITokenRepositoryTestSuite) TearDownTest() {
+	suite.baseTestSuite.TearDownTest()
+}
+
+func (suite *APITokenRepositoryTestSuite) newToken(hash string, serviceAccount *string) *models.APIToken {
+	token := &models.APIToken{
+		TokenHash:      hash,
+		Prefix:         "pat_abcdef",
+		Name:           "ci",
+		Scopes:         "read,write",
+		ServiceAccount: serviceAccount,
+		UserID:         12345,
+		Username:       "testuser",
+		Email:          "test@example.com",
+		Provider:       "githubtools",
+		ExpiresAt:      time.Now().Add(time.Hour),
+	}
+	suite.Require().NoError(suite.repo.Create(token))
+	return token
+}
+
+// TestCreateAndGet tests creating a token and reading it back by ID and hash
+func (suite *APITokenRepositoryTestSuite) TestCreateAndGet() {
+	token := suite.newToken("hash-1", nil)
+	suite.NotEqual(uuid.Nil, token.ID)
+
+	byID, err := suite.repo.GetByID(token.ID)
+	suite.NoError(err)
+	suite.Equal("hash-1", byID.TokenHash)
+
+	byHash, err := suite.repo.GetByTokenHash("hash-1")
+	suite.NoError(err)
+	suite.Equal(token.ID, byHash.ID)
+
+	_, err = suite.repo.GetByTokenHash("unknown")
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+// TestListsSeparatePersonalAndServiceAccountTokens tests that personal and service account tokens are listed separately
+func (suite *APITokenRepositoryTestSuite) TestListsSeparatePersonalAndServiceAccountTokens() {
+	bot := "release-bot"
+	suite.newToken("personal", nil)
+	suite.newToken("bot", &bot)
+
+	personal, err := suite.repo.GetPersonalByUser(12345, "githubtools")
+	suite.NoError(err)
+	suite.Len(personal, 1)
+	suite.Equal("personal", personal[0].TokenHash)
+
+	botTokens, err := suite.repo.GetByServiceAccount(bot)
+	suite.NoError(err)
+	suite.Len(botTokens, 1)
+	suite.Equal("bot", botTokens[0].TokenHash)
+}
+
+// TestUpdateLastUsedAndDelete tests last-used tracking and deletion
+func (suite *APITokenRepositoryTestSuite) TestUpdateLastUsedAndDelete() {
+	token := suite.newToken("hash-2", nil)
+	usedAt := time.Now().Truncate(time.Second)
+
+	suite.NoError(suite.repo.UpdateLastUsed(token.ID, usedAt))
+	found, err := suite.repo.GetByID(token.ID)
+	suite.NoError(err)
+	suite.Require().NotNil(found.LastUsedAt)
+	suite.WithinDuration(usedAt, *found.LastUsedAt, time.Second)
+
+	suite.NoError(suite.repo.Delete(token.ID))
+	_, err = suite.repo.GetByID(token.ID)
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+// TestDeletePersonalByEmail tests that only the personal access tokens of the email are deleted
+func (suite *APITokenRepositoryTestSuite) TestDeletePersonalByEmail() {
+	bot := "release-bot"
+	personal := suite.newToken("personal", nil)
+	botToken := suite.newToken("bot", &bot)
+
+	removed, err := suite.repo.DeletePersonalByEmail("Test@Example.com")
+	suite.NoError(err)
+	suite.Equal(int64(1), removed)
+
+	_, err = suite.repo.GetByID(personal.ID)
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+	_, err = suite.repo.GetByID(botToken.ID)
+	suite.NoError(err)
+}
+
+// TestDeleteServiceAccountRemovesTokens tests that deleting a service account deletes its tokens
+func (suite *APITokenRepositoryTestSuite) TestDeleteServiceAccountRemovesTokens() {
+	suite.NoError(suite.accounts.Create(&models.ServiceAccount{Name: "release-bot", CreatedBy: "admin"}))
+	bot := "release-bot"
+	suite.newToken("bot", &bot)
+	suite.newToken("personal", nil)
+
+	accounts, err := suite.accounts.GetAll()
+	suite.NoError(err)
+	suite.Len(accounts, 1)
+
+	suite.NoError(suite.accounts.Delete("release-bot"))
+	suite.ErrorIs(suite.accounts.Delete("release-bot"), gorm.ErrRecordNotFound)
+
+	_, err = suite.repo.GetByTokenHash("bot")
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+	_, err = suite.repo.GetByTokenHash("personal")
+	suite.NoError(err)
+}
+
+func TestAPITokenRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(APITokenRepositoryTestSuite))
+}
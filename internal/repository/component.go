@@ -67,9 +67,9 @@ func (r *ComponentRepository) GetByOrganizationID(orgID uuid.UUID, limit, offset
 
 // GetActiveComponents retrieves all active components for an organization
 
-// Update updates a component
+// Update updates a component. Returns ErrVersionConflict if it was updated since it was read.
 func (r *ComponentRepository) Update(component *models.Component) error {
-	return r.db.Save(component).Error
+	return updateVersioned(r.db, component, component.ID, &component.Version)
 }
 
 // Delete deletes a component
@@ -197,6 +197,32 @@ func (r *ComponentRepository) GetByOwnerID(ownerID uuid.UUID, limit, offset int)
 	return r.GetComponentsByTeamID(ownerID, limit, offset)
 }
 
+// CountByOwnerIDs counts the components owned by each of the given teams. Teams without
+// components are missing from the result.
+func (r *ComponentRepository) CountByOwnerIDs(ownerIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
+	counts := make(map[uuid.UUID]int64)
+	if len(ownerIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		OwnerID uuid.UUID
+		Count   int64
+	}
+	err := r.db.Model(&models.Component{}).
+		Select("owner_id, COUNT(*) AS count").
+		Where("owner_id IN ?", ownerIDs).
+		Group("owner_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.OwnerID] = row.Count
+	}
+	return counts, nil
+}
+
 // GetComponentsByProjectID retrieves all components used by a specific project
 func (r *ComponentRepository) GetComponentsByProjectID(projectID uuid.UUID, limit, offset int) ([]models.Component, int64, error) {
 	var components []models.Component
@@ -242,3 +268,24 @@ func (r *ComponentRepository) GetComponentsByMetadata(orgID uuid.UUID, metadata
 
 	return components, total, nil
 }
+
+// TransferOwnership saves the component with its new owner and records the change in one transaction.
+// Returns ErrVersionConflict if the component was updated since it was read.
+func (r *ComponentRepository) TransferOwnership(component *models.Component, change *models.ComponentOwnershipChange) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := updateVersioned(tx, component, component.ID, &component.Version); err != nil {
+			return err
+		}
+		return tx.Create(change).Error
+	})
+}
+
+// GetOwnershipHistory retrieves the ownership changes of a component, newest first
+func (r *ComponentRepository) GetOwnershipHistory(componentID uuid.UUID) ([]models.ComponentOwnershipChange, error) {
+	var changes []models.ComponentOwnershipChange
+	err := r.db.Where("component_id = ?", componentID).Order("created_at DESC").Find(&changes).Error
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
@@ -1,8 +1,10 @@
 package repository
 
 import (
+	"fmt"
 	"testing"
 
+	"developer-portal-backend/internal/database/models"
 	"developer-portal-backend/internal/testutils"
 
 	"github.com/google/uuid"
@@ -206,6 +208,65 @@ func (suite *ComponentRepositoryTestSuite) TestGetByNameNotFound() {
 	suite.Nil(component)
 }
 
+// TestTransferOwnership tests that a transfer updates the owner and is recorded in the history
+func (suite *ComponentRepositoryTestSuite) TestTransferOwnership() {
+	component := suite.factories.Component.Create()
+	err := suite.repo.Create(component)
+	suite.NoError(err)
+
+	previousOwner := component.OwnerID
+	component.OwnerID = uuid.New()
+	err = suite.repo.TransferOwnership(component, &models.ComponentOwnershipChange{
+		ComponentID: component.ID,
+		FromOwnerID: previousOwner,
+		ToOwnerID:   component.OwnerID,
+		ChangedBy:   "jdoe",
+	})
+	suite.NoError(err)
+
+	stored, err := suite.repo.GetByID(component.ID)
+	suite.NoError(err)
+	suite.Equal(component.OwnerID, stored.OwnerID)
+	suite.Equal(int64(2), stored.Version)
+
+	history, err := suite.repo.GetOwnershipHistory(component.ID)
+	suite.NoError(err)
+	suite.Require().Len(history, 1)
+	suite.Equal(previousOwner, history[0].FromOwnerID)
+	suite.Equal("jdoe", history[0].ChangedBy)
+
+	// A stale version neither updates the owner nor records a change
+	component.Version = 1
+	err = suite.repo.TransferOwnership(component, &models.ComponentOwnershipChange{
+		ComponentID: component.ID,
+		FromOwnerID: component.OwnerID,
+		ToOwnerID:   previousOwner,
+		ChangedBy:   "jdoe",
+	})
+	suite.ErrorIs(err, ErrVersionConflict)
+	history, err = suite.repo.GetOwnershipHistory(component.ID)
+	suite.NoError(err)
+	suite.Len(history, 1)
+}
+
+// TestCountByOwnerIDs tests counting the components of several teams at once
+func (suite *ComponentRepositoryTestSuite) TestCountByOwnerIDs() {
+	owner, other, idle := uuid.New(), uuid.New(), uuid.New()
+	for i, ownerID := range []uuid.UUID{owner, owner, other} {
+		component := suite.factories.Component.WithName(fmt.Sprintf("component-%d", i))
+		component.OwnerID = ownerID
+		suite.NoError(suite.repo.Create(component))
+	}
+
+	counts, err := suite.repo.CountByOwnerIDs([]uuid.UUID{owner, other, idle})
+	suite.NoError(err)
+	suite.Equal(map[uuid.UUID]int64{owner: 2, other: 1}, counts)
+
+	counts, err = suite.repo.CountByOwnerIDs(nil)
+	suite.NoError(err)
+	suite.Empty(counts)
+}
+
 // Run the test suite
 func TestComponentRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(ComponentRepositoryTestSuite))
@@ -43,9 +43,9 @@ func (r *DocumentationRepository) GetByTeamID(teamID uuid.UUID) ([]models.Docume
 	return docs, nil
 }
 
-// Update updates an existing documentation
+// Update updates an existing documentation. Returns ErrVersionConflict if it was updated since it was read.
 func (r *DocumentationRepository) Update(doc *models.Documentation) error {
-	return r.db.Save(doc).Error
+	return updateVersioned(r.db, doc, doc.ID, &doc.Version)
 }
 
 // Delete removes a documentation by ID (soft delete)
@@ -61,9 +61,9 @@ func (r *GroupRepository) GetByOrganizationID(orgID uuid.UUID, limit, offset int
 	return groups, total, nil
 }
 
-// Update updates a group using a map of updates
-func (r *GroupRepository) Update(id uuid.UUID, updates map[string]interface{}) error {
-	return r.db.Model(&models.Group{}).Where("id = ?", id).Updates(updates).Error
+// Update saves the group unless it was updated since it was read, see updateVersioned
+func (r *GroupRepository) Update(group *models.Group) error {
+	return updateVersioned(r.db, group, group.ID, &group.Version)
 }
 
 // Delete deletes a group
@@ -71,10 +71,13 @@ func (r *GroupRepository) Delete(id uuid.UUID) error {
 	return r.db.Delete(&models.Group{}, "id = ?", id).Error
 }
 
-// GetWithTeams retrieves a group with its teams
+// GetWithTeams retrieves a group with its teams and their members, each ordered by name
 func (r *GroupRepository) GetWithTeams(id uuid.UUID) (*models.Group, error) {
 	var group models.Group
-	err := r.db.Preload("Teams").First(&group, "id = ?", id).Error
+	err := r.db.
+		Preload("Teams", orderByName).
+		Preload("Teams.Users", orderByFullName).
+		First(&group, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
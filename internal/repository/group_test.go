@@ -208,7 +208,7 @@ func (suite *GroupRepositoryTestSuite) TestGetByOrganizationIDWithPagination() {
 	suite.GreaterOrEqual(total, int64(5))
 }
 
-// TestUpdate tests updating a group using map updates
+// TestUpdate tests updating a group
 func (suite *GroupRepositoryTestSuite) TestUpdate() {
 	// Create organization
 	org := suite.createOrganization()
@@ -220,14 +220,13 @@ func (suite *GroupRepositoryTestSuite) TestUpdate() {
 	suite.NoError(err)
 
 	// Update the group
-	updates := map[string]interface{}{
-		"title":       "Updated Group Title",
-		"description": "Updated group description",
-	}
-	err = suite.repo.Update(group.ID, updates)
+	group.Title = "Updated Group Title"
+	group.Description = "Updated group description"
+	err = suite.repo.Update(group)
 
 	// Assertions
 	suite.NoError(err)
+	suite.Equal(int64(2), group.Version)
 
 	// Retrieve updated group
 	updatedGroup, err := suite.repo.GetByID(group.ID)
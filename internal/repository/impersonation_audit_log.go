@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+
+	"gorm.io/gorm"
+)
+
+// ImpersonationAuditLogRepository handles database operations for the impersonation audit log
+type ImpersonationAuditLogRepository struct {
+	db *gorm.DB
+}
+
+// Ensure ImpersonationAuditLogRepository implements ImpersonationAuditLogRepositoryInterface
+var _ ImpersonationAuditLogRepositoryInterface = (*ImpersonationAuditLogRepository)(nil)
+
+// NewImpersonationAuditLogRepository creates a new impersonation audit log repository
+func NewImpersonationAuditLogRepository(db *gorm.DB) *ImpersonationAuditLogRepository {
+	return &ImpersonationAuditLogRepository{db: db}
+}
+
+// Create appends an entry to the audit log
+func (r *ImpersonationAuditLogRepository) Create(entry *models.ImpersonationAuditLog) error {
+	return r.db.Create(entry).Error
+}
+
+// GetFiltered retrieves audit entries newest first. Empty admin, email and session ID match every
+// entry, as do a zero since and a limit of 0.
+func (r *ImpersonationAuditLogRepository) GetFiltered(admin, email, sessionID string, since time.Time, limit int) ([]models.ImpersonationAuditLog, error) {
+	query := r.db.Model(&models.ImpersonationAuditLog{})
+	if admin != "" {
+		query = query.Where("LOWER(admin) = LOWER(?)", admin)
+	}
+	if email != "" {
+		query = query.Where("LOWER(email) = LOWER(?)", email)
+	}
+	if sessionID != "" {
+		query = query.Where("session_id = ?", sessionID)
+	}
+	if !since.IsZero() {
+		query = query.Where("created_at >= ?", since)
+	}
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var entries []models.ImpersonationAuditLog
+	if err := query.Order("created_at DESC").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+	"developer-portal-backend/internal/testutils"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// ImpersonationAuditLogRepositoryTestSuite tests the ImpersonationAuditLogRepository
+type ImpersonationAuditLogRepositoryTestSuite struct {
+	suite.Suite
+	baseTestSuite *testutils.BaseTestSuite
+	repo          *ImpersonationAuditLogRepository
+}
+
+// SetupSuite runs before all tests in the suite
+func (suite *ImpersonationAuditLogRepositoryTestSuite) SetupSuite() {
+	suite.baseTestSuite = testutils.SetupTestSuite(suite.T())
+	suite.repo = NewImpersonationAuditLogRepository(suite.baseTestSuite.DB)
+}
+
+// TearDownSuite runs after all tests in the suite
+func (suite *ImpersonationAuditLogRepositoryTestSuite) TearDownSuite() {
+	suite.baseTestSuite.TeardownTestSuite()
+}
+
+// SetupTest runs before each test
+func (suite *ImpersonationAuditLogRepositoryTestSuite) SetupTest() {
+	suite.baseTestSuite.SetupTest()
+}
+
+// TearDownTest runs after each test
+func (suite *ImpersonationAuditLogRepositoryTestSuite) TearDownTest() {
+	suite.baseTestSuite.TearDownTest()
+}
+
+func (suite *ImpersonationAuditLogRepositoryTestSuite) record(sessionID, admin, email, event string, at time.Time) {
+	suite.Require().NoError(suite.repo.Create(&models.ImpersonationAuditLog{
+		CreatedAt: at,
+		SessionID: sessionID,
+		Event:     event,
+		Admin:     admin,
+		Username:  "johndoe",
+		Email:     email,
+		Reason:    "support ticket",
+	}))
+}
+
+// TestGetFiltered tests filtering and ordering of audit entries
+func (suite *ImpersonationAuditLogRepositoryTestSuite) TestGetFiltered() {
+	now := time.Now().Truncate(time.Second)
+	suite.record("s1", "jane.admin", "john.doe@example.com", "start", now.Add(-2*time.Hour))
+	suite.record("s1", "jane.admin", "john.doe@example.com", "request", now.Add(-time.Hour))
+	suite.record("s2", "max.admin", "erika@example.com", "start", now)
+
+	entries, err := suite.repo.GetFiltered("", "", "", time.Time{}, 0)
+	suite.NoError(err)
+	suite.Require().Len(entries, 3)
+	suite.Equal("s2", entries[0].SessionID)
+
+	entries, err = suite.repo.GetFiltered("Jane.Admin", "", "", time.Time{}, 0)
+	suite.NoError(err)
+	suite.Len(entries, 2)
+
+	entries, err = suite.repo.GetFiltered("", "JOHN.DOE@example.com", "s1", now.Add(-90*time.Minute), 0)
+	suite.NoError(err)
+	suite.Require().Len(entries, 1)
+	suite.Equal("request", entries[0].Event)
+
+	entries, err = suite.repo.GetFiltered("", "", "", time.Time{}, 1)
+	suite.NoError(err)
+	suite.Len(entries, 1)
+}
+
+// TestImpersonationAuditLogRepositoryTestSuite runs the test suite
+func TestImpersonationAuditLogRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(ImpersonationAuditLogRepositoryTestSuite))
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"time"
+
 	"developer-portal-backend/internal/database/models"
 
 	"github.com/google/uuid"
@@ -28,10 +30,12 @@ type OrganizationRepositoryInterface interface {
 // UserRepositoryInterface defines the interface for member repository operations
 type UserRepositoryInterface interface {
 	Create(member *models.User) error
+	CreateBatch(members []*models.User) error
 	GetByID(id uuid.UUID) (*models.User, error)
 	GetByEmail(email string) (*models.User, error)
 	GetByName(name string) (*models.User, error)
 	GetByUserID(userID string) (*models.User, error)
+	GetDeletedByUserID(userID string) (*models.User, error)
 	GetAll(limit, offset int) ([]models.User, int64, error)
 	GetByOrganizationID(orgID uuid.UUID, limit, offset int) ([]models.User, int64, error)
 	GetWithOrganization(id uuid.UUID) (*models.User, error)
@@ -40,8 +44,10 @@ type UserRepositoryInterface interface {
 	GetActiveByOrganization(orgID uuid.UUID, limit, offset int) ([]models.User, int64, error)
 	GetUserIDsByPrefix(prefix string) ([]string, error)
 	GetExistingUserIDs(ids []string) ([]string, error)
+	GetTeamMemberships(teamID uuid.UUID, from, to time.Time) ([]models.TeamMembership, error)
+	GetMemberships(memberID uuid.UUID) ([]models.TeamMembership, error)
 	Update(member *models.User) error
-	Delete(id uuid.UUID) error
+	Delete(id uuid.UUID, deletedBy string) error
 }
 
 // GroupRepositoryInterface defines the interface for group repository operations
@@ -51,7 +57,7 @@ type GroupRepositoryInterface interface {
 	GetByName(orgID uuid.UUID, name string) (*models.Group, error)
 	GetByOrganizationID(orgID uuid.UUID, limit, offset int) ([]models.Group, int64, error)
 	Search(organizationID uuid.UUID, query string, limit, offset int) ([]models.Group, int64, error)
-	Update(id uuid.UUID, updates map[string]interface{}) error
+	Update(group *models.Group) error
 	Delete(id uuid.UUID) error
 	GetWithTeams(id uuid.UUID) (*models.Group, error)
 	GetWithOrganization(id uuid.UUID) (*models.Group, error)
@@ -76,6 +82,7 @@ type ProjectRepositoryInterface interface {
 	Create(project *models.Project) error
 	GetByID(id uuid.UUID) (*models.Project, error)
 	GetByName(name string) (*models.Project, error)
+	GetAll(limit, offset int) ([]models.Project, int64, error)
 	GetByOrganizationID(orgID uuid.UUID, limit, offset int) ([]models.Project, int64, error)
 	Update(project *models.Project) error
 	Delete(id uuid.UUID) error
@@ -88,8 +95,11 @@ type ComponentRepositoryInterface interface {
 	GetByName(projectID uuid.UUID, name string) (*models.Component, error)
 	GetByProjectID(projectID uuid.UUID, limit, offset int) ([]models.Component, int64, error)
 	GetByOwnerID(ownerID uuid.UUID, limit, offset int) ([]models.Component, int64, error)
+	CountByOwnerIDs(ownerIDs []uuid.UUID) (map[uuid.UUID]int64, error)
 	Update(component *models.Component) error
 	Delete(id uuid.UUID) error
+	TransferOwnership(component *models.Component, change *models.ComponentOwnershipChange) error
+	GetOwnershipHistory(componentID uuid.UUID) ([]models.ComponentOwnershipChange, error)
 }
 
 // LandscapeRepositoryInterface defines the interface for landscape repository operations
@@ -100,6 +110,8 @@ type LandscapeRepositoryInterface interface {
 	GetByOrganizationID(orgID uuid.UUID, limit, offset int) ([]models.Landscape, int64, error)
 	GetByStatus(status string, limit, offset int) ([]models.Landscape, int64, error)
 	GetActiveLandscapes(limit, offset int) ([]models.Landscape, int64, error)
+	GetLandscapesByProjectID(projectID uuid.UUID, limit, offset int) ([]models.Landscape, int64, error)
+	GetFiltered(filter LandscapeFilter, limit, offset int) ([]models.Landscape, int64, error)
 	Update(landscape *models.Landscape) error
 	Delete(id uuid.UUID) error
 }
@@ -114,6 +126,9 @@ type CategoryRepositoryInterface interface {
 type LinkRepositoryInterface interface {
 	GetByOwner(owner uuid.UUID) ([]models.Link, error)
 	GetByIDs(ids []uuid.UUID) ([]models.Link, error)
+	GetAll() ([]models.Link, error)
+	GetBroken() ([]models.Link, error)
+	UpdateCheck(link *models.Link) error
 	Create(link *models.Link) error
 	Delete(id uuid.UUID) error
 }
@@ -127,3 +142,88 @@ type DocumentationRepositoryInterface interface {
 	Delete(id uuid.UUID) error
 	GetAll(limit, offset int) ([]models.Documentation, int64, error)
 }
+
+// RefreshTokenRepositoryInterface defines the interface for refresh token repository operations
+type RefreshTokenRepositoryInterface interface {
+	Create(token *models.RefreshToken) error
+	GetByTokenHash(tokenHash string) (*models.RefreshToken, error)
+	GetLatestActiveByUser(userID int64, provider string, now time.Time) (*models.RefreshToken, error)
+	Rotate(oldTokenHash string, newToken *models.RefreshToken) error
+	DeleteByTokenHash(tokenHash string) error
+	DeleteByUser(userID int64, provider string) (int64, error)
+	DeleteByEmail(email string) (int64, error)
+	DeleteExpired(before time.Time) (int64, error)
+	GetActiveWithoutAccessTokenPrefix(prefix string, now time.Time, limit int) ([]models.RefreshToken, error)
+	UpdateAccessToken(tokenHash, accessToken string) error
+}
+
+// TokenRevocationRepositoryInterface defines the interface for JWT denylist repository operations
+type TokenRevocationRepositoryInterface interface {
+	Create(revocation *models.TokenRevocation) error
+	GetActiveSince(since, now time.Time) ([]models.TokenRevocation, error)
+	DeleteExpired(before time.Time) (int64, error)
+}
+
+// OAuthStateRepositoryInterface defines the interface for OAuth state repository operations
+type OAuthStateRepositoryInterface interface {
+	Create(state *models.OAuthState) error
+	Consume(stateHash string) (*models.OAuthState, error)
+	DeleteExpired(before time.Time) (int64, error)
+}
+
+// APITokenRepositoryInterface defines the interface for API token repository operations
+type APITokenRepositoryInterface interface {
+	Create(token *models.APIToken) error
+	GetByID(id uuid.UUID) (*models.APIToken, error)
+	GetByTokenHash(tokenHash string) (*models.APIToken, error)
+	GetPersonalByUser(userID int64, provider string) ([]models.APIToken, error)
+	GetByServiceAccount(name string) ([]models.APIToken, error)
+	UpdateLastUsed(id uuid.UUID, at time.Time) error
+	Delete(id uuid.UUID) error
+	DeletePersonalByEmail(email string) (int64, error)
+}
+
+// SigningKeyRepositoryInterface defines the interface for JWT signing key repository operations
+type SigningKeyRepositoryInterface interface {
+	Create(key *models.SigningKey) error
+	GetActive(now time.Time) ([]models.SigningKey, error)
+	DeleteExpired(before time.Time) (int64, error)
+}
+
+// ServiceAccountRepositoryInterface defines the interface for service account repository operations
+type ServiceAccountRepositoryInterface interface {
+	Create(account *models.ServiceAccount) error
+	GetByName(name string) (*models.ServiceAccount, error)
+	GetAll() ([]models.ServiceAccount, error)
+	Delete(name string) error
+}
+
+// UserIdentityRepositoryInterface defines the interface for linked provider identity repository operations
+type UserIdentityRepositoryInterface interface {
+	Upsert(identity *models.UserIdentity) error
+	GetByProviderAccount(provider string, providerUserID int64) (*models.UserIdentity, error)
+	GetByMemberID(memberID string) ([]models.UserIdentity, error)
+	Delete(memberID, provider string) error
+}
+
+// ImpersonationAuditLogRepositoryInterface defines the interface for impersonation audit log repository operations
+type ImpersonationAuditLogRepositoryInterface interface {
+	Create(entry *models.ImpersonationAuditLog) error
+	GetFiltered(admin, email, sessionID string, since time.Time, limit int) ([]models.ImpersonationAuditLog, error)
+}
+
+// TrashRepositoryInterface defines the interface for soft-deleted catalog entity operations
+type TrashRepositoryInterface interface {
+	ListDeleted(kind TrashKind, limit, offset int) ([]TrashItem, int64, error)
+	GetDeleted(kind TrashKind, id uuid.UUID) (*TrashItem, error)
+	IsActive(kind TrashKind, id uuid.UUID) (bool, error)
+	Restore(kind TrashKind, id uuid.UUID) error
+	PurgeDeletedBefore(cutoff time.Time) (int64, error)
+}
+
+// UserOffboardingRepositoryInterface defines the interface for user offboarding operations
+type UserOffboardingRepositoryInterface interface {
+	GetOwnedResources(userID uuid.UUID, username string) (*OwnedResources, error)
+	Offboard(record *models.UserOffboarding) error
+	GetHistory(limit int) ([]models.UserOffboarding, error)
+}
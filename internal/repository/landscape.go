@@ -112,9 +112,9 @@ func (r *LandscapeRepository) GetActiveLandscapes(limit, offset int) ([]models.L
 	return landscapes, total, nil
 }
 
-// Update updates a landscape
+// Update updates a landscape. Returns ErrVersionConflict if it was updated since it was read.
 func (r *LandscapeRepository) Update(landscape *models.Landscape) error {
-	return r.db.Save(landscape).Error
+	return updateVersioned(r.db, landscape, landscape.ID, &landscape.Version)
 }
 
 // Delete deletes a landscape
@@ -166,6 +166,61 @@ func (r *LandscapeRepository) Search(orgID uuid.UUID, q string, limit, offset in
 	return landscapes, total, nil
 }
 
+// LandscapeFilter narrows GetFiltered; empty fields match every landscape
+type LandscapeFilter struct {
+	Query         string // matched against name, title and description
+	Domains       []string
+	Environments  []string
+	ProjectID     *uuid.UUID
+	CentralRegion *bool // metadata flag "central-region"
+	Extension     *bool // metadata flag "extension"
+}
+
+// GetFiltered retrieves a page of the landscapes matching filter, ordered by name and ID so that
+// pages are stable
+func (r *LandscapeRepository) GetFiltered(filter LandscapeFilter, limit, offset int) ([]models.Landscape, int64, error) {
+	query := r.db.Model(&models.Landscape{})
+	if filter.Query != "" {
+		like := "%" + filter.Query + "%"
+		query = query.Where("(name ILIKE ? OR title ILIKE ? OR description ILIKE ?)", like, like, like)
+	}
+	if len(filter.Domains) > 0 {
+		query = query.Where("domain IN ?", filter.Domains)
+	}
+	if len(filter.Environments) > 0 {
+		query = query.Where("environment IN ?", filter.Environments)
+	}
+	if filter.ProjectID != nil {
+		query = query.Where("project_id = ?", *filter.ProjectID)
+	}
+	query = whereMetadataFlag(query, "central-region", filter.CentralRegion)
+	query = whereMetadataFlag(query, "extension", filter.Extension)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var landscapes []models.Landscape
+	if err := query.Order("name ASC, id ASC").Limit(limit).Offset(offset).Find(&landscapes).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return landscapes, total, nil
+}
+
+// whereMetadataFlag restricts query to rows whose boolean metadata flag has the given value.
+// A missing or non-boolean flag counts as false.
+func whereMetadataFlag(query *gorm.DB, key string, value *bool) *gorm.DB {
+	if value == nil {
+		return query
+	}
+	if *value {
+		return query.Where("metadata -> ? = 'true'::jsonb", key)
+	}
+	return query.Where("(metadata -> ? = 'true'::jsonb) IS NOT TRUE", key)
+}
+
 // GetProjectCount returns the number of projects using a landscape (1 if ProjectID set)
 func (r *LandscapeRepository) GetProjectCount(landscapeID uuid.UUID) (int64, error) {
 	var l models.Landscape
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"encoding/json"
 	"testing"
 
 	"developer-portal-backend/internal/testutils"
@@ -392,6 +393,61 @@ func (suite *LandscapeRepositoryTestSuite) TestGetActiveLandscapes() {
 }
 */
 
+// TestGetFiltered tests filtering landscapes by columns and metadata flags
+func (suite *LandscapeRepositoryTestSuite) TestGetFiltered() {
+	project := suite.factories.Project.Create()
+	suite.Require().NoError(NewProjectRepository(suite.baseTestSuite.DB).Create(project))
+
+	for _, l := range []struct {
+		name, domain, environment, metadata string
+	}{
+		{"eu10", "eu10.example.com", "live", `{"central-region": true}`},
+		{"eu11", "eu11.example.com", "live", `{"extension": true}`},
+		{"eu12", "eu12.example.com", "live", `{"central-region": "true"}`},
+		{"dev", "dev.example.com", "dev", `{}`},
+	} {
+		landscape := suite.factories.Landscape.WithName(l.name)
+		landscape.ProjectID = project.ID
+		landscape.Domain = l.domain
+		landscape.Environment = l.environment
+		landscape.Metadata = json.RawMessage(l.metadata)
+		suite.Require().NoError(suite.repo.Create(landscape))
+	}
+	other := suite.factories.Landscape.WithName("other")
+	other.Environment = "live"
+	suite.Require().NoError(suite.repo.Create(other))
+
+	names := func(filter LandscapeFilter, limit, offset int) ([]string, int64) {
+		landscapes, total, err := suite.repo.GetFiltered(filter, limit, offset)
+		suite.Require().NoError(err)
+		result := make([]string, len(landscapes))
+		for i, l := range landscapes {
+			result[i] = l.Name
+		}
+		return result, total
+	}
+	yes, no := true, false
+
+	got, total := names(LandscapeFilter{ProjectID: &project.ID, Environments: []string{"live"}}, 2, 0)
+	suite.Equal([]string{"eu10", "eu11"}, got)
+	suite.Equal(int64(3), total)
+	got, _ = names(LandscapeFilter{ProjectID: &project.ID, Environments: []string{"live"}}, 2, 2)
+	suite.Equal([]string{"eu12"}, got)
+
+	got, _ = names(LandscapeFilter{Domains: []string{"dev.example.com", "eu11.example.com"}}, 10, 0)
+	suite.Equal([]string{"dev", "eu11"}, got)
+
+	// Only a JSON true sets a flag
+	got, _ = names(LandscapeFilter{CentralRegion: &yes}, 10, 0)
+	suite.Equal([]string{"eu10"}, got)
+	got, _ = names(LandscapeFilter{ProjectID: &project.ID, CentralRegion: &no, Extension: &no}, 10, 0)
+	suite.Equal([]string{"dev", "eu12"}, got)
+
+	got, total = names(LandscapeFilter{Query: "EU1", Extension: &yes}, 10, 0)
+	suite.Equal([]string{"eu11"}, got)
+	suite.Equal(int64(1), total)
+}
+
 // Run the test suite
 func TestLandscapeRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(LandscapeRepositoryTestSuite))
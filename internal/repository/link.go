@@ -50,3 +50,33 @@ func (r *LinkRepository) Create(link *models.Link) error {
 func (r *LinkRepository) Delete(id uuid.UUID) error {
 	return r.db.Delete(&models.Link{}, "id = ?", id).Error
 }
+
+// GetAll retrieves all links, those checked longest ago first and never checked ones before them
+func (r *LinkRepository) GetAll() ([]models.Link, error) {
+	var links []models.Link
+	if err := r.db.Order("checked_at ASC NULLS FIRST").Find(&links).Error; err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// GetBroken retrieves the links found broken by their latest check
+func (r *LinkRepository) GetBroken() ([]models.Link, error) {
+	var links []models.Link
+	if err := r.db.Where("broken").Order("title ASC").Find(&links).Error; err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// UpdateCheck saves the outcome of a dead-link check of the link. It is not an edit of the link,
+// so neither its version nor its updated_at change.
+func (r *LinkRepository) UpdateCheck(link *models.Link) error {
+	return r.db.Model(&models.Link{}).Where("id = ?", link.ID).UpdateColumns(map[string]interface{}{
+		"checked_at":       link.CheckedAt,
+		"check_status":     link.CheckStatus,
+		"check_error":      link.CheckError,
+		"response_time_ms": link.ResponseTimeMs,
+		"broken":           link.Broken,
+	}).Error
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"testing"
+	"time"
 
 	"developer-portal-backend/internal/database/models"
 	"developer-portal-backend/internal/testutils"
@@ -167,6 +168,38 @@ func (suite *LinkRepositoryTestSuite) TestDeleteNotFound() {
 	suite.NoError(err)
 }
 
+// TestUpdateCheck tests saving link checks, and listing unchecked links first and broken links
+func (suite *LinkRepositoryTestSuite) TestUpdateCheck() {
+	cat := suite.createCategory("cat-5", "Category 5", "icon-5", "purple")
+	owner := uuid.New()
+
+	checked := suite.createLink(owner, "Checked", "https://example.com/checked", cat.ID, "")
+	unchecked := suite.createLink(owner, "Unchecked", "https://example.com/unchecked", cat.ID, "")
+
+	checkedAt := time.Now()
+	checked.CheckedAt = &checkedAt
+	checked.CheckStatus = 404
+	checked.ResponseTimeMs = 120
+	checked.Broken = true
+	suite.NoError(suite.repo.UpdateCheck(checked))
+
+	links, err := suite.repo.GetAll()
+	suite.NoError(err)
+	suite.Require().Len(links, 2)
+	suite.Equal(unchecked.ID, links[0].ID)
+	suite.Equal(404, links[1].CheckStatus)
+	suite.Equal(int64(120), links[1].ResponseTimeMs)
+	suite.NotNil(links[1].CheckedAt)
+	// A check is not an edit
+	suite.Equal(checked.Version, links[1].Version)
+	suite.WithinDuration(checked.UpdatedAt, links[1].UpdatedAt, time.Millisecond)
+
+	broken, err := suite.repo.GetBroken()
+	suite.NoError(err)
+	suite.Require().Len(broken, 1)
+	suite.Equal(checked.ID, broken[0].ID)
+}
+
 // Run the test suite
 func TestLinkRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(LinkRepositoryTestSuite))
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OAuthStateRepository handles database operations for issued OAuth states
+type OAuthStateRepository struct {
+	db *gorm.DB
+}
+
+// Ensure OAuthStateRepository implements OAuthStateRepositoryInterface
+var _ OAuthStateRepositoryInterface = (*OAuthStateRepository)(nil)
+
+// NewOAuthStateRepository creates a new OAuth state repository
+func NewOAuthStateRepository(db *gorm.DB) *OAuthStateRepository {
+	return &OAuthStateRepository{db: db}
+}
+
+// Create inserts a newly issued state
+func (r *OAuthStateRepository) Create(state *models.OAuthState) error {
+	return r.db.Create(state).Error
+}
+
+// Consume deletes the state with the given hash and returns it. Returns gorm.ErrRecordNotFound
+// if no such state exists, which also happens when another request already consumed it.
+func (r *OAuthStateRepository) Consume(stateHash string) (*models.OAuthState, error) {
+	var states []models.OAuthState
+	res := r.db.Clauses(clause.Returning{}).Where("state_hash = ?", stateHash).Delete(&states)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 || len(states) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &states[0], nil
+}
+
+// DeleteExpired removes all states that expired before the given time
+// and returns the number of removed rows
+func (r *OAuthStateRepository) DeleteExpired(before time.Time) (int64, error) {
+	res := r.db.Where("expires_at <= ?", before).Delete(&models.OAuthState{})
+	return res.RowsAffected, res.Error
+}
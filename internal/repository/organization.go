@@ -71,9 +71,9 @@ func (r *OrganizationRepository) GetAll(limit, offset int) ([]models.Organizatio
 	return orgs, total, nil
 }
 
-// Update updates an organization
+// Update updates an organization. Returns ErrVersionConflict if it was updated since it was read.
 func (r *OrganizationRepository) Update(org *models.Organization) error {
-	return r.db.Save(org).Error
+	return updateVersioned(r.db, org, org.ID, &org.Version)
 }
 
 // Delete deletes an organization
@@ -131,18 +131,27 @@ func (r *OrganizationRepository) GetWithLandscapes(id uuid.UUID) (*models.Organi
 	return &org, nil
 }
 
-// GetWithAllRelations retrieves an organization with all its relations
+// GetWithAllRelations retrieves an organization with its groups, their teams and the teams' members,
+// each ordered by name
 func (r *OrganizationRepository) GetWithAllRelations(id uuid.UUID) (*models.Organization, error) {
 	var org models.Organization
 	err := r.db.
-		Preload("Users").
-		Preload("Groups").
-		Preload("Projects").
-		Preload("Components").
-		Preload("Landscapes").
+		Preload("Groups", orderByName).
+		Preload("Groups.Teams", orderByName).
+		Preload("Groups.Teams.Users", orderByFullName).
 		First(&org, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
 	return &org, nil
 }
+
+// orderByName orders preloaded organizations, groups or teams by name
+func orderByName(db *gorm.DB) *gorm.DB {
+	return db.Order("name ASC")
+}
+
+// orderByFullName orders preloaded users by first and last name
+func orderByFullName(db *gorm.DB) *gorm.DB {
+	return db.Order("first_name ASC, last_name ASC")
+}
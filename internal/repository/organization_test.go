@@ -219,6 +219,32 @@ func (suite *OrganizationRepositoryTestSuite) TestUpdate() {
 	suite.True(updatedOrg.UpdatedAt.After(updatedOrg.CreatedAt))
 }
 
+// TestUpdateVersionConflict tests that an update based on an outdated version is rejected
+func (suite *OrganizationRepositoryTestSuite) TestUpdateVersionConflict() {
+	org := suite.factories.Organization.Create()
+	suite.Require().NoError(suite.repo.Create(org))
+	suite.Equal(int64(1), org.Version)
+
+	stale, err := suite.repo.GetByID(org.ID)
+	suite.Require().NoError(err)
+
+	org.Title = "First"
+	suite.Require().NoError(suite.repo.Update(org))
+	suite.Equal(int64(2), org.Version)
+
+	stale.Title = "Second"
+	suite.ErrorIs(suite.repo.Update(stale), ErrVersionConflict)
+	suite.Equal(int64(1), stale.Version, "the version of a rejected update is kept")
+
+	stored, err := suite.repo.GetByID(org.ID)
+	suite.Require().NoError(err)
+	suite.Equal("First", stored.Title)
+	suite.Equal(int64(2), stored.Version)
+
+	missing := suite.factories.Organization.Create()
+	suite.ErrorIs(suite.repo.Update(missing), gorm.ErrRecordNotFound)
+}
+
 // TestDelete tests deleting an organization
 func (suite *OrganizationRepositoryTestSuite) TestDelete() {
 	// Create test organization
@@ -256,21 +282,55 @@ func (suite *OrganizationRepositoryTestSuite) TestDeleteNotFound() {
 // 	// Groups relationship removed in new schema
 // }
 
-// TestGetWithAllRelations tests retrieving organization (MODIFIED - many relationships removed)
+// TestGetWithAllRelations tests retrieving an organization with its groups, teams and members
 func (suite *OrganizationRepositoryTestSuite) TestGetWithAllRelations() {
-	// Create organization
 	org := suite.factories.Organization.Create()
 	err := suite.repo.Create(org)
 	suite.NoError(err)
 
-	// NOTE: In new schema:
-	// - Members relationship removed from Organization
-	// - Groups relationship removed from Organization  
-	// - Project no longer has OrganizationID
-	// - Component/Landscape no longer have OrganizationID, use ProjectID instead
-	
-	// SKIP: GetWithAllRelations tries to preload relationships that no longer exist
-	suite.T().Skip("GetWithAllRelations method tries to preload Components/Projects/Members/Groups which are not relationships on Organization in new schema")
+	groupRepo := NewGroupRepository(suite.baseTestSuite.DB)
+	teamRepo := NewTeamRepository(suite.baseTestSuite.DB)
+	userRepo := NewUserRepository(suite.baseTestSuite.DB)
+
+	platform := suite.factories.Group.WithName("platform")
+	platform.OrgID = org.ID
+	suite.NoError(groupRepo.Create(platform))
+	apps := suite.factories.Group.WithName("apps")
+	apps.OrgID = org.ID
+	suite.NoError(groupRepo.Create(apps))
+
+	team := suite.factories.Team.WithName("team-coe")
+	team.GroupID = platform.ID
+	suite.NoError(teamRepo.Create(team))
+
+	zoe := suite.factories.User.WithTeam(team.ID)
+	zoe.FirstName = "Zoe"
+	zoe.Email = "zoe@test.com"
+	suite.NoError(userRepo.Create(zoe))
+	anna := suite.factories.User.WithTeam(team.ID)
+	anna.FirstName = "Anna"
+	anna.Email = "anna@test.com"
+	suite.NoError(userRepo.Create(anna))
+
+	// Deleted members are left out
+	gone := suite.factories.User.WithTeam(team.ID)
+	gone.Email = "gone@test.com"
+	suite.NoError(userRepo.Create(gone))
+	suite.NoError(userRepo.Delete(gone.ID, "admin"))
+
+	result, err := suite.repo.GetWithAllRelations(org.ID)
+	suite.NoError(err)
+	suite.Require().Len(result.Groups, 2)
+	suite.Equal("apps", result.Groups[0].Name)
+	suite.Empty(result.Groups[0].Teams)
+	suite.Require().Len(result.Groups[1].Teams, 1)
+	members := result.Groups[1].Teams[0].Users
+	suite.Require().Len(members, 2)
+	suite.Equal("Anna", members[0].FirstName)
+	suite.Equal("Zoe", members[1].FirstName)
+
+	_, err = suite.repo.GetWithAllRelations(uuid.New())
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
 }
 
 // Run the test suite
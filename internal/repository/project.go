@@ -42,6 +42,25 @@ func (r *ProjectRepository) GetByName(name string) (*models.Project, error) {
 	return &project, nil
 }
 
+// GetAll retrieves all projects ordered by name with pagination
+func (r *ProjectRepository) GetAll(limit, offset int) ([]models.Project, int64, error) {
+	var projects []models.Project
+	var total int64
+
+	// Get total count
+	if err := r.db.Model(&models.Project{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Get paginated results
+	err := r.db.Order("name").Limit(limit).Offset(offset).Find(&projects).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return projects, total, nil
+}
+
 // GetByOrganizationID retrieves all projects for an organization with pagination
 func (r *ProjectRepository) GetByOrganizationID(orgID uuid.UUID, limit, offset int) ([]models.Project, int64, error) {
 	var projects []models.Project
@@ -65,9 +84,9 @@ func (r *ProjectRepository) GetByOrganizationID(orgID uuid.UUID, limit, offset i
 
 // GetActiveProjects retrieves all active projects for an organization
 
-// Update updates a project
+// Update updates a project. Returns ErrVersionConflict if it was updated since it was read.
 func (r *ProjectRepository) Update(project *models.Project) error {
-	return r.db.Save(project).Error
+	return updateVersioned(r.db, project, project.ID, &project.Version)
 }
 
 // Delete deletes a project
@@ -182,7 +201,7 @@ func (r *ProjectRepository) GetProjectsWithCounts(orgID uuid.UUID, limit, offset
 		FROM projects p
 		LEFT JOIN project_components pc ON p.id = pc.project_id
 		LEFT JOIN project_landscapes pl ON p.id = pl.project_id
-		WHERE p.organization_id = ?
+		WHERE p.organization_id = ? AND p.deleted_at IS NULL
 		GROUP BY p.id
 		ORDER BY p.created_at DESC
 		LIMIT ? OFFSET ?
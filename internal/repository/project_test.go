@@ -238,6 +238,21 @@ func (suite *ProjectRepositoryTestSuite) TestGetByNameNotFound() {
 	suite.Nil(project)
 }
 
+// TestGetAll tests retrieving a page of all projects ordered by name
+func (suite *ProjectRepositoryTestSuite) TestGetAll() {
+	for _, name := range []string{"usrv", "cis20", "neo"} {
+		suite.Require().NoError(suite.repo.Create(suite.factories.Project.WithName(name)))
+	}
+
+	projects, total, err := suite.repo.GetAll(2, 1)
+
+	suite.NoError(err)
+	suite.Equal(int64(3), total)
+	suite.Require().Len(projects, 2)
+	suite.Equal("neo", projects[0].Name)
+	suite.Equal("usrv", projects[1].Name)
+}
+
 // TestGetByStatus tests retrieving projects by status (REMOVED - Status field no longer on Project)
 // func (suite *ProjectRepositoryTestSuite) TestGetByStatus() {
 // 	// NOTE: Status field removed from Project model in new schema
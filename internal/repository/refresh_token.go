@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+
+	"gorm.io/gorm"
+)
+
+// RefreshTokenRepository handles database operations for refresh tokens
+type RefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+// Ensure RefreshTokenRepository implements RefreshTokenRepositoryInterface
+var _ RefreshTokenRepositoryInterface = (*RefreshTokenRepository)(nil)
+
+// NewRefreshTokenRepository creates a new refresh token repository
+func NewRefreshTokenRepository(db *gorm.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{db: db}
+}
+
+// Create inserts a new refresh token
+func (r *RefreshTokenRepository) Create(token *models.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+// GetByTokenHash retrieves a refresh token by the hash of its value
+func (r *RefreshTokenRepository) GetByTokenHash(tokenHash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	if err := r.db.First(&token, "token_hash = ?", tokenHash).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// GetLatestActiveByUser retrieves the most recently issued, not yet expired refresh token
+// for the given provider user
+func (r *RefreshTokenRepository) GetLatestActiveByUser(userID int64, provider string, now time.Time) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := r.db.Where("user_id = ? AND provider = ? AND expires_at > ?", userID, provider, now).
+		Order("created_at DESC").
+		First(&token).Error
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// Rotate atomically replaces the refresh token identified by oldTokenHash with newToken.
+// Returns gorm.ErrRecordNotFound if the old token no longer exists, which happens when
+// another replica already rotated it.
+func (r *RefreshTokenRepository) Rotate(oldTokenHash string, newToken *models.RefreshToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("token_hash = ?", oldTokenHash).Delete(&models.RefreshToken{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Create(newToken).Error
+	})
+}
+
+// DeleteByTokenHash removes a refresh token by the hash of its value
+func (r *RefreshTokenRepository) DeleteByTokenHash(tokenHash string) error {
+	return r.db.Where("token_hash = ?", tokenHash).Delete(&models.RefreshToken{}).Error
+}
+
+// DeleteByUser removes all refresh tokens of a provider user and returns the number of removed rows
+func (r *RefreshTokenRepository) DeleteByUser(userID int64, provider string) (int64, error) {
+	res := r.db.Where("user_id = ? AND provider = ?", userID, provider).Delete(&models.RefreshToken{})
+	return res.RowsAffected, res.Error
+}
+
+// DeleteByEmail removes all refresh tokens issued to the given email across all providers
+// and returns the number of removed rows
+func (r *RefreshTokenRepository) DeleteByEmail(email string) (int64, error) {
+	res := r.db.Where("LOWER(email) = LOWER(?)", email).Delete(&models.RefreshToken{})
+	return res.RowsAffected, res.Error
+}
+
+// DeleteExpired removes all refresh tokens that expired before the given time
+// and returns the number of removed rows
+func (r *RefreshTokenRepository) DeleteExpired(before time.Time) (int64, error) {
+	res := r.db.Where("expires_at <= ?", before).Delete(&models.RefreshToken{})
+	return res.RowsAffected, res.Error
+}
+
+// GetActiveWithoutAccessTokenPrefix retrieves up to limit not yet expired refresh tokens whose
+// access token does not start with prefix. The prefix must not contain LIKE wildcards.
+func (r *RefreshTokenRepository) GetActiveWithoutAccessTokenPrefix(prefix string, now time.Time, limit int) ([]models.RefreshToken, error) {
+	var tokens []models.RefreshToken
+	err := r.db.Where("expires_at > ? AND access_token NOT LIKE ?", now, prefix+"%").
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&tokens).Error
+	if err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// UpdateAccessToken replaces the access token stored with a refresh token
+func (r *RefreshTokenRepository) UpdateAccessToken(tokenHash, accessToken string) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("token_hash = ?", tokenHash).
+		Update("access_token", accessToken).Error
+}
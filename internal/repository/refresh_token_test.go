@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+	"developer-portal-backend/internal/testutils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+// RefreshTokenRepositoryTestSuite tests the RefreshTokenRepository
+type RefreshTokenRepositoryTestSuite struct {
+	suite.Suite
+	baseTestSuite *testutils.BaseTestSuite
+	repo          *RefreshTokenRepository
+}
+
+// SetupSuite runs before all tests in the suite
+func (suite *RefreshTokenRepositoryTestSuite) SetupSuite() {
+	suite.baseTestSuite = testutils.SetupTestSuite(suite.T())
+	suite.repo = NewRefreshTokenRepository(suite.baseTestSuite.DB)
+}
+
+// TearDownSuite runs after all tests in the suite
+func (suite *RefreshTokenRepositoryTestSuite) TearDownSuite() {
+	suite.baseTestSuite.TeardownTestSuite()
+}
+
+// SetupTest runs before each test
+func (suite *RefreshTokenRepositoryTestSuite) SetupTest() {
+	suite.baseTestSuite.SetupTest()
+}
+
+// TearDownTest runs after each test
+func (suite *RefreshTokenRepositoryTestSuite) TearDownTest() {
+	suite.baseTestSuite.TearDownTest()
+}
+
+// helper to build a refresh token
+func (suite *RefreshTokenRepositoryTestSuite) newToken(userID int64, provider string, expiresAt time.Time) *models.RefreshToken {
+	return &models.RefreshToken{
+		TokenHash:   uuid.New().String(),
+		UserID:      userID,
+		Username:    "testuser",
+		Email:       "test@example.com",
+		Provider:    provider,
+		AccessToken: "gho_access_token",
+		ExpiresAt:   expiresAt,
+	}
+}
+
+// TestCreateAndGetByTokenHash tests creating a token and reading it back by hash
+func (suite *RefreshTokenRepositoryTestSuite) TestCreateAndGetByTokenHash() {
+	token := suite.newToken(42, "githubtools", time.Now().Add(time.Hour))
+
+	err := suite.repo.Create(token)
+	suite.NoError(err)
+	suite.NotEqual(uuid.Nil, token.ID)
+
+	found, err := suite.repo.GetByTokenHash(token.TokenHash)
+	suite.NoError(err)
+	suite.Equal(int64(42), found.UserID)
+	suite.Equal("gho_access_token", found.AccessToken)
+}
+
+// TestGetByTokenHashNotFound tests retrieving an unknown token
+func (suite *RefreshTokenRepositoryTestSuite) TestGetByTokenHashNotFound() {
+	found, err := suite.repo.GetByTokenHash("unknown")
+	suite.Error(err)
+	suite.Nil(found)
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+// TestGetLatestActiveByUser tests that the newest non-expired token for the user/provider is returned
+func (suite *RefreshTokenRepositoryTestSuite) TestGetLatestActiveByUser() {
+	older := suite.newToken(7, "githubtools", time.Now().Add(time.Hour))
+	older.CreatedAt = time.Now().Add(-2 * time.Hour)
+	suite.NoError(suite.repo.Create(older))
+
+	newer := suite.newToken(7, "githubtools", time.Now().Add(time.Hour))
+	newer.AccessToken = "newer_access_token"
+	suite.NoError(suite.repo.Create(newer))
+
+	expired := suite.newToken(7, "githubtools", time.Now().Add(-time.Minute))
+	suite.NoError(suite.repo.Create(expired))
+
+	otherProvider := suite.newToken(7, "githubwdf", time.Now().Add(time.Hour))
+	suite.NoError(suite.repo.Create(otherProvider))
+
+	found, err := suite.repo.GetLatestActiveByUser(7, "githubtools", time.Now())
+	suite.NoError(err)
+	suite.Equal("newer_access_token", found.AccessToken)
+
+	_, err = suite.repo.GetLatestActiveByUser(8, "githubtools", time.Now())
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+// TestRotate tests that a token can only be rotated once
+func (suite *RefreshTokenRepositoryTestSuite) TestRotate() {
+	old := suite.newToken(1, "githubtools", time.Now().Add(time.Hour))
+	suite.NoError(suite.repo.Create(old))
+
+	replacement := suite.newToken(1, "githubtools", time.Now().Add(time.Hour))
+	err := suite.repo.Rotate(old.TokenHash, replacement)
+	suite.NoError(err)
+
+	_, err = suite.repo.GetByTokenHash(old.TokenHash)
+	suite.Equal(gorm.ErrRecordNotFound, err)
+	_, err = suite.repo.GetByTokenHash(replacement.TokenHash)
+	suite.NoError(err)
+
+	// A second rotation of the same old token must fail and must not insert anything
+	second := suite.newToken(1, "githubtools", time.Now().Add(time.Hour))
+	err = suite.repo.Rotate(old.TokenHash, second)
+	suite.Equal(gorm.ErrRecordNotFound, err)
+	_, err = suite.repo.GetByTokenHash(second.TokenHash)
+	suite.Equal(gorm.ErrRecordNotFound, err)
+}
+
+// TestDeleteByTokenHash tests deleting a token
+func (suite *RefreshTokenRepositoryTestSuite) TestDeleteByTokenHash() {
+	token := suite.newToken(1, "githubtools", time.Now().Add(time.Hour))
+	suite.NoError(suite.repo.Create(token))
+
+	suite.NoError(suite.repo.DeleteByTokenHash(token.TokenHash))
+	_, err := suite.repo.GetByTokenHash(token.TokenHash)
+	suite.Equal(gorm.ErrRecordNotFound, err)
+
+	// Deleting again is a no-op
+	suite.NoError(suite.repo.DeleteByTokenHash(token.TokenHash))
+}
+
+// TestDeleteExpired tests purging expired tokens
+func (suite *RefreshTokenRepositoryTestSuite) TestDeleteExpired() {
+	suite.NoError(suite.repo.Create(suite.newToken(1, "githubtools", time.Now().Add(-time.Hour))))
+	suite.NoError(suite.repo.Create(suite.newToken(2, "githubtools", time.Now().Add(-time.Minute))))
+	active := suite.newToken(3, "githubtools", time.Now().Add(time.Hour))
+	suite.NoError(suite.repo.Create(active))
+
+	removed, err := suite.repo.DeleteExpired(time.Now())
+	suite.NoError(err)
+	suite.Equal(int64(2), removed)
+
+	_, err = suite.repo.GetByTokenHash(active.TokenHash)
+	suite.NoError(err)
+}
+
+// TestGetActiveWithoutAccessTokenPrefix tests finding access tokens not sealed with the current key
+func (suite *RefreshTokenRepositoryTestSuite) TestGetActiveWithoutAccessTokenPrefix() {
+	plaintext := suite.newToken(1, "githubtools", time.Now().Add(time.Hour))
+	suite.NoError(suite.repo.Create(plaintext))
+	retired := suite.newToken(2, "githubtools", time.Now().Add(time.Hour))
+	retired.AccessToken = "enc1:v1:wrapped:sealed"
+	suite.NoError(suite.repo.Create(retired))
+	current := suite.newToken(3, "githubtools", time.Now().Add(time.Hour))
+	current.AccessToken = "enc1:v2:wrapped:sealed"
+	suite.NoError(suite.repo.Create(current))
+	suite.NoError(suite.repo.Create(suite.newToken(4, "githubtools", time.Now().Add(-time.Hour))))
+
+	stale, err := suite.repo.GetActiveWithoutAccessTokenPrefix("enc1:v2:", time.Now(), 10)
+	suite.NoError(err)
+	suite.Require().Len(stale, 2)
+	suite.Equal(plaintext.TokenHash, stale[0].TokenHash)
+	suite.Equal(retired.TokenHash, stale[1].TokenHash)
+
+	stale, err = suite.repo.GetActiveWithoutAccessTokenPrefix("enc1:v2:", time.Now(), 1)
+	suite.NoError(err)
+	suite.Len(stale, 1)
+}
+
+// TestUpdateAccessToken tests replacing the stored access token
+func (suite *RefreshTokenRepositoryTestSuite) TestUpdateAccessToken() {
+	token := suite.newToken(1, "githubtools", time.Now().Add(time.Hour))
+	suite.NoError(suite.repo.Create(token))
+
+	suite.NoError(suite.repo.UpdateAccessToken(token.TokenHash, "enc1:v2:wrapped:sealed"))
+	found, err := suite.repo.GetByTokenHash(token.TokenHash)
+	suite.NoError(err)
+	suite.Equal("enc1:v2:wrapped:sealed", found.AccessToken)
+
+	suite.NoError(suite.repo.UpdateAccessToken("unknown", "enc1:v2:wrapped:sealed"))
+}
+
+// Run the test suite
+func TestRefreshTokenRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RefreshTokenRepositoryTestSuite))
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+
+	"gorm.io/gorm"
+)
+
+// SigningKeyRepository handles database operations for the JWT key set
+type SigningKeyRepository struct {
+	db *gorm.DB
+}
+
+// Ensure SigningKeyRepository implements SigningKeyRepositoryInterface
+var _ SigningKeyRepositoryInterface = (*SigningKeyRepository)(nil)
+
+// NewSigningKeyRepository creates a new signing key repository
+func NewSigningKeyRepository(db *gorm.DB) *SigningKeyRepository {
+	return &SigningKeyRepository{db: db}
+}
+
+// Create inserts a new signing key
+func (r *SigningKeyRepository) Create(key *models.SigningKey) error {
+	return r.db.Create(key).Error
+}
+
+// GetActive retrieves all keys that have not expired at the given time, oldest first
+func (r *SigningKeyRepository) GetActive(now time.Time) ([]models.SigningKey, error) {
+	var keys []models.SigningKey
+	err := r.db.Where("expires_at > ?", now).
+		Order("not_before ASC").
+		Find(&keys).Error
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// DeleteExpired removes all keys that expired before the given time
+// and returns the number of removed rows
+func (r *SigningKeyRepository) DeleteExpired(before time.Time) (int64, error) {
+	res := r.db.Where("expires_at <= ?", before).Delete(&models.SigningKey{})
+	return res.RowsAffected, res.Error
+}
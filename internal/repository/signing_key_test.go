@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+	"developer-portal-backend/internal/testutils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+// SigningKeyRepositoryTestSuite tests the SigningKeyRepository
+type SigningKeyRepositoryTestSuite struct {
+	suite.Suite
+	baseTestSuite *testutils.BaseTestSuite
+	repo          *SigningKeyRepository
+}
+
+// SetupSuite runs before all tests in the suite
+func (suite *SigningKeyRepositoryTestSuite) SetupSuite() {
+	suite.baseTestSuite = testutils.SetupTestSuite(suite.T())
+	suite.repo = NewSigningKeyRepository(suite.baseTestSuite.DB)
+}
+
+// TearDownSuite runs after all tests in the suite
+func (suite *SigningKeyRepositoryTestSuite) TearDownSuite() {
+	suite.baseTestSuite.TeardownTestSuite()
+}
+
+// SetupTest runs before each test
+func (suite *SigningKeyRepositoryTestSuite) SetupTest() {
+	suite.baseTestSuite.SetupTest()
+}
+
+// TearDownTest runs after each test
+func (suite *SigningKeyRepositoryTestSuite) TearDownTest() {
+	suite.baseTestSuite.TearDownTest()
+}
+
+func (suite *SigningKeyRepositoryTestSuite) newKey(notBefore time.Time, validFor time.Duration) *models.SigningKey {
+	key := &models.SigningKey{
+		ID:         uuid.NewString(),
+		Algorithm:  "RS256",
+		PublicKey:  []byte("public"),
+		PrivateKey: []byte("sealed"),
+		NotBefore:  notBefore,
+		RotatesAt:  notBefore.Add(validFor),
+		ExpiresAt:  notBefore.Add(validFor + time.Hour),
+	}
+	suite.Require().NoError(suite.repo.Create(key))
+	return key
+}
+
+// TestGetActive tests that only non-expired keys are returned, oldest first
+func (suite *SigningKeyRepositoryTestSuite) TestGetActive() {
+	now := time.Now()
+	suite.newKey(now.Add(-48*time.Hour), time.Hour) // expired
+	current := suite.newKey(now.Add(-time.Hour), 24*time.Hour)
+	next := suite.newKey(now.Add(23*time.Hour), 24*time.Hour)
+
+	keys, err := suite.repo.GetActive(now)
+	suite.NoError(err)
+	suite.Require().Len(keys, 2)
+	suite.Equal(current.ID, keys[0].ID)
+	suite.Equal(next.ID, keys[1].ID)
+	suite.Equal([]byte("sealed"), keys[0].PrivateKey)
+}
+
+// TestDeleteExpired tests that expired keys are removed
+func (suite *SigningKeyRepositoryTestSuite) TestDeleteExpired() {
+	now := time.Now()
+	suite.newKey(now.Add(-48*time.Hour), time.Hour)
+	suite.newKey(now, time.Hour)
+
+	removed, err := suite.repo.DeleteExpired(now)
+	suite.NoError(err)
+	suite.Equal(int64(1), removed)
+
+	keys, err := suite.repo.GetActive(now)
+	suite.NoError(err)
+	suite.Len(keys, 1)
+}
+
+func TestSigningKeyRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(SigningKeyRepositoryTestSuite))
+}
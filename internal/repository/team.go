@@ -78,7 +78,7 @@ func (r *TeamRepository) GetByOrganizationID(orgID uuid.UUID, limit, offset int)
 
 	// Get total count - join with groups to filter by organization
 	if err := r.db.Model(&models.Team{}).
-		Joins("JOIN groups ON teams.group_id = groups.id").
+		Joins("JOIN groups ON teams.group_id = groups.id AND groups.deleted_at IS NULL").
 		Where("groups.org_id = ?", orgID).
 		Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -86,7 +86,7 @@ func (r *TeamRepository) GetByOrganizationID(orgID uuid.UUID, limit, offset int)
 
 	// Get paginated results
 	err := r.db.
-		Joins("JOIN groups ON teams.group_id = groups.id").
+		Joins("JOIN groups ON teams.group_id = groups.id AND groups.deleted_at IS NULL").
 		Where("groups.org_id = ?", orgID).
 		Limit(limit).Offset(offset).
 		Find(&teams).Error
@@ -97,9 +97,9 @@ func (r *TeamRepository) GetByOrganizationID(orgID uuid.UUID, limit, offset int)
 	return teams, total, nil
 }
 
-// Update updates a team
+// Update updates a team. Returns ErrVersionConflict if it was updated since it was read.
 func (r *TeamRepository) Update(team *models.Team) error {
-	return r.db.Save(team).Error
+	return updateVersioned(r.db, team, team.ID, &team.Version)
 }
 
 // Delete deletes a team
@@ -171,7 +171,7 @@ func (r *TeamRepository) Search(orgID uuid.UUID, query string, limit, offset int
 	var total int64
 
 	searchQuery := r.db.Model(&models.Team{}).
-		Joins("JOIN groups ON teams.group_id = groups.id").
+		Joins("JOIN groups ON teams.group_id = groups.id AND groups.deleted_at IS NULL").
 		Where("groups.org_id = ? AND (teams.name ILIKE ? OR teams.description ILIKE ?)", orgID, "%"+query+"%", "%"+query+"%")
 
 	// Get total count
@@ -210,7 +210,7 @@ func (r *TeamRepository) GetTeamsWithMemberCount(orgID uuid.UUID, limit, offset
 
 	// Get total count
 	if err := r.db.Model(&models.Team{}).
-		Joins("JOIN groups ON teams.group_id = groups.id").
+		Joins("JOIN groups ON teams.group_id = groups.id AND groups.deleted_at IS NULL").
 		Where("groups.org_id = ?", orgID).
 		Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -220,9 +220,9 @@ func (r *TeamRepository) GetTeamsWithMemberCount(orgID uuid.UUID, limit, offset
 	err := r.db.Raw(`
 		SELECT t.*, COUNT(m.id) as member_count
 		FROM teams t
-		JOIN groups g ON t.group_id = g.id
-		LEFT JOIN members m ON t.id = m.team_id
-		WHERE g.org_id = ?
+		JOIN groups g ON t.group_id = g.id AND g.deleted_at IS NULL
+		LEFT JOIN members m ON t.id = m.team_id AND m.deleted_at IS NULL
+		WHERE g.org_id = ? AND t.deleted_at IS NULL
 		GROUP BY t.id, g.id
 		LIMIT ? OFFSET ?
 	`, orgID, limit, offset).Scan(&teams).Error
@@ -261,7 +261,7 @@ func (r *TeamRepository) CheckTeamExists(id uuid.UUID) (bool, error) {
 // CheckTeamNameExists checks if a team name exists within an organization (through groups)
 func (r *TeamRepository) CheckTeamNameExists(orgID uuid.UUID, name string, excludeID *uuid.UUID) (bool, error) {
 	query := r.db.Model(&models.Team{}).
-		Joins("JOIN groups ON teams.group_id = groups.id").
+		Joins("JOIN groups ON teams.group_id = groups.id AND groups.deleted_at IS NULL").
 		Where("groups.organization_id = ? AND teams.name = ?", orgID, name)
 	if excludeID != nil {
 		query = query.Where("teams.id != ?", *excludeID)
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+
+	"gorm.io/gorm"
+)
+
+// TokenRevocationRepository handles database operations for the JWT denylist
+type TokenRevocationRepository struct {
+	db *gorm.DB
+}
+
+// Ensure TokenRevocationRepository implements TokenRevocationRepositoryInterface
+var _ TokenRevocationRepositoryInterface = (*TokenRevocationRepository)(nil)
+
+// NewTokenRevocationRepository creates a new token revocation repository
+func NewTokenRevocationRepository(db *gorm.DB) *TokenRevocationRepository {
+	return &TokenRevocationRepository{db: db}
+}
+
+// Create inserts a new revocation entry
+func (r *TokenRevocationRepository) Create(revocation *models.TokenRevocation) error {
+	return r.db.Create(revocation).Error
+}
+
+// GetActiveSince retrieves all non-expired revocations recorded at or after the given time,
+// oldest first
+func (r *TokenRevocationRepository) GetActiveSince(since, now time.Time) ([]models.TokenRevocation, error) {
+	var revocations []models.TokenRevocation
+	err := r.db.Where("revoked_at >= ? AND expires_at > ?", since, now).
+		Order("revoked_at ASC").
+		Find(&revocations).Error
+	if err != nil {
+		return nil, err
+	}
+	return revocations, nil
+}
+
+// DeleteExpired removes all revocations that expired before the given time
+// and returns the number of removed rows
+func (r *TokenRevocationRepository) DeleteExpired(before time.Time) (int64, error) {
+	res := r.db.Where("expires_at <= ?", before).Delete(&models.TokenRevocation{})
+	return res.RowsAffected, res.Error
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+	"developer-portal-backend/internal/testutils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+// TokenRevocationRepositoryTestSuite tests the TokenRevocationRepository
+type TokenRevocationRepositoryTestSuite struct {
+	suite.Suite
+	baseTestSuite *testutils.BaseTestSuite
+	repo          *TokenRevocationRepository
+}
+
+// SetupSuite runs before all tests in the suite
+func (suite *TokenRevocationRepositoryTestSuite) SetupSuite() {
+	suite.baseTestSuite = testutils.SetupTestSuite(suite.T())
+	suite.repo = NewTokenRevocationRepository(suite.baseTestSuite.DB)
+}
+
+// TearDownSuite runs after all tests in the suite
+func (suite *TokenRevocationRepositoryTestSuite) TearDownSuite() {
+	suite.baseTestSuite.TeardownTestSuite()
+}
+
+// SetupTest runs before each test
+func (suite *TokenRevocationRepositoryTestSuite) SetupTest() {
+	suite.baseTestSuite.SetupTest()
+}
+
+// TearDownTest runs after each test
+func (suite *TokenRevocationRepositoryTestSuite) TearDownTest() {
+	suite.baseTestSuite.TearDownTest()
+}
+
+// TestCreate tests creating revocation entries
+func (suite *TokenRevocationRepositoryTestSuite) TestCreate() {
+	revocation := &models.TokenRevocation{
+		JTI:       uuid.New().String(),
+		Email:     "test@example.com",
+		RevokedAt: time.Now(),
+		RevokedBy: "testuser",
+		Reason:    "logout",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	err := suite.repo.Create(revocation)
+	suite.NoError(err)
+	suite.NotEqual(uuid.Nil, revocation.ID)
+}
+
+// TestGetActiveSince tests that only non-expired entries revoked after the given time are returned, oldest first
+func (suite *TokenRevocationRepositoryTestSuite) TestGetActiveSince() {
+	now := time.Now()
+	old := &models.TokenRevocation{JTI: "old", RevokedAt: now.Add(-2 * time.Hour), ExpiresAt: now.Add(time.Hour)}
+	expired := &models.TokenRevocation{JTI: "expired", RevokedAt: now.Add(-time.Minute), ExpiresAt: now.Add(-time.Second)}
+	second := &models.TokenRevocation{Email: "test@example.com", RevokedAt: now.Add(-time.Minute), ExpiresAt: now.Add(time.Hour)}
+	first := &models.TokenRevocation{JTI: "first", RevokedAt: now.Add(-10 * time.Minute), ExpiresAt: now.Add(time.Hour)}
+	for _, r := range []*models.TokenRevocation{old, expired, second, first} {
+		suite.Require().NoError(suite.repo.Create(r))
+	}
+
+	found, err := suite.repo.GetActiveSince(now.Add(-time.Hour), now)
+	suite.NoError(err)
+	suite.Require().Len(found, 2)
+	suite.Equal("first", found[0].JTI)
+	suite.Equal("test@example.com", found[1].Email)
+
+	all, err := suite.repo.GetActiveSince(time.Time{}, now)
+	suite.NoError(err)
+	suite.Len(all, 3)
+}
+
+// TestDeleteExpired tests removing expired entries
+func (suite *TokenRevocationRepositoryTestSuite) TestDeleteExpired() {
+	now := time.Now()
+	suite.Require().NoError(suite.repo.Create(&models.TokenRevocation{JTI: "expired", RevokedAt: now, ExpiresAt: now.Add(-time.Minute)}))
+	suite.Require().NoError(suite.repo.Create(&models.TokenRevocation{JTI: "active", RevokedAt: now, ExpiresAt: now.Add(time.Hour)}))
+
+	removed, err := suite.repo.DeleteExpired(now)
+	suite.NoError(err)
+	suite.Equal(int64(1), removed)
+
+	remaining, err := suite.repo.GetActiveSince(time.Time{}, now)
+	suite.NoError(err)
+	suite.Require().Len(remaining, 1)
+	suite.Equal("active", remaining[0].JTI)
+}
+
+// TestTokenRevocationRepositoryTestSuite runs the test suite
+func TestTokenRevocationRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(TokenRevocationRepositoryTestSuite))
+}
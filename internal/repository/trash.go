@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// TrashKind names a soft-deletable catalog entity
+type TrashKind string
+
+const (
+	TrashKindOrganization TrashKind = "organization"
+	TrashKindGroup        TrashKind = "group"
+	TrashKindTeam         TrashKind = "team"
+	TrashKindUser         TrashKind = "user"
+	TrashKindProject      TrashKind = "project"
+	TrashKindComponent    TrashKind = "component"
+	TrashKindLandscape    TrashKind = "landscape"
+	TrashKindCategory     TrashKind = "category"
+	TrashKindLink         TrashKind = "link"
+)
+
+// TrashKinds lists all soft-deletable kinds, children before the rows they reference.
+// Purging in this order lets a parent go in the same run as its children.
+var TrashKinds = []TrashKind{
+	TrashKindLink,
+	TrashKindComponent,
+	TrashKindLandscape,
+	TrashKindUser,
+	TrashKindTeam,
+	TrashKindGroup,
+	TrashKindOrganization,
+	TrashKindProject,
+	TrashKindCategory,
+}
+
+// trashReference is a column of another table holding the id of a row
+type trashReference struct {
+	table  string
+	column string
+}
+
+// trashTable describes where a kind is stored and how its rows relate to others
+type trashTable struct {
+	table string
+	// parent and parentColumn name the row a restored entity needs to be live, if any
+	parent       TrashKind
+	parentColumn string
+	// references are the columns pointing at rows of this table. Rows still referenced are not purged.
+	references []trashReference
+}
+
+var trashTables = map[TrashKind]trashTable{
+	TrashKindOrganization: {table: "organizations", references: []trashReference{{"groups", "org_id"}}},
+	TrashKindGroup:        {table: "groups", parent: TrashKindOrganization, parentColumn: "org_id", references: []trashReference{{"teams", "group_id"}}},
+	TrashKindTeam:         {table: "teams", parent: TrashKindGroup, parentColumn: "group_id", references: []trashReference{{"users", "team_id"}, {"components", "owner_id"}}},
+	TrashKindUser:         {table: "users", parent: TrashKindTeam, parentColumn: "team_id", references: []trashReference{{"links", "owner"}}},
+	TrashKindProject:      {table: "projects", references: []trashReference{{"components", "project_id"}, {"landscapes", "project_id"}}},
+	TrashKindComponent:    {table: "components", parent: TrashKindProject, parentColumn: "project_id"},
+	TrashKindLandscape:    {table: "landscapes", parent: TrashKindProject, parentColumn: "project_id"},
+	TrashKindCategory:     {table: "categories", references: []trashReference{{"links", "category_id"}}},
+	TrashKindLink:         {table: "links", parent: TrashKindCategory, parentColumn: "category_id"},
+}
+
+// IsValid reports whether the kind names a soft-deletable entity
+func (k TrashKind) IsValid() bool {
+	_, ok := trashTables[k]
+	return ok
+}
+
+// TrashItem is a soft-deleted catalog entity
+type TrashItem struct {
+	Kind       TrashKind  `json:"type"`
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Title      string     `json:"title"`
+	DeletedAt  time.Time  `json:"deleted_at"`
+	ParentKind TrashKind  `json:"parent_type,omitempty"`
+	ParentID   *uuid.UUID `json:"parent_id,omitempty"`
+}
+
+// TrashRepository lists, restores and purges soft-deleted catalog entities across all kinds
+type TrashRepository struct {
+	db *gorm.DB
+}
+
+// Ensure TrashRepository implements TrashRepositoryInterface
+var _ TrashRepositoryInterface = (*TrashRepository)(nil)
+
+// NewTrashRepository creates a new trash repository
+func NewTrashRepository(db *gorm.DB) *TrashRepository {
+	return &TrashRepository{db: db}
+}
+
+// deletedRowsQuery selects the deleted rows of the kinds as TrashItem columns
+func deletedRowsQuery(kinds []TrashKind) string {
+	selects := make([]string, 0, len(kinds))
+	for _, kind := range kinds {
+		t := trashTables[kind]
+		parentID := "NULL::uuid"
+		if t.parentColumn != "" {
+			parentID = t.parentColumn
+		}
+		selects = append(selects, fmt.Sprintf(
+			"SELECT '%s' AS kind, id, name, title, deleted_at, '%s' AS parent_kind, %s AS parent_id FROM %s WHERE deleted_at IS NOT NULL",
+			kind, t.parent, parentID, t.table))
+	}
+	return strings.Join(selects, " UNION ALL ")
+}
+
+// ListDeleted retrieves deleted entities of the kind, or of all kinds if it is empty, most recently
+// deleted first
+func (r *TrashRepository) ListDeleted(kind TrashKind, limit, offset int) ([]TrashItem, int64, error) {
+	kinds := TrashKinds
+	if kind != "" {
+		if !kind.IsValid() {
+			return nil, 0, fmt.Errorf("unknown trash kind %q", kind)
+		}
+		kinds = []TrashKind{kind}
+	}
+	rows := deletedRowsQuery(kinds)
+
+	var total int64
+	if err := r.db.Raw("SELECT COUNT(*) FROM (" + rows + ") AS trash").Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var items []TrashItem
+	err := r.db.Raw("SELECT * FROM ("+rows+") AS trash ORDER BY deleted_at DESC, id LIMIT ? OFFSET ?", limit, offset).
+		Scan(&items).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return items, total, nil
+}
+
+// GetDeleted retrieves a deleted entity. Returns gorm.ErrRecordNotFound if there is no such entity
+// or it is not deleted.
+func (r *TrashRepository) GetDeleted(kind TrashKind, id uuid.UUID) (*TrashItem, error) {
+	if !kind.IsValid() {
+		return nil, fmt.Errorf("unknown trash kind %q", kind)
+	}
+	var items []TrashItem
+	err := r.db.Raw("SELECT * FROM ("+deletedRowsQuery([]TrashKind{kind})+") AS trash WHERE id = ?", id).
+		Scan(&items).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &items[0], nil
+}
+
+// IsActive reports whether the entity exists and is not deleted
+func (r *TrashRepository) IsActive(kind TrashKind, id uuid.UUID) (bool, error) {
+	t, ok := trashTables[kind]
+	if !ok {
+		return false, fmt.Errorf("unknown trash kind %q", kind)
+	}
+	var count int64
+	err := r.db.Table(t.table).Where("id = ? AND deleted_at IS NULL", id).Count(&count).Error
+	return count > 0, err
+}
+
+// Restore undeletes an entity. Returns gorm.ErrRecordNotFound if there is no such deleted entity
+// and gorm.ErrDuplicatedKey if a live entity took over a unique value, such as a user's email.
+func (r *TrashRepository) Restore(kind TrashKind, id uuid.UUID) error {
+	t, ok := trashTables[kind]
+	if !ok {
+		return fmt.Errorf("unknown trash kind %q", kind)
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Table(t.table).
+			Where("id = ? AND deleted_at IS NOT NULL", id).
+			Updates(map[string]interface{}{"deleted_at": nil, "updated_at": time.Now(), "version": nextVersion})
+		if res.Error != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(res.Error, &pgErr) && pgErr.Code == "23505" {
+				return gorm.ErrDuplicatedKey
+			}
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if kind != TrashKindUser {
+			return nil
+		}
+
+		// A restored user is a member of their team again
+		var user models.User
+		if err := tx.First(&user, "id = ?", id).Error; err != nil {
+			return err
+		}
+		return syncMembership(tx, &user, "", time.Now())
+	})
+}
+
+// PurgeDeletedBefore permanently removes entities deleted before the cutoff and returns how many
+// were removed. Entities still referenced by another row, deleted or not, are kept until that row
+// is purged as well.
+func (r *TrashRepository) PurgeDeletedBefore(cutoff time.Time) (int64, error) {
+	var removed int64
+	for _, kind := range TrashKinds {
+		t := trashTables[kind]
+		query := fmt.Sprintf("DELETE FROM %s WHERE deleted_at IS NOT NULL AND deleted_at < ?", t.table)
+		for _, ref := range t.references {
+			query += fmt.Sprintf(" AND NOT EXISTS (SELECT 1 FROM %s WHERE %s.%s = %s.id)", ref.table, ref.table, ref.column, t.table)
+		}
+		res := r.db.Exec(query, cutoff)
+		if res.Error != nil {
+			return removed, fmt.Errorf("purge deleted %s: %w", t.table, res.Error)
+		}
+		removed += res.RowsAffected
+	}
+	return removed, nil
+}
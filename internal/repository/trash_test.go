@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+	"developer-portal-backend/internal/testutils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+// TrashRepositoryTestSuite tests the TrashRepository
+type TrashRepositoryTestSuite struct {
+	suite.Suite
+	baseTestSuite *testutils.BaseTestSuite
+	repo          *TrashRepository
+	linkRepo      *LinkRepository
+}
+
+// SetupSuite runs before all tests in the suite
+func (suite *TrashRepositoryTestSuite) SetupSuite() {
+	suite.baseTestSuite = testutils.SetupTestSuite(suite.T())
+	suite.repo = NewTrashRepository(suite.baseTestSuite.DB)
+	suite.linkRepo = NewLinkRepository(suite.baseTestSuite.DB)
+}
+
+// TearDownSuite runs after all tests in the suite
+func (suite *TrashRepositoryTestSuite) TearDownSuite() {
+	suite.baseTestSuite.TeardownTestSuite()
+}
+
+// SetupTest runs before each test
+func (suite *TrashRepositoryTestSuite) SetupTest() {
+	suite.baseTestSuite.SetupTest()
+}
+
+// TearDownTest runs after each test
+func (suite *TrashRepositoryTestSuite) TearDownTest() {
+	suite.baseTestSuite.TearDownTest()
+}
+
+// createCategoryWithLink inserts a category and a link in it
+func (suite *TrashRepositoryTestSuite) createCategoryWithLink(name string) (*models.Category, *models.Link) {
+	category := &models.Category{
+		BaseModel: models.BaseModel{Name: name, Title: name},
+		Icon:      "icon",
+		Color:     "red",
+	}
+	suite.Require().NoError(suite.baseTestSuite.DB.Create(category).Error)
+	link := &models.Link{
+		BaseModel:  models.BaseModel{Name: name + "-link", Title: name + " link"},
+		Owner:      uuid.New(),
+		URL:        "https://example.com/" + name,
+		CategoryID: category.ID,
+	}
+	suite.Require().NoError(suite.baseTestSuite.DB.Create(link).Error)
+	return category, link
+}
+
+// TestDeleteIsSoft tests that deleted rows are hidden from queries but listed in the trash
+func (suite *TrashRepositoryTestSuite) TestDeleteIsSoft() {
+	category, link := suite.createCategoryWithLink("docs")
+	suite.Require().NoError(suite.linkRepo.Delete(link.ID))
+
+	links, err := suite.linkRepo.GetByOwner(link.Owner)
+	suite.Require().NoError(err)
+	suite.Empty(links)
+
+	items, total, err := suite.repo.ListDeleted("", 10, 0)
+	suite.Require().NoError(err)
+	suite.Equal(int64(1), total)
+	suite.Require().Len(items, 1)
+	suite.Equal(TrashKindLink, items[0].Kind)
+	suite.Equal(link.ID, items[0].ID)
+	suite.Equal(TrashKindCategory, items[0].ParentKind)
+	suite.Equal(category.ID, *items[0].ParentID)
+
+	items, total, err = suite.repo.ListDeleted(TrashKindTeam, 10, 0)
+	suite.Require().NoError(err)
+	suite.Zero(total)
+	suite.Empty(items)
+
+	active, err := suite.repo.IsActive(TrashKindCategory, category.ID)
+	suite.Require().NoError(err)
+	suite.True(active)
+}
+
+// TestRestore tests restoring a deleted row
+func (suite *TrashRepositoryTestSuite) TestRestore() {
+	_, link := suite.createCategoryWithLink("docs")
+	suite.ErrorIs(suite.repo.Restore(TrashKindLink, link.ID), gorm.ErrRecordNotFound, "only deleted rows can be restored")
+
+	suite.Require().NoError(suite.linkRepo.Delete(link.ID))
+	item, err := suite.repo.GetDeleted(TrashKindLink, link.ID)
+	suite.Require().NoError(err)
+	suite.Equal(link.Title, item.Title)
+
+	suite.Require().NoError(suite.repo.Restore(TrashKindLink, link.ID))
+	restored, err := suite.linkRepo.GetByIDs([]uuid.UUID{link.ID})
+	suite.Require().NoError(err)
+	suite.Require().Len(restored, 1)
+	suite.Equal(link.URL, restored[0].URL)
+	_, err = suite.repo.GetDeleted(TrashKindLink, link.ID)
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
+// TestRestoreDuplicateEmail tests that a user cannot be restored while another user has the email
+func (suite *TrashRepositoryTestSuite) TestRestoreDuplicateEmail() {
+	userRepo := NewUserRepository(suite.baseTestSuite.DB)
+	deleted := testutils.NewUserFactory().WithEmail("jane@example.com")
+	suite.Require().NoError(userRepo.Create(deleted))
+	suite.Require().NoError(userRepo.Delete(deleted.ID, "admin"))
+
+	replacement := testutils.NewUserFactory().WithEmail("jane@example.com")
+	suite.Require().NoError(userRepo.Create(replacement), "deleted users do not block their email")
+
+	suite.ErrorIs(suite.repo.Restore(TrashKindUser, deleted.ID), gorm.ErrDuplicatedKey)
+}
+
+// TestPurgeDeletedBefore tests that only expired, unreferenced rows are purged
+func (suite *TrashRepositoryTestSuite) TestPurgeDeletedBefore() {
+	category, link := suite.createCategoryWithLink("docs")
+	suite.Require().NoError(suite.baseTestSuite.DB.Delete(&models.Category{}, "id = ?", category.ID).Error)
+
+	// The deleted category is still referenced by the live link
+	removed, err := suite.repo.PurgeDeletedBefore(time.Now().Add(time.Minute))
+	suite.Require().NoError(err)
+	suite.Zero(removed)
+
+	suite.Require().NoError(suite.linkRepo.Delete(link.ID))
+	removed, err = suite.repo.PurgeDeletedBefore(time.Now().Add(-time.Hour))
+	suite.Require().NoError(err)
+	suite.Zero(removed, "rows deleted after the cutoff are kept")
+
+	removed, err = suite.repo.PurgeDeletedBefore(time.Now().Add(time.Minute))
+	suite.Require().NoError(err)
+	suite.Equal(int64(2), removed, "the link goes first, then its category")
+
+	var count int64
+	suite.Require().NoError(suite.baseTestSuite.DB.Unscoped().Model(&models.Link{}).Count(&count).Error)
+	suite.Zero(count)
+}
+
+// TestTrashRepositoryTestSuite runs the test suite
+func TestTrashRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(TrashRepositoryTestSuite))
+}
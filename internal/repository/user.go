@@ -2,7 +2,10 @@ package repository
 
 import (
 	"developer-portal-backend/internal/database/models"
+	"errors"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -18,9 +21,29 @@ func NewUserRepository(db *gorm.DB) *UserRepository {
 	return &UserRepository{db: db}
 }
 
-// Create creates a new member
+// Create creates a new member and starts their team membership, if they have a team
 func (r *UserRepository) Create(member *models.User) error {
-	return r.db.Create(member).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(member).Error; err != nil {
+			return err
+		}
+		return syncMembership(tx, member, member.CreatedBy, member.CreatedAt)
+	})
+}
+
+// CreateBatch creates members in one transaction: either all of them are created or none
+func (r *UserRepository) CreateBatch(members []*models.User) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, member := range members {
+			if err := tx.Create(member).Error; err != nil {
+				return fmt.Errorf("failed to create user %s: %w", member.UserID, err)
+			}
+			if err := syncMembership(tx, member, member.CreatedBy, member.CreatedAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 // GetByID retrieves a member by ID
@@ -53,7 +76,7 @@ func (r *UserRepository) GetByName(name string) (*models.User, error) {
 	return &member, nil
 }
 
- // GetByUserID retrieves a member by their string UserID (e.g., I123456)
+// GetByUserID retrieves a member by their string UserID (e.g., I123456)
 func (r *UserRepository) GetByUserID(userID string) (*models.User, error) {
 	var member models.User
 	err := r.db.First(&member, "user_id = ?", userID).Error
@@ -63,6 +86,17 @@ func (r *UserRepository) GetByUserID(userID string) (*models.User, error) {
 	return &member, nil
 }
 
+// GetDeletedByUserID retrieves the most recently deleted user with the given user_id, e.g. an
+// offboarded user or one deleted because they were missing from LDAP
+func (r *UserRepository) GetDeletedByUserID(userID string) (*models.User, error) {
+	var member models.User
+	err := r.db.Unscoped().Where("user_id = ? AND deleted_at IS NOT NULL", userID).Order("deleted_at DESC").First(&member).Error
+	if err != nil {
+		return nil, err
+	}
+	return &member, nil
+}
+
 // GetAll retrieves all users with pagination
 func (r *UserRepository) GetAll(limit, offset int) ([]models.User, int64, error) {
 	var members []models.User
@@ -73,8 +107,8 @@ func (r *UserRepository) GetAll(limit, offset int) ([]models.User, int64, error)
 		return nil, 0, err
 	}
 
-	// Get paginated results
-	if err := r.db.Model(&models.User{}).Limit(limit).Offset(offset).Find(&members).Error; err != nil {
+	// Get paginated results, in a stable order for paging through all users
+	if err := r.db.Model(&models.User{}).Order("user_id ASC, id ASC").Limit(limit).Offset(offset).Find(&members).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -88,8 +122,8 @@ func (r *UserRepository) GetByOrganizationID(orgID uuid.UUID, limit, offset int)
 
 	// Users no longer carry organization_id directly; filter by organization through team -> group
 	countQuery := r.db.Model(&models.User{}).
-		Joins("JOIN teams ON members.team_id = teams.id").
-		Joins("JOIN groups ON teams.group_id = groups.id").
+		Joins("JOIN teams ON members.team_id = teams.id AND teams.deleted_at IS NULL").
+		Joins("JOIN groups ON teams.group_id = groups.id AND groups.deleted_at IS NULL").
 		Where("groups.org_id = ?", orgID)
 
 	if err := countQuery.Count(&total).Error; err != nil {
@@ -98,8 +132,8 @@ func (r *UserRepository) GetByOrganizationID(orgID uuid.UUID, limit, offset int)
 
 	// Get paginated results
 	err := r.db.Model(&models.User{}).
-		Joins("JOIN teams ON members.team_id = teams.id").
-		Joins("JOIN groups ON teams.group_id = groups.id").
+		Joins("JOIN teams ON members.team_id = teams.id AND teams.deleted_at IS NULL").
+		Joins("JOIN groups ON teams.group_id = groups.id AND groups.deleted_at IS NULL").
 		Where("groups.org_id = ?", orgID).
 		Limit(limit).Offset(offset).
 		Find(&members).Error
@@ -135,8 +169,8 @@ func (r *UserRepository) GetByRole(orgID uuid.UUID, role models.TeamDomain, limi
 	var total int64
 
 	query := r.db.Model(&models.User{}).
-		Joins("JOIN teams ON members.team_id = teams.id").
-		Joins("JOIN groups ON teams.group_id = groups.id").
+		Joins("JOIN teams ON members.team_id = teams.id AND teams.deleted_at IS NULL").
+		Joins("JOIN groups ON teams.group_id = groups.id AND groups.deleted_at IS NULL").
 		Where("groups.org_id = ? AND members.team_domain = ?", orgID, role)
 
 	// Get total count
@@ -159,14 +193,24 @@ func (r *UserRepository) GetActiveMembers(orgID uuid.UUID, limit, offset int) ([
 	return r.GetByOrganizationID(orgID, limit, offset)
 }
 
-// Update updates a member
+// Update updates a member. Returns ErrVersionConflict if it was updated since it was read.
 func (r *UserRepository) Update(member *models.User) error {
-	return r.db.Save(member).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := updateVersioned(tx, member, member.ID, &member.Version); err != nil {
+			return err
+		}
+		return syncMembership(tx, member, member.UpdatedBy, time.Now())
+	})
 }
 
-// Delete deletes a member
-func (r *UserRepository) Delete(id uuid.UUID) error {
-	return r.db.Delete(&models.User{}, "id = ?", id).Error
+// Delete deletes a member and ends their team membership on behalf of deletedBy
+func (r *UserRepository) Delete(id uuid.UUID, deletedBy string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&models.User{}, "id = ?", id).Error; err != nil {
+			return err
+		}
+		return endMembership(tx, id, deletedBy, time.Now())
+	})
 }
 
 // GetWithOrganization retrieves a member with organization details
@@ -211,17 +255,95 @@ func (r *UserRepository) GetWithDutySchedules(id uuid.UUID) (*models.User, error
 
 // AssignToTeam assigns a member to a team
 func (r *UserRepository) AssignToTeam(memberID, teamID uuid.UUID) error {
-	return r.db.Model(&models.User{}).Where("id = ?", memberID).Update("team_id", teamID).Error
+	return r.updateTeamFields(memberID, map[string]interface{}{"team_id": teamID, "version": nextVersion})
 }
 
 // RemoveFromTeam removes a member from their team
 func (r *UserRepository) RemoveFromTeam(memberID uuid.UUID) error {
-	return r.db.Model(&models.User{}).Where("id = ?", memberID).Update("team_id", nil).Error
+	return r.updateTeamFields(memberID, map[string]interface{}{"team_id": nil, "version": nextVersion})
 }
 
 // UpdateRole updates a member's role
 func (r *UserRepository) UpdateRole(memberID uuid.UUID, role models.TeamDomain) error {
-	return r.db.Model(&models.User{}).Where("id = ?", memberID).Update("team_domain", role).Error
+	return r.updateTeamFields(memberID, map[string]interface{}{"team_domain": role, "version": nextVersion})
+}
+
+// updateTeamFields updates columns of a member that are part of their team membership and records
+// the change in their membership history
+func (r *UserRepository) updateTeamFields(memberID uuid.UUID, updates map[string]interface{}) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.User{}).Where("id = ?", memberID).Updates(updates).Error; err != nil {
+			return err
+		}
+		var member models.User
+		if err := tx.First(&member, "id = ?", memberID).Error; err != nil {
+			return err
+		}
+		return syncMembership(tx, &member, "", time.Now())
+	})
+}
+
+// GetTeamMemberships retrieves the memberships of a team that overlap the period from..to, with
+// their users, including deleted ones. Pass the same time twice for the members at that time.
+func (r *UserRepository) GetTeamMemberships(teamID uuid.UUID, from, to time.Time) ([]models.TeamMembership, error) {
+	var memberships []models.TeamMembership
+	err := r.db.Preload("User", func(db *gorm.DB) *gorm.DB { return db.Unscoped() }).
+		Where("team_id = ? AND started_at <= ? AND (ended_at IS NULL OR ended_at > ?)", teamID, to, from).
+		Order("started_at ASC").
+		Find(&memberships).Error
+	if err != nil {
+		return nil, err
+	}
+	return memberships, nil
+}
+
+// GetMemberships retrieves the membership history of a member, newest first
+func (r *UserRepository) GetMemberships(memberID uuid.UUID) ([]models.TeamMembership, error) {
+	var memberships []models.TeamMembership
+	err := r.db.Where("user_id = ?", memberID).Order("started_at DESC").Find(&memberships).Error
+	if err != nil {
+		return nil, err
+	}
+	return memberships, nil
+}
+
+// syncMembership makes the current membership of member match their team, role and domain. If they
+// differ, the current membership ends and, if member has a team, a new one starts at the given time.
+func syncMembership(tx *gorm.DB, member *models.User, by string, at time.Time) error {
+	var current models.TeamMembership
+	err := tx.Where("user_id = ? AND ended_at IS NULL", member.ID).Take(&current).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	found := err == nil
+	if found && member.TeamID != nil && current.TeamID == *member.TeamID &&
+		current.TeamRole == member.TeamRole && current.TeamDomain == member.TeamDomain {
+		return nil
+	}
+
+	if found {
+		if err := endMembership(tx, member.ID, by, at); err != nil {
+			return err
+		}
+	}
+	if member.TeamID == nil {
+		return nil
+	}
+	return tx.Create(&models.TeamMembership{
+		UserID:     member.ID,
+		TeamID:     *member.TeamID,
+		TeamRole:   member.TeamRole,
+		TeamDomain: member.TeamDomain,
+		StartedAt:  at,
+		StartedBy:  by,
+	}).Error
+}
+
+// endMembership ends the current membership of a member, if any
+func endMembership(tx *gorm.DB, memberID uuid.UUID, by string, at time.Time) error {
+	return tx.Model(&models.TeamMembership{}).
+		Where("user_id = ? AND ended_at IS NULL", memberID).
+		Updates(map[string]interface{}{"ended_at": at, "ended_by": by}).Error
 }
 
 // SetActiveStatus sets the active status of a member
@@ -236,8 +358,8 @@ func (r *UserRepository) Search(orgID uuid.UUID, query string, limit, offset int
 	var total int64
 
 	searchQuery := r.db.Model(&models.User{}).
-		Joins("JOIN teams ON members.team_id = teams.id").
-		Joins("JOIN groups ON teams.group_id = groups.id").
+		Joins("JOIN teams ON members.team_id = teams.id AND teams.deleted_at IS NULL").
+		Joins("JOIN groups ON teams.group_id = groups.id AND groups.deleted_at IS NULL").
 		Where("groups.org_id = ? AND (members.first_name ILIKE ? OR members.last_name ILIKE ? OR members.email ILIKE ?)", orgID, "%"+query+"%", "%"+query+"%", "%"+query+"%")
 
 	// Get total count
@@ -259,12 +381,12 @@ func (r *UserRepository) SearchByOrganization(orgID uuid.UUID, query string, lim
 	return r.Search(orgID, query, limit, offset)
 }
 
- // GetActiveByOrganization retrieves all active members for an organization
+// GetActiveByOrganization retrieves all active members for an organization
 func (r *UserRepository) GetActiveByOrganization(orgID uuid.UUID, limit, offset int) ([]models.User, int64, error) {
 	return r.GetByOrganizationID(orgID, limit, offset)
 }
 
- // GetUserIDsByPrefix returns user_ids with the given prefix (case-insensitive)
+// GetUserIDsByPrefix returns user_ids with the given prefix (case-insensitive)
 func (r *UserRepository) GetUserIDsByPrefix(prefix string) ([]string, error) {
 	var ids []string
 	if err := r.db.Model(&models.User{}).
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"developer-portal-backend/internal/database/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UserIdentityRepository handles database operations for provider identities linked to users
+type UserIdentityRepository struct {
+	db *gorm.DB
+}
+
+// Ensure UserIdentityRepository implements UserIdentityRepositoryInterface
+var _ UserIdentityRepositoryInterface = (*UserIdentityRepository)(nil)
+
+// NewUserIdentityRepository creates a new user identity repository
+func NewUserIdentityRepository(db *gorm.DB) *UserIdentityRepository {
+	return &UserIdentityRepository{db: db}
+}
+
+// Upsert inserts the identity of a provider account, or moves an existing one to identity.MemberID
+// and updates its username, email and last login. LinkedAt is kept unless the owner changes.
+func (r *UserIdentityRepository) Upsert(identity *models.UserIdentity) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "provider"}, {Name: "provider_user_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"linked_at":     gorm.Expr("CASE WHEN user_identities.member_id = excluded.member_id THEN user_identities.linked_at ELSE excluded.linked_at END"),
+			"member_id":     gorm.Expr("excluded.member_id"),
+			"username":      gorm.Expr("excluded.username"),
+			"email":         gorm.Expr("excluded.email"),
+			"last_login_at": gorm.Expr("excluded.last_login_at"),
+			"updated_at":    gorm.Expr("excluded.updated_at"),
+		}),
+	}).Create(identity).Error
+}
+
+// GetByProviderAccount retrieves the identity of a provider account
+func (r *UserIdentityRepository) GetByProviderAccount(provider string, providerUserID int64) (*models.UserIdentity, error) {
+	var identity models.UserIdentity
+	err := r.db.Where("provider = ? AND provider_user_id = ?", provider, providerUserID).First(&identity).Error
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// GetByMemberID retrieves all identities linked to a user, oldest link first
+func (r *UserIdentityRepository) GetByMemberID(memberID string) ([]models.UserIdentity, error) {
+	var identities []models.UserIdentity
+	err := r.db.Where("member_id = ?", memberID).
+		Order("linked_at ASC").
+		Find(&identities).Error
+	if err != nil {
+		return nil, err
+	}
+	return identities, nil
+}
+
+// Delete removes the user's identities of the provider. Returns gorm.ErrRecordNotFound
+// if the user has no identity of the provider.
+func (r *UserIdentityRepository) Delete(memberID, provider string) error {
+	res := r.db.Where("member_id = ? AND provider = ?", memberID, provider).Delete(&models.UserIdentity{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
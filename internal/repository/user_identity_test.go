@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+	"developer-portal-backend/internal/testutils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+// UserIdentityRepositoryTestSuite tests the UserIdentityRepository
+type UserIdentityRepositoryTestSuite struct {
+	suite.Suite
+	baseTestSuite *testutils.BaseTestSuite
+	repo          *UserIdentityRepository
+}
+
+// SetupSuite runs before all tests in the suite
+func (suite *UserIdentityRepositoryTestSuite) SetupSuite() {
+	suite.baseTestSuite = testutils.SetupTestSuite(suite.T())
+	suite.repo = NewUserIdentityRepository(suite.baseTestSuite.DB)
+}
+
+// TearDownSuite runs after all tests in the suite
+func (suite *UserIdentityRepositoryTestSuite) TearDownSuite() {
+	suite.baseTestSuite.TeardownTestSuite()
+}
+
+// SetupTest runs before each test
+func (suite *UserIdentityRepositoryTestSuite) SetupTest() {
+	suite.baseTestSuite.SetupTest()
+}
+
+// TearDownTest runs after each test
+func (suite *UserIdentityRepositoryTestSuite) TearDownTest() {
+	suite.baseTestSuite.TearDownTest()
+}
+
+func (suite *UserIdentityRepositoryTestSuite) link(memberID, provider string, providerUserID int64, at time.Time) {
+	suite.Require().NoError(suite.repo.Upsert(&models.UserIdentity{
+		MemberID:       memberID,
+		Provider:       provider,
+		ProviderUserID: providerUserID,
+		Username:       "johndoe",
+		Email:          "john.doe@example.com",
+		LinkedAt:       at,
+		LastLoginAt:    at,
+	}))
+}
+
+// TestUpsert tests that linking an account again updates it in place
+func (suite *UserIdentityRepositoryTestSuite) TestUpsert() {
+	memberID := uuid.NewString()
+	first := time.Now().Add(-time.Hour).Truncate(time.Second)
+	suite.link(memberID, "githubtools", 1, first)
+	suite.link(memberID, "githubtools", 1, first.Add(time.Hour))
+
+	identities, err := suite.repo.GetByMemberID(memberID)
+	suite.NoError(err)
+	suite.Require().Len(identities, 1)
+	suite.True(identities[0].LinkedAt.Equal(first))
+	suite.True(identities[0].LastLoginAt.Equal(first.Add(time.Hour)))
+
+	// The account moves to another user
+	other := uuid.NewString()
+	suite.link(other, "githubtools", 1, first.Add(2*time.Hour))
+	identity, err := suite.repo.GetByProviderAccount("githubtools", 1)
+	suite.NoError(err)
+	suite.Equal(other, identity.MemberID)
+	suite.True(identity.LinkedAt.Equal(first.Add(2 * time.Hour)))
+}
+
+// TestGetByMemberID tests that all identities of a user are returned, oldest link first
+func (suite *UserIdentityRepositoryTestSuite) TestGetByMemberID() {
+	memberID := uuid.NewString()
+	now := time.Now()
+	suite.link(memberID, "githubwdf", 2, now)
+	suite.link(memberID, "githubtools", 1, now.Add(-time.Hour))
+	suite.link(uuid.NewString(), "githubtools", 3, now)
+
+	identities, err := suite.repo.GetByMemberID(memberID)
+	suite.NoError(err)
+	suite.Require().Len(identities, 2)
+	suite.Equal("githubtools", identities[0].Provider)
+	suite.Equal("githubwdf", identities[1].Provider)
+}
+
+// TestDelete tests unlinking an identity
+func (suite *UserIdentityRepositoryTestSuite) TestDelete() {
+	memberID := uuid.NewString()
+	suite.link(memberID, "githubwdf", 2, time.Now())
+
+	suite.NoError(suite.repo.Delete(memberID, "githubwdf"))
+	_, err := suite.repo.GetByProviderAccount("githubwdf", 2)
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+	suite.ErrorIs(suite.repo.Delete(memberID, "githubwdf"), gorm.ErrRecordNotFound)
+}
+
+// TestUserIdentityRepositoryTestSuite runs the test suite
+func TestUserIdentityRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(UserIdentityRepositoryTestSuite))
+}
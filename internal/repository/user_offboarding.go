@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"strings"
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserOffboardingRepository handles database operations for offboarding users
+type UserOffboardingRepository struct {
+	db *gorm.DB
+}
+
+// Ensure UserOffboardingRepository implements UserOffboardingRepositoryInterface
+var _ UserOffboardingRepositoryInterface = (*UserOffboardingRepository)(nil)
+
+// NewUserOffboardingRepository creates a new user offboarding repository
+func NewUserOffboardingRepository(db *gorm.DB) *UserOffboardingRepository {
+	return &UserOffboardingRepository{db: db}
+}
+
+// OwnedResources are the rows naming a user as their owner
+type OwnedResources struct {
+	Links         []models.Link
+	Teams         []models.Team
+	Groups        []models.Group
+	Organizations []models.Organization
+	// FavoritedBy is the number of other users with one of the links among their favorites
+	FavoritedBy int64
+}
+
+// ownedBy matches rows whose owner is the given I/C/D user, like the authorization policies do
+const ownedBy = "LOWER(owner) = LOWER(?)"
+
+// favoritesAny matches users with one of the given link ids among their favorites
+const favoritesAny = "jsonb_typeof(metadata->'favorites') = 'array' AND jsonb_exists_any(metadata->'favorites', ?::text[])"
+
+// GetOwnedResources retrieves the links, teams, groups and organizations owned by a user
+func (r *UserOffboardingRepository) GetOwnedResources(userID uuid.UUID, username string) (*OwnedResources, error) {
+	owned := &OwnedResources{}
+	if err := r.db.Where("owner = ?", userID).Order("title ASC").Find(&owned.Links).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where(ownedBy, username).Order("name ASC").Find(&owned.Teams).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where(ownedBy, username).Order("name ASC").Find(&owned.Groups).Error; err != nil {
+		return nil, err
+	}
+	if err := r.db.Where(ownedBy, username).Order("name ASC").Find(&owned.Organizations).Error; err != nil {
+		return nil, err
+	}
+
+	if len(owned.Links) > 0 {
+		ids := make([]uuid.UUID, len(owned.Links))
+		for i, link := range owned.Links {
+			ids[i] = link.ID
+		}
+		err := r.db.Model(&models.User{}).
+			Where("id <> ?", userID).
+			Where(favoritesAny, textArray(ids)).
+			Count(&owned.FavoritedBy).Error
+		if err != nil {
+			return nil, err
+		}
+	}
+	return owned, nil
+}
+
+// Offboard hands the resources of the user over to the owners named in the record, deletes the
+// user and ends their team membership, and saves the record, all in one transaction.
+// Links move to LinkOwnerID or, if it is nil, are deleted and removed from the favorites of all
+// users. Teams, groups and organizations with an empty owner in the record are left untouched.
+// The counts of the record are set to the number of rows changed.
+func (r *UserOffboardingRepository) Offboard(record *models.UserOffboarding) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var linkIDs []uuid.UUID
+		if err := tx.Model(&models.Link{}).Where("owner = ?", record.UserID).Pluck("id", &linkIDs).Error; err != nil {
+			return err
+		}
+		record.LinkCount = len(linkIDs)
+		if len(linkIDs) > 0 {
+			if record.LinkOwnerID != nil {
+				err := tx.Model(&models.Link{}).Where("id IN ?", linkIDs).Updates(map[string]interface{}{
+					"owner":      *record.LinkOwnerID,
+					"updated_by": record.OffboardedBy,
+					"version":    nextVersion,
+				}).Error
+				if err != nil {
+					return err
+				}
+			} else {
+				if err := tx.Delete(&models.Link{}, "id IN ?", linkIDs).Error; err != nil {
+					return err
+				}
+				result := tx.Model(&models.User{}).
+					Where("id <> ?", record.UserID).
+					Where(favoritesAny, textArray(linkIDs)).
+					Updates(map[string]interface{}{
+						"metadata": gorm.Expr("jsonb_set(metadata, '{favorites}', (metadata->'favorites') - ?::text[])", textArray(linkIDs)),
+						"version":  nextVersion,
+					})
+				if result.Error != nil {
+					return result.Error
+				}
+				record.FavoritesRemoved = int(result.RowsAffected)
+			}
+		}
+
+		var err error
+		if record.TeamCount, err = reassignOwner(tx, &models.Team{}, record.Username, record.TeamOwner, record.OffboardedBy); err != nil {
+			return err
+		}
+		if record.GroupCount, err = reassignOwner(tx, &models.Group{}, record.Username, record.GroupOwner, record.OffboardedBy); err != nil {
+			return err
+		}
+		if record.OrganizationCount, err = reassignOwner(tx, &models.Organization{}, record.Username, record.OrganizationOwner, record.OffboardedBy); err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&models.User{}, "id = ?", record.UserID).Error; err != nil {
+			return err
+		}
+		if err := endMembership(tx, record.UserID, record.OffboardedBy, time.Now()); err != nil {
+			return err
+		}
+		return tx.Create(record).Error
+	})
+}
+
+// reassignOwner sets the owner of the rows of model owned by from to to and returns how many there were.
+// Nothing changes if to is empty.
+func reassignOwner(tx *gorm.DB, model interface{}, from, to, by string) (int, error) {
+	if to == "" {
+		return 0, nil
+	}
+	result := tx.Model(model).Where(ownedBy, from).Updates(map[string]interface{}{
+		"owner":      to,
+		"updated_by": by,
+		"version":    nextVersion,
+	})
+	return int(result.RowsAffected), result.Error
+}
+
+// textArray formats ids as a Postgres text array literal
+func textArray(ids []uuid.UUID) string {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+	return "{" + strings.Join(values, ",") + "}"
+}
+
+// GetHistory retrieves offboardings newest first. A limit of 0 retrieves all of them.
+func (r *UserOffboardingRepository) GetHistory(limit int) ([]models.UserOffboarding, error) {
+	query := r.db.Order("created_at DESC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var records []models.UserOffboarding
+	if err := query.Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return records, nil
+}
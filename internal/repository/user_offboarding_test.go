@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"encoding/json"
+	"testing"
+
+	"developer-portal-backend/internal/database/models"
+	"developer-portal-backend/internal/testutils"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+// UserOffboardingRepositoryTestSuite tests the UserOffboardingRepository
+type UserOffboardingRepositoryTestSuite struct {
+	suite.Suite
+	baseTestSuite *testutils.BaseTestSuite
+	repo          *UserOffboardingRepository
+	factories     *testutils.FactorySet
+}
+
+// SetupSuite runs before all tests in the suite
+func (suite *UserOffboardingRepositoryTestSuite) SetupSuite() {
+	suite.baseTestSuite = testutils.SetupTestSuite(suite.T())
+	suite.repo = NewUserOffboardingRepository(suite.baseTestSuite.DB)
+	suite.factories = testutils.NewFactorySet()
+}
+
+// TearDownSuite runs after all tests in the suite
+func (suite *UserOffboardingRepositoryTestSuite) TearDownSuite() {
+	suite.baseTestSuite.TeardownTestSuite()
+}
+
+// SetupTest runs before each test
+func (suite *UserOffboardingRepositoryTestSuite) SetupTest() {
+	suite.baseTestSuite.SetupTest()
+}
+
+// TearDownTest runs after each test
+func (suite *UserOffboardingRepositoryTestSuite) TearDownTest() {
+	suite.baseTestSuite.TearDownTest()
+}
+
+// ownedFixture is a user owning a link, team, group and organization, and a user with the link among their favorites
+type ownedFixture struct {
+	user  *models.User
+	fan   *models.User
+	link  *models.Link
+	team  *models.Team
+	group *models.Group
+	org   *models.Organization
+}
+
+// createOwnedFixture inserts a user and the resources they own
+func (suite *UserOffboardingRepositoryTestSuite) createOwnedFixture() *ownedFixture {
+	db := suite.baseTestSuite.DB
+	f := &ownedFixture{}
+
+	f.org = suite.factories.Organization.Create()
+	f.org.Owner = "i00001" // owners match regardless of case
+	suite.Require().NoError(db.Create(f.org).Error)
+	f.group = suite.factories.Group.WithOrganization(f.org.ID)
+	f.group.Owner = "I00001"
+	suite.Require().NoError(db.Create(f.group).Error)
+	f.team = suite.factories.Team.WithGroup(f.group.ID)
+	f.team.Owner = "I00001"
+	suite.Require().NoError(db.Create(f.team).Error)
+
+	f.user = suite.factories.User.WithTeam(f.team.ID)
+	f.user.UserID = "I00001"
+	suite.Require().NoError(NewUserRepository(db).Create(f.user))
+
+	category := &models.Category{BaseModel: models.BaseModel{Name: "docs", Title: "Docs"}, Icon: "icon", Color: "red"}
+	suite.Require().NoError(db.Create(category).Error)
+	f.link = &models.Link{
+		BaseModel:  models.BaseModel{Name: "wiki", Title: "Wiki"},
+		Owner:      f.user.ID,
+		URL:        "https://example.com/wiki",
+		CategoryID: category.ID,
+	}
+	suite.Require().NoError(db.Create(f.link).Error)
+
+	f.fan = suite.factories.User.WithEmail("fan@example.com")
+	other := uuid.New().String()
+	f.fan.Metadata = json.RawMessage(`{"favorites":["` + f.link.ID.String() + `","` + other + `"],"theme":"dark"}`)
+	suite.Require().NoError(db.Create(f.fan).Error)
+	return f
+}
+
+// TestGetOwnedResources tests listing the resources owned by a user
+func (suite *UserOffboardingRepositoryTestSuite) TestGetOwnedResources() {
+	f := suite.createOwnedFixture()
+
+	owned, err := suite.repo.GetOwnedResources(f.user.ID, f.user.UserID)
+	suite.Require().NoError(err)
+	suite.Require().Len(owned.Links, 1)
+	suite.Equal(f.link.ID, owned.Links[0].ID)
+	suite.Len(owned.Teams, 1)
+	suite.Len(owned.Groups, 1)
+	suite.Len(owned.Organizations, 1)
+	suite.Equal(int64(1), owned.FavoritedBy)
+
+	owned, err = suite.repo.GetOwnedResources(f.fan.ID, f.fan.UserID)
+	suite.Require().NoError(err)
+	suite.Empty(owned.Links)
+	suite.Empty(owned.Teams)
+	suite.Zero(owned.FavoritedBy)
+}
+
+// TestOffboard_Reassigns tests handing the resources of a user over to another user
+func (suite *UserOffboardingRepositoryTestSuite) TestOffboard_Reassigns() {
+	f := suite.createOwnedFixture()
+	db := suite.baseTestSuite.DB
+
+	record := &models.UserOffboarding{
+		UserID:            f.user.ID,
+		Username:          f.user.UserID,
+		LinkOwnerID:       &f.fan.ID,
+		TeamOwner:         f.fan.UserID,
+		GroupOwner:        f.fan.UserID,
+		OrganizationOwner: f.fan.UserID,
+		OffboardedBy:      "I99999",
+		Reason:            "left the company",
+	}
+	suite.Require().NoError(suite.repo.Offboard(record))
+	suite.Equal(1, record.LinkCount)
+	suite.Equal(1, record.TeamCount)
+	suite.Equal(1, record.GroupCount)
+	suite.Equal(1, record.OrganizationCount)
+	suite.Zero(record.FavoritesRemoved)
+
+	var link models.Link
+	suite.Require().NoError(db.First(&link, "id = ?", f.link.ID).Error)
+	suite.Equal(f.fan.ID, link.Owner)
+	suite.Equal(int64(2), link.Version)
+	var team models.Team
+	suite.Require().NoError(db.First(&team, "id = ?", f.team.ID).Error)
+	suite.Equal(f.fan.UserID, team.Owner)
+	suite.Equal("I99999", team.UpdatedBy)
+
+	// The user is deleted and their membership ended
+	suite.ErrorIs(db.First(&models.User{}, "id = ?", f.user.ID).Error, gorm.ErrRecordNotFound)
+	var memberships []models.TeamMembership
+	suite.Require().NoError(db.Where("user_id = ? AND ended_at IS NULL", f.user.ID).Find(&memberships).Error)
+	suite.Empty(memberships)
+
+	history, err := suite.repo.GetHistory(10)
+	suite.Require().NoError(err)
+	suite.Require().Len(history, 1)
+	suite.Equal(f.user.UserID, history[0].Username)
+	suite.Equal(1, history[0].TeamCount)
+}
+
+// TestOffboard_DeletesLinks tests that deleted links are removed from favorites
+func (suite *UserOffboardingRepositoryTestSuite) TestOffboard_DeletesLinks() {
+	f := suite.createOwnedFixture()
+	db := suite.baseTestSuite.DB
+
+	record := &models.UserOffboarding{
+		UserID:            f.user.ID,
+		Username:          f.user.UserID,
+		TeamOwner:         f.fan.UserID,
+		GroupOwner:        f.fan.UserID,
+		OrganizationOwner: f.fan.UserID,
+		OffboardedBy:      "I99999",
+	}
+	suite.Require().NoError(suite.repo.Offboard(record))
+	suite.Equal(1, record.LinkCount)
+	suite.Equal(1, record.FavoritesRemoved)
+
+	suite.ErrorIs(db.First(&models.Link{}, "id = ?", f.link.ID).Error, gorm.ErrRecordNotFound)
+	var fan models.User
+	suite.Require().NoError(db.First(&fan, "id = ?", f.fan.ID).Error)
+	var meta struct {
+		Favorites []string `json:"favorites"`
+		Theme     string   `json:"theme"`
+	}
+	suite.Require().NoError(json.Unmarshal(fan.Metadata, &meta))
+	suite.Len(meta.Favorites, 1)
+	suite.NotContains(meta.Favorites, f.link.ID.String())
+	suite.Equal("dark", meta.Theme)
+}
+
+// TestUserOffboardingRepositoryTestSuite runs the test suite
+func TestUserOffboardingRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(UserOffboardingRepositoryTestSuite))
+}
@@ -5,6 +5,7 @@ package repository
 
 import (
 	"testing"
+	"time"
 
 	"developer-portal-backend/internal/database/models"
 	"developer-portal-backend/internal/testutils"
@@ -95,6 +96,23 @@ func (suite *UserRepositoryTestSuite) TestCreateDuplicateEmail() {
 	suite.Contains(err.Error(), "duplicate key value")
 }
 
+// TestCreateBatch tests that a batch is created in one transaction
+func (suite *UserRepositoryTestSuite) TestCreateBatch() {
+	member1 := suite.factories.User.WithEmail("batch1@example.com")
+	member2 := suite.factories.User.WithEmail("batch2@example.com")
+	err := suite.repo.CreateBatch([]*models.User{member1, member2})
+	suite.NoError(err)
+	suite.NotEqual(uuid.Nil, member2.ID)
+
+	// A duplicate email rolls back the whole batch
+	member3 := suite.factories.User.WithEmail("batch3@example.com")
+	duplicate := suite.factories.User.WithEmail("batch1@example.com")
+	err = suite.repo.CreateBatch([]*models.User{member3, duplicate})
+	suite.Error(err)
+	_, err = suite.repo.GetByEmail("batch3@example.com")
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+}
+
 // TestGetByID tests retrieving a member by ID
 func (suite *UserRepositoryTestSuite) TestGetByID() {
 	// Create organization first
@@ -309,7 +327,7 @@ func (suite *UserRepositoryTestSuite) TestDelete() {
 	suite.NoError(err)
 
 	// Delete the member
-	err = suite.repo.Delete(member.ID)
+	err = suite.repo.Delete(member.ID, "admin")
 	suite.NoError(err)
 
 	// Verify member is deleted
@@ -318,11 +336,27 @@ func (suite *UserRepositoryTestSuite) TestDelete() {
 	suite.Equal(gorm.ErrRecordNotFound, err)
 }
 
+// TestGetDeletedByUserID tests retrieving a deleted member by user_id
+func (suite *UserRepositoryTestSuite) TestGetDeletedByUserID() {
+	member := suite.factories.User.Create()
+	suite.Require().NoError(suite.repo.Create(member))
+
+	// Only deleted members are found
+	_, err := suite.repo.GetDeletedByUserID(member.UserID)
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+
+	suite.Require().NoError(suite.repo.Delete(member.ID, "admin"))
+	deleted, err := suite.repo.GetDeletedByUserID(member.UserID)
+	suite.Require().NoError(err)
+	suite.Equal(member.ID, deleted.ID)
+	suite.True(deleted.DeletedAt.Valid)
+}
+
 // TestDeleteNotFound tests deleting a non-existent member
 func (suite *UserRepositoryTestSuite) TestDeleteNotFound() {
 	nonExistentID := uuid.New()
 
-	err := suite.repo.Delete(nonExistentID)
+	err := suite.repo.Delete(nonExistentID, "admin")
 
 	// Should not error when deleting non-existent record
 	suite.NoError(err)
@@ -693,6 +727,96 @@ func (suite *UserRepositoryTestSuite) TestSearch() {
 	suite.GreaterOrEqual(total, int64(3))
 }
 
+// TestTeamMemberships tests that team changes are recorded as time-ranged memberships
+func (suite *UserRepositoryTestSuite) TestTeamMemberships() {
+	// Create organization
+	org := suite.factories.Organization.Create()
+	orgRepo := NewOrganizationRepository(suite.baseTestSuite.DB)
+	err := orgRepo.Create(org)
+	suite.NoError(err)
+
+	// Create team
+	group := suite.factories.Group.WithOrganization(org.ID)
+	groupRepo := NewGroupRepository(suite.baseTestSuite.DB)
+	err = groupRepo.Create(group)
+	suite.NoError(err)
+
+	team := suite.factories.Team.WithGroup(group.ID)
+	teamRepo := NewTeamRepository(suite.baseTestSuite.DB)
+	err = teamRepo.Create(team)
+	suite.NoError(err)
+
+	// Create member with team
+	member := suite.factories.User.WithEmail("membership-test@example.com")
+	member.OrganizationID = org.ID
+	member.TeamID = &team.ID
+	member.TeamRole = models.TeamRoleMember
+	err = suite.repo.Create(member)
+	suite.NoError(err)
+	joined := time.Now()
+
+	// Changing the role starts a new membership
+	member.TeamRole = models.TeamRoleScM
+	err = suite.repo.Update(member)
+	suite.NoError(err)
+	promoted := time.Now()
+
+	// Updating another field keeps it
+	member.FirstName = "Renamed"
+	err = suite.repo.Update(member)
+	suite.NoError(err)
+
+	err = suite.repo.RemoveFromTeam(member.ID)
+	suite.NoError(err)
+
+	memberships, err := suite.repo.GetMemberships(member.ID)
+	suite.NoError(err)
+	suite.Require().Len(memberships, 2)
+	suite.Equal(models.TeamRoleScM, memberships[0].TeamRole)
+	suite.NotNil(memberships[0].EndedAt)
+	suite.Equal(models.TeamRoleMember, memberships[1].TeamRole)
+	suite.Equal(memberships[0].StartedAt, *memberships[1].EndedAt)
+
+	// Only the first membership overlaps the time between joining and the promotion
+	memberships, err = suite.repo.GetTeamMemberships(team.ID, joined, joined)
+	suite.NoError(err)
+	suite.Require().Len(memberships, 1)
+	suite.Equal(models.TeamRoleMember, memberships[0].TeamRole)
+	suite.Equal(member.UserID, memberships[0].User.UserID)
+
+	memberships, err = suite.repo.GetTeamMemberships(team.ID, joined, promoted)
+	suite.NoError(err)
+	suite.Len(memberships, 2)
+
+	// Nobody is a member now
+	memberships, err = suite.repo.GetTeamMemberships(team.ID, time.Now(), time.Now())
+	suite.NoError(err)
+	suite.Empty(memberships)
+}
+
+// TestDeleteEndsMembership tests that deleting a member ends their membership on behalf of the deleter
+func (suite *UserRepositoryTestSuite) TestDeleteEndsMembership() {
+	org := suite.factories.Organization.Create()
+	suite.Require().NoError(NewOrganizationRepository(suite.baseTestSuite.DB).Create(org))
+	group := suite.factories.Group.WithOrganization(org.ID)
+	suite.Require().NoError(NewGroupRepository(suite.baseTestSuite.DB).Create(group))
+	team := suite.factories.Team.WithGroup(group.ID)
+	suite.Require().NoError(NewTeamRepository(suite.baseTestSuite.DB).Create(team))
+
+	member := suite.factories.User.WithEmail("deleted-member@example.com")
+	member.OrganizationID = org.ID
+	member.TeamID = &team.ID
+	suite.Require().NoError(suite.repo.Create(member))
+
+	suite.Require().NoError(suite.repo.Delete(member.ID, "admin"))
+
+	memberships, err := suite.repo.GetMemberships(member.ID)
+	suite.NoError(err)
+	suite.Require().Len(memberships, 1)
+	suite.NotNil(memberships[0].EndedAt)
+	suite.Equal("admin", memberships[0].EndedBy)
+}
+
 // Run the test suite
 func TestUserRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(UserRepositoryTestSuite))
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ErrVersionConflict is returned by updates of versioned models when the stored row no longer has
+// the version of the model, i.e. someone else updated it after it was read
+var ErrVersionConflict = errors.New("record was modified concurrently")
+
+// nextVersion is the update expression incrementing a row's version
+var nextVersion = gorm.Expr("version + 1")
+
+// updateVersioned saves all fields of the model with the given id and version, like Save, but only
+// if the stored row still has that version. On success the version is incremented in the model too.
+// Returns gorm.ErrRecordNotFound if there is no such row and ErrVersionConflict if it has another version.
+func updateVersioned(db *gorm.DB, model interface{}, id uuid.UUID, version *int64) error {
+	expected := *version
+	*version = expected + 1
+	res := db.Model(model).Where("version = ?", expected).Select("*").Updates(model)
+	if res.Error == nil && res.RowsAffected > 0 {
+		return nil
+	}
+	*version = expected
+	if res.Error != nil {
+		return res.Error
+	}
+
+	var count int64
+	if err := db.Model(model).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return ErrVersionConflict
+}
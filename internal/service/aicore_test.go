@@ -29,6 +29,11 @@ func (m *MockUserRepository) Create(user *models.User) error {
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) CreateBatch(users []*models.User) error {
+	args := m.Called(users)
+	return args.Error(0)
+}
+
 func (m *MockUserRepository) GetByID(id uuid.UUID) (*models.User, error) {
 	args := m.Called(id)
 	user, _ := args.Get(0).(*models.User)
@@ -101,19 +106,37 @@ func (m *MockUserRepository) GetUserIDsByPrefix(prefix string) ([]string, error)
 	return ids, args.Error(1)
 }
 
+func (m *MockUserRepository) GetDeletedByUserID(userID string) (*models.User, error) {
+	args := m.Called(userID)
+	user, _ := args.Get(0).(*models.User)
+	return user, args.Error(1)
+}
+
 func (m *MockUserRepository) GetExistingUserIDs(ids []string) ([]string, error) {
 	args := m.Called(ids)
 	existing, _ := args.Get(0).([]string)
 	return existing, args.Error(1)
 }
 
+func (m *MockUserRepository) GetTeamMemberships(teamID uuid.UUID, from, to time.Time) ([]models.TeamMembership, error) {
+	args := m.Called(teamID, from, to)
+	memberships, _ := args.Get(0).([]models.TeamMembership)
+	return memberships, args.Error(1)
+}
+
+func (m *MockUserRepository) GetMemberships(memberID uuid.UUID) ([]models.TeamMembership, error) {
+	args := m.Called(memberID)
+	memberships, _ := args.Get(0).([]models.TeamMembership)
+	return memberships, args.Error(1)
+}
+
 func (m *MockUserRepository) Update(member *models.User) error {
 	args := m.Called(member)
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) Delete(id uuid.UUID) error {
-	args := m.Called(id)
+func (m *MockUserRepository) Delete(id uuid.UUID, deletedBy string) error {
+	args := m.Called(id, deletedBy)
 	return args.Error(0)
 }
 
@@ -232,8 +255,8 @@ func (m *MockGroupRepository) Search(organizationID uuid.UUID, query string, lim
 	return args.Get(0).([]models.Group), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockGroupRepository) Update(id uuid.UUID, updates map[string]interface{}) error {
-	args := m.Called(id, updates)
+func (m *MockGroupRepository) Update(group *models.Group) error {
+	args := m.Called(group)
 	return args.Error(0)
 }
 
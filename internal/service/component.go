@@ -4,8 +4,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
+	"developer-portal-backend/internal/database/models"
 	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/metaschema"
 	"developer-portal-backend/internal/repository"
 
 	"github.com/go-playground/validator/v10"
@@ -13,41 +17,153 @@ import (
 	"gorm.io/gorm"
 )
 
+// deprecationDateLayout is the format of component deprecation dates in requests and responses
+const deprecationDateLayout = "2006-01-02"
+
 // ComponentService handles business logic for components
 type ComponentService struct {
-	repo             *repository.ComponentRepository
-	organizationRepo *repository.OrganizationRepository
-	projectRepo      *repository.ProjectRepository
-	validator        *validator.Validate
+	repo            repository.ComponentRepositoryInterface
+	projectRepo     repository.ProjectRepositoryInterface
+	teamRepo        repository.TeamRepositoryInterface
+	validator       *validator.Validate
+	metadataSchemas *metaschema.Registry
 }
 
+// Ensure ComponentService implements ComponentServiceInterface
+var _ ComponentServiceInterface = (*ComponentService)(nil)
+
 // NewComponentService creates a new component service
-func NewComponentService(repo *repository.ComponentRepository, orgRepo *repository.OrganizationRepository, projRepo *repository.ProjectRepository, validator *validator.Validate) *ComponentService {
+func NewComponentService(
+	repo repository.ComponentRepositoryInterface,
+	projRepo repository.ProjectRepositoryInterface,
+	teamRepo repository.TeamRepositoryInterface,
+	validator *validator.Validate,
+	metadataSchemas *metaschema.Registry,
+) *ComponentService {
 	return &ComponentService{
-		repo:             repo,
-		organizationRepo: orgRepo,
-		projectRepo:      projRepo,
-		validator:        validator,
+		repo:            repo,
+		projectRepo:     projRepo,
+		teamRepo:        teamRepo,
+		validator:       validator,
+		metadataSchemas: metadataSchemas,
+	}
+}
+
+// CreateComponentRequest represents the payload for creating a component.
+// The fields are validated against the tags of models.Component.
+type CreateComponentRequest struct {
+	Name        string    `json:"name"`
+	Title       string    `json:"title"`
+	Description string    `json:"description"`
+	ProjectID   uuid.UUID `json:"project_id"`
+	OwnerID     uuid.UUID `json:"owner_id"` // owning team
+	// Lifecycle is experimental, production (the default), deprecated or end-of-life
+	Lifecycle models.ComponentLifecycle `json:"lifecycle"`
+	// DeprecationDate (YYYY-MM-DD) and ReplacementID are only allowed for deprecated or end-of-life components
+	DeprecationDate string          `json:"deprecation_date"`
+	ReplacementID   *uuid.UUID      `json:"replacement_id"`
+	Metadata        json.RawMessage `json:"metadata" swaggertype:"object"`
+	CreatedBy       string          `json:"-"` // derived from bearer token
+}
+
+// UpdateComponentRequest represents the payload for updating a component. Omitted fields are kept.
+// Moving a component back to experimental or production clears its deprecation date and replacement.
+type UpdateComponentRequest struct {
+	Title       *string                    `json:"title"`
+	Description *string                    `json:"description"`
+	Lifecycle   *models.ComponentLifecycle `json:"lifecycle"`
+	// DeprecationDate is YYYY-MM-DD; an empty string clears it
+	DeprecationDate *string `json:"deprecation_date"`
+	// ReplacementID is the component to use instead; the nil UUID clears it
+	ReplacementID *uuid.UUID      `json:"replacement_id"`
+	Metadata      json.RawMessage `json:"metadata" swaggertype:"object"`
+	UpdatedBy     string          `json:"-"` // derived from bearer token
+	// ExpectedVersion is the version the update is based on, from the If-Match header. Zero updates any version.
+	ExpectedVersion int64 `json:"-"`
+}
+
+// TransferOwnershipRequest represents the payload for transferring a component to another team
+type TransferOwnershipRequest struct {
+	OwnerID   uuid.UUID `json:"owner_id" binding:"required"`
+	Reason    string    `json:"reason" binding:"max=200"`
+	ChangedBy string    `json:"-"` // derived from bearer token
+	// ExpectedVersion is the version the transfer is based on, from the If-Match header. Zero transfers any version.
+	ExpectedVersion int64 `json:"-"`
+}
+
+// ComponentResponse represents a component in API responses
+type ComponentResponse struct {
+	ID              uuid.UUID                 `json:"id"`
+	Name            string                    `json:"name"`
+	Title           string                    `json:"title"`
+	Description     string                    `json:"description"`
+	ProjectID       uuid.UUID                 `json:"project_id"`
+	OwnerID         uuid.UUID                 `json:"owner_id"`
+	Lifecycle       models.ComponentLifecycle `json:"lifecycle"`
+	DeprecationDate string                    `json:"deprecation_date,omitempty"`
+	ReplacementID   *uuid.UUID                `json:"replacement_id,omitempty"`
+	Metadata        json.RawMessage           `json:"metadata" swaggertype:"object"`
+	Version         int64                     `json:"version"` // for If-Match, see the ETag header
+	CreatedAt       string                    `json:"created_at"`
+	CreatedBy       string                    `json:"created_by"`
+	UpdatedAt       string                    `json:"updated_at"`
+	UpdatedBy       string                    `json:"updated_by"`
+}
+
+// LifecycleFilter selects components by lifecycle state
+type LifecycleFilter map[models.ComponentLifecycle]bool
+
+// ParseLifecycleFilter parses a comma-separated list of lifecycle states. Without states it selects
+// every component that has not reached end-of-life.
+func ParseLifecycleFilter(raw string) (LifecycleFilter, error) {
+	filter := LifecycleFilter{}
+	for _, part := range strings.Split(raw, ",") {
+		lifecycle := models.ComponentLifecycle(strings.TrimSpace(part))
+		if lifecycle == "" {
+			continue
+		}
+		if !lifecycle.IsValid() {
+			return nil, apperrors.NewValidationError("lifecycle", fmt.Sprintf("unknown lifecycle %q, expected experimental, production, deprecated or end-of-life", lifecycle))
+		}
+		filter[lifecycle] = true
 	}
+	if len(filter) == 0 {
+		filter[models.ComponentLifecycleExperimental] = true
+		filter[models.ComponentLifecycleProduction] = true
+		filter[models.ComponentLifecycleDeprecated] = true
+	}
+	return filter, nil
+}
+
+// Matches reports whether the filter selects the lifecycle. Rows from before lifecycles count as production.
+func (f LifecycleFilter) Matches(lifecycle models.ComponentLifecycle) bool {
+	if lifecycle == "" {
+		lifecycle = models.ComponentLifecycleProduction
+	}
+	return f[lifecycle]
 }
 
 // ComponentProjectView is a minimal view for /components?project-name=<name>
 type ComponentProjectView struct {
-	ID          uuid.UUID       `json:"id"`
-	OwnerID     uuid.UUID       `json:"owner_id"`
-	Name        string          `json:"name"`
-	Title       string          `json:"title"`
-	Description string          `json:"description"`
-	QOS         string          `json:"qos,omitempty"`
-	Sonar       string          `json:"sonar,omitempty"`
-	GitHub      string          `json:"github,omitempty"`
-	Metadata    json.RawMessage `json:"metadata,omitempty" swaggertype:"object"`
+	ID              uuid.UUID                 `json:"id"`
+	OwnerID         uuid.UUID                 `json:"owner_id"`
+	Name            string                    `json:"name"`
+	Title           string                    `json:"title"`
+	Description     string                    `json:"description"`
+	Lifecycle       models.ComponentLifecycle `json:"lifecycle"`
+	DeprecationDate string                    `json:"deprecation_date,omitempty"`
+	ReplacementID   *uuid.UUID                `json:"replacement_id,omitempty"`
+	QOS             string                    `json:"qos,omitempty"`
+	Sonar           string                    `json:"sonar,omitempty"`
+	GitHub          string                    `json:"github,omitempty"`
+	Metadata        json.RawMessage           `json:"metadata,omitempty" swaggertype:"object"`
 }
 
 // GetByProjectNameAllView returns ALL components for a project (unpaginated) with a minimal view:
 // - Omits project_id, created_at, updated_at, metadata
 // - Adds fields: qos (metadata.ci.qos), sonar (metadata.sonar.project_id), github (metadata.github.url)
-func (s *ComponentService) GetByProjectNameAllView(projectName string) ([]ComponentProjectView, error) {
+// - Only includes components whose lifecycle matches the filter
+func (s *ComponentService) GetByProjectNameAllView(projectName string, lifecycles LifecycleFilter) ([]ComponentProjectView, error) {
 	if projectName == "" {
 		return []ComponentProjectView{}, nil
 	}
@@ -62,20 +178,29 @@ func (s *ComponentService) GetByProjectNameAllView(projectName string) ([]Compon
 		return nil, apperrors.ErrProjectNotFound
 	}
 
-	components, _, err := s.repo.GetComponentsByProjectID(project.ID, 1000000, 0)
+	all, _, err := s.repo.GetByProjectID(project.ID, 1000000, 0)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get components by project: %w", err)
 	}
+	components := make([]models.Component, 0, len(all))
+	for _, c := range all {
+		if lifecycles.Matches(c.Lifecycle) {
+			components = append(components, c)
+		}
+	}
 
 	views := make([]ComponentProjectView, len(components))
 	for i, c := range components {
 		views[i] = ComponentProjectView{
-			ID:          c.ID,
-			OwnerID:     c.OwnerID,
-			Name:        c.Name,
-			Title:       c.Title,
-			Description: c.Description,
-			Metadata:    c.Metadata, // Include full metadata for filtering
+			ID:              c.ID,
+			OwnerID:         c.OwnerID,
+			Name:            c.Name,
+			Title:           c.Title,
+			Description:     c.Description,
+			Lifecycle:       c.Lifecycle,
+			DeprecationDate: formatDeprecationDate(c.DeprecationDate),
+			ReplacementID:   c.ReplacementID,
+			Metadata:        c.Metadata, // Include full metadata for filtering
 		}
 
 		// Extract qos, sonar, github from metadata if present
@@ -133,3 +258,307 @@ func (s *ComponentService) GetProjectTitleByID(id uuid.UUID) (string, error) {
 	}
 	return project.Title, nil
 }
+
+// CreateComponent validates and creates a component with a name unique in its project
+func (s *ComponentService) CreateComponent(req *CreateComponentRequest) (*ComponentResponse, error) {
+	lifecycle := req.Lifecycle
+	if lifecycle == "" {
+		lifecycle = models.ComponentLifecycleProduction
+	}
+	deprecationDate, err := parseDeprecationDate(req.DeprecationDate)
+	if err != nil {
+		return nil, err
+	}
+	component := &models.Component{
+		BaseModel: models.BaseModel{
+			Name:        req.Name,
+			Title:       req.Title,
+			Description: req.Description,
+			Metadata:    req.Metadata,
+			CreatedBy:   req.CreatedBy,
+			UpdatedBy:   req.CreatedBy,
+		},
+		ProjectID:       req.ProjectID,
+		OwnerID:         req.OwnerID,
+		Lifecycle:       lifecycle,
+		DeprecationDate: deprecationDate,
+		ReplacementID:   req.ReplacementID,
+	}
+	if err := s.validate(component); err != nil {
+		return nil, err
+	}
+	if err := s.checkReplacement(component); err != nil {
+		return nil, err
+	}
+	if err := s.checkTeam(component.OwnerID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.GetByName(component.ProjectID, component.Name); err == nil {
+		return nil, apperrors.ErrComponentExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check component name: %w", err)
+	}
+
+	if err := s.repo.Create(component); err != nil {
+		return nil, fmt.Errorf("failed to create component: %w", err)
+	}
+	return toComponentResponse(component), nil
+}
+
+// GetComponentByID retrieves a component by ID
+func (s *ComponentService) GetComponentByID(id uuid.UUID) (*ComponentResponse, error) {
+	component, err := s.getComponent(id)
+	if err != nil {
+		return nil, err
+	}
+	return toComponentResponse(component), nil
+}
+
+// UpdateComponent updates the given fields of a component. Without an expected version the update
+// is applied to the latest version, retrying if the component is updated concurrently.
+func (s *ComponentService) UpdateComponent(id uuid.UUID, req *UpdateComponentRequest) (*ComponentResponse, error) {
+	var deprecationDate *time.Time
+	if req.DeprecationDate != nil {
+		var err error
+		if deprecationDate, err = parseDeprecationDate(*req.DeprecationDate); err != nil {
+			return nil, err
+		}
+	}
+
+	var component *models.Component
+	err := retryOnConflict(req.ExpectedVersion, func() error {
+		var err error
+		component, err = s.getComponent(id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(req.ExpectedVersion, component.Version); err != nil {
+			return err
+		}
+
+		if req.Title != nil {
+			component.Title = *req.Title
+		}
+		if req.Description != nil {
+			component.Description = *req.Description
+		}
+		if req.Metadata != nil {
+			component.Metadata = req.Metadata
+		}
+		if req.Lifecycle != nil {
+			component.Lifecycle = *req.Lifecycle
+			if !component.Lifecycle.IsRetiring() {
+				component.DeprecationDate = nil
+				component.ReplacementID = nil
+			}
+		}
+		if req.DeprecationDate != nil {
+			component.DeprecationDate = deprecationDate
+		}
+		if req.ReplacementID != nil {
+			component.ReplacementID = req.ReplacementID
+			if *req.ReplacementID == uuid.Nil {
+				component.ReplacementID = nil
+			}
+		}
+		component.UpdatedBy = req.UpdatedBy
+		if err := s.validate(component); err != nil {
+			return err
+		}
+		if req.ReplacementID != nil {
+			if err := s.checkReplacement(component); err != nil {
+				return err
+			}
+		}
+
+		if err := s.repo.Update(component); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperrors.ErrComponentNotFound
+			}
+			if errors.Is(err, repository.ErrVersionConflict) {
+				return err
+			}
+			return fmt.Errorf("failed to update component: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toComponentResponse(component), nil
+}
+
+// DeleteComponent deletes a component
+func (s *ComponentService) DeleteComponent(id uuid.UUID) error {
+	if _, err := s.getComponent(id); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete component: %w", err)
+	}
+	return nil
+}
+
+// TransferOwnership moves a component to another team and records the transfer in its ownership history
+func (s *ComponentService) TransferOwnership(id uuid.UUID, req *TransferOwnershipRequest) (*ComponentResponse, error) {
+	if err := s.checkTeam(req.OwnerID); err != nil {
+		return nil, err
+	}
+
+	var component *models.Component
+	err := retryOnConflict(req.ExpectedVersion, func() error {
+		var err error
+		component, err = s.getComponent(id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(req.ExpectedVersion, component.Version); err != nil {
+			return err
+		}
+		if component.OwnerID == req.OwnerID {
+			return apperrors.NewValidationError("owner_id", "component is already owned by this team")
+		}
+
+		change := &models.ComponentOwnershipChange{
+			ComponentID: component.ID,
+			FromOwnerID: component.OwnerID,
+			ToOwnerID:   req.OwnerID,
+			ChangedBy:   req.ChangedBy,
+			Reason:      req.Reason,
+		}
+		component.OwnerID = req.OwnerID
+		component.UpdatedBy = req.ChangedBy
+		if err := s.repo.TransferOwnership(component, change); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperrors.ErrComponentNotFound
+			}
+			if errors.Is(err, repository.ErrVersionConflict) {
+				return err
+			}
+			return fmt.Errorf("failed to transfer component: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toComponentResponse(component), nil
+}
+
+// GetOwnershipHistory retrieves the ownership transfers of a component, newest first
+func (s *ComponentService) GetOwnershipHistory(id uuid.UUID) ([]models.ComponentOwnershipChange, error) {
+	if _, err := s.getComponent(id); err != nil {
+		return nil, err
+	}
+	changes, err := s.repo.GetOwnershipHistory(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ownership history: %w", err)
+	}
+	return changes, nil
+}
+
+// validate checks a component against the model tags, that only retiring components have a deprecation
+// date or replacement and its metadata against the schemas of components and of its project
+func (s *ComponentService) validate(component *models.Component) error {
+	if err := s.validator.Struct(component); err != nil {
+		return apperrors.NewValidationError("", err.Error())
+	}
+
+	if !component.Lifecycle.IsRetiring() && (component.DeprecationDate != nil || component.ReplacementID != nil) {
+		return apperrors.NewValidationError("lifecycle", "deprecation_date and replacement_id are only allowed for deprecated or end-of-life components")
+	}
+	project, err := s.projectRepo.GetByID(component.ProjectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrProjectNotFound
+		}
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	return s.metadataSchemas.Validate(metaschema.EntityComponent, project.Name, component.Metadata)
+}
+
+// checkReplacement verifies that a newly set replacement is another component that has not reached end-of-life
+func (s *ComponentService) checkReplacement(component *models.Component) error {
+	if component.ReplacementID == nil {
+		return nil
+	}
+	if *component.ReplacementID == component.ID {
+		return apperrors.NewValidationError("replacement_id", "a component cannot replace itself")
+	}
+	replacement, err := s.repo.GetByID(*component.ReplacementID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.NewValidationError("replacement_id", "replacement component not found")
+		}
+		return fmt.Errorf("failed to get replacement component: %w", err)
+	}
+	if replacement.Lifecycle == models.ComponentLifecycleEndOfLife {
+		return apperrors.NewValidationError("replacement_id", "replacement component has reached end-of-life")
+	}
+	return nil
+}
+
+// checkTeam verifies that the owning team exists
+func (s *ComponentService) checkTeam(id uuid.UUID) error {
+	if _, err := s.teamRepo.GetByID(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrTeamNotFound
+		}
+		return fmt.Errorf("failed to get team: %w", err)
+	}
+	return nil
+}
+
+// getComponent loads a component, mapping a missing one to ErrComponentNotFound
+func (s *ComponentService) getComponent(id uuid.UUID) (*models.Component, error) {
+	component, err := s.repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrComponentNotFound
+		}
+		return nil, fmt.Errorf("failed to get component: %w", err)
+	}
+	return component, nil
+}
+
+// parseDeprecationDate parses a YYYY-MM-DD date; an empty string is no date
+func parseDeprecationDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse(deprecationDateLayout, value)
+	if err != nil {
+		return nil, apperrors.NewValidationError("deprecation_date", "expected a date like 2025-12-31")
+	}
+	return &date, nil
+}
+
+// formatDeprecationDate formats a deprecation date as YYYY-MM-DD, or returns "" if there is none
+func formatDeprecationDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format(deprecationDateLayout)
+}
+
+// toComponentResponse converts a Component model to ComponentResponse
+func toComponentResponse(component *models.Component) *ComponentResponse {
+	return &ComponentResponse{
+		ID:              component.ID,
+		Name:            component.Name,
+		Title:           component.Title,
+		Description:     component.Description,
+		ProjectID:       component.ProjectID,
+		OwnerID:         component.OwnerID,
+		Lifecycle:       component.Lifecycle,
+		DeprecationDate: formatDeprecationDate(component.DeprecationDate),
+		ReplacementID:   component.ReplacementID,
+		Metadata:        component.Metadata,
+		Version:         component.Version,
+		CreatedAt:       component.CreatedAt.Format(time.RFC3339),
+		CreatedBy:       component.CreatedBy,
+		UpdatedAt:       component.UpdatedAt.Format(time.RFC3339),
+		UpdatedBy:       component.UpdatedBy,
+	}
+}
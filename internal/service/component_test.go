@@ -1,10 +1,270 @@
 package service_test
 
-import "testing"
+import (
+	"encoding/json"
+	"testing"
 
-// Placeholder test: ComponentService DTO validation tests removed as DTOs were deleted.
-// Current ComponentService only provides project-related view helpers, which are covered via handler integration.
-// This test intentionally skips.
-func TestComponentServicePlaceholder(t *testing.T) {
-	t.Skip("ComponentService DTO tests removed; no unit tests for minimal service helpers at this time.")
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/metaschema"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/repository"
+	"developer-portal-backend/internal/service"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type ComponentServiceTestSuite struct {
+	suite.Suite
+	ctrl             *gomock.Controller
+	mockRepo         *mocks.MockComponentRepositoryInterface
+	mockProjectRepo  *mocks.MockProjectRepositoryInterface
+	mockTeamRepo     *mocks.MockTeamRepositoryInterface
+	componentService *service.ComponentService
+	project          *models.Project
+	team             *models.Team
+}
+
+func (suite *ComponentServiceTestSuite) SetupTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockRepo = mocks.NewMockComponentRepositoryInterface(suite.ctrl)
+	suite.mockProjectRepo = mocks.NewMockProjectRepositoryInterface(suite.ctrl)
+	suite.mockTeamRepo = mocks.NewMockTeamRepositoryInterface(suite.ctrl)
+	schemas, err := metaschema.NewRegistry()
+	suite.Require().NoError(err)
+	suite.componentService = service.NewComponentService(suite.mockRepo, suite.mockProjectRepo, suite.mockTeamRepo, validator.New(), schemas)
+
+	suite.project = &models.Project{BaseModel: models.BaseModel{ID: uuid.New(), Name: "cis20", Title: "CIS 2.0"}}
+	suite.team = &models.Team{BaseModel: models.BaseModel{ID: uuid.New(), Name: "team-a", Title: "Team A"}}
+	suite.mockProjectRepo.EXPECT().GetByID(suite.project.ID).Return(suite.project, nil).AnyTimes()
+	suite.mockTeamRepo.EXPECT().GetByID(suite.team.ID).Return(suite.team, nil).AnyTimes()
+}
+
+func (suite *ComponentServiceTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *ComponentServiceTestSuite) newComponent(version int64, lifecycle models.ComponentLifecycle) *models.Component {
+	return &models.Component{
+		BaseModel: models.BaseModel{ID: uuid.New(), Name: "api", Title: "API", Version: version},
+		ProjectID: suite.project.ID,
+		OwnerID:   suite.team.ID,
+		Lifecycle: lifecycle,
+	}
+}
+
+// storedComponent makes GetByID return a fresh copy of component on every call, like the database would
+func (suite *ComponentServiceTestSuite) storedComponent(component *models.Component) *gomock.Call {
+	return suite.mockRepo.EXPECT().GetByID(component.ID).DoAndReturn(func(uuid.UUID) (*models.Component, error) {
+		stored := *component
+		return &stored, nil
+	})
+}
+
+func (suite *ComponentServiceTestSuite) TestCreateComponent() {
+	suite.mockRepo.EXPECT().GetByName(suite.project.ID, "api").Return(nil, gorm.ErrRecordNotFound)
+	suite.mockRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(component *models.Component) error {
+		suite.Equal(models.ComponentLifecycleProduction, component.Lifecycle)
+		suite.Equal("jdoe", component.CreatedBy)
+		component.Version = 1
+		return nil
+	})
+
+	resp, err := suite.componentService.CreateComponent(&service.CreateComponentRequest{
+		Name: "api", Title: "API", ProjectID: suite.project.ID, OwnerID: suite.team.ID, CreatedBy: "jdoe",
+	})
+	suite.Require().NoError(err)
+	suite.Equal(models.ComponentLifecycleProduction, resp.Lifecycle)
+	suite.Equal(int64(1), resp.Version)
+}
+
+func (suite *ComponentServiceTestSuite) TestCreateComponent_Errors() {
+	valid := service.CreateComponentRequest{Name: "api", Title: "API", ProjectID: suite.project.ID, OwnerID: suite.team.ID}
+
+	req := valid
+	req.Lifecycle = "retired"
+	_, err := suite.componentService.CreateComponent(&req)
+	suite.True(apperrors.IsValidation(err))
+	suite.ErrorContains(err, "Lifecycle")
+
+	req = valid
+	req.DeprecationDate = "2026-03-31"
+	_, err = suite.componentService.CreateComponent(&req)
+	suite.True(apperrors.IsValidation(err), "deprecation date on a production component")
+
+	req = valid
+	req.Lifecycle = models.ComponentLifecycleDeprecated
+	req.DeprecationDate = "31.03.2026"
+	_, err = suite.componentService.CreateComponent(&req)
+	suite.True(apperrors.IsValidation(err))
+	suite.ErrorContains(err, "deprecation_date")
+
+	retired := suite.newComponent(1, models.ComponentLifecycleEndOfLife)
+	suite.storedComponent(retired)
+	req = valid
+	req.Lifecycle = models.ComponentLifecycleDeprecated
+	req.ReplacementID = &retired.ID
+	_, err = suite.componentService.CreateComponent(&req)
+	suite.True(apperrors.IsValidation(err))
+	suite.ErrorContains(err, "end-of-life")
+
+	req = valid
+	req.Metadata = json.RawMessage(`{"sonar":{"project_id":""}}`)
+	_, err = suite.componentService.CreateComponent(&req)
+	suite.True(apperrors.IsValidation(err))
+
+	req = valid
+	req.OwnerID = uuid.New()
+	suite.mockTeamRepo.EXPECT().GetByID(req.OwnerID).Return(nil, gorm.ErrRecordNotFound)
+	_, err = suite.componentService.CreateComponent(&req)
+	suite.ErrorIs(err, apperrors.ErrTeamNotFound)
+
+	suite.mockRepo.EXPECT().GetByName(suite.project.ID, "api").Return(suite.newComponent(1, models.ComponentLifecycleProduction), nil)
+	req = valid
+	_, err = suite.componentService.CreateComponent(&req)
+	suite.ErrorIs(err, apperrors.ErrComponentExists)
+}
+
+func (suite *ComponentServiceTestSuite) TestUpdateComponent_Deprecate() {
+	component := suite.newComponent(2, models.ComponentLifecycleProduction)
+	replacement := suite.newComponent(1, models.ComponentLifecycleProduction)
+	suite.storedComponent(component)
+	suite.storedComponent(replacement)
+	suite.mockRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	lifecycle := models.ComponentLifecycleDeprecated
+	date := "2026-03-31"
+	resp, err := suite.componentService.UpdateComponent(component.ID, &service.UpdateComponentRequest{
+		Lifecycle: &lifecycle, DeprecationDate: &date, ReplacementID: &replacement.ID, UpdatedBy: "jdoe", ExpectedVersion: 2,
+	})
+	suite.Require().NoError(err)
+	suite.Equal(models.ComponentLifecycleDeprecated, resp.Lifecycle)
+	suite.Equal("2026-03-31", resp.DeprecationDate)
+	suite.Equal(&replacement.ID, resp.ReplacementID)
+}
+
+func (suite *ComponentServiceTestSuite) TestUpdateComponent_ReinstateClearsDeprecation() {
+	component := suite.newComponent(2, models.ComponentLifecycleDeprecated)
+	replacement := uuid.New()
+	component.ReplacementID = &replacement
+	suite.storedComponent(component)
+	suite.mockRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(updated *models.Component) error {
+		suite.Nil(updated.ReplacementID)
+		suite.Nil(updated.DeprecationDate)
+		return nil
+	})
+
+	lifecycle := models.ComponentLifecycleProduction
+	resp, err := suite.componentService.UpdateComponent(component.ID, &service.UpdateComponentRequest{Lifecycle: &lifecycle})
+	suite.Require().NoError(err)
+	suite.Equal(models.ComponentLifecycleProduction, resp.Lifecycle)
+}
+
+func (suite *ComponentServiceTestSuite) TestUpdateComponent_Errors() {
+	component := suite.newComponent(2, models.ComponentLifecycleDeprecated)
+	suite.storedComponent(component).Times(3)
+
+	_, err := suite.componentService.UpdateComponent(component.ID, &service.UpdateComponentRequest{ExpectedVersion: 1})
+	suite.ErrorIs(err, apperrors.ErrVersionConflict)
+
+	_, err = suite.componentService.UpdateComponent(component.ID, &service.UpdateComponentRequest{ReplacementID: &component.ID})
+	suite.True(apperrors.IsValidation(err))
+	suite.ErrorContains(err, "itself")
+
+	suite.mockRepo.EXPECT().Update(gomock.Any()).Return(repository.ErrVersionConflict)
+	_, err = suite.componentService.UpdateComponent(component.ID, &service.UpdateComponentRequest{ExpectedVersion: 2})
+	suite.ErrorIs(err, apperrors.ErrVersionConflict)
+
+	suite.mockRepo.EXPECT().GetByID(gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
+	_, err = suite.componentService.UpdateComponent(uuid.New(), &service.UpdateComponentRequest{})
+	suite.ErrorIs(err, apperrors.ErrComponentNotFound)
+}
+
+func (suite *ComponentServiceTestSuite) TestTransferOwnership() {
+	component := suite.newComponent(2, models.ComponentLifecycleProduction)
+	newOwner := &models.Team{BaseModel: models.BaseModel{ID: uuid.New(), Name: "team-b", Title: "Team B"}}
+	suite.mockTeamRepo.EXPECT().GetByID(newOwner.ID).Return(newOwner, nil)
+	suite.storedComponent(component)
+	suite.mockRepo.EXPECT().TransferOwnership(gomock.Any(), gomock.Any()).DoAndReturn(func(updated *models.Component, change *models.ComponentOwnershipChange) error {
+		suite.Equal(newOwner.ID, updated.OwnerID)
+		suite.Equal(component.ID, change.ComponentID)
+		suite.Equal(suite.team.ID, change.FromOwnerID)
+		suite.Equal(newOwner.ID, change.ToOwnerID)
+		suite.Equal("jdoe", change.ChangedBy)
+		suite.Equal("reorg", change.Reason)
+		updated.Version = 3
+		return nil
+	})
+
+	resp, err := suite.componentService.TransferOwnership(component.ID, &service.TransferOwnershipRequest{
+		OwnerID: newOwner.ID, Reason: "reorg", ChangedBy: "jdoe",
+	})
+	suite.Require().NoError(err)
+	suite.Equal(newOwner.ID, resp.OwnerID)
+	suite.Equal(int64(3), resp.Version)
+}
+
+func (suite *ComponentServiceTestSuite) TestTransferOwnership_Errors() {
+	component := suite.newComponent(2, models.ComponentLifecycleProduction)
+	suite.storedComponent(component).Times(2)
+
+	unknown := uuid.New()
+	suite.mockTeamRepo.EXPECT().GetByID(unknown).Return(nil, gorm.ErrRecordNotFound)
+	_, err := suite.componentService.TransferOwnership(component.ID, &service.TransferOwnershipRequest{OwnerID: unknown})
+	suite.ErrorIs(err, apperrors.ErrTeamNotFound)
+
+	_, err = suite.componentService.TransferOwnership(component.ID, &service.TransferOwnershipRequest{OwnerID: suite.team.ID})
+	suite.True(apperrors.IsValidation(err))
+
+	// Not retried with an expected version
+	suite.mockRepo.EXPECT().TransferOwnership(gomock.Any(), gomock.Any()).Return(repository.ErrVersionConflict)
+	other := &models.Team{BaseModel: models.BaseModel{ID: uuid.New()}}
+	suite.mockTeamRepo.EXPECT().GetByID(other.ID).Return(other, nil)
+	_, err = suite.componentService.TransferOwnership(component.ID, &service.TransferOwnershipRequest{OwnerID: other.ID, ExpectedVersion: 2})
+	suite.ErrorIs(err, apperrors.ErrVersionConflict)
+}
+
+func (suite *ComponentServiceTestSuite) TestGetOwnershipHistory() {
+	component := suite.newComponent(1, models.ComponentLifecycleProduction)
+	suite.storedComponent(component)
+	changes := []models.ComponentOwnershipChange{{ComponentID: component.ID, ToOwnerID: suite.team.ID}}
+	suite.mockRepo.EXPECT().GetOwnershipHistory(component.ID).Return(changes, nil)
+
+	history, err := suite.componentService.GetOwnershipHistory(component.ID)
+	suite.Require().NoError(err)
+	suite.Equal(changes, history)
+}
+
+func (suite *ComponentServiceTestSuite) TestGetByProjectNameAllView_FiltersLifecycle() {
+	suite.mockProjectRepo.EXPECT().GetByName("cis20").Return(suite.project, nil).Times(2)
+	components := []models.Component{
+		*suite.newComponent(1, models.ComponentLifecycleProduction),
+		*suite.newComponent(1, models.ComponentLifecycleEndOfLife),
+	}
+	suite.mockRepo.EXPECT().GetByProjectID(suite.project.ID, gomock.Any(), 0).Return(components, int64(2), nil).Times(2)
+
+	defaults, err := service.ParseLifecycleFilter("")
+	suite.Require().NoError(err)
+	views, err := suite.componentService.GetByProjectNameAllView("cis20", defaults)
+	suite.Require().NoError(err)
+	suite.Require().Len(views, 1)
+	suite.Equal(components[0].ID, views[0].ID)
+
+	eol, err := service.ParseLifecycleFilter("end-of-life, deprecated")
+	suite.Require().NoError(err)
+	views, err = suite.componentService.GetByProjectNameAllView("cis20", eol)
+	suite.Require().NoError(err)
+	suite.Require().Len(views, 1)
+	suite.Equal(components[1].ID, views[0].ID)
+
+	_, err = service.ParseLifecycleFilter("retired")
+	suite.True(apperrors.IsValidation(err))
+}
+
+func TestComponentServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ComponentServiceTestSuite))
 }
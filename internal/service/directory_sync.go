@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/logger"
+	"developer-portal-backend/internal/repository"
+)
+
+// directorySyncActor is recorded as the updater of users changed by the reconciliation
+const directorySyncActor = "ldap-sync"
+
+// directorySyncPageSize is the number of users loaded per page
+const directorySyncPageSize = 500
+
+// DirectorySyncService reconciles users with LDAP: it copies changed names, emails and mobiles,
+// flags users missing from the directory and reports those missing longer than the grace period
+// for offboarding. Optionally it deletes them instead, unless they still own resources. Deleted
+// users go to the trash, from where admins can restore them.
+type DirectorySyncService struct {
+	userRepo        repository.UserRepositoryInterface
+	offboardingRepo repository.UserOffboardingRepositoryInterface
+	ldap            *LDAPService
+	// grace is how long a user may be missing from LDAP before being reported or deleted
+	grace time.Duration
+	// deleteMissing deletes users missing longer than the grace period who own no resources
+	deleteMissing bool
+	now           func() time.Time
+
+	// running is held while a reconciliation runs
+	running sync.Mutex
+	mu      sync.RWMutex
+	report  *DirectorySyncReport
+}
+
+// Ensure DirectorySyncService implements DirectorySyncServiceInterface
+var _ DirectorySyncServiceInterface = (*DirectorySyncService)(nil)
+
+// NewDirectorySyncService creates a new DirectorySyncService
+func NewDirectorySyncService(userRepo repository.UserRepositoryInterface, offboardingRepo repository.UserOffboardingRepositoryInterface, ldap *LDAPService, grace time.Duration, deleteMissing bool) *DirectorySyncService {
+	return &DirectorySyncService{
+		userRepo:        userRepo,
+		offboardingRepo: offboardingRepo,
+		ldap:            ldap,
+		grace:           grace,
+		deleteMissing:   deleteMissing,
+		now:             time.Now,
+	}
+}
+
+// DirectorySyncReport describes the outcome of a reconciliation
+type DirectorySyncReport struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Checked    int       `json:"checked"` // users looked up in LDAP
+	// Updated are the users whose attributes changed in LDAP
+	Updated []DirectoryUserUpdate `json:"updated"`
+	// Returned are flagged users that were found in LDAP again
+	Returned []string `json:"returned"`
+	// Missing are the users missing from LDAP that are kept, within the grace period
+	Missing []DirectoryMissingUser `json:"missing"`
+	// Overdue are the users missing longer than the grace period that were kept for admins to
+	// offboard, because deleting is disabled or they still own resources
+	Overdue []DirectoryMissingUser `json:"overdue"`
+	// Deactivated are the users deleted for having been missing longer than the grace period
+	Deactivated []DirectoryMissingUser `json:"deactivated"`
+	Failed      []DirectorySyncFailure `json:"failed"`
+	// DeactivationSkipped is set if so many users were missing that nobody was deleted, as that
+	// points to a directory problem rather than to people leaving
+	DeactivationSkipped bool `json:"deactivation_skipped,omitempty"`
+	// Error is set if the reconciliation failed as a whole, e.g. because LDAP was unreachable
+	Error string `json:"error,omitempty"`
+}
+
+// DirectoryUserUpdate lists the attributes of a user that changed, by JSON field name
+type DirectoryUserUpdate struct {
+	UserID  string                          `json:"user_id"`
+	Changes map[string]DirectoryFieldChange `json:"changes"`
+}
+
+// DirectoryFieldChange is the previous and the new value of an attribute
+type DirectoryFieldChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// DirectoryMissingUser is a user missing from LDAP
+type DirectoryMissingUser struct {
+	UserID       string    `json:"user_id"`
+	MissingSince time.Time `json:"missing_since"`
+	// OwnsResources is set if the user still owns links, teams, groups or organizations
+	OwnsResources bool `json:"owns_resources,omitempty"`
+}
+
+// DirectorySyncFailure is a user that could not be updated or deleted
+type DirectorySyncFailure struct {
+	UserID string `json:"user_id"`
+	Error  string `json:"error"`
+}
+
+// Reconcile reconciles all users with LDAP and keeps the report for LastReport. It returns
+// apperrors.ErrDirectorySyncRunning if a reconciliation is already running.
+func (s *DirectorySyncService) Reconcile() (*DirectorySyncReport, error) {
+	if !s.running.TryLock() {
+		return nil, apperrors.ErrDirectorySyncRunning
+	}
+	defer s.running.Unlock()
+
+	report := &DirectorySyncReport{
+		StartedAt:   s.now(),
+		Updated:     []DirectoryUserUpdate{},
+		Returned:    []string{},
+		Missing:     []DirectoryMissingUser{},
+		Overdue:     []DirectoryMissingUser{},
+		Deactivated: []DirectoryMissingUser{},
+		Failed:      []DirectorySyncFailure{},
+	}
+	err := s.reconcile(report)
+	if err != nil {
+		report.Error = err.Error()
+	}
+	report.FinishedAt = s.now()
+
+	s.mu.Lock()
+	s.report = report
+	s.mu.Unlock()
+	return report, err
+}
+
+// LastReport returns the report of the latest reconciliation of this instance
+func (s *DirectorySyncService) LastReport() (*DirectorySyncReport, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.report == nil {
+		return nil, apperrors.ErrDirectorySyncReportNotFound
+	}
+	return s.report, nil
+}
+
+// StartDirectorySync reconciles users with LDAP every interval until ctx is done
+func (s *DirectorySyncService) StartDirectorySync(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				report, err := s.Reconcile()
+				if err != nil {
+					logger.WithContext(ctx).Warnf("LDAP reconciliation failed: %v", err)
+					continue
+				}
+				logger.WithContext(ctx).Infof("LDAP reconciliation checked %d users: %d updated, %d missing, %d overdue, %d deactivated, %d failed",
+					report.Checked, len(report.Updated), len(report.Missing), len(report.Overdue), len(report.Deactivated), len(report.Failed))
+			}
+		}
+	}()
+}
+
+func (s *DirectorySyncService) reconcile(report *DirectorySyncReport) error {
+	users, err := s.allUsers()
+	if err != nil {
+		return err
+	}
+	ids := make([]string, len(users))
+	for i := range users {
+		ids[i] = users[i].UserID
+	}
+	entries, err := s.ldap.LookupUsers(ids)
+	if err != nil {
+		return fmt.Errorf("failed to look up users in LDAP: %w", err)
+	}
+	report.Checked = len(users)
+
+	var missing []*models.User
+	for i := range users {
+		user := &users[i]
+		entry, found := entries[strings.ToUpper(user.UserID)]
+		if !found {
+			missing = append(missing, user)
+			continue
+		}
+
+		changes := applyDirectoryEntry(user, entry)
+		returned := user.DirectoryMissingSince != nil
+		if len(changes) == 0 && !returned {
+			continue
+		}
+		user.DirectoryMissingSince = nil
+		if err := s.save(user); err != nil {
+			report.Failed = append(report.Failed, DirectorySyncFailure{UserID: user.UserID, Error: err.Error()})
+			continue
+		}
+		if len(changes) > 0 {
+			report.Updated = append(report.Updated, DirectoryUserUpdate{UserID: user.UserID, Changes: changes})
+		}
+		if returned {
+			report.Returned = append(report.Returned, user.UserID)
+		}
+	}
+
+	report.DeactivationSkipped = len(missing) > 0 && 2*len(missing) > len(users)
+	for _, user := range missing {
+		if user.DirectoryMissingSince == nil {
+			since := report.StartedAt
+			user.DirectoryMissingSince = &since
+			if err := s.save(user); err != nil {
+				report.Failed = append(report.Failed, DirectorySyncFailure{UserID: user.UserID, Error: err.Error()})
+				continue
+			}
+		}
+
+		entry := DirectoryMissingUser{UserID: user.UserID, MissingSince: *user.DirectoryMissingSince}
+		if report.DeactivationSkipped || report.StartedAt.Sub(entry.MissingSince) < s.grace {
+			report.Missing = append(report.Missing, entry)
+			continue
+		}
+		if !s.deleteMissing {
+			report.Overdue = append(report.Overdue, entry)
+			continue
+		}
+		// Users who own resources are left to the offboarding, which hands the resources over
+		owned, err := s.offboardingRepo.GetOwnedResources(user.ID, user.UserID)
+		if err != nil {
+			report.Failed = append(report.Failed, DirectorySyncFailure{UserID: user.UserID, Error: fmt.Sprintf("failed to get owned resources: %v", err)})
+			continue
+		}
+		if len(owned.Links)+len(owned.Teams)+len(owned.Groups)+len(owned.Organizations) > 0 {
+			entry.OwnsResources = true
+			report.Overdue = append(report.Overdue, entry)
+			continue
+		}
+		if err := s.userRepo.Delete(user.ID, directorySyncActor); err != nil {
+			report.Failed = append(report.Failed, DirectorySyncFailure{UserID: user.UserID, Error: fmt.Sprintf("failed to delete user: %v", err)})
+			continue
+		}
+		report.Deactivated = append(report.Deactivated, entry)
+	}
+	return nil
+}
+
+// allUsers loads all users page by page
+func (s *DirectorySyncService) allUsers() ([]models.User, error) {
+	var users []models.User
+	for offset := 0; ; offset += directorySyncPageSize {
+		page, total, err := s.userRepo.GetAll(directorySyncPageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get users: %w", err)
+		}
+		users = append(users, page...)
+		if len(page) < directorySyncPageSize || int64(len(users)) >= total {
+			return users, nil
+		}
+	}
+}
+
+// save updates a user changed by the reconciliation
+func (s *DirectorySyncService) save(user *models.User) error {
+	user.UpdatedBy = directorySyncActor
+	if err := s.userRepo.Update(user); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	return nil
+}
+
+// applyDirectoryEntry copies the attributes of an LDAP entry to user and returns those that
+// changed. Empty attributes are ignored.
+func applyDirectoryEntry(user *models.User, entry LDAPUser) map[string]DirectoryFieldChange {
+	changes := map[string]DirectoryFieldChange{}
+	apply := func(field string, value *string, ldapValue string) {
+		if ldapValue == "" || ldapValue == *value {
+			return
+		}
+		changes[field] = DirectoryFieldChange{From: *value, To: ldapValue}
+		*value = ldapValue
+	}
+	apply("first_name", &user.FirstName, entry.GivenName)
+	apply("last_name", &user.LastName, entry.SN)
+	apply("email", &user.Email, entry.Mail)
+	apply("mobile", &user.Mobile, entry.Mobile)
+	return changes
+}
@@ -0,0 +1,267 @@
+package service
+
+import (
+	"crypto/tls"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/repository"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeDirectory returns an LDAP client that answers name lookups from entries, keyed by user ID
+func fakeDirectory(entries map[string]*ldap.Entry) *fakeLDAPClient {
+	names := regexp.MustCompile(`\(name=([^)]*)\)`)
+	return &fakeLDAPClient{
+		search: func(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+			res := &ldap.SearchResult{}
+			for _, match := range names.FindAllStringSubmatch(req.Filter, -1) {
+				if entry, ok := entries[match[1]]; ok {
+					res.Entries = append(res.Entries, entry)
+				}
+			}
+			return res, nil
+		},
+	}
+}
+
+func directoryEntry(userID, givenName, sn, mail, mobile string) *ldap.Entry {
+	return ldap.NewEntry("CN="+userID+",OU=I,DC=example,DC=com", map[string][]string{
+		"name":      {userID},
+		"givenName": {givenName},
+		"sn":        {sn},
+		"mail":      {mail},
+		"mobile":    {mobile},
+	})
+}
+
+type MockUserOffboardingRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserOffboardingRepository) GetOwnedResources(userID uuid.UUID, username string) (*repository.OwnedResources, error) {
+	args := m.Called(userID, username)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repository.OwnedResources), args.Error(1)
+}
+
+func (m *MockUserOffboardingRepository) Offboard(record *models.UserOffboarding) error {
+	args := m.Called(record)
+	return args.Error(0)
+}
+
+func (m *MockUserOffboardingRepository) GetHistory(limit int) ([]models.UserOffboarding, error) {
+	args := m.Called(limit)
+	return args.Get(0).([]models.UserOffboarding), args.Error(1)
+}
+
+type DirectorySyncServiceTestSuite struct {
+	suite.Suite
+	userRepo        *MockUserRepository
+	offboardingRepo *MockUserOffboardingRepository
+	directory       map[string]*ldap.Entry
+	syncService     *DirectorySyncService
+	now             time.Time
+	origDial        func(network, addr string, config *tls.Config) (ldapClient, error)
+}
+
+func (suite *DirectorySyncServiceTestSuite) SetupTest() {
+	suite.origDial = dialLDAP
+	suite.directory = map[string]*ldap.Entry{}
+	dialLDAP = func(network, addr string, cfg *tls.Config) (ldapClient, error) {
+		return fakeDirectory(suite.directory), nil
+	}
+
+	suite.userRepo = new(MockUserRepository)
+	suite.offboardingRepo = new(MockUserOffboardingRepository)
+	suite.syncService = NewDirectorySyncService(suite.userRepo, suite.offboardingRepo, NewLDAPService(makeConfig()), 7*24*time.Hour, false)
+	suite.now = time.Date(2026, 3, 1, 2, 0, 0, 0, time.UTC)
+	suite.syncService.now = func() time.Time { return suite.now }
+}
+
+func (suite *DirectorySyncServiceTestSuite) TearDownTest() {
+	dialLDAP = suite.origDial
+	suite.userRepo.AssertExpectations(suite.T())
+	suite.offboardingRepo.AssertExpectations(suite.T())
+}
+
+func (suite *DirectorySyncServiceTestSuite) user(userID string) models.User {
+	return models.User{
+		BaseModel: models.BaseModel{ID: uuid.New(), Version: 1},
+		UserID:    userID,
+		FirstName: "John",
+		LastName:  "Doe",
+		Email:     userID + "@example.com",
+		Mobile:    "+49 123",
+	}
+}
+
+func (suite *DirectorySyncServiceTestSuite) TestReconcile_UpdatesAttributes() {
+	renamed, unchanged, returned := suite.user("I000001"), suite.user("I000002"), suite.user("I000003")
+	flagged := suite.now.AddDate(0, 0, -2)
+	returned.DirectoryMissingSince = &flagged
+	suite.userRepo.On("GetAll", directorySyncPageSize, 0).Return([]models.User{renamed, unchanged, returned}, int64(3), nil)
+
+	// Empty attributes in LDAP keep the stored values
+	suite.directory["I000001"] = directoryEntry("I000001", "Jane", "Smith", "I000001@example.com", "")
+	suite.directory["I000002"] = directoryEntry("I000002", "John", "Doe", "I000002@example.com", "+49 123")
+	suite.directory["I000003"] = directoryEntry("I000003", "John", "Doe", "", "")
+	suite.userRepo.On("Update", mock.MatchedBy(func(u *models.User) bool { return u.UserID == "I000001" })).Run(func(args mock.Arguments) {
+		u := args.Get(0).(*models.User)
+		suite.Equal("Jane", u.FirstName)
+		suite.Equal("Smith", u.LastName)
+		suite.Equal("+49 123", u.Mobile)
+		suite.Equal("ldap-sync", u.UpdatedBy)
+	}).Return(nil)
+	suite.userRepo.On("Update", mock.MatchedBy(func(u *models.User) bool {
+		return u.UserID == "I000003" && u.DirectoryMissingSince == nil
+	})).Return(nil)
+
+	report, err := suite.syncService.Reconcile()
+	suite.Require().NoError(err)
+	suite.Equal(3, report.Checked)
+	suite.Require().Len(report.Updated, 1)
+	suite.Equal(DirectoryFieldChange{From: "John", To: "Jane"}, report.Updated[0].Changes["first_name"])
+	suite.Len(report.Updated[0].Changes, 2)
+	suite.Equal([]string{"I000003"}, report.Returned)
+	suite.Empty(report.Missing)
+	suite.Empty(report.Failed)
+
+	last, err := suite.syncService.LastReport()
+	suite.Require().NoError(err)
+	suite.Same(report, last)
+}
+
+func (suite *DirectorySyncServiceTestSuite) TestReconcile_FlagsAndReportsMissingUsers() {
+	present, leaving, left := suite.user("I000001"), suite.user("I000002"), suite.user("I000003")
+	longAgo := suite.now.AddDate(0, 0, -8)
+	left.DirectoryMissingSince = &longAgo
+	suite.userRepo.On("GetAll", directorySyncPageSize, 0).Return([]models.User{present, leaving, left, suite.user("I000004")}, int64(4), nil)
+	suite.directory["I000001"] = directoryEntry("I000001", "John", "Doe", "", "")
+	suite.directory["I000004"] = directoryEntry("I000004", "John", "Doe", "", "")
+
+	suite.userRepo.On("Update", mock.MatchedBy(func(u *models.User) bool {
+		return u.UserID == "I000002" && u.DirectoryMissingSince != nil && u.DirectoryMissingSince.Equal(suite.now)
+	})).Return(nil)
+
+	// Without LDAP_SYNC_DELETE_MISSING users past the grace period are only reported
+	report, err := suite.syncService.Reconcile()
+	suite.Require().NoError(err)
+	suite.False(report.DeactivationSkipped)
+	suite.Equal([]DirectoryMissingUser{{UserID: "I000002", MissingSince: suite.now}}, report.Missing)
+	suite.Equal([]DirectoryMissingUser{{UserID: "I000003", MissingSince: longAgo}}, report.Overdue)
+	suite.Empty(report.Deactivated)
+	suite.userRepo.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (suite *DirectorySyncServiceTestSuite) TestReconcile_DeletesOverdueUsersWithoutResources() {
+	suite.syncService.deleteMissing = true
+	left, owner := suite.user("I000001"), suite.user("I000002")
+	longAgo := suite.now.AddDate(0, 0, -8)
+	left.DirectoryMissingSince = &longAgo
+	owner.DirectoryMissingSince = &longAgo
+	suite.userRepo.On("GetAll", directorySyncPageSize, 0).Return([]models.User{left, owner, suite.user("I000003"), suite.user("I000004")}, int64(4), nil)
+	suite.directory["I000003"] = directoryEntry("I000003", "John", "Doe", "", "")
+	suite.directory["I000004"] = directoryEntry("I000004", "John", "Doe", "", "")
+
+	suite.offboardingRepo.On("GetOwnedResources", left.ID, "I000001").Return(&repository.OwnedResources{}, nil)
+	suite.offboardingRepo.On("GetOwnedResources", owner.ID, "I000002").Return(&repository.OwnedResources{Teams: []models.Team{{BaseModel: models.BaseModel{Name: "team-a"}}}}, nil)
+	suite.userRepo.On("Delete", left.ID, "ldap-sync").Return(nil)
+
+	report, err := suite.syncService.Reconcile()
+	suite.Require().NoError(err)
+	suite.Equal([]DirectoryMissingUser{{UserID: "I000001", MissingSince: longAgo}}, report.Deactivated)
+	// Users who own resources are left to the offboarding
+	suite.Equal([]DirectoryMissingUser{{UserID: "I000002", MissingSince: longAgo, OwnsResources: true}}, report.Overdue)
+	suite.userRepo.AssertNotCalled(suite.T(), "Delete", owner.ID, mock.Anything)
+}
+
+func (suite *DirectorySyncServiceTestSuite) TestReconcile_SkipsDeactivationWhenMostUsersAreMissing() {
+	longAgo := suite.now.AddDate(0, -1, 0)
+	users := []models.User{suite.user("I000001"), suite.user("I000002"), suite.user("I000003")}
+	for i := range users {
+		users[i].DirectoryMissingSince = &longAgo
+	}
+	suite.userRepo.On("GetAll", directorySyncPageSize, 0).Return(users, int64(3), nil)
+	suite.directory["I000001"] = directoryEntry("I000001", "John", "Doe", "", "")
+	suite.userRepo.On("Update", mock.Anything).Return(nil).Once()
+
+	report, err := suite.syncService.Reconcile()
+	suite.Require().NoError(err)
+	suite.True(report.DeactivationSkipped)
+	suite.Len(report.Missing, 2)
+	suite.Empty(report.Deactivated)
+	suite.userRepo.AssertNotCalled(suite.T(), "Delete", mock.Anything, mock.Anything)
+}
+
+func (suite *DirectorySyncServiceTestSuite) TestReconcile_PagesThroughUsers() {
+	page := make([]models.User, directorySyncPageSize)
+	for i := range page {
+		page[i] = suite.user("I000001")
+	}
+	suite.userRepo.On("GetAll", directorySyncPageSize, 0).Return(page, int64(directorySyncPageSize+1), nil)
+	suite.userRepo.On("GetAll", directorySyncPageSize, directorySyncPageSize).Return([]models.User{suite.user("I000002")}, int64(directorySyncPageSize+1), nil)
+	suite.directory["I000001"] = directoryEntry("I000001", "John", "Doe", "", "")
+	suite.directory["I000002"] = directoryEntry("I000002", "John", "Doe", "", "")
+
+	report, err := suite.syncService.Reconcile()
+	suite.Require().NoError(err)
+	suite.Equal(directorySyncPageSize+1, report.Checked)
+}
+
+func (suite *DirectorySyncServiceTestSuite) TestReconcile_ReportsFailures() {
+	suite.userRepo.On("GetAll", directorySyncPageSize, 0).Return([]models.User{suite.user("I000001"), suite.user("I000002")}, int64(2), nil)
+	suite.directory["I000001"] = directoryEntry("I000001", "Jane", "Doe", "", "")
+	suite.directory["I000002"] = directoryEntry("I000002", "John", "Doe", "taken@example.com", "")
+	suite.userRepo.On("Update", mock.MatchedBy(func(u *models.User) bool { return u.UserID == "I000001" })).Return(nil)
+	suite.userRepo.On("Update", mock.MatchedBy(func(u *models.User) bool { return u.UserID == "I000002" })).Return(errors.New("duplicate key value violates unique constraint"))
+
+	report, err := suite.syncService.Reconcile()
+	suite.Require().NoError(err)
+	suite.Len(report.Updated, 1)
+	suite.Require().Len(report.Failed, 1)
+	suite.Equal("I000002", report.Failed[0].UserID)
+	suite.Contains(report.Failed[0].Error, "duplicate key")
+}
+
+func (suite *DirectorySyncServiceTestSuite) TestReconcile_LDAPUnreachable() {
+	_, err := suite.syncService.LastReport()
+	suite.ErrorIs(err, apperrors.ErrDirectorySyncReportNotFound)
+
+	dialLDAP = func(network, addr string, cfg *tls.Config) (ldapClient, error) {
+		return nil, errors.New("connection refused")
+	}
+	suite.userRepo.On("GetAll", directorySyncPageSize, 0).Return([]models.User{suite.user("I000001")}, int64(1), nil)
+
+	_, err = suite.syncService.Reconcile()
+	suite.ErrorContains(err, "connection refused")
+
+	// The failed run is reported, and nobody was flagged
+	report, err := suite.syncService.LastReport()
+	suite.Require().NoError(err)
+	suite.Contains(report.Error, "connection refused")
+	suite.Empty(report.Missing)
+	suite.userRepo.AssertNotCalled(suite.T(), "Update", mock.Anything)
+}
+
+func (suite *DirectorySyncServiceTestSuite) TestReconcile_AlreadyRunning() {
+	suite.syncService.running.Lock()
+	defer suite.syncService.running.Unlock()
+
+	_, err := suite.syncService.Reconcile()
+	suite.ErrorIs(err, apperrors.ErrDirectorySyncRunning)
+}
+
+func TestDirectorySyncServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(DirectorySyncServiceTestSuite))
+}
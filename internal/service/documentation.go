@@ -49,6 +49,7 @@ type DocumentationResponse struct {
 	CreatedBy   string `json:"created_by"`
 	UpdatedAt   string `json:"updated_at"`
 	UpdatedBy   string `json:"updated_by"`
+	Version     int64  `json:"version"`
 }
 
 // CreateDocumentationRequest represents the payload for creating a documentation
@@ -66,6 +67,8 @@ type UpdateDocumentationRequest struct {
 	Title       *string `json:"title" validate:"omitempty,min=1,max=100"`
 	Description *string `json:"description" validate:"omitempty,max=200"`
 	UpdatedBy   string  `json:"-"` // derived from bearer token
+	// ExpectedVersion is the version the update is based on, from the If-Match header. Zero updates any version.
+	ExpectedVersion int64 `json:"-"`
 }
 
 // CreateDocumentation validates and creates a new documentation
@@ -142,7 +145,8 @@ func (s *DocumentationService) GetDocumentationsByTeamID(teamID uuid.UUID) ([]Do
 	return responses, nil
 }
 
-// UpdateDocumentation updates an existing documentation
+// UpdateDocumentation updates an existing documentation. Without an expected version the update is
+// applied to the latest version, retrying if the documentation is updated concurrently.
 func (s *DocumentationService) UpdateDocumentation(id uuid.UUID, req *UpdateDocumentationRequest) (*DocumentationResponse, error) {
 	if err := s.validator.Struct(req); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
@@ -152,36 +156,53 @@ func (s *DocumentationService) UpdateDocumentation(id uuid.UUID, req *UpdateDocu
 		return nil, fmt.Errorf("updated_by is required")
 	}
 
-	// Get existing documentation
-	doc, err := s.docRepo.GetByID(id)
-	if err != nil {
-		return nil, fmt.Errorf("documentation not found: %w", err)
-	}
-
-	// Update fields if provided
+	// Parse and validate GitHub URL if provided
+	var owner, repo, branch, docsPath string
 	if req.URL != nil && *req.URL != "" {
-		owner, repo, branch, docsPath, err := parseGitHubURL(*req.URL)
+		var err error
+		owner, repo, branch, docsPath, err = parseGitHubURL(*req.URL)
 		if err != nil {
 			return nil, fmt.Errorf("invalid GitHub URL: %w", err)
 		}
-		doc.Owner = owner
-		doc.Repo = repo
-		doc.Branch = branch
-		doc.DocsPath = docsPath
 	}
 
-	if req.Title != nil {
-		doc.Title = *req.Title
-	}
+	var doc *models.Documentation
+	err := retryOnConflict(req.ExpectedVersion, func() error {
+		// Get existing documentation
+		var err error
+		doc, err = s.docRepo.GetByID(id)
+		if err != nil {
+			return fmt.Errorf("documentation not found: %w", err)
+		}
+		if err := checkVersion(req.ExpectedVersion, doc.Version); err != nil {
+			return err
+		}
 
-	if req.Description != nil {
-		doc.Description = *req.Description
-	}
+		// Update fields if provided
+		if req.URL != nil && *req.URL != "" {
+			doc.Owner = owner
+			doc.Repo = repo
+			doc.Branch = branch
+			doc.DocsPath = docsPath
+		}
+
+		if req.Title != nil {
+			doc.Title = *req.Title
+		}
 
-	doc.UpdatedBy = req.UpdatedBy
+		if req.Description != nil {
+			doc.Description = *req.Description
+		}
+
+		doc.UpdatedBy = req.UpdatedBy
 
-	if err := s.docRepo.Update(doc); err != nil {
-		return nil, fmt.Errorf("failed to update documentation: %w", err)
+		if err := s.docRepo.Update(doc); err != nil {
+			return fmt.Errorf("failed to update documentation: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return toDocumentationResponse(doc), nil
@@ -266,5 +287,6 @@ func toDocumentationResponse(doc *models.Documentation) *DocumentationResponse {
 		CreatedBy:   doc.CreatedBy,
 		UpdatedAt:   doc.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedBy:   doc.UpdatedBy,
+		Version:     doc.Version,
 	}
 }
@@ -0,0 +1,97 @@
+package service_test
+
+import (
+	"testing"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/repository"
+	"developer-portal-backend/internal/service"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+)
+
+type DocumentationServiceTestSuite struct {
+	suite.Suite
+	ctrl         *gomock.Controller
+	mockDocRepo  *mocks.MockDocumentationRepositoryInterface
+	mockTeamRepo *mocks.MockTeamRepositoryInterface
+	docService   *service.DocumentationService
+}
+
+func (suite *DocumentationServiceTestSuite) SetupTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockDocRepo = mocks.NewMockDocumentationRepositoryInterface(suite.ctrl)
+	suite.mockTeamRepo = mocks.NewMockTeamRepositoryInterface(suite.ctrl)
+	suite.docService = service.NewDocumentationService(suite.mockDocRepo, suite.mockTeamRepo, validator.New())
+}
+
+func (suite *DocumentationServiceTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+func newDocumentation(version int64) *models.Documentation {
+	return &models.Documentation{
+		ID:       uuid.New(),
+		TeamID:   uuid.New(),
+		Owner:    "org",
+		Repo:     "docs",
+		Branch:   "main",
+		DocsPath: "docs",
+		Title:    "Docs",
+		Version:  version,
+	}
+}
+
+func (suite *DocumentationServiceTestSuite) TestUpdateDocumentation_RetriesConflictWithoutExpectedVersion() {
+	doc := newDocumentation(2)
+	title := "New title"
+	gomock.InOrder(
+		suite.mockDocRepo.EXPECT().GetByID(doc.ID).Return(doc, nil),
+		suite.mockDocRepo.EXPECT().Update(gomock.Any()).Return(repository.ErrVersionConflict),
+		suite.mockDocRepo.EXPECT().GetByID(doc.ID).Return(newDocumentation(3), nil),
+		suite.mockDocRepo.EXPECT().Update(gomock.Any()).Return(nil),
+	)
+
+	resp, err := suite.docService.UpdateDocumentation(doc.ID, &service.UpdateDocumentationRequest{Title: &title, UpdatedBy: "jane"})
+	suite.Require().NoError(err)
+	suite.Equal(title, resp.Title)
+	suite.Equal(int64(3), resp.Version)
+}
+
+func (suite *DocumentationServiceTestSuite) TestUpdateDocumentation_GivesUpAfterRepeatedConflicts() {
+	doc := newDocumentation(1)
+	title := "New title"
+	suite.mockDocRepo.EXPECT().GetByID(doc.ID).Return(doc, nil).Times(4)
+	suite.mockDocRepo.EXPECT().Update(gomock.Any()).Return(repository.ErrVersionConflict).Times(4)
+
+	_, err := suite.docService.UpdateDocumentation(doc.ID, &service.UpdateDocumentationRequest{Title: &title, UpdatedBy: "jane"})
+	suite.ErrorIs(err, apperrors.ErrVersionConflict)
+}
+
+func (suite *DocumentationServiceTestSuite) TestUpdateDocumentation_ExpectedVersionMismatch() {
+	doc := newDocumentation(5)
+	title := "New title"
+	suite.mockDocRepo.EXPECT().GetByID(doc.ID).Return(doc, nil)
+
+	_, err := suite.docService.UpdateDocumentation(doc.ID, &service.UpdateDocumentationRequest{Title: &title, UpdatedBy: "jane", ExpectedVersion: 4})
+	suite.ErrorIs(err, apperrors.ErrVersionConflict)
+}
+
+func (suite *DocumentationServiceTestSuite) TestUpdateDocumentation_ExpectedVersionIsNotRetried() {
+	doc := newDocumentation(4)
+	title := "New title"
+	suite.mockDocRepo.EXPECT().GetByID(doc.ID).Return(doc, nil)
+	suite.mockDocRepo.EXPECT().Update(gomock.Any()).Return(repository.ErrVersionConflict)
+
+	_, err := suite.docService.UpdateDocumentation(doc.ID, &service.UpdateDocumentationRequest{Title: &title, UpdatedBy: "jane", ExpectedVersion: 4})
+	suite.ErrorIs(err, apperrors.ErrVersionConflict)
+}
+
+func TestDocumentationServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(DocumentationServiceTestSuite))
+}
@@ -23,21 +23,24 @@ import (
 // GitHubService provides methods to interact with GitHub API
 type GitHubService struct {
 	authService GitHubAuthService
+	identities  GitHubIdentityResolver // nil if the auth service does not link identities
 }
 
 // NewGitHubService creates a new GitHub service
 func NewGitHubService(authService *auth.AuthService) *GitHubService {
-	return &GitHubService{
-		authService: NewAuthServiceAdapter(authService),
-	}
+	return NewGitHubServiceWithAdapter(NewAuthServiceAdapter(authService))
 }
 
 // NewGitHubServiceWithAdapter creates a new GitHub service with a custom auth service adapter
 // This constructor is primarily for testing with mock auth services
 func NewGitHubServiceWithAdapter(authService GitHubAuthService) *GitHubService {
-	return &GitHubService{
+	s := &GitHubService{
 		authService: authService,
 	}
+	if resolver, ok := authService.(GitHubIdentityResolver); ok {
+		s.identities = resolver
+	}
+	return s
 }
 
 // PullRequest represents a GitHub pull request
@@ -52,6 +55,7 @@ type PullRequest struct {
 	User      GitHubUser `json:"user"`
 	Repo      Repository `json:"repository"`
 	Draft     bool       `json:"draft" example:"false"`
+	Provider  string     `json:"provider,omitempty" example:"githubtools"` // provider of the identity that authored the PR
 }
 
 // GitHubUser represents a GitHub user
@@ -159,12 +163,46 @@ func parseRepositoryFromURL(urlStr string) (owner, repoName, fullName string) {
 	return owner, repoName, fullName
 }
 
-// GetUserOpenPullRequests retrieves all open pull requests for the authenticated user
+// linkedIdentities returns claims for the user's other provider identities with an active session.
+// Failing to resolve them narrows the response to the identity of the claims.
+func (s *GitHubService) linkedIdentities(ctx context.Context, claims *auth.AuthClaims) []*auth.AuthClaims {
+	if s.identities == nil {
+		return nil
+	}
+	linked, err := s.identities.GetLinkedIdentityClaims(claims)
+	if err != nil {
+		logger.WithContext(ctx).Warnf("Failed to resolve linked identities of %s: %v", claims.Username, err)
+		return nil
+	}
+	return linked
+}
+
+// GetUserOpenPullRequests retrieves all open pull requests for the authenticated user, including
+// those of the user's linked identities of other providers. Errors of linked identities are logged
+// and their pull requests left out.
 func (s *GitHubService) GetUserOpenPullRequests(ctx context.Context, claims *auth.AuthClaims, state, sort, direction string, perPage, page int) (*PullRequestsResponse, error) {
 	if claims == nil {
 		return nil, fmt.Errorf("authentication required")
 	}
 
+	response, err := s.userPullRequests(ctx, claims, state, sort, direction, perPage, page)
+	if err != nil {
+		return nil, err
+	}
+	for _, linked := range s.linkedIdentities(ctx, claims) {
+		linkedResponse, err := s.userPullRequests(ctx, linked, state, sort, direction, perPage, page)
+		if err != nil {
+			logger.WithContext(ctx).Warnf("Failed to fetch pull requests of linked %s identity: %v", linked.Provider, err)
+			continue
+		}
+		response.PullRequests = append(response.PullRequests, linkedResponse.PullRequests...)
+		response.Total += linkedResponse.Total
+	}
+	return response, nil
+}
+
+// userPullRequests retrieves the pull requests of a single provider identity
+func (s *GitHubService) userPullRequests(ctx context.Context, claims *auth.AuthClaims, state, sort, direction string, perPage, page int) (*PullRequestsResponse, error) {
 	// Get GitHub access token using validated JWT claims
 	accessToken, err := s.authService.GetGitHubAccessTokenFromClaims(claims)
 	if err != nil {
@@ -255,6 +293,7 @@ func (s *GitHubService) GetUserOpenPullRequests(ctx context.Context, claims *aut
 			UpdatedAt: issue.GetUpdatedAt().Time,
 			HTMLURL:   issue.GetHTMLURL(),
 			Draft:     issue.GetDraft(),
+			Provider:  claims.Provider,
 			User: GitHubUser{
 				Login:     issue.GetUser().GetLogin(),
 				ID:        issue.GetUser().GetID(),
@@ -299,12 +338,31 @@ func (s *GitHubService) GetUserOpenPullRequests(ctx context.Context, claims *aut
 	return response, nil
 }
 
-// GetUserTotalContributions retrieves the total contributions for the authenticated user over a specified period
+// GetUserTotalContributions retrieves the total contributions for the authenticated user over a specified period,
+// summed over the user's linked identities of other providers. Errors of linked identities are logged and their
+// contributions left out; the reported date range is the one of the authenticated identity.
 func (s *GitHubService) GetUserTotalContributions(ctx context.Context, claims *auth.AuthClaims, period string) (*TotalContributionsResponse, error) {
 	if claims == nil {
 		return nil, fmt.Errorf("authentication required")
 	}
 
+	response, err := s.userTotalContributions(ctx, claims, period)
+	if err != nil {
+		return nil, err
+	}
+	for _, linked := range s.linkedIdentities(ctx, claims) {
+		linkedResponse, err := s.userTotalContributions(ctx, linked, period)
+		if err != nil {
+			logger.WithContext(ctx).Warnf("Failed to fetch contributions of linked %s identity: %v", linked.Provider, err)
+			continue
+		}
+		response.TotalContributions += linkedResponse.TotalContributions
+	}
+	return response, nil
+}
+
+// userTotalContributions retrieves the total contributions of a single provider identity
+func (s *GitHubService) userTotalContributions(ctx context.Context, claims *auth.AuthClaims, period string) (*TotalContributionsResponse, error) {
 	// Validate period format early (before making any API calls)
 	var from, to time.Time
 	var parsedPeriod string
@@ -1544,12 +1602,31 @@ func (s *GitHubService) ClosePullRequest(ctx context.Context, claims *auth.AuthC
 	return &result, nil
 }
 
-// GetUserPRReviewComments gets the total number of PR review comments made by the authenticated user
+// GetUserPRReviewComments gets the total number of PR review comments made by the authenticated user,
+// summed over the user's linked identities of other providers. Errors of linked identities are logged
+// and their comments left out.
 func (s *GitHubService) GetUserPRReviewComments(ctx context.Context, claims *auth.AuthClaims, period string) (*PRReviewCommentsResponse, error) {
 	if claims == nil {
 		return nil, fmt.Errorf("authentication required")
 	}
 
+	response, err := s.userPRReviewComments(ctx, claims, period)
+	if err != nil {
+		return nil, err
+	}
+	for _, linked := range s.linkedIdentities(ctx, claims) {
+		linkedResponse, err := s.userPRReviewComments(ctx, linked, period)
+		if err != nil {
+			logger.WithContext(ctx).Warnf("Failed to fetch review comments of linked %s identity: %v", linked.Provider, err)
+			continue
+		}
+		response.TotalComments += linkedResponse.TotalComments
+	}
+	return response, nil
+}
+
+// userPRReviewComments counts the PR review comments of a single provider identity
+func (s *GitHubService) userPRReviewComments(ctx context.Context, claims *auth.AuthClaims, period string) (*PRReviewCommentsResponse, error) {
 	// Parse period (default to 30 days)
 	var from, to time.Time
 	var parsedPeriod string
@@ -14,6 +14,12 @@ type GitHubAuthService interface {
 	GetGitHubClient(provider string) (*auth.GitHubClient, error)
 }
 
+// GitHubIdentityResolver is implemented by auth services that link several provider identities to
+// one portal user. GitHubService aggregates user data across the identities it returns.
+type GitHubIdentityResolver interface {
+	GetLinkedIdentityClaims(claims *auth.AuthClaims) ([]*auth.AuthClaims, error)
+}
+
 // authServiceAdapter adapts auth.AuthService to implement GitHubAuthService interface
 type authServiceAdapter struct {
 	authService *auth.AuthService
@@ -40,3 +46,10 @@ func (a *authServiceAdapter) GetGitHubClient(provider string) (*auth.GitHubClien
 	}
 	return a.authService.GetGitHubClient(provider)
 }
+
+func (a *authServiceAdapter) GetLinkedIdentityClaims(claims *auth.AuthClaims) ([]*auth.AuthClaims, error) {
+	if a.authService == nil {
+		return []*auth.AuthClaims{}, nil
+	}
+	return a.authService.GetLinkedIdentityClaims(claims)
+}
@@ -1208,3 +1208,114 @@ func (m *mockAuthServiceFailure) GetGitHubClient(provider string) (*auth.GitHubC
 	}
 	return auth.NewGitHubClient(config), nil
 }
+
+// mockLinkedAuthService serves each provider from its own GitHub server and links identities of
+// other providers to the authenticated user
+type mockLinkedAuthService struct {
+	baseURLs map[string]string
+	linked   []*auth.AuthClaims
+}
+
+func (m *mockLinkedAuthService) GetGitHubAccessTokenFromClaims(claims *auth.AuthClaims) (string, error) {
+	return "token-" + claims.Provider, nil
+}
+
+func (m *mockLinkedAuthService) GetGitHubClient(provider string) (*auth.GitHubClient, error) {
+	baseURL, ok := m.baseURLs[provider]
+	if !ok {
+		return nil, fmt.Errorf("provider %s not configured", provider)
+	}
+	return auth.NewGitHubClient(&auth.ProviderConfig{
+		ClientID:          "test-client-id",
+		ClientSecret:      "test-client-secret",
+		EnterpriseBaseURL: baseURL,
+	}), nil
+}
+
+func (m *mockLinkedAuthService) GetLinkedIdentityClaims(claims *auth.AuthClaims) ([]*auth.AuthClaims, error) {
+	return m.linked, nil
+}
+
+// newLinkedGitHubServer serves a user with the given number of PRs, contributions and reviews
+func newLinkedGitHubServer(t *testing.T, provider string, count int) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token-"+provider, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v3/user":
+			json.NewEncoder(w).Encode(map[string]interface{}{"login": provider + "-user"})
+		case "/api/v3/search/issues":
+			items := make([]map[string]interface{}, 0, count)
+			for i := 0; i < count; i++ {
+				items = append(items, map[string]interface{}{
+					"id":           i + 1,
+					"number":       i + 1,
+					"title":        provider + " PR",
+					"html_url":     "https://github.example.com/owner/repo/pull/1",
+					"pull_request": map[string]interface{}{"url": "https://github.example.com/api/v3/repos/owner/repo/pulls/1"},
+				})
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"total_count": count, "items": items})
+		case "/api/graphql":
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{
+				"viewer": map[string]interface{}{"contributionsCollection": map[string]interface{}{
+					"startedAt":            "2025-01-01T00:00:00Z",
+					"endedAt":              "2025-01-31T00:00:00Z",
+					"contributionCalendar": map[string]interface{}{"totalContributions": count * 10},
+				}},
+			}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// TestLinkedIdentitiesAreAggregated tests that user data is combined across linked identities
+func TestLinkedIdentitiesAreAggregated(t *testing.T) {
+	tools := newLinkedGitHubServer(t, "githubtools", 2)
+	wdf := newLinkedGitHubServer(t, "githubwdf", 1)
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer broken.Close()
+
+	mockAuthService := &mockLinkedAuthService{
+		baseURLs: map[string]string{"githubtools": tools.URL, "githubwdf": wdf.URL, "githubbroken": broken.URL},
+		linked: []*auth.AuthClaims{
+			{UserID: 2, Username: "wdf-user", Provider: "githubwdf"},
+			{UserID: 3, Username: "broken-user", Provider: "githubbroken"},
+		},
+	}
+	githubService := NewGitHubServiceWithAdapter(mockAuthService)
+	claims := &auth.AuthClaims{UserID: 1, Username: "tools-user", Provider: "githubtools"}
+	ctx := context.Background()
+
+	t.Run("PullRequests", func(t *testing.T) {
+		result, err := githubService.GetUserOpenPullRequests(ctx, claims, "open", "created", "desc", 30, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Total)
+		require.Len(t, result.PullRequests, 3)
+		assert.Equal(t, "githubtools", result.PullRequests[0].Provider)
+		assert.Equal(t, "githubwdf", result.PullRequests[2].Provider)
+	})
+
+	t.Run("TotalContributions", func(t *testing.T) {
+		result, err := githubService.GetUserTotalContributions(ctx, claims, "30d")
+		require.NoError(t, err)
+		assert.Equal(t, 30, result.TotalContributions)
+	})
+
+	t.Run("PRReviewComments", func(t *testing.T) {
+		result, err := githubService.GetUserPRReviewComments(ctx, claims, "30d")
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.TotalComments)
+	})
+
+	t.Run("PrimaryIdentityErrorsAreReturned", func(t *testing.T) {
+		failing := &auth.AuthClaims{UserID: 3, Username: "broken-user", Provider: "githubbroken"}
+		_, err := githubService.GetUserOpenPullRequests(ctx, failing, "open", "created", "desc", 30, 1)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,380 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/repository"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// GroupService provides group-related business logic
+type GroupService struct {
+	groupRepo repository.GroupRepositoryInterface
+	orgRepo   repository.OrganizationRepositoryInterface
+	teamRepo  repository.TeamRepositoryInterface
+	validator *validator.Validate
+}
+
+// Ensure GroupService implements GroupServiceInterface
+var _ GroupServiceInterface = (*GroupService)(nil)
+
+// NewGroupService creates a new GroupService
+func NewGroupService(
+	groupRepo repository.GroupRepositoryInterface,
+	orgRepo repository.OrganizationRepositoryInterface,
+	teamRepo repository.TeamRepositoryInterface,
+	validator *validator.Validate,
+) *GroupService {
+	return &GroupService{
+		groupRepo: groupRepo,
+		orgRepo:   orgRepo,
+		teamRepo:  teamRepo,
+		validator: validator,
+	}
+}
+
+// CreateGroupRequest represents the payload for creating a group.
+// The fields are validated against the tags of models.Group.
+type CreateGroupRequest struct {
+	OrgID       uuid.UUID       `json:"org_id"`
+	Name        string          `json:"name"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Owner       string          `json:"owner"` // I/C/D user
+	Email       string          `json:"email"` // DL
+	PictureURL  string          `json:"picture_url"`
+	Metadata    json.RawMessage `json:"metadata" swaggertype:"object"`
+	CreatedBy   string          `json:"-"` // derived from bearer token
+}
+
+// UpdateGroupRequest represents the payload for updating a group. Omitted fields are kept.
+type UpdateGroupRequest struct {
+	Title       *string         `json:"title"`
+	Description *string         `json:"description"`
+	Owner       *string         `json:"owner"`
+	Email       *string         `json:"email"`
+	PictureURL  *string         `json:"picture_url"`
+	Metadata    json.RawMessage `json:"metadata" swaggertype:"object"`
+	UpdatedBy   string          `json:"-"` // derived from bearer token
+	// ExpectedVersion is the version the update is based on, from the If-Match header. Zero updates any version.
+	ExpectedVersion int64 `json:"-"`
+}
+
+// MoveTeamRequest represents the payload for moving a team to another group
+type MoveTeamRequest struct {
+	GroupID   uuid.UUID `json:"group_id" binding:"required"`
+	UpdatedBy string    `json:"-"` // derived from bearer token
+}
+
+// GroupResponse represents a group in API responses
+type GroupResponse struct {
+	ID          uuid.UUID       `json:"id"`
+	OrgID       uuid.UUID       `json:"org_id"`
+	Name        string          `json:"name"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Owner       string          `json:"owner"`
+	Email       string          `json:"email"`
+	PictureURL  string          `json:"picture_url"`
+	Metadata    json.RawMessage `json:"metadata" swaggertype:"object"`
+	Version     int64           `json:"version"` // for If-Match, see the ETag header
+	CreatedAt   string          `json:"created_at"`
+	CreatedBy   string          `json:"created_by"`
+	UpdatedAt   string          `json:"updated_at"`
+	UpdatedBy   string          `json:"updated_by"`
+}
+
+// GroupWithTeamsResponse represents a group with its teams
+type GroupWithTeamsResponse struct {
+	GroupResponse
+	Teams []TeamResponse `json:"teams"`
+}
+
+// GroupListResponse represents a paginated list of groups
+type GroupListResponse struct {
+	Groups   []GroupResponse `json:"groups"`
+	Total    int64           `json:"total"`
+	Page     int             `json:"page"`
+	PageSize int             `json:"page_size"`
+}
+
+// CreateGroup validates and creates a new group with a name unique in its organization
+func (s *GroupService) CreateGroup(req *CreateGroupRequest) (*GroupResponse, error) {
+	group := &models.Group{
+		BaseModel: models.BaseModel{
+			Name:        req.Name,
+			Title:       req.Title,
+			Description: req.Description,
+			Metadata:    req.Metadata,
+			CreatedBy:   req.CreatedBy,
+			UpdatedBy:   req.CreatedBy,
+		},
+		OrgID:      req.OrgID,
+		Owner:      req.Owner,
+		Email:      req.Email,
+		PictureURL: req.PictureURL,
+	}
+	if err := s.validator.Struct(group); err != nil {
+		return nil, apperrors.NewValidationError("", err.Error())
+	}
+
+	if _, err := s.orgRepo.GetByID(group.OrgID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	if _, err := s.groupRepo.GetByName(group.OrgID, group.Name); err == nil {
+		return nil, apperrors.ErrGroupExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check group name: %w", err)
+	}
+
+	if err := s.groupRepo.Create(group); err != nil {
+		return nil, fmt.Errorf("failed to create group: %w", err)
+	}
+	return toGroupResponse(group), nil
+}
+
+// GetGroupWithTeams retrieves a group along with all of its teams
+func (s *GroupService) GetGroupWithTeams(id uuid.UUID) (*GroupWithTeamsResponse, error) {
+	group, err := s.getGroup(id)
+	if err != nil {
+		return nil, err
+	}
+
+	teams, _, err := s.teamRepo.GetByGroupID(id, 1000, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teams of group: %w", err)
+	}
+
+	teamResponses := make([]TeamResponse, len(teams))
+	for i := range teams {
+		teamResponses[i] = toGroupTeamResponse(&teams[i], group.OrgID)
+	}
+	return &GroupWithTeamsResponse{
+		GroupResponse: *toGroupResponse(group),
+		Teams:         teamResponses,
+	}, nil
+}
+
+// GetGroupsByOrganization retrieves a page of the groups of an organization. A non-empty query
+// restricts them to groups whose name, title or description contains it.
+func (s *GroupService) GetGroupsByOrganization(orgID uuid.UUID, query string, page, pageSize int) (*GroupListResponse, error) {
+	if _, err := s.orgRepo.GetByID(orgID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	offset := (page - 1) * pageSize
+
+	var (
+		groups []models.Group
+		total  int64
+		err    error
+	)
+	if query != "" {
+		groups, total, err = s.groupRepo.Search(orgID, query, pageSize, offset)
+	} else {
+		groups, total, err = s.groupRepo.GetByOrganizationID(orgID, pageSize, offset)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get groups: %w", err)
+	}
+
+	responses := make([]GroupResponse, len(groups))
+	for i := range groups {
+		responses[i] = *toGroupResponse(&groups[i])
+	}
+	return &GroupListResponse{
+		Groups:   responses,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// UpdateGroup updates the given fields of a group. A non-zero expected version must match the group's version.
+func (s *GroupService) UpdateGroup(id uuid.UUID, req *UpdateGroupRequest) (*GroupResponse, error) {
+	var group *models.Group
+	err := retryOnConflict(req.ExpectedVersion, func() error {
+		var err error
+		group, err = s.getGroup(id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(req.ExpectedVersion, group.Version); err != nil {
+			return err
+		}
+
+		if req.Title != nil {
+			group.Title = *req.Title
+		}
+		if req.Description != nil {
+			group.Description = *req.Description
+		}
+		if req.Owner != nil {
+			group.Owner = *req.Owner
+		}
+		if req.Email != nil {
+			group.Email = *req.Email
+		}
+		if req.PictureURL != nil {
+			group.PictureURL = *req.PictureURL
+		}
+		if req.Metadata != nil {
+			group.Metadata = req.Metadata
+		}
+		group.UpdatedBy = req.UpdatedBy
+		if err := s.validator.Struct(group); err != nil {
+			return apperrors.NewValidationError("", err.Error())
+		}
+
+		if err := s.groupRepo.Update(group); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperrors.ErrGroupNotFound
+			}
+			if errors.Is(err, repository.ErrVersionConflict) {
+				return err
+			}
+			return fmt.Errorf("failed to update group: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toGroupResponse(group), nil
+}
+
+// DeleteGroup deletes a group. Groups with teams cannot be deleted; move the teams first.
+func (s *GroupService) DeleteGroup(id uuid.UUID) error {
+	if _, err := s.getGroup(id); err != nil {
+		return err
+	}
+
+	_, teams, err := s.teamRepo.GetByGroupID(id, 1, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get teams of group: %w", err)
+	}
+	if teams > 0 {
+		return apperrors.ErrGroupHasTeams
+	}
+
+	if err := s.groupRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete group: %w", err)
+	}
+	return nil
+}
+
+// MoveTeam moves a team to another group, which must not have a team of the same name
+func (s *GroupService) MoveTeam(teamID uuid.UUID, req *MoveTeamRequest) (*TeamResponse, error) {
+	group, err := s.getGroup(req.GroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	var team *models.Team
+	err = retryOnConflict(0, func() error {
+		var err error
+		team, err = s.teamRepo.GetByID(teamID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperrors.ErrTeamNotFound
+			}
+			return fmt.Errorf("failed to get team: %w", err)
+		}
+		if team.GroupID == group.ID {
+			return nil
+		}
+
+		if _, err := s.teamRepo.GetByName(group.ID, team.Name); err == nil {
+			return apperrors.ErrTeamExists
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to check team name: %w", err)
+		}
+
+		team.GroupID = group.ID
+		team.UpdatedBy = req.UpdatedBy
+		if err := s.teamRepo.Update(team); err != nil {
+			if errors.Is(err, repository.ErrVersionConflict) {
+				return err
+			}
+			return fmt.Errorf("failed to move team: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := toGroupTeamResponse(team, group.OrgID)
+	return &resp, nil
+}
+
+// getGroup loads a group, mapping a missing one to ErrGroupNotFound
+func (s *GroupService) getGroup(id uuid.UUID) (*models.Group, error) {
+	group, err := s.groupRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrGroupNotFound
+		}
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+	return group, nil
+}
+
+// toGroupResponse converts a Group model to GroupResponse
+func toGroupResponse(group *models.Group) *GroupResponse {
+	return &GroupResponse{
+		ID:          group.ID,
+		OrgID:       group.OrgID,
+		Name:        group.Name,
+		Title:       group.Title,
+		Description: group.Description,
+		Owner:       group.Owner,
+		Email:       group.Email,
+		PictureURL:  group.PictureURL,
+		Metadata:    group.Metadata,
+		Version:     group.Version,
+		CreatedAt:   group.CreatedAt.Format(time.RFC3339),
+		CreatedBy:   group.CreatedBy,
+		UpdatedAt:   group.UpdatedAt.Format(time.RFC3339),
+		UpdatedBy:   group.UpdatedBy,
+	}
+}
+
+// toGroupTeamResponse converts a team of a group in the organization to TeamResponse
+func toGroupTeamResponse(team *models.Team, orgID uuid.UUID) TeamResponse {
+	return TeamResponse{
+		ID:             team.ID,
+		GroupID:        team.GroupID,
+		OrganizationID: orgID,
+		Name:           team.Name,
+		Title:          team.Title,
+		Description:    team.Description,
+		Owner:          team.Owner,
+		Email:          team.Email,
+		PictureURL:     team.PictureURL,
+		Metadata:       team.Metadata,
+		Version:        team.Version,
+		CreatedAt:      team.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      team.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,207 @@
+package service_test
+
+import (
+	"testing"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/repository"
+	"developer-portal-backend/internal/service"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type GroupServiceTestSuite struct {
+	suite.Suite
+	ctrl          *gomock.Controller
+	mockGroupRepo *mocks.MockGroupRepositoryInterface
+	mockOrgRepo   *mocks.MockOrganizationRepositoryInterface
+	mockTeamRepo  *mocks.MockTeamRepositoryInterface
+	groupService  *service.GroupService
+}
+
+func (suite *GroupServiceTestSuite) SetupTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockGroupRepo = mocks.NewMockGroupRepositoryInterface(suite.ctrl)
+	suite.mockOrgRepo = mocks.NewMockOrganizationRepositoryInterface(suite.ctrl)
+	suite.mockTeamRepo = mocks.NewMockTeamRepositoryInterface(suite.ctrl)
+	suite.groupService = service.NewGroupService(suite.mockGroupRepo, suite.mockOrgRepo, suite.mockTeamRepo, validator.New())
+}
+
+func (suite *GroupServiceTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+func newGroup(version int64) *models.Group {
+	return &models.Group{
+		BaseModel:  models.BaseModel{ID: uuid.New(), Name: "core-platform", Title: "Core Platform", Version: version},
+		OrgID:      uuid.New(),
+		Owner:      "I000002",
+		Email:      "core@sap.com",
+		PictureURL: "https://example.com/core.png",
+	}
+}
+
+func newGroupTeam(groupID uuid.UUID, name string) *models.Team {
+	return &models.Team{
+		BaseModel: models.BaseModel{ID: uuid.New(), Name: name, Title: name, Version: 1},
+		GroupID:   groupID,
+		Owner:     "I000003",
+	}
+}
+
+func (suite *GroupServiceTestSuite) TestCreateGroup() {
+	orgID := uuid.New()
+	req := &service.CreateGroupRequest{
+		OrgID: orgID, Name: "core-platform", Title: "Core Platform", Owner: "I000002", Email: "core@sap.com",
+		PictureURL: "https://example.com/core.png", CreatedBy: "jdoe",
+	}
+	suite.mockOrgRepo.EXPECT().GetByID(orgID).Return(&models.Organization{BaseModel: models.BaseModel{ID: orgID}}, nil).Times(2)
+	suite.mockGroupRepo.EXPECT().GetByName(orgID, "core-platform").Return(nil, gorm.ErrRecordNotFound)
+	suite.mockGroupRepo.EXPECT().Create(gomock.Any()).Return(nil)
+
+	resp, err := suite.groupService.CreateGroup(req)
+	suite.Require().NoError(err)
+	suite.Equal(orgID, resp.OrgID)
+	suite.Equal("jdoe", resp.CreatedBy)
+
+	suite.mockGroupRepo.EXPECT().GetByName(orgID, "core-platform").Return(newGroup(1), nil)
+	_, err = suite.groupService.CreateGroup(req)
+	suite.ErrorIs(err, apperrors.ErrGroupExists)
+}
+
+func (suite *GroupServiceTestSuite) TestCreateGroup_ValidatesModelTags() {
+	_, err := suite.groupService.CreateGroup(&service.CreateGroupRequest{Name: "core-platform", Title: "Core Platform", Owner: "I000002", Email: "core@sap.com"})
+	suite.True(apperrors.IsValidation(err))
+	suite.ErrorContains(err, "OrgID")
+	suite.ErrorContains(err, "PictureURL")
+}
+
+func (suite *GroupServiceTestSuite) TestGetGroupWithTeams() {
+	group := newGroup(1)
+	team := newGroupTeam(group.ID, "team-a")
+	suite.mockGroupRepo.EXPECT().GetByID(group.ID).Return(group, nil)
+	suite.mockTeamRepo.EXPECT().GetByGroupID(group.ID, gomock.Any(), 0).Return([]models.Team{*team}, int64(1), nil)
+
+	resp, err := suite.groupService.GetGroupWithTeams(group.ID)
+	suite.Require().NoError(err)
+	suite.Equal("core-platform", resp.Name)
+	suite.Require().Len(resp.Teams, 1)
+	suite.Equal(team.ID, resp.Teams[0].ID)
+	suite.Equal(group.OrgID, resp.Teams[0].OrganizationID)
+}
+
+func (suite *GroupServiceTestSuite) TestGetGroupsByOrganization_Search() {
+	orgID := uuid.New()
+	suite.mockOrgRepo.EXPECT().GetByID(orgID).Return(&models.Organization{BaseModel: models.BaseModel{ID: orgID}}, nil).Times(2)
+	suite.mockGroupRepo.EXPECT().Search(orgID, "core", 20, 0).Return([]models.Group{*newGroup(1)}, int64(1), nil)
+	suite.mockGroupRepo.EXPECT().GetByOrganizationID(orgID, 10, 10).Return(nil, int64(10), nil)
+
+	resp, err := suite.groupService.GetGroupsByOrganization(orgID, "core", 0, 0)
+	suite.Require().NoError(err)
+	suite.Len(resp.Groups, 1)
+
+	resp, err = suite.groupService.GetGroupsByOrganization(orgID, "", 2, 10)
+	suite.Require().NoError(err)
+	suite.Equal(int64(10), resp.Total)
+	suite.Empty(resp.Groups)
+}
+
+func (suite *GroupServiceTestSuite) TestUpdateGroup() {
+	group := newGroup(2)
+	title := "Core"
+	// Read before the update, then before the rejected updates
+	suite.mockGroupRepo.EXPECT().GetByID(group.ID).DoAndReturn(func(uuid.UUID) (*models.Group, error) {
+		copied := *group
+		return &copied, nil
+	}).Times(3)
+	suite.mockGroupRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(updated *models.Group) error {
+		suite.Equal("Core", updated.Title)
+		suite.Equal("I000002", updated.Owner)
+		suite.Equal("jdoe", updated.UpdatedBy)
+		updated.Version++
+		return nil
+	})
+
+	resp, err := suite.groupService.UpdateGroup(group.ID, &service.UpdateGroupRequest{Title: &title, UpdatedBy: "jdoe", ExpectedVersion: 2})
+	suite.Require().NoError(err)
+	suite.Equal("Core", resp.Title)
+	suite.Equal(int64(3), resp.Version)
+
+	_, err = suite.groupService.UpdateGroup(group.ID, &service.UpdateGroupRequest{ExpectedVersion: 1})
+	suite.ErrorIs(err, apperrors.ErrVersionConflict)
+
+	// A concurrent update between reading and writing the group is not overwritten
+	suite.mockGroupRepo.EXPECT().Update(gomock.Any()).Return(repository.ErrVersionConflict)
+	_, err = suite.groupService.UpdateGroup(group.ID, &service.UpdateGroupRequest{Title: &title, ExpectedVersion: 2})
+	suite.ErrorIs(err, apperrors.ErrVersionConflict)
+}
+
+func (suite *GroupServiceTestSuite) TestUpdateGroup_RetriesWithoutExpectedVersion() {
+	group := newGroup(2)
+	title := "Core"
+	suite.mockGroupRepo.EXPECT().GetByID(group.ID).Return(group, nil).Times(2)
+	gomock.InOrder(
+		suite.mockGroupRepo.EXPECT().Update(gomock.Any()).Return(repository.ErrVersionConflict),
+		suite.mockGroupRepo.EXPECT().Update(gomock.Any()).Return(nil),
+	)
+
+	resp, err := suite.groupService.UpdateGroup(group.ID, &service.UpdateGroupRequest{Title: &title})
+	suite.Require().NoError(err)
+	suite.Equal("Core", resp.Title)
+}
+
+func (suite *GroupServiceTestSuite) TestDeleteGroup() {
+	group := newGroup(1)
+	suite.mockGroupRepo.EXPECT().GetByID(group.ID).Return(group, nil).Times(2)
+
+	suite.mockTeamRepo.EXPECT().GetByGroupID(group.ID, 1, 0).Return([]models.Team{{}}, int64(2), nil)
+	suite.ErrorIs(suite.groupService.DeleteGroup(group.ID), apperrors.ErrGroupHasTeams)
+
+	suite.mockTeamRepo.EXPECT().GetByGroupID(group.ID, 1, 0).Return(nil, int64(0), nil)
+	suite.mockGroupRepo.EXPECT().Delete(group.ID).Return(nil)
+	suite.NoError(suite.groupService.DeleteGroup(group.ID))
+}
+
+func (suite *GroupServiceTestSuite) TestMoveTeam() {
+	target := newGroup(1)
+	team := newGroupTeam(uuid.New(), "team-a")
+	suite.mockGroupRepo.EXPECT().GetByID(target.ID).Return(target, nil)
+	gomock.InOrder(
+		suite.mockTeamRepo.EXPECT().GetByID(team.ID).Return(team, nil),
+		suite.mockTeamRepo.EXPECT().GetByName(target.ID, "team-a").Return(nil, gorm.ErrRecordNotFound),
+		suite.mockTeamRepo.EXPECT().Update(gomock.Any()).Return(repository.ErrVersionConflict),
+		suite.mockTeamRepo.EXPECT().GetByID(team.ID).Return(newGroupTeam(team.GroupID, "team-a"), nil),
+		suite.mockTeamRepo.EXPECT().GetByName(target.ID, "team-a").Return(nil, gorm.ErrRecordNotFound),
+		suite.mockTeamRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(t *models.Team) error {
+			suite.Equal(target.ID, t.GroupID)
+			suite.Equal("jdoe", t.UpdatedBy)
+			return nil
+		}),
+	)
+
+	resp, err := suite.groupService.MoveTeam(team.ID, &service.MoveTeamRequest{GroupID: target.ID, UpdatedBy: "jdoe"})
+	suite.Require().NoError(err)
+	suite.Equal(target.ID, resp.GroupID)
+	suite.Equal(target.OrgID, resp.OrganizationID)
+}
+
+func (suite *GroupServiceTestSuite) TestMoveTeam_NameTakenInTargetGroup() {
+	target := newGroup(1)
+	team := newGroupTeam(uuid.New(), "team-a")
+	suite.mockGroupRepo.EXPECT().GetByID(target.ID).Return(target, nil)
+	suite.mockTeamRepo.EXPECT().GetByID(team.ID).Return(team, nil)
+	suite.mockTeamRepo.EXPECT().GetByName(target.ID, "team-a").Return(newGroupTeam(target.ID, "team-a"), nil)
+
+	_, err := suite.groupService.MoveTeam(team.ID, &service.MoveTeamRequest{GroupID: target.ID})
+	suite.ErrorIs(err, apperrors.ErrTeamExists)
+}
+
+func TestGroupServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(GroupServiceTestSuite))
+}
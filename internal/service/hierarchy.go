@@ -0,0 +1,297 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// HierarchyService builds the organization chart: an organization or group with the groups, teams
+// and members below it, in one response
+type HierarchyService struct {
+	orgRepo       repository.OrganizationRepositoryInterface
+	groupRepo     repository.GroupRepositoryInterface
+	componentRepo repository.ComponentRepositoryInterface
+}
+
+// Ensure HierarchyService implements HierarchyServiceInterface
+var _ HierarchyServiceInterface = (*HierarchyService)(nil)
+
+// NewHierarchyService creates a new hierarchy service
+func NewHierarchyService(
+	orgRepo repository.OrganizationRepositoryInterface,
+	groupRepo repository.GroupRepositoryInterface,
+	componentRepo repository.ComponentRepositoryInterface,
+) *HierarchyService {
+	return &HierarchyService{
+		orgRepo:       orgRepo,
+		groupRepo:     groupRepo,
+		componentRepo: componentRepo,
+	}
+}
+
+// MaxHierarchyDepth is the depth of a full organization tree: groups, teams and members
+const MaxHierarchyDepth = 3
+
+// HierarchyFields are the optional fields of the nodes of a tree
+var HierarchyFields = []string{"title", "description", "owner", "email", "picture_url"}
+
+// HierarchyOptions selects what a tree contains
+type HierarchyOptions struct {
+	// Depth is the number of levels below the root to include. Counts always cover the whole tree.
+	Depth int
+	// Fields are the HierarchyFields to include; none includes all of them
+	Fields []string
+	// ComponentCounts adds the number of components owned by each team, and their sums
+	ComponentCounts bool
+}
+
+// HierarchyNode holds the fields shared by the organizations, groups and teams of a tree
+type HierarchyNode struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Title       string    `json:"title,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Owner       string    `json:"owner,omitempty"` // I/C/D user
+	Email       string    `json:"email,omitempty"` // DL
+	PictureURL  string    `json:"picture_url,omitempty"`
+}
+
+// OrganizationTreeResponse represents an organization with its groups
+type OrganizationTreeResponse struct {
+	HierarchyNode
+	GroupCount     int                 `json:"group_count"`
+	TeamCount      int                 `json:"team_count"`
+	MemberCount    int                 `json:"member_count"`
+	ComponentCount *int64              `json:"component_count,omitempty"`
+	Groups         []GroupTreeResponse `json:"groups,omitempty"`
+}
+
+// GroupTreeResponse represents a group with its teams
+type GroupTreeResponse struct {
+	HierarchyNode
+	TeamCount      int                `json:"team_count"`
+	MemberCount    int                `json:"member_count"`
+	ComponentCount *int64             `json:"component_count,omitempty"`
+	Teams          []TeamTreeResponse `json:"teams,omitempty"`
+}
+
+// TeamTreeResponse represents a team with its members
+type TeamTreeResponse struct {
+	HierarchyNode
+	MemberCount    int                  `json:"member_count"`
+	ComponentCount *int64               `json:"component_count,omitempty"`
+	Members        []TreeMemberResponse `json:"members,omitempty"`
+}
+
+// TreeMemberResponse represents a member of a team in a tree. Email is included with the email field.
+type TreeMemberResponse struct {
+	UserID     string `json:"user_id"`
+	FirstName  string `json:"first_name"`
+	LastName   string `json:"last_name"`
+	Email      string `json:"email,omitempty"`
+	TeamRole   string `json:"team_role"`
+	TeamDomain string `json:"team_domain"`
+}
+
+// GetOrganizationTree returns an organization with its groups, their teams and the teams' members
+func (s *HierarchyService) GetOrganizationTree(id uuid.UUID, opts HierarchyOptions) (*OrganizationTreeResponse, error) {
+	builder, err := newTreeBuilder(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgRepo.GetWithAllRelations(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization tree: %w", err)
+	}
+
+	var teams []models.Team
+	for _, group := range org.Groups {
+		teams = append(teams, group.Teams...)
+	}
+	if err := s.countComponents(builder, opts, teams); err != nil {
+		return nil, err
+	}
+
+	resp := &OrganizationTreeResponse{
+		HierarchyNode:  builder.node(&org.BaseModel, org.Owner, org.Email, ""),
+		GroupCount:     len(org.Groups),
+		ComponentCount: builder.sum(),
+	}
+	for i := range org.Groups {
+		group := builder.group(&org.Groups[i], opts.Depth-1)
+		resp.TeamCount += group.TeamCount
+		resp.MemberCount += group.MemberCount
+		builder.add(resp.ComponentCount, group.ComponentCount)
+		if opts.Depth > 0 {
+			resp.Groups = append(resp.Groups, group)
+		}
+	}
+	return resp, nil
+}
+
+// GetGroupTree returns a group with its teams and their members. A depth beyond members is ignored.
+func (s *HierarchyService) GetGroupTree(id uuid.UUID, opts HierarchyOptions) (*GroupTreeResponse, error) {
+	builder, err := newTreeBuilder(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := s.groupRepo.GetWithTeams(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrGroupNotFound
+		}
+		return nil, fmt.Errorf("failed to get group tree: %w", err)
+	}
+
+	if err := s.countComponents(builder, opts, group.Teams); err != nil {
+		return nil, err
+	}
+
+	resp := builder.group(group, opts.Depth)
+	return &resp, nil
+}
+
+// countComponents loads the component counts of teams into builder, if they were requested
+func (s *HierarchyService) countComponents(builder *treeBuilder, opts HierarchyOptions, teams []models.Team) error {
+	if !opts.ComponentCounts {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(teams))
+	for i := range teams {
+		ids[i] = teams[i].ID
+	}
+	counts, err := s.componentRepo.CountByOwnerIDs(ids)
+	if err != nil {
+		return fmt.Errorf("failed to count components: %w", err)
+	}
+	if counts == nil {
+		counts = map[uuid.UUID]int64{}
+	}
+	builder.components = counts
+	return nil
+}
+
+// treeBuilder converts the models of a tree to responses with the selected fields
+type treeBuilder struct {
+	fields map[string]bool
+	// components are the component counts by team; nil unless they were requested
+	components map[uuid.UUID]int64
+}
+
+// newTreeBuilder validates opts and creates a builder for the selected fields
+func newTreeBuilder(opts HierarchyOptions) (*treeBuilder, error) {
+	if opts.Depth < 0 {
+		return nil, apperrors.NewValidationError("depth", "must not be negative")
+	}
+
+	fields := make(map[string]bool, len(HierarchyFields))
+	for _, field := range opts.Fields {
+		if !slices.Contains(HierarchyFields, field) {
+			return nil, apperrors.NewValidationError("fields", fmt.Sprintf("unknown field %q; expected any of %s", field, strings.Join(HierarchyFields, ", ")))
+		}
+		fields[field] = true
+	}
+	if len(fields) == 0 {
+		for _, field := range HierarchyFields {
+			fields[field] = true
+		}
+	}
+	return &treeBuilder{fields: fields}, nil
+}
+
+// group converts a group with its teams, including depth levels below it
+func (b *treeBuilder) group(group *models.Group, depth int) GroupTreeResponse {
+	resp := GroupTreeResponse{
+		HierarchyNode:  b.node(&group.BaseModel, group.Owner, group.Email, group.PictureURL),
+		TeamCount:      len(group.Teams),
+		ComponentCount: b.sum(),
+	}
+	for i := range group.Teams {
+		team := b.team(&group.Teams[i], depth-1)
+		resp.MemberCount += team.MemberCount
+		b.add(resp.ComponentCount, team.ComponentCount)
+		if depth > 0 {
+			resp.Teams = append(resp.Teams, team)
+		}
+	}
+	return resp
+}
+
+// team converts a team, with its members if depth is positive
+func (b *treeBuilder) team(team *models.Team, depth int) TeamTreeResponse {
+	resp := TeamTreeResponse{
+		HierarchyNode: b.node(&team.BaseModel, team.Owner, team.Email, team.PictureURL),
+		MemberCount:   len(team.Users),
+	}
+	if b.components != nil {
+		count := b.components[team.ID]
+		resp.ComponentCount = &count
+	}
+	if depth > 0 {
+		for _, user := range team.Users {
+			member := TreeMemberResponse{
+				UserID:     user.UserID,
+				FirstName:  user.FirstName,
+				LastName:   user.LastName,
+				TeamRole:   string(user.TeamRole),
+				TeamDomain: string(user.TeamDomain),
+			}
+			if b.fields["email"] {
+				member.Email = user.Email
+			}
+			resp.Members = append(resp.Members, member)
+		}
+	}
+	return resp
+}
+
+// node converts the fields shared by organizations, groups and teams, leaving out those not selected
+func (b *treeBuilder) node(base *models.BaseModel, owner, email, pictureURL string) HierarchyNode {
+	node := HierarchyNode{ID: base.ID, Name: base.Name}
+	if b.fields["title"] {
+		node.Title = base.Title
+	}
+	if b.fields["description"] {
+		node.Description = base.Description
+	}
+	if b.fields["owner"] {
+		node.Owner = owner
+	}
+	if b.fields["email"] {
+		node.Email = email
+	}
+	if b.fields["picture_url"] {
+		node.PictureURL = pictureURL
+	}
+	return node
+}
+
+// sum returns a zero component count to add to, or nil if component counts were not requested
+func (b *treeBuilder) sum() *int64 {
+	if b.components == nil {
+		return nil
+	}
+	var sum int64
+	return &sum
+}
+
+// add adds count to sum; both are nil if component counts were not requested
+func (b *treeBuilder) add(sum, count *int64) {
+	if sum != nil && count != nil {
+		*sum += *count
+	}
+}
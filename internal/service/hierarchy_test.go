@@ -0,0 +1,170 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type HierarchyServiceTestSuite struct {
+	suite.Suite
+	ctrl              *gomock.Controller
+	mockOrgRepo       *mocks.MockOrganizationRepositoryInterface
+	mockGroupRepo     *mocks.MockGroupRepositoryInterface
+	mockComponentRepo *mocks.MockComponentRepositoryInterface
+	hierarchyService  *service.HierarchyService
+	org               *models.Organization
+}
+
+func (suite *HierarchyServiceTestSuite) SetupTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockOrgRepo = mocks.NewMockOrganizationRepositoryInterface(suite.ctrl)
+	suite.mockGroupRepo = mocks.NewMockGroupRepositoryInterface(suite.ctrl)
+	suite.mockComponentRepo = mocks.NewMockComponentRepositoryInterface(suite.ctrl)
+	suite.hierarchyService = service.NewHierarchyService(suite.mockOrgRepo, suite.mockGroupRepo, suite.mockComponentRepo)
+
+	member := func(userID, firstName string) models.User {
+		return models.User{UserID: userID, FirstName: firstName, LastName: "Doe", Email: userID + "@example.com", TeamRole: models.TeamRoleMember, TeamDomain: models.TeamDomainDeveloper}
+	}
+	suite.org = &models.Organization{
+		BaseModel: models.BaseModel{ID: uuid.New(), Name: "sap", Title: "SAP"},
+		Owner:     "I000001",
+		Email:     "org@example.com",
+		Groups: []models.Group{
+			{
+				BaseModel: models.BaseModel{ID: uuid.New(), Name: "apps", Title: "Apps"},
+				Owner:     "I000002",
+			},
+			{
+				BaseModel: models.BaseModel{ID: uuid.New(), Name: "platform", Title: "Platform"},
+				Owner:     "I000003",
+				Teams: []models.Team{
+					{
+						BaseModel: models.BaseModel{ID: uuid.New(), Name: "team-coe", Title: "CoE"},
+						Owner:     "I000004",
+						Users:     []models.User{member("I123456", "Anna"), member("I234567", "Zoe")},
+					},
+					{
+						BaseModel: models.BaseModel{ID: uuid.New(), Name: "team-ops", Title: "Ops"},
+						Users:     []models.User{member("I345678", "John")},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (suite *HierarchyServiceTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *HierarchyServiceTestSuite) TestGetOrganizationTree() {
+	suite.mockOrgRepo.EXPECT().GetWithAllRelations(suite.org.ID).Return(suite.org, nil)
+
+	tree, err := suite.hierarchyService.GetOrganizationTree(suite.org.ID, service.HierarchyOptions{Depth: service.MaxHierarchyDepth})
+	suite.Require().NoError(err)
+	suite.Equal("SAP", tree.Title)
+	suite.Equal("I000001", tree.Owner)
+	suite.Equal(2, tree.GroupCount)
+	suite.Equal(2, tree.TeamCount)
+	suite.Equal(3, tree.MemberCount)
+	suite.Nil(tree.ComponentCount)
+
+	suite.Require().Len(tree.Groups, 2)
+	suite.Zero(tree.Groups[0].TeamCount)
+	platform := tree.Groups[1]
+	suite.Equal(2, platform.TeamCount)
+	suite.Equal(3, platform.MemberCount)
+	suite.Require().Len(platform.Teams, 2)
+	suite.Equal(2, platform.Teams[0].MemberCount)
+	suite.Require().Len(platform.Teams[0].Members, 2)
+	suite.Equal("I123456", platform.Teams[0].Members[0].UserID)
+	suite.Equal("I123456@example.com", platform.Teams[0].Members[0].Email)
+	suite.Equal("developer", platform.Teams[0].Members[0].TeamDomain)
+}
+
+func (suite *HierarchyServiceTestSuite) TestGetOrganizationTree_DepthAndFields() {
+	suite.mockOrgRepo.EXPECT().GetWithAllRelations(suite.org.ID).Return(suite.org, nil).Times(2)
+
+	// Teams without members keep the member counts
+	tree, err := suite.hierarchyService.GetOrganizationTree(suite.org.ID, service.HierarchyOptions{Depth: 2, Fields: []string{"owner"}})
+	suite.Require().NoError(err)
+	suite.Empty(tree.Title)
+	suite.Equal("I000001", tree.Owner)
+	suite.Empty(tree.Email)
+	team := tree.Groups[1].Teams[0]
+	suite.Equal("I000004", team.Owner)
+	suite.Equal(2, team.MemberCount)
+	suite.Nil(team.Members)
+
+	tree, err = suite.hierarchyService.GetOrganizationTree(suite.org.ID, service.HierarchyOptions{Depth: 0, Fields: []string{"title"}})
+	suite.Require().NoError(err)
+	suite.Equal("SAP", tree.Title)
+	suite.Empty(tree.Owner)
+	suite.Equal(3, tree.MemberCount)
+	suite.Nil(tree.Groups)
+}
+
+func (suite *HierarchyServiceTestSuite) TestGetOrganizationTree_ComponentCounts() {
+	platform := suite.org.Groups[1]
+	coe, ops := platform.Teams[0].ID, platform.Teams[1].ID
+	suite.mockOrgRepo.EXPECT().GetWithAllRelations(suite.org.ID).Return(suite.org, nil)
+	suite.mockComponentRepo.EXPECT().CountByOwnerIDs([]uuid.UUID{coe, ops}).Return(map[uuid.UUID]int64{coe: 5}, nil)
+
+	tree, err := suite.hierarchyService.GetOrganizationTree(suite.org.ID, service.HierarchyOptions{Depth: 2, ComponentCounts: true})
+	suite.Require().NoError(err)
+	suite.Require().NotNil(tree.ComponentCount)
+	suite.Equal(int64(5), *tree.ComponentCount)
+	suite.Equal(int64(0), *tree.Groups[0].ComponentCount)
+	suite.Equal(int64(5), *tree.Groups[1].ComponentCount)
+	suite.Equal(int64(5), *tree.Groups[1].Teams[0].ComponentCount)
+	suite.Equal(int64(0), *tree.Groups[1].Teams[1].ComponentCount)
+}
+
+func (suite *HierarchyServiceTestSuite) TestGetOrganizationTree_Errors() {
+	_, err := suite.hierarchyService.GetOrganizationTree(suite.org.ID, service.HierarchyOptions{Depth: -1})
+	suite.True(apperrors.IsValidation(err))
+
+	_, err = suite.hierarchyService.GetOrganizationTree(suite.org.ID, service.HierarchyOptions{Fields: []string{"metadata"}})
+	suite.True(apperrors.IsValidation(err))
+	suite.ErrorContains(err, "metadata")
+
+	suite.mockOrgRepo.EXPECT().GetWithAllRelations(gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
+	_, err = suite.hierarchyService.GetOrganizationTree(uuid.New(), service.HierarchyOptions{})
+	suite.ErrorIs(err, apperrors.ErrOrganizationNotFound)
+
+	suite.mockOrgRepo.EXPECT().GetWithAllRelations(suite.org.ID).Return(suite.org, nil)
+	suite.mockComponentRepo.EXPECT().CountByOwnerIDs(gomock.Any()).Return(nil, errors.New("connection refused"))
+	_, err = suite.hierarchyService.GetOrganizationTree(suite.org.ID, service.HierarchyOptions{ComponentCounts: true})
+	suite.ErrorContains(err, "failed to count components")
+}
+
+func (suite *HierarchyServiceTestSuite) TestGetGroupTree() {
+	group := &suite.org.Groups[1]
+	suite.mockGroupRepo.EXPECT().GetWithTeams(group.ID).Return(group, nil)
+	suite.mockGroupRepo.EXPECT().GetWithTeams(gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
+
+	// A depth beyond members returns the whole group
+	tree, err := suite.hierarchyService.GetGroupTree(group.ID, service.HierarchyOptions{Depth: service.MaxHierarchyDepth})
+	suite.Require().NoError(err)
+	suite.Equal("platform", tree.Name)
+	suite.Equal(3, tree.MemberCount)
+	suite.Require().Len(tree.Teams, 2)
+	suite.Len(tree.Teams[1].Members, 1)
+
+	_, err = suite.hierarchyService.GetGroupTree(uuid.New(), service.HierarchyOptions{})
+	suite.ErrorIs(err, apperrors.ErrGroupNotFound)
+}
+
+func TestHierarchyServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(HierarchyServiceTestSuite))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"mime/multipart"
+	"time"
 
 	"developer-portal-backend/internal/auth"
 	"developer-portal-backend/internal/database/models"
@@ -20,7 +21,7 @@ type UserServiceInterface interface {
 	GetUserByID(id uuid.UUID) (*UserResponse, error)
 	GetUsersByOrganization(organizationID uuid.UUID, limit, offset int) ([]UserResponse, int64, error)
 	UpdateUser(id uuid.UUID, req *UpdateUserRequest) (*UserResponse, error)
-	DeleteUser(id uuid.UUID) error
+	DeleteUser(id uuid.UUID, deletedBy string) error
 	SearchUsers(organizationID uuid.UUID, query string, limit, offset int) ([]UserResponse, int64, error)
 	GetActiveUsers(organizationID uuid.UUID, limit, offset int) ([]UserResponse, int64, error)
 	GetQuickLinks(id uuid.UUID) (*QuickLinksResponse, error)
@@ -35,7 +36,16 @@ type TeamServiceInterface interface {
 	GetBySimpleName(teamName string) (*TeamWithMembersResponse, error)
 	GetBySimpleNameWithViewer(teamName string, viewerName string) (*TeamWithMembersResponse, error)
 	GetTeamComponentsByID(id uuid.UUID, page, pageSize int) ([]models.Component, int64, error)
-	UpdateTeamMetadata(id uuid.UUID, metadata json.RawMessage) (*TeamResponse, error)
+	UpdateTeamMetadata(id uuid.UUID, metadata json.RawMessage, expectedVersion int64) (*TeamResponse, error)
+}
+
+// TeamMembershipServiceInterface defines the interface for team membership service
+type TeamMembershipServiceInterface interface {
+	GetTeamMembers(teamID uuid.UUID, from, to time.Time) ([]TeamMemberResponse, error)
+	AddTeamMember(teamID uuid.UUID, req *AddTeamMemberRequest) (*UserResponse, error)
+	UpdateTeamMember(teamID uuid.UUID, userID string, req *UpdateTeamMemberRequest) (*UserResponse, error)
+	RemoveTeamMember(teamID uuid.UUID, userID string, removedBy string) error
+	GetMembershipHistory(userID string) ([]models.TeamMembership, error)
 }
 
 // LandscapeServiceInterface defines the interface for landscape service
@@ -47,7 +57,7 @@ type LandscapeServiceInterface interface {
 	DeleteLandscape(id uuid.UUID) error
 	GetByProjectName(projectName string) (*LandscapeListResponse, error)
 	GetByProjectNameAll(projectName string) ([]LandscapeMinimalResponse, error)
-	ListByQuery(q string, domains []string, environments []string, limit int, offset int) (*LandscapeListResponse, error)
+	ListByQuery(query *LandscapeQuery, page, pageSize int) (*LandscapeListResponse, error)
 }
 
 // GitHubServiceInterface defines the interface for GitHub service
@@ -123,3 +133,90 @@ type DocumentationServiceInterface interface {
 	UpdateDocumentation(id uuid.UUID, req *UpdateDocumentationRequest) (*DocumentationResponse, error)
 	DeleteDocumentation(id uuid.UUID) error
 }
+
+// TrashServiceInterface defines the interface for trash service
+type TrashServiceInterface interface {
+	ListTrash(kind string, page, pageSize int) (*TrashListResponse, error)
+	Restore(kind string, id uuid.UUID) error
+}
+
+// OrganizationServiceInterface defines the interface for organization service
+type OrganizationServiceInterface interface {
+	CreateOrganization(req *CreateOrganizationRequest) (*OrganizationResponse, error)
+	GetOrganizationByID(id uuid.UUID) (*OrganizationResponse, error)
+	GetAllOrganizations(page, pageSize int) (*OrganizationListResponse, error)
+	UpdateOrganization(id uuid.UUID, req *UpdateOrganizationRequest) (*OrganizationResponse, error)
+	DeleteOrganization(id uuid.UUID) error
+}
+
+// GroupServiceInterface defines the interface for group service
+type GroupServiceInterface interface {
+	CreateGroup(req *CreateGroupRequest) (*GroupResponse, error)
+	GetGroupWithTeams(id uuid.UUID) (*GroupWithTeamsResponse, error)
+	GetGroupsByOrganization(orgID uuid.UUID, query string, page, pageSize int) (*GroupListResponse, error)
+	UpdateGroup(id uuid.UUID, req *UpdateGroupRequest) (*GroupResponse, error)
+	DeleteGroup(id uuid.UUID) error
+	MoveTeam(teamID uuid.UUID, req *MoveTeamRequest) (*TeamResponse, error)
+}
+
+// DirectorySyncServiceInterface defines the interface for the LDAP reconciliation
+type DirectorySyncServiceInterface interface {
+	Reconcile() (*DirectorySyncReport, error)
+	LastReport() (*DirectorySyncReport, error)
+}
+
+// UserImportServiceInterface defines the interface for bulk user imports
+type UserImportServiceInterface interface {
+	ImportUsers(req *UserImportRequest) (*UserImportResult, error)
+}
+
+// LinkCheckServiceInterface defines the interface for the dead-link checker
+type LinkCheckServiceInterface interface {
+	StartLinkCheck() error
+	LastRun() (*LinkCheckRun, error)
+	GetBrokenLinkReport() (*BrokenLinkReport, error)
+}
+
+// UserOffboardingServiceInterface defines the interface for offboarding users
+type UserOffboardingServiceInterface interface {
+	PreviewOffboarding(userID string) (*OffboardingPreview, error)
+	OffboardUser(userID string, req *OffboardUserRequest) (*models.UserOffboarding, error)
+	GetOffboardingHistory(limit int) ([]models.UserOffboarding, error)
+}
+
+// MembershipDriftServiceInterface defines the interface for comparing teams with their distribution lists
+type MembershipDriftServiceInterface interface {
+	GetTeamDrift(teamID uuid.UUID) (*TeamMembershipDrift, error)
+	ApplyTeamDrift(teamID uuid.UUID, appliedBy string) (*TeamMembershipDrift, error)
+	GetDriftReport() (*MembershipDriftReport, error)
+	ApplyDrift(appliedBy string) (*MembershipDriftReport, error)
+}
+
+// HierarchyServiceInterface defines the interface for hierarchy service
+type HierarchyServiceInterface interface {
+	GetOrganizationTree(id uuid.UUID, opts HierarchyOptions) (*OrganizationTreeResponse, error)
+	GetGroupTree(id uuid.UUID, opts HierarchyOptions) (*GroupTreeResponse, error)
+}
+
+// ProjectServiceInterface defines the interface for project service
+type ProjectServiceInterface interface {
+	CreateProject(req *CreateProjectRequest) (*ProjectResponse, error)
+	GetProjectByID(id uuid.UUID) (*ProjectResponse, error)
+	GetAllProjects(page, pageSize int) (*ProjectListResponse, error)
+	UpdateProject(id uuid.UUID, req *UpdateProjectRequest) (*ProjectResponse, error)
+	DeleteProject(id uuid.UUID) error
+	GetProjectSettings(id uuid.UUID) (*ProjectSettingsResponse, error)
+	UpdateProjectSettings(id uuid.UUID, req *UpdateProjectSettingsRequest) (*ProjectSettingsResponse, error)
+}
+
+// ComponentServiceInterface defines the interface for component service
+type ComponentServiceInterface interface {
+	CreateComponent(req *CreateComponentRequest) (*ComponentResponse, error)
+	GetComponentByID(id uuid.UUID) (*ComponentResponse, error)
+	UpdateComponent(id uuid.UUID, req *UpdateComponentRequest) (*ComponentResponse, error)
+	DeleteComponent(id uuid.UUID) error
+	TransferOwnership(id uuid.UUID, req *TransferOwnershipRequest) (*ComponentResponse, error)
+	GetOwnershipHistory(id uuid.UUID) ([]models.ComponentOwnershipChange, error)
+	GetByProjectNameAllView(projectName string, lifecycles LifecycleFilter) ([]ComponentProjectView, error)
+	GetProjectTitleByID(id uuid.UUID) (string, error)
+}
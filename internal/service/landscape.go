@@ -8,6 +8,7 @@ import (
 
 	"developer-portal-backend/internal/database/models"
 	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/metaschema"
 	"developer-portal-backend/internal/repository"
 
 	"github.com/go-playground/validator/v10"
@@ -21,15 +22,17 @@ type LandscapeService struct {
 	organizationRepo *repository.OrganizationRepository
 	projectRepo      *repository.ProjectRepository
 	validator        *validator.Validate
+	metadataSchemas  *metaschema.Registry
 }
 
 // NewLandscapeService creates a new landscape service
-func NewLandscapeService(repo *repository.LandscapeRepository, orgRepo *repository.OrganizationRepository, projectRepo *repository.ProjectRepository, validator *validator.Validate) *LandscapeService {
+func NewLandscapeService(repo *repository.LandscapeRepository, orgRepo *repository.OrganizationRepository, projectRepo *repository.ProjectRepository, validator *validator.Validate, metadataSchemas *metaschema.Registry) *LandscapeService {
 	return &LandscapeService{
 		repo:             repo,
 		organizationRepo: orgRepo,
 		projectRepo:      projectRepo,
 		validator:        validator,
+		metadataSchemas:  metadataSchemas,
 	}
 }
 
@@ -42,6 +45,7 @@ type CreateLandscapeRequest struct {
 	Domain      string          `json:"domain" validate:"required,max=200"`
 	Environment string          `json:"environment" validate:"required,max=20"`
 	Metadata    json.RawMessage `json:"metadata,omitempty" swaggertype:"object"`
+	CreatedBy   string          `json:"-"` // derived from bearer token
 }
 
 // UpdateLandscapeRequest represents the request to update a landscape (new model)
@@ -52,6 +56,9 @@ type UpdateLandscapeRequest struct {
 	Domain      string          `json:"domain,omitempty" validate:"max=200"`
 	Environment string          `json:"environment,omitempty" validate:"max=20"`
 	Metadata    json.RawMessage `json:"metadata,omitempty" swaggertype:"object"`
+	UpdatedBy   string          `json:"-"` // derived from bearer token
+	// ExpectedVersion is the version the caller last read (from If-Match); 0 updates the latest version
+	ExpectedVersion int64 `json:"-"`
 }
 
 // LandscapeResponse represents the response for landscape operations (new model)
@@ -65,7 +72,10 @@ type LandscapeResponse struct {
 	Environment string          `json:"environment"`
 	Metadata    json.RawMessage `json:"metadata,omitempty" swaggertype:"object"`
 	CreatedAt   string          `json:"created_at"`
+	CreatedBy   string          `json:"created_by"`
 	UpdatedAt   string          `json:"updated_at"`
+	UpdatedBy   string          `json:"updated_by"`
+	Version     int64           `json:"version"`
 }
 
 // LandscapeMinimalResponse represents a trimmed landscape projection for list endpoints
@@ -111,7 +121,7 @@ type LandscapeListResponse struct {
 func (s *LandscapeService) CreateLandscape(req *CreateLandscapeRequest) (*LandscapeResponse, error) {
 	// Validate request
 	if err := s.validator.Struct(req); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
+		return nil, apperrors.NewValidationError("", err.Error())
 	}
 
 	// Check if landscape with same name exists (global scope in new model)
@@ -122,6 +132,9 @@ func (s *LandscapeService) CreateLandscape(req *CreateLandscapeRequest) (*Landsc
 	if existingByName != nil {
 		return nil, apperrors.ErrLandscapeExists
 	}
+	if err := s.validateMetadata(req.ProjectID, req.Metadata); err != nil {
+		return nil, err
+	}
 
 	// Create landscape (new model)
 	landscape := &models.Landscape{
@@ -135,6 +148,8 @@ func (s *LandscapeService) CreateLandscape(req *CreateLandscapeRequest) (*Landsc
 		Domain:      req.Domain,
 		Environment: req.Environment,
 	}
+	landscape.CreatedBy = req.CreatedBy
+	landscape.UpdatedBy = req.CreatedBy
 
 	if err := s.repo.Create(landscape); err != nil {
 		return nil, fmt.Errorf("failed to create landscape: %w", err)
@@ -495,14 +510,60 @@ func enrichLandscapeMetadata(projectName string, domain string, raw json.RawMess
 	return enr
 }
 
-// ListByQuery searches landscapes with filters
-func (s *LandscapeService) ListByQuery(q string, domains []string, environments []string, limit int, offset int) (*LandscapeListResponse, error) {
-	// Convert limit/offset to page/pageSize
-	page := (offset / limit) + 1
-	pageSize := limit
+// LandscapeQuery filters ListByQuery; empty fields match every landscape
+type LandscapeQuery struct {
+	Q             string   // matched against name, title and description
+	Domains       []string // any of
+	Environments  []string // any of
+	ProjectName   string
+	CentralRegion *bool
+	Extension     *bool
+}
+
+// ListByQuery retrieves a page of the landscapes matching query, ordered by name
+func (s *LandscapeService) ListByQuery(query *LandscapeQuery, page, pageSize int) (*LandscapeListResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	filter := repository.LandscapeFilter{
+		Query:         query.Q,
+		Domains:       query.Domains,
+		Environments:  query.Environments,
+		CentralRegion: query.CentralRegion,
+		Extension:     query.Extension,
+	}
+	if query.ProjectName != "" {
+		project, err := s.projectRepo.GetByName(query.ProjectName)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, apperrors.ErrProjectNotFound
+			}
+			return nil, fmt.Errorf("failed to resolve project by name: %w", err)
+		}
+		filter.ProjectID = &project.ID
+	}
 
-	// For now, use basic search (filters can be enhanced later)
-	return s.Search(uuid.Nil, q, page, pageSize)
+	offset := (page - 1) * pageSize
+	landscapes, total, err := s.repo.GetFiltered(filter, pageSize, offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list landscapes: %w", err)
+	}
+
+	responses := make([]LandscapeResponse, len(landscapes))
+	for i, landscape := range landscapes {
+		responses[i] = *s.toResponse(&landscape)
+	}
+
+	return &LandscapeListResponse{
+		Landscapes: responses,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+	}, nil
 }
 
 // Search searches landscapes by name, title, or description (org ignored)
@@ -537,41 +598,71 @@ func (s *LandscapeService) Search(_ uuid.UUID, query string, page, pageSize int)
 func (s *LandscapeService) UpdateLandscape(id uuid.UUID, req *UpdateLandscapeRequest) (*LandscapeResponse, error) {
 	// Validate request
 	if err := s.validator.Struct(req); err != nil {
-		return nil, fmt.Errorf("validation failed: %w", err)
-	}
-
-	// Get existing landscape
-	landscape, err := s.repo.GetByID(id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, apperrors.ErrLandscapeNotFound
+		return nil, apperrors.NewValidationError("", err.Error())
+	}
+
+	var landscape *models.Landscape
+	err := retryOnConflict(req.ExpectedVersion, func() error {
+		// Get existing landscape
+		var err error
+		landscape, err = s.repo.GetByID(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperrors.ErrLandscapeNotFound
+			}
+			return fmt.Errorf("failed to get landscape: %w", err)
+		}
+		if err := checkVersion(req.ExpectedVersion, landscape.Version); err != nil {
+			return err
 		}
-		return nil, fmt.Errorf("failed to get landscape: %w", err)
-	}
 
-	// Update fields aligned with new model
-	landscape.Title = req.Title
-	landscape.Description = req.Description
-	if req.ProjectID != nil {
-		landscape.ProjectID = *req.ProjectID
-	}
-	if req.Domain != "" {
-		landscape.Domain = req.Domain
-	}
-	if req.Environment != "" {
-		landscape.Environment = req.Environment
-	}
-	if req.Metadata != nil {
-		landscape.Metadata = req.Metadata
-	}
+		// Update fields aligned with new model
+		landscape.Title = req.Title
+		landscape.Description = req.Description
+		if req.ProjectID != nil {
+			landscape.ProjectID = *req.ProjectID
+		}
+		if req.Domain != "" {
+			landscape.Domain = req.Domain
+		}
+		if req.Environment != "" {
+			landscape.Environment = req.Environment
+		}
+		if req.Metadata != nil {
+			landscape.Metadata = req.Metadata
+		}
+		landscape.UpdatedBy = req.UpdatedBy
+		if err := s.validateMetadata(landscape.ProjectID, landscape.Metadata); err != nil {
+			return err
+		}
 
-	if err := s.repo.Update(landscape); err != nil {
-		return nil, fmt.Errorf("failed to update landscape: %w", err)
+		if err := s.repo.Update(landscape); err != nil {
+			if errors.Is(err, repository.ErrVersionConflict) {
+				return err
+			}
+			return fmt.Errorf("failed to update landscape: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return s.toResponse(landscape), nil
 }
 
+// validateMetadata checks landscape metadata against the schemas of landscapes and of the project
+func (s *LandscapeService) validateMetadata(projectID uuid.UUID, metadata json.RawMessage) error {
+	project, err := s.projectRepo.GetByID(projectID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrProjectNotFound
+		}
+		return fmt.Errorf("failed to get project: %w", err)
+	}
+	return s.metadataSchemas.Validate(metaschema.EntityLandscape, project.Name, metadata)
+}
+
 // DeleteLandscape deletes a landscape
 func (s *LandscapeService) DeleteLandscape(id uuid.UUID) error {
 	// Check if landscape exists
@@ -672,6 +763,9 @@ func (s *LandscapeService) toResponse(landscape *models.Landscape) *LandscapeRes
 		Environment: landscape.Environment,
 		Metadata:    landscape.Metadata,
 		CreatedAt:   landscape.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		CreatedBy:   landscape.CreatedBy,
 		UpdatedAt:   landscape.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedBy:   landscape.UpdatedBy,
+		Version:     landscape.Version,
 	}
 }
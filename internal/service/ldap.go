@@ -2,10 +2,13 @@ package service
 
 import (
 	"crypto/tls"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
 	"developer-portal-backend/internal/config"
+	apperrors "developer-portal-backend/internal/errors"
 
 	"github.com/go-ldap/ldap/v3"
 )
@@ -45,28 +48,14 @@ func NewLDAPService(cfg *config.Config) *LDAPService {
 
 // SearchUsersByCN searches users by common name (cn prefix match)
 func (s *LDAPService) SearchUsersByCN(cn string) ([]LDAPUser, error) {
-	addr := s.cfg.LDAPHost + ":" + s.cfg.LDAPPort
-
-	// Establish TLS connection to LDAP server
-	l, err := dialLDAP("tcp", addr, &tls.Config{InsecureSkipVerify: s.cfg.LDAPInsecureSkipVerify})
+	l, err := s.connect()
 	if err != nil {
 		return nil, err
 	}
 	defer l.Close()
 
-	// Set timeout
-	if s.cfg.LDAPTimeoutSec > 0 {
-		l.SetTimeout(time.Duration(s.cfg.LDAPTimeoutSec) * time.Second)
-	}
-
-	// Bind with configured credentials
-	if err := l.Bind(s.cfg.LDAPBindDN, s.cfg.LDAPBindPW); err != nil {
-		return nil, err
-	}
-
 	// Build search request
 	filter := "(cn=" + ldap.EscapeFilter(cn) + "*)"
-	attrs := []string{"displayName", "mobile", "sn", "name", "mail", "givenName"}
 	// Adjust base DN prefix based on first letter of search string (case-insensitive)
 	baseDN := s.cfg.LDAPBaseDN
 	if len(cn) > 0 {
@@ -80,20 +69,8 @@ func (s *LDAPService) SearchUsersByCN(cn string) ([]LDAPUser, error) {
 		}
 	}
 
-	req := ldap.NewSearchRequest(
-		baseDN,
-		ldap.ScopeWholeSubtree,
-		ldap.NeverDerefAliases,
-		0,
-		s.cfg.LDAPTimeoutSec,
-		false,
-		filter,
-		attrs,
-		nil,
-	)
-
 	// Execute search
-	res, err := l.Search(req)
+	res, err := l.Search(s.searchRequest(baseDN, filter))
 	if err != nil {
 		return nil, err
 	}
@@ -101,17 +78,190 @@ func (s *LDAPService) SearchUsersByCN(cn string) ([]LDAPUser, error) {
 	// Map results
 	out := make([]LDAPUser, 0, len(res.Entries))
 	for _, e := range res.Entries {
-		get := func(a string) string { return e.GetAttributeValue(a) }
-		out = append(out, LDAPUser{
-			DN:          e.DN,
-			DisplayName: get("displayName"),
-			Mobile:      get("mobile"),
-			SN:          get("sn"),
-			Name:        get("name"),
-			Mail:        get("mail"),
-			GivenName:   get("givenName"),
-		})
+		out = append(out, toLDAPUser(e))
 	}
 
 	return out, nil
 }
+
+// ldapLookupBatchSize is the number of user IDs looked up by one LDAP search
+const ldapLookupBatchSize = 50
+
+// LookupUsers looks up users by their user ID (the LDAP name attribute), in batches over one
+// connection. Users missing from the directory are missing from the result.
+func (s *LDAPService) LookupUsers(userIDs []string) (map[string]LDAPUser, error) {
+	out := make(map[string]LDAPUser, len(userIDs))
+	if len(userIDs) == 0 {
+		return out, nil
+	}
+
+	l, err := s.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer l.Close()
+
+	for start := 0; start < len(userIDs); start += ldapLookupBatchSize {
+		batch := userIDs[start:min(start+ldapLookupBatchSize, len(userIDs))]
+
+		var filter strings.Builder
+		filter.WriteString("(|")
+		for _, id := range batch {
+			filter.WriteString("(name=" + ldap.EscapeFilter(id) + ")")
+		}
+		filter.WriteString(")")
+
+		res, err := l.Search(s.searchRequest(s.cfg.LDAPBaseDN, filter.String()))
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range res.Entries {
+			user := toLDAPUser(e)
+			out[strings.ToUpper(user.Name)] = user
+		}
+	}
+	return out, nil
+}
+
+// LookupDNs looks up users by their distinguished name over one connection. Results are keyed by
+// the lower-case DN; users that do not exist are missing from the result.
+func (s *LDAPService) LookupDNs(dns []string) (map[string]LDAPUser, error) {
+	out := make(map[string]LDAPUser, len(dns))
+	if len(dns) == 0 {
+		return out, nil
+	}
+
+	l, err := s.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer l.Close()
+
+	for _, dn := range dns {
+		res, err := l.Search(s.newSearchRequest(dn, ldap.ScopeBaseObject, "(objectClass=*)", ldapUserAttributes))
+		if err != nil {
+			if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+				continue
+			}
+			return nil, err
+		}
+		for _, e := range res.Entries {
+			out[strings.ToLower(dn)] = toLDAPUser(e)
+		}
+	}
+	return out, nil
+}
+
+// ExpandDistributionList returns the users in the distribution list (LDAP group) with the given
+// mail address, following the member attribute through nested groups. Members that no longer
+// exist are skipped. It returns apperrors.ErrDistributionListNotFound if there is no group with
+// that address.
+func (s *LDAPService) ExpandDistributionList(mail string) ([]LDAPUser, error) {
+	l, err := s.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer l.Close()
+
+	filter := "(&(objectClass=group)(mail=" + ldap.EscapeFilter(mail) + "))"
+	res, err := l.Search(s.newSearchRequest(s.cfg.LDAPBaseDN, ldap.ScopeWholeSubtree, filter, []string{"member"}))
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Entries) == 0 {
+		return nil, apperrors.ErrDistributionListNotFound
+	}
+
+	// Members are users or nested groups
+	memberAttributes := append(slices.Clone(ldapUserAttributes), "objectClass", "member")
+	users := []LDAPUser{}
+	seen := map[string]bool{strings.ToLower(res.Entries[0].DN): true}
+	queue := res.Entries[0].GetAttributeValues("member")
+	for len(queue) > 0 {
+		dn := queue[0]
+		queue = queue[1:]
+		if seen[strings.ToLower(dn)] {
+			continue
+		}
+		seen[strings.ToLower(dn)] = true
+
+		res, err := l.Search(s.newSearchRequest(dn, ldap.ScopeBaseObject, "(objectClass=*)", memberAttributes))
+		if err != nil {
+			if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+				continue
+			}
+			return nil, err
+		}
+		for _, e := range res.Entries {
+			if slices.Contains(e.GetAttributeValues("objectClass"), "group") {
+				queue = append(queue, e.GetAttributeValues("member")...)
+				continue
+			}
+			if user := toLDAPUser(e); user.Name != "" {
+				users = append(users, user)
+			}
+		}
+	}
+
+	sort.Slice(users, func(i, j int) bool { return users[i].Name < users[j].Name })
+	return users, nil
+}
+
+// connect opens a TLS connection to the LDAP server and binds with the configured credentials
+func (s *LDAPService) connect() (ldapClient, error) {
+	addr := s.cfg.LDAPHost + ":" + s.cfg.LDAPPort
+
+	// Establish TLS connection to LDAP server
+	l, err := dialLDAP("tcp", addr, &tls.Config{InsecureSkipVerify: s.cfg.LDAPInsecureSkipVerify})
+	if err != nil {
+		return nil, err
+	}
+
+	// Set timeout
+	if s.cfg.LDAPTimeoutSec > 0 {
+		l.SetTimeout(time.Duration(s.cfg.LDAPTimeoutSec) * time.Second)
+	}
+
+	// Bind with configured credentials
+	if err := l.Bind(s.cfg.LDAPBindDN, s.cfg.LDAPBindPW); err != nil {
+		l.Close()
+		return nil, err
+	}
+	return l, nil
+}
+
+// ldapUserAttributes are the attributes mapped to LDAPUser
+var ldapUserAttributes = []string{"displayName", "mobile", "sn", "name", "mail", "givenName"}
+
+// searchRequest builds a subtree search for the user attributes of LDAPUser
+func (s *LDAPService) searchRequest(baseDN, filter string) *ldap.SearchRequest {
+	return s.newSearchRequest(baseDN, ldap.ScopeWholeSubtree, filter, ldapUserAttributes)
+}
+
+func (s *LDAPService) newSearchRequest(baseDN string, scope int, filter string, attributes []string) *ldap.SearchRequest {
+	return ldap.NewSearchRequest(
+		baseDN,
+		scope,
+		ldap.NeverDerefAliases,
+		0,
+		s.cfg.LDAPTimeoutSec,
+		false,
+		filter,
+		attributes,
+		nil,
+	)
+}
+
+// toLDAPUser maps the attributes of an LDAP entry
+func toLDAPUser(e *ldap.Entry) LDAPUser {
+	get := func(a string) string { return e.GetAttributeValue(a) }
+	return LDAPUser{
+		DN:          e.DN,
+		DisplayName: get("displayName"),
+		Mobile:      get("mobile"),
+		SN:          get("sn"),
+		Name:        get("name"),
+		Mail:        get("mail"),
+		GivenName:   get("givenName"),
+	}
+}
@@ -3,10 +3,12 @@ package service
 import (
 	"crypto/tls"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"developer-portal-backend/internal/config"
+	apperrors "developer-portal-backend/internal/errors"
 
 	"github.com/go-ldap/ldap/v3"
 	"github.com/stretchr/testify/assert"
@@ -18,6 +20,8 @@ type fakeLDAPClient struct {
 	searchErr         error
 	searchRes         *ldap.SearchResult
 	receivedSearchReq *ldap.SearchRequest
+	// search, if set, answers every search
+	search func(*ldap.SearchRequest) (*ldap.SearchResult, error)
 
 	setTimeoutCalled bool
 	timeoutValue     time.Duration
@@ -31,6 +35,9 @@ func (f *fakeLDAPClient) Bind(username, password string) error {
 
 func (f *fakeLDAPClient) Search(searchRequest *ldap.SearchRequest) (*ldap.SearchResult, error) {
 	f.receivedSearchReq = searchRequest
+	if f.search != nil {
+		return f.search(searchRequest)
+	}
 	if f.searchErr != nil {
 		return nil, f.searchErr
 	}
@@ -202,3 +209,88 @@ func TestLDAP_SearchUsersByCN_TimeoutZero_DoesNotSet(t *testing.T) {
 
 	assert.False(t, fc.setTimeoutCalled, "SetTimeout should not be called when timeout is 0")
 }
+
+func TestLDAP_LookupUsers_Batches(t *testing.T) {
+	orig := dialLDAP
+	defer func() { dialLDAP = orig }()
+
+	ids := make([]string, 120)
+	entries := map[string]*ldap.Entry{}
+	for i := range ids {
+		ids[i] = fmt.Sprintf("I%06d", i)
+		entries[ids[i]] = directoryEntry(ids[i], "John", "Doe", "", "")
+	}
+	delete(entries, "I000007")
+	fc := fakeDirectory(entries)
+	var searches int
+	search := fc.search
+	fc.search = func(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+		searches++
+		assert.Equal(t, "DC=example,DC=com", req.BaseDN)
+		return search(req)
+	}
+	dialLDAP = func(network, addr string, cfg *tls.Config) (ldapClient, error) {
+		return fc, nil
+	}
+
+	users, err := NewLDAPService(makeConfig()).LookupUsers(ids)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, searches)
+	assert.Len(t, users, 119)
+	assert.Equal(t, "John", users["I000042"].GivenName)
+	assert.NotContains(t, users, "I000007")
+	assert.True(t, fc.closed)
+}
+
+func TestLDAP_LookupUsers_SearchError(t *testing.T) {
+	orig := dialLDAP
+	defer func() { dialLDAP = orig }()
+
+	fc := &fakeLDAPClient{searchErr: errors.New("size limit exceeded")}
+	dialLDAP = func(network, addr string, cfg *tls.Config) (ldapClient, error) {
+		return fc, nil
+	}
+
+	_, err := NewLDAPService(makeConfig()).LookupUsers([]string{"I123456"})
+	assert.ErrorContains(t, err, "size limit exceeded")
+	assert.True(t, fc.closed)
+}
+
+func TestLDAP_ExpandDistributionList(t *testing.T) {
+	orig := dialLDAP
+	defer func() { dialLDAP = orig }()
+
+	// The nested list refers back to the outer one, and one member no longer exists
+	fc := fakeDistributionLists(
+		distributionList("CN=DL_TEAM,OU=DL,DC=example,DC=com", "DL_TEAM@example.com",
+			"CN=I000002,OU=I,DC=example,DC=com", "CN=DL_NESTED,OU=DL,DC=example,DC=com", "CN=I999999,OU=I,DC=example,DC=com"),
+		distributionList("CN=DL_NESTED,OU=DL,DC=example,DC=com", "DL_NESTED@example.com",
+			"CN=I000001,OU=I,DC=example,DC=com", "CN=DL_TEAM,OU=DL,DC=example,DC=com", "CN=I000002,OU=I,DC=example,DC=com"),
+		directoryEntry("I000001", "Jane", "Doe", "jane.doe@example.com", ""),
+		directoryEntry("I000002", "John", "Doe", "john.doe@example.com", ""),
+	)
+	dialLDAP = func(network, addr string, cfg *tls.Config) (ldapClient, error) {
+		return fc, nil
+	}
+
+	users, err := NewLDAPService(makeConfig()).ExpandDistributionList("DL_TEAM@example.com")
+	assert.NoError(t, err)
+	if assert.Len(t, users, 2) {
+		assert.Equal(t, "I000001", users[0].Name)
+		assert.Equal(t, "jane.doe@example.com", users[0].Mail)
+		assert.Equal(t, "I000002", users[1].Name)
+	}
+	assert.True(t, fc.closed)
+}
+
+func TestLDAP_ExpandDistributionList_NotFound(t *testing.T) {
+	orig := dialLDAP
+	defer func() { dialLDAP = orig }()
+
+	dialLDAP = func(network, addr string, cfg *tls.Config) (ldapClient, error) {
+		return fakeDistributionLists(), nil
+	}
+
+	_, err := NewLDAPService(makeConfig()).ExpandDistributionList("DL_UNKNOWN@example.com")
+	assert.ErrorIs(t, err, apperrors.ErrDistributionListNotFound)
+}
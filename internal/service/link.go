@@ -45,6 +45,7 @@ type LinkResponse struct {
 	CategoryID  string   `json:"category_id"`
 	Tags        []string `json:"tags"`
 	Favorite    bool     `json:"favorite,omitempty"`
+	Broken      bool     `json:"broken"` // found broken by the latest dead-link check
 }
 
 // CreateLinkRequest represents the payload for creating a link
@@ -231,6 +232,7 @@ func toLinkResponse(l *models.Link) LinkResponse {
 		URL:         l.URL,
 		CategoryID:  l.CategoryID.String(),
 		Tags:        tags,
+		Broken:      l.Broken,
 	}
 }
 
@@ -0,0 +1,559 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/logger"
+	"developer-portal-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// linkCheckUserAgent identifies the dead-link checker to the servers it visits
+const linkCheckUserAgent = "developer-portal-link-checker"
+
+// maxLinkCheckRedirects is the number of redirects followed before a link counts as broken
+const maxLinkCheckRedirects = 10
+
+// maxLinkCheckErrorLength is the size of models.Link.CheckError
+const maxLinkCheckErrorLength = 200
+
+// LinkCheckOptions configures the dead-link checker
+type LinkCheckOptions struct {
+	// Concurrency is the number of links checked at the same time
+	Concurrency int
+	// HostInterval is the minimum time between two requests to the same host
+	HostInterval time.Duration
+	// Timeout is how long a request may take, redirects included
+	Timeout time.Duration
+	// AllowPrivateHosts lets the checker connect to loopback, private and link-local addresses,
+	// which it refuses otherwise; for tests against local servers
+	AllowPrivateHosts bool
+}
+
+// LinkCheckService checks that the URLs of links still work. Each link gets a HEAD request, and a
+// GET if the HEAD fails, as some servers reject HEAD; redirects are followed. Any user can add
+// links, so the checker only connects to public addresses. The outcome is saved with the link, and
+// the links found broken are reported by owner and by category.
+type LinkCheckService struct {
+	linkRepo     repository.LinkRepositoryInterface
+	userRepo     repository.UserRepositoryInterface
+	teamRepo     repository.TeamRepositoryInterface
+	categoryRepo repository.CategoryRepositoryInterface
+	client       *http.Client
+	opts         LinkCheckOptions
+	now          func() time.Time
+
+	// running is held while a check runs
+	running sync.Mutex
+	mu      sync.RWMutex
+	run     *LinkCheckRun
+}
+
+// Ensure LinkCheckService implements LinkCheckServiceInterface
+var _ LinkCheckServiceInterface = (*LinkCheckService)(nil)
+
+// NewLinkCheckService creates a new LinkCheckService
+func NewLinkCheckService(
+	linkRepo repository.LinkRepositoryInterface,
+	userRepo repository.UserRepositoryInterface,
+	teamRepo repository.TeamRepositoryInterface,
+	categoryRepo repository.CategoryRepositoryInterface,
+	opts LinkCheckOptions,
+) *LinkCheckService {
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 1
+	}
+	dialer := &net.Dialer{Timeout: opts.Timeout}
+	if !opts.AllowPrivateHosts {
+		dialer.Control = refusePrivateAddress
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would be dialed instead of the host of the link
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &LinkCheckService{
+		linkRepo:     linkRepo,
+		userRepo:     userRepo,
+		teamRepo:     teamRepo,
+		categoryRepo: categoryRepo,
+		client: &http.Client{
+			Timeout:   opts.Timeout,
+			Transport: transport,
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				if len(via) >= maxLinkCheckRedirects {
+					return fmt.Errorf("stopped after %d redirects", maxLinkCheckRedirects)
+				}
+				return nil
+			},
+		},
+		opts: opts,
+		now:  time.Now,
+	}
+}
+
+// LinkCheckRun describes the outcome of checking all links
+type LinkCheckRun struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Checked    int       `json:"checked"`
+	Broken     int       `json:"broken"`
+	// Failed are the links whose outcome could not be saved
+	Failed []LinkCheckFailure `json:"failed"`
+	// Error is set if the check failed as a whole, e.g. because the links could not be loaded
+	Error string `json:"error,omitempty"`
+}
+
+// LinkCheckFailure is a link whose outcome could not be saved
+type LinkCheckFailure struct {
+	LinkID uuid.UUID `json:"link_id"`
+	Error  string    `json:"error"`
+}
+
+// BrokenLink is a link found broken by its latest check. Every user sees the report, so it leaves
+// out the error and response time, which tell about the network of the checker.
+type BrokenLink struct {
+	ID        uuid.UUID  `json:"id"`
+	Title     string     `json:"title"`
+	URL       string     `json:"url"`
+	Status    int        `json:"status"` // 0 if there was no response
+	CheckedAt *time.Time `json:"checked_at"`
+}
+
+// BrokenLinkOwner lists the broken links of a user or team
+type BrokenLinkOwner struct {
+	OwnerID   uuid.UUID    `json:"owner_id"`
+	OwnerType string       `json:"owner_type,omitempty"` // user or team; empty if the owner no longer exists
+	OwnerName string       `json:"owner_name,omitempty"` // user ID or team name
+	Links     []BrokenLink `json:"links"`
+}
+
+// BrokenLinkCategory lists the broken links of a category
+type BrokenLinkCategory struct {
+	CategoryID   uuid.UUID    `json:"category_id"`
+	CategoryName string       `json:"category_name,omitempty"`
+	Links        []BrokenLink `json:"links"`
+}
+
+// BrokenLinkReport lists the broken links by owner and by category
+type BrokenLinkReport struct {
+	Total      int                  `json:"total"`
+	ByOwner    []BrokenLinkOwner    `json:"by_owner"`
+	ByCategory []BrokenLinkCategory `json:"by_category"`
+}
+
+// CheckLinks checks all links and keeps the outcome for LastRun. It returns
+// apperrors.ErrLinkCheckRunning if a check is already running.
+func (s *LinkCheckService) CheckLinks(ctx context.Context) (*LinkCheckRun, error) {
+	if !s.running.TryLock() {
+		return nil, apperrors.ErrLinkCheckRunning
+	}
+	defer s.running.Unlock()
+	return s.checkLinks(ctx)
+}
+
+// StartLinkCheck checks all links in the background. It returns apperrors.ErrLinkCheckRunning if a
+// check is already running.
+func (s *LinkCheckService) StartLinkCheck() error {
+	if !s.running.TryLock() {
+		return apperrors.ErrLinkCheckRunning
+	}
+	go func() {
+		defer s.running.Unlock()
+		ctx := context.Background()
+		run, err := s.checkLinks(ctx)
+		logLinkCheck(ctx, run, err)
+	}()
+	return nil
+}
+
+// LastRun returns the outcome of the latest completed check of this instance
+func (s *LinkCheckService) LastRun() (*LinkCheckRun, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.run == nil {
+		return nil, apperrors.ErrLinkCheckRunNotFound
+	}
+	return s.run, nil
+}
+
+// StartLinkChecker checks all links every interval until ctx is done
+func (s *LinkCheckService) StartLinkChecker(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run, err := s.CheckLinks(ctx)
+				logLinkCheck(ctx, run, err)
+			}
+		}
+	}()
+}
+
+// logLinkCheck logs the outcome of a check
+func logLinkCheck(ctx context.Context, run *LinkCheckRun, err error) {
+	if err != nil {
+		logger.WithContext(ctx).Warnf("Link check failed: %v", err)
+		return
+	}
+	logger.WithContext(ctx).Infof("Link check checked %d links: %d broken, %d failed",
+		run.Checked, run.Broken, len(run.Failed))
+}
+
+// GetBrokenLinkReport lists the links found broken by their latest check, by owner and by category
+func (s *LinkCheckService) GetBrokenLinkReport() (*BrokenLinkReport, error) {
+	links, err := s.linkRepo.GetBroken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get broken links: %w", err)
+	}
+
+	report := &BrokenLinkReport{
+		Total:      len(links),
+		ByOwner:    []BrokenLinkOwner{},
+		ByCategory: []BrokenLinkCategory{},
+	}
+	owners := map[uuid.UUID]int{}
+	categories := map[uuid.UUID]int{}
+	for i := range links {
+		link := &links[i]
+		broken := BrokenLink{
+			ID:        link.ID,
+			Title:     link.Title,
+			URL:       link.URL,
+			Status:    link.CheckStatus,
+			CheckedAt: link.CheckedAt,
+		}
+
+		o, ok := owners[link.Owner]
+		if !ok {
+			owner, err := s.owner(link.Owner)
+			if err != nil {
+				return nil, err
+			}
+			report.ByOwner = append(report.ByOwner, *owner)
+			o = len(report.ByOwner) - 1
+			owners[link.Owner] = o
+		}
+		report.ByOwner[o].Links = append(report.ByOwner[o].Links, broken)
+
+		c, ok := categories[link.CategoryID]
+		if !ok {
+			category, err := s.category(link.CategoryID)
+			if err != nil {
+				return nil, err
+			}
+			report.ByCategory = append(report.ByCategory, *category)
+			c = len(report.ByCategory) - 1
+			categories[link.CategoryID] = c
+		}
+		report.ByCategory[c].Links = append(report.ByCategory[c].Links, broken)
+	}
+
+	sort.SliceStable(report.ByOwner, func(i, j int) bool {
+		return report.ByOwner[i].OwnerName < report.ByOwner[j].OwnerName
+	})
+	sort.SliceStable(report.ByCategory, func(i, j int) bool {
+		return report.ByCategory[i].CategoryName < report.ByCategory[j].CategoryName
+	})
+	return report, nil
+}
+
+// owner resolves the user or team owning links
+func (s *LinkCheckService) owner(id uuid.UUID) (*BrokenLinkOwner, error) {
+	owner := &BrokenLinkOwner{OwnerID: id, Links: []BrokenLink{}}
+	user, err := s.userRepo.GetByID(id)
+	if err == nil {
+		owner.OwnerType, owner.OwnerName = "user", user.UserID
+		return owner, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	team, err := s.teamRepo.GetByID(id)
+	if err == nil {
+		owner.OwnerType, owner.OwnerName = "team", team.Name
+		return owner, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+	return owner, nil
+}
+
+// category resolves the category of links
+func (s *LinkCheckService) category(id uuid.UUID) (*BrokenLinkCategory, error) {
+	category := &BrokenLinkCategory{CategoryID: id, Links: []BrokenLink{}}
+	found, err := s.categoryRepo.GetByID(id)
+	if err == nil {
+		category.CategoryName = found.Name
+		return category, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	return category, nil
+}
+
+// checkLinks checks all links and keeps the outcome for LastRun. The caller holds running.
+func (s *LinkCheckService) checkLinks(ctx context.Context) (*LinkCheckRun, error) {
+	run := &LinkCheckRun{
+		StartedAt: s.now(),
+		Failed:    []LinkCheckFailure{},
+	}
+	err := s.checkAll(ctx, run)
+	if err != nil {
+		run.Error = err.Error()
+	}
+	run.FinishedAt = s.now()
+
+	s.mu.Lock()
+	s.run = run
+	s.mu.Unlock()
+	return run, err
+}
+
+// checkAll checks the links with opts.Concurrency workers. Links on different hosts are
+// interleaved, so that the workers rarely wait for the same host.
+func (s *LinkCheckService) checkAll(ctx context.Context, run *LinkCheckRun) error {
+	links, err := s.linkRepo.GetAll()
+	if err != nil {
+		return fmt.Errorf("failed to get links: %w", err)
+	}
+
+	throttle := newHostThrottle(s.opts.HostInterval)
+	jobs := make(chan *models.Link)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < s.opts.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for link := range jobs {
+				if !s.checkLink(ctx, throttle, link) {
+					continue
+				}
+				err := s.linkRepo.UpdateCheck(link)
+
+				mu.Lock()
+				run.Checked++
+				if link.Broken {
+					run.Broken++
+				}
+				if err != nil {
+					run.Failed = append(run.Failed, LinkCheckFailure{LinkID: link.ID, Error: err.Error()})
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for _, link := range interleaveHosts(links) {
+		select {
+		case jobs <- link:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return ctx.Err()
+}
+
+// checkLink requests the URL of the link and sets the outcome on it. It returns false if ctx was
+// done before the outcome was known.
+func (s *LinkCheckService) checkLink(ctx context.Context, throttle *hostThrottle, link *models.Link) bool {
+	status, elapsed, err := s.probe(ctx, throttle, link.URL)
+	if ctx.Err() != nil {
+		return false
+	}
+
+	checkedAt := s.now()
+	link.CheckedAt = &checkedAt
+	link.CheckStatus = status
+	link.ResponseTimeMs = elapsed.Milliseconds()
+	link.CheckError = ""
+	if err != nil {
+		link.CheckError = linkCheckError(err)
+	}
+	link.Broken = linkBroken(status, err)
+	return true
+}
+
+// probe requests the URL with HEAD and, if that fails, with GET. It returns the status and
+// response time of the last request.
+func (s *LinkCheckService) probe(ctx context.Context, throttle *hostThrottle, rawURL string) (int, time.Duration, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return 0, 0, errors.New("not an http or https URL")
+	}
+
+	status, elapsed, err := s.request(ctx, throttle, http.MethodHead, u)
+	if err == nil && status < 400 {
+		return status, elapsed, nil
+	}
+	return s.request(ctx, throttle, http.MethodGet, u)
+}
+
+// request sends a request once the host may be visited again and returns the status of the
+// final response. The body is not read.
+func (s *LinkCheckService) request(ctx context.Context, throttle *hostThrottle, method string, u *url.URL) (int, time.Duration, error) {
+	if err := throttle.wait(ctx, strings.ToLower(u.Hostname())); err != nil {
+		return 0, 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	req.Header.Set("User-Agent", linkCheckUserAgent)
+
+	start := time.Now()
+	resp, err := s.client.Do(req)
+	elapsed := time.Since(start)
+	if err != nil {
+		return 0, elapsed, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, elapsed, nil
+}
+
+// nonPublicPrefixes are refused on top of the ranges the net/netip package classifies: "this
+// network" and the shared address space of carrier-grade NAT, which many cluster networks use
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+// refusePrivateAddress is the dialer control of the checker. It runs once the host name is
+// resolved, for redirects too, and refuses loopback, private, shared, link-local, unspecified
+// and multicast addresses, so that links cannot reach internal services or cloud metadata.
+func refusePrivateAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("address %s is not public", host)
+	}
+	ip = ip.Unmap()
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsUnspecified() || ip.IsMulticast() ||
+		slices.ContainsFunc(nonPublicPrefixes, func(prefix netip.Prefix) bool { return prefix.Contains(ip) }) {
+		return fmt.Errorf("address %s is not public", host)
+	}
+	return nil
+}
+
+// linkBroken tells whether a check found a link dead. Servers asking for a login or throttling
+// the checker answer 401, 403 or 429; their links work for people.
+func linkBroken(status int, err error) bool {
+	if err != nil {
+		return true
+	}
+	switch status {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusTooManyRequests:
+		return false
+	}
+	return status >= 400
+}
+
+// linkCheckError describes why a request failed, without the URL, which the link has anyway
+func linkCheckError(err error) string {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	message := err.Error()
+	if len(message) > maxLinkCheckErrorLength {
+		message = message[:maxLinkCheckErrorLength]
+	}
+	return message
+}
+
+// interleaveHosts orders links so that consecutive ones are on different hosts where possible,
+// keeping the order of the links of each host
+func interleaveHosts(links []models.Link) []*models.Link {
+	byHost := map[string][]*models.Link{}
+	hosts := []string{}
+	for i := range links {
+		host := ""
+		if u, err := url.Parse(links[i].URL); err == nil {
+			host = strings.ToLower(u.Hostname())
+		}
+		if _, ok := byHost[host]; !ok {
+			hosts = append(hosts, host)
+		}
+		byHost[host] = append(byHost[host], &links[i])
+	}
+
+	ordered := make([]*models.Link, 0, len(links))
+	for len(ordered) < len(links) {
+		for _, host := range hosts {
+			if queue := byHost[host]; len(queue) > 0 {
+				ordered = append(ordered, queue[0])
+				byHost[host] = queue[1:]
+			}
+		}
+	}
+	return ordered
+}
+
+// hostThrottle spaces the requests to each host at least interval apart
+type hostThrottle struct {
+	interval time.Duration
+	mu       sync.Mutex
+	next     map[string]time.Time // earliest time of the next request to the host
+}
+
+// newHostThrottle creates a hostThrottle
+func newHostThrottle(interval time.Duration) *hostThrottle {
+	return &hostThrottle{interval: interval, next: map[string]time.Time{}}
+}
+
+// wait blocks until a request to host may be sent, and reserves that slot. It returns early with
+// the error of ctx if ctx is done first.
+func (t *hostThrottle) wait(ctx context.Context, host string) error {
+	t.mu.Lock()
+	now := time.Now()
+	at := t.next[host]
+	if at.Before(now) {
+		at = now
+	}
+	t.next[host] = at.Add(t.interval)
+	t.mu.Unlock()
+
+	delay := at.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,296 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type LinkCheckServiceTestSuite struct {
+	suite.Suite
+	ctrl             *gomock.Controller
+	mockLinkRepo     *mocks.MockLinkRepositoryInterface
+	mockUserRepo     *mocks.MockUserRepositoryInterface
+	mockTeamRepo     *mocks.MockTeamRepositoryInterface
+	mockCategoryRepo *mocks.MockCategoryRepositoryInterface
+	server           *httptest.Server
+}
+
+func (suite *LinkCheckServiceTestSuite) SetupTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockLinkRepo = mocks.NewMockLinkRepositoryInterface(suite.ctrl)
+	suite.mockUserRepo = mocks.NewMockUserRepositoryInterface(suite.ctrl)
+	suite.mockTeamRepo = mocks.NewMockTeamRepositoryInterface(suite.ctrl)
+	suite.mockCategoryRepo = mocks.NewMockCategoryRepositoryInterface(suite.ctrl)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("/missing", http.NotFound)
+	mux.HandleFunc("/login", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+	mux.HandleFunc("/no-head", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	suite.server = httptest.NewServer(mux)
+}
+
+func (suite *LinkCheckServiceTestSuite) TearDownTest() {
+	suite.server.Close()
+	suite.ctrl.Finish()
+}
+
+func (suite *LinkCheckServiceTestSuite) newService(opts service.LinkCheckOptions) *service.LinkCheckService {
+	if opts.Timeout == 0 {
+		opts.Timeout = 5 * time.Second
+	}
+	// The test servers listen on loopback
+	opts.AllowPrivateHosts = true
+	return service.NewLinkCheckService(suite.mockLinkRepo, suite.mockUserRepo, suite.mockTeamRepo, suite.mockCategoryRepo, opts)
+}
+
+// expectChecks returns the links passed to UpdateCheck, keyed by URL
+func (suite *LinkCheckServiceTestSuite) expectChecks(links []models.Link) map[string]models.Link {
+	checked := map[string]models.Link{}
+	var mu sync.Mutex
+	suite.mockLinkRepo.EXPECT().GetAll().Return(links, nil)
+	suite.mockLinkRepo.EXPECT().UpdateCheck(gomock.Any()).DoAndReturn(func(link *models.Link) error {
+		mu.Lock()
+		defer mu.Unlock()
+		checked[link.URL] = *link
+		return nil
+	}).Times(len(links))
+	return checked
+}
+
+func newCheckLink(url string) models.Link {
+	return models.Link{BaseModel: models.BaseModel{ID: uuid.New()}, URL: url}
+}
+
+func (suite *LinkCheckServiceTestSuite) TestCheckLinks() {
+	url := suite.server.URL
+	checked := suite.expectChecks([]models.Link{
+		newCheckLink(url + "/ok"),
+		newCheckLink(url + "/missing"),
+		newCheckLink(url + "/login"),
+		newCheckLink(url + "/no-head"),
+		newCheckLink(url + "/moved"),
+		newCheckLink(url + "/loop"),
+		newCheckLink("ftp://example.com/file"),
+		newCheckLink("http://127.0.0.1:1/closed"),
+	})
+
+	run, err := suite.newService(service.LinkCheckOptions{Concurrency: 4}).CheckLinks(context.Background())
+	suite.Require().NoError(err)
+	suite.Equal(8, run.Checked)
+	suite.Equal(4, run.Broken)
+	suite.Empty(run.Failed)
+
+	suite.False(checked[url+"/ok"].Broken)
+	suite.Equal(http.StatusOK, checked[url+"/ok"].CheckStatus)
+	suite.NotNil(checked[url+"/ok"].CheckedAt)
+
+	suite.True(checked[url+"/missing"].Broken)
+	suite.Equal(http.StatusNotFound, checked[url+"/missing"].CheckStatus)
+
+	// Logins are not dead links
+	suite.False(checked[url+"/login"].Broken)
+	suite.Equal(http.StatusForbidden, checked[url+"/login"].CheckStatus)
+
+	// GET is tried when HEAD is rejected
+	suite.False(checked[url+"/no-head"].Broken)
+	suite.Equal(http.StatusOK, checked[url+"/no-head"].CheckStatus)
+
+	suite.False(checked[url+"/moved"].Broken)
+	suite.Equal(http.StatusOK, checked[url+"/moved"].CheckStatus)
+
+	suite.True(checked[url+"/loop"].Broken)
+	suite.Contains(checked[url+"/loop"].CheckError, "stopped after 10 redirects")
+
+	suite.True(checked["ftp://example.com/file"].Broken)
+	suite.Equal("not an http or https URL", checked["ftp://example.com/file"].CheckError)
+
+	closed := checked["http://127.0.0.1:1/closed"]
+	suite.True(closed.Broken)
+	suite.Zero(closed.CheckStatus)
+	suite.NotContains(closed.CheckError, "127.0.0.1:1/closed")
+
+	last, err := suite.newService(service.LinkCheckOptions{}).LastRun()
+	suite.Nil(last)
+	suite.ErrorIs(err, apperrors.ErrLinkCheckRunNotFound)
+}
+
+func (suite *LinkCheckServiceTestSuite) TestCheckLinks_SpacesRequestsToHost() {
+	var mu sync.Mutex
+	var times []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		times = append(times, time.Now())
+		mu.Unlock()
+	}))
+	defer server.Close()
+	suite.expectChecks([]models.Link{newCheckLink(server.URL + "/a"), newCheckLink(server.URL + "/b"), newCheckLink(server.URL + "/c")})
+
+	interval := 50 * time.Millisecond
+	_, err := suite.newService(service.LinkCheckOptions{Concurrency: 3, HostInterval: interval}).CheckLinks(context.Background())
+	suite.Require().NoError(err)
+	suite.Require().Len(times, 3)
+	for i := 1; i < len(times); i++ {
+		// Allow for the timer resolution
+		suite.GreaterOrEqual(times[i].Sub(times[i-1]), interval-5*time.Millisecond)
+	}
+}
+
+func (suite *LinkCheckServiceTestSuite) TestCheckLinks_RefusesPrivateAddresses() {
+	var hits int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+	}))
+	defer server.Close()
+	urls := []string{
+		server.URL + "/admin",
+		"http://localhost:1/",
+		"http://[::1]:1/",
+		"http://10.0.0.1/",
+		"http://169.254.169.254/latest/meta-data/",
+		"http://0.0.0.0/",
+		"http://0.1.2.3/",
+		"http://100.64.0.1/",
+		"http://[::ffff:100.127.255.254]/",
+	}
+	links := []models.Link{}
+	for _, url := range urls {
+		links = append(links, newCheckLink(url))
+	}
+	checked := suite.expectChecks(links)
+
+	checkService := service.NewLinkCheckService(suite.mockLinkRepo, suite.mockUserRepo, suite.mockTeamRepo, suite.mockCategoryRepo,
+		service.LinkCheckOptions{Concurrency: 2, Timeout: 5 * time.Second})
+	run, err := checkService.CheckLinks(context.Background())
+	suite.Require().NoError(err)
+	suite.Equal(len(urls), run.Broken)
+	for _, url := range urls {
+		suite.True(checked[url].Broken, url)
+		suite.Zero(checked[url].CheckStatus, url)
+		suite.Contains(checked[url].CheckError, "is not public", url)
+	}
+	suite.Zero(atomic.LoadInt32(&hits))
+}
+
+func (suite *LinkCheckServiceTestSuite) TestCheckLinks_Errors() {
+	suite.mockLinkRepo.EXPECT().GetAll().Return(nil, errors.New("connection refused"))
+	checkService := suite.newService(service.LinkCheckOptions{})
+
+	run, err := checkService.CheckLinks(context.Background())
+	suite.ErrorContains(err, "failed to get links: connection refused")
+	suite.Equal("failed to get links: connection refused", run.Error)
+
+	// Outcomes that cannot be saved are reported
+	ok := newCheckLink(suite.server.URL + "/ok")
+	suite.mockLinkRepo.EXPECT().GetAll().Return([]models.Link{ok}, nil)
+	suite.mockLinkRepo.EXPECT().UpdateCheck(gomock.Any()).Return(errors.New("deadlock detected"))
+	run, err = checkService.CheckLinks(context.Background())
+	suite.Require().NoError(err)
+	suite.Equal([]service.LinkCheckFailure{{LinkID: ok.ID, Error: "deadlock detected"}}, run.Failed)
+
+	last, err := checkService.LastRun()
+	suite.Require().NoError(err)
+	suite.Equal(run, last)
+}
+
+func (suite *LinkCheckServiceTestSuite) TestStartLinkCheck() {
+	release := make(chan struct{})
+	suite.mockLinkRepo.EXPECT().GetAll().DoAndReturn(func() ([]models.Link, error) {
+		<-release
+		return []models.Link{}, nil
+	})
+	checkService := suite.newService(service.LinkCheckOptions{})
+
+	suite.Require().NoError(checkService.StartLinkCheck())
+	suite.ErrorIs(checkService.StartLinkCheck(), apperrors.ErrLinkCheckRunning)
+	_, err := checkService.CheckLinks(context.Background())
+	suite.ErrorIs(err, apperrors.ErrLinkCheckRunning)
+	close(release)
+
+	suite.Eventually(func() bool {
+		run, err := checkService.LastRun()
+		return err == nil && run.Checked == 0 && run.Error == ""
+	}, time.Second, 10*time.Millisecond)
+}
+
+func (suite *LinkCheckServiceTestSuite) TestGetBrokenLinkReport() {
+	user := &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, UserID: "I000001"}
+	team := &models.Team{BaseModel: models.BaseModel{ID: uuid.New(), Name: "team-a"}}
+	goneOwner := uuid.New()
+	docs := &models.Category{BaseModel: models.BaseModel{ID: uuid.New(), Name: "docs"}}
+	tools := &models.Category{BaseModel: models.BaseModel{ID: uuid.New(), Name: "tools"}}
+
+	links := []models.Link{
+		{BaseModel: models.BaseModel{ID: uuid.New(), Title: "Wiki"}, Owner: user.ID, CategoryID: docs.ID, CheckStatus: 404},
+		{BaseModel: models.BaseModel{ID: uuid.New(), Title: "Board"}, Owner: team.ID, CategoryID: tools.ID, CheckError: "timeout"},
+		{BaseModel: models.BaseModel{ID: uuid.New(), Title: "Runbook"}, Owner: user.ID, CategoryID: tools.ID, CheckStatus: 500},
+		{BaseModel: models.BaseModel{ID: uuid.New(), Title: "Old"}, Owner: goneOwner, CategoryID: docs.ID, CheckStatus: 410},
+	}
+	suite.mockLinkRepo.EXPECT().GetBroken().Return(links, nil)
+	suite.mockUserRepo.EXPECT().GetByID(user.ID).Return(user, nil)
+	suite.mockUserRepo.EXPECT().GetByID(team.ID).Return(nil, gorm.ErrRecordNotFound)
+	suite.mockUserRepo.EXPECT().GetByID(goneOwner).Return(nil, gorm.ErrRecordNotFound)
+	suite.mockTeamRepo.EXPECT().GetByID(team.ID).Return(team, nil)
+	suite.mockTeamRepo.EXPECT().GetByID(goneOwner).Return(nil, gorm.ErrRecordNotFound)
+	suite.mockCategoryRepo.EXPECT().GetByID(docs.ID).Return(docs, nil)
+	suite.mockCategoryRepo.EXPECT().GetByID(tools.ID).Return(tools, nil)
+
+	report, err := suite.newService(service.LinkCheckOptions{}).GetBrokenLinkReport()
+	suite.Require().NoError(err)
+	suite.Equal(4, report.Total)
+
+	suite.Require().Len(report.ByOwner, 3)
+	suite.Equal(goneOwner, report.ByOwner[0].OwnerID)
+	suite.Empty(report.ByOwner[0].OwnerType)
+	suite.Equal("I000001", report.ByOwner[1].OwnerName)
+	suite.Equal("user", report.ByOwner[1].OwnerType)
+	suite.Len(report.ByOwner[1].Links, 2)
+	suite.Equal("team-a", report.ByOwner[2].OwnerName)
+	suite.Equal("team", report.ByOwner[2].OwnerType)
+	suite.Zero(report.ByOwner[2].Links[0].Status)
+
+	suite.Require().Len(report.ByCategory, 2)
+	suite.Equal("docs", report.ByCategory[0].CategoryName)
+	suite.Equal([]string{"Wiki", "Old"}, []string{report.ByCategory[0].Links[0].Title, report.ByCategory[0].Links[1].Title})
+	suite.Equal("tools", report.ByCategory[1].CategoryName)
+	suite.Len(report.ByCategory[1].Links, 2)
+}
+
+func (suite *LinkCheckServiceTestSuite) TestGetBrokenLinkReport_Error() {
+	suite.mockLinkRepo.EXPECT().GetBroken().Return(nil, errors.New("connection refused"))
+
+	_, err := suite.newService(service.LinkCheckOptions{}).GetBrokenLinkReport()
+	suite.ErrorContains(err, "failed to get broken links: connection refused")
+}
+
+func TestLinkCheckServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(LinkCheckServiceTestSuite))
+}
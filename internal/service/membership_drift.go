@@ -0,0 +1,374 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/logger"
+	"developer-portal-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// membershipDriftActor is recorded as the creator or updater of users changed by the auto-apply job
+const membershipDriftActor = "dl-sync"
+
+// MembershipDriftService compares the members of team distribution lists in LDAP with the users
+// of the teams. Applying the drift creates the users missing from the portal and moves users to
+// the team whose distribution list they are in; users missing from the distribution list, and
+// deleted users still in it, are only reported.
+type MembershipDriftService struct {
+	teamRepo repository.TeamRepositoryInterface
+	userRepo repository.UserRepositoryInterface
+	ldap     *LDAPService
+	members  *TeamMembershipService
+	now      func() time.Time
+}
+
+// Ensure MembershipDriftService implements MembershipDriftServiceInterface
+var _ MembershipDriftServiceInterface = (*MembershipDriftService)(nil)
+
+// NewMembershipDriftService creates a new MembershipDriftService
+func NewMembershipDriftService(
+	teamRepo repository.TeamRepositoryInterface,
+	userRepo repository.UserRepositoryInterface,
+	ldap *LDAPService,
+) *MembershipDriftService {
+	return &MembershipDriftService{
+		teamRepo: teamRepo,
+		userRepo: userRepo,
+		ldap:     ldap,
+		members:  NewTeamMembershipService(userRepo, teamRepo),
+		now:      time.Now,
+	}
+}
+
+// MembershipDriftReport is the membership drift of all teams
+type MembershipDriftReport struct {
+	GeneratedAt time.Time             `json:"generated_at"`
+	Teams       []TeamMembershipDrift `json:"teams"`
+	Drifted     int                   `json:"drifted"` // teams whose members differ from their distribution list
+}
+
+// TeamMembershipDrift compares the members of a team with its distribution list. The lists
+// describe the drift found; when it was applied, Created, Moved and Failed tell what changed.
+type TeamMembershipDrift struct {
+	TeamID           uuid.UUID `json:"team_id"`
+	TeamName         string    `json:"team_name"`
+	DistributionList string    `json:"distribution_list"`
+	InSync           int       `json:"in_sync"` // distribution list members that are members of the team
+	// Missing are distribution list members without a portal user
+	Missing []DriftUser `json:"missing"`
+	// Skipped are distribution list members whose portal user was deleted, e.g. offboarded or
+	// missing from LDAP for too long. They are not created again; restore them from the trash.
+	Skipped []DriftUser `json:"skipped"`
+	// Misplaced are distribution list members whose user is in another team or in none
+	Misplaced []DriftUser `json:"misplaced"`
+	// Conflicting are distribution list members who are also in the distribution list of their
+	// current team. They are never moved automatically.
+	Conflicting []DriftUser `json:"conflicting"`
+	// Unlisted are members of the team missing from the distribution list
+	Unlisted []DriftUser `json:"unlisted"`
+
+	Applied bool                   `json:"applied,omitempty"`
+	Created []string               `json:"created,omitempty"`
+	Moved   []string               `json:"moved,omitempty"`
+	Failed  []DirectorySyncFailure `json:"failed,omitempty"`
+	// Error is set in reports of all teams if the distribution list could not be expanded
+	Error string `json:"error,omitempty"`
+}
+
+// HasDrift reports whether the members of the team differ from its distribution list
+func (d *TeamMembershipDrift) HasDrift() bool {
+	return len(d.Missing)+len(d.Misplaced)+len(d.Conflicting)+len(d.Unlisted) > 0
+}
+
+// DriftUser is a user in a membership drift, with their attributes from LDAP or the portal
+type DriftUser struct {
+	UserID        string     `json:"user_id"`
+	FirstName     string     `json:"first_name"`
+	LastName      string     `json:"last_name"`
+	Email         string     `json:"email"`
+	Mobile        string     `json:"mobile,omitempty"`
+	CurrentTeamID *uuid.UUID `json:"current_team_id,omitempty"`
+}
+
+// GetTeamDrift compares the members of a team with its distribution list
+func (s *MembershipDriftService) GetTeamDrift(teamID uuid.UUID) (*TeamMembershipDrift, error) {
+	return s.teamDrift(newDriftBuilder(s), teamID, "")
+}
+
+// ApplyTeamDrift compares the members of a team with its distribution list and applies the drift
+func (s *MembershipDriftService) ApplyTeamDrift(teamID uuid.UUID, appliedBy string) (*TeamMembershipDrift, error) {
+	if strings.TrimSpace(appliedBy) == "" {
+		return nil, apperrors.NewValidationError("applied_by", "applied_by is required")
+	}
+	return s.teamDrift(newDriftBuilder(s), teamID, appliedBy)
+}
+
+// GetDriftReport compares the members of all teams with their distribution lists
+func (s *MembershipDriftService) GetDriftReport() (*MembershipDriftReport, error) {
+	return s.driftReport("")
+}
+
+// ApplyDrift compares the members of all teams with their distribution lists and applies the drift
+func (s *MembershipDriftService) ApplyDrift(appliedBy string) (*MembershipDriftReport, error) {
+	if strings.TrimSpace(appliedBy) == "" {
+		return nil, apperrors.NewValidationError("applied_by", "applied_by is required")
+	}
+	return s.driftReport(appliedBy)
+}
+
+// StartDriftAutoApply applies the membership drift of all teams every interval until ctx is done
+func (s *MembershipDriftService) StartDriftAutoApply(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				report, err := s.ApplyDrift(membershipDriftActor)
+				if err != nil {
+					logger.WithContext(ctx).Warnf("Applying team membership drift failed: %v", err)
+					continue
+				}
+				created, moved, failed := 0, 0, 0
+				for _, team := range report.Teams {
+					created, moved, failed = created+len(team.Created), moved+len(team.Moved), failed+len(team.Failed)
+				}
+				logger.WithContext(ctx).Infof("Team membership drift applied to %d of %d teams: %d users created, %d moved, %d failed",
+					report.Drifted, len(report.Teams), created, moved, failed)
+			}
+		}
+	}()
+}
+
+// driftReport compares and, if appliedBy is set, applies the drift of the teams one by one, so
+// that each team sees the moves made for the teams before it
+func (s *MembershipDriftService) driftReport(appliedBy string) (*MembershipDriftReport, error) {
+	teams, err := s.teamRepo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teams: %w", err)
+	}
+
+	report := &MembershipDriftReport{GeneratedAt: s.now(), Teams: []TeamMembershipDrift{}}
+	b := newDriftBuilder(s)
+	for i := range teams {
+		if teams[i].Email == "" {
+			continue
+		}
+		drift, err := s.teamDrift(b, teams[i].ID, appliedBy)
+		if err != nil {
+			drift = newTeamMembershipDrift(&teams[i])
+			drift.Error = err.Error()
+		}
+		if drift.HasDrift() {
+			report.Drifted++
+		}
+		report.Teams = append(report.Teams, *drift)
+	}
+	return report, nil
+}
+
+func (s *MembershipDriftService) teamDrift(b *driftBuilder, teamID uuid.UUID, appliedBy string) (*TeamMembershipDrift, error) {
+	team, err := s.teamRepo.GetWithMembers(teamID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrTeamNotFound
+		}
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+
+	drift, err := b.compare(team)
+	if err != nil {
+		return nil, err
+	}
+	if appliedBy != "" {
+		s.apply(team, drift, appliedBy)
+	}
+	return drift, nil
+}
+
+// apply creates the missing users in the team and moves the misplaced users to it
+func (s *MembershipDriftService) apply(team *models.Team, drift *TeamMembershipDrift, appliedBy string) {
+	drift.Applied = true
+	drift.Created, drift.Moved, drift.Failed = []string{}, []string{}, []DirectorySyncFailure{}
+
+	for _, u := range drift.Missing {
+		if u.FirstName == "" || u.LastName == "" || u.Email == "" {
+			drift.Failed = append(drift.Failed, DirectorySyncFailure{UserID: u.UserID, Error: "directory entry has no name or email"})
+			continue
+		}
+		name := strings.TrimSpace(u.FirstName + " " + u.LastName)
+		user := &models.User{
+			BaseModel:  models.BaseModel{Name: name, Title: name, CreatedBy: appliedBy},
+			TeamID:     &team.ID,
+			UserID:     u.UserID,
+			FirstName:  u.FirstName,
+			LastName:   u.LastName,
+			Email:      u.Email,
+			Mobile:     u.Mobile,
+			TeamDomain: models.TeamDomainDeveloper,
+			TeamRole:   models.TeamRoleMember,
+		}
+		if err := s.userRepo.Create(user); err != nil {
+			drift.Failed = append(drift.Failed, DirectorySyncFailure{UserID: u.UserID, Error: fmt.Sprintf("failed to create user: %v", err)})
+			continue
+		}
+		drift.Created = append(drift.Created, u.UserID)
+	}
+
+	for _, u := range drift.Misplaced {
+		if _, err := s.members.AddTeamMember(team.ID, &AddTeamMemberRequest{UserID: u.UserID, ChangedBy: appliedBy}); err != nil {
+			drift.Failed = append(drift.Failed, DirectorySyncFailure{UserID: u.UserID, Error: err.Error()})
+			continue
+		}
+		drift.Moved = append(drift.Moved, u.UserID)
+	}
+}
+
+// driftBuilder compares teams with their distribution lists, expanding each list only once
+type driftBuilder struct {
+	s *MembershipDriftService
+	// lists are the expanded distribution lists by team, as sets of upper-case user IDs
+	lists map[uuid.UUID]map[string]bool
+}
+
+func newDriftBuilder(s *MembershipDriftService) *driftBuilder {
+	return &driftBuilder{
+		s:     s,
+		lists: map[uuid.UUID]map[string]bool{},
+	}
+}
+
+func newTeamMembershipDrift(team *models.Team) *TeamMembershipDrift {
+	return &TeamMembershipDrift{
+		TeamID:           team.ID,
+		TeamName:         team.Name,
+		DistributionList: team.Email,
+		Missing:          []DriftUser{},
+		Skipped:          []DriftUser{},
+		Misplaced:        []DriftUser{},
+		Conflicting:      []DriftUser{},
+		Unlisted:         []DriftUser{},
+	}
+}
+
+func (b *driftBuilder) compare(team *models.Team) (*TeamMembershipDrift, error) {
+	drift := newTeamMembershipDrift(team)
+	entries, err := b.expand(team)
+	if err != nil {
+		return nil, err
+	}
+	b.lists[team.ID] = listedUserIDs(entries)
+
+	members := make(map[string]bool, len(team.Users))
+	for _, user := range team.Users {
+		members[strings.ToUpper(user.UserID)] = true
+	}
+
+	for _, entry := range entries {
+		if members[strings.ToUpper(entry.Name)] {
+			drift.InSync++
+			continue
+		}
+
+		user, err := b.s.userRepo.GetByUserID(entry.Name)
+		if err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, fmt.Errorf("failed to get user: %w", err)
+			}
+			deleted, err := b.s.userRepo.GetDeletedByUserID(entry.Name)
+			switch {
+			case err == nil:
+				drift.Skipped = append(drift.Skipped, DriftUser{
+					UserID: deleted.UserID, FirstName: deleted.FirstName, LastName: deleted.LastName, Email: deleted.Email,
+				})
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				drift.Missing = append(drift.Missing, DriftUser{
+					UserID: entry.Name, FirstName: entry.GivenName, LastName: entry.SN, Email: entry.Mail, Mobile: entry.Mobile,
+				})
+			default:
+				return nil, fmt.Errorf("failed to get deleted user: %w", err)
+			}
+			continue
+		}
+
+		u := DriftUser{UserID: user.UserID, FirstName: user.FirstName, LastName: user.LastName, Email: user.Email, CurrentTeamID: user.TeamID}
+		conflicting, err := b.listedInCurrentTeam(user)
+		if err != nil {
+			return nil, err
+		}
+		if conflicting {
+			drift.Conflicting = append(drift.Conflicting, u)
+		} else {
+			drift.Misplaced = append(drift.Misplaced, u)
+		}
+	}
+
+	for _, user := range team.Users {
+		if !b.lists[team.ID][strings.ToUpper(user.UserID)] {
+			drift.Unlisted = append(drift.Unlisted, DriftUser{UserID: user.UserID, FirstName: user.FirstName, LastName: user.LastName, Email: user.Email})
+		}
+	}
+	return drift, nil
+}
+
+// listedInCurrentTeam reports whether a user is in the distribution list of the team they are in.
+// Teams without a distribution list list nobody.
+func (b *driftBuilder) listedInCurrentTeam(user *models.User) (bool, error) {
+	if user.TeamID == nil {
+		return false, nil
+	}
+	teamID := *user.TeamID
+	if _, ok := b.lists[teamID]; !ok {
+		var entries []LDAPUser
+		team, err := b.s.teamRepo.GetByID(teamID)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+		case err != nil:
+			return false, fmt.Errorf("failed to get team: %w", err)
+		default:
+			entries, err = b.expand(team)
+			if err != nil && !errors.Is(err, apperrors.ErrDistributionListNotFound) {
+				return false, err
+			}
+		}
+		b.lists[teamID] = listedUserIDs(entries)
+	}
+	return b.lists[teamID][strings.ToUpper(user.UserID)], nil
+}
+
+// expand returns the members of the distribution list of a team
+func (b *driftBuilder) expand(team *models.Team) ([]LDAPUser, error) {
+	if team.Email == "" {
+		return nil, apperrors.ErrDistributionListNotFound
+	}
+	entries, err := b.s.ldap.ExpandDistributionList(team.Email)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrDistributionListNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to expand distribution list %s: %w", team.Email, err)
+	}
+	return entries, nil
+}
+
+func listedUserIDs(entries []LDAPUser) map[string]bool {
+	ids := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		ids[strings.ToUpper(entry.Name)] = true
+	}
+	return ids
+}
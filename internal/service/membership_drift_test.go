@@ -0,0 +1,260 @@
+package service
+
+import (
+	"crypto/tls"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+// fakeDistributionLists returns an LDAP client that finds groups by mail and reads entries by DN
+func fakeDistributionLists(entries ...*ldap.Entry) *fakeLDAPClient {
+	mails := regexp.MustCompile(`\(mail=([^)]*)\)`)
+	return &fakeLDAPClient{
+		search: func(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+			res := &ldap.SearchResult{}
+			for _, entry := range entries {
+				if req.Scope == ldap.ScopeBaseObject {
+					if strings.EqualFold(entry.DN, req.BaseDN) {
+						res.Entries = append(res.Entries, entry)
+					}
+					continue
+				}
+				if match := mails.FindStringSubmatch(req.Filter); match != nil && entry.GetAttributeValue("mail") == match[1] {
+					res.Entries = append(res.Entries, entry)
+				}
+			}
+			if req.Scope == ldap.ScopeBaseObject && len(res.Entries) == 0 {
+				return nil, ldap.NewError(ldap.LDAPResultNoSuchObject, errors.New("no such object"))
+			}
+			return res, nil
+		},
+	}
+}
+
+func distributionList(dn, mail string, members ...string) *ldap.Entry {
+	return ldap.NewEntry(dn, map[string][]string{
+		"objectClass": {"top", "group"},
+		"mail":        {mail},
+		"member":      members,
+	})
+}
+
+func userDN(userID string) string {
+	return "CN=" + userID + ",OU=I,DC=example,DC=com"
+}
+
+type MembershipDriftServiceTestSuite struct {
+	suite.Suite
+	teamRepo     *MockTeamRepository
+	userRepo     *MockUserRepository
+	directory    []*ldap.Entry
+	driftService *MembershipDriftService
+	origDial     func(network, addr string, config *tls.Config) (ldapClient, error)
+
+	team, otherTeam, listlessTeam *models.Team
+}
+
+func (suite *MembershipDriftServiceTestSuite) SetupTest() {
+	suite.origDial = dialLDAP
+	suite.directory = nil
+	dialLDAP = func(network, addr string, cfg *tls.Config) (ldapClient, error) {
+		return fakeDistributionLists(suite.directory...), nil
+	}
+
+	suite.teamRepo = new(MockTeamRepository)
+	suite.userRepo = new(MockUserRepository)
+	suite.driftService = NewMembershipDriftService(suite.teamRepo, suite.userRepo, NewLDAPService(makeConfig()))
+
+	suite.team = &models.Team{BaseModel: models.BaseModel{ID: uuid.New(), Name: "team-a"}, Email: "DL_A@example.com"}
+	suite.otherTeam = &models.Team{BaseModel: models.BaseModel{ID: uuid.New(), Name: "team-b"}, Email: "DL_B@example.com"}
+	suite.listlessTeam = &models.Team{BaseModel: models.BaseModel{ID: uuid.New(), Name: "team-c"}, Email: "DL_C@example.com"}
+}
+
+func (suite *MembershipDriftServiceTestSuite) TearDownTest() {
+	dialLDAP = suite.origDial
+	suite.teamRepo.AssertExpectations(suite.T())
+	suite.userRepo.AssertExpectations(suite.T())
+}
+
+func (suite *MembershipDriftServiceTestSuite) user(userID string, team *models.Team) *models.User {
+	user := &models.User{
+		BaseModel: models.BaseModel{ID: uuid.New(), Version: 1},
+		UserID:    userID,
+		FirstName: "John",
+		LastName:  "Doe",
+		Email:     userID + "@example.com",
+	}
+	if team != nil {
+		user.TeamID = &team.ID
+	}
+	return user
+}
+
+// setupDrift makes I000001 a member in sync and I000004 an unlisted member of the team. Of the
+// other list members, I000002 has no team, I000003 is listed by their own team, I000005 is in a
+// team without a distribution list and I000006 has no portal user.
+func (suite *MembershipDriftServiceTestSuite) setupDrift() map[string]*models.User {
+	users := map[string]*models.User{
+		"I000001": suite.user("I000001", suite.team),
+		"I000002": suite.user("I000002", nil),
+		"I000003": suite.user("I000003", suite.otherTeam),
+		"I000004": suite.user("I000004", suite.team),
+		"I000005": suite.user("I000005", suite.listlessTeam),
+	}
+	suite.team.Users = []models.User{*users["I000001"], *users["I000004"]}
+	suite.directory = []*ldap.Entry{
+		distributionList("CN=DL_A,OU=DL,DC=example,DC=com", "DL_A@example.com",
+			userDN("I000001"), userDN("I000002"), userDN("I000003"), userDN("I000005"), userDN("I000006")),
+		distributionList("CN=DL_B,OU=DL,DC=example,DC=com", "DL_B@example.com", userDN("I000003")),
+		directoryEntry("I000001", "John", "Doe", "I000001@example.com", ""),
+		directoryEntry("I000002", "John", "Doe", "I000002@example.com", ""),
+		directoryEntry("I000003", "John", "Doe", "I000003@example.com", ""),
+		directoryEntry("I000005", "John", "Doe", "I000005@example.com", ""),
+		directoryEntry("I000006", "Jane", "Smith", "jane.smith@example.com", "+49 123"),
+	}
+
+	suite.teamRepo.On("GetWithMembers", suite.team.ID).Return(suite.team, nil)
+	suite.teamRepo.On("GetByID", suite.otherTeam.ID).Return(suite.otherTeam, nil)
+	suite.teamRepo.On("GetByID", suite.listlessTeam.ID).Return(suite.listlessTeam, nil)
+	for _, id := range []string{"I000002", "I000003", "I000005"} {
+		suite.userRepo.On("GetByUserID", id).Return(users[id], nil)
+	}
+	suite.userRepo.On("GetByUserID", "I000006").Return(nil, gorm.ErrRecordNotFound)
+	suite.userRepo.On("GetDeletedByUserID", "I000006").Return(nil, gorm.ErrRecordNotFound)
+	return users
+}
+
+func driftUserIDs(users []DriftUser) []string {
+	ids := []string{}
+	for _, u := range users {
+		ids = append(ids, u.UserID)
+	}
+	return ids
+}
+
+func (suite *MembershipDriftServiceTestSuite) TestGetTeamDrift() {
+	suite.setupDrift()
+
+	drift, err := suite.driftService.GetTeamDrift(suite.team.ID)
+	suite.Require().NoError(err)
+	suite.Equal("DL_A@example.com", drift.DistributionList)
+	suite.Equal(1, drift.InSync)
+	suite.Equal([]string{"I000006"}, driftUserIDs(drift.Missing))
+	suite.Equal("Jane", drift.Missing[0].FirstName)
+	suite.Equal([]string{"I000002", "I000005"}, driftUserIDs(drift.Misplaced))
+	suite.Equal(&suite.listlessTeam.ID, drift.Misplaced[1].CurrentTeamID)
+	suite.Equal([]string{"I000003"}, driftUserIDs(drift.Conflicting))
+	suite.Equal([]string{"I000004"}, driftUserIDs(drift.Unlisted))
+	suite.Empty(drift.Skipped)
+	suite.True(drift.HasDrift())
+	suite.False(drift.Applied)
+	suite.userRepo.AssertNotCalled(suite.T(), "Create", mock.Anything)
+	suite.userRepo.AssertNotCalled(suite.T(), "Update", mock.Anything)
+}
+
+func (suite *MembershipDriftServiceTestSuite) TestApplyTeamDrift() {
+	suite.setupDrift()
+	suite.teamRepo.On("GetByID", suite.team.ID).Return(suite.team, nil)
+	suite.userRepo.On("Create", mock.MatchedBy(func(u *models.User) bool { return u.UserID == "I000006" })).Run(func(args mock.Arguments) {
+		u := args.Get(0).(*models.User)
+		suite.Equal(&suite.team.ID, u.TeamID)
+		suite.Equal("Jane Smith", u.Name)
+		suite.Equal("jane.smith@example.com", u.Email)
+		suite.Equal("+49 123", u.Mobile)
+		suite.Equal(models.TeamRoleMember, u.TeamRole)
+		suite.Equal("I000001", u.CreatedBy)
+	}).Return(nil)
+	suite.userRepo.On("Update", mock.MatchedBy(func(u *models.User) bool { return u.UserID == "I000002" })).Run(func(args mock.Arguments) {
+		u := args.Get(0).(*models.User)
+		suite.Equal(&suite.team.ID, u.TeamID)
+		suite.Equal("I000001", u.UpdatedBy)
+	}).Return(nil)
+	suite.userRepo.On("Update", mock.MatchedBy(func(u *models.User) bool { return u.UserID == "I000005" })).Return(errors.New("connection reset"))
+
+	drift, err := suite.driftService.ApplyTeamDrift(suite.team.ID, "I000001")
+	suite.Require().NoError(err)
+	suite.True(drift.Applied)
+	suite.Equal([]string{"I000006"}, drift.Created)
+	suite.Equal([]string{"I000002"}, drift.Moved)
+	suite.Require().Len(drift.Failed, 1)
+	suite.Equal("I000005", drift.Failed[0].UserID)
+	suite.Contains(drift.Failed[0].Error, "connection reset")
+}
+
+func (suite *MembershipDriftServiceTestSuite) TestApplyTeamDrift_SkipsDeletedUsers() {
+	suite.team.Users = []models.User{*suite.user("I000001", suite.team)}
+	suite.directory = []*ldap.Entry{
+		distributionList("CN=DL_A,OU=DL,DC=example,DC=com", "DL_A@example.com", userDN("I000001"), userDN("I000007")),
+		directoryEntry("I000001", "John", "Doe", "I000001@example.com", ""),
+		directoryEntry("I000007", "Max", "Mustermann", "max@example.com", ""),
+	}
+	offboarded := suite.user("I000007", nil)
+	offboarded.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	suite.teamRepo.On("GetWithMembers", suite.team.ID).Return(suite.team, nil)
+	suite.userRepo.On("GetByUserID", "I000007").Return(nil, gorm.ErrRecordNotFound)
+	suite.userRepo.On("GetDeletedByUserID", "I000007").Return(offboarded, nil)
+
+	drift, err := suite.driftService.ApplyTeamDrift(suite.team.ID, "dl-sync")
+	suite.Require().NoError(err)
+	suite.Equal([]string{"I000007"}, driftUserIDs(drift.Skipped))
+	suite.Empty(drift.Missing)
+	suite.Empty(drift.Created)
+	suite.Empty(drift.Failed)
+	suite.userRepo.AssertNotCalled(suite.T(), "Create", mock.Anything)
+}
+
+func (suite *MembershipDriftServiceTestSuite) TestApplyTeamDrift_RequiresActor() {
+	_, err := suite.driftService.ApplyTeamDrift(suite.team.ID, " ")
+	suite.True(apperrors.IsValidation(err))
+}
+
+func (suite *MembershipDriftServiceTestSuite) TestGetTeamDrift_Errors() {
+	unknown := uuid.New()
+	suite.teamRepo.On("GetWithMembers", unknown).Return((*models.Team)(nil), gorm.ErrRecordNotFound)
+	suite.teamRepo.On("GetWithMembers", suite.team.ID).Return(suite.team, nil)
+
+	_, err := suite.driftService.GetTeamDrift(unknown)
+	suite.ErrorIs(err, apperrors.ErrTeamNotFound)
+
+	// The team's distribution list is not in LDAP
+	_, err = suite.driftService.GetTeamDrift(suite.team.ID)
+	suite.ErrorIs(err, apperrors.ErrDistributionListNotFound)
+}
+
+func (suite *MembershipDriftServiceTestSuite) TestGetDriftReport() {
+	withoutList := &models.Team{BaseModel: models.BaseModel{ID: uuid.New(), Name: "team-d"}}
+	suite.team.Users = []models.User{*suite.user("I000001", suite.team)}
+	suite.directory = []*ldap.Entry{
+		distributionList("CN=DL_A,OU=DL,DC=example,DC=com", "DL_A@example.com", userDN("I000001"), userDN("I000002")),
+		directoryEntry("I000001", "John", "Doe", "I000001@example.com", ""),
+		directoryEntry("I000002", "John", "Doe", "I000002@example.com", ""),
+	}
+	suite.teamRepo.On("GetAll").Return([]models.Team{*suite.team, *suite.otherTeam, *withoutList}, nil)
+	suite.teamRepo.On("GetWithMembers", suite.team.ID).Return(suite.team, nil)
+	suite.teamRepo.On("GetWithMembers", suite.otherTeam.ID).Return(suite.otherTeam, nil)
+	suite.userRepo.On("GetByUserID", "I000002").Return(suite.user("I000002", nil), nil)
+
+	report, err := suite.driftService.GetDriftReport()
+	suite.Require().NoError(err)
+	suite.Equal(1, report.Drifted)
+	suite.Require().Len(report.Teams, 2)
+	suite.Equal([]string{"I000002"}, driftUserIDs(report.Teams[0].Misplaced))
+	suite.Equal(suite.otherTeam.ID, report.Teams[1].TeamID)
+	suite.Contains(report.Teams[1].Error, "distribution list not found")
+}
+
+func TestMembershipDriftServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(MembershipDriftServiceTestSuite))
+}
@@ -0,0 +1,253 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/repository"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OrganizationService provides organization-related business logic
+type OrganizationService struct {
+	orgRepo   repository.OrganizationRepositoryInterface
+	groupRepo repository.GroupRepositoryInterface
+	validator *validator.Validate
+}
+
+// Ensure OrganizationService implements OrganizationServiceInterface
+var _ OrganizationServiceInterface = (*OrganizationService)(nil)
+
+// NewOrganizationService creates a new OrganizationService
+func NewOrganizationService(
+	orgRepo repository.OrganizationRepositoryInterface,
+	groupRepo repository.GroupRepositoryInterface,
+	validator *validator.Validate,
+) *OrganizationService {
+	return &OrganizationService{
+		orgRepo:   orgRepo,
+		groupRepo: groupRepo,
+		validator: validator,
+	}
+}
+
+// CreateOrganizationRequest represents the payload for creating an organization.
+// The fields are validated against the tags of models.Organization.
+type CreateOrganizationRequest struct {
+	Name        string          `json:"name"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Owner       string          `json:"owner"` // I/C/D user
+	Email       string          `json:"email"` // DL
+	Metadata    json.RawMessage `json:"metadata" swaggertype:"object"`
+	CreatedBy   string          `json:"-"` // derived from bearer token
+}
+
+// UpdateOrganizationRequest represents the payload for updating an organization. Omitted fields are kept.
+type UpdateOrganizationRequest struct {
+	Title       *string         `json:"title"`
+	Description *string         `json:"description"`
+	Owner       *string         `json:"owner"`
+	Email       *string         `json:"email"`
+	Metadata    json.RawMessage `json:"metadata" swaggertype:"object"`
+	UpdatedBy   string          `json:"-"` // derived from bearer token
+	// ExpectedVersion is the version the update is based on, from the If-Match header. Zero updates any version.
+	ExpectedVersion int64 `json:"-"`
+}
+
+// OrganizationResponse represents an organization in API responses
+type OrganizationResponse struct {
+	ID          uuid.UUID       `json:"id"`
+	Name        string          `json:"name"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Owner       string          `json:"owner"`
+	Email       string          `json:"email"`
+	Metadata    json.RawMessage `json:"metadata" swaggertype:"object"`
+	Version     int64           `json:"version"` // for If-Match, see the ETag header
+	CreatedAt   string          `json:"created_at"`
+	CreatedBy   string          `json:"created_by"`
+	UpdatedAt   string          `json:"updated_at"`
+	UpdatedBy   string          `json:"updated_by"`
+}
+
+// OrganizationListResponse represents a paginated list of organizations
+type OrganizationListResponse struct {
+	Organizations []OrganizationResponse `json:"organizations"`
+	Total         int64                  `json:"total"`
+	Page          int                    `json:"page"`
+	PageSize      int                    `json:"page_size"`
+}
+
+// CreateOrganization validates and creates a new organization with a unique name
+func (s *OrganizationService) CreateOrganization(req *CreateOrganizationRequest) (*OrganizationResponse, error) {
+	org := &models.Organization{
+		BaseModel: models.BaseModel{
+			Name:        req.Name,
+			Title:       req.Title,
+			Description: req.Description,
+			Metadata:    req.Metadata,
+			CreatedBy:   req.CreatedBy,
+			UpdatedBy:   req.CreatedBy,
+		},
+		Owner: req.Owner,
+		Email: req.Email,
+	}
+	if err := s.validator.Struct(org); err != nil {
+		return nil, apperrors.NewValidationError("", err.Error())
+	}
+
+	if _, err := s.orgRepo.GetByName(org.Name); err == nil {
+		return nil, apperrors.ErrOrganizationExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check organization name: %w", err)
+	}
+
+	if err := s.orgRepo.Create(org); err != nil {
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+	return toOrganizationResponse(org), nil
+}
+
+// GetOrganizationByID retrieves an organization by ID
+func (s *OrganizationService) GetOrganizationByID(id uuid.UUID) (*OrganizationResponse, error) {
+	org, err := s.getOrganization(id)
+	if err != nil {
+		return nil, err
+	}
+	return toOrganizationResponse(org), nil
+}
+
+// GetAllOrganizations retrieves a page of all organizations
+func (s *OrganizationService) GetAllOrganizations(page, pageSize int) (*OrganizationListResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	orgs, total, err := s.orgRepo.GetAll(pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organizations: %w", err)
+	}
+
+	responses := make([]OrganizationResponse, len(orgs))
+	for i := range orgs {
+		responses[i] = *toOrganizationResponse(&orgs[i])
+	}
+	return &OrganizationListResponse{
+		Organizations: responses,
+		Total:         total,
+		Page:          page,
+		PageSize:      pageSize,
+	}, nil
+}
+
+// UpdateOrganization updates the given fields of an organization. Without an expected version the
+// update is applied to the latest version, retrying if the organization is updated concurrently.
+func (s *OrganizationService) UpdateOrganization(id uuid.UUID, req *UpdateOrganizationRequest) (*OrganizationResponse, error) {
+	var org *models.Organization
+	err := retryOnConflict(req.ExpectedVersion, func() error {
+		var err error
+		org, err = s.getOrganization(id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(req.ExpectedVersion, org.Version); err != nil {
+			return err
+		}
+
+		if req.Title != nil {
+			org.Title = *req.Title
+		}
+		if req.Description != nil {
+			org.Description = *req.Description
+		}
+		if req.Owner != nil {
+			org.Owner = *req.Owner
+		}
+		if req.Email != nil {
+			org.Email = *req.Email
+		}
+		if req.Metadata != nil {
+			org.Metadata = req.Metadata
+		}
+		org.UpdatedBy = req.UpdatedBy
+		if err := s.validator.Struct(org); err != nil {
+			return apperrors.NewValidationError("", err.Error())
+		}
+
+		if err := s.orgRepo.Update(org); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperrors.ErrOrganizationNotFound
+			}
+			if errors.Is(err, repository.ErrVersionConflict) {
+				return err
+			}
+			return fmt.Errorf("failed to update organization: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toOrganizationResponse(org), nil
+}
+
+// DeleteOrganization deletes an organization. Organizations with groups cannot be deleted.
+func (s *OrganizationService) DeleteOrganization(id uuid.UUID) error {
+	if _, err := s.getOrganization(id); err != nil {
+		return err
+	}
+
+	_, groups, err := s.groupRepo.GetByOrganizationID(id, 1, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get groups of organization: %w", err)
+	}
+	if groups > 0 {
+		return apperrors.ErrOrganizationHasGroups
+	}
+
+	if err := s.orgRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+	return nil
+}
+
+// getOrganization loads an organization, mapping a missing one to ErrOrganizationNotFound
+func (s *OrganizationService) getOrganization(id uuid.UUID) (*models.Organization, error) {
+	org, err := s.orgRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return org, nil
+}
+
+// toOrganizationResponse converts an Organization model to OrganizationResponse
+func toOrganizationResponse(org *models.Organization) *OrganizationResponse {
+	return &OrganizationResponse{
+		ID:          org.ID,
+		Name:        org.Name,
+		Title:       org.Title,
+		Description: org.Description,
+		Owner:       org.Owner,
+		Email:       org.Email,
+		Metadata:    org.Metadata,
+		Version:     org.Version,
+		CreatedAt:   org.CreatedAt.Format(time.RFC3339),
+		CreatedBy:   org.CreatedBy,
+		UpdatedAt:   org.UpdatedAt.Format(time.RFC3339),
+		UpdatedBy:   org.UpdatedBy,
+	}
+}
@@ -0,0 +1,129 @@
+package service_test
+
+import (
+	"testing"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/repository"
+	"developer-portal-backend/internal/service"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type OrganizationServiceTestSuite struct {
+	suite.Suite
+	ctrl          *gomock.Controller
+	mockOrgRepo   *mocks.MockOrganizationRepositoryInterface
+	mockGroupRepo *mocks.MockGroupRepositoryInterface
+	orgService    *service.OrganizationService
+}
+
+func (suite *OrganizationServiceTestSuite) SetupTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockOrgRepo = mocks.NewMockOrganizationRepositoryInterface(suite.ctrl)
+	suite.mockGroupRepo = mocks.NewMockGroupRepositoryInterface(suite.ctrl)
+	suite.orgService = service.NewOrganizationService(suite.mockOrgRepo, suite.mockGroupRepo, validator.New())
+}
+
+func (suite *OrganizationServiceTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+func newOrganization(version int64) *models.Organization {
+	return &models.Organization{
+		BaseModel: models.BaseModel{ID: uuid.New(), Name: "sap-cfs", Title: "SAP CFS", Version: version},
+		Owner:     "I000001",
+		Email:     "cfs@sap.com",
+	}
+}
+
+func (suite *OrganizationServiceTestSuite) TestCreateOrganization() {
+	suite.mockOrgRepo.EXPECT().GetByName("sap-cfs").Return(nil, gorm.ErrRecordNotFound)
+	suite.mockOrgRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(org *models.Organization) error {
+		suite.Equal("jdoe", org.CreatedBy)
+		org.Version = 1
+		return nil
+	})
+
+	resp, err := suite.orgService.CreateOrganization(&service.CreateOrganizationRequest{
+		Name: "sap-cfs", Title: "SAP CFS", Owner: "I000001", Email: "cfs@sap.com", CreatedBy: "jdoe",
+	})
+	suite.Require().NoError(err)
+	suite.Equal("sap-cfs", resp.Name)
+	suite.Equal(int64(1), resp.Version)
+}
+
+func (suite *OrganizationServiceTestSuite) TestCreateOrganization_ValidatesModelTags() {
+	_, err := suite.orgService.CreateOrganization(&service.CreateOrganizationRequest{
+		Name: "sap-cfs", Title: "SAP CFS", Owner: "I1", Email: "cfs@sap.com",
+	})
+	suite.True(apperrors.IsValidation(err))
+	suite.ErrorContains(err, "Owner")
+}
+
+func (suite *OrganizationServiceTestSuite) TestCreateOrganization_NameTaken() {
+	suite.mockOrgRepo.EXPECT().GetByName("sap-cfs").Return(newOrganization(1), nil)
+
+	_, err := suite.orgService.CreateOrganization(&service.CreateOrganizationRequest{
+		Name: "sap-cfs", Title: "SAP CFS", Owner: "I000001", Email: "cfs@sap.com",
+	})
+	suite.ErrorIs(err, apperrors.ErrOrganizationExists)
+}
+
+func (suite *OrganizationServiceTestSuite) TestUpdateOrganization() {
+	org := newOrganization(2)
+	owner := "I000002"
+	suite.mockOrgRepo.EXPECT().GetByID(org.ID).Return(org, nil)
+	suite.mockOrgRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	resp, err := suite.orgService.UpdateOrganization(org.ID, &service.UpdateOrganizationRequest{Owner: &owner, UpdatedBy: "jdoe", ExpectedVersion: 2})
+	suite.Require().NoError(err)
+	suite.Equal(owner, resp.Owner)
+	suite.Equal("SAP CFS", resp.Title)
+	suite.Equal("jdoe", resp.UpdatedBy)
+}
+
+func (suite *OrganizationServiceTestSuite) TestUpdateOrganization_Errors() {
+	org := newOrganization(2)
+	suite.mockOrgRepo.EXPECT().GetByID(org.ID).DoAndReturn(func(uuid.UUID) (*models.Organization, error) {
+		stored := *org
+		return &stored, nil
+	}).Times(3)
+
+	_, err := suite.orgService.UpdateOrganization(org.ID, &service.UpdateOrganizationRequest{ExpectedVersion: 1})
+	suite.ErrorIs(err, apperrors.ErrVersionConflict)
+
+	email := "x"
+	_, err = suite.orgService.UpdateOrganization(org.ID, &service.UpdateOrganizationRequest{Email: &email})
+	suite.True(apperrors.IsValidation(err))
+
+	suite.mockOrgRepo.EXPECT().Update(gomock.Any()).Return(repository.ErrVersionConflict)
+	_, err = suite.orgService.UpdateOrganization(org.ID, &service.UpdateOrganizationRequest{ExpectedVersion: 2})
+	suite.ErrorIs(err, apperrors.ErrVersionConflict)
+
+	suite.mockOrgRepo.EXPECT().GetByID(gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
+	_, err = suite.orgService.UpdateOrganization(uuid.New(), &service.UpdateOrganizationRequest{})
+	suite.ErrorIs(err, apperrors.ErrOrganizationNotFound)
+}
+
+func (suite *OrganizationServiceTestSuite) TestDeleteOrganization() {
+	org := newOrganization(1)
+	suite.mockOrgRepo.EXPECT().GetByID(org.ID).Return(org, nil).Times(2)
+
+	suite.mockGroupRepo.EXPECT().GetByOrganizationID(org.ID, 1, 0).Return([]models.Group{{}}, int64(3), nil)
+	suite.ErrorIs(suite.orgService.DeleteOrganization(org.ID), apperrors.ErrOrganizationHasGroups)
+
+	suite.mockGroupRepo.EXPECT().GetByOrganizationID(org.ID, 1, 0).Return(nil, int64(0), nil)
+	suite.mockOrgRepo.EXPECT().Delete(org.ID).Return(nil)
+	suite.NoError(suite.orgService.DeleteOrganization(org.ID))
+}
+
+func TestOrganizationServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(OrganizationServiceTestSuite))
+}
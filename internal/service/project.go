@@ -1,3 +1,421 @@
 package service
 
-// Project service has been removed. This file is intentionally left minimal.
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/metaschema"
+	"developer-portal-backend/internal/repository"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Metadata keys of the project settings
+const (
+	projectAlertsRepoKey     = "alerts-repo"
+	projectLandscapeLinksKey = "landscape-links"
+	projectSonarHostKey      = "sonar-host"
+)
+
+// landscapeLinkPlaceholders are the landscape fields a landscape link template may refer to
+var landscapeLinkPlaceholders = map[string]bool{"name": true, "domain": true, "environment": true}
+
+var placeholderPattern = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// ProjectService provides project-related business logic
+type ProjectService struct {
+	projectRepo     repository.ProjectRepositoryInterface
+	componentRepo   repository.ComponentRepositoryInterface
+	landscapeRepo   repository.LandscapeRepositoryInterface
+	validator       *validator.Validate
+	metadataSchemas *metaschema.Registry
+}
+
+// Ensure ProjectService implements ProjectServiceInterface
+var _ ProjectServiceInterface = (*ProjectService)(nil)
+
+// NewProjectService creates a new ProjectService
+func NewProjectService(
+	projectRepo repository.ProjectRepositoryInterface,
+	componentRepo repository.ComponentRepositoryInterface,
+	landscapeRepo repository.LandscapeRepositoryInterface,
+	validator *validator.Validate,
+	metadataSchemas *metaschema.Registry,
+) *ProjectService {
+	return &ProjectService{
+		projectRepo:     projectRepo,
+		componentRepo:   componentRepo,
+		landscapeRepo:   landscapeRepo,
+		validator:       validator,
+		metadataSchemas: metadataSchemas,
+	}
+}
+
+// CreateProjectRequest represents the payload for creating a project.
+// The fields are validated against the tags of models.Project.
+type CreateProjectRequest struct {
+	Name        string          `json:"name"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Metadata    json.RawMessage `json:"metadata" swaggertype:"object"`
+	CreatedBy   string          `json:"-"` // derived from bearer token
+}
+
+// UpdateProjectRequest represents the payload for updating a project. Omitted fields are kept;
+// metadata, if given, replaces the project's metadata including its settings.
+type UpdateProjectRequest struct {
+	Title       *string         `json:"title"`
+	Description *string         `json:"description"`
+	Metadata    json.RawMessage `json:"metadata" swaggertype:"object"`
+	UpdatedBy   string          `json:"-"` // derived from bearer token
+	// ExpectedVersion is the version the update is based on, from the If-Match header. Zero updates any version.
+	ExpectedVersion int64 `json:"-"`
+}
+
+// ProjectResponse represents a project in API responses
+type ProjectResponse struct {
+	ID          uuid.UUID       `json:"id"`
+	Name        string          `json:"name"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Metadata    json.RawMessage `json:"metadata" swaggertype:"object"`
+	Version     int64           `json:"version"` // for If-Match, see the ETag header
+	CreatedAt   string          `json:"created_at"`
+	CreatedBy   string          `json:"created_by"`
+	UpdatedAt   string          `json:"updated_at"`
+	UpdatedBy   string          `json:"updated_by"`
+}
+
+// ProjectListResponse represents a paginated list of projects
+type ProjectListResponse struct {
+	Projects []ProjectResponse `json:"projects"`
+	Total    int64             `json:"total"`
+	Page     int               `json:"page"`
+	PageSize int               `json:"page_size"`
+}
+
+// ProjectSettings are the settings of a project, kept in its metadata. Empty settings are unset.
+type ProjectSettings struct {
+	// AlertsRepo is the GitHub folder with the project's alert rules (metadata alerts-repo)
+	AlertsRepo string `json:"alerts_repo"`
+	// LandscapeLinks maps link titles to URL templates shown for every landscape of the project,
+	// with {name}, {domain} and {environment} replaced by the landscape's values (metadata landscape-links)
+	LandscapeLinks map[string]string `json:"landscape_links"`
+	// SonarHost is the base URL of the Sonar instance for the project's components (metadata sonar-host)
+	SonarHost string `json:"sonar_host"`
+}
+
+// ProjectSettingsResponse represents the settings of a project in API responses
+type ProjectSettingsResponse struct {
+	ProjectSettings
+	Version int64 `json:"version"` // of the project, for If-Match, see the ETag header
+}
+
+// UpdateProjectSettingsRequest replaces the settings of a project
+type UpdateProjectSettingsRequest struct {
+	ProjectSettings
+	UpdatedBy string `json:"-"` // derived from bearer token
+	// ExpectedVersion is the version the update is based on, from the If-Match header. Zero updates any version.
+	ExpectedVersion int64 `json:"-"`
+}
+
+// CreateProject validates and creates a new project with a unique name
+func (s *ProjectService) CreateProject(req *CreateProjectRequest) (*ProjectResponse, error) {
+	project := &models.Project{
+		BaseModel: models.BaseModel{
+			Name:        req.Name,
+			Title:       req.Title,
+			Description: req.Description,
+			Metadata:    req.Metadata,
+			CreatedBy:   req.CreatedBy,
+			UpdatedBy:   req.CreatedBy,
+		},
+	}
+	if err := s.validate(project); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.projectRepo.GetByName(project.Name); err == nil {
+		return nil, apperrors.ErrProjectExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check project name: %w", err)
+	}
+
+	if err := s.projectRepo.Create(project); err != nil {
+		return nil, fmt.Errorf("failed to create project: %w", err)
+	}
+	return toProjectResponse(project), nil
+}
+
+// GetProjectByID retrieves a project by ID
+func (s *ProjectService) GetProjectByID(id uuid.UUID) (*ProjectResponse, error) {
+	project, err := s.getProject(id)
+	if err != nil {
+		return nil, err
+	}
+	return toProjectResponse(project), nil
+}
+
+// GetAllProjects retrieves a page of all projects ordered by name
+func (s *ProjectService) GetAllProjects(page, pageSize int) (*ProjectListResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	projects, total, err := s.projectRepo.GetAll(pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+
+	responses := make([]ProjectResponse, len(projects))
+	for i := range projects {
+		responses[i] = *toProjectResponse(&projects[i])
+	}
+	return &ProjectListResponse{
+		Projects: responses,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// UpdateProject updates the given fields of a project. Without an expected version the update is
+// applied to the latest version, retrying if the project is updated concurrently.
+func (s *ProjectService) UpdateProject(id uuid.UUID, req *UpdateProjectRequest) (*ProjectResponse, error) {
+	project, err := s.updateProject(id, req.ExpectedVersion, req.UpdatedBy, func(project *models.Project) error {
+		if req.Title != nil {
+			project.Title = *req.Title
+		}
+		if req.Description != nil {
+			project.Description = *req.Description
+		}
+		if req.Metadata != nil {
+			project.Metadata = req.Metadata
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toProjectResponse(project), nil
+}
+
+// DeleteProject deletes a project. Projects with components or landscapes cannot be deleted.
+func (s *ProjectService) DeleteProject(id uuid.UUID) error {
+	if _, err := s.getProject(id); err != nil {
+		return err
+	}
+
+	_, components, err := s.componentRepo.GetByProjectID(id, 1, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get components of project: %w", err)
+	}
+	_, landscapes, err := s.landscapeRepo.GetLandscapesByProjectID(id, 1, 0)
+	if err != nil {
+		return fmt.Errorf("failed to get landscapes of project: %w", err)
+	}
+	if components > 0 || landscapes > 0 {
+		return apperrors.ErrProjectHasResources
+	}
+
+	if err := s.projectRepo.Delete(id); err != nil {
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+	return nil
+}
+
+// GetProjectSettings retrieves the settings of a project
+func (s *ProjectService) GetProjectSettings(id uuid.UUID) (*ProjectSettingsResponse, error) {
+	project, err := s.getProject(id)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := projectSettingsFromMetadata(project.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	return &ProjectSettingsResponse{ProjectSettings: *settings, Version: project.Version}, nil
+}
+
+// UpdateProjectSettings replaces the settings of a project, keeping the rest of its metadata
+func (s *ProjectService) UpdateProjectSettings(id uuid.UUID, req *UpdateProjectSettingsRequest) (*ProjectSettingsResponse, error) {
+	project, err := s.updateProject(id, req.ExpectedVersion, req.UpdatedBy, func(project *models.Project) error {
+		metadata, err := projectMetadataMap(project.Metadata)
+		if err != nil {
+			return err
+		}
+		setOrDelete(metadata, projectAlertsRepoKey, req.AlertsRepo, req.AlertsRepo != "")
+		setOrDelete(metadata, projectLandscapeLinksKey, req.LandscapeLinks, len(req.LandscapeLinks) > 0)
+		setOrDelete(metadata, projectSonarHostKey, req.SonarHost, req.SonarHost != "")
+
+		project.Metadata, err = json.Marshal(metadata)
+		if err != nil {
+			return fmt.Errorf("failed to marshal project metadata: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := projectSettingsFromMetadata(project.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	return &ProjectSettingsResponse{ProjectSettings: *settings, Version: project.Version}, nil
+}
+
+// updateProject applies change to the latest or the expected version of a project, validates and saves it
+func (s *ProjectService) updateProject(id uuid.UUID, expectedVersion int64, updatedBy string, change func(*models.Project) error) (*models.Project, error) {
+	var project *models.Project
+	err := retryOnConflict(expectedVersion, func() error {
+		var err error
+		project, err = s.getProject(id)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(expectedVersion, project.Version); err != nil {
+			return err
+		}
+
+		if err := change(project); err != nil {
+			return err
+		}
+		project.UpdatedBy = updatedBy
+		if err := s.validate(project); err != nil {
+			return err
+		}
+
+		if err := s.projectRepo.Update(project); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperrors.ErrProjectNotFound
+			}
+			if errors.Is(err, repository.ErrVersionConflict) {
+				return err
+			}
+			return fmt.Errorf("failed to update project: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// validate checks a project against the model tags and its metadata against the project metadata schema
+func (s *ProjectService) validate(project *models.Project) error {
+	if err := s.validator.Struct(project); err != nil {
+		return apperrors.NewValidationError("", err.Error())
+	}
+	if err := s.metadataSchemas.Validate(metaschema.EntityProject, project.Name, project.Metadata); err != nil {
+		return err
+	}
+	settings, err := projectSettingsFromMetadata(project.Metadata)
+	if err != nil {
+		return err
+	}
+	return validateLandscapeLinks(settings.LandscapeLinks)
+}
+
+// getProject loads a project, mapping a missing one to ErrProjectNotFound
+func (s *ProjectService) getProject(id uuid.UUID) (*models.Project, error) {
+	project, err := s.projectRepo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrProjectNotFound
+		}
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+	return project, nil
+}
+
+// validateLandscapeLinks checks that landscape link templates only use known placeholders
+func validateLandscapeLinks(links map[string]string) error {
+	titles := make([]string, 0, len(links))
+	for title := range links {
+		titles = append(titles, title)
+	}
+	sort.Strings(titles)
+
+	for _, title := range titles {
+		if strings.TrimSpace(title) == "" {
+			return apperrors.NewValidationError("landscape_links", "link titles must not be empty")
+		}
+		for _, match := range placeholderPattern.FindAllStringSubmatch(links[title], -1) {
+			if !landscapeLinkPlaceholders[match[1]] {
+				return apperrors.NewValidationError("landscape_links", fmt.Sprintf("%s: unknown placeholder %s, expected {name}, {domain} or {environment}", title, match[0]))
+			}
+		}
+	}
+	return nil
+}
+
+// projectSettingsFromMetadata reads the settings from project metadata, ignoring other keys
+func projectSettingsFromMetadata(metadata json.RawMessage) (*ProjectSettings, error) {
+	settings := &ProjectSettings{}
+	if trimmed := strings.TrimSpace(string(metadata)); trimmed == "" || trimmed == "null" {
+		return settings, nil
+	}
+	var stored struct {
+		AlertsRepo     string            `json:"alerts-repo"`
+		LandscapeLinks map[string]string `json:"landscape-links"`
+		SonarHost      string            `json:"sonar-host"`
+	}
+	if err := json.Unmarshal(metadata, &stored); err != nil {
+		return nil, apperrors.NewValidationError("metadata", "invalid project settings: "+err.Error())
+	}
+	settings.AlertsRepo = stored.AlertsRepo
+	settings.LandscapeLinks = stored.LandscapeLinks
+	settings.SonarHost = stored.SonarHost
+	return settings, nil
+}
+
+// projectMetadataMap parses project metadata into a map, which is empty if there is none
+func projectMetadataMap(metadata json.RawMessage) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	if trimmed := strings.TrimSpace(string(metadata)); trimmed == "" || trimmed == "null" {
+		return result, nil
+	}
+	if err := json.Unmarshal(metadata, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse project metadata: %w", err)
+	}
+	return result, nil
+}
+
+// setOrDelete sets the key to the value if set is true and removes it otherwise
+func setOrDelete(metadata map[string]interface{}, key string, value interface{}, set bool) {
+	if set {
+		metadata[key] = value
+	} else {
+		delete(metadata, key)
+	}
+}
+
+// toProjectResponse converts a Project model to ProjectResponse
+func toProjectResponse(project *models.Project) *ProjectResponse {
+	return &ProjectResponse{
+		ID:          project.ID,
+		Name:        project.Name,
+		Title:       project.Title,
+		Description: project.Description,
+		Metadata:    project.Metadata,
+		Version:     project.Version,
+		CreatedAt:   project.CreatedAt.Format(time.RFC3339),
+		CreatedBy:   project.CreatedBy,
+		UpdatedAt:   project.UpdatedAt.Format(time.RFC3339),
+		UpdatedBy:   project.UpdatedBy,
+	}
+}
@@ -1,8 +1,206 @@
 package service_test
 
-import "testing"
+import (
+	"encoding/json"
+	"testing"
 
-// Project service was removed. Placeholder test to keep package compiling.
-func TestProjectServiceRemoved(t *testing.T) {
-	t.Skip("Project service removed; tests skipped.")
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/metaschema"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/repository"
+	"developer-portal-backend/internal/service"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type ProjectServiceTestSuite struct {
+	suite.Suite
+	ctrl              *gomock.Controller
+	mockProjectRepo   *mocks.MockProjectRepositoryInterface
+	mockComponentRepo *mocks.MockComponentRepositoryInterface
+	mockLandscapeRepo *mocks.MockLandscapeRepositoryInterface
+	projectService    *service.ProjectService
+}
+
+func (suite *ProjectServiceTestSuite) SetupTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockProjectRepo = mocks.NewMockProjectRepositoryInterface(suite.ctrl)
+	suite.mockComponentRepo = mocks.NewMockComponentRepositoryInterface(suite.ctrl)
+	suite.mockLandscapeRepo = mocks.NewMockLandscapeRepositoryInterface(suite.ctrl)
+	schemas, err := metaschema.NewRegistry()
+	suite.Require().NoError(err)
+	suite.projectService = service.NewProjectService(suite.mockProjectRepo, suite.mockComponentRepo, suite.mockLandscapeRepo, validator.New(), schemas)
+}
+
+func (suite *ProjectServiceTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+func newProject(version int64, metadata string) *models.Project {
+	return &models.Project{
+		BaseModel: models.BaseModel{ID: uuid.New(), Name: "cis20", Title: "CIS 2.0", Version: version, Metadata: json.RawMessage(metadata)},
+	}
+}
+
+// storedProject makes GetByID return a fresh copy of project on every call, like the database would
+func (suite *ProjectServiceTestSuite) storedProject(project *models.Project) *gomock.Call {
+	return suite.mockProjectRepo.EXPECT().GetByID(project.ID).DoAndReturn(func(uuid.UUID) (*models.Project, error) {
+		stored := *project
+		return &stored, nil
+	})
+}
+
+func (suite *ProjectServiceTestSuite) TestCreateProject() {
+	suite.mockProjectRepo.EXPECT().GetByName("cis20").Return(nil, gorm.ErrRecordNotFound)
+	suite.mockProjectRepo.EXPECT().Create(gomock.Any()).DoAndReturn(func(project *models.Project) error {
+		suite.Equal("jdoe", project.CreatedBy)
+		project.Version = 1
+		return nil
+	})
+
+	resp, err := suite.projectService.CreateProject(&service.CreateProjectRequest{
+		Name: "cis20", Title: "CIS 2.0", Metadata: json.RawMessage(`{"sonar-host":"https://sonar.example.com"}`), CreatedBy: "jdoe",
+	})
+	suite.Require().NoError(err)
+	suite.Equal("cis20", resp.Name)
+	suite.Equal(int64(1), resp.Version)
+}
+
+func (suite *ProjectServiceTestSuite) TestCreateProject_Errors() {
+	_, err := suite.projectService.CreateProject(&service.CreateProjectRequest{Name: "cis20"})
+	suite.True(apperrors.IsValidation(err))
+	suite.ErrorContains(err, "Title")
+
+	_, err = suite.projectService.CreateProject(&service.CreateProjectRequest{
+		Name: "cis20", Title: "CIS 2.0", Metadata: json.RawMessage(`{"sonar-host":"sonar.example.com"}`),
+	})
+	suite.True(apperrors.IsValidation(err))
+	suite.ErrorContains(err, "sonar-host")
+
+	suite.mockProjectRepo.EXPECT().GetByName("cis20").Return(newProject(1, ""), nil)
+	_, err = suite.projectService.CreateProject(&service.CreateProjectRequest{Name: "cis20", Title: "CIS 2.0"})
+	suite.ErrorIs(err, apperrors.ErrProjectExists)
+}
+
+func (suite *ProjectServiceTestSuite) TestGetAllProjects() {
+	suite.mockProjectRepo.EXPECT().GetAll(20, 0).Return([]models.Project{*newProject(1, "")}, int64(1), nil)
+
+	resp, err := suite.projectService.GetAllProjects(0, 500)
+	suite.Require().NoError(err)
+	suite.Len(resp.Projects, 1)
+	suite.Equal(int64(1), resp.Total)
+	suite.Equal(1, resp.Page)
+	suite.Equal(20, resp.PageSize)
+}
+
+func (suite *ProjectServiceTestSuite) TestUpdateProject() {
+	project := newProject(2, `{"alerts-repo":"https://github.example.com/org/repo/tree/main/alerts"}`)
+	title := "CIS"
+	suite.storedProject(project)
+	suite.mockProjectRepo.EXPECT().Update(gomock.Any()).Return(nil)
+
+	resp, err := suite.projectService.UpdateProject(project.ID, &service.UpdateProjectRequest{Title: &title, UpdatedBy: "jdoe", ExpectedVersion: 2})
+	suite.Require().NoError(err)
+	suite.Equal("CIS", resp.Title)
+	suite.JSONEq(string(project.Metadata), string(resp.Metadata))
+	suite.Equal("jdoe", resp.UpdatedBy)
+}
+
+func (suite *ProjectServiceTestSuite) TestUpdateProject_Errors() {
+	project := newProject(2, "")
+	suite.storedProject(project).Times(3)
+
+	_, err := suite.projectService.UpdateProject(project.ID, &service.UpdateProjectRequest{ExpectedVersion: 1})
+	suite.ErrorIs(err, apperrors.ErrVersionConflict)
+
+	_, err = suite.projectService.UpdateProject(project.ID, &service.UpdateProjectRequest{Metadata: json.RawMessage(`{"landscape-links":{"Grafana":"https://grafana.{region}"}}`)})
+	suite.True(apperrors.IsValidation(err))
+	suite.ErrorContains(err, "{region}")
+
+	suite.mockProjectRepo.EXPECT().Update(gomock.Any()).Return(repository.ErrVersionConflict)
+	_, err = suite.projectService.UpdateProject(project.ID, &service.UpdateProjectRequest{ExpectedVersion: 2})
+	suite.ErrorIs(err, apperrors.ErrVersionConflict)
+
+	suite.mockProjectRepo.EXPECT().GetByID(gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
+	_, err = suite.projectService.UpdateProject(uuid.New(), &service.UpdateProjectRequest{})
+	suite.ErrorIs(err, apperrors.ErrProjectNotFound)
+}
+
+func (suite *ProjectServiceTestSuite) TestDeleteProject() {
+	project := newProject(1, "")
+	suite.storedProject(project).Times(3)
+
+	suite.mockComponentRepo.EXPECT().GetByProjectID(project.ID, 1, 0).Return(nil, int64(0), nil)
+	suite.mockLandscapeRepo.EXPECT().GetLandscapesByProjectID(project.ID, 1, 0).Return([]models.Landscape{{}}, int64(2), nil)
+	suite.ErrorIs(suite.projectService.DeleteProject(project.ID), apperrors.ErrProjectHasResources)
+
+	suite.mockComponentRepo.EXPECT().GetByProjectID(project.ID, 1, 0).Return([]models.Component{{}}, int64(1), nil)
+	suite.mockLandscapeRepo.EXPECT().GetLandscapesByProjectID(project.ID, 1, 0).Return(nil, int64(0), nil)
+	suite.ErrorIs(suite.projectService.DeleteProject(project.ID), apperrors.ErrProjectHasResources)
+
+	suite.mockComponentRepo.EXPECT().GetByProjectID(project.ID, 1, 0).Return(nil, int64(0), nil)
+	suite.mockLandscapeRepo.EXPECT().GetLandscapesByProjectID(project.ID, 1, 0).Return(nil, int64(0), nil)
+	suite.mockProjectRepo.EXPECT().Delete(project.ID).Return(nil)
+	suite.NoError(suite.projectService.DeleteProject(project.ID))
+}
+
+func (suite *ProjectServiceTestSuite) TestGetProjectSettings() {
+	project := newProject(3, `{"alerts-repo":"https://github.example.com/org/repo/tree/main/alerts","landscape-links":{"Grafana":"https://grafana.{domain}"},"owner":"cis"}`)
+	suite.storedProject(project)
+
+	resp, err := suite.projectService.GetProjectSettings(project.ID)
+	suite.Require().NoError(err)
+	suite.Equal("https://github.example.com/org/repo/tree/main/alerts", resp.AlertsRepo)
+	suite.Equal(map[string]string{"Grafana": "https://grafana.{domain}"}, resp.LandscapeLinks)
+	suite.Empty(resp.SonarHost)
+	suite.Equal(int64(3), resp.Version)
+}
+
+func (suite *ProjectServiceTestSuite) TestUpdateProjectSettings() {
+	project := newProject(3, `{"alerts-repo":"https://github.example.com/org/repo/tree/main/alerts","owner":"cis"}`)
+	suite.storedProject(project)
+	suite.mockProjectRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(updated *models.Project) error {
+		// The alerts repo is unset, other metadata keys are kept
+		suite.JSONEq(`{"owner":"cis","sonar-host":"https://sonar.example.com","landscape-links":{"Dynatrace":"https://dt.{environment}.example.com/{name}"}}`, string(updated.Metadata))
+		suite.Equal("jdoe", updated.UpdatedBy)
+		updated.Version = 4
+		return nil
+	})
+
+	resp, err := suite.projectService.UpdateProjectSettings(project.ID, &service.UpdateProjectSettingsRequest{
+		ProjectSettings: service.ProjectSettings{
+			LandscapeLinks: map[string]string{"Dynatrace": "https://dt.{environment}.example.com/{name}"},
+			SonarHost:      "https://sonar.example.com",
+		},
+		UpdatedBy:       "jdoe",
+		ExpectedVersion: 3,
+	})
+	suite.Require().NoError(err)
+	suite.Empty(resp.AlertsRepo)
+	suite.Equal("https://sonar.example.com", resp.SonarHost)
+	suite.Equal(int64(4), resp.Version)
+}
+
+func (suite *ProjectServiceTestSuite) TestUpdateProjectSettings_Invalid() {
+	project := newProject(3, "")
+	suite.storedProject(project).Times(3)
+
+	cases := []service.ProjectSettings{
+		{AlertsRepo: "https://github.example.com/org"},
+		{LandscapeLinks: map[string]string{"Grafana": "http://grafana.{domain}"}},
+		{LandscapeLinks: map[string]string{"Grafana": "https://grafana.{landscape}"}},
+	}
+	for _, settings := range cases {
+		_, err := suite.projectService.UpdateProjectSettings(project.ID, &service.UpdateProjectSettingsRequest{ProjectSettings: settings})
+		suite.True(apperrors.IsValidation(err), "%+v: %v", settings, err)
+	}
+}
+
+func TestProjectServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ProjectServiceTestSuite))
 }
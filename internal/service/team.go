@@ -7,6 +7,7 @@ import (
 
 	"developer-portal-backend/internal/database/models"
 	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/metaschema"
 	"developer-portal-backend/internal/repository"
 
 	"github.com/go-playground/validator/v10"
@@ -23,10 +24,11 @@ type TeamService struct {
 	linkRepo         repository.LinkRepositoryInterface
 	componentRepo    *repository.ComponentRepository
 	validator        *validator.Validate
+	metadataSchemas  *metaschema.Registry
 }
 
 // NewTeamService creates a new team service
-func NewTeamService(repo *repository.TeamRepository, groupRepo repository.GroupRepositoryInterface, orgRepo *repository.OrganizationRepository, userRepo *repository.UserRepository, linkRepo repository.LinkRepositoryInterface, compRepo *repository.ComponentRepository, validator *validator.Validate) *TeamService {
+func NewTeamService(repo *repository.TeamRepository, groupRepo repository.GroupRepositoryInterface, orgRepo *repository.OrganizationRepository, userRepo *repository.UserRepository, linkRepo repository.LinkRepositoryInterface, compRepo *repository.ComponentRepository, validator *validator.Validate, metadataSchemas *metaschema.Registry) *TeamService {
 	return &TeamService{
 		repo:             repo,
 		groupRepo:        groupRepo,
@@ -35,6 +37,7 @@ func NewTeamService(repo *repository.TeamRepository, groupRepo repository.GroupR
 		linkRepo:         linkRepo,
 		componentRepo:    compRepo,
 		validator:        validator,
+		metadataSchemas:  metadataSchemas,
 	}
 }
 
@@ -72,6 +75,7 @@ type TeamResponse struct {
 	Email          string          `json:"email"`
 	PictureURL     string          `json:"picture_url"`
 	Metadata       json.RawMessage `json:"metadata" swaggertype:"object"`
+	Version        int64           `json:"version"` // for If-Match, see the ETag header
 	CreatedAt      string          `json:"created_at"`
 	UpdatedAt      string          `json:"updated_at"`
 }
@@ -343,53 +347,70 @@ func (s *TeamService) toResponse(team *models.Team) *TeamResponse {
 		Email:          team.Email,
 		PictureURL:     team.PictureURL,
 		Metadata:       team.Metadata,
+		Version:        team.Version,
 	}
 }
 
-// UpdateTeamMetadata updates only specific fields in the team's metadata (merge, not replace)
-func (s *TeamService) UpdateTeamMetadata(id uuid.UUID, newMetadata json.RawMessage) (*TeamResponse, error) {
-	// Get the team first to ensure it exists
-	team, err := s.repo.GetByID(id)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, apperrors.ErrTeamNotFound
-		}
-		return nil, fmt.Errorf("failed to get team: %w", err)
-	}
-
-	// Parse existing metadata
-	var existingMeta map[string]interface{}
-	if len(team.Metadata) > 0 {
-		if err := json.Unmarshal(team.Metadata, &existingMeta); err != nil {
-			return nil, fmt.Errorf("failed to parse existing metadata: %w", err)
-		}
-	} else {
-		existingMeta = make(map[string]interface{})
-	}
-
+// UpdateTeamMetadata updates only specific fields in the team's metadata (merge, not replace).
+// A non-zero expectedVersion must match the team's version; otherwise the merge is applied to the
+// latest version, retrying if the team is updated concurrently.
+func (s *TeamService) UpdateTeamMetadata(id uuid.UUID, newMetadata json.RawMessage, expectedVersion int64) (*TeamResponse, error) {
 	// Parse new metadata to merge
 	var newMeta map[string]interface{}
 	if err := json.Unmarshal(newMetadata, &newMeta); err != nil {
 		return nil, fmt.Errorf("failed to parse new metadata: %w", err)
 	}
 
-	// Merge: update existing fields, add new fields, preserve unmentioned fields
-	for key, value := range newMeta {
-		existingMeta[key] = value
-	}
+	var team *models.Team
+	err := retryOnConflict(expectedVersion, func() error {
+		// Get the team first to ensure it exists
+		var err error
+		team, err = s.repo.GetByID(id)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperrors.ErrTeamNotFound
+			}
+			return fmt.Errorf("failed to get team: %w", err)
+		}
+		if err := checkVersion(expectedVersion, team.Version); err != nil {
+			return err
+		}
 
-	// Marshal back to JSON
-	mergedMetadata, err := json.Marshal(existingMeta)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal merged metadata: %w", err)
-	}
+		// Parse existing metadata
+		var existingMeta map[string]interface{}
+		if len(team.Metadata) > 0 {
+			if err := json.Unmarshal(team.Metadata, &existingMeta); err != nil {
+				return fmt.Errorf("failed to parse existing metadata: %w", err)
+			}
+		} else {
+			existingMeta = make(map[string]interface{})
+		}
 
-	// Update the metadata field
-	team.Metadata = mergedMetadata
+		// Merge: update existing fields, add new fields, preserve unmentioned fields
+		for key, value := range newMeta {
+			existingMeta[key] = value
+		}
 
-	// Save the updated team
-	if err := s.repo.Update(team); err != nil {
-		return nil, fmt.Errorf("failed to update team metadata: %w", err)
+		// Marshal back to JSON
+		mergedMetadata, err := json.Marshal(existingMeta)
+		if err != nil {
+			return fmt.Errorf("failed to marshal merged metadata: %w", err)
+		}
+		if err := s.metadataSchemas.Validate(metaschema.EntityTeam, "", mergedMetadata); err != nil {
+			return err
+		}
+
+		// Update the metadata field
+		team.Metadata = mergedMetadata
+
+		// Save the updated team unless someone else updated it since it was read
+		if err := s.repo.Update(team); err != nil {
+			return fmt.Errorf("failed to update team metadata: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Get organization ID through group (for backwards compatibility)
@@ -409,5 +430,6 @@ func (s *TeamService) UpdateTeamMetadata(id uuid.UUID, newMetadata json.RawMessa
 		Email:          team.Email,
 		PictureURL:     team.PictureURL,
 		Metadata:       team.Metadata,
+		Version:        team.Version,
 	}, nil
 }
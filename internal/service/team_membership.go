@@ -0,0 +1,258 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TeamMembershipService handles adding, changing and removing team members. Every change is
+// recorded by the user repository as a time-ranged membership.
+type TeamMembershipService struct {
+	userRepo repository.UserRepositoryInterface
+	teamRepo repository.TeamRepositoryInterface
+}
+
+// Ensure TeamMembershipService implements TeamMembershipServiceInterface
+var _ TeamMembershipServiceInterface = (*TeamMembershipService)(nil)
+
+// NewTeamMembershipService creates a new team membership service
+func NewTeamMembershipService(
+	userRepo repository.UserRepositoryInterface,
+	teamRepo repository.TeamRepositoryInterface,
+) *TeamMembershipService {
+	return &TeamMembershipService{
+		userRepo: userRepo,
+		teamRepo: teamRepo,
+	}
+}
+
+// AddTeamMemberRequest represents the payload for adding a user to a team. A user in another
+// team leaves that team.
+type AddTeamMemberRequest struct {
+	UserID     string            `json:"user_id" binding:"required"`                // I/C/D user
+	TeamRole   models.TeamRole   `json:"team_role,omitempty" example:"member"`      // defaults to member
+	TeamDomain models.TeamDomain `json:"team_domain,omitempty" example:"developer"` // defaults to the user's current domain
+	ChangedBy  string            `json:"-"`                                         // derived from bearer token
+}
+
+// UpdateTeamMemberRequest represents the payload for changing the role or domain of a team member
+type UpdateTeamMemberRequest struct {
+	TeamRole   *models.TeamRole   `json:"team_role,omitempty" example:"scm"`
+	TeamDomain *models.TeamDomain `json:"team_domain,omitempty" example:"devops"`
+	ChangedBy  string             `json:"-"` // derived from bearer token
+	// ExpectedVersion is the user version the caller last read (from If-Match); 0 updates the latest version
+	ExpectedVersion int64 `json:"-"`
+}
+
+// TeamMemberResponse represents a user during (part of) their membership in a team
+type TeamMemberResponse struct {
+	UserID     string     `json:"user_id"`
+	UUID       uuid.UUID  `json:"uuid"`
+	FirstName  string     `json:"first_name"`
+	LastName   string     `json:"last_name"`
+	Email      string     `json:"email"`
+	TeamRole   string     `json:"team_role"`
+	TeamDomain string     `json:"team_domain"`
+	Since      time.Time  `json:"since"`
+	Until      *time.Time `json:"until,omitempty"` // unset for current members
+}
+
+// GetTeamMembers returns the users who were members of a team at any time between from and to,
+// each with their last membership in that period. Pass the same time twice for the members at
+// that time.
+func (s *TeamMembershipService) GetTeamMembers(teamID uuid.UUID, from, to time.Time) ([]TeamMemberResponse, error) {
+	if to.Before(from) {
+		return nil, apperrors.NewValidationError("to", "must not be before from")
+	}
+	if err := s.checkTeam(teamID); err != nil {
+		return nil, err
+	}
+
+	memberships, err := s.userRepo.GetTeamMemberships(teamID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team memberships: %w", err)
+	}
+
+	// Memberships are ordered by start, so a later one of the same user replaces the earlier one
+	members := []TeamMemberResponse{}
+	index := map[uuid.UUID]int{}
+	for _, m := range memberships {
+		member := toTeamMemberResponse(&m)
+		if i, ok := index[m.UserID]; ok {
+			members[i] = member
+			continue
+		}
+		index[m.UserID] = len(members)
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+// AddTeamMember makes a user a member of a team
+func (s *TeamMembershipService) AddTeamMember(teamID uuid.UUID, req *AddTeamMemberRequest) (*UserResponse, error) {
+	if req.TeamRole == "" {
+		req.TeamRole = models.TeamRoleMember
+	}
+	var domain *models.TeamDomain
+	if req.TeamDomain != "" {
+		domain = &req.TeamDomain
+	}
+	if err := validateTeamRoleAndDomain(&req.TeamRole, domain); err != nil {
+		return nil, err
+	}
+	if err := s.checkTeam(teamID); err != nil {
+		return nil, err
+	}
+
+	return s.updateUser(req.UserID, 0, req.ChangedBy, func(user *models.User) error {
+		if user.TeamID != nil && *user.TeamID == teamID {
+			return apperrors.ErrTeamMemberExists
+		}
+		user.TeamID = &teamID
+		user.TeamRole = req.TeamRole
+		if req.TeamDomain != "" {
+			user.TeamDomain = req.TeamDomain
+		}
+		return nil
+	})
+}
+
+// UpdateTeamMember changes the role or domain of a member of a team
+func (s *TeamMembershipService) UpdateTeamMember(teamID uuid.UUID, userID string, req *UpdateTeamMemberRequest) (*UserResponse, error) {
+	if err := validateTeamRoleAndDomain(req.TeamRole, req.TeamDomain); err != nil {
+		return nil, err
+	}
+
+	return s.updateUser(userID, req.ExpectedVersion, req.ChangedBy, func(user *models.User) error {
+		if user.TeamID == nil || *user.TeamID != teamID {
+			return apperrors.ErrTeamMemberNotFound
+		}
+		if req.TeamRole != nil {
+			user.TeamRole = *req.TeamRole
+		}
+		if req.TeamDomain != nil {
+			user.TeamDomain = *req.TeamDomain
+		}
+		return nil
+	})
+}
+
+// RemoveTeamMember removes a user from a team, leaving them without a team
+func (s *TeamMembershipService) RemoveTeamMember(teamID uuid.UUID, userID string, removedBy string) error {
+	_, err := s.updateUser(userID, 0, removedBy, func(user *models.User) error {
+		if user.TeamID == nil || *user.TeamID != teamID {
+			return apperrors.ErrTeamMemberNotFound
+		}
+		user.TeamID = nil
+		return nil
+	})
+	return err
+}
+
+// GetMembershipHistory returns the team memberships of a user, newest first
+func (s *TeamMembershipService) GetMembershipHistory(userID string) ([]models.TeamMembership, error) {
+	user, err := s.getUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	memberships, err := s.userRepo.GetMemberships(user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team memberships: %w", err)
+	}
+	return memberships, nil
+}
+
+// updateUser applies change to the latest or the expected version of a user and saves it
+func (s *TeamMembershipService) updateUser(userID string, expectedVersion int64, changedBy string, change func(*models.User) error) (*UserResponse, error) {
+	var user *models.User
+	err := retryOnConflict(expectedVersion, func() error {
+		var err error
+		user, err = s.getUser(userID)
+		if err != nil {
+			return err
+		}
+		if err := checkVersion(expectedVersion, user.Version); err != nil {
+			return err
+		}
+
+		if err := change(user); err != nil {
+			return err
+		}
+		user.UpdatedBy = changedBy
+
+		if err := s.userRepo.Update(user); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperrors.ErrUserNotFound
+			}
+			if errors.Is(err, repository.ErrVersionConflict) {
+				return err
+			}
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toUserResponse(user), nil
+}
+
+// getUser retrieves a user by their I/C/D user ID
+func (s *TeamMembershipService) getUser(userID string) (*models.User, error) {
+	user, err := s.userRepo.GetByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
+
+// checkTeam returns apperrors.ErrTeamNotFound if the team does not exist
+func (s *TeamMembershipService) checkTeam(teamID uuid.UUID) error {
+	if _, err := s.teamRepo.GetByID(teamID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrTeamNotFound
+		}
+		return fmt.Errorf("failed to get team: %w", err)
+	}
+	return nil
+}
+
+// validateTeamRoleAndDomain checks the given role and domain, if not nil
+func validateTeamRoleAndDomain(role *models.TeamRole, domain *models.TeamDomain) error {
+	if role != nil && !role.IsValid() {
+		return apperrors.NewValidationError("team_role", fmt.Sprintf("unknown team role %q", *role))
+	}
+	if domain != nil && !domain.IsValid() {
+		return apperrors.NewValidationError("team_domain", fmt.Sprintf("unknown team domain %q", *domain))
+	}
+	return nil
+}
+
+func toTeamMemberResponse(m *models.TeamMembership) TeamMemberResponse {
+	member := TeamMemberResponse{
+		UUID:       m.UserID,
+		TeamRole:   string(m.TeamRole),
+		TeamDomain: string(m.TeamDomain),
+		Since:      m.StartedAt,
+		Until:      m.EndedAt,
+	}
+	// The user is missing if it was purged from the trash
+	if m.User != nil {
+		member.UserID = m.User.UserID
+		member.FirstName = m.User.FirstName
+		member.LastName = m.User.LastName
+		member.Email = m.User.Email
+	}
+	return member
+}
@@ -0,0 +1,187 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/repository"
+	"developer-portal-backend/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type TeamMembershipServiceTestSuite struct {
+	suite.Suite
+	ctrl              *gomock.Controller
+	mockUserRepo      *mocks.MockUserRepositoryInterface
+	mockTeamRepo      *mocks.MockTeamRepositoryInterface
+	membershipService *service.TeamMembershipService
+	team              *models.Team
+}
+
+func (suite *TeamMembershipServiceTestSuite) SetupTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockUserRepo = mocks.NewMockUserRepositoryInterface(suite.ctrl)
+	suite.mockTeamRepo = mocks.NewMockTeamRepositoryInterface(suite.ctrl)
+	suite.membershipService = service.NewTeamMembershipService(suite.mockUserRepo, suite.mockTeamRepo)
+	suite.team = &models.Team{BaseModel: models.BaseModel{ID: uuid.New(), Name: "team-coe"}}
+}
+
+func (suite *TeamMembershipServiceTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+// storedUser makes GetByUserID return a fresh copy of user on every call, like the database would
+func (suite *TeamMembershipServiceTestSuite) storedUser(user *models.User) *gomock.Call {
+	return suite.mockUserRepo.EXPECT().GetByUserID(user.UserID).DoAndReturn(func(string) (*models.User, error) {
+		stored := *user
+		return &stored, nil
+	})
+}
+
+func (suite *TeamMembershipServiceTestSuite) newUser(teamID *uuid.UUID) *models.User {
+	return &models.User{
+		BaseModel:  models.BaseModel{ID: uuid.New(), Version: 3},
+		UserID:     "I123456",
+		FirstName:  "Jane",
+		LastName:   "Doe",
+		TeamID:     teamID,
+		TeamRole:   models.TeamRoleMember,
+		TeamDomain: models.TeamDomainDeveloper,
+	}
+}
+
+func (suite *TeamMembershipServiceTestSuite) TestGetTeamMembers() {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	jane, john := uuid.New(), uuid.New()
+	ended := day.Add(10 * time.Hour)
+	suite.mockTeamRepo.EXPECT().GetByID(suite.team.ID).Return(suite.team, nil)
+	suite.mockUserRepo.EXPECT().GetTeamMemberships(suite.team.ID, day, day.Add(24*time.Hour)).Return([]models.TeamMembership{
+		{UserID: jane, TeamRole: models.TeamRoleMember, StartedAt: day.AddDate(0, -1, 0), EndedAt: &ended, User: &models.User{UserID: "I123456"}},
+		{UserID: john, TeamRole: models.TeamRoleMember, StartedAt: day.AddDate(-1, 0, 0)},
+		{UserID: jane, TeamRole: models.TeamRoleScM, StartedAt: ended, User: &models.User{UserID: "I123456"}},
+	}, nil)
+
+	members, err := suite.membershipService.GetTeamMembers(suite.team.ID, day, day.Add(24*time.Hour))
+	suite.Require().NoError(err)
+	suite.Require().Len(members, 2)
+	// Jane's role change during the day leaves her last membership
+	suite.Equal("I123456", members[0].UserID)
+	suite.Equal("scm", members[0].TeamRole)
+	suite.Nil(members[0].Until)
+	// John was purged from the trash since
+	suite.Equal(john, members[1].UUID)
+	suite.Empty(members[1].UserID)
+}
+
+func (suite *TeamMembershipServiceTestSuite) TestGetTeamMembers_Errors() {
+	now := time.Now()
+	_, err := suite.membershipService.GetTeamMembers(suite.team.ID, now, now.Add(-time.Hour))
+	suite.True(apperrors.IsValidation(err))
+
+	suite.mockTeamRepo.EXPECT().GetByID(gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
+	_, err = suite.membershipService.GetTeamMembers(uuid.New(), now, now)
+	suite.ErrorIs(err, apperrors.ErrTeamNotFound)
+}
+
+func (suite *TeamMembershipServiceTestSuite) TestAddTeamMember() {
+	otherTeam := uuid.New()
+	user := suite.newUser(&otherTeam)
+	suite.mockTeamRepo.EXPECT().GetByID(suite.team.ID).Return(suite.team, nil)
+	suite.storedUser(user)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(updated *models.User) error {
+		suite.Equal(suite.team.ID, *updated.TeamID)
+		suite.Equal(models.TeamRoleMember, updated.TeamRole)
+		suite.Equal(models.TeamDomainDeveloper, updated.TeamDomain)
+		suite.Equal("jdoe", updated.UpdatedBy)
+		updated.Version++
+		return nil
+	})
+
+	resp, err := suite.membershipService.AddTeamMember(suite.team.ID, &service.AddTeamMemberRequest{UserID: user.UserID, ChangedBy: "jdoe"})
+	suite.Require().NoError(err)
+	suite.Equal(suite.team.ID, *resp.TeamID)
+	suite.Equal(int64(4), resp.Version)
+}
+
+func (suite *TeamMembershipServiceTestSuite) TestAddTeamMember_Errors() {
+	_, err := suite.membershipService.AddTeamMember(suite.team.ID, &service.AddTeamMemberRequest{UserID: "I123456", TeamRole: "lead"})
+	suite.True(apperrors.IsValidation(err))
+	suite.ErrorContains(err, "lead")
+
+	user := suite.newUser(&suite.team.ID)
+	suite.mockTeamRepo.EXPECT().GetByID(suite.team.ID).Return(suite.team, nil).Times(2)
+	suite.storedUser(user)
+	_, err = suite.membershipService.AddTeamMember(suite.team.ID, &service.AddTeamMemberRequest{UserID: user.UserID})
+	suite.ErrorIs(err, apperrors.ErrTeamMemberExists)
+
+	suite.mockUserRepo.EXPECT().GetByUserID("I000000").Return(nil, gorm.ErrRecordNotFound)
+	_, err = suite.membershipService.AddTeamMember(suite.team.ID, &service.AddTeamMemberRequest{UserID: "I000000"})
+	suite.ErrorIs(err, apperrors.ErrUserNotFound)
+}
+
+func (suite *TeamMembershipServiceTestSuite) TestUpdateTeamMember() {
+	user := suite.newUser(&suite.team.ID)
+	role, domain := models.TeamRoleManager, models.TeamDomainArchitect
+	suite.storedUser(user).Times(2)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any()).Return(repository.ErrVersionConflict)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(updated *models.User) error {
+		suite.Equal(models.TeamRoleManager, updated.TeamRole)
+		suite.Equal(models.TeamDomainArchitect, updated.TeamDomain)
+		return nil
+	})
+
+	// A concurrent change is retried without If-Match
+	resp, err := suite.membershipService.UpdateTeamMember(suite.team.ID, user.UserID, &service.UpdateTeamMemberRequest{TeamRole: &role, TeamDomain: &domain})
+	suite.Require().NoError(err)
+	suite.Equal("manager", resp.TeamRole)
+	suite.Equal("architect", resp.TeamDomain)
+}
+
+func (suite *TeamMembershipServiceTestSuite) TestUpdateTeamMember_Errors() {
+	user := suite.newUser(&suite.team.ID)
+	suite.storedUser(user).Times(2)
+
+	_, err := suite.membershipService.UpdateTeamMember(suite.team.ID, user.UserID, &service.UpdateTeamMemberRequest{ExpectedVersion: 2})
+	suite.ErrorIs(err, apperrors.ErrVersionConflict)
+
+	_, err = suite.membershipService.UpdateTeamMember(uuid.New(), user.UserID, &service.UpdateTeamMemberRequest{})
+	suite.ErrorIs(err, apperrors.ErrTeamMemberNotFound)
+
+	domain := models.TeamDomain("qa")
+	_, err = suite.membershipService.UpdateTeamMember(suite.team.ID, user.UserID, &service.UpdateTeamMemberRequest{TeamDomain: &domain})
+	suite.True(apperrors.IsValidation(err))
+}
+
+func (suite *TeamMembershipServiceTestSuite) TestRemoveTeamMember() {
+	user := suite.newUser(&suite.team.ID)
+	suite.storedUser(user).Times(2)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(updated *models.User) error {
+		suite.Nil(updated.TeamID)
+		suite.Equal("jdoe", updated.UpdatedBy)
+		return nil
+	})
+
+	suite.NoError(suite.membershipService.RemoveTeamMember(suite.team.ID, user.UserID, "jdoe"))
+	suite.ErrorIs(suite.membershipService.RemoveTeamMember(uuid.New(), user.UserID, "jdoe"), apperrors.ErrTeamMemberNotFound)
+}
+
+func (suite *TeamMembershipServiceTestSuite) TestGetMembershipHistory() {
+	user := suite.newUser(&suite.team.ID)
+	suite.storedUser(user)
+	suite.mockUserRepo.EXPECT().GetMemberships(user.ID).Return([]models.TeamMembership{{UserID: user.ID, TeamID: suite.team.ID}}, nil)
+
+	memberships, err := suite.membershipService.GetMembershipHistory(user.UserID)
+	suite.Require().NoError(err)
+	suite.Len(memberships, 1)
+}
+
+func TestTeamMembershipServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(TeamMembershipServiceTestSuite))
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/logger"
+	"developer-portal-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// TrashService lists and restores soft-deleted catalog entities and purges them after the retention period
+type TrashService struct {
+	repo repository.TrashRepositoryInterface
+	// retention is how long deleted entities are kept. Zero keeps them forever.
+	retention time.Duration
+}
+
+// Ensure TrashService implements TrashServiceInterface
+var _ TrashServiceInterface = (*TrashService)(nil)
+
+// NewTrashService creates a new TrashService
+func NewTrashService(repo repository.TrashRepositoryInterface, retention time.Duration) *TrashService {
+	return &TrashService{
+		repo:      repo,
+		retention: retention,
+	}
+}
+
+// TrashItemResponse represents a deleted entity in API responses
+type TrashItemResponse struct {
+	repository.TrashItem
+	// PurgeAt is when the retention job removes the entity for good, unset if it is kept forever
+	PurgeAt *time.Time `json:"purge_at,omitempty"`
+}
+
+// TrashListResponse represents a paginated list of deleted entities
+type TrashListResponse struct {
+	Items    []TrashItemResponse `json:"items"`
+	Total    int64               `json:"total"`
+	Page     int                 `json:"page"`
+	PageSize int                 `json:"page_size"`
+}
+
+// parseTrashKind validates the entity type of a trash request. An empty type is returned as is.
+func parseTrashKind(kind string) (repository.TrashKind, error) {
+	parsed := repository.TrashKind(kind)
+	if kind != "" && !parsed.IsValid() {
+		return "", apperrors.NewValidationError("type", fmt.Sprintf("unknown type %q, expected one of %v", kind, repository.TrashKinds))
+	}
+	return parsed, nil
+}
+
+// ListTrash retrieves deleted entities of the given type, or of all types if it is empty, most recently deleted first
+func (s *TrashService) ListTrash(kind string, page, pageSize int) (*TrashListResponse, error) {
+	parsed, err := parseTrashKind(kind)
+	if err != nil {
+		return nil, err
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	items, total, err := s.repo.ListDeleted(parsed, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted entities: %w", err)
+	}
+
+	responses := make([]TrashItemResponse, len(items))
+	for i, item := range items {
+		responses[i] = TrashItemResponse{TrashItem: item}
+		if s.retention > 0 {
+			purgeAt := item.DeletedAt.Add(s.retention)
+			responses[i].PurgeAt = &purgeAt
+		}
+	}
+
+	return &TrashListResponse{
+		Items:    responses,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	}, nil
+}
+
+// Restore undeletes an entity. Its parent, e.g. the group of a team, has to be restored first.
+func (s *TrashService) Restore(kind string, id uuid.UUID) error {
+	parsed, err := parseTrashKind(kind)
+	if err != nil {
+		return err
+	}
+	if parsed == "" {
+		return apperrors.NewValidationError("type", "is required")
+	}
+
+	item, err := s.repo.GetDeleted(parsed, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apperrors.ErrTrashItemNotFound
+		}
+		return fmt.Errorf("failed to get deleted %s: %w", parsed, err)
+	}
+
+	if item.ParentID != nil {
+		active, err := s.repo.IsActive(item.ParentKind, *item.ParentID)
+		if err != nil {
+			return fmt.Errorf("failed to check %s %s: %w", item.ParentKind, item.ParentID, err)
+		}
+		if !active {
+			return apperrors.NewValidationError(string(item.ParentKind),
+				fmt.Sprintf("%s %s is deleted; restore it first", item.ParentKind, item.ParentID))
+		}
+	}
+
+	if err := s.repo.Restore(parsed, id); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return apperrors.ErrTrashItemNotFound
+		case errors.Is(err, gorm.ErrDuplicatedKey) && parsed == repository.TrashKindUser:
+			return apperrors.ErrUserExists
+		case errors.Is(err, gorm.ErrDuplicatedKey):
+			return &apperrors.AlreadyExistsError{Entity: string(parsed)}
+		}
+		return fmt.Errorf("failed to restore %s: %w", parsed, err)
+	}
+	return nil
+}
+
+// PurgeExpired permanently removes entities deleted longer ago than the retention period and returns
+// how many were removed
+func (s *TrashService) PurgeExpired() (int64, error) {
+	if s.retention <= 0 {
+		return 0, nil
+	}
+	return s.repo.PurgeDeletedBefore(time.Now().Add(-s.retention))
+}
+
+// StartTrashPurge periodically purges expired entities until ctx is cancelled. Nothing is started
+// when deleted entities are kept forever.
+func (s *TrashService) StartTrashPurge(ctx context.Context, interval time.Duration) {
+	if s.retention <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				removed, err := s.PurgeExpired()
+				if err != nil {
+					logger.WithContext(ctx).Warnf("trash purge failed: %v", err)
+				} else if removed > 0 {
+					logger.WithContext(ctx).Infof("Purged %d deleted entities past the retention period", removed)
+				}
+			}
+		}
+	}()
+}
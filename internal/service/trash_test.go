@@ -0,0 +1,141 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/repository"
+	"developer-portal-backend/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type TrashServiceTestSuite struct {
+	suite.Suite
+	ctrl          *gomock.Controller
+	mockTrashRepo *mocks.MockTrashRepositoryInterface
+	trashService  *service.TrashService
+}
+
+func (suite *TrashServiceTestSuite) SetupTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockTrashRepo = mocks.NewMockTrashRepositoryInterface(suite.ctrl)
+	suite.trashService = service.NewTrashService(suite.mockTrashRepo, 30*24*time.Hour)
+}
+
+func (suite *TrashServiceTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *TrashServiceTestSuite) TestListTrash() {
+	deletedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	items := []repository.TrashItem{{Kind: repository.TrashKindTeam, ID: uuid.New(), Name: "team-a", DeletedAt: deletedAt}}
+	suite.mockTrashRepo.EXPECT().ListDeleted(repository.TrashKindTeam, 10, 10).Return(items, int64(11), nil)
+
+	resp, err := suite.trashService.ListTrash("team", 2, 10)
+	suite.Require().NoError(err)
+	suite.Equal(int64(11), resp.Total)
+	suite.Require().Len(resp.Items, 1)
+	suite.Equal("team-a", resp.Items[0].Name)
+	suite.Equal(deletedAt.Add(30*24*time.Hour), *resp.Items[0].PurgeAt)
+}
+
+func (suite *TrashServiceTestSuite) TestListTrash_AllTypesDefaultPagination() {
+	suite.mockTrashRepo.EXPECT().ListDeleted(repository.TrashKind(""), 20, 0).Return(nil, int64(0), nil)
+
+	resp, err := suite.trashService.ListTrash("", 0, 0)
+	suite.Require().NoError(err)
+	suite.Equal(1, resp.Page)
+	suite.Equal(20, resp.PageSize)
+	suite.Empty(resp.Items)
+}
+
+func (suite *TrashServiceTestSuite) TestListTrash_KeptForever() {
+	items := []repository.TrashItem{{Kind: repository.TrashKindLink, ID: uuid.New(), DeletedAt: time.Now()}}
+	suite.mockTrashRepo.EXPECT().ListDeleted(repository.TrashKindLink, 20, 0).Return(items, int64(1), nil)
+
+	resp, err := service.NewTrashService(suite.mockTrashRepo, 0).ListTrash("link", 1, 20)
+	suite.Require().NoError(err)
+	suite.Nil(resp.Items[0].PurgeAt)
+}
+
+func (suite *TrashServiceTestSuite) TestListTrash_UnknownType() {
+	_, err := suite.trashService.ListTrash("documentation", 1, 20)
+	suite.True(apperrors.IsValidation(err))
+}
+
+func (suite *TrashServiceTestSuite) TestRestore() {
+	id, groupID := uuid.New(), uuid.New()
+	suite.mockTrashRepo.EXPECT().GetDeleted(repository.TrashKindTeam, id).
+		Return(&repository.TrashItem{Kind: repository.TrashKindTeam, ID: id, ParentKind: repository.TrashKindGroup, ParentID: &groupID}, nil)
+	suite.mockTrashRepo.EXPECT().IsActive(repository.TrashKindGroup, groupID).Return(true, nil)
+	suite.mockTrashRepo.EXPECT().Restore(repository.TrashKindTeam, id).Return(nil)
+
+	suite.NoError(suite.trashService.Restore("team", id))
+}
+
+func (suite *TrashServiceTestSuite) TestRestore_ParentDeleted() {
+	id, groupID := uuid.New(), uuid.New()
+	suite.mockTrashRepo.EXPECT().GetDeleted(repository.TrashKindTeam, id).
+		Return(&repository.TrashItem{Kind: repository.TrashKindTeam, ID: id, ParentKind: repository.TrashKindGroup, ParentID: &groupID}, nil)
+	suite.mockTrashRepo.EXPECT().IsActive(repository.TrashKindGroup, groupID).Return(false, nil)
+
+	err := suite.trashService.Restore("team", id)
+	suite.True(apperrors.IsValidation(err))
+	suite.Contains(err.Error(), "restore it first")
+}
+
+func (suite *TrashServiceTestSuite) TestRestore_NotDeleted() {
+	id := uuid.New()
+	suite.mockTrashRepo.EXPECT().GetDeleted(repository.TrashKindLink, id).Return(nil, gorm.ErrRecordNotFound)
+
+	suite.ErrorIs(suite.trashService.Restore("link", id), apperrors.ErrTrashItemNotFound)
+}
+
+func (suite *TrashServiceTestSuite) TestRestore_UserEmailTaken() {
+	id := uuid.New()
+	suite.mockTrashRepo.EXPECT().GetDeleted(repository.TrashKindUser, id).
+		Return(&repository.TrashItem{Kind: repository.TrashKindUser, ID: id, ParentKind: repository.TrashKindTeam}, nil)
+	suite.mockTrashRepo.EXPECT().Restore(repository.TrashKindUser, id).Return(gorm.ErrDuplicatedKey)
+
+	suite.ErrorIs(suite.trashService.Restore("user", id), apperrors.ErrUserExists)
+}
+
+func (suite *TrashServiceTestSuite) TestRestore_InvalidType() {
+	suite.True(apperrors.IsValidation(suite.trashService.Restore("", uuid.New())))
+	suite.True(apperrors.IsValidation(suite.trashService.Restore("documentation", uuid.New())))
+}
+
+func (suite *TrashServiceTestSuite) TestPurgeExpired() {
+	suite.mockTrashRepo.EXPECT().PurgeDeletedBefore(gomock.Any()).DoAndReturn(func(cutoff time.Time) (int64, error) {
+		suite.WithinDuration(time.Now().Add(-30*24*time.Hour), cutoff, time.Minute)
+		return 3, nil
+	})
+
+	removed, err := suite.trashService.PurgeExpired()
+	suite.Require().NoError(err)
+	suite.Equal(int64(3), removed)
+}
+
+func (suite *TrashServiceTestSuite) TestPurgeExpired_Errors() {
+	suite.mockTrashRepo.EXPECT().PurgeDeletedBefore(gomock.Any()).Return(int64(0), errors.New("db down"))
+
+	_, err := suite.trashService.PurgeExpired()
+	suite.Error(err)
+}
+
+func (suite *TrashServiceTestSuite) TestPurgeExpired_KeptForever() {
+	removed, err := service.NewTrashService(suite.mockTrashRepo, 0).PurgeExpired()
+	suite.Require().NoError(err)
+	suite.Zero(removed)
+}
+
+func TestTrashServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(TrashServiceTestSuite))
+}
@@ -3,10 +3,13 @@ package service
 import (
 	"developer-portal-backend/internal/database/models"
 	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/metaschema"
 	"developer-portal-backend/internal/repository"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
@@ -14,17 +17,19 @@ import (
 
 // UserService handles business logic for members
 type UserService struct {
-	repo      repository.UserRepositoryInterface
-	linkRepo  repository.LinkRepositoryInterface
-	validator *validator.Validate
+	repo            repository.UserRepositoryInterface
+	linkRepo        repository.LinkRepositoryInterface
+	validator       *validator.Validate
+	metadataSchemas *metaschema.Registry
 }
 
 // NewUserService creates a new member service
-func NewUserService(repo repository.UserRepositoryInterface, linkRepo repository.LinkRepositoryInterface, validator *validator.Validate) *UserService {
+func NewUserService(repo repository.UserRepositoryInterface, linkRepo repository.LinkRepositoryInterface, validator *validator.Validate, metadataSchemas *metaschema.Registry) *UserService {
 	return &UserService{
-		repo:      repo,
-		linkRepo:  linkRepo,
-		validator: validator,
+		repo:            repo,
+		linkRepo:        linkRepo,
+		validator:       validator,
+		metadataSchemas: metadataSchemas,
 	}
 }
 
@@ -64,6 +69,9 @@ type UserResponse struct {
 	Mobile     string     `json:"mobile"`
 	TeamDomain string     `json:"team_domain"` // models.TeamDomain value
 	TeamRole   string     `json:"team_role"`   // models.TeamRole value
+	Version    int64      `json:"version"`     // for If-Match, see the ETag header
+	// DirectoryMissingSince is set while the user is missing from LDAP, see DirectorySyncService
+	DirectoryMissingSince *time.Time `json:"directory_missing_since,omitempty"`
 }
 
 type UserWithLinksResponse struct {
@@ -163,62 +171,74 @@ func (s *UserService) AddFavoriteLinkByUserID(userID string, linkID uuid.UUID) (
 		return nil, apperrors.NewValidationError("link_id", "link_id is required")
 	}
 
-	// Load user by string user_id
-	user, err := s.repo.GetByUserID(userID)
-	if err != nil || user == nil {
-		return nil, apperrors.ErrUserNotFound
-	}
+	// Favorites of the user may change concurrently, e.g. from two browser tabs; none must get lost
+	var user *models.User
+	err := retryOnConflict(0, func() error {
+		// Load user by string user_id
+		var err error
+		user, err = s.repo.GetByUserID(userID)
+		if err != nil || user == nil {
+			return apperrors.ErrUserNotFound
+		}
 
-	// Parse or initialize metadata as a JSON object
-	var meta map[string]interface{}
-	if len(user.Metadata) == 0 {
-		meta = map[string]interface{}{}
-	} else {
-		if err := json.Unmarshal(user.Metadata, &meta); err != nil || meta == nil {
-			// If metadata is invalid/not an object, reset to empty object
+		// Parse or initialize metadata as a JSON object
+		var meta map[string]interface{}
+		if len(user.Metadata) == 0 {
 			meta = map[string]interface{}{}
+		} else {
+			if err := json.Unmarshal(user.Metadata, &meta); err != nil || meta == nil {
+				// If metadata is invalid/not an object, reset to empty object
+				meta = map[string]interface{}{}
+			}
 		}
-	}
 
-	// Ensure favorites array exists
-	var favorites []string
-	if v, ok := meta["favorites"]; ok && v != nil {
-		switch arr := v.(type) {
-		case []interface{}:
-			for _, it := range arr {
-				if str, ok := it.(string); ok && str != "" {
-					favorites = append(favorites, str)
+		// Ensure favorites array exists
+		var favorites []string
+		if v, ok := meta["favorites"]; ok && v != nil {
+			switch arr := v.(type) {
+			case []interface{}:
+				for _, it := range arr {
+					if str, ok := it.(string); ok && str != "" {
+						favorites = append(favorites, str)
+					}
 				}
+			case []string:
+				favorites = append(favorites, arr...)
 			}
-		case []string:
-			favorites = append(favorites, arr...)
 		}
-	}
 
-	// Deduplicate: add linkID if not already present
-	linkStr := linkID.String()
-	exists := false
-	for _, id := range favorites {
-		if id == linkStr {
-			exists = true
-			break
+		// Deduplicate: add linkID if not already present
+		linkStr := linkID.String()
+		exists := false
+		for _, id := range favorites {
+			if id == linkStr {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			favorites = append(favorites, linkStr)
 		}
-	}
-	if !exists {
-		favorites = append(favorites, linkStr)
-	}
 
-	// Save back to metadata
-	meta["favorites"] = favorites
-	bytes, err := json.Marshal(meta)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
-	}
-	user.Metadata = json.RawMessage(bytes)
+		// Save back to metadata
+		meta["favorites"] = favorites
+		bytes, err := json.Marshal(meta)
+		if err != nil {
+			return fmt.Errorf("failed to marshal metadata: %w", err)
+		}
+		if err := s.metadataSchemas.Validate(metaschema.EntityUser, "", bytes); err != nil {
+			return err
+		}
+		user.Metadata = json.RawMessage(bytes)
 
-	// Persist update
-	if err := s.repo.Update(user); err != nil {
-		return nil, fmt.Errorf("failed to update user: %w", err)
+		// Persist update unless the user was updated since it was read
+		if err := s.repo.Update(user); err != nil {
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return s.convertToResponse(user), nil
@@ -233,58 +253,70 @@ func (s *UserService) RemoveFavoriteLinkByUserID(userID string, linkID uuid.UUID
 		return nil, apperrors.NewValidationError("link_id", "link_id is required")
 	}
 
-	// Load user by string user_id
-	user, err := s.repo.GetByUserID(userID)
-	if err != nil || user == nil {
-		return nil, apperrors.ErrUserNotFound
-	}
+	// Favorites of the user may change concurrently, e.g. from two browser tabs; none must get lost
+	var user *models.User
+	err := retryOnConflict(0, func() error {
+		// Load user by string user_id
+		var err error
+		user, err = s.repo.GetByUserID(userID)
+		if err != nil || user == nil {
+			return apperrors.ErrUserNotFound
+		}
 
-	// Parse or initialize metadata as a JSON object
-	var meta map[string]interface{}
-	if len(user.Metadata) == 0 {
-		meta = map[string]interface{}{}
-	} else {
-		if err := json.Unmarshal(user.Metadata, &meta); err != nil || meta == nil {
-			// If metadata is invalid/not an object, reset to empty object
+		// Parse or initialize metadata as a JSON object
+		var meta map[string]interface{}
+		if len(user.Metadata) == 0 {
 			meta = map[string]interface{}{}
+		} else {
+			if err := json.Unmarshal(user.Metadata, &meta); err != nil || meta == nil {
+				// If metadata is invalid/not an object, reset to empty object
+				meta = map[string]interface{}{}
+			}
 		}
-	}
 
-	// Extract favorites array if exists
-	var favorites []string
-	if v, ok := meta["favorites"]; ok && v != nil {
-		switch arr := v.(type) {
-		case []interface{}:
-			for _, it := range arr {
-				if str, ok := it.(string); ok && str != "" {
-					favorites = append(favorites, str)
+		// Extract favorites array if exists
+		var favorites []string
+		if v, ok := meta["favorites"]; ok && v != nil {
+			switch arr := v.(type) {
+			case []interface{}:
+				for _, it := range arr {
+					if str, ok := it.(string); ok && str != "" {
+						favorites = append(favorites, str)
+					}
 				}
+			case []string:
+				favorites = append(favorites, arr...)
 			}
-		case []string:
-			favorites = append(favorites, arr...)
 		}
-	}
 
-	// Filter out the linkID (idempotent if not present)
-	linkStr := linkID.String()
-	filtered := make([]string, 0, len(favorites))
-	for _, id := range favorites {
-		if id != linkStr {
-			filtered = append(filtered, id)
+		// Filter out the linkID (idempotent if not present)
+		linkStr := linkID.String()
+		filtered := make([]string, 0, len(favorites))
+		for _, id := range favorites {
+			if id != linkStr {
+				filtered = append(filtered, id)
+			}
 		}
-	}
 
-	// Save back to metadata
-	meta["favorites"] = filtered
-	bytes, err := json.Marshal(meta)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
-	}
-	user.Metadata = json.RawMessage(bytes)
+		// Save back to metadata
+		meta["favorites"] = filtered
+		bytes, err := json.Marshal(meta)
+		if err != nil {
+			return fmt.Errorf("failed to marshal metadata: %w", err)
+		}
+		if err := s.metadataSchemas.Validate(metaschema.EntityUser, "", bytes); err != nil {
+			return err
+		}
+		user.Metadata = json.RawMessage(bytes)
 
-	// Persist update
-	if err := s.repo.Update(user); err != nil {
-		return nil, fmt.Errorf("failed to update user: %w", err)
+		// Persist update unless the user was updated since it was read
+		if err := s.repo.Update(user); err != nil {
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return s.convertToResponse(user), nil
@@ -529,6 +561,9 @@ func (s *UserService) UpdateUser(id uuid.UUID, req *UpdateUserRequest) (*UserRes
 	}
 
 	if err := s.repo.Update(user); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return nil, apperrors.ErrVersionConflict
+		}
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
 
@@ -547,19 +582,22 @@ func (s *UserService) UpdateUserTeam(userID uuid.UUID, teamID uuid.UUID, updated
 	user.TeamID = &teamID
 	user.UpdatedBy = updatedBy
 	if err := s.repo.Update(user); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return nil, apperrors.ErrVersionConflict
+		}
 		return nil, fmt.Errorf("failed to update user team: %w", err)
 	}
 	return s.convertToResponse(user), nil
 }
 
-// DeleteMember deletes a
-func (s *UserService) DeleteUser(id uuid.UUID) error {
+// DeleteUser deletes a user on behalf of deletedBy, who is recorded as having ended their membership
+func (s *UserService) DeleteUser(id uuid.UUID, deletedBy string) error {
 	_, err := s.repo.GetByID(id)
 	if err != nil {
 		return apperrors.ErrUserNotFound
 	}
 
-	if err := s.repo.Delete(id); err != nil {
+	if err := s.repo.Delete(id, deletedBy); err != nil {
 		return fmt.Errorf("failed to delete member: %w", err)
 	}
 
@@ -598,6 +636,11 @@ func (s *UserService) GetActiveUsers(organizationID uuid.UUID, limit, offset int
 
 // convertToResponse converts a member model to response
 func (s *UserService) convertToResponse(user *models.User) *UserResponse {
+	return toUserResponse(user)
+}
+
+// toUserResponse converts a member model to response
+func toUserResponse(user *models.User) *UserResponse {
 	return &UserResponse{
 		ID:         user.UserID,
 		UUID:       user.ID.String(),
@@ -608,6 +651,9 @@ func (s *UserService) convertToResponse(user *models.User) *UserResponse {
 		Mobile:     user.Mobile,
 		TeamDomain: string(user.TeamDomain),
 		TeamRole:   string(user.TeamRole),
+		Version:    user.Version,
+
+		DirectoryMissingSince: user.DirectoryMissingSince,
 	}
 }
 
@@ -0,0 +1,403 @@
+package service
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxUserImportRows is the largest number of rows accepted by one import
+const MaxUserImportRows = 500
+
+// userImportColumns are the columns of an import CSV. Every row needs a user_id or a dn.
+var userImportColumns = []string{"user_id", "dn", "team", "role", "domain"}
+
+// UserImportService creates many users at once from user IDs or LDAP DNs, taking their names,
+// emails and mobiles from LDAP
+type UserImportService struct {
+	userRepo repository.UserRepositoryInterface
+	teamRepo repository.TeamRepositoryInterface
+	ldap     *LDAPService
+}
+
+// Ensure UserImportService implements UserImportServiceInterface
+var _ UserImportServiceInterface = (*UserImportService)(nil)
+
+// NewUserImportService creates a new UserImportService
+func NewUserImportService(
+	userRepo repository.UserRepositoryInterface,
+	teamRepo repository.TeamRepositoryInterface,
+	ldap *LDAPService,
+) *UserImportService {
+	return &UserImportService{
+		userRepo: userRepo,
+		teamRepo: teamRepo,
+		ldap:     ldap,
+	}
+}
+
+// UserImportRow is a user to import, identified by user ID or by LDAP DN
+type UserImportRow struct {
+	UserID string `json:"user_id,omitempty" example:"I123456"`
+	DN     string `json:"dn,omitempty" example:"CN=I123456,OU=I,DC=example,DC=com"`
+	Team   string `json:"team" example:"team-a"`                // team name or ID
+	Role   string `json:"role,omitempty" example:"member"`      // team role, defaults to member
+	Domain string `json:"domain,omitempty" example:"developer"` // team domain, defaults to developer
+}
+
+// UserImportRequest is a bulk import of users
+type UserImportRequest struct {
+	Rows []UserImportRow
+	// DryRun validates the rows without creating any user
+	DryRun bool
+	// Atomic creates the users in one transaction, and none of them if any row is invalid
+	Atomic    bool
+	CreatedBy string
+}
+
+// UserImportStatus is the outcome of a row of an import
+type UserImportStatus string
+
+const (
+	UserImportValid   UserImportStatus = "valid"   // passed validation in a dry run
+	UserImportCreated UserImportStatus = "created" // user created
+	UserImportInvalid UserImportStatus = "invalid" // failed validation
+	UserImportFailed  UserImportStatus = "failed"  // valid, but the user could not be created
+	UserImportSkipped UserImportStatus = "skipped" // not created because the atomic import failed, or the user is deleted
+)
+
+// UserImportResult is the outcome of an import, row by row
+type UserImportResult struct {
+	DryRun  bool                  `json:"dry_run"`
+	Atomic  bool                  `json:"atomic"`
+	Valid   int                   `json:"valid"` // rows that passed validation
+	Created int                   `json:"created"`
+	Rows    []UserImportRowResult `json:"rows"`
+}
+
+// UserImportRowResult is the outcome of a row of an import
+type UserImportRowResult struct {
+	Row    int              `json:"row"` // 1-based position in the input, not counting the CSV header
+	UserID string           `json:"user_id,omitempty"`
+	DN     string           `json:"dn,omitempty"`
+	Status UserImportStatus `json:"status"`
+	Errors []string         `json:"errors,omitempty"`
+	// User is the created user, or the user that would be created in a dry run
+	User *UserResponse `json:"user,omitempty"`
+}
+
+// ParseUserImportCSV reads import rows from a CSV with a header row. Known columns are user_id,
+// dn, team, role and domain, in any order; user_id or dn is required.
+func ParseUserImportCSV(r io.Reader) ([]UserImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, apperrors.NewValidationError("file", "CSV is empty")
+	}
+	if err != nil {
+		return nil, apperrors.NewValidationError("file", err.Error())
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if !slices.Contains(userImportColumns, name) {
+			return nil, apperrors.NewValidationError("file", fmt.Sprintf("unknown column %q, expected %s", name, strings.Join(userImportColumns, ", ")))
+		}
+		columns[name] = i
+	}
+	_, hasUserID := columns["user_id"]
+	_, hasDN := columns["dn"]
+	if !hasUserID && !hasDN {
+		return nil, apperrors.NewValidationError("file", "CSV needs a user_id or a dn column")
+	}
+
+	rows := []UserImportRow{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, apperrors.NewValidationError("file", err.Error())
+		}
+		get := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		rows = append(rows, UserImportRow{
+			UserID: get("user_id"),
+			DN:     get("dn"),
+			Team:   get("team"),
+			Role:   get("role"),
+			Domain: get("domain"),
+		})
+	}
+}
+
+// ImportUsers validates all rows up front, looking the users up in LDAP, and then creates the
+// users of the valid rows. Atomic imports create them in one transaction, and only if all rows
+// are valid; dry runs create nobody.
+func (s *UserImportService) ImportUsers(req *UserImportRequest) (*UserImportResult, error) {
+	if len(req.Rows) == 0 {
+		return nil, apperrors.NewValidationError("rows", "at least one row is required")
+	}
+	if len(req.Rows) > MaxUserImportRows {
+		return nil, apperrors.NewValidationError("rows", fmt.Sprintf("at most %d rows can be imported at once", MaxUserImportRows))
+	}
+	if strings.TrimSpace(req.CreatedBy) == "" {
+		return nil, apperrors.NewValidationError("created_by", "created_by is required")
+	}
+
+	result := &UserImportResult{DryRun: req.DryRun, Atomic: req.Atomic, Rows: make([]UserImportRowResult, len(req.Rows))}
+	users, err := s.validate(req, result.Rows)
+	if err != nil {
+		return nil, err
+	}
+	result.Valid = len(users)
+
+	switch {
+	case req.DryRun:
+		for i, user := range users {
+			result.Rows[i].Status = UserImportValid
+			result.Rows[i].User = toUserResponse(user)
+		}
+	case req.Atomic:
+		s.createAtomically(users, result)
+	default:
+		for i := range result.Rows {
+			user, ok := users[i]
+			if !ok {
+				continue
+			}
+			if err := s.userRepo.Create(user); err != nil {
+				result.Rows[i].Status = UserImportFailed
+				result.Rows[i].Errors = []string{fmt.Sprintf("failed to create user: %v", err)}
+				continue
+			}
+			result.Rows[i].Status = UserImportCreated
+			result.Rows[i].User = toUserResponse(user)
+			result.Created++
+		}
+	}
+	return result, nil
+}
+
+// createAtomically creates all users in one transaction, if all rows are valid
+func (s *UserImportService) createAtomically(users map[int]*models.User, result *UserImportResult) {
+	skip := func(reason string) {
+		for i := range users {
+			result.Rows[i].Status = UserImportSkipped
+			result.Rows[i].Errors = []string{reason}
+		}
+	}
+	if len(users) < len(result.Rows) {
+		skip("not created, as other rows are invalid")
+		return
+	}
+
+	batch := make([]*models.User, len(result.Rows))
+	for i, user := range users {
+		batch[i] = user
+	}
+	if err := s.userRepo.CreateBatch(batch); err != nil {
+		skip(fmt.Sprintf("import rolled back: %v", err))
+		return
+	}
+	for i, user := range users {
+		result.Rows[i].Status = UserImportCreated
+		result.Rows[i].User = toUserResponse(user)
+	}
+	result.Created = len(users)
+}
+
+// validate checks all rows, filling in their results, and returns the users to create for the
+// valid rows, by row index
+func (s *UserImportService) validate(req *UserImportRequest, results []UserImportRowResult) (map[int]*models.User, error) {
+	var userIDs, dns []string
+	for i, row := range req.Rows {
+		results[i] = UserImportRowResult{Row: i + 1, UserID: row.UserID, DN: row.DN, Status: UserImportInvalid}
+		switch {
+		case row.UserID != "":
+			userIDs = append(userIDs, strings.ToUpper(row.UserID))
+		case row.DN != "":
+			dns = append(dns, row.DN)
+		}
+	}
+
+	byUserID, err := s.ldap.LookupUsers(userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up users in LDAP: %w", err)
+	}
+	byDN, err := s.ldap.LookupDNs(dns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up users in LDAP: %w", err)
+	}
+
+	teams := map[string]*models.Team{}
+	users := map[int]*models.User{}
+	firstRow := map[string]int{}
+	for i, row := range req.Rows {
+		res := &results[i]
+		fail := func(format string, args ...any) { res.Errors = append(res.Errors, fmt.Sprintf(format, args...)) }
+
+		var entry LDAPUser
+		var found bool
+		switch {
+		case row.UserID != "" && row.DN != "":
+			fail("set either user_id or dn")
+		case row.UserID != "":
+			entry, found = byUserID[strings.ToUpper(row.UserID)]
+			if !found {
+				fail("user %s not found in LDAP", row.UserID)
+			}
+		case row.DN != "":
+			entry, found = byDN[strings.ToLower(row.DN)]
+			if !found {
+				fail("dn not found in LDAP")
+			}
+		default:
+			fail("user_id or dn is required")
+		}
+		if found {
+			res.UserID = entry.Name
+			if entry.GivenName == "" || entry.SN == "" || entry.Mail == "" {
+				fail("LDAP entry has no first name, last name or email")
+			}
+			if first, ok := firstRow[strings.ToUpper(entry.Name)]; ok {
+				fail("duplicate of row %d", first)
+			} else {
+				firstRow[strings.ToUpper(entry.Name)] = res.Row
+			}
+		}
+
+		role, domain := models.TeamRole(row.Role), models.TeamDomain(row.Domain)
+		if role == "" {
+			role = models.TeamRoleMember
+		}
+		if domain == "" {
+			domain = models.TeamDomainDeveloper
+		}
+		if err := validateTeamRoleAndDomain(&role, &domain); err != nil {
+			fail("%s", err.Error())
+		}
+
+		team, err := s.team(teams, row.Team)
+		if err != nil {
+			if !apperrors.IsNotFound(err) && !apperrors.IsValidation(err) {
+				return nil, err
+			}
+			fail("%s", err.Error())
+		}
+
+		if len(res.Errors) > 0 {
+			continue
+		}
+		name := strings.TrimSpace(entry.GivenName + " " + entry.SN)
+		users[i] = &models.User{
+			BaseModel:  models.BaseModel{Name: name, Title: name, CreatedBy: req.CreatedBy},
+			TeamID:     &team.ID,
+			UserID:     entry.Name,
+			FirstName:  entry.GivenName,
+			LastName:   entry.SN,
+			Email:      entry.Mail,
+			Mobile:     entry.Mobile,
+			TeamDomain: domain,
+			TeamRole:   role,
+		}
+	}
+
+	if err := s.checkExisting(users, results); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// checkExisting rejects the rows of users that already exist, or whose email is taken, and skips
+// those of deleted users, who are restored from the trash rather than created again
+func (s *UserImportService) checkExisting(users map[int]*models.User, results []UserImportRowResult) error {
+	ids := make([]string, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.UserID)
+	}
+	existing, err := s.userRepo.GetExistingUserIDs(ids)
+	if err != nil {
+		return fmt.Errorf("failed to check existing users: %w", err)
+	}
+
+	for i, user := range users {
+		if slices.Contains(existing, user.UserID) {
+			results[i].Errors = append(results[i].Errors, fmt.Sprintf("user %s already exists", user.UserID))
+			delete(users, i)
+			continue
+		}
+		deleted, err := s.userRepo.GetDeletedByUserID(user.UserID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to check deleted users: %w", err)
+		}
+		if deleted != nil {
+			results[i].Status = UserImportSkipped
+			results[i].Errors = append(results[i].Errors, fmt.Sprintf("user %s is deleted; restore them from the trash instead", user.UserID))
+			delete(users, i)
+			continue
+		}
+		taken, err := s.userRepo.GetByEmail(user.Email)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to check existing users: %w", err)
+		}
+		if taken != nil {
+			results[i].Errors = append(results[i].Errors, fmt.Sprintf("email %s is used by user %s", user.Email, taken.UserID))
+			delete(users, i)
+		}
+	}
+	return nil
+}
+
+// team resolves a team by ID or name, caching the teams found
+func (s *UserImportService) team(teams map[string]*models.Team, ref string) (*models.Team, error) {
+	if ref == "" {
+		return nil, apperrors.NewValidationError("team", "team is required")
+	}
+	if team, ok := teams[ref]; ok {
+		return team, nil
+	}
+
+	team, err := teamByRef(s.teamRepo, ref)
+	if err != nil {
+		return nil, err
+	}
+	teams[ref] = team
+	return team, nil
+}
+
+// teamByRef retrieves a team by ID or by name
+func teamByRef(teamRepo repository.TeamRepositoryInterface, ref string) (*models.Team, error) {
+	var team *models.Team
+	var err error
+	if id, parseErr := uuid.Parse(ref); parseErr == nil {
+		team, err = teamRepo.GetByID(id)
+	} else {
+		team, err = teamRepo.GetByNameGlobal(ref)
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewNotFoundError(fmt.Sprintf("team %s", ref))
+		}
+		return nil, fmt.Errorf("failed to get team: %w", err)
+	}
+	return team, nil
+}
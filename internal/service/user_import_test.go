@@ -0,0 +1,251 @@
+package service
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+func TestParseUserImportCSV(t *testing.T) {
+	rows, err := ParseUserImportCSV(strings.NewReader("\ufeffTeam, user_id,role\nteam-a, i123456 ,scm\n\nteam-b,I654321\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []UserImportRow{
+		{UserID: "i123456", Team: "team-a", Role: "scm"},
+		{UserID: "I654321", Team: "team-b"},
+	}, rows)
+
+	rows, err = ParseUserImportCSV(strings.NewReader("dn,team\n\"CN=I123456,OU=I,DC=example,DC=com\",team-a\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "CN=I123456,OU=I,DC=example,DC=com", rows[0].DN)
+
+	for input, message := range map[string]string{
+		"":                   "CSV is empty",
+		"user_id,email\n":    `unknown column "email"`,
+		"team,role\nteam-a,": "needs a user_id or a dn column",
+		"user_id\n\"I1":      "extraneous or missing",
+	} {
+		_, err := ParseUserImportCSV(strings.NewReader(input))
+		assert.True(t, apperrors.IsValidation(err), input)
+		assert.ErrorContains(t, err, message, input)
+	}
+}
+
+type UserImportServiceTestSuite struct {
+	suite.Suite
+	teamRepo      *MockTeamRepository
+	userRepo      *MockUserRepository
+	directory     map[string]*ldap.Entry
+	importService *UserImportService
+	origDial      func(network, addr string, config *tls.Config) (ldapClient, error)
+	team          *models.Team
+}
+
+func (suite *UserImportServiceTestSuite) SetupTest() {
+	suite.origDial = dialLDAP
+	suite.directory = map[string]*ldap.Entry{
+		"I000001": directoryEntry("I000001", "Jane", "Doe", "jane.doe@example.com", "+49 1"),
+		"I000002": directoryEntry("I000002", "John", "Doe", "john.doe@example.com", ""),
+		"I000003": directoryEntry("I000003", "Max", "Mustermann", "max@example.com", ""),
+		"I000004": directoryEntry("I000004", "", "", "", ""),
+	}
+	// Users are looked up by name, and by DN for rows with a dn
+	dialLDAP = func(network, addr string, cfg *tls.Config) (ldapClient, error) {
+		byName := fakeDirectory(suite.directory)
+		entries := []*ldap.Entry{}
+		for _, entry := range suite.directory {
+			entries = append(entries, entry)
+		}
+		byDN := fakeDistributionLists(entries...)
+		return &fakeLDAPClient{search: func(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+			if req.Scope == ldap.ScopeBaseObject {
+				return byDN.search(req)
+			}
+			return byName.search(req)
+		}}, nil
+	}
+
+	suite.teamRepo = new(MockTeamRepository)
+	suite.userRepo = new(MockUserRepository)
+	suite.importService = NewUserImportService(suite.userRepo, suite.teamRepo, NewLDAPService(makeConfig()))
+	suite.team = &models.Team{BaseModel: models.BaseModel{ID: uuid.New(), Name: "team-a"}}
+	suite.teamRepo.On("GetByNameGlobal", "team-a").Return(suite.team, nil).Maybe()
+	suite.teamRepo.On("GetByNameGlobal", "team-x").Return((*models.Team)(nil), gorm.ErrRecordNotFound).Maybe()
+}
+
+func (suite *UserImportServiceTestSuite) TearDownTest() {
+	dialLDAP = suite.origDial
+	suite.teamRepo.AssertExpectations(suite.T())
+	suite.userRepo.AssertExpectations(suite.T())
+}
+
+func (suite *UserImportServiceTestSuite) statuses(result *UserImportResult) []UserImportStatus {
+	statuses := []UserImportStatus{}
+	for _, row := range result.Rows {
+		statuses = append(statuses, row.Status)
+	}
+	return statuses
+}
+
+func (suite *UserImportServiceTestSuite) TestImportUsers_DryRunValidatesAllRows() {
+	existing := &models.User{UserID: "I000009"}
+	suite.userRepo.On("GetExistingUserIDs", mock.Anything).Return([]string{"I000003"}, nil)
+	suite.userRepo.On("GetByEmail", "john.doe@example.com").Return(existing, nil)
+	suite.userRepo.On("GetByEmail", mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	suite.userRepo.On("GetDeletedByUserID", mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	suite.teamRepo.On("GetByID", suite.team.ID).Return(suite.team, nil)
+
+	result, err := suite.importService.ImportUsers(&UserImportRequest{
+		Rows: []UserImportRow{
+			{UserID: "i000001", Team: "team-a", Role: "scm"},
+			{DN: "CN=I000002,OU=I,DC=example,DC=com", Team: suite.team.ID.String()},
+			{UserID: "I000003", Team: "team-a"},
+			{UserID: "I000004", Team: "team-a"},
+			{UserID: "I000005", Team: "team-x", Domain: "sales"},
+			{DN: "CN=I000001,OU=I,DC=example,DC=com", Team: "team-a"},
+			{Team: "team-a"},
+		},
+		DryRun:    true,
+		CreatedBy: "I999999",
+	})
+	suite.Require().NoError(err)
+	suite.Equal([]UserImportStatus{
+		UserImportValid, UserImportInvalid, UserImportInvalid, UserImportInvalid, UserImportInvalid, UserImportInvalid, UserImportInvalid,
+	}, suite.statuses(result))
+	suite.Equal(1, result.Valid)
+	suite.Zero(result.Created)
+
+	preview := result.Rows[0].User
+	suite.Require().NotNil(preview)
+	suite.Equal("I000001", preview.ID)
+	suite.Equal("Jane", preview.FirstName)
+	suite.Equal("scm", preview.TeamRole)
+	suite.Equal(&suite.team.ID, preview.TeamID)
+
+	suite.Equal([]string{"email john.doe@example.com is used by user I000009"}, result.Rows[1].Errors)
+	suite.Equal("I000002", result.Rows[1].UserID)
+	suite.Equal([]string{"user I000003 already exists"}, result.Rows[2].Errors)
+	suite.Equal([]string{"LDAP entry has no first name, last name or email"}, result.Rows[3].Errors)
+	suite.Len(result.Rows[4].Errors, 3)
+	suite.Contains(result.Rows[4].Errors[0], "user I000005 not found in LDAP")
+	suite.Contains(result.Rows[4].Errors[1], "sales")
+	suite.Equal("team team-x not found", result.Rows[4].Errors[2])
+	suite.Equal([]string{"duplicate of row 1"}, result.Rows[5].Errors)
+	suite.Equal([]string{"user_id or dn is required"}, result.Rows[6].Errors)
+	suite.userRepo.AssertNotCalled(suite.T(), "Create", mock.Anything)
+}
+
+func (suite *UserImportServiceTestSuite) TestImportUsers_CreatesValidRows() {
+	suite.userRepo.On("GetExistingUserIDs", mock.Anything).Return([]string{}, nil)
+	suite.userRepo.On("GetByEmail", mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	suite.userRepo.On("GetDeletedByUserID", mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	suite.userRepo.On("Create", mock.MatchedBy(func(u *models.User) bool { return u.UserID == "I000001" })).Run(func(args mock.Arguments) {
+		u := args.Get(0).(*models.User)
+		suite.Equal("Jane Doe", u.Name)
+		suite.Equal("+49 1", u.Mobile)
+		suite.Equal(models.TeamDomainDeveloper, u.TeamDomain)
+		suite.Equal(models.TeamRoleMember, u.TeamRole)
+		suite.Equal("I999999", u.CreatedBy)
+	}).Return(nil)
+	suite.userRepo.On("Create", mock.MatchedBy(func(u *models.User) bool { return u.UserID == "I000002" })).Return(errors.New("duplicate key value"))
+
+	result, err := suite.importService.ImportUsers(&UserImportRequest{
+		Rows:      []UserImportRow{{UserID: "I000001", Team: "team-a"}, {UserID: "I000002", Team: "team-a"}, {UserID: "I000004", Team: "team-a"}},
+		CreatedBy: "I999999",
+	})
+	suite.Require().NoError(err)
+	suite.Equal([]UserImportStatus{UserImportCreated, UserImportFailed, UserImportInvalid}, suite.statuses(result))
+	suite.Equal(2, result.Valid)
+	suite.Equal(1, result.Created)
+	suite.Contains(result.Rows[1].Errors[0], "duplicate key value")
+}
+
+func (suite *UserImportServiceTestSuite) TestImportUsers_AtomicCreatesAllOrNone() {
+	suite.userRepo.On("GetExistingUserIDs", mock.Anything).Return([]string{}, nil)
+	suite.userRepo.On("GetByEmail", mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	suite.userRepo.On("GetDeletedByUserID", mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+	// An invalid row stops the import
+	result, err := suite.importService.ImportUsers(&UserImportRequest{
+		Rows:      []UserImportRow{{UserID: "I000001", Team: "team-a"}, {UserID: "I000005", Team: "team-a"}},
+		Atomic:    true,
+		CreatedBy: "I999999",
+	})
+	suite.Require().NoError(err)
+	suite.Equal([]UserImportStatus{UserImportSkipped, UserImportInvalid}, suite.statuses(result))
+	suite.Zero(result.Created)
+	suite.userRepo.AssertNotCalled(suite.T(), "CreateBatch", mock.Anything)
+
+	suite.userRepo.On("CreateBatch", mock.MatchedBy(func(users []*models.User) bool {
+		return len(users) == 2 && users[0].UserID == "I000001" && users[1].UserID == "I000002"
+	})).Return(nil).Once()
+	result, err = suite.importService.ImportUsers(&UserImportRequest{
+		Rows:      []UserImportRow{{UserID: "I000001", Team: "team-a"}, {UserID: "I000002", Team: "team-a"}},
+		Atomic:    true,
+		CreatedBy: "I999999",
+	})
+	suite.Require().NoError(err)
+	suite.Equal([]UserImportStatus{UserImportCreated, UserImportCreated}, suite.statuses(result))
+	suite.Equal(2, result.Created)
+
+	// A failing insert rolls back the whole import
+	suite.userRepo.On("CreateBatch", mock.Anything).Return(errors.New("failed to create user I000002: duplicate key value")).Once()
+	result, err = suite.importService.ImportUsers(&UserImportRequest{
+		Rows:      []UserImportRow{{UserID: "I000001", Team: "team-a"}, {UserID: "I000002", Team: "team-a"}},
+		Atomic:    true,
+		CreatedBy: "I999999",
+	})
+	suite.Require().NoError(err)
+	suite.Equal([]UserImportStatus{UserImportSkipped, UserImportSkipped}, suite.statuses(result))
+	suite.Contains(result.Rows[0].Errors[0], "import rolled back")
+}
+
+func (suite *UserImportServiceTestSuite) TestImportUsers_SkipsDeletedUsers() {
+	suite.userRepo.On("GetExistingUserIDs", mock.Anything).Return([]string{}, nil)
+	suite.userRepo.On("GetDeletedByUserID", "I000001").Return(&models.User{UserID: "I000001"}, nil)
+	suite.userRepo.On("GetDeletedByUserID", mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	suite.userRepo.On("GetByEmail", mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+
+	// Deleted users are restored from the trash rather than created again
+	result, err := suite.importService.ImportUsers(&UserImportRequest{
+		Rows:      []UserImportRow{{UserID: "I000001", Team: "team-a"}, {UserID: "I000002", Team: "team-a"}},
+		DryRun:    true,
+		CreatedBy: "I999999",
+	})
+	suite.Require().NoError(err)
+	suite.Equal([]UserImportStatus{UserImportSkipped, UserImportValid}, suite.statuses(result))
+	suite.Equal(1, result.Valid)
+	suite.Equal([]string{"user I000001 is deleted; restore them from the trash instead"}, result.Rows[0].Errors)
+}
+
+func (suite *UserImportServiceTestSuite) TestImportUsers_Errors() {
+	_, err := suite.importService.ImportUsers(&UserImportRequest{CreatedBy: "I999999"})
+	suite.True(apperrors.IsValidation(err))
+
+	_, err = suite.importService.ImportUsers(&UserImportRequest{Rows: make([]UserImportRow, MaxUserImportRows+1), CreatedBy: "I999999"})
+	suite.ErrorContains(err, fmt.Sprintf("at most %d rows", MaxUserImportRows))
+
+	_, err = suite.importService.ImportUsers(&UserImportRequest{Rows: []UserImportRow{{UserID: "I000001"}}})
+	suite.True(apperrors.IsValidation(err))
+
+	dialLDAP = func(network, addr string, cfg *tls.Config) (ldapClient, error) {
+		return nil, errors.New("connection refused")
+	}
+	_, err = suite.importService.ImportUsers(&UserImportRequest{Rows: []UserImportRow{{UserID: "I000001", Team: "team-a"}}, CreatedBy: "I999999"})
+	suite.ErrorContains(err, "connection refused")
+}
+
+func TestUserImportServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(UserImportServiceTestSuite))
+}
@@ -0,0 +1,241 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/repository"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserOffboardingService hands the links, teams, groups and organizations owned by a user over to
+// others before deleting the user, so that nothing is left with an owner who is gone
+type UserOffboardingService struct {
+	repo     repository.UserOffboardingRepositoryInterface
+	userRepo repository.UserRepositoryInterface
+	teamRepo repository.TeamRepositoryInterface
+}
+
+// Ensure UserOffboardingService implements UserOffboardingServiceInterface
+var _ UserOffboardingServiceInterface = (*UserOffboardingService)(nil)
+
+// NewUserOffboardingService creates a new UserOffboardingService
+func NewUserOffboardingService(
+	repo repository.UserOffboardingRepositoryInterface,
+	userRepo repository.UserRepositoryInterface,
+	teamRepo repository.TeamRepositoryInterface,
+) *UserOffboardingService {
+	return &UserOffboardingService{
+		repo:     repo,
+		userRepo: userRepo,
+		teamRepo: teamRepo,
+	}
+}
+
+// OwnedResource is a link, team, group or organization owned by a user
+type OwnedResource struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Title string    `json:"title"`
+}
+
+// OffboardingPreview lists everything a user owns, i.e. what an offboarding hands over
+type OffboardingPreview struct {
+	UserUUID      uuid.UUID       `json:"user_uuid"`
+	UserID        string          `json:"user_id" example:"I123456"`
+	TeamID        *uuid.UUID      `json:"team_id,omitempty"` // current team, left on offboarding
+	Links         []OwnedResource `json:"links"`
+	FavoritedBy   int64           `json:"favorited_by"` // users with one of the links among their favorites
+	Teams         []OwnedResource `json:"teams"`
+	Groups        []OwnedResource `json:"groups"`
+	Organizations []OwnedResource `json:"organizations"`
+}
+
+// OffboardUserRequest names who takes over the resources of an offboarded user. Every kind of
+// resource the user owns needs a new owner; links can be deleted instead.
+type OffboardUserRequest struct {
+	LinkOwner         string `json:"link_owner,omitempty" example:"I654321"` // user to take over the links
+	LinkTeam          string `json:"link_team,omitempty" example:"team-a"`   // team name or ID to take over the links, instead of a user
+	DeleteLinks       bool   `json:"delete_links,omitempty"`                 // delete the links and remove them from all favorites
+	TeamOwner         string `json:"team_owner,omitempty" example:"I654321"`
+	GroupOwner        string `json:"group_owner,omitempty" example:"I654321"`
+	OrganizationOwner string `json:"organization_owner,omitempty" example:"I654321"`
+	Reason            string `json:"reason" binding:"max=200"`
+	OffboardedBy      string `json:"-"` // derived from bearer token
+}
+
+// PreviewOffboarding lists the resources owned by a user
+func (s *UserOffboardingService) PreviewOffboarding(userID string) (*OffboardingPreview, error) {
+	user, err := s.user(userID)
+	if err != nil {
+		return nil, err
+	}
+	owned, err := s.repo.GetOwnedResources(user.ID, user.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owned resources: %w", err)
+	}
+
+	preview := &OffboardingPreview{
+		UserUUID:      user.ID,
+		UserID:        user.UserID,
+		TeamID:        user.TeamID,
+		Links:         make([]OwnedResource, 0, len(owned.Links)),
+		FavoritedBy:   owned.FavoritedBy,
+		Teams:         make([]OwnedResource, 0, len(owned.Teams)),
+		Groups:        make([]OwnedResource, 0, len(owned.Groups)),
+		Organizations: make([]OwnedResource, 0, len(owned.Organizations)),
+	}
+	for _, link := range owned.Links {
+		preview.Links = append(preview.Links, ownedResource(link.BaseModel))
+	}
+	for _, team := range owned.Teams {
+		preview.Teams = append(preview.Teams, ownedResource(team.BaseModel))
+	}
+	for _, group := range owned.Groups {
+		preview.Groups = append(preview.Groups, ownedResource(group.BaseModel))
+	}
+	for _, org := range owned.Organizations {
+		preview.Organizations = append(preview.Organizations, ownedResource(org.BaseModel))
+	}
+	return preview, nil
+}
+
+// OffboardUser hands the resources of a user over to the owners in the request, then deletes the
+// user and ends their team membership. The offboarding is recorded in the history.
+func (s *UserOffboardingService) OffboardUser(userID string, req *OffboardUserRequest) (*models.UserOffboarding, error) {
+	if req.OffboardedBy == "" {
+		return nil, apperrors.NewValidationError("offboarded_by", "offboarded_by is required")
+	}
+	user, err := s.user(userID)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(user.UserID, req.OffboardedBy) {
+		return nil, apperrors.NewValidationError("user_id", "users cannot offboard themselves")
+	}
+	owned, err := s.repo.GetOwnedResources(user.ID, user.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owned resources: %w", err)
+	}
+
+	record := &models.UserOffboarding{
+		UserID:       user.ID,
+		Username:     user.UserID,
+		OffboardedBy: req.OffboardedBy,
+		Reason:       req.Reason,
+	}
+	if record.LinkOwnerID, err = s.linkOwner(user, req, len(owned.Links)); err != nil {
+		return nil, err
+	}
+	if record.TeamOwner, err = s.owner(user, "team_owner", req.TeamOwner, "teams", len(owned.Teams)); err != nil {
+		return nil, err
+	}
+	if record.GroupOwner, err = s.owner(user, "group_owner", req.GroupOwner, "groups", len(owned.Groups)); err != nil {
+		return nil, err
+	}
+	if record.OrganizationOwner, err = s.owner(user, "organization_owner", req.OrganizationOwner, "organizations", len(owned.Organizations)); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Offboard(record); err != nil {
+		return nil, fmt.Errorf("failed to offboard user: %w", err)
+	}
+	return record, nil
+}
+
+// GetOffboardingHistory retrieves the latest offboardings, newest first. A limit of 0 retrieves all of them.
+func (s *UserOffboardingService) GetOffboardingHistory(limit int) ([]models.UserOffboarding, error) {
+	records, err := s.repo.GetHistory(limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get offboarding history: %w", err)
+	}
+	return records, nil
+}
+
+// user retrieves the user to offboard
+func (s *UserOffboardingService) user(userID string) (*models.User, error) {
+	if userID == "" {
+		return nil, apperrors.NewValidationError("user_id", "user_id is required")
+	}
+	user, err := s.userRepo.GetByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return user, nil
+}
+
+// linkOwner resolves the user or team taking over the links of the user. It is nil if the links
+// are deleted.
+func (s *UserOffboardingService) linkOwner(user *models.User, req *OffboardUserRequest, links int) (*uuid.UUID, error) {
+	given := 0
+	for _, set := range []bool{req.LinkOwner != "", req.LinkTeam != "", req.DeleteLinks} {
+		if set {
+			given++
+		}
+	}
+	if given > 1 {
+		return nil, apperrors.NewValidationError("link_owner", "give only one of link_owner, link_team and delete_links")
+	}
+	if given == 0 && links > 0 {
+		return nil, apperrors.NewValidationError("link_owner", fmt.Sprintf("user owns %d links; give link_owner, link_team or delete_links", links))
+	}
+
+	switch {
+	case req.LinkOwner != "":
+		owner, err := s.newOwner(user, "link_owner", req.LinkOwner)
+		if err != nil {
+			return nil, err
+		}
+		return &owner.ID, nil
+	case req.LinkTeam != "":
+		team, err := teamByRef(s.teamRepo, req.LinkTeam)
+		if err != nil {
+			return nil, err
+		}
+		return &team.ID, nil
+	}
+	return nil, nil
+}
+
+// owner resolves the new owner given in field, which is required if the user owns any of kind
+func (s *UserOffboardingService) owner(user *models.User, field, ref, kind string, owned int) (string, error) {
+	if ref == "" {
+		if owned > 0 {
+			return "", apperrors.NewValidationError(field, fmt.Sprintf("user owns %d %s; %s is required", owned, kind, field))
+		}
+		return "", nil
+	}
+	owner, err := s.newOwner(user, field, ref)
+	if err != nil {
+		return "", err
+	}
+	return owner.UserID, nil
+}
+
+// newOwner retrieves the user given in field to take over resources of the offboarded user
+func (s *UserOffboardingService) newOwner(user *models.User, field, userID string) (*models.User, error) {
+	if strings.EqualFold(userID, user.UserID) {
+		return nil, apperrors.NewValidationError(field, "the new owner cannot be the offboarded user")
+	}
+	owner, err := s.userRepo.GetByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.NewValidationError(field, fmt.Sprintf("user %s not found", userID))
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return owner, nil
+}
+
+// ownedResource converts the identifying fields of a model to an OwnedResource
+func ownedResource(base models.BaseModel) OwnedResource {
+	return OwnedResource{ID: base.ID, Name: base.Name, Title: base.Title}
+}
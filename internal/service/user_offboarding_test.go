@@ -0,0 +1,163 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/mocks"
+	"developer-portal-backend/internal/repository"
+	"developer-portal-backend/internal/service"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+)
+
+type UserOffboardingServiceTestSuite struct {
+	suite.Suite
+	ctrl               *gomock.Controller
+	mockRepo           *mocks.MockUserOffboardingRepositoryInterface
+	mockUserRepo       *mocks.MockUserRepositoryInterface
+	mockTeamRepo       *mocks.MockTeamRepositoryInterface
+	offboardingService *service.UserOffboardingService
+	user               *models.User
+	successor          *models.User
+}
+
+func (suite *UserOffboardingServiceTestSuite) SetupTest() {
+	suite.ctrl = gomock.NewController(suite.T())
+	suite.mockRepo = mocks.NewMockUserOffboardingRepositoryInterface(suite.ctrl)
+	suite.mockUserRepo = mocks.NewMockUserRepositoryInterface(suite.ctrl)
+	suite.mockTeamRepo = mocks.NewMockTeamRepositoryInterface(suite.ctrl)
+	suite.offboardingService = service.NewUserOffboardingService(suite.mockRepo, suite.mockUserRepo, suite.mockTeamRepo)
+
+	teamID := uuid.New()
+	suite.user = &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, UserID: "I000001", TeamID: &teamID}
+	suite.successor = &models.User{BaseModel: models.BaseModel{ID: uuid.New()}, UserID: "I000002"}
+	suite.mockUserRepo.EXPECT().GetByUserID("I000001").Return(suite.user, nil).AnyTimes()
+	suite.mockUserRepo.EXPECT().GetByUserID("I000002").Return(suite.successor, nil).AnyTimes()
+	suite.mockUserRepo.EXPECT().GetByUserID("I000009").Return(nil, gorm.ErrRecordNotFound).AnyTimes()
+}
+
+func (suite *UserOffboardingServiceTestSuite) TearDownTest() {
+	suite.ctrl.Finish()
+}
+
+func (suite *UserOffboardingServiceTestSuite) owned() *repository.OwnedResources {
+	return &repository.OwnedResources{
+		Links:       []models.Link{{BaseModel: models.BaseModel{ID: uuid.New(), Name: "wiki", Title: "Wiki"}}},
+		Teams:       []models.Team{{BaseModel: models.BaseModel{ID: uuid.New(), Name: "team-a", Title: "Team A"}}},
+		FavoritedBy: 3,
+	}
+}
+
+func (suite *UserOffboardingServiceTestSuite) TestPreviewOffboarding() {
+	owned := suite.owned()
+	suite.mockRepo.EXPECT().GetOwnedResources(suite.user.ID, "I000001").Return(owned, nil)
+
+	preview, err := suite.offboardingService.PreviewOffboarding("I000001")
+	suite.Require().NoError(err)
+	suite.Equal(suite.user.ID, preview.UserUUID)
+	suite.Equal(suite.user.TeamID, preview.TeamID)
+	suite.Equal([]service.OwnedResource{{ID: owned.Links[0].ID, Name: "wiki", Title: "Wiki"}}, preview.Links)
+	suite.Equal(int64(3), preview.FavoritedBy)
+	suite.Equal("team-a", preview.Teams[0].Name)
+	suite.NotNil(preview.Groups)
+	suite.Empty(preview.Organizations)
+
+	_, err = suite.offboardingService.PreviewOffboarding("I000009")
+	suite.ErrorIs(err, apperrors.ErrUserNotFound)
+}
+
+func (suite *UserOffboardingServiceTestSuite) TestOffboardUser_ReassignsToUserAndTeam() {
+	suite.mockRepo.EXPECT().GetOwnedResources(suite.user.ID, "I000001").Return(suite.owned(), nil).Times(2)
+	suite.mockRepo.EXPECT().Offboard(&models.UserOffboarding{
+		UserID:       suite.user.ID,
+		Username:     "I000001",
+		LinkOwnerID:  &suite.successor.ID,
+		TeamOwner:    "I000002",
+		OffboardedBy: "I999999",
+		Reason:       "left the company",
+	}).Return(nil)
+
+	record, err := suite.offboardingService.OffboardUser("I000001", &service.OffboardUserRequest{
+		LinkOwner:    "I000002",
+		TeamOwner:    "I000002",
+		Reason:       "left the company",
+		OffboardedBy: "I999999",
+	})
+	suite.Require().NoError(err)
+	suite.Equal("I000002", record.TeamOwner)
+
+	team := &models.Team{BaseModel: models.BaseModel{ID: uuid.New(), Name: "team-b"}}
+	suite.mockTeamRepo.EXPECT().GetByNameGlobal("team-b").Return(team, nil)
+	suite.mockRepo.EXPECT().Offboard(gomock.Any()).DoAndReturn(func(record *models.UserOffboarding) error {
+		suite.Equal(&team.ID, record.LinkOwnerID)
+		return nil
+	})
+	_, err = suite.offboardingService.OffboardUser("I000001", &service.OffboardUserRequest{
+		LinkTeam:     "team-b",
+		TeamOwner:    "I000002",
+		OffboardedBy: "I999999",
+	})
+	suite.Require().NoError(err)
+}
+
+func (suite *UserOffboardingServiceTestSuite) TestOffboardUser_DeletesLinks() {
+	suite.mockRepo.EXPECT().GetOwnedResources(suite.user.ID, "I000001").Return(&repository.OwnedResources{
+		Links: suite.owned().Links,
+	}, nil)
+	suite.mockRepo.EXPECT().Offboard(gomock.Any()).DoAndReturn(func(record *models.UserOffboarding) error {
+		suite.Nil(record.LinkOwnerID)
+		suite.Empty(record.TeamOwner)
+		record.LinkCount, record.FavoritesRemoved = 1, 3
+		return nil
+	})
+
+	record, err := suite.offboardingService.OffboardUser("I000001", &service.OffboardUserRequest{DeleteLinks: true, OffboardedBy: "I999999"})
+	suite.Require().NoError(err)
+	suite.Equal(3, record.FavoritesRemoved)
+}
+
+func (suite *UserOffboardingServiceTestSuite) TestOffboardUser_RequiresNewOwners() {
+	suite.mockRepo.EXPECT().GetOwnedResources(suite.user.ID, "I000001").Return(suite.owned(), nil).AnyTimes()
+	suite.mockTeamRepo.EXPECT().GetByNameGlobal("team-x").Return(nil, gorm.ErrRecordNotFound)
+
+	for message, req := range map[string]service.OffboardUserRequest{
+		"user owns 1 links":                {TeamOwner: "I000002", OffboardedBy: "I999999"},
+		"user owns 1 teams; team_owner":    {DeleteLinks: true, OffboardedBy: "I999999"},
+		"give only one of":                 {LinkOwner: "I000002", DeleteLinks: true, TeamOwner: "I000002", OffboardedBy: "I999999"},
+		"cannot be the offboarded user":    {DeleteLinks: true, TeamOwner: "i000001", OffboardedBy: "I999999"},
+		"user I000009 not found":           {LinkOwner: "I000009", TeamOwner: "I000002", OffboardedBy: "I999999"},
+		"team team-x not found":            {LinkTeam: "team-x", TeamOwner: "I000002", OffboardedBy: "I999999"},
+		"users cannot offboard themselves": {DeleteLinks: true, TeamOwner: "I000002", OffboardedBy: "I000001"},
+		"offboarded_by is required":        {DeleteLinks: true, TeamOwner: "I000002"},
+	} {
+		_, err := suite.offboardingService.OffboardUser("I000001", &req)
+		suite.ErrorContains(err, message)
+	}
+}
+
+func (suite *UserOffboardingServiceTestSuite) TestOffboardUser_RepositoryError() {
+	suite.mockRepo.EXPECT().GetOwnedResources(suite.user.ID, "I000001").Return(&repository.OwnedResources{}, nil)
+	suite.mockRepo.EXPECT().Offboard(gomock.Any()).Return(errors.New("connection refused"))
+
+	_, err := suite.offboardingService.OffboardUser("I000001", &service.OffboardUserRequest{OffboardedBy: "I999999"})
+	suite.ErrorContains(err, "failed to offboard user: connection refused")
+}
+
+func (suite *UserOffboardingServiceTestSuite) TestGetOffboardingHistory() {
+	records := []models.UserOffboarding{{Username: "I000001"}}
+	suite.mockRepo.EXPECT().GetHistory(20).Return(records, nil)
+
+	result, err := suite.offboardingService.GetOffboardingHistory(20)
+	suite.Require().NoError(err)
+	suite.Equal(records, result)
+}
+
+func TestUserOffboardingServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(UserOffboardingServiceTestSuite))
+}
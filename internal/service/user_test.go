@@ -1,9 +1,12 @@
 package service_test
 
 import (
+	"encoding/json"
 	"testing"
 
 	"developer-portal-backend/internal/database/models"
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/metaschema"
 	"developer-portal-backend/internal/mocks"
 	"developer-portal-backend/internal/service"
 
@@ -36,8 +39,11 @@ func (suite *UserServiceTestSuite) SetupTest() {
 	suite.validator = validator.New()
 	mockLinkRepo := mocks.NewMockLinkRepositoryInterface(suite.ctrl)
 
+	schemas, err := metaschema.NewRegistry()
+	suite.Require().NoError(err)
+
 	// Create service with mock repository
-	suite.userService = service.NewUserService(suite.mockUserRepo, mockLinkRepo, suite.validator)
+	suite.userService = service.NewUserService(suite.mockUserRepo, mockLinkRepo, suite.validator, schemas)
 }
 
 // TearDownTest cleans up after each test
@@ -330,11 +336,11 @@ func (suite *UserServiceTestSuite) TestDeleteMember() {
 		Times(1)
 
 	suite.mockUserRepo.EXPECT().
-		Delete(userID).
+		Delete(userID, "admin").
 		Return(nil).
 		Times(1)
 
-	err := suite.userService.DeleteUser(userID)
+	err := suite.userService.DeleteUser(userID, "admin")
 
 	assert.NoError(suite.T(), err)
 }
@@ -527,7 +533,7 @@ func (suite *UserServiceTestSuite) TestDeleteMemberNotFound() {
 		Return(nil, gorm.ErrRecordNotFound).
 		Times(1)
 
-	err := suite.userService.DeleteUser(userID)
+	err := suite.userService.DeleteUser(userID, "admin")
 
 	assert.Error(suite.T(), err)
 	assert.Contains(suite.T(), err.Error(), "user not found")
@@ -602,6 +608,28 @@ func TestAddQuickLinkValidation(t *testing.T) {
 	}
 }
 
+// TestFavoriteLinks_ValidatesMetadata tests that favorites are not saved into metadata violating the user schema
+func (suite *UserServiceTestSuite) TestFavoriteLinks_ValidatesMetadata() {
+	linkID := uuid.New()
+	suite.mockUserRepo.EXPECT().GetByUserID("I000001").DoAndReturn(func(string) (*models.User, error) {
+		return &models.User{UserID: "I000001", Metadata: json.RawMessage(`{"ai_instances":"instance-1"}`)}, nil
+	}).Times(2)
+	suite.mockUserRepo.EXPECT().GetByUserID("I000002").Return(&models.User{UserID: "I000002", Metadata: json.RawMessage(`{"theme":"dark"}`)}, nil)
+	suite.mockUserRepo.EXPECT().Update(gomock.Any()).DoAndReturn(func(user *models.User) error {
+		suite.JSONEq(`{"theme":"dark","favorites":["`+linkID.String()+`"]}`, string(user.Metadata))
+		return nil
+	})
+
+	_, err := suite.userService.AddFavoriteLinkByUserID("I000001", linkID)
+	suite.True(apperrors.IsValidation(err))
+	suite.ErrorContains(err, "ai_instances")
+	_, err = suite.userService.RemoveFavoriteLinkByUserID("I000001", linkID)
+	suite.True(apperrors.IsValidation(err))
+
+	_, err = suite.userService.AddFavoriteLinkByUserID("I000002", linkID)
+	suite.NoError(err)
+}
+
 func TestUserServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(UserServiceTestSuite))
 }
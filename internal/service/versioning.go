@@ -0,0 +1,35 @@
+package service
+
+import (
+	"errors"
+
+	apperrors "developer-portal-backend/internal/errors"
+	"developer-portal-backend/internal/repository"
+)
+
+// conflictRetries is how often a read-modify-write is repeated after losing a race with a
+// concurrent update of the same entity
+const conflictRetries = 3
+
+// retryOnConflict runs update, which reads, modifies and saves a versioned entity, again if the save
+// failed because someone else updated the entity in between. There are no retries if the caller
+// expects a version: the change was based on that version and must not be applied to a newer one.
+func retryOnConflict(expectedVersion int64, update func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := update()
+		if !errors.Is(err, repository.ErrVersionConflict) {
+			return err
+		}
+		if expectedVersion != 0 || attempt == conflictRetries {
+			return apperrors.ErrVersionConflict
+		}
+	}
+}
+
+// checkVersion returns apperrors.ErrVersionConflict if a version is expected and the entity has another one
+func checkVersion(expectedVersion, version int64) error {
+	if expectedVersion != 0 && expectedVersion != version {
+		return apperrors.ErrVersionConflict
+	}
+	return nil
+}
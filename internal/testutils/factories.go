@@ -244,6 +244,7 @@ func (f *ComponentFactory) Create() *models.Component {
 		},
 		ProjectID: uuid.New(),
 		OwnerID:   uuid.New(),
+		Lifecycle: models.ComponentLifecycleProduction,
 	}
 }
 
@@ -129,6 +129,9 @@ func (s *BaseTestSuite) CleanTestDB() {
 		"deployment_timelines",
 		"outage_calls",
 		"duty_schedules",
+		"component_ownership_changes",
+		"team_memberships",
+		"user_offboardings",
 		"components",
 		"landscapes",
 		"projects",
@@ -136,6 +139,14 @@ func (s *BaseTestSuite) CleanTestDB() {
 		"teams",
 		"groups",
 		"organizations",
+		"refresh_tokens",
+		"token_revocations",
+		"oauth_states",
+		"api_tokens",
+		"service_accounts",
+		"signing_keys",
+		"user_identities",
+		"impersonation_audit_logs",
 	}
 	m := s.DB.Migrator()
 	s.DB.Exec(`SET session_replication_role = replica;`)
@@ -204,7 +215,7 @@ func initSharedPGContainer() error {
 			time.Sleep(250 * time.Millisecond)
 		}
 
-		// 4b) Now initialize GORM (your database.Initialize does two-phase migration)
+		// 4b) Now initialize GORM (database.Initialize applies the embedded migrations)
 		gdb, err := database.Initialize(dsn, nil)
 		if err != nil {
 			return err
@@ -4,6 +4,7 @@ import (
 	"developer-portal-backend/internal/config"
 	"developer-portal-backend/internal/database"
 	"developer-portal-backend/internal/database/models"
+	"developer-portal-backend/internal/metaschema"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -148,9 +149,18 @@ type InitialLinkData struct {
 	TagsRaw     interface{} `yaml:"tags"` // supports "a, b, c" or ["a","b"]
 }
 
+// metadataSchemas validates the metadata of the loaded entities, so that typos fail loading
+var metadataSchemas *metaschema.Registry
+
 func main() {
 	log.Println("🚀 Loading initial data from YAML files...")
 
+	var err error
+	metadataSchemas, err = metaschema.NewRegistry()
+	if err != nil {
+		log.Fatalf("Failed to load metadata schemas: %v", err)
+	}
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -171,6 +181,18 @@ func main() {
 	log.Println("✅ Initial data loaded successfully!")
 }
 
+// validateMetadata checks the YAML metadata of an entity against its schema
+func validateMetadata(entity metaschema.Entity, project string, metadata map[string]interface{}) error {
+	if metadata == nil {
+		return nil
+	}
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("invalid metadata: %w", err)
+	}
+	return metadataSchemas.Validate(entity, project, metadataJSON)
+}
+
 // connectWithRetry attempts to initialize the DB with retries to wait for Postgres readiness.
 func connectWithRetry(dsn string, maxAttempts int, delay time.Duration) (*gorm.DB, error) {
 	// Configure database options to suppress verbose logging during data loading
@@ -729,6 +751,7 @@ func createLink(db *gorm.DB, data InitialLinkData) (*models.Link, bool, error) {
 		"tags":        tagsCSV,
 		"title":       data.Title,
 		"description": data.Description,
+		"version":     gorm.Expr("version + 1"),
 	}
 	if err := db.Model(&link).Updates(updates).Error; err != nil {
 		log.Printf("⚠️  Warning: failed to update link %s: %v", data.Title, err)
@@ -809,6 +832,9 @@ func createTeam(db *gorm.DB, teamData TeamData, groupMap map[string]*models.Grou
 	if group == nil {
 		return nil, false, fmt.Errorf("group %s not found for team %s", teamData.GroupName, teamData.Name)
 	}
+	if err := validateMetadata(metaschema.EntityTeam, "", teamData.Metadata); err != nil {
+		return nil, false, err
+	}
 
 	var team models.Team
 	if err := db.Where("name = ? AND group_id = ?", teamData.Name, group.ID).First(&team).Error; err != nil {
@@ -845,7 +871,7 @@ func createTeam(db *gorm.DB, teamData TeamData, groupMap map[string]*models.Grou
 	// If team already exists, update metadata if provided in YAML
 	if teamData.Metadata != nil {
 		metadataJSON, _ := json.Marshal(teamData.Metadata)
-		if err := db.Model(&team).Update("metadata", metadataJSON).Error; err != nil {
+		if err := db.Model(&team).Updates(map[string]interface{}{"metadata": metadataJSON, "version": gorm.Expr("version + 1")}).Error; err != nil {
 			log.Printf("⚠️  Warning: failed to update metadata for team %s: %v", teamData.Name, err)
 		} else {
 			team.Metadata = metadataJSON
@@ -855,6 +881,9 @@ func createTeam(db *gorm.DB, teamData TeamData, groupMap map[string]*models.Grou
 }
 
 func createUser(db *gorm.DB, userData UserData, teamMap map[string]*models.Team) (*models.User, bool, error) {
+	if err := validateMetadata(metaschema.EntityUser, "", userData.Metadata); err != nil {
+		return nil, false, err
+	}
 	team := teamMap[userData.TeamName]
 
 	var teamID *uuid.UUID
@@ -933,6 +962,9 @@ func createComponent(db *gorm.DB, componentData ComponentData, orgMap map[string
 		return nil, false, fmt.Errorf("owner team %s not found for component %s", componentData.Owner, componentData.Name)
 	}
 	ownerID := team.ID
+	if err := validateMetadata(metaschema.EntityComponent, componentData.Project, componentData.Metadata); err != nil {
+		return nil, false, err
+	}
 
 	// Require project to exist (do not auto-create; projects seeded only from projects.yaml)
 	var proj models.Project
@@ -978,6 +1010,7 @@ func createComponent(db *gorm.DB, componentData ComponentData, orgMap map[string
 		metadataJSON, _ := json.Marshal(componentData.Metadata)
 		updates["metadata"] = metadataJSON
 	}
+	updates["version"] = gorm.Expr("version + 1")
 	if err := db.Model(&component).Updates(updates).Error; err != nil {
 		log.Printf("⚠️  Warning: failed to update component %s: %v", componentData.Name, err)
 	} else {
@@ -991,6 +1024,9 @@ func createComponent(db *gorm.DB, componentData ComponentData, orgMap map[string
 }
 
 func createProject(db *gorm.DB, projectData ProjectData, orgMap map[string]*models.Organization) (*models.Project, bool, error) {
+	if err := validateMetadata(metaschema.EntityProject, projectData.Name, projectData.Metadata); err != nil {
+		return nil, false, err
+	}
 	var project models.Project
 	if err := db.Where("name = ?", projectData.Name).First(&project).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -1020,6 +1056,9 @@ func createProject(db *gorm.DB, projectData ProjectData, orgMap map[string]*mode
 }
 
 func createLandscapeFromYAML(db *gorm.DB, item LandscapeData, orgID uuid.UUID) (*models.Landscape, bool, error) {
+	if err := validateMetadata(metaschema.EntityLandscape, item.Project, item.Metadata); err != nil {
+		return nil, false, err
+	}
 	// Merge domain into metadata
 	md := item.Metadata
 	if md == nil {
@@ -1064,6 +1103,7 @@ func createLandscapeFromYAML(db *gorm.DB, item LandscapeData, orgID uuid.UUID) (
 		"environment": env,
 		"project_id":  proj.ID,
 		"metadata":    metadataJSON,
+		"version":     gorm.Expr("version + 1"),
 	}
 	if err := db.Model(&landscape).Updates(updates).Error; err != nil {
 		log.Printf("⚠️  Warning: failed to update landscape %s: %v", item.Name, err)
@@ -1106,9 +1146,10 @@ func createCategory(db *gorm.DB, catData CategoryData) (*models.Category, bool,
 
 	// Update mutable fields on existing category
 	updates := map[string]interface{}{
-		"title": catData.Title,
-		"icon":  catData.Icon,
-		"color": catData.Color,
+		"title":   catData.Title,
+		"icon":    catData.Icon,
+		"color":   catData.Color,
+		"version": gorm.Expr("version + 1"),
 	}
 	if err := db.Model(&cat).Updates(updates).Error; err != nil {
 		log.Printf("⚠️  Warning: failed to update category %s: %v", catData.Name, err)